@@ -0,0 +1,162 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// inlineScriptsPath is where strict mode serves the page template's inline scripts
+const inlineScriptsPath = "/inline-scripts/"
+
+// cspScriptSources are the CDNs presentations load Mermaid and Prism from
+const cspScriptSources = "'self' https://cdn.jsdelivr.net https://unpkg.com"
+
+// cspOtherDirectives cover everything but scripts. Styles stay inline in
+// every mode: themes, layouts and Mermaid's diagrams all set styles inline.
+const cspOtherDirectives = "style-src 'self' 'unsafe-inline' https://unpkg.com https://fonts.googleapis.com; " +
+	"img-src 'self' data: blob: https:; " +
+	"font-src 'self' data: https://fonts.gstatic.com; " +
+	"connect-src 'self' ws: wss:; " +
+	"object-src 'none'; " +
+	"base-uri 'self'; " +
+	"frame-ancestors 'self'"
+
+// contentSecurityPolicy returns the policy header for a CSP mode, or ""
+// when the mode sends none. The default allows inline scripts, which the
+// navigation, live reload and Mermaid blocks are written as.
+func contentSecurityPolicy(mode string) string {
+	switch mode {
+	case entities.CSPOff:
+		return ""
+	case entities.CSPStrict:
+		return "default-src 'self'; script-src " + cspScriptSources + "; " + cspOtherDirectives
+	default:
+		return "default-src 'self'; script-src " + cspScriptSources + " 'unsafe-inline'; " + cspOtherDirectives
+	}
+}
+
+// pageSecurity applies the configured Content-Security-Policy to
+// presentation pages
+type pageSecurity struct {
+	policy string
+
+	// scripts holds the template's inline scripts moved out of pages in
+	// strict mode
+	scripts *inlineScripts
+}
+
+func newPageSecurity(config *entities.Config) *pageSecurity {
+	mode := config.Server.GetCSP()
+	security := &pageSecurity{policy: contentSecurityPolicy(mode)}
+	if mode == entities.CSPStrict {
+		security.scripts = newInlineScripts(templateScripts(config))
+	}
+	return security
+}
+
+// templateScripts returns the bodies of the inline scripts slicli's own page
+// template and live reload add to pages served with config. Slides don't
+// change them, so they're known before any deck is rendered.
+func templateScripts(config *entities.Config) []string {
+	page := generatePresentationHTML("", 0, "", "", config, slideTransitions{}) + liveReloadScript
+
+	var scripts []string
+	for _, match := range inlineScriptPattern.FindAllStringSubmatch(page, -1) {
+		if strings.TrimSpace(match[2]) != "" && isJavaScript(match[1]) {
+			scripts = append(scripts, match[2])
+		}
+	}
+	return scripts
+}
+
+// apply sets the policy header and returns the page to send, with the
+// template's inline scripts swapped for served files in strict mode
+func (s *pageSecurity) apply(w http.ResponseWriter, page string) string {
+	if s.policy != "" {
+		w.Header().Set("Content-Security-Policy", s.policy)
+	}
+	if s.scripts != nil {
+		page = s.scripts.externalize(page)
+	}
+	return page
+}
+
+// inlineScriptPattern matches a script element and its body
+var inlineScriptPattern = regexp.MustCompile(`(?is)<script\b([^>]*)>(.*?)</script>`)
+
+// scriptTypePattern matches the type attribute of a script element
+var scriptTypePattern = regexp.MustCompile(`(?i)\btype\s*=\s*["']?([^"'\s>]+)`)
+
+// inlineScripts serves a fixed set of trusted scripts by the hash of their
+// content
+type inlineScripts struct {
+	scripts map[string]string
+}
+
+func newInlineScripts(trusted []string) *inlineScripts {
+	scripts := make(map[string]string, len(trusted))
+	for _, body := range trusted {
+		scripts[inlineScriptName(body)] = body
+	}
+	return &inlineScripts{scripts: scripts}
+}
+
+// inlineScriptName returns the file a script body is served as
+func inlineScriptName(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:8]) + ".js"
+}
+
+// externalize replaces each trusted inline script in page with one loading
+// the same code from the server. External scripts are loaded in document
+// order like inline ones, so the page runs the same way. Other inline
+// scripts, such as ones written in slide markdown or added by plugins, are
+// left in place for the policy to block.
+func (s *inlineScripts) externalize(page string) string {
+	return inlineScriptPattern.ReplaceAllStringFunc(page, func(element string) string {
+		match := inlineScriptPattern.FindStringSubmatch(element)
+		attrs, body := match[1], match[2]
+		if strings.Contains(strings.ToLower(attrs), "src=") || !isJavaScript(attrs) {
+			return element
+		}
+
+		name := inlineScriptName(body)
+		if trusted, ok := s.scripts[name]; !ok || trusted != body {
+			return element
+		}
+		return `<script` + attrs + ` src="` + inlineScriptsPath + name + `"></script>`
+	})
+}
+
+// isJavaScript reports whether a script element with attrs runs as code
+func isJavaScript(attrs string) bool {
+	match := scriptTypePattern.FindStringSubmatch(attrs)
+	if match == nil {
+		return true
+	}
+	switch strings.ToLower(match[1]) {
+	case "text/javascript", "application/javascript", "module":
+		return true
+	}
+	return false
+}
+
+func (s *inlineScripts) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, inlineScriptsPath)
+	script, ok := s.scripts[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if _, err := w.Write([]byte(script)); err != nil {
+		appLogger.Error("Failed to write script", "error", err)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// scriptSrcDirective returns the script-src directive of a policy
+func scriptSrcDirective(policy string) string {
+	for _, directive := range strings.Split(policy, ";") {
+		if directive = strings.TrimSpace(directive); strings.HasPrefix(directive, "script-src ") {
+			return directive
+		}
+	}
+	return ""
+}
+
+func TestPresentationContentSecurityPolicy(t *testing.T) {
+	serve := func(t *testing.T, mode string) (*httptest.ResponseRecorder, http.Handler) {
+		config := &entities.Config{Server: entities.ServerConfig{CSP: mode}}
+		page := processMarkdownToSlides("# One\n\n---\n\n# Two", "talk.md", config, false)
+		handler := createHTTPServer(config, "", page, nil).Handler
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec, handler
+	}
+
+	t.Run("default allows the CDNs and inline scripts", func(t *testing.T) {
+		rec, _ := serve(t, "")
+		policy := rec.Header().Get("Content-Security-Policy")
+		require.NotEmpty(t, policy)
+
+		scriptSrc := scriptSrcDirective(policy)
+		assert.Contains(t, scriptSrc, "https://cdn.jsdelivr.net")
+		assert.Contains(t, scriptSrc, "https://unpkg.com")
+		assert.Contains(t, scriptSrc, "'unsafe-inline'")
+		assert.Contains(t, rec.Body.String(), "function nextSlide()", "scripts stay inline")
+	})
+
+	t.Run("strict serves inline scripts as files", func(t *testing.T) {
+		rec, handler := serve(t, entities.CSPStrict)
+		policy := rec.Header().Get("Content-Security-Policy")
+		scriptSrc := scriptSrcDirective(policy)
+		require.NotEmpty(t, scriptSrc)
+		assert.NotContains(t, scriptSrc, "'unsafe-inline'")
+
+		page := rec.Body.String()
+		assert.NotContains(t, page, "function nextSlide()")
+		assert.NotContains(t, page, "onclick=")
+
+		src := regexp.MustCompile(`<script src="(/inline-scripts/[0-9a-f]+\.js)"></script>`).FindAllStringSubmatch(page, -1)
+		require.NotEmpty(t, src)
+
+		var scripts strings.Builder
+		for _, match := range src {
+			script := httptest.NewRecorder()
+			handler.ServeHTTP(script, httptest.NewRequest(http.MethodGet, match[1], nil))
+			require.Equal(t, http.StatusOK, script.Code)
+			assert.Equal(t, "text/javascript; charset=utf-8", script.Header().Get("Content-Type"))
+			scripts.WriteString(script.Body.String())
+		}
+		assert.Contains(t, scripts.String(), "function nextSlide()")
+
+		missing := httptest.NewRecorder()
+		handler.ServeHTTP(missing, httptest.NewRequest(http.MethodGet, "/inline-scripts/0000000000000000.js", nil))
+		assert.Equal(t, http.StatusNotFound, missing.Code)
+	})
+
+	t.Run("off sends no policy", func(t *testing.T) {
+		rec, _ := serve(t, entities.CSPOff)
+		assert.Empty(t, rec.Header().Get("Content-Security-Policy"))
+	})
+}
+
+func TestInlineScriptsExternalize(t *testing.T) {
+	scripts := newInlineScripts([]string{"run()", "go()"})
+	page := scripts.externalize(`<script src="/lib.js"></script>` +
+		`<script type="application/json" id="data">{"a": 1}</script>` +
+		`<script type="module">run()</script>` +
+		`<script>steal()</script>` +
+		`<SCRIPT>go()</SCRIPT>`)
+
+	assert.Contains(t, page, `<script src="/lib.js"></script>`)
+	assert.Contains(t, page, `<script type="application/json" id="data">{"a": 1}</script>`)
+	assert.Contains(t, page, `<script>steal()</script>`, "untrusted scripts stay inline for the policy to block")
+	assert.Regexp(t, `<script type="module" src="/inline-scripts/[0-9a-f]{16}\.js"></script>`, page)
+	assert.Regexp(t, `<script src="/inline-scripts/[0-9a-f]{16}\.js"></script>$`, page)
+	assert.Len(t, scripts.scripts, 2)
+}
+
+func TestStrictPolicyBlocksSlideScripts(t *testing.T) {
+	config := &entities.Config{Server: entities.ServerConfig{CSP: entities.CSPStrict}}
+	page := processMarkdownToSlides("# One\n\n<script>alert(document.cookie)</script>", "talk.md", config, false)
+	handler := createHTTPServer(config, "", page, nil).Handler
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.NotContains(t, body, "function nextSlide()", "the template's scripts are served as files")
+	assert.Contains(t, body, "<script>alert(document.cookie)</script>", "the slide's script is left for the policy to block")
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fredcamaral/slicli/internal/adapters/primary/parser"
+	mdparser "github.com/fredcamaral/slicli/internal/adapters/secondary/parser"
+	concurrentplugin "github.com/fredcamaral/slicli/internal/adapters/secondary/plugin"
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/services"
+	pluginapi "github.com/fredcamaral/slicli/pkg/plugin"
+)
+
+const (
+	// dryRunPluginTimeout bounds each plugin execution in a dry run
+	dryRunPluginTimeout = 30 * time.Second
+	// dryRunCacheTTL is how long a block's output is reused by later dry runs
+	dryRunCacheTTL = 24 * time.Hour
+)
+
+var (
+	// fencePattern matches a fenced code block, capturing its language and content
+	fencePattern = regexp.MustCompile("(?ms)^(```|~~~)[ \\t]*([^\\s`{]*)[^\\n]*\\n(.*?)^(?:```|~~~)[ \\t]*$")
+)
+
+// dryRunReport summarizes a presentation checked with serve --dry-run
+type dryRunReport struct {
+	Slides   int
+	Drafts   int
+	Theme    string
+	ThemeDir string         // Where the theme was found, empty if it wasn't
+	Plugins  map[string]int // Code blocks per matched plugin
+	Warnings []string
+	Errors   []string
+}
+
+// runServeDryRun checks everything serve would load, then prints a summary
+// instead of starting the server. It fails if the presentation won't render.
+func runServeDryRun(cmd *cobra.Command, presentationPath string, config *entities.Config) error {
+	if _, err := loadPresentationContent(presentationPath, config); err != nil {
+		return err
+	}
+	markdown, sources, err := mdparser.ReadPresentationSource(presentationPath)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	plugins, warnings := loadConfiguredPlugins(ctx, config.Plugins)
+	report := validatePresentation(ctx, markdown, filepath.Dir(sources[0]), config, plugins)
+	report.Warnings = append(warnings, report.Warnings...)
+
+	printDryRunReport(cmd.OutOrStdout(), presentationPath, report)
+	if len(report.Errors) > 0 {
+		return fmt.Errorf("%s failed validation with %d errors", presentationPath, len(report.Errors))
+	}
+	return nil
+}
+
+// validatePresentation checks a deck's front matter, slides and theme, and
+// runs each code block through the plugin that would render it, as part of
+// a presentation in dir
+func validatePresentation(ctx context.Context, markdown, dir string, config *entities.Config, plugins map[string]pluginapi.Plugin) dryRunReport {
+	report := dryRunReport{Theme: "default", Plugins: make(map[string]int)}
+	if config.Theme.Name != "" {
+		report.Theme = config.Theme.Name
+	}
+
+	if err := checkFrontMatter(markdown); err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	for _, slide := range splitMarkdownSlides(markdown) {
+		if entities.IsDraftContent(slide) && !includeDrafts {
+			report.Drafts++
+			continue
+		}
+		report.Slides++
+	}
+	if report.Slides == 0 {
+		report.Errors = append(report.Errors, "presentation has no slides to show")
+	}
+
+	for _, candidate := range themeSearchPaths(report.Theme) {
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			report.ThemeDir = candidate
+			break
+		}
+	}
+	if report.ThemeDir == "" {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("theme %q not found; the built-in styles will be used", report.Theme))
+	}
+
+	// Blocks rendered by an earlier run, with the configured on-disk cache,
+	// aren't run again
+	executor := concurrentplugin.NewSandboxExecutor(dryRunPluginTimeout, 1)
+	cache := concurrentplugin.NewCacheFromConfig(config.Plugins)
+	for i, slide := range splitMarkdownSlides(markdown) {
+		for _, block := range fencePattern.FindAllStringSubmatch(slide, -1) {
+			language, content := block[2], block[3]
+			name := parser.PluginForLanguage(language)
+			if name == "" {
+				continue
+			}
+			report.Plugins[name]++
+
+			p, loaded := plugins[name]
+			if !loaded {
+				continue
+			}
+			input := pluginapi.PluginInput{
+				Content:  content,
+				Language: language,
+				Options:  make(map[string]interface{}),
+				Metadata: map[string]interface{}{
+					pluginapi.MetadataSlideIndex:      i,
+					pluginapi.MetadataTheme:           report.Theme,
+					pluginapi.MetadataPresentationDir: dir,
+				},
+			}
+			key := services.PluginCacheKey(name, p, input)
+			if _, cached := cache.Get(key); cached {
+				continue
+			}
+			output, err := executor.ExecuteWithTimeout(ctx, p, input, dryRunPluginTimeout)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("slide %d: %s block: %v", i+1, name, err))
+				continue
+			}
+			cache.Set(key, &output, dryRunCacheTTL)
+		}
+	}
+
+	return report
+}
+
+// checkFrontMatter reports front matter that isn't valid YAML or TOML, which
+// the parser would otherwise silently treat as a slide
+func checkFrontMatter(markdown string) error {
+	if err := mdparser.CheckFrontMatter([]byte(markdown)); err != nil {
+		return fmt.Errorf("front matter: %w", err)
+	}
+	return nil
+}
+
+// loadConfiguredPlugins loads the plugins in the configured directory,
+// keeping the ones the whitelist and blacklist allow. Plugins that can't be
+// loaded are reported as warnings, since serve would run without them.
+func loadConfiguredPlugins(ctx context.Context, config entities.PluginsConfig) (map[string]pluginapi.Plugin, []string) {
+	plugins := make(map[string]pluginapi.Plugin)
+	if !config.Enabled || config.Directory == "" {
+		return plugins, nil
+	}
+
+	loader := concurrentplugin.NewGoPluginLoader(Version)
+	found, err := loader.Discover(ctx, []string{config.Directory})
+	if err != nil {
+		return plugins, []string{fmt.Sprintf("discovering plugins: %v", err)}
+	}
+
+	var warnings []string
+	for _, info := range found {
+		if !pluginAllowed(info.Name, config) {
+			continue
+		}
+		p, err := loader.Load(ctx, info.Path)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("plugin %s not loaded: %v", filepath.Base(info.Path), err))
+			continue
+		}
+		if err := p.Init(make(map[string]interface{})); err != nil {
+			warnings = append(warnings, fmt.Sprintf("plugin %s failed to initialize: %v", p.Name(), err))
+			continue
+		}
+		plugins[p.Name()] = p
+	}
+	return plugins, warnings
+}
+
+// pluginAllowed applies the plugin whitelist, when set, then the blacklist
+func pluginAllowed(name string, config entities.PluginsConfig) bool {
+	if len(config.Whitelist) > 0 && !containsString(config.Whitelist, name) {
+		return false
+	}
+	return !containsString(config.Blacklist, name)
+}
+
+// containsString reports whether list holds s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func printDryRunReport(w io.Writer, path string, report dryRunReport) {
+	_, _ = fmt.Fprintf(w, "%s: %d slides", path, report.Slides)
+	if report.Drafts > 0 {
+		_, _ = fmt.Fprintf(w, " (%d drafts hidden)", report.Drafts)
+	}
+	_, _ = fmt.Fprintln(w)
+
+	themeDir := report.ThemeDir
+	if themeDir == "" {
+		themeDir = "not found"
+	}
+	_, _ = fmt.Fprintf(w, "Theme: %s (%s)\n", report.Theme, themeDir)
+
+	if len(report.Plugins) == 0 {
+		_, _ = fmt.Fprintln(w, "Plugins: none matched")
+	} else {
+		names := make([]string, 0, len(report.Plugins))
+		for name := range report.Plugins {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for i, name := range names {
+			names[i] = fmt.Sprintf("%s (%d blocks)", name, report.Plugins[name])
+		}
+		_, _ = fmt.Fprintf(w, "Plugins: %s\n", strings.Join(names, ", "))
+	}
+
+	for _, warning := range report.Warnings {
+		_, _ = fmt.Fprintf(w, "Warning: %s\n", warning)
+	}
+	for _, err := range report.Errors {
+		_, _ = fmt.Fprintf(w, "Error: %s\n", err)
+	}
+	if len(report.Errors) == 0 {
+		_, _ = fmt.Fprintln(w, "OK")
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	pluginapi "github.com/fredcamaral/slicli/pkg/plugin"
+)
+
+// runDryRun runs serve --dry-run on a deck, returning its output
+func runDryRun(t *testing.T, markdown string) (string, error) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	path := filepath.Join(t.TempDir(), "talk.md")
+	require.NoError(t, os.WriteFile(path, []byte(markdown), 0600))
+
+	dryRun = true
+	defer func() { dryRun = false }()
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	err := runServe(cmd, []string{path})
+	return out.String(), err
+}
+
+func TestServeDryRun(t *testing.T) {
+	t.Run("valid deck", func(t *testing.T) {
+		output, err := runDryRun(t, "---\ntitle: Talk\nauthor: Me\n---\n# Intro\n\n```go\nfmt.Println(1)\n```\n\n---\n\n<!-- draft -->\n# Later\n\n---\n\n```mermaid\ngraph TD\nA-->B\n```")
+		require.NoError(t, err)
+
+		assert.Contains(t, output, "talk.md: 2 slides (1 drafts hidden)", "front matter is not a slide")
+		assert.Contains(t, output, "Theme: default (")
+		assert.Contains(t, output, "Plugins: mermaid (1 blocks), syntax-highlight (1 blocks)")
+		assert.Contains(t, output, "OK")
+	})
+
+	t.Run("invalid front matter", func(t *testing.T) {
+		output, err := runDryRun(t, "---\ntitle: [Talk\n---\n# Intro")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed validation with 1 errors")
+		assert.Contains(t, output, "Error: front matter: yaml:")
+		assert.NotContains(t, output, "OK")
+	})
+
+	t.Run("invalid toml front matter", func(t *testing.T) {
+		output, err := runDryRun(t, "+++\ntitle = \"Talk\nauthor = \"Me\"\n+++\n# Intro")
+		require.Error(t, err)
+		assert.Contains(t, output, "Error: front matter: toml:")
+	})
+
+	t.Run("empty deck", func(t *testing.T) {
+		output, err := runDryRun(t, "<!-- draft -->\n# Not yet")
+		require.Error(t, err)
+		assert.Contains(t, output, "Error: presentation has no slides to show")
+	})
+}
+
+// failingPlugin rejects every block it is given
+type failingPlugin struct{}
+
+func (failingPlugin) Name() string                      { return "mermaid" }
+func (failingPlugin) Version() string                   { return "1.0.0" }
+func (failingPlugin) Description() string               { return "rejects every diagram" }
+func (failingPlugin) Init(map[string]interface{}) error { return nil }
+func (failingPlugin) Cleanup() error                    { return nil }
+func (failingPlugin) Execute(context.Context, pluginapi.PluginInput) (pluginapi.PluginOutput, error) {
+	return pluginapi.PluginOutput{}, errors.New("parse error on line 2")
+}
+
+func TestValidatePresentationRunsPlugins(t *testing.T) {
+	config := &entities.Config{Theme: entities.ThemeConfig{Name: "no-such-theme"}}
+	report := validatePresentation(context.Background(), "# Intro\n\n---\n\n```mermaid\ngraph TD\nA-->\n```", t.TempDir(),
+		config, map[string]pluginapi.Plugin{"mermaid": failingPlugin{}})
+
+	assert.Equal(t, 2, report.Slides)
+	assert.Equal(t, 1, report.Plugins["mermaid"])
+	require.Len(t, report.Errors, 1)
+	assert.Contains(t, report.Errors[0], "slide 2: mermaid block:")
+	assert.Contains(t, report.Errors[0], "parse error on line 2")
+	assert.Contains(t, report.Warnings, `theme "no-such-theme" not found; the built-in styles will be used`)
+}
+
+// countingPlugin renders every block, counting how many it was given
+type countingPlugin struct {
+	failingPlugin
+	runs *int
+}
+
+func (p countingPlugin) Execute(context.Context, pluginapi.PluginInput) (pluginapi.PluginOutput, error) {
+	*p.runs++
+	return pluginapi.PluginOutput{HTML: "<svg></svg>"}, nil
+}
+
+func TestValidatePresentationUsesConfiguredCache(t *testing.T) {
+	config := &entities.Config{Plugins: entities.PluginsConfig{CacheDir: t.TempDir(), CacheMaxSizeMB: 1}}
+	markdown := "# Intro\n\n```mermaid\ngraph TD\nA-->B\n```"
+	runs := 0
+	plugins := map[string]pluginapi.Plugin{"mermaid": countingPlugin{runs: &runs}}
+	dir := t.TempDir()
+
+	report := validatePresentation(context.Background(), markdown, dir, config, plugins)
+	assert.Empty(t, report.Errors)
+	report = validatePresentation(context.Background(), markdown, dir, config, plugins)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, 1, report.Plugins["mermaid"])
+	assert.Equal(t, 1, runs, "the second run reads the diagram from the on-disk cache")
+
+	entries, err := os.ReadDir(config.Plugins.CacheDir)
+	require.NoError(t, err)
+	assert.NotEmpty(t, entries)
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+)
+
+// deckHeadingIDs generates heading IDs that are unique across a whole deck
+// rather than within each slide, so in-deck links reach the right heading.
+// It implements goldmark's parser.IDs and is shared by every slide.
+type deckHeadingIDs struct {
+	used       map[string]bool
+	slides     map[string]int      // Heading ID to the 1-based slide it's on
+	collisions map[string][]string // Base ID to the suffixed IDs given out for it
+	slide      int                 // Slide being converted
+	given      []headingID         // IDs given out for the slide being converted
+}
+
+// headingID is an ID given out while converting a slide
+type headingID struct {
+	base     string // The ID asked for
+	id       string
+	kind     ast.NodeKind
+	explicit bool // Set in the markdown rather than generated
+}
+
+// newDeckHeadingIDs creates an ID table with reserved IDs already taken
+func newDeckHeadingIDs(reserved ...string) *deckHeadingIDs {
+	ids := &deckHeadingIDs{
+		used:       make(map[string]bool),
+		slides:     make(map[string]int),
+		collisions: make(map[string][]string),
+	}
+	for _, id := range reserved {
+		ids.used[id] = true
+	}
+	return ids
+}
+
+// startSlide prepares the table for converting the given 1-based slide
+func (d *deckHeadingIDs) startSlide(slide int) {
+	d.slide = slide
+	d.given = nil
+}
+
+// Generate returns goldmark's ID for value, suffixed with -1, -2... when an
+// earlier slide already uses it
+func (d *deckHeadingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	// A fresh table slugs value the way goldmark does, without suffixes
+	base := string(parser.NewContext().IDs().Generate(value, kind))
+
+	heading := headingID{base: base, id: d.next(base, nil), kind: kind}
+	d.assign(heading)
+	return []byte(heading.id)
+}
+
+// Put marks an explicitly set ID as used
+func (d *deckHeadingIDs) Put(value []byte) {
+	d.assign(headingID{base: string(value), id: string(value), explicit: true})
+}
+
+// replay gives the headings of a slide converted earlier the IDs they got
+// then. It reports false, leaving the table as it was, when an earlier
+// slide has since taken one of them and the slide needs converting again.
+func (d *deckHeadingIDs) replay(slide int, headings []headingID) bool {
+	taken := make(map[string]bool, len(headings))
+	for _, heading := range headings {
+		if !heading.explicit && d.next(heading.base, taken) != heading.id {
+			return false
+		}
+		taken[heading.id] = true
+	}
+
+	d.startSlide(slide)
+	for _, heading := range headings {
+		d.assign(heading)
+	}
+	return true
+}
+
+// next returns the first free ID for base, also skipping the taken ones
+func (d *deckHeadingIDs) next(base string, taken map[string]bool) string {
+	id := base
+	for i := 1; d.used[id] || taken[id]; i++ {
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+	return id
+}
+
+// assign records an ID given out for the current slide
+func (d *deckHeadingIDs) assign(heading headingID) {
+	d.used[heading.id] = true
+	d.given = append(d.given, heading)
+	if heading.explicit {
+		return
+	}
+	if heading.id != heading.base {
+		d.collisions[heading.base] = append(d.collisions[heading.base], heading.id)
+	}
+	if heading.kind == ast.KindHeading {
+		d.slides[heading.id] = d.slide
+	}
+}
+
+// firstHeading returns the ID of the first heading on the slide being
+// converted
+func (d *deckHeadingIDs) firstHeading() (string, bool) {
+	for _, heading := range d.given {
+		if heading.explicit || heading.kind == ast.KindHeading {
+			return heading.id, true
+		}
+	}
+	return "", false
+}
+
+// takeHeadingID removes id from the heading carrying it in htmlContent, for
+// the slide to take over
+func takeHeadingID(htmlContent, id string) string {
+	pattern := regexp.MustCompile(`(<h[1-6]\b[^>]*?) id="` + regexp.QuoteMeta(id) + `"`)
+	loc := pattern.FindStringSubmatchIndex(htmlContent)
+	if loc == nil {
+		return htmlContent
+	}
+	return htmlContent[:loc[3]] + htmlContent[loc[1]:]
+}
+
+// collisionSummary lists the renamed IDs, as "overview (overview-1)", or ""
+// if every heading got the ID it asked for
+func (d *deckHeadingIDs) collisionSummary() string {
+	bases := make([]string, 0, len(d.collisions))
+	for base := range d.collisions {
+		bases = append(bases, base)
+	}
+	sort.Strings(bases)
+
+	parts := make([]string, len(bases))
+	for i, base := range bases {
+		parts[i] = fmt.Sprintf("%s (%s)", base, strings.Join(d.collisions[base], ", "))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// idleMonitor notices when nobody has used the server for a while, so
+// preview servers started by editors don't outlive them
+type idleMonitor struct {
+	timeout time.Duration
+
+	// clients counts the browsers connected for live reload, which keep
+	// the server in use while they're open
+	clients func() int
+
+	lastActive atomic.Int64
+}
+
+// newIdleMonitor creates a monitor that considers the server idle after
+// timeout without activity or connected browsers
+func newIdleMonitor(timeout time.Duration, clients func() int) *idleMonitor {
+	if clients == nil {
+		clients = func() int { return 0 }
+	}
+	m := &idleMonitor{timeout: timeout, clients: clients}
+	m.touch()
+	return m
+}
+
+// touch records activity now
+func (m *idleMonitor) touch() {
+	m.lastActive.Store(time.Now().UnixNano())
+}
+
+// handler records each request as activity, both when it arrives and when
+// it's done, so a slow request doesn't count as idle time
+func (m *idleMonitor) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.touch()
+		defer m.touch()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Idle returns a channel closed once the timeout has passed with no
+// activity and no connected browsers. Monitoring stops when ctx is done.
+func (m *idleMonitor) Idle(ctx context.Context) <-chan struct{} {
+	idle := make(chan struct{})
+	go func() {
+		for {
+			wait := m.timeout - time.Since(time.Unix(0, m.lastActive.Load()))
+			if wait <= 0 {
+				if m.clients() == 0 {
+					close(idle)
+					return
+				}
+				// Browsers leaving count as activity, so the timeout
+				// starts over once the last one has gone
+				wait = m.timeout
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+	return idle
+}
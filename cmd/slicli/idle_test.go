@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestServeShutsDownWhenIdle(t *testing.T) {
+	free, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := free.Addr().(*net.TCPAddr).Port
+	require.NoError(t, free.Close())
+
+	config := &entities.Config{Server: entities.ServerConfig{Host: "127.0.0.1", Port: port}}
+	url := config.Server.URL() + "/"
+	// Spare keep-alive connections would hold up the graceful shutdown
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	logger := newLoggerWithLevel(false, entities.LogLevelError)
+
+	server := createHTTPServer(config, "", "<html>slides</html>", nil)
+	monitor := watchIdle(server, nil, 300*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- startAndManageServer(server, config, logger, monitor.Idle(ctx)) }()
+	defer server.Close()
+	require.Eventually(t, func() bool {
+		resp, err := client.Get(url)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return true
+	}, 2*time.Second, 20*time.Millisecond)
+
+	// Steady requests keep the server up well past the timeout
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		resp, err := client.Get(url)
+		require.NoError(t, err)
+		resp.Body.Close()
+		select {
+		case err := <-done:
+			t.Fatalf("server shut down while in use: %v", err)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	// Once they stop it shuts down on its own
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("idle server didn't shut down")
+	}
+	_, err = client.Get(url)
+	assert.Error(t, err)
+}
+
+func TestIdleMonitorWaitsForBrowsers(t *testing.T) {
+	config := &entities.Config{}
+	reloader := newLiveReloader("talk.md", config, "<html>slides</html>")
+	server := createHTTPServer(config, "", "<html>slides</html>", reloader)
+	monitor := watchIdle(server, reloader, 200*time.Millisecond)
+
+	ts := httptest.NewServer(server.Handler)
+	defer ts.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return reloader.clientCount() == 1 }, time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	idle := monitor.Idle(ctx)
+
+	// An open browser keeps the server in use without any requests
+	select {
+	case <-idle:
+		t.Fatal("server went idle with a browser connected")
+	case <-time.After(600 * time.Millisecond):
+	}
+
+	// The timeout runs from when the last browser leaves
+	require.NoError(t, conn.Close())
+	select {
+	case <-idle:
+	case <-time.After(2 * time.Second):
+		t.Fatal("server didn't go idle after the browser left")
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"html"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// leadingHeadingPattern matches the first heading of rendered slide HTML
+	leadingHeadingPattern = regexp.MustCompile(`(?s)^\s*<h[1-6][^>]*>(.*?)</h[1-6]>`)
+
+	tagPattern = regexp.MustCompile(`<[^>]*>`)
+)
+
+// slideLayouts holds a theme's per slide type templates, keyed by type
+// (title, section, content, or any type declared with a layout comment)
+type slideLayouts map[string]*template.Template
+
+// fingerprint identifies the layouts' templates, so slides rendered with
+// other or since edited layouts aren't reused
+func (l slideLayouts) fingerprint() string {
+	types := make([]string, 0, len(l))
+	for slideType := range l {
+		types = append(types, slideType)
+	}
+	sort.Strings(types)
+
+	hash := sha256.New()
+	for _, slideType := range types {
+		hash.Write([]byte(slideType + "\x00"))
+		if tree := l[slideType].Tree; tree != nil && tree.Root != nil {
+			hash.Write([]byte(tree.Root.String()))
+		}
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// slideLayoutData is what layout templates are executed against
+type slideLayoutData struct {
+	Type    string
+	Number  int
+	Heading string        // Text of the slide's leading heading, if any
+	Body    template.HTML // Slide HTML after the leading heading
+	Content template.HTML // Full slide HTML
+}
+
+// loadSlideLayouts parses templates/layouts/<type>.html from the named theme.
+// Themes without layouts, or with templates that fail to parse, get none and
+// slides keep the generic container.
+func loadSlideLayouts(themeName string) slideLayouts {
+	if themeName == "" || strings.ContainsAny(themeName, `/\`) || strings.Contains(themeName, "..") {
+		return nil
+	}
+
+	for _, dir := range themeSearchPaths(filepath.Join(themeName, "templates", "layouts")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		layouts := make(slideLayouts)
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".html" {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			content, err := os.ReadFile(path) // #nosec G304 - path from the theme directory
+			if err != nil {
+				appLogger.Warn("Failed to read layout", "path", path, "error", err)
+				continue
+			}
+
+			slideType := strings.TrimSuffix(entry.Name(), ".html")
+			tmpl, err := template.New(slideType).Parse(string(content))
+			if err != nil {
+				appLogger.Warn("Failed to parse layout", "path", path, "error", err)
+				continue
+			}
+			layouts[slideType] = tmpl
+		}
+		return layouts
+	}
+
+	return nil
+}
+
+// apply renders content with the layout for slideType, reporting false when
+// the theme has no such layout or it fails to execute
+func (l slideLayouts) apply(slideType string, number int, content string) (string, bool) {
+	tmpl, ok := l[slideType]
+	if !ok {
+		return "", false
+	}
+
+	data := slideLayoutData{
+		Type:    slideType,
+		Number:  number,
+		Body:    template.HTML(content), // #nosec G203 - content is the rendered slide HTML
+		Content: template.HTML(content), // #nosec G203 - content is the rendered slide HTML
+	}
+	if match := leadingHeadingPattern.FindStringSubmatchIndex(content); match != nil {
+		data.Heading = strings.TrimSpace(html.UnescapeString(tagPattern.ReplaceAllString(content[match[2]:match[3]], "")))
+		data.Body = template.HTML(strings.TrimSpace(content[match[1]:])) // #nosec G203 - slide HTML
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		appLogger.Warn("Failed to apply layout", "layout", slideType, "slide", number, "error", err)
+		return "", false
+	}
+	return buf.String(), true
+}
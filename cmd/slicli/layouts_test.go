@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// writeLayout creates themes/<theme>/templates/layouts/<slideType>.html under dir
+func writeLayout(t *testing.T, dir, theme, slideType, content string) {
+	t.Helper()
+	layoutDir := filepath.Join(dir, "themes", theme, "templates", "layouts")
+	require.NoError(t, os.MkdirAll(layoutDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(layoutDir, slideType+".html"), []byte(content), 0o644))
+}
+
+func TestProcessMarkdownToSlidesLayouts(t *testing.T) {
+	dir := t.TempDir()
+	writeLayout(t, dir, "hero", "title",
+		`<div class="title-layout"><h1 class="centered">{{.Heading}}</h1><div class="subtitle">{{.Body}}</div></div>`)
+	writeLayout(t, dir, "hero", "quote", `<blockquote class="quote-layout">{{.Content}}</blockquote>`)
+	t.Chdir(dir)
+
+	markdown := "# Ship It & Win\n\nA talk about releases\n\n---\n\n# Details\n\n- one\n- two\n- three\n\n---\n\n<!-- layout: quote -->\nStay hungry"
+	config := &entities.Config{Theme: entities.ThemeConfig{Name: "hero"}}
+
+	html := processMarkdownToSlides(markdown, "talk.md", config, false)
+
+	assert.Contains(t, html, `<div class="slide dev-title" id="slide-1" role="region" aria-roledescription="slide" aria-label="Slide 1" tabindex="-1" data-index="1"><div class="title-layout"><h1 class="centered">Ship It &amp; Win</h1><div class="subtitle"><p>A talk about releases</p></div></div></div>`)
+	assert.Contains(t, html, `<div class="slide dev-quote" id="slide-3" role="region" aria-roledescription="slide" aria-label="Slide 3" tabindex="-1" data-index="3"><blockquote class="quote-layout">`)
+
+	// Slide types without a layout keep the generic container
+	assert.Contains(t, html, `<div class="slide dev-content" id="slide-2" role="region" aria-roledescription="slide" aria-label="Slide 2" tabindex="-1" data-index="2"><h1 id="details">Details</h1>`)
+}
+
+func TestLoadSlideLayouts(t *testing.T) {
+	dir := t.TempDir()
+	writeLayout(t, dir, "broken", "title", `{{.Heading`)
+	writeLayout(t, dir, "broken", "section", `<section>{{.Content}}</section>`)
+	t.Chdir(dir)
+
+	layouts := loadSlideLayouts("broken")
+	assert.NotContains(t, layouts, "title", "unparsable layouts are skipped")
+	assert.Contains(t, layouts, "section")
+
+	assert.Nil(t, loadSlideLayouts("missing"))
+	assert.Nil(t, loadSlideLayouts("../broken"))
+}
+
+func TestProcessMarkdownToDeckLayoutChanges(t *testing.T) {
+	dir := t.TempDir()
+	writeLayout(t, dir, "hero", "title", `<div class="v1">{{.Content}}</div>`)
+	t.Chdir(dir)
+
+	markdown := "# Launch\n\nToday\n\n---\n\n# Details"
+	config := &entities.Config{Theme: entities.ThemeConfig{Name: "hero"}}
+	cache := newSlideCache()
+
+	first := processMarkdownToDeck(markdown, "talk.md", config, false, cache)
+	assert.Contains(t, first.Slides[0], `<div class="v1">`)
+
+	writeLayout(t, dir, "hero", "title", `<div class="v2">{{.Content}}</div>`)
+	second := processMarkdownToDeck(markdown, "talk.md", config, false, cache)
+	assert.Contains(t, second.Slides[0], `<div class="v2">`, "an edited layout re-renders unchanged slides")
+	assert.Equal(t, []int{1, 2}, second.Changed)
+
+	third := processMarkdownToDeck(markdown, "talk.md", config, false, cache)
+	assert.Empty(t, third.Changed, "unchanged layouts keep the cache")
+
+	config.Server.SlideIDs = entities.SlideIDsHeading
+	fourth := processMarkdownToDeck(markdown, "talk.md", config, false, cache)
+	assert.Equal(t, []int{1, 2}, fourth.Changed, "another slide ID mode re-renders every slide")
+	assert.Contains(t, fourth.Slides[1], `id="details"`)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	mdparser "github.com/fredcamaral/slicli/internal/adapters/secondary/parser"
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [file]",
+	Short: "Check a presentation and report slide statistics",
+	Long: `Check a markdown presentation and report how many slides it has,
+including slides marked with <!-- draft --> that are hidden unless
+serve is run with --include-drafts.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLint,
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+}
+
+// lintReport summarizes a presentation's slides
+type lintReport struct {
+	Slides int
+	Drafts []int // 1-based slide numbers
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	markdown, _, err := mdparser.ReadPresentationSource(args[0])
+	if err != nil {
+		return err
+	}
+
+	printLintReport(cmd.OutOrStdout(), args[0], lintMarkdown(markdown))
+	return nil
+}
+
+// lintMarkdown collects slide statistics using the same splitting as serve
+func lintMarkdown(markdown string) lintReport {
+	slides := splitMarkdownSlides(markdown)
+	report := lintReport{Slides: len(slides)}
+	for i, slide := range slides {
+		if entities.IsDraftContent(slide) {
+			report.Drafts = append(report.Drafts, i+1)
+		}
+	}
+	return report
+}
+
+func printLintReport(w io.Writer, path string, report lintReport) {
+	_, _ = fmt.Fprintf(w, "%s: %d slides\n", path, report.Slides)
+	if len(report.Drafts) == 0 {
+		return
+	}
+
+	numbers := make([]string, len(report.Drafts))
+	for i, n := range report.Drafts {
+		numbers[i] = fmt.Sprint(n)
+	}
+	_, _ = fmt.Fprintf(w, "%s: %d draft slides (%s), hidden unless --include-drafts is set\n",
+		path, len(report.Drafts), strings.Join(numbers, ", "))
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintReportsDrafts(t *testing.T) {
+	report := lintMarkdown("# One\n---\n<!-- draft -->\n# Two\n---\n# Three\n---\n# Four\n<!-- draft -->")
+	assert.Equal(t, 4, report.Slides)
+	assert.Equal(t, []int{2, 4}, report.Drafts)
+
+	var out bytes.Buffer
+	printLintReport(&out, "talk.md", report)
+	assert.Contains(t, out.String(), "talk.md: 4 slides")
+	assert.Contains(t, out.String(), "talk.md: 2 draft slides (2, 4)")
+
+	out.Reset()
+	printLintReport(&out, "talk.md", lintMarkdown("# Only"))
+	assert.NotContains(t, out.String(), "draft")
+}
+
+func TestRunLintExpandsIncludes(t *testing.T) {
+	dir := writeThemeFiles(t, map[string]string{
+		"talk.md": "# One\n\n---\n\n@include more.md\n",
+		"more.md": "# Two\n\n---\n\n<!-- draft -->\n# Three\n",
+	})
+
+	var out bytes.Buffer
+	lintCmd.SetOut(&out)
+	defer lintCmd.SetOut(nil)
+	require.NoError(t, runLint(lintCmd, []string{filepath.Join(dir, "talk.md")}))
+	assert.Contains(t, out.String(), "talk.md: 3 slides")
+	assert.Contains(t, out.String(), "1 draft slides (3)")
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// levelSuccess ranks success messages between info and warnings
+const levelSuccess = slog.LevelInfo + 2
+
+// Log output formats accepted by --log-format
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// Logger provides structured logging for the serve command. Messages take
+// slog-style key-value pairs as their fields.
+type Logger struct {
+	verbose bool
+	logger  *slog.Logger
+}
+
+// appLogger logs for code that isn't handed a logger, such as slide rendering
+// and live reload. serve replaces it with the logger it configures.
+var appLogger = newLoggerWithLevel(false, entities.LogLevelInfo)
+
+// newLoggerWithLevel creates a new text logger with specific level
+func newLoggerWithLevel(verbose bool, level entities.LogLevel) *Logger {
+	return newLogger(log.Writer(), false, verbose, level)
+}
+
+// newLogger creates a logger writing human-readable lines to w, or one JSON
+// object per record when jsonFormat is set
+func newLogger(w io.Writer, jsonFormat, verbose bool, level entities.LogLevel) *Logger {
+	var handler slog.Handler
+	if jsonFormat {
+		handler = slog.NewJSONHandler(w, &slog.HandlerOptions{
+			Level:       slogLevel(level),
+			ReplaceAttr: replaceLevelName,
+		})
+	} else {
+		handler = &textHandler{out: log.New(w, "", log.LstdFlags), level: slogLevel(level)}
+	}
+	return &Logger{verbose: verbose, logger: slog.New(handler)}
+}
+
+// slogLevel maps a configured log level to its slog level, defaulting to info
+func slogLevel(level entities.LogLevel) slog.Level {
+	switch level {
+	case entities.LogLevelDebug:
+		return slog.LevelDebug
+	case entities.LogLevelWarn:
+		return slog.LevelWarn
+	case entities.LogLevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// levelName names a level in log output
+func levelName(level slog.Level) string {
+	if level == levelSuccess {
+		return "SUCCESS"
+	}
+	return level.String()
+}
+
+// replaceLevelName writes the success level by name in JSON records
+func replaceLevelName(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.LevelKey && len(groups) == 0 {
+		if level, ok := a.Value.Any().(slog.Level); ok {
+			a.Value = slog.StringValue(levelName(level))
+		}
+	}
+	return a
+}
+
+// Info logs informational messages
+func (l *Logger) Info(msg string, args ...any) {
+	if l.verbose {
+		l.logger.Info(msg, args...)
+	}
+}
+
+// Warn logs warning messages
+func (l *Logger) Warn(msg string, args ...any) {
+	l.logger.Warn(msg, args...)
+}
+
+// Error logs error messages (always shown if error level)
+func (l *Logger) Error(msg string, args ...any) {
+	l.logger.Error(msg, args...)
+}
+
+// Success logs success messages
+func (l *Logger) Success(msg string, args ...any) {
+	if l.verbose {
+		l.logger.Log(context.Background(), levelSuccess, msg, args...)
+	}
+}
+
+// textHandler writes records as "[LEVEL] message key=value" lines through a
+// standard logger, the serve command's human-readable format
+type textHandler struct {
+	out    *log.Logger
+	level  slog.Leveler
+	prefix string // Group names of attributes added from now on
+	attrs  string // Attributes added with WithAttrs, already formatted
+}
+
+// Enabled reports whether records of a level are written
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle writes one record
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[%s] %s%s", levelName(r.Level), r.Message, h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		writeTextAttr(&b, h.prefix, a)
+		return true
+	})
+	return h.out.Output(2, b.String())
+}
+
+// WithAttrs returns a handler that writes attrs with every record
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	b.WriteString(h.attrs)
+	for _, a := range attrs {
+		writeTextAttr(&b, h.prefix, a)
+	}
+	clone := *h
+	clone.attrs = b.String()
+	return &clone
+}
+
+// WithGroup returns a handler that qualifies later attribute keys with name
+func (h *textHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.prefix += name + "."
+	return &clone
+}
+
+// writeTextAttr writes an attribute as " key=value", flattening groups into
+// dotted keys and quoting values that would be ambiguous unquoted
+func writeTextAttr(b *strings.Builder, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, member := range a.Value.Group() {
+			writeTextAttr(b, prefix, member)
+		}
+		return
+	}
+
+	value := a.Value.String()
+	if value == "" || strings.ContainsAny(value, " =\"\t\n") {
+		value = strconv.Quote(value)
+	}
+	fmt.Fprintf(b, " %s%s=%s", prefix, a.Key, value)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestLoggerJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(&buf, true, true, entities.LogLevelInfo)
+
+	logger.Info("Starting server for presentation", "path", "slides.md")
+	logger.Success("Server running", "url", "http://localhost:3000")
+	logger.Warn("Failed to open browser", "error", errors.New("no display"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	records := make([]map[string]any, len(lines))
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal([]byte(line), &records[i]), "line %q", line)
+		for _, key := range []string{"time", "level", "msg"} {
+			assert.Contains(t, records[i], key)
+		}
+	}
+
+	assert.Equal(t, "INFO", records[0]["level"])
+	assert.Equal(t, "Starting server for presentation", records[0]["msg"])
+	assert.Equal(t, "slides.md", records[0]["path"])
+	assert.Equal(t, "SUCCESS", records[1]["level"])
+	assert.Equal(t, "http://localhost:3000", records[1]["url"])
+	assert.Equal(t, "WARN", records[2]["level"])
+	assert.Equal(t, "no display", records[2]["error"])
+}
+
+func TestLoggerTextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(&buf, false, true, entities.LogLevelInfo)
+
+	logger.Warn("Failed to open browser", "error", errors.New("no display"), "browser", "firefox")
+	assert.Contains(t, buf.String(), `[WARN] Failed to open browser error="no display" browser=firefox`)
+}
+
+func TestLoggerLevels(t *testing.T) {
+	t.Run("filters below the configured level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newLogger(&buf, true, true, entities.LogLevelError)
+
+		logger.Info("hidden")
+		logger.Success("hidden")
+		logger.Warn("hidden")
+		logger.Error("shown")
+		assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+		assert.Contains(t, buf.String(), `"level":"ERROR"`)
+	})
+
+	t.Run("info needs verbose", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := newLogger(&buf, false, false, entities.LogLevelDebug)
+
+		logger.Info("hidden")
+		logger.Success("hidden")
+		assert.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// slideMarkdown converts every slide's markdown to HTML
+var slideMarkdown = goldmark.New(
+	goldmark.WithExtensions(
+		extension.GFM,      // Tables, strikethrough, autolinks and task lists
+		mermaidExtension{}, // ```mermaid fences become diagram containers
+	),
+	goldmark.WithParserOptions(
+		parser.WithAutoHeadingID(), // Auto-generate heading IDs
+	),
+	goldmark.WithRendererOptions(
+		html.WithHardWraps(), // Convert line breaks to <br>
+		html.WithXHTML(),     // XHTML compliant output
+		html.WithUnsafe(),    // Allow raw HTML in slides
+	),
+)
+
+// basicMarkdownToHTML converts a slide's markdown to HTML
+func basicMarkdownToHTML(markdown string) string {
+	return slideMarkdownToHTML(markdown, nil)
+}
+
+// slideMarkdownToHTML converts a slide's markdown to HTML, taking heading IDs
+// from ids when it is set
+func slideMarkdownToHTML(markdown string, ids parser.IDs) string {
+	var opts []parser.ParseOption
+	if ids != nil {
+		opts = append(opts, parser.WithContext(parser.NewContext(parser.WithIDs(ids))))
+	}
+
+	var buf bytes.Buffer
+	if err := slideMarkdown.Convert([]byte(markdown), &buf, opts...); err != nil {
+		appLogger.Error("Failed to convert markdown", "error", err)
+		return markdown // Return original markdown on error
+	}
+	return buf.String()
+}
+
+// mermaidExtension renders mermaid code fences as diagram containers
+type mermaidExtension struct{}
+
+// Extend installs the mermaid renderer ahead of goldmark's code block renderer
+func (mermaidExtension) Extend(m goldmark.Markdown) {
+	m.Renderer().AddOptions(
+		renderer.WithNodeRenderers(
+			util.Prioritized(newMermaidRenderer(), 100),
+		),
+	)
+}
+
+// mermaidRenderer writes mermaid fences as <div class="mermaid"> and leaves
+// every other fence to goldmark's default rendering
+type mermaidRenderer struct {
+	fallback renderer.NodeRendererFunc
+}
+
+// newMermaidRenderer creates a mermaid renderer falling back to goldmark's
+// HTML renderer for other languages; its options don't affect code blocks
+func newMermaidRenderer() *mermaidRenderer {
+	capture := &funcCapture{kind: ast.KindFencedCodeBlock}
+	html.NewRenderer().RegisterFuncs(capture)
+	return &mermaidRenderer{fallback: capture.fn}
+}
+
+// RegisterFuncs registers the renderer for fenced code blocks
+func (r *mermaidRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+// renderFencedCodeBlock renders a mermaid fence, keeping the diagram source in
+// data-original so the client can re-render it when the theme changes
+func (r *mermaidRenderer) renderFencedCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.FencedCodeBlock)
+	if string(n.Language(source)) != "mermaid" {
+		return r.fallback(w, source, node, entering)
+	}
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	var content strings.Builder
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		content.Write(line.Value(source))
+	}
+	diagram := strings.TrimSpace(content.String())
+
+	original := strings.ReplaceAll(diagram, `"`, `&quot;`)
+	original = strings.ReplaceAll(original, `'`, `&#39;`)
+	original = strings.ReplaceAll(original, "\n", "&#10;")
+
+	if _, err := fmt.Fprintf(w, `<div class="mermaid" data-original="%s">%s</div>`+"\n", original, diagram); err != nil {
+		return ast.WalkStop, err
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+// funcCapture picks a single node kind's render function out of a renderer
+type funcCapture struct {
+	kind ast.NodeKind
+	fn   renderer.NodeRendererFunc
+}
+
+// Register keeps the function registered for the captured kind
+func (c *funcCapture) Register(kind ast.NodeKind, fn renderer.NodeRendererFunc) {
+	if kind == c.kind {
+		c.fn = fn
+	}
+}
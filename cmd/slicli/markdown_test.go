@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBasicMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "intraword emphasis",
+			markdown: "a*b*c",
+			contains: []string{"<p>a<em>b</em>c</p>"},
+		},
+		{
+			name:     "nested emphasis",
+			markdown: "**bold _nested_**",
+			contains: []string{"<p><strong>bold <em>nested</em></strong></p>"},
+		},
+		{
+			name:     "underscores inside words",
+			markdown: "snake_case_name",
+			contains: []string{"<p>snake_case_name</p>"},
+			excludes: []string{"<em>"},
+		},
+		{
+			name:     "pipe in a table code span",
+			markdown: "| Op | Meaning |\n|----|---------|\n| `a\\|b` | either |",
+			contains: []string{"<th>Op</th>", "<td><code>a|b</code></td>", "<td>either</td>"},
+		},
+		{
+			name:     "strikethrough",
+			markdown: "~~gone~~",
+			contains: []string{"<del>gone</del>"},
+		},
+		{
+			name:     "code fence",
+			markdown: "```go\nif a < b {}\n```",
+			contains: []string{`<pre><code class="language-go">if a &lt; b {}`},
+		},
+		{
+			name:     "mermaid fence",
+			markdown: "```mermaid\ngraph TD\n  A[\"Start\"] --> B\n```",
+			contains: []string{`<div class="mermaid" data-original="graph TD&#10;  A[&quot;Start&quot;] --> B">graph TD` + "\n" + `  A["Start"] --> B</div>`},
+			excludes: []string{"language-mermaid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html := basicMarkdownToHTML(tt.markdown)
+			for _, want := range tt.contains {
+				assert.Contains(t, html, want)
+			}
+			for _, unwanted := range tt.excludes {
+				assert.False(t, strings.Contains(html, unwanted), "unexpected %q in %s", unwanted, html)
+			}
+		})
+	}
+}
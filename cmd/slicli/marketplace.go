@@ -115,13 +115,14 @@ func getMarketplaceClientWithConfig(appConfig *entities.Config) (*plugin.Marketp
 			if globalConfig.Plugins.MarketplaceURL != "" {
 				appConfig.Plugins.MarketplaceURL = globalConfig.Plugins.MarketplaceURL
 			}
+			appConfig.Plugins.MarketplaceAPIKey = globalConfig.Plugins.MarketplaceAPIKey
 		}
 	}
 
 	// Create marketplace configuration using config system
 	marketplaceConfig := plugin.MarketplaceConfig{
 		BaseURL: appConfig.Plugins.GetMarketplaceURL(),
-		APIKey:  os.Getenv("SLICLI_API_KEY"),
+		APIKey:  appConfig.Plugins.GetMarketplaceAPIKey(),
 		UserID:  getUserID(),
 	}
 
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/plugin"
+)
+
+// pluginManifestName is the manifest file a plugin directory carries
+const pluginManifestName = "plugin.toml"
+
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "Work with local plugins",
+}
+
+var pluginsValidateCmd = &cobra.Command{
+	Use:   "validate <dir>",
+	Short: "Check plugin.toml manifests for missing and invalid fields",
+	Long: `Check the plugin.toml of a plugin directory, or of each plugin directory
+inside <dir>, before loading or publishing it. slicli falls back to the
+plugin's own name and version when a manifest fails to load, so mistakes
+here otherwise go unnoticed.
+
+Each manifest needs a [metadata] section with a name, a semantic version and
+a type of processor, exporter, theme or analyzer. Default [config] values must
+be well formed, and unknown keys are reported as likely typos. Each problem
+is listed by key and the command exits with an error.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPluginsValidate,
+}
+
+func init() {
+	pluginsCmd.AddCommand(pluginsValidateCmd)
+	rootCmd.AddCommand(pluginsCmd)
+}
+
+func runPluginsValidate(cmd *cobra.Command, args []string) error {
+	manifests, err := findPluginManifests(args[0])
+	if err != nil {
+		return err
+	}
+
+	invalid := 0
+	for _, path := range manifests {
+		problems, err := plugin.ValidateManifestFile(path)
+		if err != nil {
+			return err
+		}
+		printManifestProblems(cmd.OutOrStdout(), path, problems)
+		if len(problems) > 0 {
+			invalid++
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d plugin manifests failed validation", invalid, len(manifests))
+	}
+	return nil
+}
+
+// findPluginManifests returns the manifest of the plugin in dir, or those
+// of the plugin directories directly inside it
+func findPluginManifests(dir string) ([]string, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading plugins: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	own := filepath.Join(dir, pluginManifestName)
+	if _, err := os.Stat(own); err == nil {
+		return []string{own}, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading plugins: %w", err)
+	}
+	var manifests []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name(), pluginManifestName)
+		if _, err := os.Stat(path); err == nil {
+			manifests = append(manifests, path)
+		}
+	}
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no %s found in %s or its subdirectories", pluginManifestName, dir)
+	}
+	return manifests, nil
+}
+
+func printManifestProblems(w io.Writer, path string, problems []plugin.ManifestProblem) {
+	if len(problems) == 0 {
+		_, _ = fmt.Fprintf(w, "%s: manifest is valid\n", path)
+		return
+	}
+	for _, problem := range problems {
+		_, _ = fmt.Fprintf(w, "%s: Error: %s\n", path, problem)
+	}
+}
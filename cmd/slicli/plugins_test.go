@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validPluginManifest = `[metadata]
+name = "diagrams"
+version = "1.2.0"
+type = "processor"
+
+[config]
+enabled = true
+timeout = "5s"
+`
+
+func TestPluginsValidate(t *testing.T) {
+	validate := func(t *testing.T, dir string) (string, error) {
+		cmd := pluginsValidateCmd
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		err := runPluginsValidate(cmd, []string{dir})
+		return out.String(), err
+	}
+
+	t.Run("valid manifest", func(t *testing.T) {
+		dir := writeThemeFiles(t, map[string]string{"plugin.toml": validPluginManifest})
+
+		out, err := validate(t, dir)
+		require.NoError(t, err)
+		assert.Contains(t, out, "plugin.toml: manifest is valid")
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		dir := writeThemeFiles(t, map[string]string{
+			"good/plugin.toml": validPluginManifest,
+			"bad/plugin.toml":  "[metadata]\nname = \"bad\"\nversion = \"1.0.0\"\ntype = \"widget\"\n",
+			"notes/README.md":  "not a plugin",
+		})
+
+		out, err := validate(t, dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 of 2 plugin manifests failed validation")
+		assert.Contains(t, out, filepath.Join(dir, "good", "plugin.toml")+": manifest is valid")
+		assert.Contains(t, out, filepath.Join(dir, "bad", "plugin.toml")+`: Error: metadata.type: "widget" must be one of processor, exporter, theme, analyzer`)
+	})
+
+	t.Run("no manifests", func(t *testing.T) {
+		_, err := validate(t, t.TempDir())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no plugin.toml found")
+	})
+}
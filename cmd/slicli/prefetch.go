@@ -0,0 +1,20 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+)
+
+// prefetchAttr renders a slide's assets as the data-prefetch attribute the
+// navigation script reads, or nothing when the slide has none
+func prefetchAttr(assets []string) string {
+	if len(assets) == 0 {
+		return ""
+	}
+	encoded, err := json.Marshal(assets)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf(` data-prefetch="%s"`, html.EscapeString(string(encoded)))
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestProcessMarkdownToSlidesPrefetch(t *testing.T) {
+	markdown := "# Intro\n\n---\n\n# Diagram\n\n![big](img/big-diagram.png \"Big\")\n\n---\n\n# End"
+	prefetchPattern := regexp.MustCompile(`<div class="slide [^"]*" id="slide-(\d)"( data-prefetch="[^"]*")?`)
+
+	html := processMarkdownToSlides(markdown, "talk.md", &entities.Config{}, false)
+
+	slides := prefetchPattern.FindAllStringSubmatch(html, -1)
+	require.Len(t, slides, 3)
+	assert.Empty(t, slides[0][2], "slides without assets get no prefetch list")
+	assert.Equal(t, ` data-prefetch="[&#34;img/big-diagram.png&#34;]"`, slides[1][2], "the slide after the first lists its image")
+	assert.Empty(t, slides[2][2])
+
+	assert.Contains(t, html, "const prefetchDepth = 1;", "one slide ahead by default")
+
+	t.Run("configured depth", func(t *testing.T) {
+		html := processMarkdownToSlides(markdown, "talk.md", &entities.Config{Server: entities.ServerConfig{PrefetchDepth: 3}}, false)
+		assert.Contains(t, html, "const prefetchDepth = 3;")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		html := processMarkdownToSlides(markdown, "talk.md", &entities.Config{Server: entities.ServerConfig{PrefetchDepth: -1}}, false)
+		assert.Contains(t, html, "const prefetchDepth = 0;")
+	})
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"html"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/export"
+)
+
+// cssURLPattern matches the target of a CSS url(), such as a slide's
+// background image
+var cssURLPattern = regexp.MustCompile(`url\(\s*['"]?([^'")\s]+)['"]?\s*\)`)
+
+// createPresentationFilesHandler serves the presentation at / and, everywhere
+// else, the files in dir that the page content returns references, so
+// relative image, media and background paths in slides resolve. Nothing else
+// in dir is served, and symlinks must stay inside it.
+func createPresentationFilesHandler(dir string, content func() string, presentation http.HandlerFunc) http.HandlerFunc {
+	var (
+		mu         sync.Mutex
+		page       string
+		referenced map[string]bool
+	)
+	// The page's references are only scanned again once it changes
+	isReferenced := func(urlPath string) bool {
+		current := content()
+		mu.Lock()
+		defer mu.Unlock()
+		if referenced == nil || current != page {
+			page, referenced = current, referencedFiles(current)
+		}
+		return referenced[urlPath]
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			presentation(w, r)
+			return
+		}
+
+		cleanPath := path.Clean(r.URL.Path)
+		if !isReferenced(cleanPath) {
+			http.NotFound(w, r)
+			return
+		}
+
+		filePath, ok := presentationFile(dir, cleanPath)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, filePath)
+	}
+}
+
+// referencedFiles lists the local paths a rendered page loads from its
+// images, media, embeds and CSS url()s, as cleaned server paths
+func referencedFiles(page string) map[string]bool {
+	targets := export.HTMLAssets(page)
+	for _, match := range cssURLPattern.FindAllStringSubmatch(html.UnescapeString(page), -1) {
+		targets = append(targets, match[1])
+	}
+
+	files := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		parsed, err := url.Parse(target)
+		if err != nil || parsed.Scheme != "" || parsed.Host != "" || parsed.Path == "" {
+			continue
+		}
+		files[path.Clean("/"+parsed.Path)] = true
+	}
+	return files
+}
+
+// presentationFile returns the regular file urlPath names under dir, after
+// following symlinks, refusing hidden files and anything outside dir
+func presentationFile(dir, urlPath string) (string, bool) {
+	for _, segment := range strings.Split(strings.TrimPrefix(urlPath, "/"), "/") {
+		if segment == ".." || strings.HasPrefix(segment, ".") {
+			return "", false
+		}
+	}
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return "", false
+	}
+	filePath, err := filepath.EvalSymlinks(filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(urlPath, "/"))))
+	if err != nil {
+		return "", false
+	}
+	if rel, err := filepath.Rel(root, filePath); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	fileInfo, err := os.Stat(filePath)
+	if err != nil || !fileInfo.Mode().IsRegular() {
+		return "", false
+	}
+	return filePath, true
+}
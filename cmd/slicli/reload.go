@@ -0,0 +1,380 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+	"github.com/fredcamaral/slicli/internal/domain/services"
+)
+
+// liveReloadScript reconnects the page to /ws and reloads it, keeping the
+// current slide, whenever the server reports that the presentation changed.
+// When only some slides changed, it swaps those in place instead, and when
+// only stylesheets changed it refetches them.
+const liveReloadScript = `    <script>
+        (function() {
+            const stored = sessionStorage.getItem('slicli-slide');
+            if (stored) {
+                sessionStorage.removeItem('slicli-slide');
+                showSlide(Math.min(parseInt(stored, 10) || 1, totalSlides));
+            }
+            function connect() {
+                const scheme = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
+                const socket = new WebSocket(scheme + '//' + window.location.host + '/ws');
+                socket.onmessage = (message) => {
+                    let event = {};
+                    try {
+                        event = JSON.parse(message.data);
+                    } catch (e) {
+                        return;
+                    }
+                    if (event.type === 'css_reload') {
+                        // Refetch this server's stylesheets without losing the page
+                        document.querySelectorAll('link[rel="stylesheet"]').forEach((link) => {
+                            const href = new URL(link.href);
+                            if (href.origin !== window.location.origin) return;
+                            href.searchParams.set('reload', Date.now());
+                            link.href = href.toString();
+                        });
+                    }
+                    if (event.type === 'reload') {
+                        sessionStorage.setItem('slicli-slide', currentSlide);
+                        window.location.reload();
+                    }
+                    if (event.type === 'slides_update') {
+                        (event.data.slides || []).forEach((update) => {
+                            const slide = slides[update.index - 1];
+                            const template = document.createElement('template');
+                            template.innerHTML = update.html;
+                            const fresh = template.content.firstElementChild;
+                            if (!slide || !fresh) return;
+                            // Keep the element, which navigation holds on to
+                            Array.from(slide.attributes).forEach((attr) => {
+                                if (attr.name !== 'style') slide.removeAttribute(attr.name);
+                            });
+                            Array.from(fresh.attributes).forEach((attr) => slide.setAttribute(attr.name, attr.value));
+                            slide.innerHTML = fresh.innerHTML;
+                            if (typeof Prism !== 'undefined') Prism.highlightAllUnder(slide);
+                        });
+                        showSlide(currentSlide);
+                    }
+                };
+                socket.onclose = () => setTimeout(connect, 1000);
+            }
+            connect();
+        })();
+    </script>
+`
+
+// liveReloader keeps the served presentation in step with its source file
+// and tells connected browsers to reload when it changes
+type liveReloader struct {
+	path     string
+	config   *entities.Config
+	upgrader websocket.Upgrader
+
+	mu      sync.RWMutex
+	content string
+
+	// Only used from Run
+	slides *slideCache
+	deck   renderedDeck
+
+	// watch adds a file to those followed for changes, once live reload
+	// has started
+	watch func(path string) error
+
+	clientsMu sync.Mutex
+	clients   map[*websocket.Conn]struct{}
+
+	// activity, when set, is called as browsers connect, send messages
+	// and disconnect
+	activity func()
+}
+
+// newLiveReloader creates a reloader serving htmlContent until the first change
+func newLiveReloader(path string, config *entities.Config, htmlContent string) *liveReloader {
+	r := &liveReloader{
+		path:    path,
+		config:  config,
+		slides:  newSlideCache(),
+		clients: make(map[*websocket.Conn]struct{}),
+	}
+	r.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     sameOrigin,
+	}
+	r.setContent(htmlContent)
+	return r
+}
+
+// sameOrigin accepts WebSocket connections from pages served by this server
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(originURL.Host, r.Host)
+}
+
+// Content returns the current presentation page
+func (r *liveReloader) Content() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.content
+}
+
+// setContent stores a freshly rendered page with the reload client attached
+func (r *liveReloader) setContent(htmlContent string) {
+	if i := strings.LastIndex(htmlContent, "</body>"); i >= 0 {
+		htmlContent = htmlContent[:i] + liveReloadScript + htmlContent[i:]
+	} else {
+		htmlContent += liveReloadScript
+	}
+
+	r.mu.Lock()
+	r.content = htmlContent
+	r.mu.Unlock()
+}
+
+// handleWebSocket registers a browser for reload notifications
+func (r *liveReloader) handleWebSocket(w http.ResponseWriter, req *http.Request) {
+	conn, err := r.upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		appLogger.Warn("WebSocket upgrade failed", "error", err)
+		return
+	}
+
+	r.clientsMu.Lock()
+	r.clients[conn] = struct{}{}
+	r.clientsMu.Unlock()
+	r.touch()
+
+	// Browsers only listen; reading just detects when they go away
+	go func() {
+		defer r.removeClient(conn)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+			r.touch()
+		}
+	}()
+}
+
+func (r *liveReloader) removeClient(conn *websocket.Conn) {
+	r.clientsMu.Lock()
+	delete(r.clients, conn)
+	r.clientsMu.Unlock()
+	_ = conn.Close()
+	r.touch()
+}
+
+// clientCount returns how many browsers are connected for live reload
+func (r *liveReloader) clientCount() int {
+	r.clientsMu.Lock()
+	defer r.clientsMu.Unlock()
+	return len(r.clients)
+}
+
+func (r *liveReloader) touch() {
+	if r.activity != nil {
+		r.activity()
+	}
+}
+
+// broadcast sends an event to every connected browser, dropping the ones
+// that can't be written to
+func (r *liveReloader) broadcast(event ports.UpdateEvent) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		appLogger.Error("Failed to encode event", "type", event.Type, "error", err)
+		return
+	}
+
+	r.clientsMu.Lock()
+	defer r.clientsMu.Unlock()
+	for conn := range r.clients {
+		_ = conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+		if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+			delete(r.clients, conn)
+			_ = conn.Close()
+		}
+	}
+}
+
+// Run re-renders the presentation on each change and broadcasts a reload
+// until ctx is cancelled or the events channel closes
+func (r *liveReloader) Run(ctx context.Context, events <-chan ports.FileChangeEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			batch, ok := r.debounce(ctx, events, event)
+			if !ok {
+				return
+			}
+
+			// Restyling the open pages is enough when only stylesheets changed
+			if stylesheetsOnly(batch) {
+				r.broadcast(cssReloadEvent(batch))
+				continue
+			}
+
+			previous := r.deck
+			if err := r.reloadWithRetry(ctx); err != nil {
+				if ctx.Err() == nil {
+					appLogger.Warn("Failed to reload presentation", "path", r.path, "error", err)
+				}
+				continue
+			}
+			r.watchSources()
+
+			// Other assets, such as images, only show up on a full reload
+			file := batch[len(batch)-1].Path
+			if !r.touchesSource(batch) {
+				r.broadcast(ports.UpdateEvent{
+					Type:      ports.EventTypeReload,
+					Timestamp: time.Now(),
+					Data:      map[string]interface{}{"file": file},
+				})
+				continue
+			}
+			r.broadcast(r.updateEvent(file, previous))
+		}
+	}
+}
+
+// stylesheetsOnly reports whether every change in batch is to a stylesheet
+func stylesheetsOnly(batch []ports.FileChangeEvent) bool {
+	for _, event := range batch {
+		if !strings.EqualFold(filepath.Ext(event.Path), ".css") {
+			return false
+		}
+	}
+	return true
+}
+
+// cssReloadEvent tells browsers to refetch their stylesheets
+func cssReloadEvent(batch []ports.FileChangeEvent) ports.UpdateEvent {
+	files := make([]string, 0, len(batch))
+	for _, event := range batch {
+		if !slices.Contains(files, event.Path) {
+			files = append(files, event.Path)
+		}
+	}
+	return ports.UpdateEvent{
+		Type:      ports.EventTypeCSSReload,
+		Timestamp: time.Now(),
+		Data:      map[string]interface{}{"files": files},
+	}
+}
+
+// touchesSource reports whether batch changed the presentation or a file it
+// includes, rather than only other files in the watched directories
+func (r *liveReloader) touchesSource(batch []ports.FileChangeEvent) bool {
+	if len(r.deck.Sources) == 0 {
+		return true
+	}
+	for _, event := range batch {
+		path := event.Path
+		if real, err := filepath.EvalSymlinks(path); err == nil {
+			path = real
+		}
+		if slices.Contains(r.deck.Sources, path) || slices.Contains(r.deck.Sources, event.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchSources follows every file the presentation includes, including
+// ones it has started including since the last reload
+func (r *liveReloader) watchSources() {
+	if r.watch == nil {
+		return
+	}
+	for _, path := range r.deck.Sources {
+		if err := r.watch(path); err != nil {
+			appLogger.Warn("Failed to watch file", "path", path, "error", err)
+		}
+	}
+}
+
+// slideUpdate is a re-rendered slide sent to browsers to swap in
+type slideUpdate struct {
+	Index int    `json:"index"` // 1-based
+	HTML  string `json:"html"`
+}
+
+// updateEvent describes the last reload: the slides that changed when the
+// rest of the page is as it was and some slides were reused, a full reload
+// otherwise
+func (r *liveReloader) updateEvent(file string, previous renderedDeck) ports.UpdateEvent {
+	deck := r.deck
+	if len(deck.Slides) != len(previous.Slides) || len(deck.Changed) == len(deck.Slides) ||
+		!reflect.DeepEqual(deck.Transitions, previous.Transitions) {
+		return ports.UpdateEvent{
+			Type:      ports.EventTypeReload,
+			Timestamp: time.Now(),
+			Data:      map[string]interface{}{"file": file},
+		}
+	}
+
+	updates := make([]slideUpdate, 0, len(deck.Changed))
+	for _, slide := range deck.Changed {
+		updates = append(updates, slideUpdate{Index: slide, HTML: deck.Slides[slide-1]})
+	}
+	return ports.UpdateEvent{
+		Type:      ports.EventTypeSlidesUpdate,
+		Timestamp: time.Now(),
+		Data:      map[string]interface{}{"file": file, "slides": updates},
+	}
+}
+
+// debounce waits until no further events arrive for the configured debounce
+// period so a burst of saves triggers a single reload, returning the burst
+func (r *liveReloader) debounce(ctx context.Context, events <-chan ports.FileChangeEvent, event ports.FileChangeEvent) ([]ports.FileChangeEvent, bool) {
+	return services.DebounceEvents(ctx, events, event, time.Duration(r.config.Watcher.DebounceMs)*time.Millisecond)
+}
+
+// reloadWithRetry re-renders the presentation, trying again after a delay
+// when the file can't be read since an editor may be midway through saving it
+func (r *liveReloader) reloadWithRetry(ctx context.Context) error {
+	err := services.RetryReload(ctx, r.config.Watcher, func() error {
+		deck, err := loadPresentationDeck(r.path, r.config, r.slides)
+		if err != nil {
+			return err
+		}
+		r.deck = deck
+		r.setContent(deck.HTML)
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("after %d retries: %w", r.config.Watcher.MaxRetries, err)
+	}
+	return err
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+)
+
+func TestLiveReloadBroadcastsOnWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "talk.md")
+	require.NoError(t, os.WriteFile(path, []byte("# First draft"), 0600))
+
+	config := &entities.Config{Watcher: entities.WatcherConfig{DebounceMs: 50, MaxRetries: 3, RetryDelayMs: 20}}
+	htmlContent, err := loadPresentationContent(path, config)
+	require.NoError(t, err)
+
+	reloader := newLiveReloader(path, config, htmlContent)
+	stop, err := startLiveReload(reloader, path)
+	require.NoError(t, err)
+	defer stop()
+
+	server := httptest.NewServer(createHTTPServer(config, "", htmlContent, reloader).Handler)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Wait for the server to register the client before changing the file
+	require.Eventually(t, func() bool {
+		reloader.clientsMu.Lock()
+		defer reloader.clientsMu.Unlock()
+		return len(reloader.clients) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte("# Second draft"), 0600))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	var event ports.UpdateEvent
+	require.NoError(t, json.Unmarshal(message, &event))
+	assert.Equal(t, ports.EventTypeReload, event.Type)
+
+	resp, err := http.Get(server.URL + "/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Second draft")
+	assert.NotContains(t, string(body), "First draft")
+	assert.Contains(t, string(body), "new WebSocket(")
+}
+
+func TestLiveReloadWatchesIncludedFiles(t *testing.T) {
+	dir := writeThemeFiles(t, map[string]string{
+		"talk.md":           "# Talk\n\n---\n\n@include sections/intro.md\n",
+		"sections/intro.md": "# Intro draft\n",
+	})
+	path := filepath.Join(dir, "talk.md")
+
+	config := &entities.Config{Watcher: entities.WatcherConfig{DebounceMs: 50, MaxRetries: 3, RetryDelayMs: 20}}
+	deck, err := loadPresentationDeck(path, config, nil)
+	require.NoError(t, err)
+
+	reloader := newLiveReloader(path, config, deck.HTML)
+	reloader.deck = deck
+	stop, err := startLiveReload(reloader, path)
+	require.NoError(t, err)
+	defer stop()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sections", "intro.md"), []byte("# Intro final\n"), 0600))
+	assert.Eventually(t, func() bool {
+		return strings.Contains(reloader.Content(), "Intro final")
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestLiveReloadWatchDirs(t *testing.T) {
+	dir := writeThemeFiles(t, map[string]string{
+		"talk.md":                "# Talk\n",
+		"themes/mine/style.css":  "h1 { color: navy; }\n",
+		"assets/images/logo.svg": "<svg/>",
+	})
+	path := filepath.Join(dir, "talk.md")
+
+	config := &entities.Config{Watcher: entities.WatcherConfig{
+		DebounceMs: 50, MaxRetries: 3, RetryDelayMs: 20,
+		Dirs: []string{filepath.Join(dir, "themes"), filepath.Join(dir, "assets")},
+	}}
+	deck, err := loadPresentationDeck(path, config, nil)
+	require.NoError(t, err)
+
+	reloader := newLiveReloader(path, config, deck.HTML)
+	reloader.deck = deck
+	stop, err := startLiveReload(reloader, path)
+	require.NoError(t, err)
+	defer stop()
+
+	server := httptest.NewServer(createHTTPServer(config, "", deck.HTML, reloader).Handler)
+	defer server.Close()
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool {
+		reloader.clientsMu.Lock()
+		defer reloader.clientsMu.Unlock()
+		return len(reloader.clients) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	nextEvent := func(t *testing.T) ports.UpdateEvent {
+		t.Helper()
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, message, err := conn.ReadMessage()
+		require.NoError(t, err)
+		var event ports.UpdateEvent
+		require.NoError(t, json.Unmarshal(message, &event))
+		return event
+	}
+
+	stylesheet := filepath.Join(dir, "themes", "mine", "style.css")
+	require.NoError(t, os.WriteFile(stylesheet, []byte("h1 { color: teal; }\n"), 0600))
+	event := nextEvent(t)
+	assert.Equal(t, ports.EventTypeCSSReload, event.Type, "stylesheet changes only restyle the page")
+	assert.Contains(t, event.Data.(map[string]interface{})["files"], stylesheet)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assets", "images", "logo.svg"), []byte("<svg></svg>"), 0600))
+	assert.Equal(t, ports.EventTypeReload, nextEvent(t).Type, "other assets reload the page")
+
+	require.NoError(t, os.WriteFile(path, []byte("# Talk, edited\n"), 0600))
+	assert.Equal(t, ports.EventTypeReload, nextEvent(t).Type)
+	assert.Contains(t, reloader.Content(), "Talk, edited")
+}
+
+func TestLiveReloadSendsChangedSlides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "talk.md")
+	require.NoError(t, os.WriteFile(path, []byte("# One\n\n---\n\n# Two\n\n---\n\n# Three"), 0600))
+
+	config := &entities.Config{Watcher: entities.WatcherConfig{MaxRetries: 3, RetryDelayMs: 20}}
+	reloader := newLiveReloader(path, config, "<html><body></body></html>")
+
+	// The first render has nothing to compare against
+	previous := reloader.deck
+	require.NoError(t, reloader.reloadWithRetry(t.Context()))
+	assert.Equal(t, ports.EventTypeReload, reloader.updateEvent(path, previous).Type)
+
+	require.NoError(t, os.WriteFile(path, []byte("# One\n\n---\n\n# Two, edited\n\n---\n\n# Three"), 0600))
+	previous = reloader.deck
+	require.NoError(t, reloader.reloadWithRetry(t.Context()))
+
+	event := reloader.updateEvent(path, previous)
+	assert.Equal(t, ports.EventTypeSlidesUpdate, event.Type)
+	updates := event.Data.(map[string]interface{})["slides"].([]slideUpdate)
+	require.Len(t, updates, 1)
+	assert.Equal(t, 2, updates[0].Index)
+	assert.Contains(t, updates[0].HTML, "Two, edited")
+	assert.Contains(t, reloader.Content(), "Two, edited")
+
+	t.Run("reloads the page when slides are added", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("# One\n\n---\n\n# Two, edited\n\n---\n\n# Three\n\n---\n\n# Four"), 0600))
+		previous := reloader.deck
+		require.NoError(t, reloader.reloadWithRetry(t.Context()))
+		assert.Equal(t, ports.EventTypeReload, reloader.updateEvent(path, previous).Type)
+	})
+}
+
+func TestLiveReloadRetriesUnreadableFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "talk.md")
+	config := &entities.Config{Watcher: entities.WatcherConfig{MaxRetries: 10, RetryDelayMs: 20}}
+	reloader := newLiveReloader(path, config, "<html><body></body></html>")
+
+	t.Run("file appears within the retries", func(t *testing.T) {
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			_ = os.WriteFile(path, []byte("# Saved"), 0600)
+		}()
+
+		require.NoError(t, reloader.reloadWithRetry(t.Context()))
+		assert.Contains(t, reloader.Content(), "Saved")
+	})
+
+	t.Run("gives up and keeps the last page", func(t *testing.T) {
+		require.NoError(t, os.Remove(path))
+		config.Watcher.MaxRetries = 1
+
+		err := reloader.reloadWithRetry(t.Context())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "after 1 retries")
+		assert.Contains(t, reloader.Content(), "Saved")
+	})
+}
@@ -3,28 +3,34 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"html"
 	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/yuin/goldmark"
-	"github.com/yuin/goldmark/extension"
-	"github.com/yuin/goldmark/parser"
-	"github.com/yuin/goldmark/renderer/html"
 
 	"github.com/fredcamaral/slicli/internal/adapters/secondary/browser"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/certs"
 	"github.com/fredcamaral/slicli/internal/adapters/secondary/config"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/export"
+	mdparser "github.com/fredcamaral/slicli/internal/adapters/secondary/parser"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/theme"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/watcher"
 	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/services"
+	"github.com/fredcamaral/slicli/web"
 )
 
 var (
@@ -34,73 +40,20 @@ var (
 	noBrowser  bool
 	themeName  string
 	watchFiles bool
+	watchDirs  []string
+	useTLS     bool
+	tlsCert    string
+	tlsKey     string
+	readOnly   bool
+	offline    bool
+	portRetry  int
+
+	includeDrafts bool
+	dryRun        bool
+	idleTimeout   int
+	logFormat     string
 )
 
-// Logger provides structured logging for the serve command
-type Logger struct {
-	verbose bool
-	level   entities.LogLevel
-}
-
-// shouldLog checks if the message should be logged based on level
-func (l *Logger) shouldLog(msgLevel entities.LogLevel) bool {
-	levelMap := map[entities.LogLevel]int{
-		entities.LogLevelDebug: 0,
-		entities.LogLevelInfo:  1,
-		entities.LogLevelWarn:  2,
-		entities.LogLevelError: 3,
-	}
-
-	currentLevel := levelMap[l.level]
-	messageLevel := levelMap[msgLevel]
-
-	return messageLevel >= currentLevel
-}
-
-// Info logs informational messages
-func (l *Logger) Info(msg string, args ...interface{}) {
-	if l.shouldLog(entities.LogLevelInfo) && l.verbose {
-		log.Printf("[INFO] "+msg, args...)
-	}
-}
-
-// Warn logs warning messages
-func (l *Logger) Warn(msg string, args ...interface{}) {
-	if l.shouldLog(entities.LogLevelWarn) {
-		log.Printf("[WARN] "+msg, args...)
-	}
-}
-
-// Error logs error messages (always shown if error level)
-func (l *Logger) Error(msg string, args ...interface{}) {
-	if l.shouldLog(entities.LogLevelError) {
-		log.Printf("[ERROR] "+msg, args...)
-	}
-}
-
-// Success logs success messages
-func (l *Logger) Success(msg string, args ...interface{}) {
-	if l.shouldLog(entities.LogLevelInfo) && l.verbose {
-		log.Printf("[SUCCESS] "+msg, args...)
-	}
-}
-
-// newLogger creates a new logger instance (kept for potential future use)
-// func newLogger(verbose bool) *Logger {
-// 	return &Logger{
-// 		verbose: verbose,
-// 		level:   entities.LogLevelInfo, // Default level
-// 	}
-// }
-
-// newLoggerWithLevel creates a new logger instance with specific level
-func newLoggerWithLevel(verbose bool, level entities.LogLevel) *Logger {
-	return &Logger{
-		verbose: verbose,
-		level:   level,
-	}
-}
-
 // serveCmd represents the serve command
 var serveCmd = &cobra.Command{
 	Use:   "serve [file]",
@@ -111,7 +64,8 @@ update when the markdown file changes.
 
 Example:
   slicli serve presentation.md
-  slicli serve slides.md --port 8080 --no-browser`,
+  slicli serve slides.md --port 8080 --no-browser
+  slicli serve slides.md --dry-run`,
 	Args: cobra.ExactArgs(1),
 	RunE: runServe,
 }
@@ -122,9 +76,20 @@ func init() {
 	// Add command flags - defaults will be overridden by config loading
 	serveCmd.Flags().IntVarP(&port, "port", "p", 0, "Port to serve on (overrides config)")
 	serveCmd.Flags().StringVar(&host, "host", "", "Host to bind to (overrides config)")
+	serveCmd.Flags().IntVar(&portRetry, "port-retry", 0, "Try up to N following ports when the port is in use (overrides config)")
 	serveCmd.Flags().BoolVar(&noBrowser, "no-browser", false, "Don't open browser automatically (overrides config)")
 	serveCmd.Flags().StringVarP(&themeName, "theme", "t", "", "Theme to use (overrides config)")
-	serveCmd.Flags().BoolVarP(&watchFiles, "watch", "w", false, "Watch files for changes (overrides config)")
+	serveCmd.Flags().BoolVarP(&watchFiles, "watch", "w", false, "Reload open browsers when the presentation file changes")
+	serveCmd.Flags().StringArrayVar(&watchDirs, "watch-dir", nil, "Also reload on changes in this directory, such as a theme or assets (repeatable, implies --watch)")
+	serveCmd.Flags().BoolVar(&useTLS, "tls", false, "Serve over HTTPS, generating a self-signed certificate unless --tls-cert is set")
+	serveCmd.Flags().StringVar(&tlsCert, "tls-cert", "", "PEM certificate file for HTTPS (implies --tls)")
+	serveCmd.Flags().StringVar(&tlsKey, "tls-key", "", "PEM private key file for HTTPS")
+	serveCmd.Flags().BoolVar(&readOnly, "read-only", false, "Serve the presentation only and reject state-changing requests (overrides config)")
+	serveCmd.Flags().BoolVar(&offline, "offline", false, "Serve Mermaid and Prism from the binary instead of their CDNs (overrides config)")
+	serveCmd.Flags().BoolVar(&includeDrafts, "include-drafts", false, "Include slides marked with <!-- draft -->")
+	serveCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the config and presentation, run its plugins and print a summary without starting the server")
+	serveCmd.Flags().IntVar(&idleTimeout, "idle-timeout", 0, "Exit after N seconds without requests or open browsers (overrides config)")
+	serveCmd.Flags().StringVar(&logFormat, "log-format", "", "Log output format: text or json (overrides config)")
 }
 
 // validateServeArgs validates serve command arguments without starting server
@@ -147,6 +112,14 @@ func validateServeConfig(config *entities.Config) error {
 		return fmt.Errorf("invalid host: %s", config.Server.Host)
 	}
 
+	// Offline mode needs the libraries vendored into the binary
+	if config.Server.Offline {
+		if missing := web.MissingVendorFiles(); len(missing) > 0 {
+			return fmt.Errorf("offline mode is unavailable: this build lacks %s; run make vendor-assets and rebuild",
+				strings.Join(missing, ", "))
+		}
+	}
+
 	return nil
 }
 
@@ -159,6 +132,10 @@ func runServe(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if dryRun {
+		return runServeDryRun(cmd, presentationPath, finalConfig)
+	}
+
 	// Get verbose flag and create logger with logging configuration
 	verbose, _ := cmd.Flags().GetBool("verbose")
 
@@ -167,24 +144,82 @@ func runServe(cmd *cobra.Command, args []string) error {
 		verbose = finalConfig.Logging.Verbose
 	}
 
-	logger := newLoggerWithLevel(verbose, finalConfig.Logging.GetLevel())
+	logger := newLogger(os.Stderr, finalConfig.Logging.JSONFormat, verbose, finalConfig.Logging.GetLevel())
+	appLogger = logger
+	if finalConfig.Logging.JSONFormat {
+		// Route the standard logger and plugin service logs through the same
+		// handler so every line stays JSON
+		slog.SetDefault(logger.logger)
+	}
 	printStartupInfo(logger, presentationPath, finalConfig)
 
-	// Load presentation content
-	htmlContent, err := loadPresentationContent(presentationPath, finalConfig)
+	// Load presentation content, keeping its slides so live reload only
+	// converts the ones that change
+	slides := newSlideCache()
+	deck, err := loadPresentationDeck(presentationPath, finalConfig, slides)
 	if err != nil {
 		return err
 	}
+	htmlContent := deck.HTML
+
+	// Re-render and push reloads to open browsers when the file changes
+	var reloader *liveReloader
+	if watchFiles || len(watchDirs) > 0 {
+		reloader = newLiveReloader(presentationPath, finalConfig, htmlContent)
+		reloader.slides = slides
+		reloader.deck = deck
+		stop, err := startLiveReload(reloader, presentationPath)
+		if err != nil {
+			return err
+		}
+		defer stop()
+		logger.Info("Watching presentation for changes", "path", presentationPath, "dirs", finalConfig.Watcher.Dirs)
+	} else if finalConfig.Server.GetIdleTimeout() > 0 {
+		// Open tabs still connect to /ws so they count as activity; without
+		// a watcher nothing is ever reloaded
+		reloader = newLiveReloader(presentationPath, finalConfig, htmlContent)
+	}
 
 	// Create HTTP server
-	server := createHTTPServer(finalConfig, htmlContent)
+	server := createHTTPServer(finalConfig, filepath.Dir(presentationPath), htmlContent, reloader)
+	if err := configureTLS(server, finalConfig, logger); err != nil {
+		return err
+	}
+
+	// Stop once nothing has used the server for the idle timeout
+	var idle <-chan struct{}
+	if timeout := finalConfig.Server.GetIdleTimeout(); timeout > 0 {
+		monitor := watchIdle(server, reloader, timeout)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		idle = monitor.Idle(ctx)
+	}
 
 	// Start server and handle lifecycle
-	return startAndManageServer(server, finalConfig, logger)
+	return startAndManageServer(server, finalConfig, logger, idle)
+}
+
+// watchIdle tracks the server's requests and live reload browsers as
+// activity for an idle monitor
+func watchIdle(server *http.Server, reloader *liveReloader, timeout time.Duration) *idleMonitor {
+	var clients func() int
+	if reloader != nil {
+		clients = reloader.clientCount
+	}
+	monitor := newIdleMonitor(timeout, clients)
+	if reloader != nil {
+		reloader.activity = monitor.touch
+	}
+	server.Handler = monitor.handler(server.Handler)
+	return monitor
 }
 
 // loadAndValidateConfig loads configuration and validates it
 func loadAndValidateConfig(cmd *cobra.Command, presentationPath string) (*entities.Config, error) {
+	if cmd.Flags().Changed("log-format") && logFormat != logFormatText && logFormat != logFormatJSON {
+		return nil, fmt.Errorf("invalid log format: %s (must be %s or %s)", logFormat, logFormatText, logFormatJSON)
+	}
+
 	// Load configuration with proper precedence: CLI flags > local config > global config > defaults
 	finalConfig, err := loadAndMergeConfig(cmd, presentationPath)
 	if err != nil {
@@ -206,84 +241,191 @@ func loadAndValidateConfig(cmd *cobra.Command, presentationPath string) (*entiti
 
 // printStartupInfo prints startup information if verbose mode is enabled
 func printStartupInfo(logger *Logger, presentationPath string, config *entities.Config) {
-	logger.Info("Starting server for presentation: %s", presentationPath)
-	logger.Info("Attempting to start server at: http://%s:%d", config.Server.Host, config.Server.Port)
-	if config.Browser.AutoOpen {
+	logger.Info("Starting server for presentation", "path", presentationPath)
+	logger.Info("Attempting to start server", "url", config.Server.URL())
+	if _, isUnix := config.Server.UnixSocket(); config.Browser.AutoOpen && !isUnix {
 		logger.Info("Browser will open automatically if server starts successfully")
 	}
 	if config.Theme.Name != "" {
-		logger.Info("Using theme: %s", config.Theme.Name)
+		logger.Info("Using theme", "theme", config.Theme.Name)
 	}
 }
 
 // loadPresentationContent validates and loads the presentation file content
 func loadPresentationContent(presentationPath string, config *entities.Config) (string, error) {
+	deck, err := loadPresentationDeck(presentationPath, config, nil)
+	return deck.HTML, err
+}
+
+// loadPresentationDeck validates and renders the presentation file, reusing
+// the slides in cache that haven't changed
+func loadPresentationDeck(presentationPath string, config *entities.Config, cache *slideCache) (renderedDeck, error) {
 	// Validate and read the presentation file
 	fileInfo, err := os.Stat(presentationPath)
 	if err != nil {
-		return "", fmt.Errorf("accessing presentation file: %w", err)
+		return renderedDeck{}, fmt.Errorf("accessing presentation file: %w", err)
 	}
 	if !fileInfo.Mode().IsRegular() {
-		return "", fmt.Errorf("presentation path is not a regular file: %s", presentationPath)
+		return renderedDeck{}, fmt.Errorf("presentation path is not a regular file: %s", presentationPath)
 	}
 
-	// Read the validated presentation file
-	markdownContent, err := os.ReadFile(presentationPath) // #nosec G304 - path validated above
+	// Read the validated presentation file along with the files it includes
+	markdownContent, sources, err := mdparser.ReadPresentationSource(presentationPath)
 	if err != nil {
-		return "", fmt.Errorf("reading presentation file: %w", err)
+		return renderedDeck{}, err
 	}
 
 	// Process markdown into HTML slides
-	return processMarkdownToSlides(string(markdownContent), presentationPath, config), nil
+	deck := processMarkdownToDeck(markdownContent, presentationPath, config, includeDrafts, cache)
+	deck.Sources = sources
+	return deck, nil
+}
+
+// startLiveReload watches the presentation file, the files it includes and
+// the configured watch directories and feeds their changes to the reloader,
+// returning a function that stops both
+func startLiveReload(reloader *liveReloader, presentationPath string) (func(), error) {
+	fileWatcher, err := watcher.NewFSNotifyWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := fileWatcher.Watch(ctx, presentationPath)
+	if err != nil {
+		cancel()
+		_ = fileWatcher.Stop()
+		return nil, fmt.Errorf("watching presentation file: %w", err)
+	}
+	reloader.watch = func(path string) error {
+		_, err := fileWatcher.Watch(ctx, path)
+		return err
+	}
+	reloader.watchSources()
+	for _, dir := range reloader.config.Watcher.Dirs {
+		if _, err := fileWatcher.WatchDir(ctx, dir); err != nil {
+			cancel()
+			_ = fileWatcher.Stop()
+			return nil, err
+		}
+	}
+
+	go reloader.Run(ctx, events)
+
+	return func() {
+		cancel()
+		_ = fileWatcher.Stop()
+	}, nil
 }
 
-// createHTTPServer creates and configures the HTTP server with handlers
-func createHTTPServer(config *entities.Config, htmlContent string) *http.Server {
+// createHTTPServer creates and configures the HTTP server with handlers.
+// With a reloader the presentation is served from it and /ws is added for
+// live reload; otherwise htmlContent is served as is. Files in presentationDir
+// that the slides reference, such as their images, are served when it is set.
+func createHTTPServer(config *entities.Config, presentationDir, htmlContent string, reloader *liveReloader) *http.Server {
 	mux := http.NewServeMux()
 
 	// Serve the presentation
-	mux.HandleFunc("/", createPresentationHandler(htmlContent))
+	security := newPageSecurity(config)
+	content := func() string { return htmlContent }
+	if reloader != nil {
+		content = reloader.Content
+		mux.HandleFunc("/ws", reloader.handleWebSocket)
+	}
+	presentation := createPresentationHandler(content, security)
+	if presentationDir != "" {
+		presentation = createPresentationFilesHandler(presentationDir, content, presentation)
+	}
+	mux.HandleFunc("/", presentation)
+	if security.scripts != nil {
+		mux.Handle(inlineScriptsPath, security.scripts)
+	}
 
-	// Serve static assets
+	// Serve static assets, with the vendored libraries from the binary
+	mux.Handle("/assets/vendor/", http.StripPrefix("/assets/vendor/", http.FileServer(http.FS(web.VendorFS()))))
 	mux.HandleFunc("/assets/", createAssetsHandler())
-	
+
 	// Serve theme assets
-	mux.HandleFunc("/themes/", createThemeAssetsHandler())
+	mux.HandleFunc("/themes/", createThemeAssetsHandler(config.Theme))
+
+	var handler http.Handler = mux
+	if config.Server.ReadOnly {
+		handler = readOnlyHandler(mux)
+	}
 
 	// Create HTTP server using configuration values
 	return &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", config.Server.Host, config.Server.Port),
-		Handler:      mux,
+		Handler:      handler,
 		ReadTimeout:  config.Server.GetReadTimeout(),
 		WriteTimeout: config.Server.GetWriteTimeout(),
 		IdleTimeout:  60 * time.Second,
 	}
 }
 
-// createPresentationHandler creates the handler for serving presentation content
-func createPresentationHandler(htmlContent string) http.HandlerFunc {
+// readOnlyHandler rejects anything other than reading the presentation and its assets
+func readOnlyHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "Server is read-only", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// configureTLS attaches a TLS configuration to the server when HTTPS is enabled
+func configureTLS(server *http.Server, config *entities.Config, logger *Logger) error {
+	if !config.Server.TLS.Enabled {
+		return nil
+	}
+
+	bundle, err := certs.FromServerConfig(config.Server)
+	if err != nil {
+		return fmt.Errorf("setting up TLS: %w", err)
+	}
+	server.TLSConfig = bundle.Config
+
+	if bundle.SelfSigned {
+		// Always shown so users can verify the certificate before trusting it
+		log.Printf("[INFO] Using self-signed certificate from local CA in %s", config.Server.TLS.GetCADir())
+		log.Printf("[INFO] CA SHA-256 fingerprint: %s", bundle.Fingerprint)
+	} else {
+		logger.Info("Using TLS certificate", "file", config.Server.TLS.CertFile, "sha256", bundle.Fingerprint)
+	}
+
+	return nil
+}
+
+// createPresentationHandler creates the handler serving the presentation
+// content returns, under the server's Content-Security-Policy
+func createPresentationHandler(content func() string, security *pageSecurity) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		page := security.apply(w, presentationPage(content(), r))
 		w.Header().Set("Content-Type", "text/html")
 		w.WriteHeader(http.StatusOK)
-		if _, err := w.Write([]byte(htmlContent)); err != nil {
+		if _, err := w.Write([]byte(page)); err != nil {
 			// Use a simple log format for the serve command's basic server
-			log.Printf("[ERROR] Failed to write response: %v", err)
+			appLogger.Error("Failed to write response", "error", err)
 		}
 	}
 }
 
 // createAssetsHandler creates the handler for serving static assets
 func createAssetsHandler() http.HandlerFunc {
+	defaultCSS, defaultJS := []byte(getDefaultCSS()), []byte(getDefaultJS())
+	defaultCSSETag, defaultJSETag := contentETag(defaultCSS), contentETag(defaultJS)
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Security: Clean and validate the path
 		cleanPath := filepath.Clean(r.URL.Path)
-		
+
 		// Prevent path traversal attacks
 		if strings.Contains(cleanPath, "..") {
 			http.NotFound(w, r)
 			return
 		}
-		
+
 		// Check if it's a known asset path from web/assets
 		switch cleanPath {
 		case "/assets/style.css":
@@ -292,11 +434,7 @@ func createAssetsHandler() http.HandlerFunc {
 			if _, err := os.Stat(cssPath); err == nil {
 				http.ServeFile(w, r, cssPath)
 			} else {
-				w.Header().Set("Content-Type", "text/css")
-				css := getDefaultCSS()
-				if _, err := w.Write([]byte(css)); err != nil {
-					log.Printf("[ERROR] Failed to write CSS: %v", err)
-				}
+				serveGenerated(w, r, "text/css", defaultCSS, defaultCSSETag)
 			}
 		case "/assets/script.js":
 			// For compatibility, serve default JS if file doesn't exist
@@ -304,81 +442,111 @@ func createAssetsHandler() http.HandlerFunc {
 			if _, err := os.Stat(jsPath); err == nil {
 				http.ServeFile(w, r, jsPath)
 			} else {
-				w.Header().Set("Content-Type", "text/javascript")
-				js := getDefaultJS()
-				if _, err := w.Write([]byte(js)); err != nil {
-					log.Printf("[ERROR] Failed to write JS: %v", err)
-				}
+				serveGenerated(w, r, "text/javascript", defaultJS, defaultJSETag)
 			}
 		default:
 			// Try to serve from web/assets directory
 			assetPath := filepath.Join("web", strings.TrimPrefix(cleanPath, "/"))
-			
+
 			// Check if file exists and is not a directory
 			fileInfo, err := os.Stat(assetPath)
 			if err != nil || fileInfo.IsDir() {
 				http.NotFound(w, r)
 				return
 			}
-			
+
 			// Set appropriate content type
 			setContentType(w, cleanPath)
-			
+
 			// Serve the file
 			http.ServeFile(w, r, assetPath)
 		}
 	}
 }
 
-// createThemeAssetsHandler creates the handler for serving theme assets
-func createThemeAssetsHandler() http.HandlerFunc {
+// createThemeAssetsHandler creates the handler for serving theme assets.
+// Stylesheets of themes declaring colors in a theme.json are served with
+// the colors filled in, configured variables taking precedence.
+func createThemeAssetsHandler(themeConfig entities.ThemeConfig) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Security: Clean and validate the path
 		cleanPath := filepath.Clean(r.URL.Path)
-		
+
 		// Prevent path traversal attacks
 		if strings.Contains(cleanPath, "..") {
 			http.NotFound(w, r)
 			return
 		}
-		
+
 		// Remove /themes/ prefix to get the actual theme path
 		themePath := strings.TrimPrefix(cleanPath, "/themes/")
-		
-		// Try multiple possible theme locations
-		possiblePaths := []string{
-			filepath.Join("themes", themePath),                    // Current directory
-			filepath.Join("..", "..", "themes", themePath),       // Two levels up (when in subdirectory)
-			filepath.Join(os.Getenv("HOME"), ".slicli", "themes", themePath), // User home
-		}
-		
+
 		var fullPath string
 		var fileInfo os.FileInfo
 		var err error
-		
+
 		// Find the first existing path
-		for _, path := range possiblePaths {
+		for _, path := range themeSearchPaths(themePath) {
 			fileInfo, err = os.Stat(path)
 			if err == nil && !fileInfo.IsDir() {
 				fullPath = path
 				break
 			}
 		}
-		
+
 		// If no valid path found, return 404
 		if fullPath == "" {
 			http.NotFound(w, r)
 			return
 		}
-		
+
+		if filepath.Ext(fullPath) == ".css" {
+			themeDir := filepath.Join(strings.TrimSuffix(fullPath, themePath), strings.SplitN(filepath.ToSlash(themePath), "/", 2)[0])
+			css, ok, err := themeStylesheet(themeDir, fullPath, themeConfig.Variables, themeConfig.MaxVariableDepth)
+			if err != nil {
+				appLogger.Error("Failed to process theme stylesheet", "path", themePath, "error", err)
+				http.Error(w, "Failed to process stylesheet", http.StatusInternalServerError)
+				return
+			}
+			if ok {
+				serveGenerated(w, r, "text/css; charset=utf-8", css, contentETag(css))
+				return
+			}
+		}
+
 		// Set appropriate content type
 		setContentType(w, cleanPath)
-		
+
 		// Serve the file
 		http.ServeFile(w, r, fullPath)
 	}
 }
 
+// contentETag returns a strong ETag addressing content by its SHA-256
+func contentETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return `"` + hex.EncodeToString(sum[:])[:16] + `"`
+}
+
+// serveGenerated writes content built in memory with its ETag. Browsers
+// revalidate it on every load and get 304 Not Modified while it's unchanged.
+func serveGenerated(w http.ResponseWriter, r *http.Request, contentType string, content []byte, etag string) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+}
+
+// themeSearchPaths returns the locations a path inside a theme may be found at
+func themeSearchPaths(themePath string) []string {
+	return []string{
+		filepath.Join("themes", themePath),                               // Current directory
+		filepath.Join("..", "..", "themes", themePath),                   // Two levels up (when in subdirectory)
+		filepath.Join(os.Getenv("HOME"), ".slicli", "themes", themePath), // User home
+		filepath.Join(theme.UserThemesDirectory(), themePath),            // Installed from the marketplace
+	}
+}
+
 // setContentType sets the appropriate content type based on file extension
 func setContentType(w http.ResponseWriter, path string) {
 	ext := strings.ToLower(filepath.Ext(path))
@@ -410,14 +578,15 @@ func setContentType(w http.ResponseWriter, path string) {
 	}
 }
 
-// startAndManageServer starts the server and manages its lifecycle
-func startAndManageServer(server *http.Server, config *entities.Config, logger *Logger) error {
+// startAndManageServer starts the server and manages its lifecycle. It also
+// shuts down when idle is closed.
+func startAndManageServer(server *http.Server, config *entities.Config, logger *Logger, idle <-chan struct{}) error {
 	// Create channels for server status
 	serverStarted := make(chan struct{})
 	serverErr := make(chan error, 1)
 
 	// Start server in a goroutine
-	go startServerAsync(server, config, serverStarted, serverErr)
+	go startServerAsync(server, config, logger, serverStarted, serverErr)
 
 	// Wait for server to start and handle post-startup tasks
 	if err := waitForServerStart(serverStarted, serverErr, config, logger); err != nil {
@@ -425,42 +594,100 @@ func startAndManageServer(server *http.Server, config *entities.Config, logger *
 	}
 
 	// Handle shutdown gracefully
-	return handleServerShutdown(server, serverErr, config, logger)
+	return handleServerShutdown(server, serverErr, config, logger, idle)
 }
 
-// startServerAsync starts the server asynchronously with port validation
-func startServerAsync(server *http.Server, config *entities.Config, serverStarted chan struct{}, serverErr chan error) {
-	addr := fmt.Sprintf("%s:%d", config.Server.Host, config.Server.Port)
-
-	// First, check if the port is already in use by attempting to listen on it
-	listener, err := net.Listen("tcp", addr)
-	if err != nil {
-		serverErr <- fmt.Errorf("port %d is already in use or cannot be bound: %w", config.Server.Port, err)
-		return
-	}
-
-	// Close the test listener to avoid race condition
-	if err := listener.Close(); err != nil {
-		serverErr <- fmt.Errorf("failed to release port after testing: %w", err)
+// startServerAsync starts the server asynchronously with port validation.
+// Servers on a Unix socket skip the port check.
+func startServerAsync(server *http.Server, config *entities.Config, logger *Logger, serverStarted chan struct{}, serverErr chan error) {
+	if socket, isUnix := config.Server.UnixSocket(); isUnix {
+		listener, err := listenUnix(socket)
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		close(serverStarted)
+		if err := serveListener(server, listener); err != nil && err != http.ErrServerClosed {
+			serverErr <- fmt.Errorf("server error: %w", err)
+		}
 		return
 	}
 
-	// Create a new listener for the actual server to avoid race condition
-	actualListener, err := net.Listen("tcp", addr)
+	listener, err := listenTCP(config, logger)
 	if err != nil {
-		serverErr <- fmt.Errorf("failed to bind to port %d: %w", config.Server.Port, err)
+		serverErr <- err
 		return
 	}
+	server.Addr = listener.Addr().String()
 
 	// Signal that we can proceed (port is bound and ready)
 	close(serverStarted)
 
 	// Now serve using the bound listener to eliminate race condition
-	if err := server.Serve(actualListener); err != nil && err != http.ErrServerClosed {
+	if err := serveListener(server, listener); err != nil && err != http.ErrServerClosed {
 		serverErr <- fmt.Errorf("server error: %w", err)
 	}
 }
 
+// listenTCP binds the configured port, moving on to each following port up
+// to PortRetries times while the port can't be bound. The config's port is
+// updated to the one bound so the server URL points at it.
+func listenTCP(config *entities.Config, logger *Logger) (net.Listener, error) {
+	first := config.Server.Port
+	last := min(first+config.Server.PortRetries, 65535)
+
+	for port := first; ; port++ {
+		listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", config.Server.Host, port))
+		if err == nil {
+			if port != first {
+				logger.Warn("Port in use, serving on another", "requested", first, "port", port)
+			}
+			config.Server.Port = port
+			return listener, nil
+		}
+		if port >= last {
+			if port == first {
+				return nil, fmt.Errorf("port %d is already in use or cannot be bound: %w", port, err)
+			}
+			return nil, fmt.Errorf("ports %d-%d are already in use or cannot be bound: %w", first, port, err)
+		}
+		logger.Warn("Port unavailable, trying the next one", "port", port, "error", err)
+	}
+}
+
+// listenUnix listens on a Unix domain socket, replacing the socket file a
+// previous run left behind. The socket is readable and writable by its owner
+// and group, so a reverse proxy in the group can connect.
+func listenUnix(path string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("removing stale socket %s: %w", path, err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listening on socket %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0660); err != nil { // #nosec G302 - the proxy's group needs to connect
+		_ = listener.Close()
+		return nil, fmt.Errorf("setting permissions on socket %s: %w", path, err)
+	}
+	return listener, nil
+}
+
+// serveListener serves on an already bound listener, over TLS if configured
+func serveListener(server *http.Server, listener net.Listener) error {
+	if server.TLSConfig != nil {
+		// Certificates are already in TLSConfig, so no files are needed here
+		return server.ServeTLS(listener, "", "")
+	}
+	return server.Serve(listener)
+}
+
 // waitForServerStart waits for the server to start and handles post-startup tasks
 func waitForServerStart(serverStarted chan struct{}, serverErr chan error, config *entities.Config, logger *Logger) error {
 	select {
@@ -468,10 +695,10 @@ func waitForServerStart(serverStarted chan struct{}, serverErr chan error, confi
 		return err
 	case <-serverStarted:
 		// Server has successfully started
-		logger.Success("Server running at: http://%s:%d", config.Server.Host, config.Server.Port)
+		logger.Success("Server running", "url", config.Server.URL())
 
-		// Open browser if configured
-		if config.Browser.AutoOpen {
+		// Open browser if configured; browsers can't open a Unix socket
+		if _, isUnix := config.Server.UnixSocket(); config.Browser.AutoOpen && !isUnix {
 			openBrowserIfConfigured(config, logger)
 		}
 		return nil
@@ -483,35 +710,39 @@ func waitForServerStart(serverStarted chan struct{}, serverErr chan error, confi
 // openBrowserIfConfigured opens the browser if auto-open is enabled
 func openBrowserIfConfigured(config *entities.Config, logger *Logger) {
 	browserLauncher := browser.NewLauncher()
-	url := fmt.Sprintf("http://%s:%d", config.Server.Host, config.Server.Port)
+	url := config.Server.URL()
 
 	if err := browserLauncher.Launch(url, false); err != nil {
-		logger.Warn("Failed to open browser: %v", err)
+		logger.Warn("Failed to open browser", "error", err)
 	}
 }
 
-// handleServerShutdown handles graceful server shutdown on signals
-func handleServerShutdown(server *http.Server, serverErr chan error, config *entities.Config, logger *Logger) error {
+// handleServerShutdown handles graceful server shutdown on signals, or
+// once idle is closed
+func handleServerShutdown(server *http.Server, serverErr chan error, config *entities.Config, logger *Logger, idle <-chan struct{}) error {
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
 
 	select {
 	case err := <-serverErr:
 		return err
 	case <-sigChan:
-		logger.Info("\nShutting down server...")
-
-		// Stop server gracefully using configured timeout
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), config.Server.GetShutdownTimeout())
-		defer shutdownCancel()
+		logger.Info("Shutting down server")
+	case <-idle:
+		logger.Info("Shutting down idle server", "idle_timeout", config.Server.GetIdleTimeout())
+	}
 
-		if err := server.Shutdown(shutdownCtx); err != nil {
-			logger.Error("Error during shutdown: %v", err)
-		}
+	// Stop server gracefully using configured timeout
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), config.Server.GetShutdownTimeout())
+	defer shutdownCancel()
 
-		return nil
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Error during shutdown", "error", err)
 	}
+
+	return nil
 }
 
 // loadAndMergeConfig loads and merges configuration from multiple sources
@@ -555,6 +786,7 @@ func mergeConfigs(target, source *entities.Config) {
 	mergeWatcherConfig(target, source)
 	mergePluginsConfig(target, source)
 	mergeMetadataConfig(target, source)
+	mergeLoggingConfig(target, source)
 }
 
 // mergeServerConfig merges server configuration from source to target
@@ -565,6 +797,15 @@ func mergeServerConfig(target, source *entities.Config) {
 	if source.Server.Port != 0 {
 		target.Server.Port = source.Server.Port
 	}
+	if source.Server.SlideIDs != "" {
+		target.Server.SlideIDs = source.Server.SlideIDs
+	}
+	if source.Server.CSP != "" {
+		target.Server.CSP = source.Server.CSP
+	}
+	if source.Server.PortRetries != 0 {
+		target.Server.PortRetries = source.Server.PortRetries
+	}
 	if source.Server.ReadTimeout != 0 {
 		target.Server.ReadTimeout = source.Server.ReadTimeout
 	}
@@ -574,9 +815,55 @@ func mergeServerConfig(target, source *entities.Config) {
 	if source.Server.ShutdownTimeout != 0 {
 		target.Server.ShutdownTimeout = source.Server.ShutdownTimeout
 	}
+	if source.Server.PingInterval != 0 {
+		target.Server.PingInterval = source.Server.PingInterval
+	}
+	if source.Server.IdleTimeout != 0 {
+		target.Server.IdleTimeout = source.Server.IdleTimeout
+	}
 	if len(source.Server.CORSOrigins) > 0 {
 		target.Server.CORSOrigins = source.Server.CORSOrigins
 	}
+	if source.Server.ExportFilenames != "" {
+		target.Server.ExportFilenames = source.Server.ExportFilenames
+	}
+	if len(source.Server.ExportFonts) > 0 {
+		target.Server.ExportFonts = source.Server.ExportFonts
+	}
+	if source.Server.SubsetFonts {
+		target.Server.SubsetFonts = true
+	}
+	if source.Server.ReadOnly {
+		target.Server.ReadOnly = true
+	}
+	if source.Server.InteractiveTasks {
+		target.Server.InteractiveTasks = true
+	}
+	if source.Server.PrefetchDepth != 0 {
+		target.Server.PrefetchDepth = source.Server.PrefetchDepth
+	}
+	if source.Server.SpeakingWPM != 0 {
+		target.Server.SpeakingWPM = source.Server.SpeakingWPM
+	}
+	if source.Server.Offline {
+		target.Server.Offline = true
+	}
+	if len(source.Server.PregenerateExports) > 0 {
+		target.Server.PregenerateExports = source.Server.PregenerateExports
+	}
+	if source.Server.PregenerateDebounceMs != 0 {
+		target.Server.PregenerateDebounceMs = source.Server.PregenerateDebounceMs
+	}
+	if source.Server.TLS.Enabled {
+		target.Server.TLS.Enabled = true
+	}
+	if source.Server.TLS.CertFile != "" {
+		target.Server.TLS.CertFile = source.Server.TLS.CertFile
+		target.Server.TLS.KeyFile = source.Server.TLS.KeyFile
+	}
+	if source.Server.TLS.CADir != "" {
+		target.Server.TLS.CADir = source.Server.TLS.CADir
+	}
 }
 
 // mergeThemeConfig merges theme configuration from source to target
@@ -587,6 +874,14 @@ func mergeThemeConfig(target, source *entities.Config) {
 	if source.Theme.CustomPath != "" {
 		target.Theme.CustomPath = source.Theme.CustomPath
 	}
+	if len(source.Theme.Variables) > 0 {
+		if target.Theme.Variables == nil {
+			target.Theme.Variables = make(map[string]string)
+		}
+		for k, v := range source.Theme.Variables {
+			target.Theme.Variables[k] = v
+		}
+	}
 }
 
 // mergeBrowserConfig merges browser configuration from source to target
@@ -611,6 +906,9 @@ func mergeWatcherConfig(target, source *entities.Config) {
 	if source.Watcher.RetryDelayMs != 0 {
 		target.Watcher.RetryDelayMs = source.Watcher.RetryDelayMs
 	}
+	if len(source.Watcher.Dirs) > 0 {
+		target.Watcher.Dirs = source.Watcher.Dirs
+	}
 }
 
 // mergePluginsConfig merges plugins configuration from source to target
@@ -619,6 +917,18 @@ func mergePluginsConfig(target, source *entities.Config) {
 	if source.Plugins.Directory != "" {
 		target.Plugins.Directory = source.Plugins.Directory
 	}
+	if source.Plugins.CacheDir != "" {
+		target.Plugins.CacheDir = source.Plugins.CacheDir
+	}
+	if source.Plugins.CacheMaxSizeMB != 0 {
+		target.Plugins.CacheMaxSizeMB = source.Plugins.CacheMaxSizeMB
+	}
+	if source.Plugins.MarketplaceAPIKey != "" {
+		target.Plugins.MarketplaceAPIKey = source.Plugins.MarketplaceAPIKey
+	}
+	if source.Plugins.ThemePublicKey != "" {
+		target.Plugins.ThemePublicKey = source.Plugins.ThemePublicKey
+	}
 	if len(source.Plugins.Whitelist) > 0 {
 		target.Plugins.Whitelist = source.Plugins.Whitelist
 	}
@@ -651,6 +961,19 @@ func mergeMetadataConfig(target, source *entities.Config) {
 	}
 }
 
+// mergeLoggingConfig merges logging configuration from source to target
+func mergeLoggingConfig(target, source *entities.Config) {
+	if source.Logging.Level != "" {
+		target.Logging.Level = source.Logging.Level
+	}
+	if source.Logging.Verbose {
+		target.Logging.Verbose = true
+	}
+	if source.Logging.JSONFormat {
+		target.Logging.JSONFormat = true
+	}
+}
+
 // applyCliFlags applies CLI flag overrides to the configuration
 func applyCliFlags(cmd *cobra.Command, config *entities.Config) {
 	// Apply CLI flag overrides (highest precedence)
@@ -660,74 +983,256 @@ func applyCliFlags(cmd *cobra.Command, config *entities.Config) {
 	if cmd.Flags().Changed("host") {
 		config.Server.Host = host
 	}
+	if cmd.Flags().Changed("port-retry") {
+		config.Server.PortRetries = portRetry
+	}
 	if cmd.Flags().Changed("no-browser") {
 		config.Browser.AutoOpen = !noBrowser
 	}
 	if cmd.Flags().Changed("theme") {
 		config.Theme.Name = themeName
 	}
+	if cmd.Flags().Changed("tls") {
+		config.Server.TLS.Enabled = useTLS
+	}
+	if cmd.Flags().Changed("tls-cert") {
+		config.Server.TLS.Enabled = true
+		config.Server.TLS.CertFile = tlsCert
+		config.Server.TLS.KeyFile = tlsKey
+	}
+	if cmd.Flags().Changed("read-only") {
+		config.Server.ReadOnly = readOnly
+	}
+	if cmd.Flags().Changed("offline") {
+		config.Server.Offline = offline
+	}
+	if cmd.Flags().Changed("watch-dir") {
+		config.Watcher.Dirs = append(config.Watcher.Dirs, watchDirs...)
+	}
+	if cmd.Flags().Changed("idle-timeout") {
+		config.Server.IdleTimeout = idleTimeout
+	}
+	if cmd.Flags().Changed("log-format") {
+		config.Logging.JSONFormat = logFormat == logFormatJSON
+	}
+}
+
+// splitMarkdownSlides splits markdown by the slide separator (---) after
+// its front matter, dropping empty slides
+func splitMarkdownSlides(markdown string) []string {
+	_, slides := splitDeck(markdown)
+	return slides
+}
+
+// splitDeck separates a deck's YAML or TOML front matter from its slides
+func splitDeck(markdown string) (map[string]interface{}, []string) {
+	frontMatter, body := mdparser.SplitFrontMatter([]byte(markdown))
+	return frontMatter, entities.SplitSlides(string(body))
 }
 
-// processMarkdownToSlides converts markdown content to HTML slides
-func processMarkdownToSlides(markdown, filePath string, config *entities.Config) string {
-	// Split markdown by slide separator (---)
-	slides := strings.Split(markdown, "\n---\n")
+// deckPresentation reads a deck's title, author, date, theme and tags from
+// its front matter, taking the title from the first H1 when it has none
+func deckPresentation(frontMatter map[string]interface{}, slides []string) entities.Presentation {
+	var presentation entities.Presentation
+	mdparser.ApplyFrontMatter(&presentation, frontMatter)
+	if presentation.Title == "" {
+		presentation.Title = firstH1(slides)
+	}
+	return presentation
+}
+
+// withTableOfContents adds a contents slide after the title slide when the
+// front matter sets toc: true, listing the slides that will be shown
+func withTableOfContents(presentation *entities.Presentation, slides []string, includeDrafts bool) []string {
+	if !services.WantsTableOfContents(presentation) {
+		return slides
+	}
 
-	var htmlSlides []string
-	for i, slide := range slides {
-		slideContent := strings.TrimSpace(slide)
-		if slideContent == "" {
+	deck := entities.Presentation{}
+	for _, content := range slides {
+		if includeDrafts || !entities.IsDraftContent(content) {
+			deck.Slides = append(deck.Slides, entities.Slide{Content: content})
+		}
+	}
+	services.InsertTableOfContents(&deck)
+
+	contents := make([]string, len(deck.Slides))
+	for i, slide := range deck.Slides {
+		contents[i] = slide.Content
+	}
+	return contents
+}
+
+// firstH1 returns the text of the first level-1 heading outside code blocks
+func firstH1(slides []string) string {
+	for _, slide := range slides {
+		fenced := false
+		for _, line := range strings.Split(slide, "\n") {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+				fenced = !fenced
+				continue
+			}
+			if title, ok := strings.CutPrefix(trimmed, "# "); ok && !fenced {
+				if title = strings.TrimSpace(strings.TrimRight(title, "#")); title != "" {
+					return title
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// renderedDeck is a presentation rendered to an HTML page
+type renderedDeck struct {
+	HTML string
+	// Presentation holds the deck's metadata, without slides
+	Presentation entities.Presentation
+	// Slides holds each slide's HTML, in the order shown
+	Slides []string
+	// Changed lists the 1-based slides that were converted rather than
+	// taken from the slide cache
+	Changed []int
+	// HeadingIDs maps each heading's anchor to the 1-based slide it's on
+	HeadingIDs map[string]int
+	// Transitions are the slide transitions the page has styles for
+	Transitions slideTransitions
+	// Sources lists the presentation file and the files it includes
+	Sources []string
+}
+
+// processMarkdownToSlides converts markdown content to HTML slides, skipping
+// draft slides unless includeDrafts is set
+func processMarkdownToSlides(markdown, filePath string, config *entities.Config, includeDrafts bool) string {
+	return processMarkdownToDeck(markdown, filePath, config, includeDrafts, nil).HTML
+}
+
+// processMarkdownToDeck converts markdown content to HTML slides like
+// processMarkdownToSlides, also returning the deck's heading anchors. Headings
+// sharing a title across slides get suffixed anchors (overview, overview-1).
+// Slides unchanged since the render kept in cache are reused from it.
+func processMarkdownToDeck(markdown, filePath string, config *entities.Config, includeDrafts bool, cache *slideCache) renderedDeck {
+	themeName := "default"
+	if config != nil && config.Theme.Name != "" {
+		themeName = config.Theme.Name
+	}
+	layouts := loadSlideLayouts(themeName)
+	slideIDs := entities.SlideIDsIndex
+	if config != nil {
+		slideIDs = config.Server.GetSlideIDs()
+	}
+
+	// Cached slides are only reused with the same layouts and slide IDs
+	cache.prepare(layouts.fingerprint() + "\x00" + slideIDs)
+
+	frontMatter, slides := splitDeck(markdown)
+	presentation := deckPresentation(frontMatter, slides)
+	slides = withTableOfContents(&presentation, slides, includeDrafts)
+
+	// Headings mustn't take the slide containers' IDs
+	reserved := make([]string, len(slides))
+	for i := range slides {
+		reserved[i] = fmt.Sprintf("slide-%d", i+1)
+	}
+	headingIDs := newDeckHeadingIDs(reserved...)
+
+	transitions := slideTransitions{Deck: deckTransition(markdown, filePath, config)}
+	transitions.add(transitions.Deck)
+
+	deck := renderedDeck{
+		Presentation: presentation,
+		HeadingIDs:   headingIDs.slides,
+	}
+	for _, slideContent := range slides {
+		draft := entities.IsDraftContent(slideContent)
+		if draft && !includeDrafts {
+			continue
+		}
+		transitions.add(entities.DeclaredTransition(slideContent))
+
+		number := len(deck.Slides) + 1
+		if slideHTML, ok := cache.reuse(number, slideContent, headingIDs); ok {
+			deck.Slides = append(deck.Slides, slideHTML)
 			continue
 		}
 
-		// Basic markdown to HTML conversion
-		htmlContent := basicMarkdownToHTML(slideContent)
+		headingIDs.startSlide(number)
+		slideHTML := renderSlideHTML(slideContent, number, draft, layouts, headingIDs, slideIDs)
+		cache.store(number, slideContent, slideHTML, headingIDs.given)
 
-		// Determine slide type based on content
-		slideClass := determineSlideClass(slideContent, i)
-		
-		// Wrap in slide div with proper classes
-		slideHTML := fmt.Sprintf(`<div class="slide %s" id="slide-%d">%s</div>`, slideClass, i+1, htmlContent)
+		deck.Slides = append(deck.Slides, slideHTML)
+		deck.Changed = append(deck.Changed, number)
+	}
+	cache.truncate(len(deck.Slides))
 
-		htmlSlides = append(htmlSlides, slideHTML)
+	if collisions := headingIDs.collisionSummary(); collisions != "" {
+		appLogger.Warn("Duplicate heading IDs renamed", "file", filePath, "ids", collisions)
 	}
 
 	// Generate complete HTML page
-	return generatePresentationHTML(strings.Join(htmlSlides, "\n"), filePath, config)
-}
-
-// basicMarkdownToHTML provides complete markdown to HTML conversion using Goldmark
-func basicMarkdownToHTML(markdown string) string {
-	// Configure Goldmark with extensions for full markdown support
-	md := goldmark.New(
-		goldmark.WithExtensions(
-			extension.GFM,        // GitHub Flavored Markdown (tables, strikethrough, etc.)
-			extension.Table,      // Tables support
-			extension.Strikethrough, // ~~strikethrough~~ support
-			extension.TaskList,   // - [ ] task list support
-		),
-		goldmark.WithParserOptions(
-			parser.WithAutoHeadingID(), // Auto-generate heading IDs
-		),
-		goldmark.WithRendererOptions(
-			html.WithHardWraps(), // Convert line breaks to <br>
-			html.WithXHTML(),     // XHTML compliant output
-			html.WithUnsafe(),    // Allow raw HTML (needed for Mermaid)
-		),
-	)
-
-	// Convert markdown to HTML
-	var buf bytes.Buffer
-	if err := md.Convert([]byte(markdown), &buf); err != nil {
-		log.Printf("[ERROR] Failed to convert markdown: %v", err)
-		return markdown // Return original markdown on error
-	}
-
-	// Post-process for Mermaid diagrams
-	html := buf.String()
-	html = postProcessMermaidDiagrams(html)
-	
-	return html
+	deck.Transitions = transitions
+	deck.HTML = generatePresentationHTML(strings.Join(deck.Slides, "\n"), len(deck.Slides), deck.Presentation.Title, filePath, config, transitions)
+	return deck
+}
+
+// renderSlideHTML converts one slide's markdown to its slide div. With
+// heading slide IDs the div takes its first heading's ID in place of slide-N.
+func renderSlideHTML(slideContent string, number int, draft bool, layouts slideLayouts, headingIDs *deckHeadingIDs, slideIDs string) string {
+	// A leading <!-- slide: --> comment holds the slide div's own attributes
+	attributes, slideContent := entities.ParseSlideAttributes(slideContent)
+
+	// Speaker notes are for the presenter, not the audience
+	slideContent, _ = entities.ExtractSpeakerNotes(slideContent)
+
+	// Basic markdown to HTML conversion
+	htmlContent := slideMarkdownToHTML(slideContent, headingIDs)
+
+	// The ID moves from the heading to the slide, keeping it unique
+	slideID := fmt.Sprintf("slide-%d", number)
+	if slideIDs == entities.SlideIDsHeading {
+		if id, ok := headingIDs.firstHeading(); ok {
+			slideID = id
+			htmlContent = takeHeadingID(htmlContent, id)
+		}
+	}
+
+	// Determine slide type from an explicit layout comment or the content
+	slideType := attributes.Layout
+	if slideType == "" {
+		slideType = entities.DeclaredSlideType(slideContent)
+	}
+	if slideType == "" {
+		slideType = strings.TrimPrefix(determineSlideClass(slideContent, number-1), "dev-")
+	}
+	slideClass := "dev-" + slideType
+	if len(attributes.Classes) > 0 {
+		slideClass += " " + strings.Join(attributes.Classes, " ")
+	}
+
+	// Let the theme give the slide type its own structure
+	if layoutHTML, ok := layouts.apply(slideType, number, htmlContent); ok {
+		htmlContent = layoutHTML
+	}
+
+	// List the slide's images and media so navigation can preload them
+	transition := attributes.Transition
+	if transition == "" {
+		transition = entities.DeclaredTransition(slideContent)
+	}
+	attrs := prefetchAttr(export.HTMLAssets(htmlContent)) + transitionAttr(transition) + slideDirectiveAttrs(attributes)
+	if draft {
+		slideClass += " draft"
+		attrs += ` data-draft="true"`
+	}
+
+	// Screen readers announce each slide as one, and navigation focuses it
+	attrs += fmt.Sprintf(` role="region" aria-roledescription="slide" aria-label="Slide %d" tabindex="-1"`, number)
+
+	// Navigation finds the slide's position here whatever its ID
+	attrs += fmt.Sprintf(` data-index="%d"`, number)
+
+	// Wrap in slide div with proper classes
+	return fmt.Sprintf(`<div class="slide %s" id="%s"%s>%s</div>`, slideClass, slideID, attrs, htmlContent)
 }
 
 // determineSlideClass determines the appropriate CSS class for a slide based on its content
@@ -736,14 +1241,14 @@ func determineSlideClass(slideContent string, slideIndex int) string {
 	if len(lines) == 0 {
 		return "dev-content"
 	}
-	
+
 	firstLine := strings.TrimSpace(lines[0])
-	
+
 	// First slide is typically a title slide
 	if slideIndex == 0 {
 		return "dev-title"
 	}
-	
+
 	// Check if slide starts with a single H1 and has minimal content (section slide)
 	if strings.HasPrefix(firstLine, "# ") {
 		// Count meaningful content lines (non-empty, non-separator)
@@ -754,325 +1259,165 @@ func determineSlideClass(slideContent string, slideIndex int) string {
 				contentLines++
 			}
 		}
-		
+
 		// If H1 with minimal content, it's likely a section header
 		if contentLines <= 2 {
 			return "dev-section"
 		}
 	}
-	
+
 	// Check for specific patterns that indicate section slides
-	if strings.Contains(strings.ToLower(firstLine), "questions") || 
-	   strings.Contains(strings.ToLower(firstLine), "thank you") ||
-	   strings.Contains(strings.ToLower(firstLine), "demo") ||
-	   strings.Contains(strings.ToLower(firstLine), "roadmap") {
+	if strings.Contains(strings.ToLower(firstLine), "questions") ||
+		strings.Contains(strings.ToLower(firstLine), "thank you") ||
+		strings.Contains(strings.ToLower(firstLine), "demo") ||
+		strings.Contains(strings.ToLower(firstLine), "roadmap") {
 		return "dev-section"
 	}
-	
+
 	// Default to content slide
 	return "dev-content"
 }
 
-// postProcessMermaidDiagrams converts Goldmark's code blocks to Mermaid divs
-func postProcessMermaidDiagrams(html string) string {
-	// Pattern to match Goldmark's code blocks with mermaid language (multiline with DOTALL)
-	mermaidPattern := regexp.MustCompile(`(?s)<pre><code class="language-mermaid">(.*?)</code></pre>`)
-	
-	return mermaidPattern.ReplaceAllStringFunc(html, func(match string) string {
-		// Extract the Mermaid content from the code block
-		submatch := mermaidPattern.FindStringSubmatch(match)
-		if len(submatch) < 2 {
-			return match
-		}
-		
-		mermaidContent := submatch[1]
-		
-		// Decode HTML entities that Goldmark encoded
-		mermaidContent = strings.ReplaceAll(mermaidContent, "&lt;", "<")
-		mermaidContent = strings.ReplaceAll(mermaidContent, "&gt;", ">")
-		mermaidContent = strings.ReplaceAll(mermaidContent, "&amp;", "&")
-		mermaidContent = strings.ReplaceAll(mermaidContent, "&#34;", `"`)
-		mermaidContent = strings.ReplaceAll(mermaidContent, "&#39;", `'`)
-		
-		// Clean up extra whitespace but preserve structure
-		mermaidContent = strings.TrimSpace(mermaidContent)
-		
-		// Escape content for HTML attribute
-		escapedContent := strings.ReplaceAll(mermaidContent, `"`, `&quot;`)
-		escapedContent = strings.ReplaceAll(escapedContent, `'`, `&#39;`)
-		escapedContent = strings.ReplaceAll(escapedContent, "\n", "&#10;")
-		
-		// Return Mermaid div with data-original attribute
-		return fmt.Sprintf(`<div class="mermaid" data-original="%s">%s</div>`, escapedContent, mermaidContent)
-	})
-}
-
-// markdownProcessor handles the stateful markdown to HTML conversion
-type markdownProcessor struct {
-	html         *strings.Builder
-	inCodeBlock  bool
-	codeLanguage string
-	mermaidContent strings.Builder // Store original Mermaid content
-	inTable      bool
-	isFirstTableRow bool
-}
-
-// processLine processes a single line of markdown
-func (p *markdownProcessor) processLine(line string) {
-	// Handle code block boundaries
-	if strings.HasPrefix(line, "```") {
-		p.handleCodeBlockBoundary(line)
-		return
-	}
-
-	// Handle content inside code blocks
-	if p.inCodeBlock {
-		p.handleCodeBlockContent(line)
-		return
-	}
-
-	// Handle regular markdown content
-	p.handleRegularContent(line)
-}
-
-// handleCodeBlockBoundary handles the start and end of code blocks
-func (p *markdownProcessor) handleCodeBlockBoundary(line string) {
-	if p.inCodeBlock {
-		// End of code block
-		p.writeCodeBlockClosing()
-		p.inCodeBlock = false
-	} else {
-		// Start of code block
-		p.codeLanguage = strings.TrimPrefix(line, "```")
-		if p.codeLanguage == "" {
-			p.codeLanguage = "text"
-		}
-		p.writeCodeBlockOpening()
-		p.inCodeBlock = true
-	}
-}
-
-// writeCodeBlockOpening writes the opening tags for code blocks
-func (p *markdownProcessor) writeCodeBlockOpening() {
-	if p.codeLanguage == "mermaid" {
-		// For Mermaid, we'll store the content first, then create the div
-		p.html.WriteString(`<div class="mermaid" data-original="">`)
-	} else {
-		_, _ = fmt.Fprintf(p.html, `<pre class="code-block %s"><code>`, p.codeLanguage)
-	}
-}
-
-// writeCodeBlockClosing writes the closing tags for code blocks
-func (p *markdownProcessor) writeCodeBlockClosing() {
-	if p.codeLanguage == "mermaid" {
-		// Get the collected Mermaid content and update the opening div
-		mermaidContent := strings.TrimSpace(p.mermaidContent.String())
-		
-		// Escape quotes for HTML attribute
-		escapedContent := strings.ReplaceAll(mermaidContent, `"`, `&quot;`)
-		escapedContent = strings.ReplaceAll(escapedContent, `'`, `&#39;`)
-		
-		// Replace the empty data-original with the actual content
-		currentHTML := p.html.String()
-		updatedHTML := strings.Replace(currentHTML, `data-original=""`, `data-original="`+escapedContent+`"`, 1)
-		
-		// Reset and write the updated HTML
-		p.html.Reset()
-		p.html.WriteString(updatedHTML)
-		
-		// Reset Mermaid content for next diagram
-		p.mermaidContent.Reset()
-		
-		p.html.WriteString("</div>\n")
-	} else {
-		p.html.WriteString("</code></pre>\n")
-	}
-}
-
-// handleCodeBlockContent handles content inside code blocks
-func (p *markdownProcessor) handleCodeBlockContent(line string) {
-	if p.codeLanguage == "mermaid" {
-		// For Mermaid, collect content for data attribute but also write as-is
-		p.mermaidContent.WriteString(line + "\n")
-		p.html.WriteString(line + "\n")
-	} else {
-		// For other code blocks, escape HTML entities
-		escaped := strings.ReplaceAll(line, "&", "&amp;")
-		escaped = strings.ReplaceAll(escaped, "<", "&lt;")
-		escaped = strings.ReplaceAll(escaped, ">", "&gt;")
-		p.html.WriteString(escaped + "\n")
-	}
-}
+// cdnPluginAssets loads the common plugin libraries from their CDNs
+const cdnPluginAssets = `
+    <!-- Common plugin assets -->
+    <script src="https://cdn.jsdelivr.net/npm/mermaid@10.6.1/dist/mermaid.min.js"></script>
+    <script src="https://unpkg.com/prismjs@1/components/prism-core.min.js"></script>
+    <script src="https://unpkg.com/prismjs@1/plugins/autoloader/prism-autoloader.min.js"></script>
+    <link rel="stylesheet" href="https://unpkg.com/prismjs@1/themes/prism.css">`
 
-// handleRegularContent handles regular markdown content (headers, paragraphs, lists)
-func (p *markdownProcessor) handleRegularContent(line string) {
-	if line == "" {
-		return
-	}
+// offlinePluginAssets loads the same libraries from the copies vendored into
+// the binary, pointing the Prism autoloader at the vendored grammars too
+const offlinePluginAssets = `
+    <!-- Common plugin assets, vendored for offline use -->
+    <script src="/assets/vendor/mermaid/mermaid.min.js"></script>
+    <script src="/assets/vendor/prismjs/components/prism-core.min.js"></script>
+    <script src="/assets/vendor/prismjs/plugins/autoloader/prism-autoloader.min.js"></script>
+    <script>Prism.plugins.autoloader.languages_path = '/assets/vendor/prismjs/components/';</script>
+    <link rel="stylesheet" href="/assets/vendor/prismjs/themes/prism.css">`
+
+// interactiveSlideStyles show one slide at a time, the first until the
+// navigation script takes over
+const interactiveSlideStyles = `
+        /* Minimal base reset - let theme handle everything else */
+        html, body {
+            margin: 0;
+            padding: 0;
+            width: 100%;
+            height: 100%;
+            overflow: hidden;
+        }
+        
+        /* Initial slide setup - hide all slides by default */
+        .slide {
+            display: none !important;
+        }
+        
+        /* Show only the first slide initially */
+        .slide:first-child {
+            display: flex !important;
+        }
+        `
 
-	// Handle headers (h3, h2, h1 in priority order)
-	if strings.HasPrefix(line, "### ") {
-		content := processInlineMarkdown(strings.TrimPrefix(line, "### "))
-		p.html.WriteString("<h3>" + content + "</h3>\n")
-	} else if strings.HasPrefix(line, "## ") {
-		content := processInlineMarkdown(strings.TrimPrefix(line, "## "))
-		p.html.WriteString("<h2>" + content + "</h2>\n")
-	} else if strings.HasPrefix(line, "# ") {
-		content := processInlineMarkdown(strings.TrimPrefix(line, "# "))
-		p.html.WriteString("<h1>" + content + "</h1>\n")
-	} else if strings.HasPrefix(line, "- ") {
-		// Handle list items
-		content := processInlineMarkdown(strings.TrimPrefix(line, "- "))
-		p.html.WriteString("<li>" + content + "</li>\n")
-	} else if strings.Contains(line, "|") && strings.Count(line, "|") >= 2 {
-		// Handle table rows
-		p.handleTableRow(line)
-	} else {
-		// End table if we were in one
-		if p.inTable {
-			p.html.WriteString("</table>\n")
-			p.inTable = false
-		}
-		// Regular paragraph
-		content := processInlineMarkdown(line)
-		p.html.WriteString("<p>" + content + "</p>\n")
-	}
-}
+// printSlideStyles replace interactiveSlideStyles in print mode, stacking
+// every slide on its own page
+const printSlideStyles = `
+        /* Print mode - every slide stacked, one per page */
+        html, body {
+            margin: 0;
+            padding: 0;
+            width: 100%;
+            height: auto;
+            overflow: visible;
+        }
+        
+        .slide {
+            display: flex !important;
+            position: relative !important;
+            break-after: page;
+            page-break-after: always;
+        }
+        
+        .navigation, .presentation-info {
+            display: none !important;
+        }
+        `
 
-// handleTableRow handles markdown table rows
-func (p *markdownProcessor) handleTableRow(line string) {
-	// Skip separator rows (rows with only |, -, and spaces)
-	if regexp.MustCompile(`^[\|\-\s]*$`).MatchString(line) {
-		return
+// presentationPage returns the page to serve for a request: page as is, or
+// with every slide shown for ?print=true
+func presentationPage(page string, r *http.Request) string {
+	if r.URL.Query().Get("print") != "true" {
+		return page
 	}
-	
-	// Start table if not already in one
-	if !p.inTable {
-		p.html.WriteString("<table>\n")
-		p.inTable = true
-		p.isFirstTableRow = true
-	}
-	
-	// Split by | and clean up cells
-	cells := strings.Split(line, "|")
-	
-	// Remove empty cells at start and end
-	if len(cells) > 0 && strings.TrimSpace(cells[0]) == "" {
-		cells = cells[1:]
-	}
-	if len(cells) > 0 && strings.TrimSpace(cells[len(cells)-1]) == "" {
-		cells = cells[:len(cells)-1]
-	}
-	
-	// Start row
-	p.html.WriteString("<tr>")
-	
-	// Process each cell
-	for _, cell := range cells {
-		cell = strings.TrimSpace(cell)
-		content := processInlineMarkdown(cell)
-		
-		if p.isFirstTableRow {
-			p.html.WriteString("<th>" + content + "</th>")
-		} else {
-			p.html.WriteString("<td>" + content + "</td>")
-		}
-	}
-	
-	// End row
-	p.html.WriteString("</tr>\n")
-	
-	// No longer first row
-	p.isFirstTableRow = false
-}
-
-// processInlineMarkdown handles inline markdown formatting
-func processInlineMarkdown(text string) string {
-	// Process inline code first to avoid conflicts
-	text = regexp.MustCompile("`([^`]+)`").ReplaceAllString(text, "<code>$1</code>")
-	
-	// Process bold text (**text**)
-	text = regexp.MustCompile(`\*\*([^*]+)\*\*`).ReplaceAllString(text, "<strong>$1</strong>")
-	
-	// Process italic text (*text*) - but not list markers
-	// Use negative lookbehind/lookahead would be ideal, but Go doesn't support it
-	// So we'll use a more specific pattern
-	text = regexp.MustCompile(`(\s|^)\*([^*\s][^*]*[^*\s])\*(\s|$)`).ReplaceAllString(text, "$1<em>$2</em>$3")
-	
-	// Process strikethrough (~~text~~)
-	text = regexp.MustCompile(`~~([^~]+)~~`).ReplaceAllString(text, "<s>$1</s>")
-	
-	return text
-}
-
-// processInlinePattern applies a regex pattern to convert inline markdown
-func processInlinePattern(text, pattern, replacement string) string {
-	re := regexp.MustCompile(pattern)
-	return re.ReplaceAllString(text, replacement)
+	return strings.Replace(page, interactiveSlideStyles, printSlideStyles, 1)
 }
 
 // generatePresentationHTML creates the complete HTML page with plugin assets
-func generatePresentationHTML(slidesHTML, filePath string, config *entities.Config) string {
+// for slideCount slides
+func generatePresentationHTML(slidesHTML string, slideCount int, title, filePath string, config *entities.Config, transitions slideTransitions) string {
 	// TODO: In a real implementation, we would get the plugin renderer instance
 	// to access stored assets and include them in the HTML head section
 	// For now, we include default assets and common plugin dependencies
 
-	pluginAssets := `
-    <!-- Common plugin assets -->
-    <script src="https://cdn.jsdelivr.net/npm/mermaid@10.6.1/dist/mermaid.min.js"></script>
-    <script src="https://unpkg.com/prismjs@1/components/prism-core.min.js"></script>
-    <script src="https://unpkg.com/prismjs@1/plugins/autoloader/prism-autoloader.min.js"></script>
-    <link rel="stylesheet" href="https://unpkg.com/prismjs@1/themes/prism.css">`
+	pluginAssets := cdnPluginAssets
+	if config != nil && config.Server.Offline {
+		pluginAssets = offlinePluginAssets
+	}
 
 	themeName := "default"
 	if config != nil && config.Theme.Name != "" {
 		themeName = config.Theme.Name
 	}
 
+	if title == "" {
+		title = "SLICLI Presentation"
+	}
+	title = html.EscapeString(title)
+
+	// How many slides ahead the navigation script preloads
+	prefetchDepth := 1
+	var themeVariables map[string]string
+	if config != nil {
+		prefetchDepth = config.Server.GetPrefetchDepth()
+		themeVariables = config.Theme.Variables
+	}
+
 	// Build the HTML template with placeholders
 	htmlTemplate := `<!DOCTYPE html>
 <html lang="en">
 <head>
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
-    <title>SLICLI Presentation</title>
-    <style>
-        /* Minimal base reset - let theme handle everything else */
-        html, body {
-            margin: 0;
-            padding: 0;
-            width: 100%;
-            height: 100%;
+    <title>{TITLE}</title>
+    <style>{SLIDE_LAYOUT_STYLES}
+        /* Read by screen readers only */
+        .visually-hidden {
+            position: absolute !important;
+            width: 1px;
+            height: 1px;
             overflow: hidden;
-        }
-        
-        /* Initial slide setup - hide all slides by default */
-        .slide {
-            display: none !important;
-        }
-        
-        /* Show only the first slide initially */
-        .slide:first-child {
-            display: flex !important;
+            clip: rect(0 0 0 0);
+            white-space: nowrap;
         }
     </style>
     <!-- Main CSS is optional, theme should override -->
     <!-- <link rel="stylesheet" href="/assets/css/main.css"> -->
     <!-- Theme CSS -->
-    <link rel="stylesheet" href="/themes/{THEME_NAME}/style.css">
+    <link rel="stylesheet" href="/themes/{THEME_NAME}/style.css">{THEME_VARIABLES}{TRANSITION_STYLES}
     {PLUGIN_ASSETS}
 </head>
-<body class="theme-{THEME_NAME} presentation">
+<body class="theme-{THEME_NAME} presentation"{TRANSITION_ATTR}>
     <div class="slides-container">
         {SLIDES_HTML}
     </div>
-    <div class="navigation">
-        <button onclick="previousSlide()">←</button>
-        <span class="slide-counter">
-            <span id="current-slide">1</span> / <span id="total-slides">{SLIDE_COUNT}</span>
+    <nav class="navigation" aria-label="Slide navigation">
+        <button type="button" id="previous-slide" aria-label="Previous slide"><span aria-hidden="true">←</span></button>
+        <span class="slide-counter" aria-live="polite" aria-atomic="true">
+            <span class="visually-hidden">Slide</span>
+            <span id="current-slide">1</span> <span aria-hidden="true">/</span><span class="visually-hidden">of</span> <span id="total-slides">{SLIDE_COUNT}</span>
         </span>
-        <button onclick="nextSlide()">→</button>
-    </div>
+        <button type="button" id="next-slide" aria-label="Next slide"><span aria-hidden="true">→</span></button>
+    </nav>
     <div class="presentation-info">
         <strong>File:</strong> {FILE_PATH}
         <strong>Theme:</strong> {THEME_NAME}
@@ -1082,43 +1427,117 @@ func generatePresentationHTML(slidesHTML, filePath string, config *entities.Conf
         let currentSlide = 1;
         const slides = document.querySelectorAll('.slide');
         const totalSlides = slides.length;
+        const prefetchDepth = {PREFETCH_DEPTH};
+        const printMode = new URLSearchParams(window.location.search).get('print') === 'true';
+        const prefetched = new Set();
+        let mermaidReady = false;
+        let diagramCount = 0;
+        
+        // Prefetching is skipped on metered or slow connections and when the
+        // page is being printed or exported
+        function prefetchAllowed() {
+            if (printMode) return false;
+            const connection = navigator.connection;
+            if (connection && (connection.saveData || /(^|-)2g$/.test(connection.effectiveType || ''))) {
+                return false;
+            }
+            if (new URLSearchParams(window.location.search).has('export') || window.matchMedia('print').matches) {
+                return false;
+            }
+            return prefetchDepth > 0;
+        }
+        
+        // Preload images and render diagrams of the slides after slide n
+        function prefetchFrom(n) {
+            if (!prefetchAllowed()) return;
+            for (let i = n; i < Math.min(n + prefetchDepth, totalSlides); i++) {
+                const slide = slides[i];
+                let assets = [];
+                try {
+                    assets = JSON.parse(slide.dataset.prefetch || '[]');
+                } catch (e) {
+                    console.error('Invalid prefetch list on slide', i + 1, e);
+                }
+                assets.forEach(src => {
+                    if (prefetched.has(src)) return;
+                    prefetched.add(src);
+                    const link = document.createElement('link');
+                    link.rel = 'prefetch';
+                    link.href = src;
+                    document.head.appendChild(link);
+                });
+                renderDiagrams(slide);
+            }
+        }
         
-        function showSlide(n) {
+        // Slides are shown one at a time; with focus set, the shown slide
+        // takes keyboard focus so screen readers read it
+        function showSlide(n, focus) {
             slides.forEach(slide => {
                 slide.style.display = 'none';
                 slide.style.setProperty('display', 'none', 'important');
+                slide.setAttribute('aria-hidden', 'true');
             });
             currentSlide = n;
             if (currentSlide > totalSlides) currentSlide = 1;
             if (currentSlide < 1) currentSlide = totalSlides;
             const activeSlide = slides[currentSlide - 1];
             activeSlide.style.setProperty('display', 'flex', 'important'); // Override CSS with important
+            activeSlide.removeAttribute('aria-hidden');
+            if (focus) activeSlide.focus({ preventScroll: true });
+            const transition = activeSlide.dataset.transition || document.body.dataset.transition;
+            if (transition) {
+                activeSlide.classList.remove('transition-fade', 'transition-slide', 'transition-none');
+                void activeSlide.offsetWidth; // Restart the animation
+                activeSlide.classList.add('transition-' + transition);
+            }
             document.getElementById('current-slide').textContent = currentSlide;
             document.getElementById('total-slides').textContent = totalSlides;
+            renderDiagrams(activeSlide);
+            prefetchFrom(currentSlide);
         }
         
         function nextSlide() {
-            showSlide(currentSlide + 1);
+            showSlide(currentSlide + 1, true);
         }
         
         function previousSlide() {
-            showSlide(currentSlide - 1);
+            showSlide(currentSlide - 1, true);
         }
         
+        document.getElementById('previous-slide').addEventListener('click', previousSlide);
+        document.getElementById('next-slide').addEventListener('click', nextSlide);
+        
         // Keyboard navigation
         document.addEventListener('keydown', (e) => {
+            if (printMode) return;
             if (e.key === 'ArrowRight') nextSlide();
             if (e.key === 'ArrowLeft') previousSlide();
         });
         
-        // Initialize first slide and hide others
-        showSlide(1);
+        // The slide holding the element a link like #intro points at, or 0
+        function hashSlide() {
+            if (!window.location.hash) return 0;
+            const target = document.getElementById(decodeURIComponent(window.location.hash.slice(1)));
+            const slide = target && target.closest('.slide');
+            return slide ? Number(slide.dataset.index) : 0;
+        }
+        
+        window.addEventListener('hashchange', () => {
+            const n = hashSlide();
+            if (n && !printMode) showSlide(n, true);
+        });
+        
+        // Initialize the linked or first slide and hide others; print mode
+        // shows them all
+        if (!printMode) showSlide(hashSlide() || 1);
         
         // Ensure proper slide display on load
         document.addEventListener('DOMContentLoaded', function() {
-            // Hide all slides except the first
+            if (printMode) return;
+            // Hide all slides except the shown one
             slides.forEach((slide, index) => {
-                if (index === 0) {
+                if (index === currentSlide - 1) {
                     slide.style.display = 'flex'; // Use flex as per theme CSS
                 } else {
                     slide.style.display = 'none';
@@ -1126,56 +1545,56 @@ func generatePresentationHTML(slidesHTML, filePath string, config *entities.Conf
             });
         });
         
-        // Initialize Mermaid after slides are set up
-        async function initializeMermaid() {
-            if (typeof mermaid !== 'undefined') {
-                mermaid.initialize({
-                    startOnLoad: false,  // Don't auto-start
-                    theme: 'dark',
-                    securityLevel: 'loose'
-                });
+        // Render the Mermaid diagrams of one slide that aren't rendered yet
+        async function renderDiagrams(slide) {
+            if (!mermaidReady) return;
+            
+            const mermaidElements = slide.querySelectorAll('.mermaid:not(.mermaid-rendered)');
+            for (const element of mermaidElements) {
+                element.classList.add('mermaid-rendered');
+                
+                // Get the original markdown content from data attribute or textContent
+                let graphDefinition = element.getAttribute('data-original') || element.textContent || element.innerText || '';
+                
+                // Clean up the definition
+                graphDefinition = graphDefinition.trim();
+                graphDefinition = graphDefinition.replace(/&gt;/g, '>').replace(/&lt;/g, '<').replace(/&amp;/g, '&');
+                
+                const id = 'mermaid-' + diagramCount++;
                 
-                // Manually render all visible mermaid diagrams
                 try {
-                    const mermaidElements = document.querySelectorAll('.mermaid');
-                    console.log('Found', mermaidElements.length, 'mermaid elements');
-                    
-                    for (let i = 0; i < mermaidElements.length; i++) {
-                        const element = mermaidElements[i];
-                        
-                        // Get the original markdown content from data attribute or textContent
-                        let graphDefinition = element.getAttribute('data-original') || element.textContent || element.innerText || '';
-                        
-                        // If we got the processed content, skip this element (already rendered)
-                        if (graphDefinition.includes('#mermaid-') || graphDefinition.includes('font-family')) {
-                            console.log('Skipping diagram', i, '- already rendered or contains styling');
-                            return;
-                        }
-                        
-                        // Clean up the definition
-                        graphDefinition = graphDefinition.trim();
-                        graphDefinition = graphDefinition.replace(/&gt;/g, '>').replace(/&lt;/g, '<').replace(/&amp;/g, '&');
-                        
-                        const id = 'mermaid-' + i;
-                        
-                        console.log('Rendering diagram', i, 'content:', JSON.stringify(graphDefinition));
-                        
-                        try {
-                            const { svg } = await mermaid.render(id, graphDefinition);
-                            element.innerHTML = svg;
-                            element.classList.add('mermaid-rendered');
-                            console.log('Successfully rendered diagram', i);
-                        } catch (renderError) {
-                            console.error('Mermaid render error for diagram', i, ':', renderError);
-                            element.innerHTML = '<div style="color: red; padding: 10px; border: 1px solid red;">Error: ' + renderError.message + '</div>';
-                        }
-                    }
-                } catch (e) {
-                    console.error('Mermaid initialization error:', e);
+                    const { svg } = await mermaid.render(id, graphDefinition);
+                    element.innerHTML = svg;
+                } catch (renderError) {
+                    console.error('Mermaid render error for diagram', id, ':', renderError);
+                    element.innerHTML = '<div style="color: red; padding: 10px; border: 1px solid red;">Error: ' + renderError.message + '</div>';
                 }
             }
         }
         
+        // Initialize Mermaid after slides are set up. Without prefetching every
+        // diagram is rendered up front; otherwise the current slide and the
+        // prefetch window are, and the rest as navigation reaches them.
+        async function initializeMermaid() {
+            if (typeof mermaid === 'undefined') return;
+            
+            mermaid.initialize({
+                startOnLoad: false,  // Don't auto-start
+                theme: 'dark',
+                securityLevel: 'loose'
+            });
+            mermaidReady = true;
+            
+            if (!prefetchAllowed()) {
+                for (const slide of slides) {
+                    await renderDiagrams(slide);
+                }
+                return;
+            }
+            await renderDiagrams(slides[currentSlide - 1]);
+            prefetchFrom(currentSlide);
+        }
+        
         // Wait for DOM and scripts to be ready
         document.addEventListener('DOMContentLoaded', async function() {
             // Initialize Prism.js for syntax highlighting
@@ -1191,13 +1610,16 @@ func generatePresentationHTML(slidesHTML, filePath string, config *entities.Conf
     </script>
 </body>
 </html>`
-	
-	// Count total slides
-	slideCount := len(strings.Split(slidesHTML, `<div class="slide"`)) - 1
-	
+
 	// Replace placeholders
 	html := strings.ReplaceAll(htmlTemplate, "{THEME_NAME}", themeName)
+	html = strings.ReplaceAll(html, "{SLIDE_LAYOUT_STYLES}", interactiveSlideStyles)
+	html = strings.ReplaceAll(html, "{THEME_VARIABLES}", themeVariablesStyle(themeVariables))
+	html = strings.ReplaceAll(html, "{TRANSITION_STYLES}", transitionsStyle(transitions))
+	html = strings.ReplaceAll(html, "{TRANSITION_ATTR}", transitionAttr(transitions.Deck))
 	html = strings.ReplaceAll(html, "{PLUGIN_ASSETS}", pluginAssets)
+	html = strings.ReplaceAll(html, "{PREFETCH_DEPTH}", fmt.Sprintf("%d", prefetchDepth))
+	html = strings.ReplaceAll(html, "{TITLE}", title)
 	html = strings.ReplaceAll(html, "{SLIDES_HTML}", slidesHTML)
 	html = strings.ReplaceAll(html, "{FILE_PATH}", filePath)
 	html = strings.ReplaceAll(html, "{SLIDE_COUNT}", fmt.Sprintf("%d", slideCount))
@@ -1245,6 +1667,26 @@ body {
     display: block;
 }
 
+.slide.draft {
+    position: relative;
+    outline: 3px dashed #e67e22;
+    outline-offset: -12px;
+}
+
+.slide.draft::before {
+    content: "DRAFT";
+    position: absolute;
+    top: 16px;
+    right: 24px;
+    padding: 2px 8px;
+    background: #e67e22;
+    color: white;
+    font-size: 0.75rem;
+    font-weight: bold;
+    letter-spacing: 0.1em;
+    border-radius: 3px;
+}
+
 .slide-content {
     padding: 3rem;
     line-height: 1.6;
@@ -1363,7 +1805,11 @@ body {
 
 // getServerURL constructs the server URL from host and port
 func getServerURL() string {
-	return fmt.Sprintf("http://%s:%d", host, port)
+	scheme := "http"
+	if useTLS || tlsCert != "" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, host, port)
 }
 
 // getDefaultJS returns basic JavaScript for presentations
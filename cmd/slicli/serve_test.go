@@ -2,6 +2,18 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -9,6 +21,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/web"
 )
 
 func TestServeCommand(t *testing.T) {
@@ -136,3 +149,515 @@ func TestGetServerURL(t *testing.T) {
 		})
 	}
 }
+
+func TestServeOverSelfSignedTLS(t *testing.T) {
+	config := &entities.Config{
+		Server: entities.ServerConfig{
+			Host: "127.0.0.1",
+			TLS: entities.TLSConfig{
+				Enabled: true,
+				CADir:   t.TempDir(),
+			},
+		},
+	}
+
+	server := createHTTPServer(config, "", "<html>slides</html>", nil)
+	require.NoError(t, configureTLS(server, config, newLoggerWithLevel(false, entities.LogLevelError)))
+	require.NotNil(t, server.TLSConfig)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = serveListener(server, listener) }()
+	defer server.Close()
+
+	// Trust the generated CA, as a user would after checking the fingerprint
+	caPEM, err := os.ReadFile(filepath.Join(config.Server.TLS.CADir, "ca.pem"))
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caPEM))
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+	}}
+
+	resp, err := client.Get("https://" + listener.Addr().String() + "/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "<html>slides</html>", string(body))
+	assert.Equal(t, "https", config.Server.Scheme())
+}
+
+func TestServeRetriesNextPort(t *testing.T) {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer taken.Close()
+	takenPort := taken.Addr().(*net.TCPAddr).Port
+	if takenPort+10 > 65535 {
+		t.Skip("no room above the ephemeral port to retry")
+	}
+
+	logger := newLoggerWithLevel(false, entities.LogLevelError)
+	start := func(config *entities.Config) (*http.Server, error) {
+		server := createHTTPServer(config, "", "<html>slides</html>", nil)
+		serverStarted := make(chan struct{})
+		serverErr := make(chan error, 1)
+		go startServerAsync(server, config, logger, serverStarted, serverErr)
+		return server, waitForServerStart(serverStarted, serverErr, config, logger)
+	}
+
+	t.Run("fails without retries", func(t *testing.T) {
+		config := &entities.Config{Server: entities.ServerConfig{Host: "127.0.0.1", Port: takenPort}}
+		_, err := start(config)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), fmt.Sprintf("port %d is already in use", takenPort))
+	})
+
+	t.Run("binds the next free port", func(t *testing.T) {
+		config := &entities.Config{Server: entities.ServerConfig{Host: "127.0.0.1", Port: takenPort, PortRetries: 10}}
+		server, err := start(config)
+		require.NoError(t, err)
+		defer server.Close()
+
+		assert.Greater(t, config.Server.Port, takenPort)
+		assert.LessOrEqual(t, config.Server.Port, takenPort+10)
+		assert.Equal(t, fmt.Sprintf("http://127.0.0.1:%d", config.Server.Port), config.Server.URL())
+
+		resp, err := http.Get(config.Server.URL() + "/")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "<html>slides</html>", string(body))
+	})
+}
+
+func TestServeOverUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "slicli.sock")
+
+	// A socket left behind by an earlier run is replaced
+	stale, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	config := &entities.Config{
+		Server:  entities.ServerConfig{Host: "unix:" + socket, Port: 3000},
+		Browser: entities.BrowserConfig{AutoOpen: true},
+	}
+	require.NoError(t, config.Server.Validate())
+
+	server := createHTTPServer(config, "", "<html>slides</html>", nil)
+	serverStarted := make(chan struct{})
+	serverErr := make(chan error, 1)
+	logger := newLoggerWithLevel(false, entities.LogLevelError)
+	go startServerAsync(server, config, logger, serverStarted, serverErr)
+	require.NoError(t, waitForServerStart(serverStarted, serverErr, config, logger))
+	defer server.Close()
+
+	info, err := os.Stat(socket)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), info.Mode().Perm())
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+		},
+	}}
+	resp, err := client.Get("http://slicli/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "<html>slides</html>", string(body))
+	assert.Equal(t, "unix:"+socket, config.Server.URL())
+
+	t.Run("refuses to replace a regular file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "notes.txt")
+		require.NoError(t, os.WriteFile(file, nil, 0600))
+
+		_, err := listenUnix(file)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a socket")
+	})
+}
+
+func TestProcessMarkdownToSlidesDrafts(t *testing.T) {
+	markdown := "# Title\n\n---\n\n<!-- draft -->\n# Work in progress\n\n---\n\n# Final"
+	config := &entities.Config{}
+
+	t.Run("excluded by default", func(t *testing.T) {
+		html := processMarkdownToSlides(markdown, "talk.md", config, false)
+		assert.NotContains(t, html, "Work in progress")
+		assert.Contains(t, html, `id="slide-2"`)
+		assert.NotContains(t, html, `id="slide-3"`)
+	})
+
+	t.Run("included and marked with the flag", func(t *testing.T) {
+		html := processMarkdownToSlides(markdown, "talk.md", config, true)
+		assert.Contains(t, html, "Work in progress")
+		assert.Contains(t, html, `data-draft="true"`)
+		assert.Contains(t, html, `id="slide-3"`)
+	})
+}
+
+func TestProcessMarkdownToSlidesHidesSpeakerNotes(t *testing.T) {
+	markdown := "# Title\n\n<!-- notes: greet the room -->\n\n---\n\n# Agenda\n\n???\nskip the history part"
+
+	html := processMarkdownToSlides(markdown, "talk.md", &entities.Config{}, false)
+	assert.Contains(t, html, "Agenda")
+	assert.NotContains(t, html, "greet the room")
+	assert.NotContains(t, html, "skip the history part")
+	assert.NotContains(t, html, "???")
+}
+
+func TestProcessMarkdownToSlidesSlideDirective(t *testing.T) {
+	markdown := "# Title\n\n---\n\n<!-- slide: bg=#000 layout=two-column class=dark speaker=\"Ada Lovelace\" -->\n# Results\n\nNumbers"
+
+	html := processMarkdownToSlides(markdown, "talk.md", &entities.Config{}, false)
+	assert.Contains(t, html, `<div class="slide dev-two-column dark" id="slide-2" style="background: #000" data-speaker="Ada Lovelace"`)
+	assert.NotContains(t, html, "<!-- slide:", "the directive isn't part of the slide's content")
+
+	t.Run("image backgrounds cover the slide", func(t *testing.T) {
+		html := processMarkdownToSlides("<!-- slide: bg=images/cover.jpg transition=fade -->\n# Cover", "talk.md", &entities.Config{}, false)
+		assert.Contains(t, html, `style="background-image: url(&#39;images/cover.jpg&#39;); background-size: cover; background-position: center"`)
+		assert.Contains(t, html, `data-transition="fade"`)
+
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "images"), 0750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "images", "cover.jpg"), []byte("\xff\xd8\xff\xe0 jpeg"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("SECRET=1"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "talk.md"), []byte("# Cover"), 0600))
+		outside := filepath.Join(t.TempDir(), "id_rsa")
+		require.NoError(t, os.WriteFile(outside, []byte("PRIVATE KEY"), 0600))
+		require.NoError(t, os.Symlink(outside, filepath.Join(dir, "images", "leak.png")))
+
+		page := processMarkdownToSlides("<!-- slide: bg=images/cover.jpg -->\n# Cover\n\n---\n\n![leak](images/leak.png)", "talk.md", &entities.Config{}, false)
+		handler := createHTTPServer(&entities.Config{}, dir, page, nil).Handler
+
+		get := func(target string) *httptest.ResponseRecorder {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+			return rec
+		}
+		rec := get("/images/cover.jpg")
+		require.Equal(t, http.StatusOK, rec.Code, "the relative path resolves against the presentation's directory")
+		assert.Equal(t, "image/jpeg", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "\xff\xd8\xff\xe0 jpeg", rec.Body.String())
+
+		assert.Equal(t, http.StatusOK, get("/").Code)
+		assert.Contains(t, get("/").Body.String(), "images/cover.jpg")
+		assert.Equal(t, http.StatusNotFound, get("/images/missing.jpg").Code, "missing files aren't answered with the deck")
+		assert.Equal(t, http.StatusNotFound, get("/.env").Code, "hidden files aren't served")
+		assert.Equal(t, http.StatusNotFound, get("/talk.md").Code, "files the slides don't reference aren't served")
+		assert.Equal(t, http.StatusNotFound, get("/images").Code)
+		assert.Equal(t, http.StatusNotFound, get("/images/leak.png").Code, "symlinks must stay inside the presentation's directory")
+	})
+
+	t.Run("malformed directives are ignored", func(t *testing.T) {
+		markdown := "<!-- slide: bg=red;color:blue class=\"ok <script>\" layout=Two_Col =x index=9 stray -->\n# Title"
+
+		html := processMarkdownToSlides(markdown, "talk.md", &entities.Config{}, false)
+		assert.Contains(t, html, `<div class="slide dev-title ok" id="slide-1" role="region"`)
+		assert.NotContains(t, html, "color:blue")
+		assert.Contains(t, html, `data-index="1">`)
+		assert.NotContains(t, html, `data-index="9"`)
+	})
+}
+
+func TestProcessMarkdownToSlidesCount(t *testing.T) {
+	markdown := "# One\n\n---\n\n# Two\n\n---\n\n# Three\n\n---\n\n# Four\n\n---\n\n# Five"
+
+	html := processMarkdownToSlides(markdown, "talk.md", &entities.Config{}, false)
+	assert.Contains(t, html, `<span id="total-slides">5</span>`)
+	assert.Contains(t, html, `id="slide-5"`)
+
+	t.Run("skipped drafts aren't counted", func(t *testing.T) {
+		html := processMarkdownToSlides("# One\n\n---\n\n<!-- draft -->\n# Two", "talk.md", &entities.Config{}, false)
+		assert.Contains(t, html, `<span id="total-slides">1</span>`)
+	})
+}
+
+func TestProcessMarkdownToSlidesAccessibleNavigation(t *testing.T) {
+	html := processMarkdownToSlides("# One\n\n---\n\n# Two", "talk.md", &entities.Config{}, false)
+
+	assert.Contains(t, html, `<nav class="navigation" aria-label="Slide navigation">`)
+	assert.Contains(t, html, `<button type="button" id="previous-slide" aria-label="Previous slide">`)
+	assert.Contains(t, html, `<button type="button" id="next-slide" aria-label="Next slide">`)
+	assert.Contains(t, html, `<span class="slide-counter" aria-live="polite" aria-atomic="true">`)
+
+	for _, n := range []string{"1", "2"} {
+		assert.Contains(t, html, `id="slide-`+n+`" role="region" aria-roledescription="slide" aria-label="Slide `+n+`" tabindex="-1" data-index="`+n+`">`)
+	}
+	assert.Contains(t, html, "activeSlide.focus(", "navigation moves focus to the shown slide")
+}
+
+func TestProcessMarkdownToDeckHeadingIDs(t *testing.T) {
+	markdown := "# Overview\n\nFirst part\n\n---\n\n# Details\n\n---\n\n# Overview\n\nSecond part\n\n---\n\n# Slide 1"
+
+	deck := processMarkdownToDeck(markdown, "talk.md", &entities.Config{}, false, nil)
+	assert.Contains(t, deck.HTML, `<h1 id="overview">Overview</h1>`)
+	assert.Contains(t, deck.HTML, `<h1 id="overview-1">Overview</h1>`)
+	assert.Contains(t, deck.HTML, `<h1 id="slide-1-1">Slide 1</h1>`, "headings can't take a slide's ID")
+	assert.Equal(t, map[string]int{"overview": 1, "details": 2, "overview-1": 3, "slide-1-1": 4}, deck.HeadingIDs)
+}
+
+func TestProcessMarkdownToDeckHeadingSlideIDs(t *testing.T) {
+	config := &entities.Config{Server: entities.ServerConfig{SlideIDs: entities.SlideIDsHeading}}
+	slidePattern := regexp.MustCompile(`(?s)<div class="slide [^"]*" id="([^"]*)"[^>]* data-index="(\d+)">(.*?)</div>`)
+	slideIDs := func(markdown string) [][]string {
+		deck := processMarkdownToDeck(markdown, "talk.md", config, false, nil)
+		var slides [][]string
+		for _, slide := range deck.Slides {
+			match := slidePattern.FindStringSubmatch(slide)
+			require.NotNil(t, match, slide)
+			slides = append(slides, match[1:])
+		}
+		return slides
+	}
+
+	markdown := "# Intro\n\nWelcome\n\n---\n\nNo heading here\n\n---\n\n# Intro\n\nAgain"
+	assert.Equal(t, [][]string{
+		{"intro", "1", "<h1>Intro</h1>\n<p>Welcome</p>\n"},
+		{"slide-2", "2", "<p>No heading here</p>\n"},
+		{"intro-1", "3", "<h1>Intro</h1>\n<p>Again</p>\n"},
+	}, slideIDs(markdown), "duplicate headings are suffixed and the heading gives up its ID")
+
+	inserted := slideIDs("# Agenda\n\n---\n\n" + markdown)
+	require.Len(t, inserted, 4)
+	assert.Equal(t, []string{"intro", "2", "<h1>Intro</h1>\n<p>Welcome</p>\n"}, inserted[1], "#intro still links to the same slide")
+	assert.Equal(t, "agenda", inserted[0][0])
+
+	t.Run("index by default", func(t *testing.T) {
+		html := processMarkdownToSlides(markdown, "talk.md", &entities.Config{}, false)
+		assert.Contains(t, html, `id="slide-1"`)
+		assert.Contains(t, html, `<h1 id="intro">Intro</h1>`)
+	})
+}
+
+func TestProcessMarkdownToDeckReusesUnchangedSlides(t *testing.T) {
+	sources := make([]string, 10)
+	for i := range sources {
+		sources[i] = fmt.Sprintf("# Part %d\n\nBody %d", i+1, i+1)
+	}
+	cache := newSlideCache()
+
+	first := processMarkdownToDeck(strings.Join(sources, "\n\n---\n\n"), "talk.md", &entities.Config{}, false, cache)
+	require.Len(t, first.Slides, 10)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, first.Changed)
+
+	sources[3] = "# Part 4\n\nEdited body"
+	second := processMarkdownToDeck(strings.Join(sources, "\n\n---\n\n"), "talk.md", &entities.Config{}, false, cache)
+	assert.Equal(t, []int{4}, second.Changed)
+	assert.Contains(t, second.Slides[3], "Edited body")
+	for i, slide := range second.Slides {
+		if i != 3 {
+			assert.Equal(t, first.Slides[i], slide)
+		}
+	}
+	assert.Equal(t, first.HeadingIDs, second.HeadingIDs)
+
+	t.Run("re-renders slides whose heading IDs moved", func(t *testing.T) {
+		cache := newSlideCache()
+		processMarkdownToDeck("# Overview\n\n---\n\n# Middle\n\n---\n\n# Overview", "talk.md", &entities.Config{}, false, cache)
+
+		deck := processMarkdownToDeck("# Intro\n\n---\n\n# Middle\n\n---\n\n# Overview", "talk.md", &entities.Config{}, false, cache)
+		assert.Equal(t, []int{1, 3}, deck.Changed)
+		assert.Contains(t, deck.Slides[2], `<h1 id="overview">Overview</h1>`)
+	})
+}
+
+func TestProcessMarkdownToSlidesTransitions(t *testing.T) {
+	t.Run("theme animation by default", func(t *testing.T) {
+		html := processMarkdownToSlides("# One\n\n---\n\n# Two", "talk.md", &entities.Config{}, false)
+		assert.NotContains(t, html, ".slide.transition-")
+		assert.NotContains(t, html, "data-transition=")
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		config := &entities.Config{Theme: entities.ThemeConfig{Transition: "fade"}}
+		html := processMarkdownToSlides("# One\n\n---\n\n# Two", "talk.md", config, false)
+		assert.Contains(t, html, ".slide.transition-fade {")
+		assert.Contains(t, html, "@keyframes slicli-fade")
+		assert.Contains(t, html, `<body class="theme-default presentation" data-transition="fade">`)
+		assert.NotContains(t, html, ".slide.transition-slide {")
+	})
+
+	t.Run("front matter and slide overrides", func(t *testing.T) {
+		config := &entities.Config{Theme: entities.ThemeConfig{Transition: "fade"}}
+		markdown := "---\ntitle: Talk\ntransition: slide\n---\n# One\n\n---\n\n<!-- transition: none -->\n# Two"
+		html := processMarkdownToSlides(markdown, "talk.md", config, false)
+		assert.Contains(t, html, `data-transition="slide">`)
+		assert.Contains(t, html, ".slide.transition-slide {")
+		assert.Contains(t, html, ".slide.transition-none {")
+		assert.NotContains(t, html, ".slide.transition-fade {")
+		assert.Regexp(t, `<div class="slide [^"]*" id="slide-\d+"[^>]* data-transition="none"[^>]*>`, html)
+	})
+
+	t.Run("toml front matter", func(t *testing.T) {
+		html := processMarkdownToSlides("+++\ntitle = \"Talk\"\ntransition = \"slide\"\n+++\n# One", "talk.md", &entities.Config{}, false)
+		assert.Contains(t, html, `data-transition="slide">`)
+		assert.Contains(t, html, ".slide.transition-slide {")
+	})
+}
+
+func TestCreateHTTPServerReadOnly(t *testing.T) {
+	config := &entities.Config{Server: entities.ServerConfig{ReadOnly: true}}
+	handler := createHTTPServer(config, "", "<html>slides</html>", nil).Handler
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "<html>slides</html>", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestCreateHTTPServerPrintMode(t *testing.T) {
+	config := &entities.Config{}
+	page := processMarkdownToSlides("# One\n\n---\n\n# Two\n\n---\n\n# Three", "talk.md", config, false)
+	handler := createHTTPServer(config, "", page, nil).Handler
+
+	get := func(target string) string {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	hiddenSlides := regexp.MustCompile(`\.slide[^{]*\{[^}]*display: none !important`)
+	assert.Regexp(t, hiddenSlides, get("/"))
+
+	printed := get("/?print=true")
+	assert.NotRegexp(t, hiddenSlides, printed, "no slide is hidden")
+	assert.NotContains(t, printed, ".slide:first-child")
+	assert.Contains(t, printed, "page-break-after: always")
+	for _, id := range []string{"slide-1", "slide-2", "slide-3"} {
+		assert.Contains(t, printed, `id="`+id+`"`)
+	}
+	assert.Contains(t, printed, "if (!printMode) showSlide(hashSlide() || 1);", "the script leaves the slides visible")
+}
+
+func TestGeneratePresentationHTMLOffline(t *testing.T) {
+	markdown := "# Diagram\n\n```mermaid\ngraph TD; A-->B\n```\n\n---\n\n# Code\n\n```go\nfmt.Println(1)\n```"
+
+	online := processMarkdownToSlides(markdown, "talk.md", &entities.Config{}, false)
+	assert.Contains(t, online, "cdn.jsdelivr.net")
+
+	config := &entities.Config{Server: entities.ServerConfig{Offline: true}}
+	page := processMarkdownToSlides(markdown, "talk.md", config, false)
+	assert.NotContains(t, page, "cdn.jsdelivr.net")
+	assert.NotContains(t, page, "unpkg.com")
+	assert.Contains(t, page, `<script src="/assets/vendor/mermaid/mermaid.min.js"></script>`)
+	assert.Contains(t, page, `languages_path = '/assets/vendor/prismjs/components/'`)
+
+	t.Run("vendored files are served from the binary", func(t *testing.T) {
+		handler := createHTTPServer(config, "", page, nil).Handler
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/assets/vendor/README.md", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "make vendor-assets")
+	})
+
+	t.Run("needs a build with the vendored files", func(t *testing.T) {
+		config := &entities.Config{Server: entities.ServerConfig{Host: "localhost", Port: 3000, Offline: true}}
+		err := validateServeConfig(config)
+		if len(web.MissingVendorFiles()) == 0 {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorContains(t, err, "run make vendor-assets and rebuild")
+		}
+	})
+}
+
+func TestAssetsHandlerConditionalGet(t *testing.T) {
+	handler := createAssetsHandler()
+
+	for _, path := range []string{"/assets/style.css", "/assets/script.js"} {
+		t.Run(path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest(http.MethodGet, path, nil))
+			require.Equal(t, http.StatusOK, w.Code)
+			etag := w.Header().Get("ETag")
+			require.Regexp(t, `^"[0-9a-f]{16}"$`, etag)
+			assert.NotEmpty(t, w.Body.String())
+
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("If-None-Match", etag)
+			w = httptest.NewRecorder()
+			handler(w, req)
+			assert.Equal(t, http.StatusNotModified, w.Code)
+			assert.Empty(t, w.Body.String())
+
+			req = httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set("If-None-Match", `"stale"`)
+			w = httptest.NewRecorder()
+			handler(w, req)
+			assert.Equal(t, http.StatusOK, w.Code)
+		})
+	}
+}
+
+func TestProcessMarkdownToDeckFrontMatter(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		deck := processMarkdownToDeck("---\ntitle: Go at Scale\nauthor: Ada\ntags: [go]\n---\n# Intro\n\n---\n\n# Next", "talk.md", nil, false, nil)
+		assert.Len(t, deck.Slides, 2, "front matter is not a slide")
+		assert.Equal(t, "Go at Scale", deck.Presentation.Title)
+		assert.Equal(t, "Ada", deck.Presentation.Author)
+		assert.Equal(t, []string{"go"}, deck.Presentation.Tags)
+		assert.Contains(t, deck.HTML, "<title>Go at Scale</title>")
+		assert.NotContains(t, deck.HTML, "author: Ada")
+	})
+
+	t.Run("toml", func(t *testing.T) {
+		deck := processMarkdownToDeck("+++\ntitle = \"Q&A\"\nauthor = \"Ada\"\n+++\n# Intro", "talk.md", nil, false, nil)
+		assert.Len(t, deck.Slides, 1)
+		assert.Equal(t, "Q&A", deck.Presentation.Title)
+		assert.Contains(t, deck.HTML, "<title>Q&amp;A</title>")
+	})
+
+	t.Run("first H1 without front matter", func(t *testing.T) {
+		deck := processMarkdownToDeck("Opening words\n\n```md\n# Not a title\n```\n\n---\n\n# Real Title #\n\n---\n\n# Later", "talk.md", nil, false, nil)
+		assert.Equal(t, "Real Title", deck.Presentation.Title)
+		assert.Empty(t, deck.Presentation.Author)
+	})
+}
+
+func TestLoadPresentationDeckIncludes(t *testing.T) {
+	dir := writeThemeFiles(t, map[string]string{
+		"talk.md":             "# Welcome\n\n---\n\n@include sections/intro.md\n\n---\n\n# Thanks\n",
+		"sections/intro.md":   "# Intro\n\n---\n\n@include details.md\n",
+		"sections/details.md": "# Details\n",
+	})
+	dir, err := filepath.EvalSymlinks(dir) // Sources are real paths
+	require.NoError(t, err)
+	path := filepath.Join(dir, "talk.md")
+
+	deck, err := loadPresentationDeck(path, &entities.Config{}, nil)
+	require.NoError(t, err)
+	require.Len(t, deck.Slides, 4, "included slides merge in order")
+	for i, title := range []string{"Welcome", "Intro", "Details", "Thanks"} {
+		assert.Contains(t, deck.Slides[i], ">"+title+"</h1>")
+	}
+	assert.Equal(t, []string{path, filepath.Join(dir, "sections", "intro.md"), filepath.Join(dir, "sections", "details.md")}, deck.Sources)
+}
+
+func TestProcessMarkdownToDeckTableOfContents(t *testing.T) {
+	markdown := "---\ntoc: true\n---\n# Welcome\n\n---\n\n<!-- draft -->\n# Unfinished\n\n---\n\n# Setup\n\n---\n\nNo heading"
+
+	deck := processMarkdownToDeck(markdown, "talk.md", nil, false, nil)
+	require.Len(t, deck.Slides, 4)
+	assert.Contains(t, deck.Slides[1], "Contents")
+	assert.Contains(t, deck.Slides[1], "<li>Setup</li>")
+	assert.Contains(t, deck.Slides[1], "<li>Slide 4</li>", "fallback labels count the contents slide")
+	assert.NotContains(t, deck.Slides[1], "Unfinished", "drafts left out of the deck aren't listed")
+	assert.Equal(t, "Welcome", deck.Presentation.Title)
+
+	deck = processMarkdownToDeck("# Welcome\n\n---\n\n# Setup", "talk.md", nil, false, nil)
+	assert.Len(t, deck.Slides, 2, "no contents slide without toc: true")
+}
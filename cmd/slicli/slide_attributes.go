@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// reservedSlideData are the data attributes slicli sets on slides itself,
+// which a <!-- slide: --> comment can't replace
+var reservedSlideData = map[string]bool{
+	"draft":      true,
+	"index":      true,
+	"prefetch":   true,
+	"transition": true,
+}
+
+// slideDirectiveAttrs returns the inline background and data attributes a
+// slide's <!-- slide: --> comment sets
+func slideDirectiveAttrs(attributes entities.SlideAttributes) string {
+	var attrs strings.Builder
+	if style := slideBackgroundStyle(attributes.Background); style != "" {
+		fmt.Fprintf(&attrs, ` style="%s"`, html.EscapeString(style))
+	}
+
+	keys := make([]string, 0, len(attributes.Data))
+	for key := range attributes.Data {
+		if !reservedSlideData[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&attrs, ` data-%s="%s"`, key, html.EscapeString(attributes.Data[key]))
+	}
+	return attrs.String()
+}
+
+// slideBackgroundStyle turns a slide's background into CSS. Paths and URLs
+// to images cover the slide; colors and gradients are used as they are.
+func slideBackgroundStyle(background string) string {
+	if background == "" {
+		return ""
+	}
+	if !strings.Contains(background, "(") && strings.ContainsAny(background, "./") {
+		return fmt.Sprintf("background-image: url('%s'); background-size: cover; background-position: center", background)
+	}
+	return "background: " + background
+}
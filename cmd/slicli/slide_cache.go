@@ -0,0 +1,65 @@
+package main
+
+// slideCache keeps the slides of the last render by position, so rendering
+// an edited deck again only converts the slides whose source changed. It is
+// not safe for concurrent use.
+type slideCache struct {
+	slides []cachedSlide
+
+	// settings fingerprints what else the slides were rendered with
+	settings string
+}
+
+// cachedSlide is a rendered slide with what it was rendered from
+type cachedSlide struct {
+	source   string
+	html     string
+	headings []headingID
+}
+
+// newSlideCache creates an empty slide cache
+func newSlideCache() *slideCache {
+	return &slideCache{}
+}
+
+// prepare drops the cached slides when settings, a fingerprint of what
+// slides are rendered with besides their source, differ from the last render
+func (c *slideCache) prepare(settings string) {
+	if c != nil && c.settings != settings {
+		c.slides = nil
+		c.settings = settings
+	}
+}
+
+// reuse returns the HTML of the 1-based slide when its source is unchanged
+// and its headings can keep their IDs, registering those IDs with ids
+func (c *slideCache) reuse(slide int, source string, ids *deckHeadingIDs) (string, bool) {
+	if c == nil || slide > len(c.slides) {
+		return "", false
+	}
+	cached := c.slides[slide-1]
+	if cached.source != source || !ids.replay(slide, cached.headings) {
+		return "", false
+	}
+	return cached.html, true
+}
+
+// store keeps a freshly rendered slide in place of the one at its position
+func (c *slideCache) store(slide int, source, html string, headings []headingID) {
+	if c == nil {
+		return
+	}
+	entry := cachedSlide{source: source, html: html, headings: headings}
+	if slide > len(c.slides) {
+		c.slides = append(c.slides, entry)
+		return
+	}
+	c.slides[slide-1] = entry
+}
+
+// truncate drops the slides past the end of a deck that got shorter
+func (c *slideCache) truncate(count int) {
+	if c != nil && count < len(c.slides) {
+		c.slides = c.slides[:count]
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/BurntSushi/toml"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/theme"
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var themesCmd = &cobra.Command{
+	Use:   "themes",
+	Short: "Manage presentation themes",
+}
+
+var themesVarsCmd = &cobra.Command{
+	Use:   "vars <name>",
+	Short: "List the CSS variables a theme supports",
+	Long: `List the CSS custom properties a theme declares in :root or reads
+through var(), with their default values. Any of them can be overridden
+under [theme.variables] in the global config or a presentation's slicli.toml.
+
+References that can't be resolved, because the variable is undefined, part
+of a cycle, or nested deeper than --max-depth, are reported as warnings.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runThemesVars,
+}
+
+var (
+	themesFormat   string
+	themesMaxDepth int
+)
+
+func init() {
+	themesVarsCmd.Flags().StringVar(&themesFormat, "format", "table", "Output format: table, json")
+	themesVarsCmd.Flags().IntVar(&themesMaxDepth, "max-depth", theme.DefaultMaxVariableDepth, "Levels of var() nesting to resolve")
+	themesCmd.AddCommand(themesVarsCmd)
+	rootCmd.AddCommand(themesCmd)
+}
+
+func runThemesVars(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid theme name: %s", name)
+	}
+
+	for _, dir := range themeSearchPaths(name) {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		variables, warnings, err := inspectThemeVariables(dir, themesMaxDepth)
+		if err != nil {
+			return err
+		}
+		for _, w := range warnings {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", w)
+		}
+		return printThemeVariables(cmd.OutOrStdout(), variables, themesFormat)
+	}
+	return fmt.Errorf("theme '%s' not found", name)
+}
+
+// inspectThemeVariables reads every stylesheet in a theme directory along
+// with the variables from its theme.toml and the colors of its theme.json, lists the custom properties found
+// and reports the references that don't resolve within maxDepth levels
+func inspectThemeVariables(dir string, maxDepth int) ([]entities.ThemeVariable, []theme.VariableWarning, error) {
+	var css strings.Builder
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".css" {
+			return nil
+		}
+		content, err := os.ReadFile(path) // #nosec G304 - path from the theme directory
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		css.Write(content)
+		css.WriteString("\n")
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading theme stylesheets: %w", err)
+	}
+
+	var config struct {
+		Variables map[string]string `toml:"variables"`
+	}
+	if _, err := toml.DecodeFile(filepath.Join(dir, "theme.toml"), &config); err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("reading theme.toml: %w", err)
+	}
+
+	// An unreadable theme.json is one of the problems themes validate lists
+	if colors, err := theme.ColorSchemeVariables(dir); err == nil && len(colors) > 0 {
+		for name, value := range config.Variables {
+			colors[name] = value
+		}
+		config.Variables = colors
+	}
+
+	processor := theme.NewAssetProcessor(false)
+	processor.SetMaxVariableDepth(maxDepth)
+	content := []byte(css.String())
+	return processor.InspectVariables(content, config.Variables), processor.CheckVariables(content, config.Variables), nil
+}
+
+// themeStylesheet returns the stylesheet at path, from the theme in dir,
+// with the colors of the theme's theme.json substituted for the var()
+// references to them. Variables configured for the presentation override
+// the theme's colors, resolving up to maxDepth levels of var() nesting. ok
+// is false when the theme declares no colors and the file is served as is.
+func themeStylesheet(dir, path string, overrides map[string]string, maxDepth int) (css []byte, ok bool, err error) {
+	variables, err := theme.ColorSchemeVariables(dir)
+	if err != nil || len(variables) == 0 {
+		return nil, false, err
+	}
+	for name, value := range overrides {
+		variables[name] = value
+	}
+
+	content, err := os.ReadFile(path) // #nosec G304 - path found under a theme directory
+	if err != nil {
+		return nil, false, fmt.Errorf("reading stylesheet: %w", err)
+	}
+	processor := theme.NewAssetProcessor(false)
+	processor.SetMaxVariableDepth(maxDepth)
+	if content, err = processor.ResolveImports(content, filepath.Dir(path), filepath.Dir(dir)); err != nil {
+		return nil, false, err
+	}
+	if css, err = processor.ProcessCSS(content, variables); err != nil {
+		return nil, false, err
+	}
+	return css, true, nil
+}
+
+// themeVariablesStyle renders configured variable overrides as a :root rule
+// placed after the theme stylesheet, so they win over the theme's defaults
+func themeVariablesStyle(variables map[string]string) string {
+	if len(variables) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("\n    <style>:root {")
+	for _, name := range names {
+		fmt.Fprintf(&b, " --%s: %s;", name, variables[name])
+	}
+	b.WriteString(" }</style>")
+	return b.String()
+}
+
+func printThemeVariables(w io.Writer, variables []entities.ThemeVariable, format string) error {
+	if format == "json" {
+		data, err := json.MarshalIndent(variables, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding variables: %w", err)
+		}
+		_, _ = fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "VARIABLE\tDEFAULT\tNOTE")
+	for _, v := range variables {
+		note := ""
+		switch {
+		case !v.Declared:
+			note = "not declared by the theme"
+		case !v.Used:
+			note = "not used by the stylesheets"
+		}
+		defaultValue := v.Default
+		if defaultValue == "" {
+			defaultValue = "-"
+		}
+		_, _ = fmt.Fprintf(tw, "--%s\t%s\t%s\n", v.Name, defaultValue, note)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/config"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/theme"
+)
+
+var themesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List themes available in the marketplace",
+	Long: `List the themes published in the theme marketplace with their category
+and rating. Use --category to show a single category.`,
+	Args: cobra.NoArgs,
+	RunE: runThemesList,
+}
+
+var themesInstallCmd = &cobra.Command{
+	Use:   "install <id>",
+	Short: "Install a theme from the marketplace",
+	Long: `Download a theme from the marketplace into the user themes directory,
+where presentations can use it by name.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runThemesInstall,
+}
+
+var themesRemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Remove an installed marketplace theme",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runThemesRemove,
+}
+
+// themeCategories are the categories accepted by --category
+var themeCategories = []theme.ThemeCategory{
+	theme.CategoryCorporate,
+	theme.CategoryEducational,
+	theme.CategoryConference,
+	theme.CategoryTechnical,
+	theme.CategoryCreative,
+	theme.CategoryMinimal,
+	theme.CategoryDark,
+}
+
+var (
+	themesCategory        string
+	themesAllowUnverified bool
+)
+
+func init() {
+	themesListCmd.Flags().StringVar(&themesCategory, "category", "", "Only list themes in this category")
+	themesInstallCmd.Flags().BoolVar(&themesAllowUnverified, "allow-unverified", false,
+		"Install themes the marketplace publishes no sha256 for")
+	themesCmd.AddCommand(themesListCmd)
+	themesCmd.AddCommand(themesInstallCmd)
+	themesCmd.AddCommand(themesRemoveCmd)
+}
+
+// newThemeManager creates a theme manager for the marketplace in the global config
+func newThemeManager() (*theme.PremiumThemeManager, error) {
+	appConfig := config.GetDefaultConfig()
+	if globalConfig, err := config.NewTOMLLoader().LoadGlobal(context.Background()); err == nil && globalConfig != nil {
+		if globalConfig.Plugins.MarketplaceURL != "" {
+			appConfig.Plugins.MarketplaceURL = globalConfig.Plugins.MarketplaceURL
+		}
+		appConfig.Plugins.MarketplaceAPIKey = globalConfig.Plugins.MarketplaceAPIKey
+		appConfig.Plugins.ThemePublicKey = globalConfig.Plugins.ThemePublicKey
+	}
+
+	publicKey, err := appConfig.Plugins.GetThemePublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return theme.NewPremiumThemeManager(theme.PremiumThemeConfig{
+		BaseURL:         strings.TrimRight(appConfig.Plugins.GetMarketplaceURL(), "/"),
+		APIKey:          appConfig.Plugins.GetMarketplaceAPIKey(),
+		UserID:          getUserID(),
+		ThemesDir:       theme.UserThemesDirectory(),
+		PublicKey:       publicKey,
+		AllowUnverified: themesAllowUnverified,
+	}), nil
+}
+
+// marketplaceError explains failures to reach the marketplace at all, which
+// otherwise surface as a raw dial error
+func marketplaceError(action string, err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s: cannot reach the theme marketplace at %s; check your connection or marketplace_url under [plugins]: %w",
+			action, marketplaceHost(urlErr.URL), err)
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
+// marketplaceHost returns the scheme and host of a marketplace request URL
+func marketplaceHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+// validateThemeID rejects IDs that would escape the themes directory
+func validateThemeID(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return fmt.Errorf("invalid theme id: %s", id)
+	}
+	return nil
+}
+
+func runThemesList(cmd *cobra.Command, args []string) error {
+	category := theme.ThemeCategory(strings.ToLower(themesCategory))
+	if category != "" && !isThemeCategory(category) {
+		names := make([]string, len(themeCategories))
+		for i, c := range themeCategories {
+			names[i] = string(c)
+		}
+		return fmt.Errorf("unknown category %q (must be one of: %s)", themesCategory, strings.Join(names, ", "))
+	}
+
+	manager, err := newThemeManager()
+	if err != nil {
+		return err
+	}
+	themes, err := manager.ListThemes(category, false)
+	if err != nil {
+		return marketplaceError("failed to list themes", err)
+	}
+	if len(themes) == 0 {
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), "No themes found matching criteria.")
+		return nil
+	}
+	return printThemesTable(cmd.OutOrStdout(), themes)
+}
+
+func isThemeCategory(category theme.ThemeCategory) bool {
+	for _, c := range themeCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+func printThemesTable(w io.Writer, themes []*theme.PremiumTheme) error {
+	sort.Slice(themes, func(i, j int) bool { return themes[i].Name < themes[j].Name })
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNAME\tCATEGORY\tRATING")
+	for _, t := range themes {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%.1f\n", t.ID, t.Name, t.Category, t.Rating)
+	}
+	return tw.Flush()
+}
+
+func runThemesInstall(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	if err := validateThemeID(id); err != nil {
+		return err
+	}
+
+	manager, err := newThemeManager()
+	if err != nil {
+		return err
+	}
+	if manager.IsThemeInstalled(id) {
+		return fmt.Errorf("theme '%s' is already installed; remove it first to reinstall", id)
+	}
+	if err := manager.DownloadTheme(id, getUserID()); err != nil {
+		if errors.Is(err, theme.ErrChecksumMissing) {
+			return fmt.Errorf("failed to install theme '%s': %w; pass --allow-unverified to install it anyway", id, err)
+		}
+		return marketplaceError(fmt.Sprintf("failed to install theme '%s'", id), err)
+	}
+
+	path, _ := manager.GetLocalThemePath(id)
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Installed theme '%s' to %s\n", id, path)
+	return nil
+}
+
+func runThemesRemove(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	if err := validateThemeID(id); err != nil {
+		return err
+	}
+
+	manager, err := newThemeManager()
+	if err != nil {
+		return err
+	}
+	if !manager.IsThemeInstalled(id) {
+		return fmt.Errorf("theme '%s' is not installed", id)
+	}
+	if err := manager.RemoveTheme(id); err != nil {
+		return fmt.Errorf("failed to remove theme '%s': %w", id, err)
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Removed theme '%s'\n", id)
+	return nil
+}
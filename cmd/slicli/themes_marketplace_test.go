@@ -0,0 +1,174 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/theme"
+)
+
+// themeMarketplace serves a small theme catalog, recording the API key sent
+func themeMarketplace(t *testing.T) (*httptest.Server, *string) {
+	catalog := []*theme.PremiumTheme{
+		{ID: "boardroom", Name: "Boardroom", Category: theme.CategoryCorporate, Rating: 4.5},
+		{ID: "midnight", Name: "Midnight", Category: theme.CategoryDark, Rating: 4.8},
+	}
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	w, err := writer.Create("style.css")
+	require.NoError(t, err)
+	_, err = w.Write([]byte("body { background: #000; }"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	sum := sha256.Sum256(archive.Bytes())
+	catalog[1].Checksum = hex.EncodeToString(sum[:])
+
+	authorization := new(string)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/themes", func(w http.ResponseWriter, r *http.Request) {
+		*authorization = r.Header.Get("Authorization")
+		var themes []*theme.PremiumTheme
+		for _, t := range catalog {
+			if category := r.URL.Query().Get("category"); category == "" || string(t.Category) == category {
+				themes = append(themes, t)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(themes)
+	})
+	mux.HandleFunc("/api/v1/themes/midnight", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(catalog[1])
+	})
+	mux.HandleFunc("/api/v1/themes/boardroom", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(catalog[0])
+	})
+	for _, id := range []string{"boardroom", "midnight"} {
+		mux.HandleFunc("/api/v1/themes/"+id+"/download", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(archive.Bytes())
+		})
+	}
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, authorization
+}
+
+// isolateThemeConfig points the global config and themes directory at a
+// temporary home and the marketplace at url
+func isolateThemeConfig(t *testing.T, url string) string {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("SLICLI_MARKETPLACE_URL", url)
+	t.Setenv("SLICLI_API_KEY", "")
+	return home
+}
+
+// runThemesCommand runs a themes subcommand, returning its output
+func runThemesCommand(run func(*cobra.Command, []string) error, args ...string) (string, error) {
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	err := run(cmd, args)
+	return out.String(), err
+}
+
+func TestThemesList(t *testing.T) {
+	server, authorization := themeMarketplace(t)
+	isolateThemeConfig(t, server.URL)
+	t.Setenv("SLICLI_API_KEY", "secret")
+
+	output, err := runThemesCommand(runThemesList)
+	require.NoError(t, err)
+	assert.Contains(t, output, "ID")
+	assert.Regexp(t, `boardroom\s+Boardroom\s+corporate\s+4\.5`, output)
+	assert.Regexp(t, `midnight\s+Midnight\s+dark\s+4\.8`, output)
+	assert.Equal(t, "Bearer secret", *authorization)
+
+	t.Run("category filter", func(t *testing.T) {
+		themesCategory = "Dark"
+		defer func() { themesCategory = "" }()
+
+		output, err := runThemesCommand(runThemesList)
+		require.NoError(t, err)
+		assert.Contains(t, output, "midnight")
+		assert.NotContains(t, output, "boardroom")
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		themesCategory = "neon"
+		defer func() { themesCategory = "" }()
+
+		_, err := runThemesCommand(runThemesList)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be one of: corporate,")
+	})
+}
+
+func TestThemesListOffline(t *testing.T) {
+	server, _ := themeMarketplace(t)
+	server.Close()
+	isolateThemeConfig(t, server.URL)
+
+	_, err := runThemesCommand(runThemesList)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot reach the theme marketplace at "+server.URL)
+}
+
+func TestThemesInstallAndRemove(t *testing.T) {
+	server, _ := themeMarketplace(t)
+	home := isolateThemeConfig(t, server.URL)
+	themeDir := filepath.Join(home, ".config", "slicli", "themes", "midnight")
+
+	output, err := runThemesCommand(runThemesInstall, "midnight")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Installed theme 'midnight'")
+	assert.FileExists(t, filepath.Join(themeDir, "style.css"))
+	assert.Contains(t, themeSearchPaths("midnight/style.css"), filepath.Join(themeDir, "style.css"), "installed themes are served")
+
+	_, err = runThemesCommand(runThemesInstall, "midnight")
+	assert.ErrorContains(t, err, "already installed")
+
+	output, err = runThemesCommand(runThemesRemove, "midnight")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Removed theme 'midnight'")
+	assert.NoDirExists(t, themeDir)
+
+	_, err = runThemesCommand(runThemesRemove, "midnight")
+	assert.ErrorContains(t, err, "not installed")
+
+	_, err = runThemesCommand(runThemesInstall, "unknown")
+	assert.ErrorContains(t, err, "failed to install theme 'unknown'")
+
+	_, err = runThemesCommand(runThemesRemove, "../config.toml")
+	assert.ErrorContains(t, err, "invalid theme id")
+}
+
+func TestThemesInstallUnverified(t *testing.T) {
+	server, _ := themeMarketplace(t)
+	home := isolateThemeConfig(t, server.URL)
+
+	// boardroom is published without a sha256
+	_, err := runThemesCommand(runThemesInstall, "boardroom")
+	require.ErrorIs(t, err, theme.ErrChecksumMissing)
+	assert.ErrorContains(t, err, "--allow-unverified")
+	assert.NoDirExists(t, filepath.Join(home, ".config", "slicli", "themes", "boardroom"))
+
+	themesAllowUnverified = true
+	defer func() { themesAllowUnverified = false }()
+	_, err = runThemesCommand(runThemesInstall, "boardroom")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(home, ".config", "slicli", "themes", "boardroom", "style.css"))
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestInspectThemeVariables(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "assets", "css"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assets", "css", "variables.css"),
+		[]byte(":root {\n  --primary-color: #2563eb;\n  --font-size-base: 20px;\n}\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "style.css"),
+		[]byte("h1 { color: var(--primary-color); font-size: var(--heading-size, 2.5rem); }\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "theme.toml"),
+		[]byte("name = \"sample\"\n\n[variables]\nfont-size-base = \"22px\"\n"), 0600))
+
+	variables, warnings, err := inspectThemeVariables(dir, 0)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, []entities.ThemeVariable{
+		{Name: "font-size-base", Default: "22px", Declared: true},
+		{Name: "heading-size", Default: "2.5rem", Used: true},
+		{Name: "primary-color", Default: "#2563eb", Declared: true, Used: true},
+	}, variables)
+
+	var out bytes.Buffer
+	require.NoError(t, printThemeVariables(&out, variables, "table"))
+	assert.Contains(t, out.String(), "VARIABLE")
+	assert.Regexp(t, `--primary-color\s+#2563eb`, out.String())
+	assert.Regexp(t, `--heading-size\s+2\.5rem\s+not declared by the theme`, out.String())
+	assert.Regexp(t, `--font-size-base\s+22px\s+not used by the stylesheets`, out.String())
+
+	out.Reset()
+	require.NoError(t, printThemeVariables(&out, variables, "json"))
+	var decoded []entities.ThemeVariable
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, variables, decoded)
+}
+
+func TestThemeVariablesStyle(t *testing.T) {
+	assert.Empty(t, themeVariablesStyle(nil))
+
+	style := themeVariablesStyle(map[string]string{"primary-color": "#222", "font-size-base": "22px"})
+	assert.Contains(t, style, "<style>:root { --font-size-base: 22px; --primary-color: #222; }</style>")
+
+	config := &entities.Config{Theme: entities.ThemeConfig{
+		Name:      "default",
+		Variables: map[string]string{"primary-color": "#222"},
+	}}
+	page := generatePresentationHTML("", 0, "", "talk.md", config, slideTransitions{})
+	assert.Less(t, bytes.Index([]byte(page), []byte("/themes/default/style.css")), bytes.Index([]byte(page), []byte("--primary-color: #222")),
+		"overrides must follow the theme stylesheet")
+}
+
+func TestInspectThemeVariablesWarnings(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "style.css"),
+		[]byte(":root { --a: var(--b); --b: var(--a); }\nbody { color: var(--a); background: var(--missing); }\n"), 0600))
+
+	_, warnings, err := inspectThemeVariables(dir, 0)
+	require.NoError(t, err)
+
+	reported := make([]string, len(warnings))
+	for i, w := range warnings {
+		reported[i] = w.String()
+	}
+	assert.Contains(t, reported, "--a: cycle --a -> --b -> --a")
+	assert.Contains(t, reported, "--missing: not defined")
+}
+
+func TestThemeStylesheetColorScheme(t *testing.T) {
+	dir := t.TempDir()
+	stylesheet := filepath.Join(dir, "style.css")
+	require.NoError(t, os.WriteFile(stylesheet,
+		[]byte("h1 { color: var(--color-primary); background: var(--color-background); }\n"), 0600))
+
+	_, ok, err := themeStylesheet(dir, stylesheet, nil, 0)
+	require.NoError(t, err)
+	assert.False(t, ok, "themes without a theme.json are served as is")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "theme.json"),
+		[]byte(`{"name": "Midnight", "colors": {"primary": "#0b5fff", "background": "#0f172a"}}`), 0600))
+	css, ok, err := themeStylesheet(dir, stylesheet, map[string]string{"color-background": "#000"}, 0)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "h1 { color: #0b5fff; background: #000; }\n", string(css))
+
+	_, warnings, err := inspectThemeVariables(dir, 0)
+	require.NoError(t, err)
+	assert.Empty(t, warnings, "colors from theme.json are declared")
+
+	// Configured variables can reference others, resolved to the configured depth
+	overrides := map[string]string{"color-background": "var(--shade)", "shade": "var(--color-primary)"}
+	css, _, err = themeStylesheet(dir, stylesheet, overrides, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "h1 { color: #0b5fff; background: #0b5fff; }\n", string(css))
+	css, _, err = themeStylesheet(dir, stylesheet, overrides, 1)
+	require.NoError(t, err)
+	assert.NotContains(t, string(css), "background: #0b5fff", "nesting past max_variable_depth isn't resolved")
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/theme"
+)
+
+var themesValidateCmd = &cobra.Command{
+	Use:   "validate <path>",
+	Short: "Check a theme directory for missing files and undefined variables",
+	Long: `Check a theme directory before using or publishing it. The theme must
+have a style.css, and a theme.json, when present, must be valid JSON.
+
+Every stylesheet's local @import targets must exist, and every var()
+reference without a fallback must be defined in a stylesheet or under
+[variables] in theme.toml. Each problem found is listed and the command
+exits with an error.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runThemesValidate,
+}
+
+func init() {
+	themesCmd.AddCommand(themesValidateCmd)
+}
+
+func runThemesValidate(cmd *cobra.Command, args []string) error {
+	dir := args[0]
+	problems, err := validateTheme(dir)
+	if err != nil {
+		return err
+	}
+
+	printThemeProblems(cmd.OutOrStdout(), dir, problems)
+	if len(problems) > 0 {
+		return fmt.Errorf("%s failed validation with %d problems", dir, len(problems))
+	}
+	return nil
+}
+
+// validateTheme lists the problems of the theme in dir. Errors are reserved
+// for themes that can't be read at all.
+func validateTheme(dir string) ([]string, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading theme: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a theme directory", dir)
+	}
+
+	var problems []string
+	if _, err := os.Stat(filepath.Join(dir, "style.css")); err != nil {
+		problems = append(problems, "missing required file style.css")
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "theme.json")); err == nil { // #nosec G304 - path inside the theme directory
+		if !json.Valid(data) {
+			problems = append(problems, "theme.json is not valid JSON")
+		}
+	}
+
+	importProblems, err := checkThemeImports(dir)
+	if err != nil {
+		return nil, err
+	}
+	problems = append(problems, importProblems...)
+
+	_, warnings, err := inspectThemeVariables(dir, theme.DefaultMaxVariableDepth)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range warnings {
+		problems = append(problems, fmt.Sprintf("var(--%s) can't be resolved: %s", w.Name, w.Reason))
+	}
+	return problems, nil
+}
+
+// checkThemeImports resolves the @imports of each stylesheet in a theme,
+// reporting the ones that fail such as missing files and cycles
+func checkThemeImports(dir string) ([]string, error) {
+	var problems []string
+	processor := theme.NewAssetProcessor(false)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".css" {
+			return nil
+		}
+		content, err := os.ReadFile(path) // #nosec G304 - path from the theme directory
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		if _, err := processor.ResolveImports(content, filepath.Dir(path), filepath.Dir(dir)); err != nil {
+			rel, _ := filepath.Rel(dir, path)
+			problems = append(problems, fmt.Sprintf("%s: %v", filepath.ToSlash(rel), err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading theme stylesheets: %w", err)
+	}
+	return problems, nil
+}
+
+func printThemeProblems(w io.Writer, dir string, problems []string) {
+	if len(problems) == 0 {
+		_, _ = fmt.Fprintf(w, "%s: theme is valid\n", dir)
+		return
+	}
+	for _, problem := range problems {
+		_, _ = fmt.Fprintf(w, "Error: %s\n", problem)
+	}
+}
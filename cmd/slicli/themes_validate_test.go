@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeThemeFiles creates a theme directory holding the given files
+func writeThemeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	return dir
+}
+
+func TestValidateTheme(t *testing.T) {
+	t.Run("valid theme", func(t *testing.T) {
+		dir := writeThemeFiles(t, map[string]string{
+			"style.css":     "@import \"base.css\";\n@import url(\"https://fonts.example.com/inter.css\");\nh1 { color: var(--primary); margin: var(--gap, 1rem); }\n",
+			"base.css":      ":root { --primary: #2563eb; }\nbody { font-family: var(--font); }\n",
+			"theme.json":    `{"name": "sample"}`,
+			"theme.toml":    "[variables]\nfont = \"Inter\"\n",
+			"assets/x.json": "not css",
+		})
+
+		problems, err := validateTheme(dir)
+		require.NoError(t, err)
+		assert.Empty(t, problems)
+
+		cmd := themesValidateCmd
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		require.NoError(t, runThemesValidate(cmd, []string{dir}))
+		assert.Contains(t, out.String(), "theme is valid")
+	})
+
+	t.Run("undefined variable", func(t *testing.T) {
+		dir := writeThemeFiles(t, map[string]string{
+			"style.css": ":root { --primary: #2563eb; }\nh1 { color: var(--primary); background: var(--accent); }\n",
+		})
+
+		problems, err := validateTheme(dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"var(--accent) can't be resolved: not defined"}, problems)
+
+		cmd := themesValidateCmd
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		err = runThemesValidate(cmd, []string{dir})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed validation with 1 problems")
+		assert.Contains(t, out.String(), "Error: var(--accent) can't be resolved: not defined")
+	})
+
+	t.Run("missing files and imports", func(t *testing.T) {
+		dir := writeThemeFiles(t, map[string]string{
+			"assets/main.css": "@import \"missing.css\";\n",
+			"theme.json":      "{",
+		})
+
+		problems, err := validateTheme(dir)
+		require.NoError(t, err)
+		require.Len(t, problems, 3)
+		assert.Equal(t, "missing required file style.css", problems[0])
+		assert.Equal(t, "theme.json is not valid JSON", problems[1])
+		assert.Contains(t, problems[2], "assets/main.css: reading @import missing.css")
+	})
+
+	t.Run("not a directory", func(t *testing.T) {
+		_, err := validateTheme(filepath.Join(t.TempDir(), "nope"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	mdparser "github.com/fredcamaral/slicli/internal/adapters/secondary/parser"
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// transitionStyles holds the CSS of each slide transition, applied through
+// the transition-<name> class the navigation script puts on the shown slide
+var transitionStyles = map[string]string{
+	"fade": `.slide.transition-fade { animation: slicli-fade 0.4s ease-out !important; }
+@keyframes slicli-fade { from { opacity: 0; } to { opacity: 1; } }`,
+	"slide": `.slide.transition-slide { animation: slicli-slide 0.4s ease-out !important; }
+@keyframes slicli-slide { from { opacity: 0; transform: translateX(60px); } to { opacity: 1; transform: translateX(0); } }`,
+	"none": `.slide.transition-none { animation: none !important; }`,
+}
+
+// slideTransitions are the transitions a deck uses
+type slideTransitions struct {
+	Deck string   // For slides without their own, empty to keep the theme's
+	Used []string // Every transition the deck or one of its slides uses
+}
+
+// add records a transition as used
+func (t *slideTransitions) add(name string) {
+	if name != "" && !containsString(t.Used, name) {
+		t.Used = append(t.Used, name)
+	}
+}
+
+// deckTransition returns the transition set in a deck's front matter,
+// falling back to the configured one
+func deckTransition(markdown, filePath string, config *entities.Config) string {
+	frontMatter, _ := mdparser.SplitFrontMatter([]byte(markdown))
+	if transition, ok := frontMatter["transition"].(string); ok && transition != "" {
+		if entities.IsSlideTransition(transition) {
+			return transition
+		}
+		appLogger.Warn("Unknown transition ignored", "file", filePath, "transition", transition,
+			"allowed", strings.Join(entities.SlideTransitions, ", "))
+	}
+	if config != nil {
+		return config.Theme.Transition
+	}
+	return ""
+}
+
+// transitionsStyle renders the CSS of the transitions a deck uses
+func transitionsStyle(transitions slideTransitions) string {
+	if len(transitions.Used) == 0 {
+		return ""
+	}
+	names := append([]string(nil), transitions.Used...)
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("\n    <style>\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s\n", transitionStyles[name])
+	}
+	b.WriteString("    </style>")
+	return b.String()
+}
+
+// transitionAttr returns the data-transition attribute of an element, or ""
+// when it has no transition of its own
+func transitionAttr(name string) string {
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf(` data-transition="%s"`, name)
+}
@@ -0,0 +1,182 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+)
+
+// ConfigLoadFunc re-reads and merges the configuration the server runs with
+type ConfigLoadFunc func(ctx context.Context) (*entities.Config, error)
+
+// SetAppConfig sets the application configuration the server was started
+// with, which WatchConfig keeps up to date
+func (s *Server) SetAppConfig(config *entities.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.appConfig = config
+}
+
+// AppConfig returns the live application configuration, nil if none was set.
+// Reloads replace it rather than modify it, so callers must not modify it either.
+func (s *Server) AppConfig() *entities.Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.appConfig
+}
+
+// WatcherConfigSetter takes the watcher settings a reload changed, such as
+// services.LiveReloadService
+type WatcherConfigSetter interface {
+	SetWatcherConfig(config entities.WatcherConfig)
+}
+
+// SetLiveReload sets the live reload service that reloaded debounce and
+// retry settings are passed to
+func (s *Server) SetLiveReload(liveReload WatcherConfigSetter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.liveReload = liveReload
+}
+
+// ThemeName returns the live theme name
+func (s *Server) ThemeName() string {
+	if config := s.AppConfig(); config != nil && config.Theme.Name != "" {
+		return config.Theme.Name
+	}
+	return "default"
+}
+
+// WatchConfig reloads the configuration each time watcher reports a change
+// to path, until ctx is cancelled. The theme applies to the next page rendered
+// and the watcher's debounce and retry settings to the next reload, if a live
+// reload service was set; changes to the address the server listens on, the
+// watcher's poll interval or its directories need a restart.
+func (s *Server) WatchConfig(ctx context.Context, watcher ports.FileWatcher, path string, load ConfigLoadFunc) error {
+	events, err := watcher.Watch(ctx, path)
+	if err != nil {
+		return fmt.Errorf("watching config %s: %w", path, err)
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-events:
+				if !ok {
+					return
+				}
+				s.reloadConfig(ctx, load)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// reloadConfig loads the configuration again, keeping the current one if the
+// new one doesn't load or validate
+func (s *Server) reloadConfig(ctx context.Context, load ConfigLoadFunc) {
+	next, err := load(ctx)
+	if err != nil {
+		s.logger.Error("Config reload failed, keeping the current config: %v", err)
+		return
+	}
+	if err := next.Validate(); err != nil {
+		s.logger.Error("Reloaded config is invalid, keeping the current config: %v", err)
+		return
+	}
+
+	changed, needRestart := s.applyConfig(next)
+	if len(changed) > 0 {
+		s.logger.Info("Config reloaded, changed: %s", strings.Join(changed, ", "))
+	}
+	for _, field := range needRestart {
+		s.logger.Warn("Config %s changed; restart the server to apply it", field)
+	}
+}
+
+// applyConfig swaps in the live-reloadable settings of next, returning the
+// fields applied and the changed fields that need a restart
+func (s *Server) applyConfig(next *entities.Config) (changed, needRestart []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := s.appConfig
+	if current == nil {
+		current = &entities.Config{Server: *s.config}
+	}
+	updated := *current
+
+	themeChanged := changedFields("theme", current.Theme, next.Theme)
+	changed = append(changed, themeChanged...)
+	updated.Theme = next.Theme
+	if len(themeChanged) > 0 && next.Theme.Name != "" {
+		s.liveTheme = next.Theme.Name
+	}
+
+	// The watcher already polls its directories at the old interval, so only
+	// the settings the live reload service reads on each reload apply now
+	if s.liveReload != nil {
+		for _, field := range changedFields("watcher", current.Watcher, next.Watcher) {
+			switch field {
+			case "watcher.interval_ms", "watcher.dirs":
+				needRestart = append(needRestart, field)
+			default:
+				changed = append(changed, field)
+			}
+		}
+		updated.Watcher.DebounceMs = next.Watcher.DebounceMs
+		updated.Watcher.MaxRetries = next.Watcher.MaxRetries
+		updated.Watcher.RetryDelayMs = next.Watcher.RetryDelayMs
+		s.liveReload.SetWatcherConfig(updated.Watcher)
+	}
+
+	// The listener is already bound, so compare against what it was bound with
+	if next.Server.Host != s.config.Host {
+		needRestart = append(needRestart, "server.host")
+	}
+	if next.Server.Port != s.config.Port {
+		needRestart = append(needRestart, "server.port")
+	}
+
+	s.appConfig = &updated
+	return changed, needRestart
+}
+
+// changedFields lists the TOML keys, under prefix, whose values differ
+// between two config sections of the same type
+func changedFields(prefix string, before, after interface{}) []string {
+	beforeValue := reflect.ValueOf(before)
+	afterValue := reflect.ValueOf(after)
+
+	var fields []string
+	for i := 0; i < beforeValue.NumField(); i++ {
+		if reflect.DeepEqual(beforeValue.Field(i).Interface(), afterValue.Field(i).Interface()) {
+			continue
+		}
+		name, _, _ := strings.Cut(beforeValue.Type().Field(i).Tag.Get("toml"), ",")
+		fields = append(fields, prefix+"."+name)
+	}
+	return fields
+}
+
+// withLiveTheme returns p rendered with the theme a config reload switched
+// to, leaving p itself unchanged
+func (s *Server) withLiveTheme(p *entities.Presentation) *entities.Presentation {
+	s.mu.RLock()
+	theme := s.liveTheme
+	s.mu.RUnlock()
+
+	if theme == "" || p.Theme == theme {
+		return p
+	}
+	copied := *p
+	copied.Theme = theme
+	return &copied
+}
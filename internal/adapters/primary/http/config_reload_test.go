@@ -0,0 +1,171 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/config"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/watcher"
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/services"
+)
+
+// lockedBuffer collects log output written from the reload goroutine
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// recordingWatcherConfig keeps the last watcher settings a reload passed on
+type recordingWatcherConfig struct {
+	mu     sync.Mutex
+	config entities.WatcherConfig
+}
+
+func (r *recordingWatcherConfig) SetWatcherConfig(config entities.WatcherConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.config = config
+}
+
+func (r *recordingWatcherConfig) get() entities.WatcherConfig {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.config
+}
+
+var _ WatcherConfigSetter = (*services.LiveReloadService)(nil)
+
+// writeServerConfig writes the default config, changed by edit, to path
+func writeServerConfig(t *testing.T, path string, edit func(*entities.Config)) *entities.Config {
+	cfg := config.GetDefaultConfig()
+	cfg.Server.Host = "127.0.0.1"
+	edit(cfg)
+
+	var data bytes.Buffer
+	require.NoError(t, toml.NewEncoder(&data).Encode(cfg))
+	require.NoError(t, os.WriteFile(path, data.Bytes(), 0600))
+	return cfg
+}
+
+func TestServerWatchConfig(t *testing.T) {
+	logged := &lockedBuffer{}
+	log.SetOutput(logged)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "slicli.toml")
+	initial := writeServerConfig(t, path, func(c *entities.Config) { c.Theme.Name = "default" })
+
+	server := NewServer(new(MockPresentationService), new(MockRenderer), &initial.Server)
+	server.SetAppConfig(initial)
+	liveReload := &recordingWatcherConfig{}
+	server.SetLiveReload(liveReload)
+	assert.Equal(t, "default", server.ThemeName())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fileWatcher := watcher.NewPollingWatcher(20*time.Millisecond, 0)
+	defer func() { _ = fileWatcher.Stop() }()
+
+	load := func(ctx context.Context) (*entities.Config, error) {
+		return config.NewTOMLLoader().LoadLocal(ctx, dir)
+	}
+	require.NoError(t, server.WatchConfig(ctx, fileWatcher, path, load))
+
+	writeServerConfig(t, path, func(c *entities.Config) {
+		c.Theme.Name = "dark"
+		c.Watcher.DebounceMs = 250
+		c.Watcher.IntervalMs = initial.Watcher.IntervalMs * 2
+		c.Server.Port = initial.Server.Port + 1
+	})
+
+	require.Eventually(t, func() bool { return server.ThemeName() == "dark" }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 250, server.AppConfig().Watcher.DebounceMs)
+	assert.Equal(t, 250, liveReload.get().DebounceMs, "the live reload service debounces with the new delay")
+	assert.Equal(t, initial.Watcher.IntervalMs, liveReload.get().IntervalMs, "the watcher keeps polling at its interval")
+	assert.Equal(t, initial.Server.Port, server.AppConfig().Server.Port, "the listener keeps its port")
+	require.Eventually(t, func() bool {
+		return strings.Contains(logged.String(), "restart the server")
+	}, time.Second, 10*time.Millisecond)
+	assert.Contains(t, logged.String(), "changed: theme.name, watcher.debounce_ms")
+	assert.Contains(t, logged.String(), "Config server.port changed; restart the server to apply it")
+	assert.Contains(t, logged.String(), "Config watcher.interval_ms changed; restart the server to apply it")
+
+	t.Run("invalid config keeps the current one", func(t *testing.T) {
+		writeServerConfig(t, path, func(c *entities.Config) {
+			c.Theme.Name = "light"
+			c.Watcher.IntervalMs = 1
+		})
+
+		require.Eventually(t, func() bool {
+			return strings.Contains(logged.String(), "keeping the current config")
+		}, 2*time.Second, 10*time.Millisecond)
+		assert.Equal(t, "dark", server.ThemeName())
+	})
+}
+
+func TestServerThemeNameDefault(t *testing.T) {
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	assert.Equal(t, "default", server.ThemeName())
+
+	changed, needRestart := server.applyConfig(&entities.Config{
+		Server: *getTestServerConfig(),
+		Theme:  entities.ThemeConfig{Name: "minimal"},
+	})
+	assert.Equal(t, []string{"theme.name"}, changed)
+	assert.Empty(t, needRestart)
+	assert.Equal(t, "minimal", server.ThemeName())
+}
+
+func TestServerConfigReloadTheme(t *testing.T) {
+	renderer := new(MockRenderer)
+	server := NewServer(new(MockPresentationService), renderer, getTestServerConfig())
+	presentation := &entities.Presentation{Title: "Talk", Theme: "default"}
+	server.SetPresentation(presentation)
+
+	renderer.On("RenderPresentation", mock.Anything, mock.MatchedBy(func(p *entities.Presentation) bool {
+		return p.Theme == "dark"
+	})).Return([]byte("<html>dark</html>"), nil)
+
+	changed, _ := server.applyConfig(&entities.Config{
+		Server:   *getTestServerConfig(),
+		Theme:    entities.ThemeConfig{Name: "dark"},
+		Metadata: entities.Metadata{Author: "Ada"},
+	})
+	assert.Equal(t, []string{"theme.name"}, changed, "metadata isn't applied by the server")
+
+	w := httptest.NewRecorder()
+	server.handlePresentation(w, httptest.NewRequest("GET", "/", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "<html>dark</html>", w.Body.String())
+	assert.Equal(t, "default", presentation.Theme, "the loaded presentation is left as is")
+	renderer.AssertExpectations(t)
+}
@@ -80,6 +80,23 @@ func (cm *ConnectionManager) Unregister(connID string) {
 	cm.unregister <- connID
 }
 
+// SendTo sends an event to one connection, dropping it when the connection
+// is gone or its buffer is full
+func (cm *ConnectionManager) SendTo(connID string, event ports.UpdateEvent) {
+	// The write lock keeps the connection from being closed mid-send
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	conn, ok := cm.connections[connID]
+	if !ok {
+		return
+	}
+	select {
+	case conn.Send <- event:
+	default:
+	}
+}
+
 // Broadcast sends an event to all connections
 func (cm *ConnectionManager) Broadcast(event ports.UpdateEvent) {
 	select {
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/export"
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+)
+
+// exportJobRetention is how long the outcome of a background export can
+// still be polled once it has finished
+const exportJobRetention = export.DefaultMetricsRetention
+
+// exportMetricsReporter is implemented by export services that report the
+// progress of the exports they run
+type exportMetricsReporter interface {
+	GetExportMetrics(operationID string) (export.ExportMetrics, bool)
+}
+
+// ExportStatus is the progress of an export, as returned by /api/export/status
+type ExportStatus struct {
+	OperationID string `json:"operation_id"`
+	Done        bool   `json:"done"`
+	// Metrics are the export's live retry count, elapsed time and phase,
+	// when the export service reports them
+	Metrics *export.ExportMetrics `json:"metrics,omitempty"`
+	// Result and Error are set once a background export is done
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// exportJobs keeps the outcome of exports run in the background until it
+// has been available for exportJobRetention
+type exportJobs struct {
+	mu        sync.Mutex
+	jobs      map[string]*ExportStatus
+	retention time.Duration
+}
+
+func newExportJobs() *exportJobs {
+	return &exportJobs{
+		jobs:      make(map[string]*ExportStatus),
+		retention: exportJobRetention,
+	}
+}
+
+// start records a background export as running
+func (j *exportJobs) start(operationID string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.jobs[operationID] = &ExportStatus{OperationID: operationID}
+}
+
+// finish records the outcome of a background export and schedules it to
+// be forgotten
+func (j *exportJobs) finish(operationID string, result interface{}, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job, ok := j.jobs[operationID]
+	if !ok {
+		return
+	}
+	job.Done = true
+	job.Result = result
+	if err != nil {
+		job.Error = err.Error()
+	}
+
+	time.AfterFunc(j.retention, func() {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		delete(j.jobs, operationID)
+	})
+}
+
+// get returns a copy of a background export's status
+func (j *exportJobs) get(operationID string) (ExportStatus, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	job, ok := j.jobs[operationID]
+	if !ok {
+		return ExportStatus{}, false
+	}
+	return *job, true
+}
+
+// startAsyncExport runs an export in the background, outliving the request
+// that started it, and records its outcome for /api/export/status
+func (s *Server) startAsyncExport(ctx context.Context, exportService ports.ExportService, presentation *entities.Presentation, options *export.ExportOptions) {
+	s.exportJobs.start(options.OperationID)
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		release, err := s.acquireBrowser(ctx, options.Format)
+		if err != nil {
+			s.exportJobs.finish(options.OperationID, nil, err)
+			return
+		}
+		defer release()
+
+		result, err := exportService.Export(ctx, presentation, options)
+		if err != nil {
+			s.logger.Error("Background export %s failed: %v", options.OperationID, err)
+			result = nil
+		}
+		s.exportJobs.finish(options.OperationID, result, err)
+	}()
+}
+
+// handleExportStatus reports the progress of an export started with
+// ?async=true, or of any running export the export service reports on
+func (s *Server) handleExportStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	operationID := r.URL.Query().Get("id")
+	if operationID == "" {
+		http.Error(w, "id parameter required", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.RLock()
+	exportService := s.exportService
+	s.mu.RUnlock()
+
+	status, found := s.exportJobs.get(operationID)
+	if reporter, ok := exportService.(exportMetricsReporter); ok {
+		if metrics, ok := reporter.GetExportMetrics(operationID); ok {
+			status.OperationID = operationID
+			status.Metrics = &metrics
+			if !found {
+				status.Done = !metrics.EndTime.IsZero()
+			}
+			found = true
+		}
+	}
+	if !found {
+		s.handleError(w, errors.New("unknown export "+operationID), http.StatusNotFound)
+		return
+	}
+
+	s.writeJSON(w, status)
+}
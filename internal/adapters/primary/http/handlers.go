@@ -3,15 +3,26 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"mime"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	pluginparser "github.com/fredcamaral/slicli/internal/adapters/primary/parser"
 	"github.com/fredcamaral/slicli/internal/adapters/secondary/export"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/optimization"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/theme"
 	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+	"github.com/fredcamaral/slicli/internal/domain/services"
+	pluginapi "github.com/fredcamaral/slicli/pkg/plugin"
 	"github.com/microcosm-cc/bluemonday"
 )
 
@@ -39,6 +50,18 @@ type SlideResponse struct {
 	Notes string `json:"notes,omitempty"`
 }
 
+// TOCResponse represents the table of contents API response
+type TOCResponse struct {
+	Title   string              `json:"title"`
+	Entries []services.TOCEntry `json:"entries"`
+}
+
+// SlidesTimingResponse represents the speaker notes timing API response
+type SlidesTimingResponse struct {
+	Title string `json:"title"`
+	services.NotesTiming
+}
+
 // ConfigResponse represents the configuration API response
 type ConfigResponse struct {
 	Version         string   `json:"version"`
@@ -70,8 +93,8 @@ func (s *Server) handlePresentation(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Render the presentation
-	html, err := s.renderer.RenderPresentation(ctx, presentation)
+	// Render the presentation with task-list items in their current state
+	html, err := s.renderer.RenderPresentation(ctx, s.withLiveTheme(s.withTaskStates(presentation, s.interactiveTasks())))
 	if err != nil {
 		s.handleError(w, err, http.StatusInternalServerError)
 		return
@@ -104,10 +127,74 @@ func (s *Server) handleSlides(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// format=json returns the full versioned document used by the json export
+	if r.URL.Query().Get("format") == "json" {
+		s.writeJSON(w, presentationToDocument(presentation))
+		return
+	}
+
 	response := s.presentationToResponse(presentation)
 	s.writeJSON(w, response)
 }
 
+// handleTOC returns the presentation's table of contents as JSON
+func (s *Server) handleTOC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	response := TOCResponse{Entries: []services.TOCEntry{}}
+	if presentation := s.GetPresentation(); presentation != nil {
+		response.Title = htmlSanitizer.Sanitize(presentation.Title)
+		for _, entry := range services.BuildTableOfContents(presentation) {
+			entry.Title = htmlSanitizer.Sanitize(entry.Title)
+			response.Entries = append(response.Entries, entry)
+		}
+	}
+
+	s.writeJSON(w, response)
+}
+
+// handleSlidesTiming returns the word count and estimated speaking time of
+// each slide's notes. The configured pace can be overridden with ?wpm=.
+func (s *Server) handleSlidesTiming(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	wpm := s.config.GetSpeakingWPM()
+	if value := r.URL.Query().Get("wpm"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "wpm must be a positive number", http.StatusBadRequest)
+			return
+		}
+		wpm = parsed
+	}
+
+	presentation := s.GetPresentation()
+	response := SlidesTimingResponse{NotesTiming: services.BuildNotesTiming(presentation, wpm)}
+	if presentation != nil {
+		response.Title = htmlSanitizer.Sanitize(presentation.Title)
+	}
+	s.writeJSON(w, response)
+}
+
+// presentationToDocument converts a presentation to the JSON export document
+// with sanitized HTML, as presentationToResponse does
+func presentationToDocument(p *entities.Presentation) *export.JSONDocument {
+	doc := export.NewJSONDocument(p, true)
+	doc.Presentation.Title = htmlSanitizer.Sanitize(doc.Presentation.Title)
+	for i := range doc.Slides {
+		doc.Slides[i].Title = htmlSanitizer.Sanitize(doc.Slides[i].Title)
+		doc.Slides[i].HTML = htmlSanitizer.Sanitize(doc.Slides[i].HTML)
+		doc.Slides[i].Notes = htmlSanitizer.Sanitize(doc.Slides[i].Notes)
+	}
+	return doc
+}
+
 // handleConfig returns the server configuration
 func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -117,7 +204,7 @@ func (s *Server) handleConfig(w http.ResponseWriter, r *http.Request) {
 
 	config := ConfigResponse{
 		Version:         "1.0.0",
-		Theme:           "default",
+		Theme:           s.ThemeName(),
 		WebSocketURL:    "/ws",
 		LiveReload:      true,
 		SupportedThemes: []string{"default", "dark", "light"},
@@ -133,6 +220,8 @@ func (s *Server) handleError(w http.ResponseWriter, err error, status int) {
 	switch status {
 	case http.StatusBadRequest:
 		message = "Invalid request"
+	case http.StatusForbidden:
+		message = "Server is read-only"
 	case http.StatusNotFound:
 		message = "Resource not found"
 	case http.StatusMethodNotAllowed:
@@ -255,7 +344,7 @@ func (s *Server) handlePresenterView(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Render the presenter interface
-	html, err := presenterRenderer.RenderPresenter(ctx, presentation)
+	html, err := presenterRenderer.RenderPresenter(ctx, s.withLiveTheme(s.withTaskStates(presentation, s.interactiveTasks())))
 	if err != nil {
 		s.handleError(w, err, http.StatusInternalServerError)
 		return
@@ -309,11 +398,19 @@ func (s *Server) handleGetPresenterNotes(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// For now, return empty notes - this will be integrated with NotesService later
-	notes := map[string]interface{}{
-		"slideId": slideID,
-		"content": "",
-		"html":    "",
+	s.mu.RLock()
+	notesService := s.notesService
+	s.mu.RUnlock()
+
+	if notesService == nil {
+		http.Error(w, "Notes not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	notes, err := notesService.GetNotes(slideID)
+	if err != nil {
+		s.handleError(w, fmt.Errorf("getting notes for %s: %w", slideID, err), http.StatusInternalServerError)
+		return
 	}
 
 	s.writeJSON(w, notes)
@@ -336,10 +433,25 @@ func (s *Server) handleSetPresenterNotes(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// For now, just return success - this will be integrated with NotesService later
+	s.mu.RLock()
+	notesService := s.notesService
+	s.mu.RUnlock()
+
+	if notesService == nil {
+		http.Error(w, "Notes not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	notes := &entities.SpeakerNotes{Content: req.Content}
+	if err := notesService.SetNotes(req.SlideID, notes); err != nil {
+		s.handleError(w, fmt.Errorf("saving notes for %s: %w", req.SlideID, err), http.StatusInternalServerError)
+		return
+	}
+
 	response := map[string]interface{}{
-		"slideId": req.SlideID,
-		"content": req.Content,
+		"slideId": notes.SlideID,
+		"content": notes.Content,
+		"html":    notes.HTML,
 		"status":  "saved",
 	}
 
@@ -389,11 +501,26 @@ func (s *Server) handlePresenterNavigate(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Return updated state
+	// Audience views following the presenter move to the resulting slide
 	state := syncService.GetState()
+	_ = s.NotifyClients(navigationEvent(req.Action, state))
+
 	s.writeJSON(w, state)
 }
 
+// navigationEvent tells open views which slide the presenter is on, as a
+// zero-based index
+func navigationEvent(action string, state *entities.PresenterState) ports.UpdateEvent {
+	return ports.UpdateEvent{
+		Type:      ports.EventTypeNavigation,
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"action": action,
+			"slide":  float64(state.CurrentSlide),
+		},
+	}
+}
+
 // handlePresenterTimer handles timer control commands from the presenter
 func (s *Server) handlePresenterTimer(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -447,6 +574,119 @@ func (s *Server) handlePresenterTimer(w http.ResponseWriter, r *http.Request) {
 	s.writeJSON(w, state)
 }
 
+// handlePresenterTasks ticks or unticks a task-list item, sharing the change
+// with every connected view
+func (s *Server) handlePresenterTasks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !s.config.InteractiveTasks {
+		http.Error(w, "Interactive task lists are disabled", http.StatusForbidden)
+		return
+	}
+
+	var req struct {
+		Slide   int  `json:"slide"`
+		Task    int  `json:"task"`
+		Checked bool `json:"checked"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Check if we have a sync service
+	s.mu.RLock()
+	syncService := s.syncService
+	s.mu.RUnlock()
+
+	if syncService == nil {
+		http.Error(w, "Presenter mode not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Record the tick through the sync service, then update open slides
+	eventData := map[string]interface{}{
+		"slide":   float64(req.Slide),
+		"task":    float64(req.Task),
+		"checked": req.Checked,
+	}
+	if err := syncService.Broadcast(entities.NewSyncEvent("task", eventData)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	_ = s.NotifyClients(ports.UpdateEvent{
+		Type:      ports.EventTypeTaskUpdate,
+		Timestamp: time.Now(),
+		Data:      eventData,
+	})
+
+	state := syncService.GetState()
+	s.writeJSON(w, state)
+}
+
+// interactiveTasks reports whether task-list checkboxes are rendered clickable
+func (s *Server) interactiveTasks() bool {
+	return s.config.InteractiveTasks && !s.config.ReadOnly
+}
+
+// withTaskStates returns the presentation with its task-list checkboxes set to
+// the states recorded by the sync service. The stored presentation is left as is.
+func (s *Server) withTaskStates(p *entities.Presentation, interactive bool) *entities.Presentation {
+	if !s.config.InteractiveTasks {
+		return p
+	}
+
+	s.mu.RLock()
+	syncService := s.syncService
+	s.mu.RUnlock()
+
+	var states entities.TaskStates
+	if syncService != nil {
+		states = syncService.GetState().Tasks
+	}
+
+	copied := *p
+	copied.Slides = make([]entities.Slide, len(p.Slides))
+	for i, slide := range p.Slides {
+		slide.HTML = entities.ApplyTaskStates(slide.HTML, i, states[i], interactive)
+		copied.Slides[i] = slide
+	}
+	return &copied
+}
+
+// exportThemeFonts returns the fonts of the named theme, the live theme when
+// name is empty, for exports to declare. Without a theme loader, or when the
+// theme can't be loaded, exports use the browser's fonts.
+func (s *Server) exportThemeFonts(ctx context.Context, name string) []theme.ThemeFont {
+	s.mu.RLock()
+	themeLoader := s.themeLoader
+	s.mu.RUnlock()
+
+	if themeLoader == nil {
+		return nil
+	}
+	if name == "" {
+		name = s.ThemeName()
+	}
+
+	engine, err := themeLoader.Load(ctx, name)
+	if err != nil {
+		s.logger.Warn("Exporting without the fonts of theme %s: %v", name, err)
+		return nil
+	}
+	fonts, err := theme.Fonts(engine)
+	if err != nil {
+		s.logger.Warn("Exporting without the fonts of theme %s: %v", name, err)
+		return nil
+	}
+	return fonts
+}
+
 // handleExport handles presentation export requests
 func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -455,15 +695,25 @@ func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req struct {
-		Format          string                 `json:"format"`
-		Theme           string                 `json:"theme,omitempty"`
-		IncludeNotes    bool                   `json:"include_notes"`
-		IncludeMetadata bool                   `json:"include_metadata"`
-		Quality         string                 `json:"quality,omitempty"`
-		PageSize        string                 `json:"page_size,omitempty"`
-		Orientation     string                 `json:"orientation,omitempty"`
-		Compression     bool                   `json:"compression"`
-		Metadata        map[string]interface{} `json:"metadata,omitempty"`
+		Format              string                 `json:"format"`
+		Theme               string                 `json:"theme,omitempty"`
+		IncludeNotes        bool                   `json:"include_notes"`
+		IncludeMetadata     bool                   `json:"include_metadata"`
+		Quality             string                 `json:"quality,omitempty"`
+		PageSize            string                 `json:"page_size,omitempty"`
+		Orientation         string                 `json:"orientation,omitempty"`
+		MarginTop           string                 `json:"margin_top,omitempty"`
+		MarginRight         string                 `json:"margin_right,omitempty"`
+		MarginBottom        string                 `json:"margin_bottom,omitempty"`
+		MarginLeft          string                 `json:"margin_left,omitempty"`
+		Compression         bool                   `json:"compression"`
+		SubsetFonts         *bool                  `json:"subset_fonts,omitempty"`
+		Metadata            map[string]interface{} `json:"metadata,omitempty"`
+		SlideRange          string                 `json:"slide_range,omitempty"`
+		PrintLayout         bool                   `json:"print_layout,omitempty"`
+		ContactSheet        bool                   `json:"contact_sheet,omitempty"`
+		ContactSheetColumns int                    `json:"contact_sheet_columns,omitempty"`
+		InlineFonts         bool                   `json:"inline_fonts,omitempty"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -481,38 +731,92 @@ func (s *Server) handleExport(w http.ResponseWriter, r *http.Request) {
 	// Check if we have an export service
 	s.mu.RLock()
 	exportService := s.exportService
+	presentationDir := s.presentationDir
 	s.mu.RUnlock()
 
 	if exportService == nil {
 		http.Error(w, "Export service not available", http.StatusServiceUnavailable)
 		return
 	}
+	presentation = s.exportPresentation(r.Context(), presentation)
 
-	// Generate output path based on presentation title and format
-	filename := fmt.Sprintf("%s.%s", presentation.Title, req.Format)
-	if req.Format == "images" {
-		filename = presentation.Title // Directory for images
+	// Generate output path based on presentation title and format. Titles can
+	// hold slashes or emoji, so only the file name is sanitized; document
+	// metadata still uses the original title.
+	ext := req.Format
+	if export.ExportFormat(req.Format).WritesDirectory() {
+		ext = "" // Directory of per-slide files
 	}
+	filename := export.ExportFilename(presentation.Title, ext, export.ParseFilenameMode(s.config.ExportFilenames), time.Now())
 	outputPath := filepath.Join(exportService.GetTempDir(), filename)
 
 	// Prepare export options
 	options := &export.ExportOptions{
-		Format:          export.ExportFormat(req.Format),
-		OutputPath:      outputPath,
-		Theme:           req.Theme,
-		IncludeNotes:    req.IncludeNotes,
-		IncludeMetadata: req.IncludeMetadata,
-		Quality:         req.Quality,
-		PageSize:        req.PageSize,
-		Orientation:     req.Orientation,
-		Compression:     req.Compression,
-		Metadata:        req.Metadata,
-	}
-
-	// Perform export
-	result, err := exportService.Export(r.Context(), presentation, options)
+		Format:              export.ExportFormat(req.Format),
+		OutputPath:          outputPath,
+		Theme:               req.Theme,
+		IncludeNotes:        req.IncludeNotes,
+		IncludeMetadata:     req.IncludeMetadata,
+		Quality:             req.Quality,
+		PageSize:            req.PageSize,
+		Orientation:         req.Orientation,
+		MarginTop:           req.MarginTop,
+		MarginRight:         req.MarginRight,
+		MarginBottom:        req.MarginBottom,
+		MarginLeft:          req.MarginLeft,
+		Compression:         req.Compression,
+		Fonts:               s.config.ExportFonts,
+		SubsetFonts:         s.config.SubsetFonts,
+		Metadata:            req.Metadata,
+		SlideRange:          req.SlideRange,
+		PrintLayout:         req.PrintLayout,
+		ContactSheet:        req.ContactSheet,
+		ContactSheetColumns: req.ContactSheetColumns,
+		SourceDir:           presentationDir,
+		InlineImages:        true, // Downloads are a single file
+		ThemeFonts:          s.exportThemeFonts(r.Context(), req.Theme),
+		InlineFonts:         req.InlineFonts,
+	}
+	if req.SubsetFonts != nil {
+		options.SubsetFonts = *req.SubsetFonts
+	}
+
+	// Large decks take a while; async exports return at once with an ID
+	// to poll /api/export/status with
+	if r.URL.Query().Get("async") == "true" {
+		options.OperationID = export.NewOperationID(options.Format)
+		s.startAsyncExport(r.Context(), exportService, s.withTaskStates(presentation, false), options)
+
+		statusURL := "/api/export/status?id=" + url.QueryEscape(options.OperationID)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", statusURL)
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(map[string]string{
+			"operation_id": options.OperationID,
+			"status_url":   statusURL,
+		}); err != nil {
+			s.logger.Error("Failed to encode JSON response: %v", err)
+		}
+		return
+	}
+
+	// Wait for headless Chrome if background exports are using it
+	release, err := s.acquireBrowser(r.Context(), options.Format)
 	if err != nil {
-		s.handleError(w, err, http.StatusInternalServerError)
+		s.handleError(w, err, http.StatusServiceUnavailable)
+		return
+	}
+	defer release()
+
+	// Perform export, keeping task-list items as they were ticked
+	result, err := exportService.Export(r.Context(), s.withTaskStates(presentation, false), options)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var exportErr *export.ExportError
+		if errors.As(err, &exportErr) && exportErr.Type == export.ErrorTypeValidation {
+			status = http.StatusBadRequest
+		}
+		s.handleError(w, err, status)
 		return
 	}
 
@@ -566,13 +870,17 @@ func (s *Server) handleExportDownload(w http.ResponseWriter, r *http.Request) {
 		mimeType = "image/jpeg"
 	case ".md":
 		mimeType = "text/markdown"
+	case ".json":
+		mimeType = "application/json"
+	case ".pptx":
+		mimeType = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
 	default:
 		mimeType = "application/octet-stream"
 	}
 
 	// Set headers for download
 	w.Header().Set("Content-Type", mimeType)
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filepath.Base(filePath)))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filepath.Base(filePath)}))
 
 	// Serve file
 	http.ServeFile(w, r, filePath)
@@ -632,7 +940,197 @@ func (s *Server) handlePerformanceHealth(w http.ResponseWriter, r *http.Request)
 	s.writeJSON(w, healthStatus)
 }
 
-// handlePerformanceMetrics returns detailed performance metrics
+// PluginHealthResponse represents the plugin health endpoint response
+type PluginHealthResponse struct {
+	Status  string                  `json:"status"` // healthy, or degraded if any plugin isn't
+	Plugins []entities.PluginHealth `json:"plugins"`
+}
+
+// handlePluginHealth reports the health and load state of every plugin
+func (s *Server) handlePluginHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.RLock()
+	pluginService := s.pluginService
+	s.mu.RUnlock()
+
+	if pluginService == nil {
+		http.Error(w, "Plugin service not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	response := PluginHealthResponse{
+		Status:  "healthy",
+		Plugins: pluginService.PluginHealth(),
+	}
+	for _, plugin := range response.Plugins {
+		if !plugin.Healthy {
+			response.Status = "degraded"
+			break
+		}
+	}
+
+	s.writeJSON(w, response)
+}
+
+// Plugins run ad hoc can execute code, so each client gets a budget of runs
+// on top of the server-wide rate limit
+const (
+	pluginExecuteLimit       = 10
+	pluginExecuteWindow      = time.Minute
+	maxPluginExecuteBodySize = 1 << 20
+)
+
+var pluginExecuteLimiter = newRateLimiter()
+
+// PluginExecuteRequest is the body of an ad hoc plugin execution
+type PluginExecuteRequest struct {
+	Content  string                 `json:"content"`
+	Language string                 `json:"language,omitempty"`
+	Options  map[string]interface{} `json:"options,omitempty"`
+}
+
+// PluginExecuteResponse carries the output of an ad hoc plugin execution
+type PluginExecuteResponse struct {
+	Plugin   string                 `json:"plugin"`
+	HTML     string                 `json:"html"`
+	Assets   []pluginapi.Asset      `json:"assets,omitempty"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// handlePluginExecute runs the plugin named in the path on the posted
+// content, for trying plugins out outside of a slide. With ?stream=true the
+// output is sent as Server-Sent Events while the plugin runs.
+func (s *Server) handlePluginExecute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.handleError(w, fmt.Errorf("%s not allowed on %s", r.Method, r.URL.Path), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !pluginExecuteLimiter.isAllowed(getClientIP(r), pluginExecuteLimit, pluginExecuteWindow) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(pluginExecuteWindow.Seconds())))
+		s.handleError(w, fmt.Errorf("plugin execution rate limit exceeded for %s", getClientIP(r)), http.StatusTooManyRequests)
+		return
+	}
+
+	s.mu.RLock()
+	pluginService := s.pluginService
+	s.mu.RUnlock()
+
+	if pluginService == nil {
+		http.Error(w, "Plugin service not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	name := r.PathValue("name")
+	if _, err := pluginService.GetPlugin(name); err != nil {
+		s.handleError(w, err, http.StatusNotFound)
+		return
+	}
+
+	var req PluginExecuteRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPluginExecuteBodySize)).Decode(&req); err != nil {
+		s.handleError(w, fmt.Errorf("decoding plugin execute request: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	input := pluginapi.PluginInput{
+		Content:  req.Content,
+		Language: req.Language,
+		Options:  req.Options,
+	}
+	if r.URL.Query().Get("stream") == "true" {
+		s.streamPluginExecute(w, r, pluginService, name, input)
+		return
+	}
+
+	output, err := pluginService.ExecutePlugin(r.Context(), name, input)
+	if err != nil {
+		s.handleError(w, fmt.Errorf("executing plugin %s: %w", name, err), http.StatusInternalServerError)
+		return
+	}
+
+	s.writeJSON(w, PluginExecuteResponse{
+		Plugin:   name,
+		HTML:     output.HTML,
+		Assets:   output.Assets,
+		Metadata: output.Metadata,
+	})
+}
+
+// streamPluginExecute runs a plugin, sending each chunk of output it
+// streams as an "output" event, then the complete result as a "result"
+// event, or an "error" event when it fails. Plugins that don't stream only
+// send the result.
+func (s *Server) streamPluginExecute(w http.ResponseWriter, r *http.Request, pluginService ports.PluginService, name string, input pluginapi.PluginInput) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Snippets may run longer than the server's write timeout
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// A plugin cut off by its timeout may still be emitting after the
+	// handler returns, when the response can no longer be written
+	var mu sync.Mutex
+	closed := false
+	defer func() {
+		mu.Lock()
+		closed = true
+		mu.Unlock()
+	}()
+	send := func(event string, payload interface{}) {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			s.logger.Error("Failed to encode %s event: %v", event, err)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if closed {
+			return
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err == nil {
+			flusher.Flush()
+		}
+	}
+
+	output, err := pluginService.ExecutePluginStream(r.Context(), name, input, func(chunk pluginapi.OutputChunk) {
+		send("output", chunk)
+	})
+	if err != nil {
+		s.logger.Error("Streamed execution of plugin %s failed: %v", name, err)
+		send("error", map[string]string{"error": "Internal server error"})
+		return
+	}
+	send("result", PluginExecuteResponse{
+		Plugin:   name,
+		HTML:     output.HTML,
+		Assets:   output.Assets,
+		Metadata: output.Metadata,
+	})
+}
+
+// defaultMetricsStreamInterval is how often streamed performance metrics are sent
+const defaultMetricsStreamInterval = time.Second
+
+// minMetricsStreamInterval keeps a streaming client from busy-looping the monitor
+const minMetricsStreamInterval = 100 * time.Millisecond
+
+// handlePerformanceMetrics returns detailed performance metrics. With
+// ?stream=true it keeps sending them as Server-Sent Events every ?interval
+// (default 1s) until the client disconnects.
 func (s *Server) handlePerformanceMetrics(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -649,6 +1147,16 @@ func (s *Server) handlePerformanceMetrics(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	if r.URL.Query().Get("stream") == "true" {
+		s.streamPerformanceMetrics(w, r, optimizationSvc)
+		return
+	}
+
+	s.writeJSON(w, performanceMetricsResponse(optimizationSvc))
+}
+
+// performanceMetricsResponse snapshots the monitor's metrics in a JSON-safe shape
+func performanceMetricsResponse(optimizationSvc *optimization.OptimizationService) map[string]interface{} {
 	// Get comprehensive metrics
 	monitor := optimizationSvc.GetPerformanceMonitor()
 	metrics := monitor.GetMetrics()
@@ -676,14 +1184,61 @@ func (s *Server) handlePerformanceMetrics(w http.ResponseWriter, r *http.Request
 		"memory_growth_rate":    metrics.MemoryGrowthRate,
 	}
 
-	response := map[string]interface{}{
+	return map[string]interface{}{
 		"metrics":      metricsResponse,
 		"memory":       memoryStats,
 		"optimization": optimizationStats,
 		"timestamp":    time.Now(),
 	}
+}
+
+// streamPerformanceMetrics sends a metrics event immediately and then on every
+// tick until the request context is cancelled
+func (s *Server) streamPerformanceMetrics(w http.ResponseWriter, r *http.Request, optimizationSvc *optimization.OptimizationService) {
+	interval := defaultMetricsStreamInterval
+	if value := r.URL.Query().Get("interval"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < minMetricsStreamInterval {
+			http.Error(w, fmt.Sprintf("interval must be a duration of at least %s", minMetricsStreamInterval), http.StatusBadRequest)
+			return
+		}
+		interval = parsed
+	}
 
-	s.writeJSON(w, response)
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		data, err := json.Marshal(performanceMetricsResponse(optimizationSvc))
+		if err != nil {
+			s.logger.Error("Failed to encode performance metrics: %v", err)
+			return
+		}
+		if _, err := fmt.Fprintf(w, "event: metrics\ndata: %s\n\n", data); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
 // handlePerformanceOptimize triggers immediate optimization
@@ -753,3 +1308,23 @@ func (s *Server) handlePerformanceOptimize(w http.ResponseWriter, r *http.Reques
 
 	s.writeJSON(w, response)
 }
+
+// exportPresentation renders the presentation's source file again for an
+// export, so plugins can reuse what they last showed instead of fetching it
+// again. The shown presentation is returned when the source is unknown or
+// no longer loads.
+func (s *Server) exportPresentation(ctx context.Context, shown *entities.Presentation) *entities.Presentation {
+	s.mu.RLock()
+	path := s.presentationPath
+	s.mu.RUnlock()
+	if path == "" || s.presenter == nil {
+		return shown
+	}
+
+	presentation, err := s.presenter.LoadPresentation(pluginparser.ExportContext(ctx), path)
+	if err != nil {
+		s.logger.Warn("Exporting the presentation as shown, rendering it for export failed: %v", err)
+		return shown
+	}
+	return presentation
+}
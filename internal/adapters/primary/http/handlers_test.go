@@ -1,19 +1,34 @@
 package http
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/export"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/notes"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/optimization"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/parser"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/theme"
 	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+	"github.com/fredcamaral/slicli/internal/domain/services"
+	pluginapi "github.com/fredcamaral/slicli/pkg/plugin"
 )
 
 // getTestServerConfig returns a test server configuration
@@ -140,6 +155,79 @@ func TestHandlePresentation(t *testing.T) {
 	})
 }
 
+func TestHandleTOC(t *testing.T) {
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	server.SetPresentation(&entities.Presentation{
+		Title: "Test Presentation",
+		Slides: []entities.Slide{
+			{Index: 0, Content: "# Intro"},
+			{Index: 1, Content: "Body only"},
+		},
+	})
+
+	req := httptest.NewRequest("GET", "/api/toc", nil)
+	w := httptest.NewRecorder()
+	server.handleTOC(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var toc TOCResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &toc))
+	assert.Equal(t, "Test Presentation", toc.Title)
+	assert.Equal(t, []services.TOCEntry{
+		{Index: 0, Title: "Intro", Level: 1},
+		{Index: 1, Title: "Slide 2"},
+	}, toc.Entries)
+
+	w = httptest.NewRecorder()
+	server.handleTOC(w, httptest.NewRequest("POST", "/api/toc", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestHandleSlidesTiming(t *testing.T) {
+	config := getTestServerConfig()
+	config.SpeakingWPM = 120
+	server := NewServer(new(MockPresentationService), new(MockRenderer), config)
+	server.SetPresentation(&entities.Presentation{
+		Title: "Test Presentation",
+		Slides: []entities.Slide{
+			{Index: 0, Notes: "<p>" + strings.Repeat("word ", 60) + "</p>"},
+			{Index: 1, Notes: "<ul><li>one</li><li>two</li></ul>"},
+		},
+	})
+
+	get := func(target string) (*httptest.ResponseRecorder, SlidesTimingResponse) {
+		w := httptest.NewRecorder()
+		server.handleSlidesTiming(w, httptest.NewRequest("GET", target, nil))
+		var timing SlidesTimingResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &timing))
+		}
+		return w, timing
+	}
+
+	w, timing := get("/api/slides/timing")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Test Presentation", timing.Title)
+	assert.Equal(t, 120, timing.WordsPerMinute)
+	assert.Equal(t, []services.SlideTiming{
+		{Index: 0, Words: 60, Seconds: 30},
+		{Index: 1, Words: 2, Seconds: 1},
+	}, timing.Slides)
+	assert.Equal(t, 62, timing.TotalWords)
+	assert.Equal(t, 31, timing.TotalSeconds)
+
+	w, timing = get("/api/slides/timing?wpm=60")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 62, timing.TotalSeconds)
+
+	w, _ = get("/api/slides/timing?wpm=fast")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	server.handleSlidesTiming(w, httptest.NewRequest("POST", "/api/slides/timing", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
 func TestHandleSlides(t *testing.T) {
 	t.Run("successful slides response", func(t *testing.T) {
 		presenter := new(MockPresentationService)
@@ -185,6 +273,33 @@ func TestHandleSlides(t *testing.T) {
 		presenter.AssertExpectations(t)
 	})
 
+	t.Run("full JSON document", func(t *testing.T) {
+		server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+		server.SetPresentation(&entities.Presentation{
+			Title: "Test Presentation",
+			Slides: []entities.Slide{
+				{Index: 0, Content: "# Slide 1", HTML: `<h1>Slide 1</h1><script>alert(1)</script>`, Notes: "Notes 1"},
+				{Index: 1, Content: "## Chart\n\n![chart](chart.png)", HTML: `<h2>Chart</h2><img src="chart.png">`},
+			},
+		})
+
+		req := httptest.NewRequest("GET", "/api/slides?format=json", nil)
+		w := httptest.NewRecorder()
+		server.handleSlides(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var doc export.JSONDocument
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+
+		assert.Equal(t, export.JSONSchemaVersion, doc.SchemaVersion)
+		assert.Equal(t, 2, doc.Presentation.SlideCount)
+		require.Len(t, doc.Slides, 2)
+		assert.Equal(t, "# Slide 1", doc.Slides[0].Markdown)
+		assert.Equal(t, "Notes 1", doc.Slides[0].Notes)
+		assert.NotContains(t, doc.Slides[0].HTML, "<script>")
+		assert.Equal(t, []string{"chart.png"}, doc.Slides[1].Assets)
+	})
+
 	t.Run("method not allowed", func(t *testing.T) {
 		presenter := new(MockPresentationService)
 		renderer := new(MockRenderer)
@@ -305,3 +420,737 @@ func TestPresentationToResponse(t *testing.T) {
 		assert.Equal(t, "", response.Date)
 	})
 }
+
+// recordingExportService captures the options passed to Export
+type recordingExportService struct {
+	tempDir      string
+	options      *export.ExportOptions
+	presentation *entities.Presentation
+	err          error
+}
+
+func (s *recordingExportService) Export(ctx context.Context, presentation *entities.Presentation, options interface{}) (interface{}, error) {
+	s.options = options.(*export.ExportOptions)
+	s.presentation = presentation
+	if s.err != nil {
+		return nil, s.err
+	}
+	return map[string]string{"output_path": s.options.OutputPath}, nil
+}
+
+func (s *recordingExportService) GetSupportedFormats() []string { return []string{"pdf", "images"} }
+func (s *recordingExportService) GetTempDir() string            { return s.tempDir }
+
+func TestHandleExportFilenames(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		title    string
+		format   string
+		expected string
+	}{
+		{"emoji and slashes", "", "🚀 Launch/Plan: 2024", "pdf", "Launch-Plan-2024.pdf"},
+		{"image directory", "", "Roadmap 🗺️", "images", "Roadmap"},
+		{"unicode mode", "unicode", "東京/Tokyo", "pdf", "東京-Tokyo.pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := getTestServerConfig()
+			config.ExportFilenames = tt.mode
+			server := NewServer(new(MockPresentationService), new(MockRenderer), config)
+			server.SetPresentation(&entities.Presentation{Title: tt.title})
+
+			exportService := &recordingExportService{tempDir: t.TempDir()}
+			server.SetExportService(exportService)
+
+			req := httptest.NewRequest("POST", "/api/export", strings.NewReader(`{"format": "`+tt.format+`"}`))
+			w := httptest.NewRecorder()
+			server.handleExport(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, filepath.Join(exportService.tempDir, tt.expected), exportService.options.OutputPath)
+		})
+	}
+
+	t.Run("falls back to a timestamp", func(t *testing.T) {
+		server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+		server.SetPresentation(&entities.Presentation{Title: "🎉🎉"})
+		exportService := &recordingExportService{tempDir: t.TempDir()}
+		server.SetExportService(exportService)
+
+		req := httptest.NewRequest("POST", "/api/export", strings.NewReader(`{"format": "pdf"}`))
+		w := httptest.NewRecorder()
+		server.handleExport(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Regexp(t, `presentation-\d{8}-\d{6}\.pdf$`, exportService.options.OutputPath)
+	})
+}
+
+// recordingPluginService records the input of each plugin execution
+type recordingPluginService struct {
+	ports.PluginService
+	inputs []pluginapi.PluginInput
+}
+
+func (s *recordingPluginService) ExecutePlugin(ctx context.Context, name string, input pluginapi.PluginInput) (pluginapi.PluginOutput, error) {
+	s.inputs = append(s.inputs, input)
+	return pluginapi.PluginOutput{HTML: "<table></table>"}, nil
+}
+
+func TestHandleExportRendersForExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "talk.md")
+	require.NoError(t, os.WriteFile(path, []byte("---\ntitle: Deck\n---\n# Sales\n\n```mermaid\ngraph TD\nA-->B\n```\n"), 0600))
+
+	plugins := &recordingPluginService{}
+	adapter := parser.NewPresentationParserAdapter(parser.NewGoldmarkParser())
+	adapter.SetPluginService(plugins)
+	presenter := services.NewPresentationService(parser.NewFileRepository(adapter, nil), nil, adapter, nil)
+
+	shown, err := presenter.LoadPresentation(context.Background(), path)
+	require.NoError(t, err)
+	require.Len(t, plugins.inputs, 1)
+	assert.NotContains(t, plugins.inputs[0].Metadata, pluginapi.MetadataExport)
+
+	server := NewServer(presenter, new(MockRenderer), getTestServerConfig())
+	server.SetPresentation(shown)
+	server.SetPresentationPath(path)
+	exportService := &recordingExportService{tempDir: t.TempDir()}
+	server.SetExportService(exportService)
+
+	w := httptest.NewRecorder()
+	server.handleExport(w, httptest.NewRequest("POST", "/api/export", strings.NewReader(`{"format": "pdf"}`)))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, plugins.inputs, 2, "the deck is rendered again for the export")
+	assert.Equal(t, true, plugins.inputs[1].Metadata[pluginapi.MetadataExport])
+	assert.Equal(t, 0, plugins.inputs[1].Metadata[pluginapi.MetadataSlideIndex])
+	assert.NotSame(t, shown, exportService.presentation)
+	assert.Equal(t, "Deck", exportService.presentation.Title)
+
+	t.Run("source that no longer loads exports the deck as shown", func(t *testing.T) {
+		require.NoError(t, os.Remove(path))
+
+		w := httptest.NewRecorder()
+		server.handleExport(w, httptest.NewRequest("POST", "/api/export", strings.NewReader(`{"format": "pdf"}`)))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Same(t, shown, exportService.presentation)
+	})
+}
+
+func TestHandleExportSlideRange(t *testing.T) {
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	server.SetPresentation(&entities.Presentation{Title: "Deck"})
+	exportService := &recordingExportService{tempDir: t.TempDir()}
+	server.SetExportService(exportService)
+
+	req := httptest.NewRequest("POST", "/api/export", strings.NewReader(`{"format": "pdf", "slide_range": "4-9"}`))
+	w := httptest.NewRecorder()
+	server.handleExport(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "4-9", exportService.options.SlideRange)
+
+	t.Run("invalid range is a bad request", func(t *testing.T) {
+		exportService.err = &export.ExportError{Type: export.ErrorTypeValidation, Code: "INVALID_SLIDE_RANGE"}
+
+		req := httptest.NewRequest("POST", "/api/export", strings.NewReader(`{"format": "pdf", "slide_range": "4-99"}`))
+		w := httptest.NewRecorder()
+		server.handleExport(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandleExportThemeFonts(t *testing.T) {
+	themesDir := t.TempDir()
+	themeDir := filepath.Join(themesDir, "brand")
+	for _, file := range []string{"templates/presentation.html", "templates/slide.html", "templates/notes.html", "assets/css/main.css"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(themeDir, file)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(themeDir, file), []byte(`<div></div>`), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(themeDir, "theme.json"),
+		[]byte(`{"id": "brand", "fonts": [{"name": "Brand", "source": "local", "url": "fonts/brand.woff2"}]}`), 0644))
+
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	server.SetAppConfig(&entities.Config{Theme: entities.ThemeConfig{Name: "brand"}})
+	server.SetPresentation(&entities.Presentation{Title: "Deck"})
+	server.SetThemeLoader(theme.NewDirectoryLoader(themesDir))
+	exportService := &recordingExportService{tempDir: t.TempDir()}
+	server.SetExportService(exportService)
+
+	req := httptest.NewRequest("POST", "/api/export", strings.NewReader(`{"format": "html", "inline_fonts": true}`))
+	w := httptest.NewRecorder()
+	server.handleExport(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []theme.ThemeFont{
+		{Name: "Brand", Source: "local", URL: filepath.Join(themeDir, "fonts", "brand.woff2")},
+	}, exportService.options.ThemeFonts)
+	assert.True(t, exportService.options.InlineFonts)
+
+	t.Run("missing theme exports without its fonts", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/export", strings.NewReader(`{"format": "html", "theme": "missing"}`))
+		w := httptest.NewRecorder()
+		server.handleExport(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, exportService.options.ThemeFonts)
+		assert.False(t, exportService.options.InlineFonts)
+	})
+}
+
+// flakyRenderer fails its first render with a retryable browser error
+type flakyRenderer struct {
+	calls int
+}
+
+func (r *flakyRenderer) Render(ctx context.Context, presentation *entities.Presentation, options *export.ExportOptions) (*export.ExportResult, error) {
+	r.calls++
+	if r.calls == 1 {
+		return nil, &export.ExportError{Type: export.ErrorTypeBrowser, Message: "browser crashed", Retryable: true}
+	}
+	return &export.ExportResult{Success: true, Format: string(options.Format), OutputPath: options.OutputPath}, nil
+}
+
+func (r *flakyRenderer) Supports(format export.ExportFormat) bool { return format == export.FormatPDF }
+func (r *flakyRenderer) GetMimeType() string                      { return "application/pdf" }
+
+// exportServiceAdapter exposes an export.Service through ports.ExportService
+type exportServiceAdapter struct {
+	*export.Service
+}
+
+func (a exportServiceAdapter) Export(ctx context.Context, presentation *entities.Presentation, options interface{}) (interface{}, error) {
+	return a.Service.Export(ctx, presentation, options.(*export.ExportOptions))
+}
+
+func (a exportServiceAdapter) GetSupportedFormats() []string {
+	var formats []string
+	for _, format := range a.Service.GetSupportedFormats() {
+		formats = append(formats, string(format))
+	}
+	return formats
+}
+
+func TestHandleExportReportsRetries(t *testing.T) {
+	exportService, err := export.NewService(t.TempDir())
+	require.NoError(t, err)
+	retryConfig := exportService.GetRetryConfig()
+	retryConfig.InitialDelay = time.Millisecond
+	exportService.SetRetryConfig(retryConfig)
+	renderer := &flakyRenderer{}
+	exportService.RegisterRenderer(export.FormatPDF, renderer)
+
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	server.SetPresentation(&entities.Presentation{Title: "Deck", Slides: []entities.Slide{{Title: "One"}}})
+	server.SetExportService(exportServiceAdapter{exportService})
+
+	req := httptest.NewRequest("POST", "/api/export", strings.NewReader(`{"format": "pdf"}`))
+	w := httptest.NewRecorder()
+	server.handleExport(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, renderer.calls)
+
+	var response struct {
+		RetryCount int `json:"retry_count"`
+		Metadata   struct {
+			ExportMetrics map[string]interface{} `json:"export_metrics"`
+		} `json:"metadata"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.RetryCount)
+
+	metrics := response.Metadata.ExportMetrics
+	assert.EqualValues(t, 1, metrics["retry_count"])
+	assert.Contains(t, metrics, "duration_ms")
+	startTime, ok := metrics["start_time"].(string)
+	require.True(t, ok)
+	_, err = time.Parse(time.RFC3339, startTime)
+	assert.NoError(t, err)
+}
+
+// blockingRenderer renders once release is closed
+type blockingRenderer struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingRenderer) Render(ctx context.Context, presentation *entities.Presentation, options *export.ExportOptions) (*export.ExportResult, error) {
+	close(r.started)
+	<-r.release
+	return &export.ExportResult{Success: true, Format: string(options.Format), OutputPath: options.OutputPath}, nil
+}
+
+func (r *blockingRenderer) Supports(format export.ExportFormat) bool {
+	return format == export.FormatPDF
+}
+func (r *blockingRenderer) GetMimeType() string { return "application/pdf" }
+
+func TestHandleExportAsync(t *testing.T) {
+	exportService, err := export.NewService(t.TempDir())
+	require.NoError(t, err)
+	renderer := &blockingRenderer{started: make(chan struct{}), release: make(chan struct{})}
+	exportService.RegisterRenderer(export.FormatPDF, renderer)
+
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	server.SetPresentation(&entities.Presentation{Title: "Deck", Slides: []entities.Slide{{Title: "One"}}})
+	server.SetExportService(exportServiceAdapter{exportService})
+
+	req := httptest.NewRequest("POST", "/api/export?async=true", strings.NewReader(`{"format": "pdf"}`))
+	w := httptest.NewRecorder()
+	server.handleExport(w, req)
+
+	require.Equal(t, http.StatusAccepted, w.Code, "async exports return before rendering")
+	var started struct {
+		OperationID string `json:"operation_id"`
+		StatusURL   string `json:"status_url"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &started))
+	require.NotEmpty(t, started.OperationID)
+	assert.Equal(t, started.StatusURL, w.Header().Get("Location"))
+
+	poll := func(t *testing.T) ExportStatus {
+		t.Helper()
+		w := httptest.NewRecorder()
+		server.handleExportStatus(w, httptest.NewRequest("GET", started.StatusURL, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var status ExportStatus
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		return status
+	}
+
+	<-renderer.started
+	status := poll(t)
+	assert.Equal(t, started.OperationID, status.OperationID)
+	assert.False(t, status.Done)
+	require.NotNil(t, status.Metrics)
+	assert.Equal(t, export.PhaseRendering, status.Metrics.Phase)
+	assert.Zero(t, status.Metrics.RetryCount)
+
+	close(renderer.release)
+	require.Eventually(t, func() bool { return poll(t).Done }, 2*time.Second, 10*time.Millisecond)
+	status = poll(t)
+	assert.Empty(t, status.Error)
+	assert.Equal(t, export.PhaseCompleted, status.Metrics.Phase)
+	result, ok := status.Result.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, true, result["success"])
+
+	t.Run("unknown export", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.handleExportStatus(w, httptest.NewRequest("GET", "/api/export/status?id=pdf-missing", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestHandlePresenterTasks(t *testing.T) {
+	taskHTML := "<ul>\n<li><input disabled=\"\" type=\"checkbox\"> Install Go</li>\n<li><input disabled=\"\" type=\"checkbox\"> Clone repo</li>\n</ul>\n"
+	presentation := &entities.Presentation{
+		Title: "Workshop",
+		Slides: []entities.Slide{
+			{Index: 0, Title: "Intro", HTML: "<h1>Intro</h1>"},
+			{Index: 1, Title: "Setup", HTML: taskHTML},
+		},
+	}
+
+	newTaskServer := func(t *testing.T, interactive bool) (*Server, *MockRenderer, *notes.Service) {
+		config := getTestServerConfig()
+		config.InteractiveTasks = interactive
+		renderer := new(MockRenderer)
+		server := NewServer(new(MockPresentationService), renderer, config)
+		server.SetPresentation(presentation)
+
+		store := notes.NewService()
+		syncService := services.NewPresentationSyncService(presentation, store)
+		syncService.SetTaskStore(store)
+		t.Cleanup(syncService.Stop)
+		server.SetSyncService(syncService)
+		return server, renderer, store
+	}
+
+	tick := func(server *Server, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/presenter/tasks", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		server.setupRoutes().ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		server, _, _ := newTaskServer(t, false)
+		w := tick(server, `{"slide": 1, "task": 0, "checked": true}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("ticking broadcasts and persists", func(t *testing.T) {
+		server, renderer, store := newTaskServer(t, true)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go server.connMgr.Run(ctx)
+		server.mu.Lock()
+		server.running = true
+		server.mu.Unlock()
+
+		audience := &Connection{ID: "audience", Send: make(chan ports.UpdateEvent, 1)}
+		server.connMgr.RegisterConnection(audience)
+
+		w := tick(server, `{"slide": 1, "task": 1, "checked": true}`)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var state entities.PresenterState
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &state))
+		assert.True(t, state.Tasks[1][1])
+
+		select {
+		case event := <-audience.Send:
+			assert.Equal(t, ports.EventTypeTaskUpdate, event.Type)
+			assert.Equal(t, map[string]interface{}{"slide": float64(1), "task": float64(1), "checked": true}, event.Data)
+		case <-time.After(time.Second):
+			t.Fatal("task update was not sent to connected views")
+		}
+
+		assert.Equal(t, map[int]bool{1: true}, store.GetTaskStates("slide-1"))
+
+		// The deck is rendered with interactive checkboxes in their current state
+		renderer.On("RenderPresentation", mock.Anything, mock.Anything).Return([]byte("<html></html>"), nil)
+		rec := httptest.NewRecorder()
+		server.handlePresentation(rec, httptest.NewRequest("GET", "/", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		rendered := renderer.Calls[0].Arguments.Get(1).(*entities.Presentation)
+		assert.Contains(t, rendered.Slides[1].HTML, `<input class="task-checkbox" data-slide="1" data-task="0" type="checkbox">`)
+		assert.Contains(t, rendered.Slides[1].HTML, `<input checked="" class="task-checkbox" data-slide="1" data-task="1" type="checkbox">`)
+		assert.Equal(t, taskHTML, presentation.Slides[1].HTML, "the loaded presentation is not modified")
+
+		// Exports keep the ticks but render read-only checkboxes
+		exportService := &recordingExportService{tempDir: t.TempDir()}
+		server.SetExportService(exportService)
+		rec = httptest.NewRecorder()
+		server.handleExport(rec, httptest.NewRequest("POST", "/api/export", strings.NewReader(`{"format": "html"}`)))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, exportService.presentation.Slides[1].HTML, `<input checked="" disabled="" type="checkbox"> Clone repo`)
+		assert.Contains(t, exportService.presentation.Slides[1].HTML, `<input disabled="" type="checkbox"> Install Go`)
+	})
+
+	t.Run("rejects out of range tasks", func(t *testing.T) {
+		server, _, _ := newTaskServer(t, true)
+		w := tick(server, `{"slide": 9, "task": 0, "checked": true}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("refused in read-only mode", func(t *testing.T) {
+		server, _, _ := newTaskServer(t, true)
+		server.config.ReadOnly = true
+		w := tick(server, `{"slide": 1, "task": 0, "checked": true}`)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestHandlePerformanceMetricsStream(t *testing.T) {
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	server.SetOptimizationService(optimization.NewOptimizationService(optimization.OptimizationConfig{}))
+	// Served behind the logging middleware, whose writer must support flushing
+	ts := httptest.NewServer(loggingMiddleware(http.HandlerFunc(server.handlePerformanceMetrics)))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"?stream=true&interval=100ms", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	scanner := bufio.NewScanner(resp.Body)
+	var events []map[string]interface{}
+	for len(events) < 2 && scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		var event map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(data), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, events, 2)
+
+	for _, event := range events {
+		metrics, ok := event["metrics"].(map[string]interface{})
+		require.True(t, ok, "event carries a metrics object")
+		assert.Contains(t, metrics, "goroutine_count")
+		assert.Contains(t, metrics, "memory_usage")
+		assert.Contains(t, event, "timestamp")
+	}
+
+	t.Run("invalid interval", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.handlePerformanceMetrics(w, httptest.NewRequest(http.MethodGet, "/api/performance/metrics?stream=true&interval=1ms", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+// fakePluginService runs one echo plugin; other methods are unused
+type fakePluginService struct {
+	ports.PluginService
+	inputs []pluginapi.PluginInput
+	health []entities.PluginHealth
+}
+
+func (f *fakePluginService) PluginHealth() []entities.PluginHealth { return f.health }
+
+func (f *fakePluginService) GetPlugin(name string) (pluginapi.Plugin, error) {
+	if name != "echo" {
+		return nil, fmt.Errorf("plugin %s not found", name)
+	}
+	return nil, nil
+}
+
+func (f *fakePluginService) ExecutePlugin(ctx context.Context, name string, input pluginapi.PluginInput) (pluginapi.PluginOutput, error) {
+	f.inputs = append(f.inputs, input)
+	if input.Content == "fail" {
+		return pluginapi.PluginOutput{}, errors.New("exec: /usr/bin/secret-tool: permission denied")
+	}
+	return pluginapi.PluginOutput{
+		HTML:     "<pre>" + input.Content + "</pre>",
+		Metadata: map[string]interface{}{"language": input.Language},
+	}, nil
+}
+
+func (f *fakePluginService) ExecutePluginStream(ctx context.Context, name string, input pluginapi.PluginInput, emit func(pluginapi.OutputChunk)) (pluginapi.PluginOutput, error) {
+	for _, line := range strings.SplitAfter(input.Content, "\n") {
+		emit(pluginapi.OutputChunk{Stream: "stdout", Data: line})
+	}
+	return f.ExecutePlugin(ctx, name, input)
+}
+
+func TestHandlePluginExecute(t *testing.T) {
+	pluginService := &fakePluginService{}
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	server.SetPluginService(pluginService)
+	handler := server.setupRoutes()
+
+	execute := func(method, name, body, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/plugins/"+name+"/execute", strings.NewReader(body))
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("success", func(t *testing.T) {
+		w := execute(http.MethodPost, "echo", `{"content": "hello", "language": "text", "options": {"theme": "dark"}}`, "198.51.100.1:1234")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response PluginExecuteResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "echo", response.Plugin)
+		assert.Equal(t, "<pre>hello</pre>", response.HTML)
+		assert.Equal(t, "text", response.Metadata["language"])
+
+		require.Len(t, pluginService.inputs, 1)
+		assert.Equal(t, map[string]interface{}{"theme": "dark"}, pluginService.inputs[0].Options)
+	})
+
+	t.Run("unknown plugin", func(t *testing.T) {
+		w := execute(http.MethodPost, "missing", `{"content": "hello"}`, "198.51.100.2:1234")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "Resource not found", response.Message)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := execute(http.MethodGet, "echo", "", "198.51.100.3:1234")
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("plugin errors are sanitized", func(t *testing.T) {
+		w := execute(http.MethodPost, "echo", `{"content": "fail"}`, "198.51.100.4:1234")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.NotContains(t, w.Body.String(), "secret-tool")
+	})
+
+	t.Run("rate limited per client", func(t *testing.T) {
+		for i := 0; i < pluginExecuteLimit; i++ {
+			require.Equal(t, http.StatusOK, execute(http.MethodPost, "echo", `{"content": "hi"}`, "198.51.100.5:1234").Code)
+		}
+		w := execute(http.MethodPost, "echo", `{"content": "hi"}`, "198.51.100.5:1234")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("refused in read-only mode", func(t *testing.T) {
+		config := getTestServerConfig()
+		config.ReadOnly = true
+		readOnly := NewServer(new(MockPresentationService), new(MockRenderer), config)
+		readOnly.SetPluginService(pluginService)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/plugins/echo/execute", strings.NewReader(`{"content": "hi"}`))
+		req.RemoteAddr = "198.51.100.6:1234"
+		w := httptest.NewRecorder()
+		readOnly.setupRoutes().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("streamed output", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/plugins/echo/execute?stream=true", strings.NewReader(`{"content": "one\ntwo"}`))
+		req.RemoteAddr = "198.51.100.7:1234"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		assert.Equal(t, "event: output\ndata: {\"stream\":\"stdout\",\"data\":\"one\\n\"}\n\n"+
+			"event: output\ndata: {\"stream\":\"stdout\",\"data\":\"two\"}\n\n"+
+			"event: result\ndata: {\"plugin\":\"echo\",\"html\":\"\\u003cpre\\u003eone\\ntwo\\u003c/pre\\u003e\",\"metadata\":{\"language\":\"\"}}\n\n",
+			w.Body.String())
+	})
+
+	t.Run("streamed errors are sanitized", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/plugins/echo/execute?stream=true", strings.NewReader(`{"content": "fail"}`))
+		req.RemoteAddr = "198.51.100.8:1234"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Contains(t, w.Body.String(), "event: error\ndata: {\"error\":\"Internal server error\"}")
+		assert.NotContains(t, w.Body.String(), "secret-tool")
+	})
+}
+
+func TestHandlePluginHealth(t *testing.T) {
+	pluginService := &fakePluginService{health: []entities.PluginHealth{
+		{Name: "mermaid", Status: entities.PluginStatusActive, Healthy: true, Executions: 12},
+	}}
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	server.SetPluginService(pluginService)
+
+	get := func() PluginHealthResponse {
+		w := httptest.NewRecorder()
+		server.handlePluginHealth(w, httptest.NewRequest(http.MethodGet, "/api/plugins/health", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response PluginHealthResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	response := get()
+	assert.Equal(t, "healthy", response.Status)
+	require.Len(t, response.Plugins, 1)
+	assert.Equal(t, int64(12), response.Plugins[0].Executions)
+
+	pluginService.health = append(pluginService.health, entities.PluginHealth{
+		Name:    "code-exec",
+		Status:  entities.PluginStatusLoaded,
+		Details: map[string]interface{}{"status": "unhealthy"},
+	})
+	response = get()
+	assert.Equal(t, "degraded", response.Status)
+	assert.Equal(t, "unhealthy", response.Plugins[1].Details["status"])
+}
+
+func TestHandlePresenterNotes(t *testing.T) {
+	deck := filepath.Join(t.TempDir(), "deck.md")
+	newServer := func() (*Server, *notes.Service) {
+		notesService, err := notes.NewFileService(deck)
+		require.NoError(t, err)
+		server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+		server.SetNotesService(notesService)
+		return server, notesService
+	}
+
+	server, notesService := newServer()
+	w := httptest.NewRecorder()
+	server.handlePresenterNotes(w, httptest.NewRequest(http.MethodPost, "/api/presenter/notes",
+		strings.NewReader(`{"slideId": "slide-2", "content": "Mention the *benchmark*"}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"saved"`)
+	require.NoError(t, notesService.Close())
+
+	// A restarted server reads the notes back from the sidecar file
+	restarted, _ := newServer()
+	w = httptest.NewRecorder()
+	restarted.handlePresenterNotes(w, httptest.NewRequest(http.MethodGet, "/api/presenter/notes?slideId=slide-2", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response entities.SpeakerNotes
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "slide-2", response.SlideID)
+	assert.Equal(t, "Mention the *benchmark*", response.Content)
+	assert.Contains(t, response.HTML, "<em>benchmark</em>")
+}
+
+func TestHandlePresenterNavigate(t *testing.T) {
+	presentation := &entities.Presentation{
+		Title: "Talk",
+		Slides: []entities.Slide{
+			{Index: 0, Title: "Intro"},
+			{Index: 1, Title: "Middle"},
+			{Index: 2, Title: "End"},
+		},
+	}
+
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	server.SetPresentation(presentation)
+	syncService := services.NewPresentationSyncService(presentation, nil)
+	t.Cleanup(syncService.Stop)
+	server.SetSyncService(syncService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.connMgr.Run(ctx)
+	server.mu.Lock()
+	server.running = true
+	server.mu.Unlock()
+
+	audience := &Connection{ID: "audience", Send: make(chan ports.UpdateEvent, 2)}
+	server.connMgr.RegisterConnection(audience)
+
+	navigate := func(body string) {
+		req := httptest.NewRequest("POST", "/api/presenter/navigate", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		server.setupRoutes().ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+	}
+	receive := func() ports.UpdateEvent {
+		select {
+		case event := <-audience.Send:
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("navigation was not sent to connected views")
+			return ports.UpdateEvent{}
+		}
+	}
+
+	navigate(`{"action": "next"}`)
+	event := receive()
+	assert.Equal(t, ports.EventTypeNavigation, event.Type)
+	assert.Equal(t, map[string]interface{}{"action": "next", "slide": float64(1)}, event.Data)
+
+	navigate(`{"action": "goto", "slide": 2}`)
+	assert.Equal(t, float64(2), receive().Data.(map[string]interface{})["slide"])
+	assert.Equal(t, 2, syncService.GetState().CurrentSlide)
+
+	// A view connecting late is told the current slide after connecting
+	ts := httptest.NewServer(server.setupRoutes())
+	defer ts.Close()
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	defer func() { _ = ws.Close() }()
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(time.Second)))
+
+	var connected, current ports.UpdateEvent
+	require.NoError(t, ws.ReadJSON(&connected))
+	assert.Equal(t, "connected", connected.Type)
+	require.NoError(t, ws.ReadJSON(&current))
+	assert.Equal(t, ports.EventTypeNavigation, current.Type)
+	assert.Equal(t, float64(2), current.Data.(map[string]interface{})["slide"])
+}
@@ -1,10 +1,16 @@
 package http
 
 import (
+	"bufio"
+	"errors"
 	"net"
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/rs/cors"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 )
 
 // responseWriter wraps http.ResponseWriter to capture status code
@@ -25,13 +31,47 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 	return size, err
 }
 
+// Flush sends buffered data to the client, for server-sent event streams
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack hands the connection over for the WebSocket upgrade on /ws
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	rw.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// quietPaths are requested too often to log each time unless verbose: the
+// live connection and the endpoints health checks and scrapers poll
+var quietPaths = map[string]bool{
+	"/ws":                      true,
+	"/api/presenter/state":     true,
+	"/api/performance/health":  true,
+	"/api/performance/metrics": true,
+	"/metrics":                 true,
+}
+
 // loggingMiddleware logs HTTP requests
 func loggingMiddleware(next http.Handler) http.Handler {
-	return createLoggingMiddleware(next, NewHTTPLogger("middleware", false))
+	return createLoggingMiddleware(next, NewHTTPLogger("middleware", false), nil)
 }
 
-// createLoggingMiddleware creates logging middleware with a specific logger
-func createLoggingMiddleware(next http.Handler, logger *HTTPLogger) http.Handler {
+// createLoggingMiddleware creates logging middleware with a specific logger.
+// Each request is logged at debug level with its status and duration, and
+// counted with countRequest when it isn't nil.
+func createLoggingMiddleware(next http.Handler, logger *HTTPLogger, countRequest func()) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
@@ -44,9 +84,16 @@ func createLoggingMiddleware(next http.Handler, logger *HTTPLogger) http.Handler
 		// Process request
 		next.ServeHTTP(wrapped, r)
 
+		if countRequest != nil {
+			countRequest()
+		}
+		if quietPaths[r.URL.Path] && !logger.verbose {
+			return
+		}
+
 		// Log the request
 		duration := time.Since(start)
-		logger.Info(
+		logger.Debug(
 			"HTTP %s %s - %d %d bytes in %v",
 			r.Method,
 			r.URL.Path,
@@ -92,6 +139,20 @@ func securityHeadersMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// corsHandler lets the configured origins call the server from other
+// sites: "*" allows any origin and "https://*.example.com" its subdomains.
+// Other origins get no CORS headers, so browsers block them.
+func corsHandler(next http.Handler, config *entities.ServerConfig) http.Handler {
+	c := cors.New(cors.Options{
+		AllowedOrigins:   config.GetCORSOrigins(),
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
+		AllowedHeaders:   []string{"*"},
+		AllowCredentials: false,
+		MaxAge:           300, // 5 minutes
+	})
+	return c.Handler(next)
+}
+
 // rateLimiter manages rate limiting per IP
 type rateLimiter struct {
 	mu      sync.RWMutex
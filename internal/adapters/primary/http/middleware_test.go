@@ -1,11 +1,15 @@
 package http
 
 import (
+	"bytes"
+	"log"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 )
 
 func TestLoggingMiddleware(t *testing.T) {
@@ -26,6 +30,50 @@ func TestLoggingMiddleware(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestLoggingMiddlewareRequests(t *testing.T) {
+	var buf bytes.Buffer
+	output := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(output)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	mux.HandleFunc("/api/presenter/state", func(w http.ResponseWriter, r *http.Request) {})
+
+	requests := 0
+	countRequest := func() { requests++ }
+	logger := NewHTTPLoggerWithLevel("server", false, entities.LogLevelDebug)
+	handler := createLoggingMiddleware(mux, logger, countRequest)
+
+	for _, path := range []string{"/ok", "/missing", "/api/presenter/state"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.Equal(t, 3, requests)
+	assert.Contains(t, buf.String(), "[DEBUG] [server] HTTP GET /ok - 200 2 bytes")
+	assert.Contains(t, buf.String(), "[DEBUG] [server] HTTP GET /missing - 404")
+	assert.NotContains(t, buf.String(), "/api/presenter/state", "polled endpoints are only logged when verbose")
+
+	t.Run("verbose logs polled endpoints", func(t *testing.T) {
+		buf.Reset()
+		logger := NewHTTPLoggerWithLevel("server", true, entities.LogLevelDebug)
+		handler := createLoggingMiddleware(mux, logger, nil)
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/presenter/state", nil))
+		assert.Contains(t, buf.String(), "HTTP GET /api/presenter/state - 200")
+	})
+
+	t.Run("info level hides requests", func(t *testing.T) {
+		buf.Reset()
+		handler := createLoggingMiddleware(mux, NewHTTPLogger("server", true), nil)
+
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+		assert.Empty(t, buf.String())
+	})
+}
+
 func TestRecoveryMiddleware(t *testing.T) {
 	t.Run("normal operation", func(t *testing.T) {
 		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,272 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/export"
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+)
+
+// maxBrowserExports limits how many exports drive headless Chrome at once,
+// shared by export requests and background pre-generation
+const maxBrowserExports = 2
+
+// PregeneratedExport describes the latest background export of one format
+type PregeneratedExport struct {
+	Format string `json:"format"`
+	// File is passed to /api/export/download to fetch the export
+	File        string    `json:"file,omitempty"`
+	GeneratedAt time.Time `json:"generated_at,omitempty"`
+	// Fresh is true when the export reflects the latest reloaded presentation
+	Fresh bool   `json:"fresh"`
+	Error string `json:"error,omitempty"`
+}
+
+// pregenerated is a background export along with the revision it was built from
+type pregenerated struct {
+	PregeneratedExport
+	revision int
+}
+
+// exportPregenerator rebuilds the configured export formats in the
+// background, debounced, each time the presentation is reloaded
+type exportPregenerator struct {
+	server   *Server
+	formats  []string
+	debounce time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	timer    *time.Timer
+	pending  *entities.Presentation
+	revision int
+	latest   map[string]*pregenerated
+
+	// runMu keeps one generation pass at a time
+	runMu sync.Mutex
+	built int
+}
+
+// newExportPregenerator creates a pregenerator for the server's configured formats
+func newExportPregenerator(server *Server) *exportPregenerator {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &exportPregenerator{
+		server:   server,
+		formats:  server.config.PregenerateExports,
+		debounce: server.config.GetPregenerateDebounce(),
+		ctx:      ctx,
+		cancel:   cancel,
+		latest:   make(map[string]*pregenerated),
+	}
+}
+
+// schedule queues regeneration for p once no newer presentation arrives
+// within the debounce period
+func (g *exportPregenerator) schedule(p *entities.Presentation) {
+	if len(g.formats) == 0 || g.ctx.Err() != nil {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.revision++
+	g.pending = p
+	if g.timer == nil {
+		g.timer = time.AfterFunc(g.debounce, g.run)
+	} else {
+		g.timer.Reset(g.debounce)
+	}
+}
+
+// run regenerates every configured format from the latest presentation
+func (g *exportPregenerator) run() {
+	g.runMu.Lock()
+	defer g.runMu.Unlock()
+
+	g.mu.Lock()
+	p, revision := g.pending, g.revision
+	g.mu.Unlock()
+
+	// An earlier pass may already have picked up this revision
+	if p == nil || revision == g.built {
+		return
+	}
+	g.built = revision
+
+	for _, format := range g.formats {
+		if g.ctx.Err() != nil {
+			return
+		}
+		g.generate(p, revision, format)
+	}
+}
+
+// generate exports p in one format, replacing the previous pre-generated file
+// only once the new one is complete
+func (g *exportPregenerator) generate(p *entities.Presentation, revision int, format string) {
+	result := &pregenerated{
+		PregeneratedExport: PregeneratedExport{Format: format},
+		revision:           revision,
+	}
+
+	filename, err := g.server.pregenerateExport(g.ctx, p, export.ExportFormat(format), revision)
+	if err != nil {
+		if g.ctx.Err() != nil {
+			return
+		}
+		g.server.logger.Warn("Background %s export failed: %v", format, err)
+		result.Error = err.Error()
+	} else {
+		result.File = filename
+		result.GeneratedAt = time.Now()
+	}
+
+	g.mu.Lock()
+	previous := g.latest[format]
+	if err != nil && previous != nil {
+		// Keep serving the last good export until a new one succeeds
+		result.File = previous.File
+		result.GeneratedAt = previous.GeneratedAt
+	}
+	g.latest[format] = result
+	g.mu.Unlock()
+
+	if err == nil && previous != nil && previous.File != "" && previous.File != filename {
+		g.server.removeExport(previous.File)
+	}
+}
+
+// status reports the latest pre-generated export of each configured format
+func (g *exportPregenerator) status() []PregeneratedExport {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	exports := make([]PregeneratedExport, 0, len(g.formats))
+	for _, format := range g.formats {
+		latest, ok := g.latest[format]
+		if !ok {
+			exports = append(exports, PregeneratedExport{Format: format})
+			continue
+		}
+		entry := latest.PregeneratedExport
+		entry.Fresh = latest.Error == "" && latest.revision == g.revision
+		exports = append(exports, entry)
+	}
+	return exports
+}
+
+// stop cancels pending and running regeneration
+func (g *exportPregenerator) stop() {
+	g.cancel()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.timer != nil {
+		g.timer.Stop()
+	}
+}
+
+// UpdatePresentation serves a reloaded presentation and, when configured,
+// regenerates its exports in the background
+func (s *Server) UpdatePresentation(p *entities.Presentation) {
+	s.SetPresentation(p)
+	s.pregenerator.schedule(p)
+}
+
+// pregenerateExport writes a background export into the export directory
+// and returns its file name
+func (s *Server) pregenerateExport(ctx context.Context, p *entities.Presentation, format export.ExportFormat, revision int) (string, error) {
+	s.mu.RLock()
+	exportService := s.exportService
+	presentationDir := s.presentationDir
+	s.mu.RUnlock()
+
+	if exportService == nil {
+		return "", errors.New("export service not available")
+	}
+
+	release, err := s.acquireBrowser(ctx, format)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	ext := string(format)
+	if format.WritesDirectory() {
+		ext = "" // Directory of per-slide files
+	}
+	filename := fmt.Sprintf("pregenerated-%d-%s", revision,
+		export.ExportFilename(p.Title, ext, export.ParseFilenameMode(s.config.ExportFilenames), time.Now()))
+
+	options := &export.ExportOptions{
+		Format:       format,
+		OutputPath:   filepath.Join(exportService.GetTempDir(), filename),
+		Fonts:        s.config.ExportFonts,
+		SubsetFonts:  s.config.SubsetFonts,
+		SourceDir:    presentationDir,
+		InlineImages: true,
+		ThemeFonts:   s.exportThemeFonts(ctx, ""),
+	}
+	result, err := exportService.Export(ctx, s.withTaskStates(p, false), options)
+	if err != nil {
+		return "", err
+	}
+
+	// A fallback format writes elsewhere, e.g. HTML when a PDF export fails
+	if exported, ok := result.(*export.ExportResult); ok && exported.OutputPath != "" {
+		filename = filepath.Base(exported.OutputPath)
+	}
+	return filename, nil
+}
+
+// removeExport deletes a superseded export from the export directory
+func (s *Server) removeExport(filename string) {
+	s.mu.RLock()
+	exportService := s.exportService
+	s.mu.RUnlock()
+
+	if exportService == nil {
+		return
+	}
+	_ = os.RemoveAll(filepath.Join(exportService.GetTempDir(), filepath.Base(filename)))
+}
+
+// acquireBrowser waits for a headless Chrome slot when format needs one and
+// returns the function releasing it
+func (s *Server) acquireBrowser(ctx context.Context, format export.ExportFormat) (func(), error) {
+	if !format.UsesBrowser() {
+		return func() {}, nil
+	}
+
+	select {
+	case s.browserSlots <- struct{}{}:
+		return func() { <-s.browserSlots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// handleExportPregenerated reports the background exports kept ready for download
+func (s *Server) handleExportPregenerated(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.writeJSON(w, map[string]interface{}{
+		"exports": s.pregenerator.status(),
+	})
+}
+
+var _ ports.PresentationUpdater = (*Server)(nil)
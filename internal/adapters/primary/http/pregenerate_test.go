@@ -0,0 +1,216 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/export"
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// fileExportService writes each export's presentation title to its output
+// path, or to the same path with fallbackExt when set, like an export that
+// fell back to another format
+type fileExportService struct {
+	tempDir     string
+	fail        atomic.Bool
+	fallbackExt string
+
+	mu      sync.Mutex
+	exports []*export.ExportOptions
+	active  int
+	peak    int
+}
+
+func (s *fileExportService) Export(ctx context.Context, presentation *entities.Presentation, options interface{}) (interface{}, error) {
+	opts := options.(*export.ExportOptions)
+
+	s.mu.Lock()
+	s.exports = append(s.exports, opts)
+	s.active++
+	s.peak = max(s.peak, s.active)
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.active--
+		s.mu.Unlock()
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	if s.fail.Load() {
+		return nil, errors.New("chrome crashed")
+	}
+	outputPath := opts.OutputPath
+	if s.fallbackExt != "" {
+		outputPath = strings.TrimSuffix(outputPath, filepath.Ext(outputPath)) + s.fallbackExt
+	}
+	return &export.ExportResult{Success: true, OutputPath: outputPath}, os.WriteFile(outputPath, []byte(presentation.Title), 0600)
+}
+
+func (s *fileExportService) GetSupportedFormats() []string { return []string{"pdf", "html"} }
+func (s *fileExportService) GetTempDir() string            { return s.tempDir }
+
+func (s *fileExportService) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.exports)
+}
+
+// pregeneratedStatus fetches /api/export/pregenerated
+func pregeneratedStatus(t *testing.T, server *Server) []PregeneratedExport {
+	w := httptest.NewRecorder()
+	server.handleExportPregenerated(w, httptest.NewRequest(http.MethodGet, "/api/export/pregenerated", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Exports []PregeneratedExport `json:"exports"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body.Exports
+}
+
+func TestServerPregeneratesExports(t *testing.T) {
+	config := getTestServerConfig()
+	config.PregenerateExports = []string{"pdf", "html"}
+	config.PregenerateDebounceMs = 50
+
+	server := NewServer(new(MockPresentationService), new(MockRenderer), config)
+	defer server.pregenerator.stop()
+	exportService := &fileExportService{tempDir: t.TempDir()}
+	server.SetExportService(exportService)
+
+	status := pregeneratedStatus(t, server)
+	require.Len(t, status, 2)
+	assert.Empty(t, status[0].File)
+	assert.False(t, status[0].Fresh)
+
+	// A quick series of saves only regenerates once
+	for _, title := range []string{"Draft 1", "Draft 2", "Draft 3"} {
+		server.UpdatePresentation(&entities.Presentation{Title: title})
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, "Draft 3", server.GetPresentation().Title)
+
+	require.Eventually(t, func() bool { return exportService.count() == 2 }, 2*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return pregeneratedStatus(t, server)[1].Fresh }, time.Second, 10*time.Millisecond)
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(t, 2, exportService.count())
+
+	status = pregeneratedStatus(t, server)
+	for i, format := range []string{"pdf", "html"} {
+		assert.Equal(t, format, status[i].Format)
+		assert.True(t, status[i].Fresh)
+		assert.False(t, status[i].GeneratedAt.IsZero())
+		assert.Empty(t, status[i].Error)
+
+		content, err := os.ReadFile(filepath.Join(exportService.tempDir, status[i].File))
+		require.NoError(t, err)
+		assert.Equal(t, "Draft 3", string(content))
+	}
+	firstPDF := status[0].File
+
+	t.Run("download serves the pre-generated export", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.handleExportDownload(w, httptest.NewRequest(http.MethodGet, "/api/export/download?file="+firstPDF, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Draft 3", w.Body.String())
+	})
+
+	t.Run("stale until the next save is exported", func(t *testing.T) {
+		server.UpdatePresentation(&entities.Presentation{Title: "Final"})
+		assert.False(t, pregeneratedStatus(t, server)[0].Fresh)
+
+		require.Eventually(t, func() bool {
+			status := pregeneratedStatus(t, server)
+			return status[0].Fresh && status[1].Fresh
+		}, 2*time.Second, 10*time.Millisecond)
+
+		status := pregeneratedStatus(t, server)
+		assert.NotEqual(t, firstPDF, status[0].File)
+		assert.NoFileExists(t, filepath.Join(exportService.tempDir, firstPDF), "superseded exports are removed")
+	})
+
+	t.Run("failed export keeps the last good file", func(t *testing.T) {
+		good := pregeneratedStatus(t, server)[0].File
+		exportService.fail.Store(true)
+		defer exportService.fail.Store(false)
+
+		server.UpdatePresentation(&entities.Presentation{Title: "Broken"})
+		require.Eventually(t, func() bool { return pregeneratedStatus(t, server)[0].Error != "" }, 2*time.Second, 10*time.Millisecond)
+
+		status := pregeneratedStatus(t, server)[0]
+		assert.False(t, status.Fresh)
+		assert.Equal(t, good, status.File)
+		assert.FileExists(t, filepath.Join(exportService.tempDir, good))
+	})
+}
+
+func TestServerPregeneratedExportFallback(t *testing.T) {
+	config := getTestServerConfig()
+	config.PregenerateExports = []string{"pdf"}
+	config.PregenerateDebounceMs = 10
+
+	server := NewServer(new(MockPresentationService), new(MockRenderer), config)
+	defer server.pregenerator.stop()
+	exportService := &fileExportService{tempDir: t.TempDir(), fallbackExt: ".html"}
+	server.SetExportService(exportService)
+
+	server.UpdatePresentation(&entities.Presentation{Title: "Talk"})
+	require.Eventually(t, func() bool { return pregeneratedStatus(t, server)[0].Fresh }, 2*time.Second, 10*time.Millisecond)
+
+	// The status names the file the fallback wrote, which downloads
+	status := pregeneratedStatus(t, server)[0]
+	assert.Equal(t, ".html", filepath.Ext(status.File))
+
+	w := httptest.NewRecorder()
+	server.handleExportDownload(w, httptest.NewRequest(http.MethodGet, "/api/export/download?file="+status.File, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Talk", w.Body.String())
+}
+
+func TestServerPregenerationDisabledByDefault(t *testing.T) {
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	exportService := &fileExportService{tempDir: t.TempDir()}
+	server.SetExportService(exportService)
+
+	server.UpdatePresentation(&entities.Presentation{Title: "Talk"})
+	time.Sleep(100 * time.Millisecond)
+
+	assert.Equal(t, "Talk", server.GetPresentation().Title)
+	assert.Zero(t, exportService.count())
+	assert.Empty(t, pregeneratedStatus(t, server))
+}
+
+func TestServerBrowserExportLimit(t *testing.T) {
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	exportService := &fileExportService{tempDir: t.TempDir()}
+	server.SetExportService(exportService)
+
+	var wg sync.WaitGroup
+	for i := 0; i < maxBrowserExports*3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := server.pregenerateExport(context.Background(), &entities.Presentation{Title: "Talk"}, export.FormatPDF, i)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, maxBrowserExports*3, exportService.count())
+	assert.LessOrEqual(t, exportService.peak, maxBrowserExports)
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/monitoring"
+)
+
+// prometheusContentType is the media type of the Prometheus text exposition format
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// prometheusMetric is one metric exposed at /metrics
+type prometheusMetric struct {
+	name  string
+	help  string
+	kind  string // counter or gauge
+	value func(m *monitoring.PerformanceMetrics) float64
+}
+
+// prometheusMetrics lists the performance monitor's metrics in the order
+// they're exposed
+var prometheusMetrics = []prometheusMetric{
+	{
+		name:  "slicli_http_requests_total",
+		help:  "HTTP requests served.",
+		kind:  "counter",
+		value: func(m *monitoring.PerformanceMetrics) float64 { return float64(m.HTTPRequests) },
+	},
+	{
+		name:  "slicli_plugin_executions_total",
+		help:  "Plugin executions.",
+		kind:  "counter",
+		value: func(m *monitoring.PerformanceMetrics) float64 { return float64(m.PluginExecutions) },
+	},
+	{
+		name:  "slicli_websocket_connections_total",
+		help:  "WebSocket connections opened.",
+		kind:  "counter",
+		value: func(m *monitoring.PerformanceMetrics) float64 { return float64(m.WebSocketConnections) },
+	},
+	{
+		name:  "slicli_heap_size_bytes",
+		help:  "Bytes of allocated heap objects.",
+		kind:  "gauge",
+		value: func(m *monitoring.PerformanceMetrics) float64 { return float64(m.HeapSize) },
+	},
+	{
+		name:  "slicli_goroutines",
+		help:  "Goroutines that currently exist.",
+		kind:  "gauge",
+		value: func(m *monitoring.PerformanceMetrics) float64 { return float64(m.GoroutineCount) },
+	},
+	{
+		name:  "slicli_average_render_time_seconds",
+		help:  "Moving average of slide render times.",
+		kind:  "gauge",
+		value: func(m *monitoring.PerformanceMetrics) float64 { return m.AverageRenderTime.Seconds() },
+	},
+}
+
+// handlePrometheusMetrics serves the performance metrics in the Prometheus
+// text format. It's only available with metrics enabled in the server config.
+func (s *Server) handlePrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+	if !s.config.Metrics {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.RLock()
+	optimizationSvc := s.optimizationSvc
+	s.mu.RUnlock()
+
+	if optimizationSvc == nil {
+		http.Error(w, "Performance monitoring not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	metrics := optimizationSvc.GetPerformanceMonitor().GetMetrics()
+	w.Header().Set("Content-Type", prometheusContentType)
+	if err := writePrometheusMetrics(w, &metrics); err != nil {
+		s.logger.Error("Failed to write metrics response: %v", err)
+	}
+}
+
+// writePrometheusMetrics writes each metric with its HELP and TYPE lines
+func writePrometheusMetrics(w io.Writer, metrics *monitoring.PerformanceMetrics) error {
+	for _, metric := range prometheusMetrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %g\n",
+			metric.name, metric.help, metric.name, metric.kind, metric.name, metric.value(metrics)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
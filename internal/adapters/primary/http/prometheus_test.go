@@ -0,0 +1,107 @@
+package http
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/optimization"
+)
+
+// prometheusMetricName matches a valid Prometheus metric name
+var prometheusMetricName = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// parsePrometheusText parses unlabelled samples in the Prometheus text
+// exposition format, failing on lines a scraper would reject
+func parsePrometheusText(t *testing.T, body io.Reader) (map[string]float64, map[string]string) {
+	t.Helper()
+	samples := make(map[string]float64)
+	types := make(map[string]string)
+	helped := make(map[string]bool)
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if comment, ok := strings.CutPrefix(line, "# "); ok {
+			fields := strings.SplitN(comment, " ", 3)
+			require.Len(t, fields, 3, "malformed comment %q", line)
+			keyword, name, rest := fields[0], fields[1], fields[2]
+			require.Regexp(t, prometheusMetricName, name)
+			switch keyword {
+			case "HELP":
+				require.False(t, helped[name], "second HELP for %s", name)
+				helped[name] = true
+			case "TYPE":
+				require.Contains(t, []string{"counter", "gauge", "histogram", "summary", "untyped"}, rest)
+				_, sampled := samples[name]
+				require.False(t, sampled, "TYPE for %s after its samples", name)
+				types[name] = rest
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		require.Len(t, fields, 2, "malformed sample %q", line)
+		require.Regexp(t, prometheusMetricName, fields[0])
+		value, err := strconv.ParseFloat(fields[1], 64)
+		require.NoError(t, err, "sample %q", line)
+		_, duplicate := samples[fields[0]]
+		require.False(t, duplicate, "second sample for %s", fields[0])
+		samples[fields[0]] = value
+	}
+	require.NoError(t, scanner.Err())
+	return samples, types
+}
+
+func TestHandlePrometheusMetrics(t *testing.T) {
+	config := getTestServerConfig()
+	config.Metrics = true
+	server := NewServer(new(MockPresentationService), new(MockRenderer), config)
+	optimizationSvc := optimization.NewOptimizationService(optimization.OptimizationConfig{})
+	server.SetOptimizationService(optimizationSvc)
+
+	monitor := optimizationSvc.GetPerformanceMonitor()
+	monitor.RecordHTTPRequest()
+	monitor.RecordHTTPRequest()
+	monitor.RecordPluginExecution(0)
+
+	ts := httptest.NewServer(server.setupRoutes())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/metrics")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, prometheusContentType, resp.Header.Get("Content-Type"))
+
+	samples, types := parsePrometheusText(t, resp.Body)
+	assert.Equal(t, 2.0, samples["slicli_http_requests_total"])
+	assert.Equal(t, 1.0, samples["slicli_plugin_executions_total"])
+	for _, name := range []string{"slicli_websocket_connections_total", "slicli_heap_size_bytes", "slicli_goroutines", "slicli_average_render_time_seconds"} {
+		assert.Contains(t, samples, name)
+	}
+	assert.Equal(t, "counter", types["slicli_http_requests_total"])
+	assert.Equal(t, "gauge", types["slicli_heap_size_bytes"])
+
+	t.Run("hidden unless enabled", func(t *testing.T) {
+		server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+		server.SetOptimizationService(optimizationSvc)
+
+		w := httptest.NewRecorder()
+		server.handlePrometheusMetrics(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
@@ -12,8 +13,8 @@ import (
 	"sync"
 	"time"
 
-	"github.com/rs/cors"
-
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/certs"
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/notes"
 	"github.com/fredcamaral/slicli/internal/adapters/secondary/optimization"
 	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/internal/domain/ports"
@@ -101,18 +102,29 @@ func (l *HTTPLogger) SetLevel(level entities.LogLevel) {
 
 // Server implements the HTTPServer interface
 type Server struct {
-	server          *http.Server
-	connMgr         *ConnectionManager
-	presenter       ports.PresentationService
-	renderer        ports.Renderer
-	presentation    *entities.Presentation // Store current presentation
-	syncService     ports.PresentationSync
-	exportService   ports.ExportService
-	optimizationSvc *optimization.OptimizationService
-	config          *entities.ServerConfig // Store server configuration
-	logger          *HTTPLogger            // Structured logger
-	mu              sync.RWMutex
-	running         bool
+	server           *http.Server
+	connMgr          *ConnectionManager
+	presenter        ports.PresentationService
+	renderer         ports.Renderer
+	presentation     *entities.Presentation // Store current presentation
+	presentationDir  string                 // Directory relative slide images are exported from
+	presentationPath string                 // Source file exports render the deck from again
+	syncService      ports.PresentationSync
+	notesService     ports.NotesService
+	exportService    ports.ExportService
+	themeLoader      ports.ThemeLoader // Loads the theme whose fonts exports declare
+	optimizationSvc  *optimization.OptimizationService
+	pluginService    ports.PluginService
+	config           *entities.ServerConfig // Store server configuration
+	appConfig        *entities.Config       // Live application config, replaced on reload
+	liveReload       WatcherConfigSetter    // Receives reloaded debounce and retry settings
+	liveTheme        string                 // Theme a config reload switched to, over the deck's own
+	logger           *HTTPLogger            // Structured logger
+	browserSlots     chan struct{}          // Limits concurrent headless Chrome exports
+	pregenerator     *exportPregenerator
+	exportJobs       *exportJobs // Exports started with ?async=true
+	mu               sync.RWMutex
+	running          bool
 }
 
 // NewServer creates a new HTTP server
@@ -121,13 +133,17 @@ func NewServer(presenter ports.PresentationService, renderer ports.Renderer, con
 	if config == nil {
 		panic("server config cannot be nil - provide a valid ServerConfig")
 	}
-	return &Server{
-		presenter: presenter,
-		renderer:  renderer,
-		connMgr:   NewConnectionManager(),
-		config:    config,
-		logger:    NewHTTPLogger("server", false), // Default logger, can be overridden
+	s := &Server{
+		presenter:    presenter,
+		renderer:     renderer,
+		connMgr:      NewConnectionManager(),
+		config:       config,
+		logger:       NewHTTPLogger("server", false), // Default logger, can be overridden
+		browserSlots: make(chan struct{}, maxBrowserExports),
+		exportJobs:   newExportJobs(),
 	}
+	s.pregenerator = newExportPregenerator(s)
+	return s
 }
 
 // NewServerWithLogging creates a new HTTP server with logging configuration
@@ -144,13 +160,17 @@ func NewServerWithLogging(presenter ports.PresentationService, renderer ports.Re
 		verbose = loggingConfig.Verbose
 	}
 
-	return &Server{
-		presenter: presenter,
-		renderer:  renderer,
-		connMgr:   NewConnectionManager(),
-		config:    config,
-		logger:    NewHTTPLoggerWithLevel("server", verbose, level),
+	s := &Server{
+		presenter:    presenter,
+		renderer:     renderer,
+		connMgr:      NewConnectionManager(),
+		config:       config,
+		logger:       NewHTTPLoggerWithLevel("server", verbose, level),
+		browserSlots: make(chan struct{}, maxBrowserExports),
+		exportJobs:   newExportJobs(),
 	}
+	s.pregenerator = newExportPregenerator(s)
+	return s
 }
 
 // SetLogger sets the HTTP logger with verbose configuration
@@ -167,6 +187,32 @@ func (s *Server) SetSyncService(syncService ports.PresentationSync) {
 	s.syncService = syncService
 }
 
+// SetNotesService sets the service the presenter notes endpoint reads and
+// writes through
+func (s *Server) SetNotesService(notesService ports.NotesService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.notesService = notesService
+}
+
+// OpenNotes loads the speaker notes saved next to the presentation at path
+// and saves edits from the presenter view there. A notes service set with
+// SetNotesService is kept.
+func (s *Server) OpenNotes(presentationPath string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.notesService != nil {
+		return nil
+	}
+
+	notesService, err := notes.NewFileService(presentationPath)
+	if err != nil {
+		return err
+	}
+	s.notesService = notesService
+	return nil
+}
+
 // SetExportService sets the export service
 func (s *Server) SetExportService(exportService ports.ExportService) {
 	s.mu.Lock()
@@ -174,6 +220,13 @@ func (s *Server) SetExportService(exportService ports.ExportService) {
 	s.exportService = exportService
 }
 
+// SetThemeLoader sets the loader of the theme exports take their fonts from
+func (s *Server) SetThemeLoader(themeLoader ports.ThemeLoader) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.themeLoader = themeLoader
+}
+
 // SetOptimizationService sets the optimization service
 func (s *Server) SetOptimizationService(optimizationSvc *optimization.OptimizationService) {
 	s.mu.Lock()
@@ -181,6 +234,25 @@ func (s *Server) SetOptimizationService(optimizationSvc *optimization.Optimizati
 	s.optimizationSvc = optimizationSvc
 }
 
+// recordHTTPRequest counts a served request in the performance metrics
+func (s *Server) recordHTTPRequest() {
+	s.mu.RLock()
+	optimizationSvc := s.optimizationSvc
+	s.mu.RUnlock()
+
+	if optimizationSvc != nil {
+		optimizationSvc.GetPerformanceMonitor().RecordHTTPRequest()
+	}
+}
+
+// SetPluginService sets the plugin service behind the plugin health and
+// execute endpoints
+func (s *Server) SetPluginService(pluginService ports.PluginService) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pluginService = pluginService
+}
+
 // SetPresentation sets the current presentation
 func (s *Server) SetPresentation(p *entities.Presentation) {
 	s.mu.Lock()
@@ -188,6 +260,22 @@ func (s *Server) SetPresentation(p *entities.Presentation) {
 	s.presentation = p
 }
 
+// SetPresentationDir sets the directory of the presentation's source file,
+// which exports resolve relative image paths against
+func (s *Server) SetPresentationDir(dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.presentationDir = dir
+}
+
+// SetPresentationPath sets the presentation's source file, which exports
+// render again so plugins know they're rendering an export
+func (s *Server) SetPresentationPath(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.presentationPath = path
+}
+
 // GetPresentation returns the current presentation
 func (s *Server) GetPresentation() *entities.Presentation {
 	s.mu.RLock()
@@ -213,19 +301,7 @@ func (s *Server) Start(ctx context.Context, port int, host string) error {
 		}
 	}
 
-	router := s.setupRoutes()
-
-	// Add CORS middleware with configurable origins from config
-	corsOrigins := s.config.GetCORSOrigins()
-
-	c := cors.New(cors.Options{
-		AllowedOrigins:   corsOrigins,
-		AllowedMethods:   []string{"GET", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Accept"},
-		AllowCredentials: false,
-		MaxAge:           300, // 5 minutes
-	})
-	handler := c.Handler(router)
+	handler := s.setupRoutes()
 
 	s.server = &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", host, port),
@@ -234,13 +310,31 @@ func (s *Server) Start(ctx context.Context, port int, host string) error {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+
+	if s.config.TLS.Enabled {
+		bundle, err := certs.FromServerConfig(*s.config)
+		if err != nil {
+			s.mu.Unlock()
+			return fmt.Errorf("setting up TLS: %w", err)
+		}
+		s.server.TLSConfig = bundle.Config
+		if bundle.SelfSigned {
+			s.logger.Info("Using self-signed certificate, CA SHA-256 fingerprint: %s", bundle.Fingerprint)
+		}
+	}
 	s.running = true
 	s.mu.Unlock()
 
 	// Start server in goroutine
 	go func() {
 		s.logger.Info("HTTP server starting on %s:%d", host, port)
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		var err error
+		if s.server.TLSConfig != nil {
+			err = s.server.ListenAndServeTLS("", "")
+		} else {
+			err = s.server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
 			s.logger.Error("HTTP server error: %v", err)
 		}
 	}()
@@ -260,6 +354,9 @@ func (s *Server) Stop(ctx context.Context) error {
 	// Close all WebSocket connections
 	s.connMgr.CloseAll()
 
+	// Abandon background exports
+	s.pregenerator.stop()
+
 	// Stop optimization service if available
 	if s.optimizationSvc != nil {
 		s.optimizationSvc.Stop()
@@ -273,6 +370,13 @@ func (s *Server) Stop(ctx context.Context) error {
 		return fmt.Errorf("server shutdown: %w", err)
 	}
 
+	// Write notes edited since the last save
+	if closer, ok := s.notesService.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			s.logger.Error("Failed to save presenter notes: %v", err)
+		}
+	}
+
 	s.running = false
 	return nil
 }
@@ -306,40 +410,67 @@ func (s *Server) setupRoutes() http.Handler {
 
 	// API endpoints
 	mux.HandleFunc("/api/slides", s.handleSlides)
+	mux.HandleFunc("/api/toc", s.handleTOC)
+	mux.HandleFunc("/api/slides/timing", s.handleSlidesTiming)
 	mux.HandleFunc("/api/config", s.handleConfig)
 
 	// Presenter API endpoints
 	mux.HandleFunc("/api/presenter/state", s.handlePresenterState)
-	mux.HandleFunc("/api/presenter/notes", s.handlePresenterNotes)
-	mux.HandleFunc("/api/presenter/navigate", s.handlePresenterNavigate)
-	mux.HandleFunc("/api/presenter/timer", s.handlePresenterTimer)
+	mux.HandleFunc("/api/presenter/notes", s.mutating(s.handlePresenterNotes, http.MethodGet))
+	mux.HandleFunc("/api/presenter/navigate", s.mutating(s.handlePresenterNavigate))
+	mux.HandleFunc("/api/presenter/timer", s.mutating(s.handlePresenterTimer))
+	mux.HandleFunc("/api/presenter/tasks", s.mutating(s.handlePresenterTasks))
 
 	// Export API endpoints
-	mux.HandleFunc("/api/export", s.handleExport)
+	mux.HandleFunc("/api/export", s.mutating(s.handleExport))
 	mux.HandleFunc("/api/export/formats", s.handleExportFormats)
-	mux.HandleFunc("/api/export/download", s.handleExportDownload)
+	mux.HandleFunc("/api/export/status", s.handleExportStatus)
+	mux.HandleFunc("/api/export/download", s.mutating(s.handleExportDownload))
+	mux.HandleFunc("/api/export/pregenerated", s.handleExportPregenerated)
 
 	// Performance monitoring endpoints
 	mux.HandleFunc("/api/performance/health", s.handlePerformanceHealth)
 	mux.HandleFunc("/api/performance/metrics", s.handlePerformanceMetrics)
-	mux.HandleFunc("/api/performance/optimize", s.handlePerformanceOptimize)
+	mux.HandleFunc("/api/performance/optimize", s.mutating(s.handlePerformanceOptimize))
+	mux.HandleFunc("/api/plugins/health", s.handlePluginHealth)
+	mux.HandleFunc("/api/plugins/{name}/execute", s.mutating(s.handlePluginExecute))
+	mux.HandleFunc("/metrics", s.handlePrometheusMetrics)
 
 	// Presentation endpoints
-	mux.HandleFunc("/presenter", s.handlePresenterView)
+	mux.HandleFunc("/presenter", s.mutating(s.handlePresenterView))
 	mux.HandleFunc("/", s.handlePresentation)
 
 	// Static files with path validation
 	mux.Handle("/assets/", http.StripPrefix("/assets/", s.secureFileServer("web/assets")))
 
-	// Apply middleware in order: security -> rate limiting -> logging -> recovery
-	handler := securityHeadersMiddleware(mux)
+	// Apply middleware in order: CORS -> security -> rate limiting -> logging -> recovery
+	handler := corsHandler(mux, s.config)
+	handler = securityHeadersMiddleware(handler)
 	handler = rateLimitMiddleware(handler)
-	handler = createLoggingMiddleware(handler, s.logger)
+	handler = createLoggingMiddleware(handler, s.logger, s.recordHTTPRequest)
 	handler = createRecoveryMiddleware(handler, s.logger)
 
 	return handler
 }
 
+// mutating guards a handler that can change presentation or server state.
+// In read-only mode it is refused with 403, except for the allowed methods.
+func (s *Server) mutating(next http.HandlerFunc, allowed ...string) http.HandlerFunc {
+	if !s.config.ReadOnly {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, method := range allowed {
+			if r.Method == method {
+				next(w, r)
+				return
+			}
+		}
+		s.handleError(w, fmt.Errorf("%s %s refused in read-only mode", r.Method, r.URL.Path), http.StatusForbidden)
+	}
+}
+
 // secureFileServer creates a secure file server that prevents path traversal
 func (s *Server) secureFileServer(root string) http.Handler {
 	fs := http.FileServer(http.Dir(root))
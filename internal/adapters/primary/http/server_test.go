@@ -4,6 +4,9 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,8 +14,10 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/watcher"
 	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/internal/domain/ports"
+	"github.com/fredcamaral/slicli/internal/domain/services"
 )
 
 // MockPresentationService is a mock for PresentationService
@@ -170,6 +175,184 @@ func TestServerHTTPEndpoints(t *testing.T) {
 	})
 }
 
+func TestServerCORS(t *testing.T) {
+	newTestServer := func(origins ...string) *httptest.Server {
+		config := getTestServerConfig()
+		config.CORSOrigins = origins
+		server := NewServer(new(MockPresentationService), new(MockRenderer), config)
+		server.SetPresentation(&entities.Presentation{Title: "Embedded"})
+		ts := httptest.NewServer(server.setupRoutes())
+		t.Cleanup(ts.Close)
+		return ts
+	}
+
+	request := func(t *testing.T, ts *httptest.Server, method, origin string, headers map[string]string) *http.Response {
+		req, err := http.NewRequest(method, ts.URL+"/api/slides", nil)
+		require.NoError(t, err)
+		req.Header.Set("Origin", origin)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	ts := newTestServer("https://dashboard.example.com")
+
+	t.Run("allowed origin", func(t *testing.T) {
+		resp := request(t, ts, http.MethodGet, "https://dashboard.example.com", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "https://dashboard.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Contains(t, resp.Header.Values("Vary"), "Origin")
+	})
+
+	t.Run("disallowed origin", func(t *testing.T) {
+		resp := request(t, ts, http.MethodGet, "https://evil.example.com", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("preflight", func(t *testing.T) {
+		resp := request(t, ts, http.MethodOptions, "https://dashboard.example.com", map[string]string{
+			"Access-Control-Request-Method":  "GET",
+			"Access-Control-Request-Headers": "X-Dashboard",
+		})
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "https://dashboard.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Equal(t, "GET", resp.Header.Get("Access-Control-Allow-Methods"))
+		assert.Equal(t, "x-dashboard", strings.ToLower(resp.Header.Get("Access-Control-Allow-Headers")))
+	})
+
+	t.Run("preflight from disallowed origin", func(t *testing.T) {
+		resp := request(t, ts, http.MethodOptions, "https://evil.example.com", map[string]string{
+			"Access-Control-Request-Method": "GET",
+		})
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		resp := request(t, newTestServer("*"), http.MethodGet, "https://anywhere.example.org", nil)
+		assert.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("subdomain wildcard", func(t *testing.T) {
+		ts := newTestServer("https://*.example.com")
+		resp := request(t, ts, http.MethodGet, "https://talks.example.com", nil)
+		assert.Equal(t, "https://talks.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+
+		resp = request(t, ts, http.MethodGet, "https://example.org", nil)
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("default origins", func(t *testing.T) {
+		ts := newTestServer()
+		resp := request(t, ts, http.MethodGet, "http://localhost:3000", nil)
+		assert.Equal(t, "http://localhost:3000", resp.Header.Get("Access-Control-Allow-Origin"))
+
+		resp = request(t, ts, http.MethodGet, "https://dashboard.example.com", nil)
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestServerLiveReloadSetup(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "talk.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Talk\n\n![diagram](images/diagram.png)"), 0600))
+
+	presentation := &entities.Presentation{Title: "Talk"}
+	presenter := new(MockPresentationService)
+	presenter.On("LoadPresentation", mock.Anything, path).Return(presentation, nil)
+	presenter.On("ApplyTheme", mock.Anything, presentation, "default").Return(nil)
+	renderer := new(MockRenderer)
+	renderer.On("RenderPresentation", mock.Anything, presentation).Return([]byte("<html>talk</html>"), nil)
+
+	server := NewServer(presenter, renderer, getTestServerConfig())
+	defer server.pregenerator.stop()
+	exportService := &fileExportService{tempDir: t.TempDir()}
+	server.SetExportService(exportService)
+
+	// Wired up like serve: the live reload service hands the server each
+	// presentation loaded from disk
+	fileWatcher := watcher.NewPollingWatcher(20*time.Millisecond, 0)
+	defer func() { _ = fileWatcher.Stop() }()
+	liveReload := services.NewLiveReloadService(fileWatcher, server, nil, presenter, renderer, nil)
+	liveReload.SetPresentationUpdater(server)
+	require.NoError(t, liveReload.Start(context.Background(), path))
+	defer func() { _ = liveReload.Stop() }()
+
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte("# Talk\n\n![diagram](images/diagram.png)\n"), 0600))
+	require.Eventually(t, func() bool { return server.GetPresentation() != nil }, 2*time.Second, 10*time.Millisecond)
+
+	// Exports resolve relative images against the presentation's directory
+	w := httptest.NewRecorder()
+	server.handleExport(w, httptest.NewRequest(http.MethodPost, "/api/export", strings.NewReader(`{"format":"html"}`)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Equal(t, 1, exportService.count())
+	assert.Equal(t, dir, exportService.exports[0].SourceDir)
+
+	t.Run("notes edits are saved next to the presentation", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		server.handleSetPresenterNotes(w, httptest.NewRequest(http.MethodPost, "/api/presenter/notes",
+			strings.NewReader(`{"slideId": "slide-1", "content": "Pause for questions"}`)))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		require.Eventually(t, func() bool {
+			data, err := os.ReadFile(filepath.Join(dir, "talk.notes.json"))
+			return err == nil && strings.Contains(string(data), "Pause for questions")
+		}, 2*time.Second, 20*time.Millisecond)
+	})
+}
+
+func TestServerReadOnly(t *testing.T) {
+	presenter := new(MockPresentationService)
+	renderer := new(MockRenderer)
+	renderer.On("RenderPresentation", mock.Anything, mock.Anything).Return([]byte("<html>slides</html>"), nil)
+
+	config := getTestServerConfig()
+	config.ReadOnly = true
+	server := NewServer(presenter, renderer, config)
+	server.SetPresentation(&entities.Presentation{Title: "Kiosk"})
+
+	ts := httptest.NewServer(server.setupRoutes())
+	defer ts.Close()
+
+	t.Run("presentation still serves", func(t *testing.T) {
+		for _, path := range []string{"/", "/api/config", "/api/presenter/notes", "/api/export/formats", "/api/export/pregenerated"} {
+			resp, err := http.Get(ts.URL + path)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.NotEqual(t, http.StatusForbidden, resp.StatusCode, path)
+		}
+	})
+
+	t.Run("mutating endpoints are forbidden", func(t *testing.T) {
+		for _, path := range []string{
+			"/api/export",
+			"/api/presenter/navigate",
+			"/api/presenter/timer",
+			"/api/presenter/notes",
+			"/api/performance/optimize",
+		} {
+			resp, err := http.Post(ts.URL+path, "application/json", strings.NewReader(`{}`))
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode, path)
+		}
+
+		for _, path := range []string{"/presenter", "/api/export/download?file=slides.pdf"} {
+			resp, err := http.Get(ts.URL + path)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode, path)
+		}
+	})
+}
+
 func TestBroadcastMethods(t *testing.T) {
 	presenter := new(MockPresentationService)
 	renderer := new(MockRenderer)
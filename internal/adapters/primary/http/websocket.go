@@ -2,6 +2,8 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -18,14 +20,12 @@ const (
 	// Time allowed to write a message to the peer
 	writeWait = 10 * time.Second
 
-	// Time allowed to read the next pong message from the peer
-	pongWait = 60 * time.Second
-
-	// Send pings to peer with this period. Must be less than pongWait
-	pingPeriod = (pongWait * 9) / 10
-
 	// Maximum message size allowed from peer
 	maxMessageSize = 512
+
+	// messageTypeResync is sent by clients after reconnecting to be told
+	// the current slide again
+	messageTypeResync = "resync"
 )
 
 // createUpgrader creates a WebSocket upgrader with proper origin validation
@@ -55,6 +55,11 @@ type WebSocketClient struct {
 	manager *ConnectionManager
 	mode    ClientMode
 	logger  *HTTPLogger
+
+	// pingInterval is how often the client is pinged. A client that sends
+	// nothing, not even a pong, for two intervals is disconnected.
+	pingInterval time.Duration
+	syncState    func() (ports.UpdateEvent, bool)
 }
 
 // ClientMessage represents a message received from the client
@@ -74,7 +79,7 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 	// Determine client mode from query parameter
 	mode := ClientModeAudience
-	if r.URL.Query().Get("mode") == "presenter" {
+	if r.URL.Query().Get("mode") == "presenter" && !s.config.ReadOnly {
 		mode = ClientModePresenter
 	}
 
@@ -85,6 +90,9 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 		manager: s.connMgr,
 		mode:    mode,
 		logger:  s.logger,
+
+		pingInterval: s.config.GetPingInterval(),
+		syncState:    s.syncStateEvent,
 	}
 
 	// Register the client with connection manager
@@ -113,6 +121,26 @@ func (s *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	default:
 		// Client's send channel is full
 	}
+
+	// Late joiners start on the slide the presenter is showing
+	if event, ok := s.syncStateEvent(); ok {
+		select {
+		case client.send <- event:
+		default:
+		}
+	}
+}
+
+// syncStateEvent returns the event moving a view to the presenter's current
+// slide, if presenter sync is running
+func (s *Server) syncStateEvent() (ports.UpdateEvent, bool) {
+	s.mu.RLock()
+	syncService := s.syncService
+	s.mu.RUnlock()
+	if syncService == nil {
+		return ports.UpdateEvent{}, false
+	}
+	return navigationEvent("sync", syncService.GetState()), true
 }
 
 // readPump pumps messages from the WebSocket connection
@@ -122,6 +150,7 @@ func (c *WebSocketClient) readPump() {
 		_ = c.conn.Close()
 	}()
 
+	pongWait := 2 * c.pingInterval
 	c.conn.SetReadLimit(maxMessageSize)
 	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetPongHandler(func(string) error {
@@ -133,11 +162,22 @@ func (c *WebSocketClient) readPump() {
 		// Read message from browser
 		_, message, err := c.conn.ReadMessage()
 		if err != nil {
-			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				c.logger.Debug("Closing unresponsive WebSocket client %s", c.id)
+			} else if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				c.logger.Error("WebSocket connection error: %v", err)
 			}
 			break
 		}
+		_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		if c.isResyncRequest(message) {
+			if event, ok := c.syncState(); ok {
+				c.manager.SendTo(c.id, event)
+			}
+			continue
+		}
 
 		// Handle presenter messages
 		if c.mode == ClientModePresenter {
@@ -158,7 +198,7 @@ func (c *WebSocketClient) readPump() {
 
 // writePump pumps messages to the WebSocket connection
 func (c *WebSocketClient) writePump() {
-	ticker := time.NewTicker(pingPeriod)
+	ticker := time.NewTicker(c.pingInterval)
 	defer func() {
 		ticker.Stop()
 		_ = c.conn.Close()
@@ -188,6 +228,12 @@ func (c *WebSocketClient) writePump() {
 	}
 }
 
+// isResyncRequest reports whether message asks for the current state
+func (c *WebSocketClient) isResyncRequest(message []byte) bool {
+	var clientMsg ClientMessage
+	return json.Unmarshal(message, &clientMsg) == nil && clientMsg.Type == messageTypeResync
+}
+
 // handlePresenterCommand handles commands from presenter clients
 func (c *WebSocketClient) handlePresenterCommand(msg ClientMessage) {
 	// Get the server instance to access the sync service
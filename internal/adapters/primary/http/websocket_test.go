@@ -12,7 +12,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/internal/domain/ports"
+	"github.com/fredcamaral/slicli/internal/domain/services"
 )
 
 func TestWebSocketUpgrade(t *testing.T) {
@@ -199,3 +201,63 @@ func TestWebSocketPingPong(t *testing.T) {
 		t.Error("Did not receive pong response")
 	}
 }
+
+func TestWebSocketReapsMissedPong(t *testing.T) {
+	config := getTestServerConfig()
+	config.PingInterval = 1
+	server := NewServer(new(MockPresentationService), new(MockRenderer), config)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.connMgr.Run(ctx)
+
+	ts := httptest.NewServer(http.HandlerFunc(server.handleWebSocket))
+	defer ts.Close()
+
+	// Pongs are only sent while reading, so a client that never reads
+	// misses every ping like a connection dropped by a proxy
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	require.NoError(t, err)
+	defer func() { _ = ws.Close() }()
+
+	connections := func() int {
+		server.connMgr.mu.RLock()
+		defer server.connMgr.mu.RUnlock()
+		return len(server.connMgr.connections)
+	}
+	require.Eventually(t, func() bool { return connections() == 1 }, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return connections() == 0 }, 4*time.Second, 50*time.Millisecond,
+		"the server drops a client that misses two pings")
+}
+
+func TestWebSocketResync(t *testing.T) {
+	presentation := &entities.Presentation{
+		Title:  "Talk",
+		Slides: []entities.Slide{{Index: 0, Title: "Intro"}, {Index: 1, Title: "End"}},
+	}
+	server := NewServer(new(MockPresentationService), new(MockRenderer), getTestServerConfig())
+	syncService := services.NewPresentationSyncService(presentation, nil)
+	t.Cleanup(syncService.Stop)
+	server.SetSyncService(syncService)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.connMgr.Run(ctx)
+
+	ts := httptest.NewServer(http.HandlerFunc(server.handleWebSocket))
+	defer ts.Close()
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(ts.URL, "http"), nil)
+	require.NoError(t, err)
+	defer func() { _ = ws.Close() }()
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(time.Second)))
+
+	var event ports.UpdateEvent
+	require.NoError(t, ws.ReadJSON(&event))
+	assert.Equal(t, "connected", event.Type)
+	require.NoError(t, ws.ReadJSON(&event))
+	assert.Equal(t, ports.EventTypeNavigation, event.Type)
+
+	require.NoError(t, syncService.Broadcast(entities.NewSyncEvent("navigation", map[string]interface{}{"action": "next"})))
+	require.NoError(t, ws.WriteJSON(ClientMessage{Type: "resync"}))
+	require.NoError(t, ws.ReadJSON(&event))
+	assert.Equal(t, ports.EventTypeNavigation, event.Type)
+	assert.Equal(t, map[string]interface{}{"action": "sync", "slide": float64(1)}, event.Data)
+}
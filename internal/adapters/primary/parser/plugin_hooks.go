@@ -2,15 +2,18 @@ package parser
 
 import (
 	"context"
+	"io"
 	"strings"
 	"sync"
 
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/internal/domain/ports"
 	pluginapi "github.com/fredcamaral/slicli/pkg/plugin"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/renderer"
 	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
 	"github.com/yuin/goldmark/util"
 )
 
@@ -22,6 +25,116 @@ type PluginRenderer struct {
 	mu            sync.Mutex                   // Protect assets map
 }
 
+// Document attributes holding the state of a single render, so concurrent
+// renders don't share it
+var (
+	contextAttribute = []byte("slicli-plugin-context") // Context plugins execute under
+	slideAttribute   = []byte("slicli-plugin-slide")   // SlideContext of the slide
+)
+
+// Convert renders markdown source with md like md.Convert, executing its
+// plugin blocks under ctx, so cancelling it, as when the client requesting
+// the render disconnects, cancels plugins still running
+func Convert(ctx context.Context, md goldmark.Markdown, source []byte, w io.Writer) error {
+	doc := md.Parser().Parse(text.NewReader(source))
+	doc.SetAttribute(contextAttribute, ctx)
+	return md.Renderer().Render(w, source, doc)
+}
+
+// ConvertSlide renders the markdown of a slide like Convert, passing the
+// slide's index, title, type and theme to its plugin blocks
+func ConvertSlide(ctx context.Context, md goldmark.Markdown, slide SlideContext, source []byte, w io.Writer) error {
+	doc := md.Parser().Parse(text.NewReader(source))
+	doc.SetAttribute(contextAttribute, ctx)
+	doc.SetAttribute(slideAttribute, slide)
+	return md.Renderer().Render(w, source, doc)
+}
+
+// renderSlideMetadata returns the metadata of the slide node's document
+// renders, empty if it wasn't rendered through ConvertSlide
+func renderSlideMetadata(node ast.Node) map[string]interface{} {
+	if doc := node.OwnerDocument(); doc != nil {
+		if value, ok := doc.Attribute(slideAttribute); ok {
+			if slide, ok := value.(SlideContext); ok {
+				return slide.metadata()
+			}
+		}
+	}
+	return make(map[string]interface{})
+}
+
+// renderContext returns the context the render of node's document runs
+// under, context.Background() if it wasn't rendered through Convert
+func renderContext(node ast.Node) context.Context {
+	if doc := node.OwnerDocument(); doc != nil {
+		if value, ok := doc.Attribute(contextAttribute); ok {
+			if ctx, ok := value.(context.Context); ok && ctx != nil {
+				return ctx
+			}
+		}
+	}
+	return context.Background()
+}
+
+// exportKey marks a context as rendering an export
+type exportKey struct{}
+
+// ExportContext returns ctx marking the renders under it as part of an
+// export, which plugin blocks are told through PluginInput.Metadata
+func ExportContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, exportKey{}, true)
+}
+
+// IsExport reports whether ctx renders an export
+func IsExport(ctx context.Context) bool {
+	export, _ := ctx.Value(exportKey{}).(bool)
+	return export
+}
+
+// SlideContext describes the slide plugin blocks are rendered in. It is
+// passed to plugins through the standard PluginInput.Metadata keys.
+type SlideContext struct {
+	Index int
+	Title string
+	Type  string
+	Theme string
+	Dir   string // The presentation's directory, empty when unknown
+}
+
+// NewSlideContext builds the context of a parsed slide in a deck using theme
+func NewSlideContext(slide entities.Slide, theme string) SlideContext {
+	title := slide.Title
+	if title == "" {
+		title = slide.ExtractTitle()
+	}
+	return SlideContext{
+		Index: slide.Index,
+		Title: title,
+		Type:  entities.DeclaredSlideType(slide.Content),
+		Theme: theme,
+	}
+}
+
+// metadata returns the slide context as plugin input metadata
+func (c SlideContext) metadata() map[string]interface{} {
+	metadata := map[string]interface{}{
+		pluginapi.MetadataSlideIndex: c.Index,
+	}
+	if c.Title != "" {
+		metadata[pluginapi.MetadataSlideTitle] = c.Title
+	}
+	if c.Type != "" {
+		metadata[pluginapi.MetadataSlideType] = c.Type
+	}
+	if c.Theme != "" {
+		metadata[pluginapi.MetadataTheme] = c.Theme
+	}
+	if c.Dir != "" {
+		metadata[pluginapi.MetadataPresentationDir] = c.Dir
+	}
+	return metadata
+}
+
 // NewPluginRenderer creates a new plugin renderer
 func NewPluginRenderer(pluginService ports.PluginService, opts ...html.Option) *PluginRenderer {
 	r := &PluginRenderer{
@@ -69,15 +182,24 @@ func (r *PluginRenderer) renderFencedCodeBlock(w util.BufWriter, source []byte,
 	}
 
 	// Execute plugin
-	ctx := context.Background()
+	ctx := renderContext(n)
+	metadata := renderSlideMetadata(n)
+	if IsExport(ctx) {
+		metadata[pluginapi.MetadataExport] = true
+	}
 	input := pluginapi.PluginInput{
 		Content:  content.String(),
 		Language: language,
 		Options:  r.extractOptions(n),
+		Metadata: metadata,
 	}
 
 	output, err := r.pluginService.ExecutePlugin(ctx, pluginName, input)
 	if err != nil {
+		// Nobody is waiting for the rest of the page
+		if ctx.Err() != nil {
+			return ast.WalkStop, ctx.Err()
+		}
 		// Fallback to default rendering on error
 		return r.renderDefaultCodeBlock(w, source, n, language)
 	}
@@ -134,42 +256,50 @@ func (r *PluginRenderer) renderDefaultCodeBlock(w util.BufWriter, source []byte,
 
 // determinePlugin determines which plugin should handle this block
 func (r *PluginRenderer) determinePlugin(language string, content string) string {
+	// No automatic content matching for now
+	// This could be extended with a separate matcher service
+	return PluginForLanguage(language)
+}
+
+// PluginForLanguage returns the plugin that renders fenced code blocks of a
+// language, or "" when they are rendered as plain code
+func PluginForLanguage(language string) string {
 	// Direct plugin mappings
 	switch strings.ToLower(language) {
 	case "mermaid":
 		return "mermaid"
 	case "exec", "execute", "run":
 		return "code-exec"
+	case "timer":
+		return "timer"
 	}
 
 	// Check if it's a programming language that needs highlighting
-	if r.isProgrammingLanguage(language) {
+	if programmingLanguages[strings.ToLower(language)] {
 		return "syntax-highlight"
 	}
 
-	// No automatic content matching for now
-	// This could be extended with a separate matcher service
-
 	return ""
 }
 
+// programmingLanguages are the languages highlighted by syntax-highlight
+var programmingLanguages = map[string]bool{
+	"go": true, "golang": true, "python": true, "py": true,
+	"javascript": true, "js": true, "typescript": true, "ts": true,
+	"java": true, "c": true, "cpp": true, "c++": true, "csharp": true, "c#": true,
+	"rust": true, "ruby": true, "rb": true, "php": true, "swift": true,
+	"kotlin": true, "scala": true, "r": true, "julia": true, "dart": true,
+	"bash": true, "sh": true, "shell": true, "powershell": true,
+	"sql": true, "html": true, "css": true, "scss": true, "sass": true,
+	"json": true, "xml": true, "yaml": true, "yml": true, "toml": true,
+	"dockerfile": true, "makefile": true, "cmake": true,
+	"lua": true, "perl": true, "haskell": true, "clojure": true,
+	"elixir": true, "erlang": true, "ocaml": true, "fsharp": true, "f#": true,
+}
+
 // isProgrammingLanguage checks if the language is a known programming language
 func (r *PluginRenderer) isProgrammingLanguage(language string) bool {
-	programmingLangs := map[string]bool{
-		"go": true, "golang": true, "python": true, "py": true,
-		"javascript": true, "js": true, "typescript": true, "ts": true,
-		"java": true, "c": true, "cpp": true, "c++": true, "csharp": true, "c#": true,
-		"rust": true, "ruby": true, "rb": true, "php": true, "swift": true,
-		"kotlin": true, "scala": true, "r": true, "julia": true, "dart": true,
-		"bash": true, "sh": true, "shell": true, "powershell": true,
-		"sql": true, "html": true, "css": true, "scss": true, "sass": true,
-		"json": true, "xml": true, "yaml": true, "yml": true, "toml": true,
-		"dockerfile": true, "makefile": true, "cmake": true,
-		"lua": true, "perl": true, "haskell": true, "clojure": true,
-		"elixir": true, "erlang": true, "ocaml": true, "fsharp": true, "f#": true,
-	}
-
-	return programmingLangs[strings.ToLower(language)]
+	return programmingLanguages[strings.ToLower(language)]
 }
 
 // optionRegex matches JSON-like options in code block info strings (kept for potential future use)
@@ -185,7 +315,9 @@ func (r *PluginRenderer) extractOptions(n *ast.FencedCodeBlock) map[string]inter
 	return options
 }
 
-// storeAssets stores plugin assets for later inclusion
+// storeAssets stores plugin assets for later inclusion. An asset already
+// stored under the same name, such as the script of a plugin used by
+// several blocks, is only kept once until the assets are cleared.
 func (r *PluginRenderer) storeAssets(assets []pluginapi.Asset) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -199,10 +331,23 @@ func (r *PluginRenderer) storeAssets(assets []pluginapi.Asset) {
 		} else if strings.HasPrefix(asset.ContentType, "application/javascript") || strings.HasPrefix(asset.ContentType, "text/javascript") {
 			assetKey = "javascript"
 		}
+		if hasAsset(r.assets[assetKey], asset.Name) {
+			continue
+		}
 		r.assets[assetKey] = append(r.assets[assetKey], asset)
 	}
 }
 
+// hasAsset reports whether an asset with a name is in a list
+func hasAsset(assets []pluginapi.Asset, name string) bool {
+	for _, asset := range assets {
+		if asset.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // GetAssets returns all stored assets grouped by type
 func (r *PluginRenderer) GetAssets() map[string][]pluginapi.Asset {
 	r.mu.Lock()
@@ -339,20 +484,28 @@ func (r *PluginRenderer) GenerateAssetHTML() string {
 // PluginExtension is a Goldmark extension for plugin support
 type PluginExtension struct {
 	pluginService ports.PluginService
+	renderer      *PluginRenderer
 }
 
 // NewPluginExtension creates a new plugin extension
 func NewPluginExtension(pluginService ports.PluginService) *PluginExtension {
 	return &PluginExtension{
 		pluginService: pluginService,
+		renderer:      NewPluginRenderer(pluginService),
 	}
 }
 
+// Renderer returns the plugin renderer the extension installs, e.g. to
+// collect the assets of the plugin blocks it rendered
+func (e *PluginExtension) Renderer() *PluginRenderer {
+	return e.renderer
+}
+
 // Extend extends the markdown parser with plugin support
 func (e *PluginExtension) Extend(m goldmark.Markdown) {
 	m.Renderer().AddOptions(
 		renderer.WithNodeRenderers(
-			util.Prioritized(NewPluginRenderer(e.pluginService), 100),
+			util.Prioritized(e.renderer, 100),
 		),
 	)
 }
@@ -35,6 +35,11 @@ func (m *MockPluginService) ExecutePlugin(ctx context.Context, name string, inpu
 	return args.Get(0).(pluginapi.PluginOutput), args.Error(1)
 }
 
+func (m *MockPluginService) ExecutePluginStream(ctx context.Context, name string, input pluginapi.PluginInput, emit func(pluginapi.OutputChunk)) (pluginapi.PluginOutput, error) {
+	args := m.Called(ctx, name, input, emit)
+	return args.Get(0).(pluginapi.PluginOutput), args.Error(1)
+}
+
 func (m *MockPluginService) ListPlugins() []entities.LoadedPlugin {
 	args := m.Called()
 	if result := args.Get(0); result != nil {
@@ -67,6 +72,14 @@ func (m *MockPluginService) DiscoverPlugins(ctx context.Context) ([]pluginapi.Pl
 	return nil, args.Error(1)
 }
 
+func (m *MockPluginService) PluginHealth() []entities.PluginHealth {
+	args := m.Called()
+	if result := args.Get(0); result != nil {
+		return result.([]entities.PluginHealth)
+	}
+	return nil
+}
+
 func (m *MockPluginService) ProcessContent(ctx context.Context, content string, language string) ([]pluginapi.PluginOutput, error) {
 	args := m.Called(ctx, content, language)
 	if result := args.Get(0); result != nil {
@@ -102,6 +115,12 @@ func TestPluginRenderer_DeterminePlugin(t *testing.T) {
 			content:  "print('hello')",
 			expected: "code-exec",
 		},
+		{
+			name:     "Slide timer",
+			language: "timer",
+			content:  "budget: 5m",
+			expected: "timer",
+		},
 		{
 			name:     "Go code",
 			language: "go",
@@ -212,6 +231,108 @@ func TestPluginRenderer_RenderFencedCodeBlock(t *testing.T) {
 	})
 }
 
+func TestPluginRenderer_AssetsStoredOncePerPass(t *testing.T) {
+	mockService := new(MockPluginService)
+	mermaidOutput := pluginapi.PluginOutput{
+		HTML: `<div class="mermaid">graph TD; A-->B;</div>`,
+		Assets: []pluginapi.Asset{
+			{Name: "mermaid-init.js", Content: []byte("//js"), ContentType: "application/javascript"},
+			{Name: "mermaid.css", Content: []byte(".mermaid{}"), ContentType: "text/css"},
+		},
+	}
+	mockService.On("ExecutePlugin", mock.Anything, "mermaid", mock.Anything).Return(mermaidOutput, nil).Twice()
+
+	pluginRenderer := NewPluginRenderer(mockService)
+	md := goldmark.New(
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(
+				util.Prioritized(pluginRenderer, 100),
+			),
+		),
+	)
+
+	source := "```mermaid\ngraph TD\nA-->B\n```\n\n```mermaid\ngraph TD\nA-->B\n```"
+	var buf bytes.Buffer
+	require.NoError(t, md.Convert([]byte(source), &buf))
+
+	assets := pluginRenderer.GetAssets()
+	assert.Len(t, assets["javascript"], 1)
+	assert.Len(t, assets["css"], 1)
+	mockService.AssertExpectations(t)
+
+	// The next pass stores them again
+	pluginRenderer.ClearAssets()
+	pluginRenderer.storeAssets(mermaidOutput.Assets)
+	assert.Len(t, pluginRenderer.GetAssets()["javascript"], 1)
+}
+
+func TestPluginRenderer_Context(t *testing.T) {
+	mockService := new(MockPluginService)
+	pluginRenderer := NewPluginRenderer(mockService)
+	md := goldmark.New(
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(util.Prioritized(pluginRenderer, 100)),
+		),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockService.On("ExecutePlugin", ctx, "mermaid", mock.Anything).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(pluginapi.PluginOutput{}, context.Canceled).Once()
+
+	var buf bytes.Buffer
+	err := Convert(ctx, md, []byte("```mermaid\ngraph TD\nA-->B\n```\n\n```mermaid\ngraph LR\n```"), &buf)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.NotContains(t, buf.String(), "graph LR", "rendering stops once the client is gone")
+	mockService.AssertExpectations(t)
+
+	t.Run("renders don't share their context", func(t *testing.T) {
+		other := context.WithValue(context.Background(), struct{}{}, "other")
+		mockService.On("ExecutePlugin", other, "mermaid", mock.Anything).
+			Return(pluginapi.PluginOutput{HTML: "<svg></svg>"}, nil).Once()
+
+		buf.Reset()
+		require.NoError(t, Convert(other, md, []byte("```mermaid\ngraph TD\n```"), &buf))
+		assert.Contains(t, buf.String(), "<svg></svg>")
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestPluginRenderer_SlideMetadata(t *testing.T) {
+	mockService := new(MockPluginService)
+	extension := NewPluginExtension(mockService)
+	md := goldmark.New(goldmark.WithExtensions(extension))
+
+	var received []map[string]interface{}
+	mockService.On("ExecutePlugin", mock.Anything, "mermaid", mock.Anything).
+		Run(func(args mock.Arguments) {
+			received = append(received, args.Get(2).(pluginapi.PluginInput).Metadata)
+		}).
+		Return(pluginapi.PluginOutput{HTML: "<svg></svg>"}, nil)
+
+	slide := entities.Slide{
+		Index:   2,
+		Content: "<!-- layout: section -->\n# Architecture\n\n```mermaid\ngraph TD\nA-->B\n```",
+	}
+	var buf bytes.Buffer
+	require.NoError(t, ConvertSlide(context.Background(), md, NewSlideContext(slide, "dark"), []byte(slide.Content), &buf))
+	require.Len(t, received, 1)
+	assert.Equal(t, map[string]interface{}{
+		pluginapi.MetadataSlideIndex: 2,
+		pluginapi.MetadataSlideTitle: "Architecture",
+		pluginapi.MetadataSlideType:  "section",
+		pluginapi.MetadataTheme:      "dark",
+	}, received[0])
+
+	t.Run("without a slide context", func(t *testing.T) {
+		buf.Reset()
+		require.NoError(t, md.Convert([]byte("```mermaid\ngraph TD\n```"), &buf))
+		require.Len(t, received, 2)
+		assert.Empty(t, received[1])
+	})
+}
+
 func TestPluginRenderer_DefaultRendering(t *testing.T) {
 	// Test without plugin service
 	md := goldmark.New(
@@ -0,0 +1,295 @@
+// Package certs provides TLS certificates for serving presentations over HTTPS,
+// either loaded from user-provided files or generated as a local self-signed CA.
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+const (
+	caCertFile = "ca.pem"
+	caKeyFile  = "ca-key.pem"
+
+	caValidity   = 10 * 365 * 24 * time.Hour
+	leafValidity = 365 * 24 * time.Hour
+)
+
+// Bundle is a TLS configuration ready for serving, along with what clients need to trust it.
+type Bundle struct {
+	// Config is the server TLS configuration.
+	Config *tls.Config
+
+	// Fingerprint is the SHA-256 fingerprint of the trust anchor: the generated CA
+	// for self-signed bundles, otherwise the provided leaf certificate.
+	Fingerprint string
+
+	// RootCAs contains the trust anchor, useful for clients talking to the server.
+	RootCAs *x509.CertPool
+
+	// SelfSigned reports whether the certificate was generated locally.
+	SelfSigned bool
+}
+
+// FromServerConfig builds a bundle from server configuration, loading the configured
+// cert/key pair or generating a self-signed certificate for host when none is set.
+func FromServerConfig(cfg entities.ServerConfig) (*Bundle, error) {
+	if !cfg.TLS.Enabled {
+		return nil, errors.New("TLS is not enabled")
+	}
+
+	if cfg.TLS.CertFile != "" {
+		return Load(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+	}
+
+	return GenerateSelfSigned(cfg.TLS.GetCADir(), hostsFor(cfg.Host))
+}
+
+// Load loads a certificate and key pair from PEM files.
+func Load(certFile, keyFile string) (*Bundle, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS key pair: %w", err)
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("parsing TLS certificate: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+
+	return &Bundle{
+		Config:      newServerTLSConfig(cert),
+		Fingerprint: Fingerprint(leaf),
+		RootCAs:     pool,
+	}, nil
+}
+
+// GenerateSelfSigned issues a server certificate for hosts signed by a local CA.
+// The CA is persisted in caDir and reused across runs so users only need to trust
+// it once; when caDir is empty an ephemeral CA is used.
+func GenerateSelfSigned(caDir string, hosts []string) (*Bundle, error) {
+	caCert, caKey, err := loadOrCreateCA(caDir)
+	if err != nil {
+		return nil, err
+	}
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating server key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"slicli"}, CommonName: hosts[0]},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(leafValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &leafKey.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("creating server certificate: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(caCert)
+
+	return &Bundle{
+		Config: newServerTLSConfig(tls.Certificate{
+			Certificate: [][]byte{der, caCert.Raw},
+			PrivateKey:  leafKey,
+		}),
+		Fingerprint: Fingerprint(caCert),
+		RootCAs:     pool,
+		SelfSigned:  true,
+	}, nil
+}
+
+// Fingerprint returns the colon-separated SHA-256 fingerprint of a certificate.
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// loadOrCreateCA returns the CA stored in dir, creating and saving one if missing.
+func loadOrCreateCA(dir string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	if dir != "" {
+		if cert, key, err := loadCA(dir); err == nil {
+			return cert, key, nil
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating CA key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"slicli"}, CommonName: "slicli local CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating CA certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing CA certificate: %w", err)
+	}
+
+	if dir != "" {
+		if err := saveCA(dir, der, key); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return cert, key, nil
+}
+
+// loadCA reads a previously saved CA certificate and key from dir.
+func loadCA(dir string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certPEM, err := os.ReadFile(filepath.Join(dir, caCertFile)) // #nosec G304 - fixed file name in configured dir
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := os.ReadFile(filepath.Join(dir, caKeyFile)) // #nosec G304 - fixed file name in configured dir
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	keyBlock, _ := pem.Decode(keyPEM)
+	if certBlock == nil || keyBlock == nil {
+		return nil, nil, errors.New("invalid CA PEM data")
+	}
+
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if time.Now().After(cert.NotAfter) {
+		return nil, nil, errors.New("CA certificate expired")
+	}
+
+	return cert, key, nil
+}
+
+// saveCA writes the CA certificate and key to dir, keeping the key private.
+func saveCA(dir string, der []byte, key *ecdsa.PrivateKey) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating CA directory: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("encoding CA key: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, caCertFile), certPEM, 0o644); err != nil { // #nosec G306 - certificate is public
+		return fmt.Errorf("writing CA certificate: %w", err)
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := os.WriteFile(filepath.Join(dir, caKeyFile), keyPEM, 0o600); err != nil {
+		return fmt.Errorf("writing CA key: %w", err)
+	}
+
+	return nil
+}
+
+// hostsFor returns the names a self-signed certificate should cover for host.
+func hostsFor(host string) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		// Binding to all interfaces: include LAN addresses so other devices can connect
+		if addrs, err := net.InterfaceAddrs(); err == nil {
+			for _, addr := range addrs {
+				if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() {
+					hosts = append(hosts, ipNet.IP.String())
+				}
+			}
+		}
+		return hosts
+	}
+
+	for _, h := range hosts {
+		if h == host {
+			return hosts
+		}
+	}
+	return append([]string{host}, hosts...)
+}
+
+// newServerTLSConfig returns a TLS configuration with modern defaults.
+func newServerTLSConfig(cert tls.Certificate) *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// randomSerial returns a random certificate serial number.
+func randomSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("generating serial number: %w", err)
+	}
+	return serial, nil
+}
@@ -0,0 +1,109 @@
+package certs
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestGenerateSelfSigned_ServesOverTLS(t *testing.T) {
+	bundle, err := GenerateSelfSigned(t.TempDir(), []string{"127.0.0.1", "localhost"})
+	require.NoError(t, err)
+	assert.True(t, bundle.SelfSigned)
+	assert.Len(t, bundle.Fingerprint, 95) // 32 hex pairs separated by colons
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "slides")
+	}))
+	server.TLS = bundle.Config
+	server.StartTLS()
+	defer server.Close()
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: bundle.RootCAs, MinVersion: tls.VersionTLS12},
+	}}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "slides", string(body))
+	assert.NotNil(t, resp.TLS)
+}
+
+func TestGenerateSelfSigned_ReusesPersistedCA(t *testing.T) {
+	dir := t.TempDir()
+
+	first, err := GenerateSelfSigned(dir, []string{"localhost"})
+	require.NoError(t, err)
+	second, err := GenerateSelfSigned(dir, []string{"localhost"})
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Fingerprint, second.Fingerprint)
+
+	info, err := os.Stat(filepath.Join(dir, caKeyFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestGenerateSelfSigned_EphemeralWithoutDir(t *testing.T) {
+	first, err := GenerateSelfSigned("", []string{"localhost"})
+	require.NoError(t, err)
+	second, err := GenerateSelfSigned("", []string{"localhost"})
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.Fingerprint, second.Fingerprint)
+}
+
+func TestFromServerConfig(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		_, err := FromServerConfig(entities.ServerConfig{})
+		require.Error(t, err)
+	})
+
+	t.Run("loads provided pair", func(t *testing.T) {
+		dir := t.TempDir()
+		generated, err := GenerateSelfSigned(dir, []string{"localhost"})
+		require.NoError(t, err)
+
+		// Reuse the generated CA as a user-provided certificate
+		cfg := entities.ServerConfig{TLS: entities.TLSConfig{
+			Enabled:  true,
+			CertFile: filepath.Join(dir, caCertFile),
+			KeyFile:  filepath.Join(dir, caKeyFile),
+		}}
+
+		bundle, err := FromServerConfig(cfg)
+		require.NoError(t, err)
+		assert.False(t, bundle.SelfSigned)
+		assert.Equal(t, generated.Fingerprint, bundle.Fingerprint)
+	})
+
+	t.Run("missing files", func(t *testing.T) {
+		cfg := entities.ServerConfig{TLS: entities.TLSConfig{
+			Enabled:  true,
+			CertFile: "/nonexistent/cert.pem",
+			KeyFile:  "/nonexistent/key.pem",
+		}}
+
+		_, err := FromServerConfig(cfg)
+		require.Error(t, err)
+	})
+}
+
+func TestHostsFor(t *testing.T) {
+	assert.Equal(t, []string{"localhost", "127.0.0.1", "::1"}, hostsFor("localhost"))
+	assert.Equal(t, "192.168.1.20", hostsFor("192.168.1.20")[0])
+	assert.Contains(t, hostsFor("0.0.0.0"), "127.0.0.1")
+}
@@ -17,12 +17,25 @@ func GetDefaultConfig() *entities.Config {
 			ReadTimeout:     getEnvIntOrDefault("SLICLI_READ_TIMEOUT", 30),
 			WriteTimeout:    getEnvIntOrDefault("SLICLI_WRITE_TIMEOUT", 30),
 			ShutdownTimeout: getEnvIntOrDefault("SLICLI_SHUTDOWN_TIMEOUT", 5),
+			PingInterval:    getEnvIntOrDefault("SLICLI_PING_INTERVAL", 30),
 			CORSOrigins: getEnvSliceOrDefault("SLICLI_CORS_ORIGINS", []string{
 				"http://localhost:3000",
 				"http://127.0.0.1:3000",
 				"http://localhost:8080",
 				"http://127.0.0.1:8080",
 			}),
+			ExportFilenames:  "ascii",
+			CSP:              entities.CSPDefault,
+			SlideIDs:         entities.SlideIDsIndex,
+			SubsetFonts:      false,
+			ReadOnly:         false,
+			InteractiveTasks: false,
+			PrefetchDepth:    1,
+			SpeakingWPM:      entities.DefaultSpeakingWPM,
+			Offline:          false,
+			Metrics:          false,
+
+			PregenerateDebounceMs: 2000,
 		},
 		Theme: entities.ThemeConfig{
 			Name:       "default",
@@ -128,6 +141,10 @@ func applyEnvironmentOverrides(config *entities.Config) {
 		config.Plugins.Directory = dir
 	}
 
+	if cacheDir := os.Getenv("SLICLI_PLUGINS_CACHE_DIR"); cacheDir != "" {
+		config.Plugins.CacheDir = cacheDir
+	}
+
 	// Override theme settings
 	if theme := os.Getenv("SLICLI_THEME"); theme != "" {
 		config.Theme.Name = theme
@@ -162,6 +162,7 @@ port = 4000
 
 [theme]
 name = "custom"
+max_variable_depth = 3
 
 [watcher]
 interval_ms = 150
@@ -185,6 +186,7 @@ enabled = true
 		// Verify loaded values
 		assert.Equal(t, 4000, config.Server.Port)
 		assert.Equal(t, "custom", config.Theme.Name)
+		assert.Equal(t, 3, config.Theme.MaxVariableDepth)
 	})
 
 	t.Run("returns nil for non-existent local config", func(t *testing.T) {
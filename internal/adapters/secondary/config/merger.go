@@ -48,6 +48,10 @@ func (m *ConfigMerger) ApplyFlags(config *entities.Config, flags map[string]inte
 		result.Server.Host = host
 	}
 
+	if useTLS, ok := flags["tls"].(bool); ok && useTLS {
+		result.Server.TLS.Enabled = true
+	}
+
 	if theme, ok := flags["theme"].(string); ok && theme != "" {
 		result.Theme.Name = theme
 	}
@@ -156,6 +160,64 @@ func (m *ConfigMerger) mergeInto(target, source *entities.Config) {
 	if source.Server.ShutdownTimeout != 0 {
 		target.Server.ShutdownTimeout = source.Server.ShutdownTimeout
 	}
+	if source.Server.PingInterval != 0 {
+		target.Server.PingInterval = source.Server.PingInterval
+	}
+	if source.Server.IdleTimeout != 0 {
+		target.Server.IdleTimeout = source.Server.IdleTimeout
+	}
+	if source.Server.ExportFilenames != "" {
+		target.Server.ExportFilenames = source.Server.ExportFilenames
+	}
+	if len(source.Server.ExportFonts) > 0 {
+		target.Server.ExportFonts = source.Server.ExportFonts
+	}
+	if source.Server.SubsetFonts {
+		target.Server.SubsetFonts = true
+	}
+	if source.Server.ReadOnly {
+		target.Server.ReadOnly = true
+	}
+	if source.Server.InteractiveTasks {
+		target.Server.InteractiveTasks = true
+	}
+	if source.Server.SlideIDs != "" {
+		target.Server.SlideIDs = source.Server.SlideIDs
+	}
+	if source.Server.CSP != "" {
+		target.Server.CSP = source.Server.CSP
+	}
+	if source.Server.PortRetries != 0 {
+		target.Server.PortRetries = source.Server.PortRetries
+	}
+	if source.Server.PrefetchDepth != 0 {
+		target.Server.PrefetchDepth = source.Server.PrefetchDepth
+	}
+	if source.Server.SpeakingWPM != 0 {
+		target.Server.SpeakingWPM = source.Server.SpeakingWPM
+	}
+	if source.Server.Offline {
+		target.Server.Offline = true
+	}
+	if source.Server.Metrics {
+		target.Server.Metrics = true
+	}
+	if len(source.Server.PregenerateExports) > 0 {
+		target.Server.PregenerateExports = source.Server.PregenerateExports
+	}
+	if source.Server.PregenerateDebounceMs != 0 {
+		target.Server.PregenerateDebounceMs = source.Server.PregenerateDebounceMs
+	}
+	if source.Server.TLS.Enabled {
+		target.Server.TLS.Enabled = true
+	}
+	if source.Server.TLS.CertFile != "" {
+		target.Server.TLS.CertFile = source.Server.TLS.CertFile
+		target.Server.TLS.KeyFile = source.Server.TLS.KeyFile
+	}
+	if source.Server.TLS.CADir != "" {
+		target.Server.TLS.CADir = source.Server.TLS.CADir
+	}
 
 	// Theme config
 	if source.Theme.Name != "" {
@@ -164,6 +226,20 @@ func (m *ConfigMerger) mergeInto(target, source *entities.Config) {
 	if source.Theme.CustomPath != "" {
 		target.Theme.CustomPath = source.Theme.CustomPath
 	}
+	if source.Theme.Transition != "" {
+		target.Theme.Transition = source.Theme.Transition
+	}
+	if source.Theme.MaxVariableDepth > 0 {
+		target.Theme.MaxVariableDepth = source.Theme.MaxVariableDepth
+	}
+	if len(source.Theme.Variables) > 0 {
+		if target.Theme.Variables == nil {
+			target.Theme.Variables = make(map[string]string)
+		}
+		for k, v := range source.Theme.Variables {
+			target.Theme.Variables[k] = v
+		}
+	}
 
 	// Browser config
 	if source.Browser.Browser != "" {
@@ -187,12 +263,27 @@ func (m *ConfigMerger) mergeInto(target, source *entities.Config) {
 	if source.Watcher.RetryDelayMs != 0 {
 		target.Watcher.RetryDelayMs = source.Watcher.RetryDelayMs
 	}
+	if len(source.Watcher.Dirs) > 0 {
+		target.Watcher.Dirs = source.Watcher.Dirs
+	}
 
 	// Plugins config
 	target.Plugins.Enabled = source.Plugins.Enabled
 	if source.Plugins.Directory != "" {
 		target.Plugins.Directory = source.Plugins.Directory
 	}
+	if source.Plugins.CacheDir != "" {
+		target.Plugins.CacheDir = source.Plugins.CacheDir
+	}
+	if source.Plugins.CacheMaxSizeMB != 0 {
+		target.Plugins.CacheMaxSizeMB = source.Plugins.CacheMaxSizeMB
+	}
+	if source.Plugins.MarketplaceAPIKey != "" {
+		target.Plugins.MarketplaceAPIKey = source.Plugins.MarketplaceAPIKey
+	}
+	if source.Plugins.ThemePublicKey != "" {
+		target.Plugins.ThemePublicKey = source.Plugins.ThemePublicKey
+	}
 	if len(source.Plugins.Whitelist) > 0 {
 		target.Plugins.Whitelist = make([]string, len(source.Plugins.Whitelist))
 		copy(target.Plugins.Whitelist, source.Plugins.Whitelist)
@@ -235,15 +326,36 @@ func deepCopy(src *entities.Config) *entities.Config {
 	// Manual copy to avoid reflection for performance
 	dst := &entities.Config{
 		Server: entities.ServerConfig{
-			Host:            src.Server.Host,
-			Port:            src.Server.Port,
-			ReadTimeout:     src.Server.ReadTimeout,
-			WriteTimeout:    src.Server.WriteTimeout,
-			ShutdownTimeout: src.Server.ShutdownTimeout,
+			Host:             src.Server.Host,
+			Port:             src.Server.Port,
+			PortRetries:      src.Server.PortRetries,
+			ReadTimeout:      src.Server.ReadTimeout,
+			WriteTimeout:     src.Server.WriteTimeout,
+			ShutdownTimeout:  src.Server.ShutdownTimeout,
+			PingInterval:     src.Server.PingInterval,
+			IdleTimeout:      src.Server.IdleTimeout,
+			ExportFilenames:  src.Server.ExportFilenames,
+			CSP:              src.Server.CSP,
+			SlideIDs:         src.Server.SlideIDs,
+			ExportFonts:      append([]string(nil), src.Server.ExportFonts...),
+			SubsetFonts:      src.Server.SubsetFonts,
+			ReadOnly:         src.Server.ReadOnly,
+			InteractiveTasks: src.Server.InteractiveTasks,
+			PrefetchDepth:    src.Server.PrefetchDepth,
+			SpeakingWPM:      src.Server.SpeakingWPM,
+			Offline:          src.Server.Offline,
+			Metrics:          src.Server.Metrics,
+			TLS:              src.Server.TLS,
+
+			PregenerateExports:    append([]string(nil), src.Server.PregenerateExports...),
+			PregenerateDebounceMs: src.Server.PregenerateDebounceMs,
 		},
 		Theme: entities.ThemeConfig{
 			Name:       src.Theme.Name,
 			CustomPath: src.Theme.CustomPath,
+			Transition: src.Theme.Transition,
+
+			MaxVariableDepth: src.Theme.MaxVariableDepth,
 		},
 		Browser: entities.BrowserConfig{
 			AutoOpen: src.Browser.AutoOpen,
@@ -254,10 +366,16 @@ func deepCopy(src *entities.Config) *entities.Config {
 			DebounceMs:   src.Watcher.DebounceMs,
 			MaxRetries:   src.Watcher.MaxRetries,
 			RetryDelayMs: src.Watcher.RetryDelayMs,
+			Dirs:         append([]string(nil), src.Watcher.Dirs...),
 		},
 		Plugins: entities.PluginsConfig{
-			Enabled:   src.Plugins.Enabled,
-			Directory: src.Plugins.Directory,
+			Enabled:        src.Plugins.Enabled,
+			Directory:      src.Plugins.Directory,
+			CacheDir:       src.Plugins.CacheDir,
+			CacheMaxSizeMB: src.Plugins.CacheMaxSizeMB,
+
+			MarketplaceAPIKey: src.Plugins.MarketplaceAPIKey,
+			ThemePublicKey:    src.Plugins.ThemePublicKey,
 		},
 		Metadata: entities.Metadata{
 			Author:  src.Metadata.Author,
@@ -282,7 +400,13 @@ func deepCopy(src *entities.Config) *entities.Config {
 		copy(dst.Metadata.DefaultTags, src.Metadata.DefaultTags)
 	}
 
-	// Copy map
+	// Copy maps
+	if src.Theme.Variables != nil {
+		dst.Theme.Variables = make(map[string]string)
+		for k, v := range src.Theme.Variables {
+			dst.Theme.Variables[k] = v
+		}
+	}
 	if src.Metadata.Custom != nil {
 		dst.Metadata.Custom = make(map[string]string)
 		for k, v := range src.Metadata.Custom {
@@ -118,6 +118,33 @@ func TestConfigMerger_Merge(t *testing.T) {
 		assert.Equal(t, "value1", result.Metadata.Custom["key1"])
 		assert.Equal(t, "value2", result.Metadata.Custom["key2"])
 	})
+
+	t.Run("deck theme variables override global ones per key", func(t *testing.T) {
+		global := &entities.Config{
+			Theme: entities.ThemeConfig{
+				Variables: map[string]string{"primary-color": "#111", "font-size-base": "20px"},
+			},
+		}
+		deck := &entities.Config{
+			Theme: entities.ThemeConfig{
+				Variables: map[string]string{"primary-color": "#222"},
+			},
+		}
+
+		result := merger.Merge(global, deck)
+		assert.Equal(t, map[string]string{"primary-color": "#222", "font-size-base": "20px"}, result.Theme.Variables)
+		assert.Equal(t, "#111", global.Theme.Variables["primary-color"], "merging must not modify its inputs")
+	})
+
+	t.Run("deck max variable depth overrides the global one", func(t *testing.T) {
+		global := &entities.Config{Theme: entities.ThemeConfig{MaxVariableDepth: 4}}
+
+		result := merger.Merge(global, &entities.Config{})
+		assert.Equal(t, 4, result.Theme.MaxVariableDepth, "an unset depth keeps the global one")
+
+		result = merger.Merge(global, &entities.Config{Theme: entities.ThemeConfig{MaxVariableDepth: 2}})
+		assert.Equal(t, 2, result.Theme.MaxVariableDepth)
+	})
 }
 
 func TestConfigMerger_ApplyFlags(t *testing.T) {
@@ -326,6 +353,9 @@ func TestDeepCopy(t *testing.T) {
 
 	t.Run("deep copy creates independent slices", func(t *testing.T) {
 		original := &entities.Config{
+			Server: entities.ServerConfig{
+				ExportFonts: []string{"fonts/brand.ttf"},
+			},
 			Plugins: entities.PluginsConfig{
 				Whitelist: []string{"plugin1"},
 			},
@@ -333,10 +363,12 @@ func TestDeepCopy(t *testing.T) {
 
 		copy := deepCopy(original)
 
-		// Modify original slice
+		// Modify original slices
+		original.Server.ExportFonts[0] = "modified"
 		original.Plugins.Whitelist[0] = "modified"
 
 		// Copy should be unchanged
+		assert.Equal(t, "fonts/brand.ttf", copy.Server.ExportFonts[0])
 		assert.Equal(t, "plugin1", copy.Plugins.Whitelist[0])
 	})
 
@@ -21,6 +21,14 @@ type BrowserAutomation struct {
 	timeout         time.Duration
 	activeProcesses map[string]*exec.Cmd // Track active processes for cleanup
 	processMutex    sync.RWMutex         // Protect concurrent access to activeProcesses
+
+	warmupAttempts int
+	warmupDelay    time.Duration
+	warmedUp       bool
+	warmupMutex    sync.Mutex // Serializes warmups so Chrome is only pre-launched once
+
+	convertAttempts int
+	convertDelay    time.Duration
 }
 
 // BrowserConfig configures browser automation
@@ -28,8 +36,29 @@ type BrowserConfig struct {
 	ExecutablePath string
 	TempDir        string
 	Timeout        time.Duration
+
+	// WarmupAttempts is how many times Chrome is launched to verify it starts
+	// before the first export. Zero uses the default, negative disables warmup.
+	WarmupAttempts int
+	// WarmupDelay is the pause between warmup attempts
+	WarmupDelay time.Duration
+
+	// ConvertAttempts is how many times renderers try a conversion Chrome
+	// fails in a way worth retrying before they settle for their fallback.
+	// Zero uses the default, negative tries once.
+	ConvertAttempts int
+	// ConvertDelay is the pause between conversion attempts
+	ConvertDelay time.Duration
 }
 
+const (
+	defaultWarmupAttempts  = 3
+	defaultWarmupDelay     = 500 * time.Millisecond
+	maxWarmupTimeout       = 10 * time.Second
+	defaultConvertAttempts = 3
+	defaultConvertDelay    = 500 * time.Millisecond
+)
+
 // NewBrowserAutomation creates a new browser automation service
 func NewBrowserAutomation(config BrowserConfig) (*BrowserAutomation, error) {
 	execPath := config.ExecutablePath
@@ -51,16 +80,157 @@ func NewBrowserAutomation(config BrowserConfig) (*BrowserAutomation, error) {
 		timeout = 30 * time.Second
 	}
 
+	warmupAttempts := config.WarmupAttempts
+	if warmupAttempts == 0 {
+		warmupAttempts = defaultWarmupAttempts
+	}
+
+	warmupDelay := config.WarmupDelay
+	if warmupDelay == 0 {
+		warmupDelay = defaultWarmupDelay
+	}
+
+	convertAttempts := config.ConvertAttempts
+	if convertAttempts == 0 {
+		convertAttempts = defaultConvertAttempts
+	} else if convertAttempts < 0 {
+		convertAttempts = 1
+	}
+
+	convertDelay := config.ConvertDelay
+	if convertDelay == 0 {
+		convertDelay = defaultConvertDelay
+	}
+
 	return &BrowserAutomation{
 		executablePath:  execPath,
 		tempDir:         tempDir,
 		timeout:         timeout,
 		activeProcesses: make(map[string]*exec.Cmd),
 		processMutex:    sync.RWMutex{},
+		warmupAttempts:  warmupAttempts,
+		warmupDelay:     warmupDelay,
+		convertAttempts: convertAttempts,
+		convertDelay:    convertDelay,
 	}, nil
 }
 
-// ConvertHTMLToPDF converts an HTML file to PDF using Chrome headless
+// retry runs convert, a conversion through Chrome, again after a pause each
+// time it fails with a retryable browser error, up to the configured
+// attempts. Renderers convert through it before settling for a fallback.
+func (ba *BrowserAutomation) retry(ctx context.Context, convert func() error) error {
+	var err error
+	for attempt := 1; attempt <= ba.convertAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(ba.convertDelay):
+			case <-ctx.Done():
+				return newBrowserError(ctx, "browser conversion cancelled", nil, ctx.Err())
+			}
+		}
+
+		if err = convert(); err == nil || !isRetryableBrowserError(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// isRetryableBrowserError reports whether err is a Chrome failure that may
+// not happen again, such as a launch timing out
+func isRetryableBrowserError(err error) bool {
+	var exportErr *ExportError
+	return errors.As(err, &exportErr) && exportErr.Type == ErrorTypeBrowser && exportErr.Retryable
+}
+
+// Warmup launches headless Chrome against a blank page to verify it starts,
+// retrying since the first launch on a cold machine or CI runner often fails.
+// Once a launch succeeds later calls return immediately.
+func (ba *BrowserAutomation) Warmup(ctx context.Context) error {
+	ba.warmupMutex.Lock()
+	defer ba.warmupMutex.Unlock()
+
+	if ba.warmedUp || ba.warmupAttempts < 0 {
+		return nil
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= ba.warmupAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(ba.warmupDelay):
+			case <-ctx.Done():
+				return newBrowserError(ctx, "browser warmup cancelled", nil, ctx.Err())
+			}
+		}
+
+		if lastErr = ba.launchBlank(ctx); lastErr == nil {
+			ba.warmedUp = true
+			return nil
+		}
+	}
+
+	return lastErr
+}
+
+// launchBlank starts Chrome once and waits for it to render about:blank
+func (ba *BrowserAutomation) launchBlank(ctx context.Context) error {
+	timeout := ba.timeout
+	if timeout > maxWarmupTimeout {
+		timeout = maxWarmupTimeout
+	}
+
+	cmdCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// #nosec G204 - executablePath is validated during initialization and args are fixed
+	cmd := exec.CommandContext(cmdCtx, ba.executablePath,
+		"--headless",
+		"--disable-gpu",
+		"--no-sandbox",
+		"--disable-dev-shm-usage",
+		"--dump-dom",
+		"about:blank",
+	)
+	cmd.Dir = ba.tempDir
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return newBrowserError(cmdCtx, "chrome failed to start", output, err)
+	}
+
+	return nil
+}
+
+// newBrowserError wraps a Chrome launch failure as a retryable browser error,
+// distinguishing timeouts from crashes. Cancellation by the caller is not retried.
+func newBrowserError(ctx context.Context, message string, output []byte, err error) *ExportError {
+	exportErr := &ExportError{
+		Type:      ErrorTypeBrowser,
+		Message:   message,
+		Details:   strings.TrimSpace(string(output)),
+		Code:      "BROWSER_LAUNCH_FAILED",
+		Retryable: true,
+		Cause:     err,
+	}
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		exportErr.Code = "BROWSER_TIMEOUT"
+		exportErr.Message = message + ": timed out"
+	case errors.Is(ctx.Err(), context.Canceled):
+		exportErr.Code = "BROWSER_CANCELLED"
+		exportErr.Retryable = false
+	case exportErr.Details == "" && err != nil:
+		exportErr.Details = err.Error()
+	}
+
+	return exportErr
+}
+
+// ConvertHTMLToPDF converts an HTML file to PDF using Chrome headless. It
+// prints through the DevTools Protocol so orientation and paper size apply,
+// falling back to --print-to-pdf when Chrome's remote debugging can't be used.
 func (ba *BrowserAutomation) ConvertHTMLToPDF(ctx context.Context, htmlPath, outputPath string, options *PDFOptions) error {
 	if err := validateFilePath(htmlPath); err != nil {
 		return fmt.Errorf("invalid HTML path: %w", err)
@@ -75,7 +245,24 @@ func (ba *BrowserAutomation) ConvertHTMLToPDF(ctx context.Context, htmlPath, out
 		return fmt.Errorf("creating output directory: %w", err)
 	}
 
-	// Build Chrome arguments
+	// Convert file path to file:// URL
+	absPath, err := filepath.Abs(htmlPath)
+	if err != nil {
+		return fmt.Errorf("getting absolute path: %w", err)
+	}
+	fileURL := "file://" + absPath
+
+	// Create context with timeout
+	cmdCtx, cancel := context.WithTimeout(ctx, ba.timeout)
+	defer cancel()
+
+	err = ba.printToPDFViaCDP(cmdCtx, fileURL, outputPath, options)
+	if !errors.Is(err, errCDPUnavailable) {
+		return err
+	}
+
+	// Build Chrome arguments. --print-to-pdf always prints portrait on the
+	// default paper size, so this is only a fallback.
 	args := []string{
 		"--headless",
 		"--disable-gpu",
@@ -90,39 +277,18 @@ func (ba *BrowserAutomation) ConvertHTMLToPDF(ctx context.Context, htmlPath, out
 		"--print-to-pdf=" + outputPath,
 	}
 
-	// Add PDF-specific options
-	if options != nil {
-		if options.PageSize != "" {
-			args = append(args, "--print-to-pdf-no-header")
-		}
-		if options.Landscape {
-			// Chrome doesn't have a direct landscape flag for print-to-pdf
-			// TODO: Implement landscape mode via Chrome DevTools Protocol (CDP)
-			// For now, landscape mode is not supported in Chrome export
-			_ = options.Landscape // Explicitly acknowledge this option
-		}
-		if options.MarginTop != "" || options.MarginBottom != "" || options.MarginLeft != "" || options.MarginRight != "" {
-			// Chrome uses default margins, custom margins require CDP (Chrome DevTools Protocol)
-			// TODO: Implement custom margins via Chrome DevTools Protocol (CDP)
-			// For now, we'll use defaults and note this as a future enhancement
-			// Explicitly acknowledge these margin options
-			_, _, _, _ = options.MarginTop, options.MarginBottom, options.MarginLeft, options.MarginRight
-		}
+	if options != nil && options.PageSize != "" {
+		args = append(args, "--print-to-pdf-no-header")
 	}
 
-	// Convert file path to file:// URL
-	absPath, err := filepath.Abs(htmlPath)
-	if err != nil {
-		return fmt.Errorf("getting absolute path: %w", err)
+	if options != nil {
+		if quality := pdfQualitySettings(options.Quality); quality.DeviceScaleFactor != 1 {
+			args = append(args, fmt.Sprintf("--force-device-scale-factor=%g", quality.DeviceScaleFactor))
+		}
 	}
-	fileURL := "file://" + absPath
 
 	args = append(args, fileURL)
 
-	// Create context with timeout
-	cmdCtx, cancel := context.WithTimeout(ctx, ba.timeout)
-	defer cancel()
-
 	// Execute Chrome with process tracking
 	// #nosec G204 - executablePath is validated during initialization and args are controlled
 	// This is necessary for PDF export functionality in a CLI tool context
@@ -146,17 +312,58 @@ func (ba *BrowserAutomation) ConvertHTMLToPDF(ctx context.Context, htmlPath, out
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("chrome PDF generation failed: %w (output: %s)", err, string(output))
+		return newBrowserError(cmdCtx, "chrome PDF generation failed", output, err)
 	}
 
 	// Verify the PDF was created
 	if _, err := os.Stat(outputPath); os.IsNotExist(err) {
-		return fmt.Errorf("PDF file was not created at %s", outputPath)
+		return newBrowserError(cmdCtx, "chrome PDF generation failed", output,
+			fmt.Errorf("PDF file was not created at %s", outputPath))
 	}
 
 	return nil
 }
 
+// CaptureSlides loads an exported HTML presentation in a width by height
+// window and returns its first count slides serialized as XHTML, with their
+// computed styles inlined so they render the same outside the page
+func (ba *BrowserAutomation) CaptureSlides(ctx context.Context, htmlPath string, count, width, height int) ([]string, error) {
+	if err := validateFilePath(htmlPath); err != nil {
+		return nil, fmt.Errorf("invalid HTML path: %w", err)
+	}
+
+	absPath, err := filepath.Abs(htmlPath)
+	if err != nil {
+		return nil, fmt.Errorf("getting absolute path: %w", err)
+	}
+	fileURL := "file://" + absPath
+
+	cmdCtx, cancel := context.WithTimeout(ctx, ba.timeout)
+	defer cancel()
+
+	slides := make([]string, 0, count)
+	err = ba.withDevToolsPage(cmdCtx, "chrome slide capture failed", func(client *cdpClient) error {
+		if err := client.open(cmdCtx, fileURL, width, height, 1); err != nil {
+			return err
+		}
+		for i := 0; i < count; i++ {
+			var slide string
+			if err := client.evaluate(fmt.Sprintf(captureSlideScript, i), &slide); err != nil {
+				return fmt.Errorf("capturing slide %d: %w", i+1, err)
+			}
+			if slide == "" {
+				return fmt.Errorf("capturing slide %d: slide not found in the page", i+1)
+			}
+			slides = append(slides, slide)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slides, nil
+}
+
 // ConvertHTMLToImage converts an HTML file to an image using Chrome headless
 func (ba *BrowserAutomation) ConvertHTMLToImage(ctx context.Context, htmlPath, outputPath string, options *ImageOptions) error {
 	if err := validateFilePath(htmlPath); err != nil {
@@ -239,12 +446,13 @@ func (ba *BrowserAutomation) ConvertHTMLToImage(ctx context.Context, htmlPath, o
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("chrome screenshot generation failed: %w (output: %s)", err, string(output))
+		return newBrowserError(cmdCtx, "chrome screenshot generation failed", output, err)
 	}
 
 	// Verify the image was created
 	if _, err := os.Stat(outputPath); os.IsNotExist(err) {
-		return fmt.Errorf("image file was not created at %s", outputPath)
+		return newBrowserError(cmdCtx, "chrome screenshot generation failed", output,
+			fmt.Errorf("image file was not created at %s", outputPath))
 	}
 
 	return nil
@@ -260,6 +468,36 @@ type PDFOptions struct {
 	MarginLeft   string
 	PrintHeaders bool
 	PrintFooters bool
+	Quality      string // low, medium, high
+}
+
+// pdfQuality is how Chrome renders a PDF at one quality setting
+type pdfQuality struct {
+	DeviceScaleFactor float64 // Device pixels per CSS pixel when rasterizing
+	DPI               int     // Resolution of rasterized content
+}
+
+// pdfQualitySettings maps an export quality to Chrome's print settings.
+// CSS lays pages out at 96 pixels per inch, so content Chrome rasterizes
+// (canvases, filtered or shadowed elements, scaled images) is embedded at
+// 96 × device scale factor DPI:
+//
+//	low      device scale factor 0.75,  72 DPI
+//	medium   device scale factor 1,     96 DPI (default)
+//	high     device scale factor 2,    192 DPI
+//
+// Chrome's print scale is left at its default at every quality so text and
+// layout are identical across settings and only the density of raster
+// content changes.
+func pdfQualitySettings(quality string) pdfQuality {
+	switch quality {
+	case "low":
+		return pdfQuality{DeviceScaleFactor: 0.75, DPI: 72}
+	case "high":
+		return pdfQuality{DeviceScaleFactor: 2, DPI: 192}
+	default: // medium
+		return pdfQuality{DeviceScaleFactor: 1, DPI: 96}
+	}
 }
 
 // ImageOptions contains options for image generation
@@ -268,6 +506,11 @@ type ImageOptions struct {
 	Height  int
 	Quality string // low, medium, high
 	Format  string // png, jpg
+
+	// ContactSheet also composes the slide images into one grid image,
+	// Columns wide (DefaultContactSheetColumns when zero)
+	ContactSheet bool
+	Columns      int
 }
 
 // findChromeExecutable attempts to find Chrome or Chromium executable
@@ -1,15 +1,29 @@
 package export
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
+	"github.com/jung-kurt/gofpdf/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 )
 
 func TestNewBrowserAutomation(t *testing.T) {
@@ -382,3 +396,488 @@ func TestService_KillAllBrowserProcesses(t *testing.T) {
 	// Verify processes are gone
 	assert.Equal(t, 0, ba.GetActiveProcessCount())
 }
+
+// fakeChrome writes a script standing in for Chrome that fails its first
+// failures launches, then succeeds, writing any --print-to-pdf or
+// --screenshot output.
+// Version checks always succeed and don't count as launches. It returns the
+// script path and a func reporting how many times it was launched.
+func fakeChrome(t *testing.T, failures int) (string, func() int) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake Chrome is a shell script")
+	}
+
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "launches")
+	script := fmt.Sprintf(`#!/bin/sh
+[ "$1" = "--version" ] && exit 0
+echo "$*" >> %q
+if [ "$(wc -l < %q)" -le %d ]; then
+	echo "Failed to launch the browser process" >&2
+	exit 1
+fi
+for arg in "$@"; do
+	case "$arg" in
+	--print-to-pdf=*) printf '%%%%PDF-1.4\n' > "${arg#--print-to-pdf=}" ;;
+	--screenshot=*) printf 'chrome' > "${arg#--screenshot=}" ;;
+	esac
+done
+`, counter, counter, failures)
+
+	path := filepath.Join(dir, "chrome")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755)) // #nosec G306 - test executable
+
+	return path, func() int {
+		data, err := os.ReadFile(counter) // #nosec G304 - test file
+		if err != nil {
+			return 0
+		}
+		return strings.Count(string(data), "\n")
+	}
+}
+
+// fakeChromeLaunches returns the arguments of each launch of a fakeChrome
+func fakeChromeLaunches(t *testing.T, chrome string) []string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(chrome), "launches")) // #nosec G304 - test file
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestBrowserAutomation_Warmup(t *testing.T) {
+	t.Run("retries a failed first launch", func(t *testing.T) {
+		chrome, launches := fakeChrome(t, 1)
+		ba, err := NewBrowserAutomation(BrowserConfig{
+			ExecutablePath: chrome,
+			WarmupDelay:    time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, ba.Warmup(context.Background()))
+		assert.Equal(t, 2, launches())
+
+		// A warmed up browser is not launched again
+		require.NoError(t, ba.Warmup(context.Background()))
+		assert.Equal(t, 2, launches())
+	})
+
+	t.Run("reports a retryable browser error when every attempt fails", func(t *testing.T) {
+		chrome, launches := fakeChrome(t, 10)
+		ba, err := NewBrowserAutomation(BrowserConfig{
+			ExecutablePath: chrome,
+			WarmupAttempts: 2,
+			WarmupDelay:    time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		err = ba.Warmup(context.Background())
+		var exportErr *ExportError
+		require.ErrorAs(t, err, &exportErr)
+		assert.Equal(t, ErrorTypeBrowser, exportErr.Type)
+		assert.Equal(t, "BROWSER_LAUNCH_FAILED", exportErr.Code)
+		assert.True(t, exportErr.Retryable)
+		assert.Contains(t, exportErr.Details, "Failed to launch")
+		assert.Equal(t, 2, launches())
+	})
+
+	t.Run("can be disabled", func(t *testing.T) {
+		chrome, launches := fakeChrome(t, 0)
+		ba, err := NewBrowserAutomation(BrowserConfig{ExecutablePath: chrome, WarmupAttempts: -1})
+		require.NoError(t, err)
+
+		require.NoError(t, ba.Warmup(context.Background()))
+		assert.Equal(t, 0, launches())
+	})
+}
+
+func TestBrowserAutomation_retry(t *testing.T) {
+	ba, err := NewBrowserAutomation(BrowserConfig{ExecutablePath: "/bin/sh", ConvertAttempts: 3, ConvertDelay: time.Millisecond})
+	require.NoError(t, err)
+	flaky := &ExportError{Type: ErrorTypeBrowser, Code: "BROWSER_LAUNCH_FAILED", Retryable: true}
+
+	t.Run("retries retryable browser errors", func(t *testing.T) {
+		calls := 0
+		err := ba.retry(context.Background(), func() error {
+			if calls++; calls < 3 {
+				return flaky
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		calls := 0
+		err := ba.retry(context.Background(), func() error {
+			calls++
+			return flaky
+		})
+		assert.Same(t, flaky, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("doesn't retry other errors", func(t *testing.T) {
+		calls := 0
+		invalid := errors.New("invalid HTML path")
+		err := ba.retry(context.Background(), func() error {
+			calls++
+			return invalid
+		})
+		assert.Same(t, invalid, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestImageRenderer_RetriesChromeBeforeFallback(t *testing.T) {
+	chrome, launches := fakeChrome(t, 1)
+	renderer, err := NewImageRendererWithBrowser(BrowserConfig{
+		ExecutablePath: chrome,
+		WarmupAttempts: -1,
+		ConvertDelay:   time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	htmlPath := filepath.Join(dir, "slide.html")
+	require.NoError(t, os.WriteFile(htmlPath, []byte("<html></html>"), 0o600))
+	imagePath := filepath.Join(dir, "slide.png")
+
+	require.NoError(t, renderer.convertHTMLToImage(htmlPath, imagePath, &ExportOptions{Format: FormatImages}))
+	assert.Equal(t, 2, launches())
+	data, err := os.ReadFile(imagePath) // #nosec G304 - test file
+	require.NoError(t, err)
+	assert.Equal(t, "chrome", string(data), "captured by Chrome, not the placeholder fallback")
+}
+
+func TestBrowserAutomation_ConvertHTMLToPDFTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake Chrome is a shell script")
+	}
+
+	dir := t.TempDir()
+	chrome := filepath.Join(dir, "chrome")
+	require.NoError(t, os.WriteFile(chrome, []byte("#!/bin/sh\nexec sleep 5\n"), 0o755)) // #nosec G306 - test executable
+	htmlPath := filepath.Join(dir, "slides.html")
+	require.NoError(t, os.WriteFile(htmlPath, []byte("<html></html>"), 0o644))
+
+	ba, err := NewBrowserAutomation(BrowserConfig{ExecutablePath: chrome, Timeout: 100 * time.Millisecond})
+	require.NoError(t, err)
+
+	err = ba.ConvertHTMLToPDF(context.Background(), htmlPath, filepath.Join(dir, "out.pdf"), nil)
+	var exportErr *ExportError
+	require.ErrorAs(t, err, &exportErr)
+	assert.Equal(t, ErrorTypeBrowser, exportErr.Type)
+	assert.Equal(t, "BROWSER_TIMEOUT", exportErr.Code)
+	assert.True(t, exportErr.Retryable)
+}
+
+// fakeCDPRequest records what the fake Chrome was asked to print
+type fakeCDPRequest struct {
+	printToPDFParams
+	DeviceScaleFactor float64 `json:"deviceScaleFactor"`
+
+	// PrintParams are the Page.printToPDF parameters as sent
+	PrintParams map[string]interface{} `json:"-"`
+}
+
+// fakeCDPChrome writes a script standing in for Chrome whose remote debugging
+// endpoint is a test server. Page.printToPDF returns a blank PDF sized from
+// the requested paper size and orientation, and Runtime.evaluate a captured slide.
+func fakeCDPChrome(t *testing.T) (string, *fakeCDPRequest) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake Chrome is a shell script")
+	}
+
+	requested := &fakeCDPRequest{}
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	mux.HandleFunc("/json/list", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]cdpTarget{
+			{Type: "page", WebSocketDebuggerURL: "ws://" + host + "/devtools/page/1"},
+		})
+	})
+	mux.HandleFunc("/devtools/page/1", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		for {
+			var cmd struct {
+				ID     int64           `json:"id"`
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			if err := conn.ReadJSON(&cmd); err != nil {
+				return
+			}
+
+			result := map[string]interface{}{}
+			switch cmd.Method {
+			case "Page.navigate":
+				_ = conn.WriteJSON(map[string]interface{}{"id": cmd.ID, "result": result})
+				_ = conn.WriteJSON(map[string]interface{}{"method": "Page.loadEventFired", "params": result})
+				continue
+			case "Emulation.setDeviceMetricsOverride":
+				_ = json.Unmarshal(cmd.Params, requested)
+			case "Runtime.evaluate":
+				result["result"] = map[string]interface{}{
+					"type":  "string",
+					"value": `<div xmlns="http://www.w3.org/1999/xhtml" class="slide active" style="opacity:1;"><h1>Captured</h1></div>`,
+				}
+			case "Page.printToPDF":
+				_ = json.Unmarshal(cmd.Params, &requested.printToPDFParams)
+				requested.PrintParams = nil
+				_ = json.Unmarshal(cmd.Params, &requested.PrintParams)
+				result["data"] = base64.StdEncoding.EncodeToString(blankPDF(t, *requested))
+			}
+			_ = conn.WriteJSON(map[string]interface{}{"id": cmd.ID, "result": result})
+		}
+	})
+
+	dir := t.TempDir()
+	script := fmt.Sprintf(`#!/bin/sh
+for arg in "$@"; do
+	case "$arg" in
+	--version) echo "Chromium 120.0.0.0"; exit 0 ;;
+	--remote-debugging-port=*) echo "DevTools listening on ws://%s/devtools/browser/fake" >&2; exec sleep 30 ;;
+	esac
+done
+`, host)
+
+	path := filepath.Join(dir, "chrome")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755)) // #nosec G306 - test executable
+	return path, requested
+}
+
+// blankPDF prints a single page the way Chrome would for params
+func blankPDF(t *testing.T, params fakeCDPRequest) []byte {
+	width, height := params.PaperWidth, params.PaperHeight
+	if width == 0 || height == 0 {
+		width, height = 8.5, 11
+	}
+	orientation := "P"
+	if params.Landscape {
+		orientation = "L"
+	}
+
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{
+		OrientationStr: orientation,
+		UnitStr:        "in",
+		Size:           gofpdf.SizeType{Wd: width, Ht: height},
+	})
+	pdf.AddPage()
+
+	var buf bytes.Buffer
+	require.NoError(t, pdf.Output(&buf))
+	return buf.Bytes()
+}
+
+// pdfMediaBox returns the page width and height declared in a PDF
+func pdfMediaBox(t *testing.T, path string) (float64, float64) {
+	t.Helper()
+	data, err := os.ReadFile(path) // #nosec G304 - test file
+	require.NoError(t, err)
+
+	match := regexp.MustCompile(`/MediaBox \[0 0 ([\d.]+) ([\d.]+)\]`).FindSubmatch(data)
+	require.NotNil(t, match, "PDF should declare a MediaBox")
+	width, err := strconv.ParseFloat(string(match[1]), 64)
+	require.NoError(t, err)
+	height, err := strconv.ParseFloat(string(match[2]), 64)
+	require.NoError(t, err)
+	return width, height
+}
+
+func TestPDFRenderer_Landscape(t *testing.T) {
+	presentation := &entities.Presentation{
+		Title:  "Wide",
+		Slides: []entities.Slide{{Index: 0, Title: "Diagram", HTML: "<h1>Diagram</h1>"}},
+	}
+
+	t.Run("prints through the DevTools protocol", func(t *testing.T) {
+		chrome, requested := fakeCDPChrome(t)
+		renderer, err := NewPDFRendererWithBrowser(BrowserConfig{ExecutablePath: chrome})
+		require.NoError(t, err)
+
+		outputPath := filepath.Join(t.TempDir(), "wide.pdf")
+		_, err = renderer.Render(context.Background(), presentation, &ExportOptions{
+			Format:      FormatPDF,
+			OutputPath:  outputPath,
+			PageSize:    "A4",
+			Orientation: "landscape",
+		})
+		require.NoError(t, err)
+
+		assert.True(t, requested.Landscape)
+		assert.Equal(t, 8.27, requested.PaperWidth)
+		assert.Equal(t, 11.69, requested.PaperHeight)
+
+		width, height := pdfMediaBox(t, outputPath)
+		assert.Greater(t, width, height)
+	})
+
+	t.Run("fallback PDF honors orientation", func(t *testing.T) {
+		renderer := &PDFRenderer{htmlRenderer: NewHTMLRenderer()}
+
+		outputPath := filepath.Join(t.TempDir(), "wide.pdf")
+		_, err := renderer.Render(context.Background(), presentation, &ExportOptions{
+			Format:      FormatPDF,
+			OutputPath:  outputPath,
+			Orientation: "landscape",
+		})
+		require.NoError(t, err)
+
+		width, height := pdfMediaBox(t, outputPath)
+		assert.Greater(t, width, height)
+	})
+}
+
+func TestParseMargin(t *testing.T) {
+	tests := []struct {
+		value  string
+		inches float64
+	}{
+		{"0.5in", 0.5},
+		{"1", 1},
+		{"0", 0},
+		{"2.54cm", 1},
+		{"1CM", 1 / 2.54},
+		{"25.4mm", 1},
+		{"10 mm", 10 / 25.4},
+		{"72pt", 1},
+		{"48px", 0.5},
+		{" .25in ", 0.25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			inches, err := parseMargin(tt.value)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.inches, inches, 1e-9)
+		})
+	}
+
+	for _, value := range []string{"", "wide", "-1cm", "1.2.3in", "2em", "in", "1 in 2"} {
+		t.Run("rejects "+value, func(t *testing.T) {
+			_, err := parseMargin(value)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPDFRenderer_Margins(t *testing.T) {
+	chrome, requested := fakeCDPChrome(t)
+	renderer, err := NewPDFRendererWithBrowser(BrowserConfig{ExecutablePath: chrome})
+	require.NoError(t, err)
+
+	_, err = renderer.Render(context.Background(), &entities.Presentation{
+		Title:  "Margins",
+		Slides: []entities.Slide{{Index: 0, HTML: "<h1>Margins</h1>"}},
+	}, &ExportOptions{
+		Format:       FormatPDF,
+		OutputPath:   filepath.Join(t.TempDir(), "margins.pdf"),
+		MarginTop:    "0.5in",
+		MarginRight:  "2.54cm",
+		MarginBottom: "0",
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, requested.MarginTop)
+	assert.InDelta(t, 0.5, *requested.MarginTop, 1e-9)
+	require.NotNil(t, requested.MarginRight)
+	assert.InDelta(t, 1, *requested.MarginRight, 1e-9)
+	require.NotNil(t, requested.MarginBottom, "a zero margin is sent rather than left to Chrome")
+	assert.Zero(t, *requested.MarginBottom)
+	assert.Nil(t, requested.MarginLeft, "unset margins keep Chrome's default")
+}
+
+func TestPDFRenderer_Quality(t *testing.T) {
+	chrome, requested := fakeCDPChrome(t)
+	renderer, err := NewPDFRendererWithBrowser(BrowserConfig{ExecutablePath: chrome})
+	require.NoError(t, err)
+
+	presentation := &entities.Presentation{
+		Title:  "Print",
+		Slides: []entities.Slide{{Index: 0, Title: "Chart", HTML: "<h1>Chart</h1>"}},
+	}
+
+	tests := []struct {
+		quality     string
+		name        string
+		deviceScale float64
+		dpi         int
+	}{
+		{"low", "low", 0.75, 72},
+		{"", "medium", 1, 96},
+		{"high", "high", 2, 192},
+	}
+
+	for _, tt := range tests {
+		result, err := renderer.Render(context.Background(), presentation, &ExportOptions{
+			Format:     FormatPDF,
+			OutputPath: filepath.Join(t.TempDir(), "print.pdf"),
+			Quality:    tt.quality,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.deviceScale, requested.DeviceScaleFactor, "rasterized at the quality's density")
+		assert.Equal(t, true, requested.PrintParams["printBackground"])
+		assert.NotContains(t, requested.PrintParams, "scale", "quality never changes layout")
+		assert.Equal(t, tt.dpi, result.Metadata["dpi"])
+		assert.Equal(t, tt.name, result.Metadata["quality"], "an unset quality is reported as medium")
+	}
+
+	t.Run("print-to-pdf sets the device scale factor", func(t *testing.T) {
+		chrome, _ := fakeChrome(t, 0)
+		ba, err := NewBrowserAutomation(BrowserConfig{ExecutablePath: chrome})
+		require.NoError(t, err)
+		htmlPath := filepath.Join(t.TempDir(), "slides.html")
+		require.NoError(t, os.WriteFile(htmlPath, []byte("<html></html>"), 0o600))
+
+		for _, tt := range []struct {
+			quality string
+			arg     string
+		}{
+			{"low", "--force-device-scale-factor=0.75"},
+			{"", ""},
+			{"high", "--force-device-scale-factor=2"},
+		} {
+			before := len(fakeChromeLaunches(t, chrome))
+			require.NoError(t, ba.ConvertHTMLToPDF(context.Background(), htmlPath,
+				filepath.Join(t.TempDir(), "print.pdf"), &PDFOptions{Quality: tt.quality}))
+
+			launches := fakeChromeLaunches(t, chrome)[before:]
+			require.Len(t, launches, 2, "DevTools is unavailable, so Chrome prints with --print-to-pdf")
+			printArgs := launches[1]
+			assert.Contains(t, printArgs, "--print-to-pdf=")
+			if tt.arg == "" {
+				assert.NotContains(t, printArgs, "--force-device-scale-factor", "medium keeps Chrome's default")
+			} else {
+				assert.Contains(t, strings.Fields(printArgs), tt.arg)
+			}
+		}
+	})
+
+	t.Run("fallback reports no DPI", func(t *testing.T) {
+		fallback := &PDFRenderer{htmlRenderer: NewHTMLRenderer()}
+		result, err := fallback.Render(context.Background(), presentation, &ExportOptions{
+			Format:     FormatPDF,
+			OutputPath: filepath.Join(t.TempDir(), "print.pdf"),
+			Quality:    "high",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "high", result.Metadata["quality"])
+		assert.NotContains(t, result.Metadata, "dpi")
+	})
+}
@@ -0,0 +1,456 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// errCDPUnavailable marks DevTools Protocol failures where Chrome itself ran,
+// so the flag-based --print-to-pdf path is worth trying instead
+var errCDPUnavailable = errors.New("chrome DevTools protocol unavailable")
+
+// devToolsListening matches the line Chrome prints once remote debugging is ready
+var devToolsListening = regexp.MustCompile(`DevTools listening on (ws://\S+)`)
+
+// paperSizes maps page sizes to portrait width and height in inches
+var paperSizes = map[string][2]float64{
+	"A3":     {11.69, 16.54},
+	"A4":     {8.27, 11.69},
+	"A5":     {5.83, 8.27},
+	"Letter": {8.5, 11},
+	"Legal":  {8.5, 14},
+}
+
+// marginUnits converts supported margin units to inches
+var marginUnits = map[string]float64{
+	"in": 1,
+	"cm": 1 / 2.54,
+	"mm": 1 / 25.4,
+	"pt": 1.0 / 72,
+	"px": 1.0 / 96,
+}
+
+// marginPattern splits a margin such as "0.5in" or "10 mm" into number and unit
+var marginPattern = regexp.MustCompile(`^([0-9]*\.?[0-9]+)\s*([a-z]*)$`)
+
+// parseMargin converts a margin with a unit (in, cm, mm, pt or px) to inches.
+// A bare number is taken as inches, which is what Page.printToPDF expects.
+func parseMargin(value string) (float64, error) {
+	match := marginPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if match == nil {
+		return 0, fmt.Errorf("%q is not a non-negative length such as 0.5in, 1cm or 10mm", value)
+	}
+
+	unit := match[2]
+	if unit == "" {
+		unit = "in"
+	}
+	factor, ok := marginUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("%q has unsupported unit %q (use in, cm, mm, pt or px)", value, unit)
+	}
+
+	number, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid length: %w", value, err)
+	}
+	return number * factor, nil
+}
+
+// printToPDFParams are the Page.printToPDF parameters slicli sets. Margins
+// are pointers because zero is a valid margin distinct from Chrome's default.
+type printToPDFParams struct {
+	Landscape       bool     `json:"landscape"`
+	PrintBackground bool     `json:"printBackground"`
+	PaperWidth      float64  `json:"paperWidth,omitempty"`
+	PaperHeight     float64  `json:"paperHeight,omitempty"`
+	MarginTop       *float64 `json:"marginTop,omitempty"`
+	MarginBottom    *float64 `json:"marginBottom,omitempty"`
+	MarginLeft      *float64 `json:"marginLeft,omitempty"`
+	MarginRight     *float64 `json:"marginRight,omitempty"`
+}
+
+// newPrintToPDFParams converts PDF options to Page.printToPDF parameters.
+// Margins that don't parse are left at Chrome's default; the export service
+// rejects them before rendering.
+func newPrintToPDFParams(options *PDFOptions) printToPDFParams {
+	params := printToPDFParams{PrintBackground: true}
+	if options == nil {
+		return params
+	}
+
+	params.Landscape = options.Landscape
+	if size, ok := paperSizes[options.PageSize]; ok {
+		params.PaperWidth, params.PaperHeight = size[0], size[1]
+	}
+
+	for _, margin := range []struct {
+		value  string
+		target **float64
+	}{
+		{options.MarginTop, &params.MarginTop},
+		{options.MarginBottom, &params.MarginBottom},
+		{options.MarginLeft, &params.MarginLeft},
+		{options.MarginRight, &params.MarginRight},
+	} {
+		if margin.value == "" {
+			continue
+		}
+		if inches, err := parseMargin(margin.value); err == nil {
+			*margin.target = &inches
+		}
+	}
+	return params
+}
+
+// printToPDFViaCDP launches Chrome with remote debugging and prints fileURL
+// with Page.printToPDF, which unlike --print-to-pdf honors orientation,
+// paper size and margins
+func (ba *BrowserAutomation) printToPDFViaCDP(ctx context.Context, fileURL, outputPath string, options *PDFOptions) error {
+	var quality string
+	if options != nil {
+		quality = options.Quality
+	}
+
+	var pdf []byte
+	err := ba.withDevToolsPage(ctx, "chrome PDF generation failed", func(client *cdpClient) error {
+		var err error
+		pdf, err = client.printToPDF(ctx, fileURL, newPrintToPDFParams(options), pdfQualitySettings(quality).DeviceScaleFactor)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, pdf, 0600); err != nil {
+		return fmt.Errorf("writing PDF: %w", err)
+	}
+	return nil
+}
+
+// withDevToolsPage launches Chrome with remote debugging and runs fn against
+// the page it opens. Failures of the protocol itself are wrapped in
+// errCDPUnavailable; Chrome failing to run is reported as failure.
+func (ba *BrowserAutomation) withDevToolsPage(ctx context.Context, failure string, fn func(*cdpClient) error) error {
+	userDataDir, err := os.MkdirTemp(ba.tempDir, "slicli-cdp-")
+	if err != nil {
+		return fmt.Errorf("creating Chrome profile directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(userDataDir) }()
+
+	// #nosec G204 - executablePath is validated during initialization and args are controlled
+	cmd := exec.CommandContext(ctx, ba.executablePath,
+		"--headless",
+		"--disable-gpu",
+		"--no-sandbox",
+		"--disable-dev-shm-usage",
+		"--remote-debugging-port=0",
+		"--user-data-dir="+userDataDir,
+		"about:blank",
+	)
+	cmd.Dir = ba.tempDir
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("capturing Chrome output: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return newBrowserError(ctx, failure, nil, err)
+	}
+
+	processID := fmt.Sprintf("cdp-%d", time.Now().UnixNano())
+	ba.processMutex.Lock()
+	ba.activeProcesses[processID] = cmd
+	ba.processMutex.Unlock()
+
+	defer func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		ba.processMutex.Lock()
+		delete(ba.activeProcesses, processID)
+		ba.processMutex.Unlock()
+	}()
+
+	endpoint, output, err := waitForDevTools(ctx, stderr)
+	if err != nil {
+		if ctx.Err() != nil {
+			return newBrowserError(ctx, failure, output, ctx.Err())
+		}
+		// Chrome exited before remote debugging came up; a failed start is
+		// reported as such, a clean exit means CDP isn't supported
+		if waitErr := cmd.Wait(); waitErr != nil {
+			return newBrowserError(ctx, failure, output, waitErr)
+		}
+		return fmt.Errorf("%w: %v", errCDPUnavailable, err)
+	}
+
+	client, err := dialPageTarget(ctx, endpoint)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errCDPUnavailable, err)
+	}
+	defer func() { _ = client.Close() }()
+
+	if err := fn(client); err != nil {
+		if ctx.Err() != nil {
+			return newBrowserError(ctx, failure, nil, ctx.Err())
+		}
+		return fmt.Errorf("%w: %v", errCDPUnavailable, err)
+	}
+	return nil
+}
+
+// waitForDevTools reads Chrome's stderr until it announces the DevTools
+// endpoint, returning the output seen so far on failure
+func waitForDevTools(ctx context.Context, stderr io.Reader) (string, []byte, error) {
+	type result struct {
+		endpoint string
+		output   []byte
+	}
+	found := make(chan result, 1)
+
+	go func() {
+		var output []byte
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			line := scanner.Text()
+			output = append(output, line+"\n"...)
+			if match := devToolsListening.FindStringSubmatch(line); match != nil {
+				found <- result{endpoint: match[1]}
+				// Keep draining so Chrome never blocks on a full pipe
+				_, _ = io.Copy(io.Discard, stderr)
+				return
+			}
+		}
+		found <- result{output: output}
+	}()
+
+	select {
+	case r := <-found:
+		if r.endpoint == "" {
+			return "", r.output, errors.New("chrome exited without a DevTools endpoint")
+		}
+		return r.endpoint, nil, nil
+	case <-ctx.Done():
+		return "", nil, ctx.Err()
+	}
+}
+
+// cdpTarget is an entry of Chrome's /json/list
+type cdpTarget struct {
+	Type                 string `json:"type"`
+	WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+}
+
+// dialPageTarget connects to the page Chrome opened at startup
+func dialPageTarget(ctx context.Context, browserEndpoint string) (*cdpClient, error) {
+	endpoint, err := url.Parse(browserEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("parsing DevTools endpoint: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+endpoint.Host+"/json/list", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("listing DevTools targets: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var targets []cdpTarget
+	if err := json.NewDecoder(resp.Body).Decode(&targets); err != nil {
+		return nil, fmt.Errorf("decoding DevTools targets: %w", err)
+	}
+
+	for _, target := range targets {
+		if target.Type == "page" && target.WebSocketDebuggerURL != "" {
+			conn, _, err := websocket.DefaultDialer.DialContext(ctx, target.WebSocketDebuggerURL, nil)
+			if err != nil {
+				return nil, fmt.Errorf("connecting to page target: %w", err)
+			}
+			return newCDPClient(ctx, conn), nil
+		}
+	}
+	return nil, errors.New("no page target to print")
+}
+
+// cdpMessage is a DevTools Protocol command response or event
+type cdpMessage struct {
+	ID     int64           `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// cdpClient sends DevTools Protocol commands to a single page, one at a time
+type cdpClient struct {
+	conn   *websocket.Conn
+	nextID int64
+	events map[string]bool // Events received while waiting for responses
+	stop   func() bool
+	once   sync.Once
+}
+
+func newCDPClient(ctx context.Context, conn *websocket.Conn) *cdpClient {
+	// Closing the connection unblocks any pending read when ctx ends
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	return &cdpClient{conn: conn, events: make(map[string]bool), stop: stop}
+}
+
+// Close closes the connection to the page
+func (c *cdpClient) Close() error {
+	var err error
+	c.once.Do(func() {
+		c.stop()
+		err = c.conn.Close()
+	})
+	return err
+}
+
+// call sends a command and decodes its result into result when non-nil
+func (c *cdpClient) call(method string, params interface{}, result interface{}) error {
+	c.nextID++
+	id := c.nextID
+
+	if err := c.conn.WriteJSON(map[string]interface{}{"id": id, "method": method, "params": params}); err != nil {
+		return fmt.Errorf("sending %s: %w", method, err)
+	}
+
+	for {
+		msg, err := c.read()
+		if err != nil {
+			return fmt.Errorf("waiting for %s: %w", method, err)
+		}
+		if msg.ID != id {
+			continue
+		}
+		if msg.Error != nil {
+			return fmt.Errorf("%s failed: %s", method, msg.Error.Message)
+		}
+		if result != nil {
+			return json.Unmarshal(msg.Result, result)
+		}
+		return nil
+	}
+}
+
+// waitEvent blocks until the page emits the named event
+func (c *cdpClient) waitEvent(method string) error {
+	for !c.events[method] {
+		if _, err := c.read(); err != nil {
+			return fmt.Errorf("waiting for %s: %w", method, err)
+		}
+	}
+	return nil
+}
+
+func (c *cdpClient) read() (*cdpMessage, error) {
+	var msg cdpMessage
+	if err := c.conn.ReadJSON(&msg); err != nil {
+		return nil, err
+	}
+	if msg.Method != "" {
+		c.events[msg.Method] = true
+	}
+	return &msg, nil
+}
+
+// printToPDF loads fileURL, rasterizing at deviceScaleFactor, and returns
+// it printed as PDF
+func (c *cdpClient) printToPDF(ctx context.Context, fileURL string, params printToPDFParams, deviceScaleFactor float64) ([]byte, error) {
+	// A zero width and height keep the window size and only override the scale
+	if err := c.open(ctx, fileURL, 0, 0, deviceScaleFactor); err != nil {
+		return nil, err
+	}
+
+	var printed struct {
+		Data string `json:"data"`
+	}
+	if err := c.call("Page.printToPDF", params, &printed); err != nil {
+		return nil, err
+	}
+
+	pdf, err := base64.StdEncoding.DecodeString(printed.Data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding printed PDF: %w", err)
+	}
+	return pdf, nil
+}
+
+// open loads fileURL in a window of width by height CSS pixels, zero to keep
+// the default size, rendered at deviceScaleFactor
+func (c *cdpClient) open(ctx context.Context, fileURL string, width, height int, deviceScaleFactor float64) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.conn.SetReadDeadline(deadline)
+	}
+
+	if err := c.call("Page.enable", struct{}{}, nil); err != nil {
+		return err
+	}
+
+	if err := c.call("Emulation.setDeviceMetricsOverride", map[string]interface{}{
+		"width":             width,
+		"height":            height,
+		"deviceScaleFactor": deviceScaleFactor,
+		"mobile":            false,
+	}, nil); err != nil {
+		return err
+	}
+
+	var navigation struct {
+		ErrorText string `json:"errorText"`
+	}
+	if err := c.call("Page.navigate", map[string]string{"url": fileURL}, &navigation); err != nil {
+		return err
+	}
+	if navigation.ErrorText != "" {
+		return fmt.Errorf("loading %s: %s", fileURL, strings.TrimSpace(navigation.ErrorText))
+	}
+	return c.waitEvent("Page.loadEventFired")
+}
+
+// evaluate runs a JavaScript expression in the page and decodes its value
+// into result
+func (c *cdpClient) evaluate(expression string, result interface{}) error {
+	var evaluated struct {
+		Result struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"result"`
+		ExceptionDetails *struct {
+			Text string `json:"text"`
+		} `json:"exceptionDetails"`
+	}
+	if err := c.call("Runtime.evaluate", map[string]interface{}{
+		"expression":    expression,
+		"returnByValue": true,
+	}, &evaluated); err != nil {
+		return err
+	}
+	if evaluated.ExceptionDetails != nil {
+		return fmt.Errorf("evaluating script: %s", evaluated.ExceptionDetails.Text)
+	}
+	if len(evaluated.Result.Value) == 0 {
+		return errors.New("evaluating script: no value returned")
+	}
+	return json.Unmarshal(evaluated.Result.Value, result)
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+
+	"github.com/fogleman/gg"
+)
+
+// DefaultContactSheetColumns is the contact sheet grid width when none is set
+const DefaultContactSheetColumns = 4
+
+// Contact sheet layout, in pixels
+const (
+	contactSheetThumbWidth = 480 // Width each slide is scaled to
+	contactSheetGap        = 24  // Space around and between thumbnails
+	contactSheetCaption    = 32  // Height of the slide number under each thumbnail
+)
+
+// contactSheetName is the file a contact sheet is written to, next to the slides
+const contactSheetName = "contact-sheet"
+
+// writeContactSheet composes the slide images into one grid image, each
+// captioned with its slide number, and returns the path it was written to.
+// Thumbnails share the first slide's aspect ratio. Slides are decoded one
+// at a time, so only one full-size slide is held in memory.
+func (r *ImageRenderer) writeContactSheet(imagePaths []string, outputDir string, imageOptions *ImageOptions, exportOptions *ExportOptions) (string, error) {
+	first, err := decodeImageSize(imagePaths[0])
+	if err != nil {
+		return "", fmt.Errorf("reading slide 1 for contact sheet: %w", err)
+	}
+	thumbHeight := contactSheetThumbWidth * first.Height / first.Width
+	columns, rows := contactSheetGrid(len(imagePaths), imageOptions.Columns)
+	width, height := contactSheetSize(columns, rows, thumbHeight)
+
+	dc := gg.NewContext(width, height)
+	dc.SetColor(color.White)
+	dc.Clear()
+	if err := r.loadGoFont(dc, contactSheetCaption*0.6); err != nil {
+		return "", fmt.Errorf("loading caption font: %w", err)
+	}
+
+	for i, path := range imagePaths {
+		slide, err := decodeImageFile(path)
+		if err != nil {
+			return "", fmt.Errorf("reading slide %d for contact sheet: %w", i+1, err)
+		}
+
+		x := contactSheetGap + (i%columns)*(contactSheetThumbWidth+contactSheetGap)
+		y := contactSheetGap + (i/columns)*(thumbHeight+contactSheetCaption+contactSheetGap)
+
+		bounds := slide.Bounds()
+		scale := min(float64(contactSheetThumbWidth)/float64(bounds.Dx()), float64(thumbHeight)/float64(bounds.Dy()))
+		dc.Push()
+		dc.Translate(float64(x), float64(y))
+		dc.Scale(scale, scale)
+		dc.DrawImage(slide, 0, 0)
+		dc.Pop()
+
+		dc.SetColor(color.RGBA{203, 213, 224, 255}) // Light gray border
+		dc.DrawRectangle(float64(x)+0.5, float64(y)+0.5, contactSheetThumbWidth-1, float64(thumbHeight)-1)
+		dc.SetLineWidth(1)
+		dc.Stroke()
+
+		dc.SetColor(color.RGBA{45, 55, 72, 255}) // Dark gray
+		dc.DrawStringAnchored(fmt.Sprintf("Slide %d", i+1), float64(x)+contactSheetThumbWidth/2, float64(y+thumbHeight)+contactSheetCaption/2, 0.5, 0.5)
+	}
+
+	sheetPath := filepath.Join(outputDir, contactSheetName+"."+imageOptions.Format)
+	if imageOptions.Format == "jpg" {
+		return sheetPath, r.saveAsJPEG(dc.Image(), sheetPath, exportOptions)
+	}
+	return sheetPath, r.saveAsPNG(dc.Image(), sheetPath)
+}
+
+// contactSheetGrid returns the columns and rows a sheet of count slides
+// takes, with no more columns than slides
+func contactSheetGrid(count, columns int) (int, int) {
+	if columns <= 0 {
+		columns = DefaultContactSheetColumns
+	}
+	columns = min(columns, count)
+	return columns, (count + columns - 1) / columns
+}
+
+// contactSheetSize returns the pixel size of a sheet laid out in a grid of
+// thumbnails thumbHeight high
+func contactSheetSize(columns, rows, thumbHeight int) (width, height int) {
+	width = columns*contactSheetThumbWidth + (columns+1)*contactSheetGap
+	height = rows*(thumbHeight+contactSheetCaption) + (rows+1)*contactSheetGap
+	return width, height
+}
+
+// decodeImageSize reads the dimensions of a PNG or JPEG slide image without
+// decoding its pixels
+func decodeImageSize(path string) (image.Config, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return image.Config{}, err
+	}
+	defer func() { _ = file.Close() }()
+
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return image.Config{}, fmt.Errorf("decoding %s: %w", filepath.Base(path), err)
+	}
+	return config, nil
+}
+
+// decodeImageFile reads a PNG or JPEG slide image
+func decodeImageFile(path string) (image.Image, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", filepath.Base(path), err)
+	}
+	return img, nil
+}
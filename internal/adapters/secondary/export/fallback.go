@@ -0,0 +1,111 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// DefaultFallbacks are the formats tried, in order, when an export fails.
+// Formats printed by headless Chrome fall back to HTML, which needs no
+// browser.
+var DefaultFallbacks = map[ExportFormat][]ExportFormat{
+	FormatPDF:    {FormatHTML},
+	FormatImages: {FormatHTML},
+	FormatSVG:    {FormatHTML},
+}
+
+// SetFallbacks sets the formats tried, in order, when an export to format
+// fails. Without any, failed exports to format return their error.
+func (s *Service) SetFallbacks(format ExportFormat, chain ...ExportFormat) {
+	s.fallbackMutex.Lock()
+	defer s.fallbackMutex.Unlock()
+	if len(chain) == 0 {
+		delete(s.fallbacks, format)
+		return
+	}
+	s.fallbacks[format] = append([]ExportFormat(nil), chain...)
+}
+
+// GetFallbacks returns the formats tried when an export to format fails
+func (s *Service) GetFallbacks(format ExportFormat) []ExportFormat {
+	s.fallbackMutex.RLock()
+	defer s.fallbackMutex.RUnlock()
+	return append([]ExportFormat(nil), s.fallbacks[format]...)
+}
+
+// exportWithFallbacks tries the fallback formats of a failed export in
+// order and returns the first result, with a warning naming the
+// substitution. Each attempt is recorded in the metrics. The original error
+// is returned when no fallback applies or all of them fail.
+func (s *Service) exportWithFallbacks(ctx context.Context, presentation *entities.Presentation, options *ExportOptions, metrics *ExportMetrics, exportErr error) (*ExportResult, error) {
+	if !canFallBack(ctx, exportErr) {
+		return nil, exportErr
+	}
+
+	reason := exportErr.Error()
+	for _, format := range s.GetFallbacks(options.Format) {
+		renderer, exists := s.renderers[format]
+		if !exists || format == options.Format {
+			continue
+		}
+
+		s.metricsMutex.Lock()
+		metrics.FallbacksUsed = append(metrics.FallbacksUsed, FallbackInfo{
+			Reason:       reason,
+			FallbackUsed: string(format),
+			Timestamp:    time.Now(),
+		})
+		metrics.Phase = PhaseFallback
+		s.metricsMutex.Unlock()
+
+		fallbackOptions := *options
+		fallbackOptions.Format = format
+		fallbackOptions.OutputPath = fallbackOutputPath(options.OutputPath, options.Format, format)
+
+		result, err := s.renderFormat(ctx, renderer, presentation, &fallbackOptions, metrics)
+		if err != nil {
+			reason = err.Error()
+			continue
+		}
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("%s export failed, exported %s instead: %s", options.Format, format, exportErr.Error()))
+		return result, nil
+	}
+	return nil, exportErr
+}
+
+// canFallBack reports whether another format could succeed where an export
+// failed. Cancelled exports and bad options or output paths would fail the
+// same way in any format.
+func canFallBack(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var exportErr *ExportError
+	if errors.As(err, &exportErr) {
+		switch exportErr.Type {
+		case ErrorTypeValidation, ErrorTypeFilesystem, ErrorTypeConfiguration:
+			return false
+		}
+	}
+	return true
+}
+
+// fallbackOutputPath names the output of a fallback export after the
+// original, with the fallback format's extension
+func fallbackOutputPath(outputPath string, from, to ExportFormat) string {
+	base := strings.TrimSuffix(outputPath, string(filepath.Separator))
+	if !from.WritesDirectory() {
+		base = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+	if to.WritesDirectory() {
+		return base
+	}
+	return base + "." + string(to)
+}
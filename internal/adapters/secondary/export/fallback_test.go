@@ -0,0 +1,104 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/test/builders"
+)
+
+func TestService_ExportFallsBackToHTML(t *testing.T) {
+	presentation := builders.NewPresentationBuilder().WithTitle("Fallback").WithSlideCount(2).Build()
+
+	newService := func(t *testing.T) *Service {
+		service, err := NewService(t.TempDir())
+		require.NoError(t, err)
+		failing := new(MockRenderer)
+		failing.On("Render", mock.Anything, presentation, mock.Anything).Return(nil, errors.New("no PDF engine installed"))
+		service.RegisterRenderer(FormatPDF, failing)
+		return service
+	}
+
+	t.Run("failed PDF exports HTML instead", func(t *testing.T) {
+		service := newService(t)
+		outputPath := filepath.Join(t.TempDir(), "deck.pdf")
+
+		result, err := service.Export(context.Background(), presentation, &ExportOptions{
+			Format:     FormatPDF,
+			OutputPath: outputPath,
+		})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Equal(t, string(FormatHTML), result.Format)
+
+		htmlPath := filepath.Join(filepath.Dir(outputPath), "deck.html")
+		assert.Equal(t, htmlPath, result.OutputPath)
+		assert.FileExists(t, htmlPath)
+		assert.NoFileExists(t, outputPath)
+
+		require.Len(t, result.FallbacksUsed, 1)
+		assert.Equal(t, "html", result.FallbacksUsed[0].FallbackUsed)
+		assert.Contains(t, result.FallbacksUsed[0].Reason, "no PDF engine installed")
+		assert.False(t, result.FallbacksUsed[0].Timestamp.IsZero())
+
+		metrics, ok := result.Metadata["export_metrics"].(*ExportMetrics)
+		require.True(t, ok)
+		assert.Equal(t, result.FallbacksUsed, metrics.FallbacksUsed)
+
+		require.NotEmpty(t, result.Warnings)
+		assert.Contains(t, result.Warnings[len(result.Warnings)-1], "pdf export failed, exported html instead")
+	})
+
+	t.Run("without a chain the error is returned", func(t *testing.T) {
+		service := newService(t)
+		service.SetFallbacks(FormatPDF)
+		assert.Empty(t, service.GetFallbacks(FormatPDF))
+
+		result, err := service.Export(context.Background(), presentation, &ExportOptions{
+			Format:     FormatPDF,
+			OutputPath: filepath.Join(t.TempDir(), "deck.pdf"),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no PDF engine installed")
+		assert.False(t, result.Success)
+		assert.Empty(t, result.FallbacksUsed)
+	})
+
+	t.Run("chains are tried in order", func(t *testing.T) {
+		service := newService(t)
+		service.SetFallbacks(FormatPDF, FormatPowerPoint, FormatMarkdown)
+
+		broken := new(MockRenderer)
+		broken.On("Render", mock.Anything, presentation, mock.Anything).Return(nil, errors.New("pptx template missing"))
+		service.RegisterRenderer(FormatPowerPoint, broken)
+
+		outputPath := filepath.Join(t.TempDir(), "deck.pdf")
+		result, err := service.Export(context.Background(), presentation, &ExportOptions{
+			Format:     FormatPDF,
+			OutputPath: outputPath,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, string(FormatMarkdown), result.Format)
+
+		require.Len(t, result.FallbacksUsed, 2)
+		assert.Equal(t, "pptx", result.FallbacksUsed[0].FallbackUsed)
+		assert.Equal(t, "markdown", result.FallbacksUsed[1].FallbackUsed)
+		assert.Contains(t, result.FallbacksUsed[1].Reason, "pptx template missing")
+
+		_, err = os.Stat(filepath.Join(filepath.Dir(outputPath), "deck.markdown"))
+		assert.NoError(t, err)
+	})
+}
+
+func TestFallbackOutputPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("out", "deck.html"), fallbackOutputPath(filepath.Join("out", "deck.pdf"), FormatPDF, FormatHTML))
+	assert.Equal(t, filepath.Join("out", "slides.html"), fallbackOutputPath(filepath.Join("out", "slides")+string(filepath.Separator), FormatImages, FormatHTML))
+	assert.Equal(t, filepath.Join("out", "deck"), fallbackOutputPath(filepath.Join("out", "deck.pdf"), FormatPDF, FormatSVG))
+}
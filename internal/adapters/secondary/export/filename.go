@@ -0,0 +1,126 @@
+package export
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// FilenameMode controls which characters survive in export filenames
+type FilenameMode string
+
+const (
+	// FilenameASCII transliterates to a portable [A-Za-z0-9._-] set
+	FilenameASCII FilenameMode = "ascii"
+	// FilenameUnicode keeps letters and digits from any script
+	FilenameUnicode FilenameMode = "unicode"
+)
+
+// maxFilenameBytes keeps names well under the 255 byte limit of common filesystems
+const maxFilenameBytes = 100
+
+// transliterations covers Latin letters that don't decompose into a base letter and a mark
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE", 'ø': "o", 'Ø': "O",
+	'ł': "l", 'Ł': "L", 'đ': "d", 'Đ': "D", 'ð': "d", 'Ð': "D", 'þ': "th", 'Þ': "TH",
+	'ı': "i",
+}
+
+// windowsReservedNames can't be used as a file's base name on Windows
+var windowsReservedNames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// ParseFilenameMode returns the mode for s, defaulting to FilenameASCII
+func ParseFilenameMode(s string) FilenameMode {
+	if FilenameMode(strings.ToLower(s)) == FilenameUnicode {
+		return FilenameUnicode
+	}
+	return FilenameASCII
+}
+
+// SanitizeFilename turns a title into a base name that is safe on common
+// filesystems. Path separators, emoji, punctuation and whitespace become
+// single dashes. The result may be empty if nothing usable remains.
+func SanitizeFilename(title string, mode FilenameMode) string {
+	var b strings.Builder
+	pendingDash := false
+
+	write := func(s string) {
+		if pendingDash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		pendingDash = false
+		b.WriteString(s)
+	}
+
+	if mode == FilenameUnicode {
+		title = norm.NFC.String(title)
+	} else {
+		// Compatibility decomposition splits accents from letters and
+		// expands ligatures and full-width forms
+		title = norm.NFKD.String(title)
+	}
+
+	for _, r := range title {
+		switch {
+		case r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			write(string(r))
+		case r == '_' || r == '.':
+			// Keep inner dots and underscores, but never a leading dot or ".."
+			if b.Len() > 0 && !pendingDash && !strings.HasSuffix(b.String(), ".") {
+				write(string(r))
+			} else {
+				pendingDash = true
+			}
+		case mode == FilenameUnicode && (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)):
+			write(string(r))
+		case mode != FilenameUnicode && unicode.Is(unicode.Mn, r):
+			// Combining accents left over from decomposition
+		case transliterations[r] != "" && mode != FilenameUnicode:
+			write(transliterations[r])
+		default:
+			pendingDash = true
+		}
+	}
+
+	name := truncateUTF8(b.String(), maxFilenameBytes)
+	name = strings.Trim(name, "-._")
+
+	if windowsReservedNames[strings.ToUpper(name)] {
+		name += "_"
+	}
+	return name
+}
+
+// ExportFilename builds "<sanitized title>.<ext>" for an export, falling back to
+// a timestamped name when the title has no usable characters. An empty ext
+// yields a bare name, as used for image export directories.
+func ExportFilename(title, ext string, mode FilenameMode, now time.Time) string {
+	name := SanitizeFilename(title, mode)
+	if name == "" {
+		name = "presentation-" + now.Format("20060102-150405")
+	}
+	if ext == "" {
+		return name
+	}
+	return name + "." + ext
+}
+
+// truncateUTF8 shortens s to at most n bytes without splitting a rune
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
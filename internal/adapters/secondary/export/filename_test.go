@@ -0,0 +1,57 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		mode     FilenameMode
+		expected string
+	}{
+		{"plain title", "Quarterly Review", FilenameASCII, "Quarterly-Review"},
+		{"slashes", "Q1/Q2 results\\draft", FilenameASCII, "Q1-Q2-results-draft"},
+		{"emoji", "🚀 Launch Plan 🎉", FilenameASCII, "Launch-Plan"},
+		{"accents are transliterated", "Café Résumé — Straße", FilenameASCII, "Cafe-Resume-Strasse"},
+		{"ligatures and full-width forms", "ﬁnal Ｒｅｐｏｒｔ", FilenameASCII, "final-Report"},
+		{"no leading dots or traversal", "../../etc/passwd", FilenameASCII, "etc-passwd"},
+		{"keeps version dots", "Release v1.2", FilenameASCII, "Release-v1.2"},
+		{"non-Latin scripts are dropped", "東京 2024", FilenameASCII, "2024"},
+		{"unicode mode keeps letters", "東京 Café/🚀", FilenameUnicode, "東京-Café"},
+		{"windows reserved name", "con", FilenameASCII, "con_"},
+		{"only emoji", "🔥🔥🔥", FilenameASCII, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SanitizeFilename(tt.title, tt.mode))
+		})
+	}
+}
+
+func TestSanitizeFilename_Length(t *testing.T) {
+	name := SanitizeFilename(strings.Repeat("é", 200), FilenameUnicode)
+	assert.LessOrEqual(t, len(name), maxFilenameBytes)
+	assert.Equal(t, strings.Repeat("é", maxFilenameBytes/2), name)
+}
+
+func TestExportFilename(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "Launch-Plan.pdf", ExportFilename("🚀 Launch/Plan", "pdf", FilenameASCII, now))
+	assert.Equal(t, "Launch-Plan", ExportFilename("🚀 Launch/Plan", "", FilenameASCII, now))
+	assert.Equal(t, "presentation-20240315-103000.pdf", ExportFilename("🎉 / 🎉", "pdf", FilenameASCII, now))
+	assert.Equal(t, "presentation-20240315-103000.html", ExportFilename("", "html", FilenameASCII, now))
+}
+
+func TestParseFilenameMode(t *testing.T) {
+	assert.Equal(t, FilenameUnicode, ParseFilenameMode("Unicode"))
+	assert.Equal(t, FilenameASCII, ParseFilenameMode("ascii"))
+	assert.Equal(t, FilenameASCII, ParseFilenameMode(""))
+}
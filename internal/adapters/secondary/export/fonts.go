@@ -0,0 +1,382 @@
+package export
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/net/html"
+)
+
+// errUnsupportedFont is returned when a font can't be subset, e.g. because
+// its outlines are CFF or it is WOFF-compressed
+var errUnsupportedFont = errors.New("only TrueType (glyf) fonts can be subset")
+
+// alwaysSubsetChars are kept in subset fonts even when the deck doesn't use
+// them, since the export's script writes slide numbers at runtime
+const alwaysSubsetChars = " 0123456789/"
+
+// FontEmbedding reports how a font was embedded in an export
+type FontEmbedding struct {
+	Path         string `json:"path"`
+	Family       string `json:"family"`
+	OriginalSize int    `json:"original_size"`
+	EmbeddedSize int    `json:"embedded_size"`
+	Subset       bool   `json:"subset"`
+}
+
+// BytesSaved returns how much smaller the embedded font is than the original
+func (f FontEmbedding) BytesSaved() int {
+	return f.OriginalSize - f.EmbeddedSize
+}
+
+// embedFonts reads the font files at paths and renders them as @font-face
+// rules with inline data URIs, the first one becoming the body font. With
+// subset set, each font is cut down to the glyphs needed for text; fonts
+// that can't be subset are embedded in full and reported in the warnings.
+func embedFonts(paths []string, text string, subset bool) (string, []FontEmbedding, []string, error) {
+	var css strings.Builder
+	var embedded []FontEmbedding
+	var warnings []string
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path) // #nosec G304 - font paths come from the export configuration
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("reading font %s: %w", path, err)
+		}
+
+		embedding := FontEmbedding{
+			Path:         path,
+			Family:       fontFamily(path, data),
+			OriginalSize: len(data),
+		}
+		if subset {
+			if subsetData, err := subsetFont(data, text+alwaysSubsetChars); err != nil {
+				warnings = append(warnings, fmt.Sprintf("font %s embedded in full: %v", filepath.Base(path), err))
+			} else {
+				data = subsetData
+				embedding.Subset = true
+			}
+		}
+		embedding.EmbeddedSize = len(data)
+		embedded = append(embedded, embedding)
+
+		mimeType, format := fontFormat(data)
+		fmt.Fprintf(&css, "@font-face { font-family: '%s'; src: url(data:%s;base64,%s) format('%s'); }\n",
+			embedding.Family, mimeType, base64.StdEncoding.EncodeToString(data), format)
+	}
+
+	if len(embedded) > 0 {
+		fmt.Fprintf(&css, "body { font-family: '%s', -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; }\n", embedded[0].Family)
+	}
+
+	return css.String(), embedded, warnings, nil
+}
+
+// fontFamily returns the family name stored in the font, falling back to
+// the file name for fonts that can't be parsed
+func fontFamily(path string, data []byte) string {
+	family := ""
+	if f, err := sfnt.Parse(data); err == nil {
+		family, _ = f.Name(nil, sfnt.NameIDFamily)
+	}
+	if family == "" {
+		family = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return cssStringSafe(family)
+}
+
+// cssStringSafe drops the characters that would let a value escape a quoted
+// CSS string or the style element holding it
+func cssStringSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\'' || r == '"' || r == '\\' || r == '<' || r == '>' || r < ' ' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// fontFormat returns the MIME type and CSS format name of font data
+func fontFormat(data []byte) (string, string) {
+	if len(data) >= 4 {
+		switch string(data[:4]) {
+		case "OTTO":
+			return "font/otf", "opentype"
+		case "wOFF":
+			return "font/woff", "woff"
+		case "wOF2":
+			return "font/woff2", "woff2"
+		}
+	}
+	return "font/ttf", "truetype"
+}
+
+// documentText returns the text an HTML document displays, skipping scripts
+// and styles
+func documentText(document string) string {
+	var b strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(document))
+	skip := 0
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return b.String()
+		case html.StartTagToken:
+			if name, _ := tokenizer.TagName(); string(name) == "script" || string(name) == "style" {
+				skip++
+			}
+		case html.EndTagToken:
+			if name, _ := tokenizer.TagName(); (string(name) == "script" || string(name) == "style") && skip > 0 {
+				skip--
+			}
+		case html.TextToken:
+			if skip == 0 {
+				b.Write(tokenizer.Text())
+			}
+		}
+	}
+}
+
+// sfntTable is an entry of a font's table directory
+type sfntTable struct {
+	tag  string
+	data []byte
+}
+
+// subsetFont returns a copy of a TrueType font in which only the glyphs
+// needed for text keep their outlines. Glyph IDs are left unchanged so the
+// cmap, metrics and layout tables stay valid; the other glyphs become empty,
+// which is where nearly all of a font's size is.
+func subsetFont(data []byte, text string) ([]byte, error) {
+	if len(data) < 12 {
+		return nil, errUnsupportedFont
+	}
+	if version := binary.BigEndian.Uint32(data); version != 0x00010000 && string(data[:4]) != "true" {
+		return nil, errUnsupportedFont
+	}
+
+	font, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing font: %w", err)
+	}
+
+	tables, err := readTables(data)
+	if err != nil {
+		return nil, err
+	}
+	head, loca, glyf, maxp := tables["head"], tables["loca"], tables["glyf"], tables["maxp"]
+	if head == nil || loca == nil || glyf == nil || maxp == nil || len(head.data) < 54 || len(maxp.data) < 6 {
+		return nil, errUnsupportedFont
+	}
+
+	numGlyphs := int(binary.BigEndian.Uint16(maxp.data[4:]))
+	offsets, err := glyphOffsets(loca.data, numGlyphs, binary.BigEndian.Uint16(head.data[50:]) == 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Glyph 0 is the .notdef glyph shown for missing characters
+	keep := map[int]bool{0: true}
+	var buf sfnt.Buffer
+	for _, r := range text {
+		if index, err := font.GlyphIndex(&buf, r); err == nil && index != 0 {
+			keep[int(index)] = true
+		}
+	}
+	if err := addComponentGlyphs(keep, glyf.data, offsets); err != nil {
+		return nil, err
+	}
+
+	// Rebuild glyf with a long-format loca pointing into it
+	var newGlyf []byte
+	newLoca := make([]byte, 4*(numGlyphs+1))
+	for i := 0; i < numGlyphs; i++ {
+		binary.BigEndian.PutUint32(newLoca[4*i:], uint32(len(newGlyf)))
+		if keep[i] {
+			newGlyf = append(newGlyf, glyf.data[offsets[i]:offsets[i+1]]...)
+			for len(newGlyf)%4 != 0 {
+				newGlyf = append(newGlyf, 0)
+			}
+		}
+	}
+	binary.BigEndian.PutUint32(newLoca[4*numGlyphs:], uint32(len(newGlyf)))
+
+	newHead := append([]byte(nil), head.data...)
+	binary.BigEndian.PutUint16(newHead[50:], 1)
+	glyf.data, loca.data, head.data = newGlyf, newLoca, newHead
+
+	// The digital signature no longer matches the modified font
+	delete(tables, "DSIG")
+
+	return writeFont(data[:4], tables), nil
+}
+
+// readTables parses a font's table directory
+func readTables(data []byte) (map[string]*sfntTable, error) {
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	if len(data) < 12+16*numTables {
+		return nil, errors.New("truncated table directory")
+	}
+
+	tables := make(map[string]*sfntTable, numTables)
+	for i := 0; i < numTables; i++ {
+		record := data[12+16*i:]
+		tag := string(record[:4])
+		offset := int(binary.BigEndian.Uint32(record[8:]))
+		length := int(binary.BigEndian.Uint32(record[12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, fmt.Errorf("table %s out of bounds", tag)
+		}
+		tables[tag] = &sfntTable{tag: tag, data: data[offset : offset+length]}
+	}
+	return tables, nil
+}
+
+// glyphOffsets decodes the loca table into numGlyphs+1 offsets into glyf
+func glyphOffsets(loca []byte, numGlyphs int, long bool) ([]int, error) {
+	offsets := make([]int, numGlyphs+1)
+	for i := range offsets {
+		if long {
+			if len(loca) < 4*(i+1) {
+				return nil, errors.New("truncated loca table")
+			}
+			offsets[i] = int(binary.BigEndian.Uint32(loca[4*i:]))
+		} else {
+			if len(loca) < 2*(i+1) {
+				return nil, errors.New("truncated loca table")
+			}
+			offsets[i] = 2 * int(binary.BigEndian.Uint16(loca[2*i:]))
+		}
+		if i > 0 && offsets[i] < offsets[i-1] {
+			return nil, errors.New("invalid loca table")
+		}
+	}
+	return offsets, nil
+}
+
+// Composite glyph flags from the TrueType glyf specification
+const (
+	compositeArgsAreWords  = 0x0001
+	compositeHasScale      = 0x0008
+	compositeMoreComponent = 0x0020
+	compositeHasXYScale    = 0x0040
+	compositeHas2x2        = 0x0080
+)
+
+// addComponentGlyphs adds the glyphs that kept composite glyphs are built
+// from, e.g. the base letter and accent of an accented character
+func addComponentGlyphs(keep map[int]bool, glyf []byte, offsets []int) error {
+	pending := make([]int, 0, len(keep))
+	for index := range keep {
+		pending = append(pending, index)
+	}
+
+	for len(pending) > 0 {
+		index := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if index+1 >= len(offsets) || offsets[index+1] > len(glyf) {
+			return fmt.Errorf("glyph %d out of bounds", index)
+		}
+
+		glyph := glyf[offsets[index]:offsets[index+1]]
+		if len(glyph) < 10 || int16(binary.BigEndian.Uint16(glyph)) >= 0 {
+			continue
+		}
+
+		for pos := 10; pos+4 <= len(glyph); {
+			flags := binary.BigEndian.Uint16(glyph[pos:])
+			component := int(binary.BigEndian.Uint16(glyph[pos+2:]))
+			if !keep[component] {
+				keep[component] = true
+				pending = append(pending, component)
+			}
+
+			pos += 4
+			if flags&compositeArgsAreWords != 0 {
+				pos += 4
+			} else {
+				pos += 2
+			}
+			switch {
+			case flags&compositeHasScale != 0:
+				pos += 2
+			case flags&compositeHasXYScale != 0:
+				pos += 4
+			case flags&compositeHas2x2 != 0:
+				pos += 8
+			}
+			if flags&compositeMoreComponent == 0 {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// writeFont serializes tables into a font file, recomputing the directory,
+// table checksums and head's checkSumAdjustment
+func writeFont(version []byte, tables map[string]*sfntTable) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	numTables := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= numTables {
+		entrySelector++
+	}
+	searchRange := 16 << entrySelector
+
+	out := make([]byte, 12+16*numTables)
+	copy(out, version)
+	binary.BigEndian.PutUint16(out[4:], uint16(numTables))
+	binary.BigEndian.PutUint16(out[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(out[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(out[10:], uint16(numTables*16-searchRange))
+
+	headOffset := -1
+	for i, tag := range tags {
+		table := tables[tag].data
+		if tag == "head" {
+			table = append([]byte(nil), table...)
+			binary.BigEndian.PutUint32(table[8:], 0)
+			headOffset = len(out)
+		}
+
+		record := out[12+16*i:]
+		copy(record, tag)
+		binary.BigEndian.PutUint32(record[4:], tableChecksum(table))
+		binary.BigEndian.PutUint32(record[8:], uint32(len(out)))
+		binary.BigEndian.PutUint32(record[12:], uint32(len(table)))
+
+		out = append(out, table...)
+		for len(out)%4 != 0 {
+			out = append(out, 0)
+		}
+	}
+
+	if headOffset >= 0 {
+		binary.BigEndian.PutUint32(out[headOffset+8:], 0xB1B0AFBA-tableChecksum(out))
+	}
+	return out
+}
+
+// tableChecksum sums data as big-endian uint32 words, zero-padding the end
+func tableChecksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
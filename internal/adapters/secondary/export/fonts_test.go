@@ -0,0 +1,224 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/theme"
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// glyphSegments returns the number of outline segments drawn for r
+func glyphSegments(t *testing.T, font *sfnt.Font, r rune) int {
+	var buf sfnt.Buffer
+	index, err := font.GlyphIndex(&buf, r)
+	require.NoError(t, err)
+	segments, err := font.LoadGlyph(&buf, index, fixed.I(12), nil)
+	require.NoError(t, err)
+	return len(segments)
+}
+
+func TestSubsetFont(t *testing.T) {
+	subset, err := subsetFont(goregular.TTF, "Hé")
+	require.NoError(t, err)
+	assert.Less(t, len(subset), len(goregular.TTF)/4, "subset should be a fraction of the full font")
+
+	font, err := sfnt.Parse(subset)
+	require.NoError(t, err)
+	assert.NotZero(t, glyphSegments(t, font, 'H'))
+	assert.NotZero(t, glyphSegments(t, font, 'é'), "composite glyphs keep their components")
+	assert.Zero(t, glyphSegments(t, font, 'Z'))
+
+	family, err := font.Name(nil, sfnt.NameIDFamily)
+	require.NoError(t, err)
+	assert.Equal(t, "Go", family)
+
+	// The whole font, including checkSumAdjustment, sums to the magic number
+	assert.Equal(t, uint32(0xB1B0AFBA), tableChecksum(subset))
+}
+
+func TestSubsetFontUnsupported(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"cff":   append([]byte("OTTO"), make([]byte, 32)...),
+		"woff":  append([]byte("wOFF"), make([]byte, 32)...),
+		"short": []byte("true"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := subsetFont(data, "abc")
+			assert.ErrorIs(t, err, errUnsupportedFont)
+		})
+	}
+}
+
+func TestHTMLRenderer_EmbedsFonts(t *testing.T) {
+	dir := t.TempDir()
+	fontPath := filepath.Join(dir, "go.ttf")
+	require.NoError(t, os.WriteFile(fontPath, goregular.TTF, 0600))
+
+	presentation := &entities.Presentation{
+		Title:  "Hi",
+		Slides: []entities.Slide{{Index: 0, Title: "Hi", HTML: "<h1>Hi</h1>"}},
+	}
+	renderer := NewHTMLRenderer()
+
+	export := func(name string, subset bool, fonts ...string) (*ExportResult, string) {
+		options := &ExportOptions{
+			Format:      FormatHTML,
+			OutputPath:  filepath.Join(dir, name),
+			Fonts:       fonts,
+			SubsetFonts: subset,
+		}
+		result, err := renderer.Render(context.Background(), presentation, options)
+		require.NoError(t, err)
+		content, err := os.ReadFile(options.OutputPath)
+		require.NoError(t, err)
+		return result, string(content)
+	}
+
+	full, fullHTML := export("full.html", false, fontPath)
+	subset, subsetHTML := export("subset.html", true, fontPath)
+
+	assert.Contains(t, fullHTML, "@font-face { font-family: 'Go'; src: url(data:font/ttf;base64,")
+	assert.Contains(t, subsetHTML, "body { font-family: 'Go',")
+	assert.Less(t, subset.FileSize, full.FileSize/4)
+	assert.Empty(t, subset.Warnings)
+
+	fonts := subset.Metadata["fonts"].([]FontEmbedding)
+	require.Len(t, fonts, 1)
+	assert.True(t, fonts[0].Subset)
+	assert.Equal(t, len(goregular.TTF), fonts[0].OriginalSize)
+	assert.Equal(t, fonts[0].BytesSaved(), subset.Metadata["font_bytes_saved"])
+	assert.Greater(t, fonts[0].BytesSaved(), 0)
+	assert.Equal(t, 0, full.Metadata["font_bytes_saved"])
+
+	t.Run("falls back to full embedding", func(t *testing.T) {
+		woffPath := filepath.Join(dir, "brand.woff2")
+		woff := append([]byte("wOF2"), make([]byte, 64)...)
+		require.NoError(t, os.WriteFile(woffPath, woff, 0600))
+
+		result, content := export("woff.html", true, woffPath)
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], "brand.woff2 embedded in full")
+		assert.Contains(t, content, "font-family: 'brand'; src: url(data:font/woff2;base64,")
+		assert.Equal(t, 0, result.Metadata["font_bytes_saved"])
+	})
+
+	t.Run("without fonts", func(t *testing.T) {
+		result, content := export("plain.html", true)
+		assert.NotContains(t, content, "@font-face")
+		assert.Nil(t, result.Metadata)
+	})
+}
+
+func TestDocumentText(t *testing.T) {
+	text := documentText(`<html><head><style>p { color: red }</style></head><body><p>Hi &amp; bye</p><script>var x = 1;</script></body></html>`)
+	assert.Equal(t, "Hi & bye", strings.TrimSpace(text))
+}
+
+func TestHTMLRenderer_InlinesThemeFonts(t *testing.T) {
+	dir := t.TempDir()
+	fontPath := filepath.Join(dir, "go.ttf")
+	require.NoError(t, os.WriteFile(fontPath, goregular.TTF, 0600))
+
+	presentation := &entities.Presentation{
+		Title:  "Hi",
+		Slides: []entities.Slide{{Index: 0, Title: "Hi", HTML: "<h1>Hi</h1>"}},
+	}
+	renderer := NewHTMLRenderer()
+
+	export := func(name string, options ExportOptions) (*ExportResult, string) {
+		options.Format = FormatHTML
+		options.OutputPath = filepath.Join(dir, name)
+		result, err := renderer.Render(context.Background(), presentation, &options)
+		require.NoError(t, err)
+		content, err := os.ReadFile(options.OutputPath)
+		require.NoError(t, err)
+		return result, string(content)
+	}
+
+	localFont := theme.ThemeFont{Name: "Go", Source: "local", URL: fontPath, Fallbacks: []string{"sans-serif"}}
+
+	t.Run("local font", func(t *testing.T) {
+		result, content := export("local.html", ExportOptions{ThemeFonts: []theme.ThemeFont{localFont}, InlineFonts: true})
+		assert.Empty(t, result.Warnings)
+		assert.Contains(t, content, "@font-face { font-family: 'Go'; src: url(data:font/ttf;base64,")
+		assert.Contains(t, content, "body { font-family: 'Go', sans-serif; }")
+		assert.NotContains(t, content, fontPath)
+		assert.NotRegexp(t, `url\(['"]?(https?|file):`, content)
+	})
+
+	t.Run("without inlining", func(t *testing.T) {
+		result, content := export("linked.html", ExportOptions{ThemeFonts: []theme.ThemeFont{localFont}})
+		assert.Empty(t, result.Warnings)
+		assert.Contains(t, content, "src: url('"+fontPath+"')")
+		assert.NotContains(t, content, "data:font")
+	})
+
+	woff2 := append([]byte("wOF2"), make([]byte, 64)...)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/brand.woff2", func(w http.ResponseWriter, r *http.Request) { _, _ = w.Write(woff2) })
+	var server *httptest.Server
+	mux.HandleFunc("/css2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/css")
+		_, _ = fmt.Fprintf(w, "@font-face {\n  font-family: 'Inter';\n  src: url(%s/brand.woff2) format('woff2');\n}\n", server.URL)
+	})
+	server = httptest.NewServer(mux)
+	defer server.Close()
+
+	t.Run("url and google fonts", func(t *testing.T) {
+		result, content := export("remote.html", ExportOptions{
+			ThemeFonts: []theme.ThemeFont{
+				{Name: "Brand", Source: "url", URL: server.URL + "/brand.woff2"},
+				{Name: "Inter", Source: "google", URL: server.URL + "/css2?family=Inter"},
+			},
+			InlineFonts: true,
+		})
+		assert.Empty(t, result.Warnings)
+		assert.Equal(t, 2, strings.Count(content, "url(data:font/woff2;base64,"))
+		assert.Contains(t, content, "font-family: 'Inter'")
+		assert.NotContains(t, content, "@import")
+		assert.NotContains(t, content, server.URL+"/brand.woff2)")
+	})
+
+	t.Run("limit exceeded", func(t *testing.T) {
+		result, content := export("limited.html", ExportOptions{
+			ThemeFonts: []theme.ThemeFont{
+				{Name: "Brand", Source: "url", URL: server.URL + "/brand.woff2"},
+				localFont,
+			},
+			InlineFonts:      true,
+			InlineFontsLimit: int64(len(woff2)),
+		})
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], "go.ttf not inlined: inlined fonts would exceed 68 bytes")
+		assert.Contains(t, content, "url(data:font/woff2;base64,")
+		assert.Contains(t, content, "src: url('"+fontPath+"')")
+	})
+
+	t.Run("missing font", func(t *testing.T) {
+		result, _ := export("missing.html", ExportOptions{
+			ThemeFonts:  []theme.ThemeFont{{Name: "Gone", Source: "url", URL: server.URL + "/gone.woff2"}},
+			InlineFonts: true,
+		})
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], "gone.woff2 returned 404 Not Found")
+	})
+}
+
+func TestThemeFontCSS_Google(t *testing.T) {
+	css := themeFontCSS([]theme.ThemeFont{{Name: "Open Sans", Source: "google", Fallbacks: []string{"Arial", "sans-serif"}}})
+	assert.Contains(t, css, "@import url('https://fonts.googleapis.com/css2?family=Open+Sans&display=swap');")
+	assert.Contains(t, css, "body { font-family: 'Open Sans', Arial, sans-serif; }")
+}
@@ -1,6 +1,7 @@
 package export
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"html/template"
@@ -40,15 +41,19 @@ func NewHTMLRenderer() *HTMLRenderer {
 func (r *HTMLRenderer) Render(ctx context.Context, presentation *entities.Presentation, options *ExportOptions) (*ExportResult, error) {
 	// Prepare template data
 	data := struct {
-		Title        string
-		Author       string
-		Date         string
-		Theme        string
-		Slides       []entities.Slide
-		IncludeNotes bool
-		GeneratedAt  string
-		SlideCount   int
-		Metadata     map[string]interface{}
+		Title          string
+		Author         string
+		Date           string
+		Theme          string
+		Slides         []entities.Slide
+		IncludeNotes   bool
+		PrintLayout    bool
+		GeneratedAt    string
+		SlideCount     int
+		Metadata       map[string]interface{}
+		Document       *DocumentMetadata
+		ThemeFontFaces template.CSS
+		FontFaces      template.CSS
 	}{
 		Title:        presentation.Title,
 		Author:       presentation.Author,
@@ -56,6 +61,7 @@ func (r *HTMLRenderer) Render(ctx context.Context, presentation *entities.Presen
 		Theme:        options.Theme,
 		Slides:       presentation.Slides,
 		IncludeNotes: options.IncludeNotes,
+		PrintLayout:  options.PrintLayout,
 		GeneratedAt:  time.Now().Format("2006-01-02 15:04:05"),
 		SlideCount:   len(presentation.Slides),
 		Metadata:     options.Metadata,
@@ -66,28 +72,72 @@ func (r *HTMLRenderer) Render(ctx context.Context, presentation *entities.Presen
 		data.Theme = presentation.Theme
 	}
 
-	// Create output file
-	outputFile, err := os.Create(options.OutputPath)
-	if err != nil {
-		return nil, fmt.Errorf("creating output file: %w", err)
+	if options.IncludeMetadata {
+		data.Document = newDocumentMetadata(presentation, options)
+		data.Title = data.Document.Title
+		data.Author = data.Document.Author
+	}
+
+	var warnings []string
+	if len(options.ThemeFonts) > 0 {
+		themeFontFaces := themeFontCSS(options.ThemeFonts)
+		if options.InlineFonts {
+			inliner := newFontInliner(options.InlineFontsLimit)
+			themeFontFaces = inliner.inline(ctx, themeFontFaces)
+			warnings = append(warnings, inliner.warnings...)
+		}
+		data.ThemeFontFaces = template.CSS(themeFontFaces) // #nosec G203 - generated from the theme's fonts, names and URLs are sanitized
 	}
-	defer func() { _ = outputFile.Close() }()
 
-	// Execute template
-	if err := r.template.Execute(outputFile, data); err != nil {
+	var page bytes.Buffer
+	if err := r.template.Execute(&page, data); err != nil {
 		return nil, fmt.Errorf("executing template: %w", err)
 	}
 
+	// Fonts are embedded once the page is rendered, so subsetting can keep
+	// just the characters it displays
+	var fonts []FontEmbedding
+	if len(options.Fonts) > 0 {
+		fontFaces, embedded, fontWarnings, err := embedFonts(options.Fonts, documentText(page.String()), options.SubsetFonts)
+		if err != nil {
+			return nil, err
+		}
+		fonts = embedded
+		warnings = append(warnings, fontWarnings...)
+		data.FontFaces = template.CSS(fontFaces) // #nosec G203 - generated from font files, names are sanitized
+
+		page.Reset()
+		if err := r.template.Execute(&page, data); err != nil {
+			return nil, fmt.Errorf("executing template: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(options.OutputPath, page.Bytes(), 0600); err != nil {
+		return nil, fmt.Errorf("creating output file: %w", err)
+	}
+
 	// Get file size
 	fileSize, _ := GetFileSize(options.OutputPath)
 
-	return &ExportResult{
+	result := &ExportResult{
 		Success:    true,
 		Format:     string(FormatHTML),
 		OutputPath: options.OutputPath,
 		FileSize:   fileSize,
 		PageCount:  len(presentation.Slides),
-	}, nil
+		Warnings:   warnings,
+	}
+	if len(fonts) > 0 {
+		saved := 0
+		for _, font := range fonts {
+			saved += font.BytesSaved()
+		}
+		result.Metadata = map[string]interface{}{
+			"fonts":            fonts,
+			"font_bytes_saved": saved,
+		}
+	}
+	return result, nil
 }
 
 // Supports returns true if this renderer supports the given format
@@ -110,6 +160,9 @@ const staticHTMLTemplate = `<!DOCTYPE html>
     <meta name="author" content="{{.Author}}">
     <meta name="generator" content="slicli - CLI Presentation Generator">
     <meta name="export-date" content="{{.GeneratedAt}}">
+    {{with .Document}}{{if .Subject}}<meta name="description" content="{{.Subject}}">
+    {{end}}{{if .Keywords}}<meta name="keywords" content="{{.KeywordList}}">
+    {{end}}<meta name="dcterms.created" content="{{.CreatedAt.Format "2006-01-02T15:04:05Z07:00"}}">{{end}}
     
     <style>
         /* Reset and base styles */
@@ -252,6 +305,26 @@ const staticHTMLTemplate = `<!DOCTYPE html>
             margin-bottom: 1em;
         }
         
+        /* Draft slides included with --include-drafts */
+        .slide.draft {
+            outline: 3px dashed #e67e22;
+            outline-offset: -12px;
+        }
+        
+        .slide.draft::before {
+            content: "DRAFT";
+            position: absolute;
+            top: 16px;
+            right: 24px;
+            padding: 2px 8px;
+            background: #e67e22;
+            color: #fff;
+            font-size: 0.75em;
+            font-weight: bold;
+            letter-spacing: 0.1em;
+            border-radius: 3px;
+        }
+        
         /* Speaker notes */
         .speaker-notes {
             {{if not .IncludeNotes}}display: none;{{else}}
@@ -373,6 +446,28 @@ const staticHTMLTemplate = `<!DOCTYPE html>
             }
         }
         
+        /* Print layout - every slide stacked, one per page */
+        body.print-layout,
+        body.print-layout .presentation {
+            height: auto;
+            overflow: visible;
+        }
+        
+        body.print-layout .slide {
+            position: relative;
+            opacity: 1;
+            transform: none;
+            width: auto;
+            break-after: page;
+            page-break-after: always;
+        }
+        
+        body.print-layout .controls,
+        body.print-layout .slide-number,
+        body.print-layout .progress-bar {
+            display: none;
+        }
+        
         /* Responsive design */
         @media (max-width: 768px) {
             .slide {
@@ -386,9 +481,13 @@ const staticHTMLTemplate = `<!DOCTYPE html>
             .slide h3 { font-size: 1.4em; }
             .slide p, .slide li { font-size: 1em; }
         }
-    </style>
+    </style>{{if .ThemeFontFaces}}
+    <style>
+{{.ThemeFontFaces}}    </style>{{end}}{{if .FontFaces}}
+    <style>
+{{.FontFaces}}    </style>{{end}}
 </head>
-<body>
+<body{{if .PrintLayout}} class="print-layout"{{end}}>
     <div class="presentation" data-theme="{{.Theme}}">
         <!-- Progress bar -->
         <div class="progress-bar">
@@ -403,7 +502,7 @@ const staticHTMLTemplate = `<!DOCTYPE html>
         
         <!-- Slides -->
         {{range $index, $slide := .Slides}}
-        <div class="slide" data-index="{{$index}}">
+        <div class="slide{{if $slide.Draft}} draft{{end}}" data-index="{{$index}}"{{if $slide.Draft}} data-draft="true"{{end}}>
             {{$slide.HTML | safeHTML}}
             {{if $.IncludeNotes}}{{if $slide.Notes}}
             <div class="speaker-notes">
@@ -437,6 +536,9 @@ const staticHTMLTemplate = `<!DOCTYPE html>
         (function() {
             'use strict';
             
+            // The print layout has no navigation
+            if (document.body.classList.contains('print-layout')) return;
+            
             let currentSlide = 0;
             const slides = document.querySelectorAll('.slide');
             const totalSlides = slides.length;
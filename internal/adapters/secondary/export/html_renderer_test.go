@@ -0,0 +1,46 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestHTMLRenderer_PrintLayout(t *testing.T) {
+	presentation := &entities.Presentation{
+		Title: "Print",
+		Slides: []entities.Slide{
+			{Index: 0, Title: "One", HTML: "<h1>One</h1>"},
+			{Index: 1, Title: "Two", HTML: "<h1>Two</h1>"},
+		},
+	}
+	renderer := NewHTMLRenderer()
+
+	export := func(t *testing.T, printLayout bool) string {
+		options := &ExportOptions{
+			Format:      FormatHTML,
+			OutputPath:  filepath.Join(t.TempDir(), "deck.html"),
+			PrintLayout: printLayout,
+		}
+		_, err := renderer.Render(context.Background(), presentation, options)
+		require.NoError(t, err)
+		content, err := os.ReadFile(options.OutputPath)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	printed := export(t, true)
+	assert.Contains(t, printed, `<body class="print-layout">`)
+	assert.Contains(t, printed, "<h1>One</h1>")
+	assert.Contains(t, printed, "<h1>Two</h1>")
+
+	interactive := export(t, false)
+	assert.Contains(t, interactive, "<body>")
+	assert.NotContains(t, interactive, `<body class="print-layout">`)
+}
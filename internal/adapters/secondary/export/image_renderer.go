@@ -2,6 +2,7 @@ package export
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"image"
@@ -19,6 +20,24 @@ import (
 	"golang.org/x/net/html"
 )
 
+// ImageManifestFile is written next to the slide images of an image export
+const ImageManifestFile = "manifest.json"
+
+// ImageManifest lists the images of an image export in slide order
+type ImageManifest struct {
+	Title  string               `json:"title"`
+	Slides []ImageManifestSlide `json:"slides"`
+}
+
+// ImageManifestSlide describes the image rendered for one slide
+type ImageManifestSlide struct {
+	Index  int    `json:"index"` // 0-based position in the export
+	Title  string `json:"title"`
+	File   string `json:"file"` // Relative to the manifest
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
 // ImageRenderer implements export to image files (PNG/JPG)
 type ImageRenderer struct {
 	htmlRenderer      *HTMLRenderer
@@ -65,8 +84,15 @@ func (r *ImageRenderer) Render(ctx context.Context, presentation *entities.Prese
 	// Create temporary HTML file for each slide
 	tmpDir := filepath.Dir(outputDir)
 
+	imageOptions := imageOptionsFor(options)
+
 	var generatedFiles []string
 	var totalSize int64
+	var warnings []string
+	manifest := ImageManifest{
+		Title:  presentation.Title,
+		Slides: make([]ImageManifestSlide, 0, len(presentation.Slides)),
+	}
 
 	for i, slide := range presentation.Slides {
 		// Create individual slide presentation
@@ -91,26 +117,27 @@ func (r *ImageRenderer) Render(ctx context.Context, presentation *entities.Prese
 
 		// Generate HTML for this slide
 		htmlOptions := &ExportOptions{
-			Format:          FormatHTML,
-			OutputPath:      tmpFile.Name(),
-			Theme:           options.Theme,
-			IncludeNotes:    false, // Don't include notes in image exports
-			IncludeMetadata: options.IncludeMetadata,
-			Metadata:        options.Metadata,
+			Format:           FormatHTML,
+			OutputPath:       tmpFile.Name(),
+			Theme:            options.Theme,
+			IncludeNotes:     false, // Don't include notes in image exports
+			IncludeMetadata:  options.IncludeMetadata,
+			Metadata:         options.Metadata,
+			ThemeFonts:       options.ThemeFonts,
+			InlineFonts:      options.InlineFonts,
+			InlineFontsLimit: options.InlineFontsLimit,
 		}
 
-		_, err = r.htmlRenderer.Render(ctx, singleSlidePresentation, htmlOptions)
+		htmlResult, err := r.htmlRenderer.Render(ctx, singleSlidePresentation, htmlOptions)
 		if err != nil {
 			return nil, fmt.Errorf("generating HTML for slide %d: %w", i, err)
 		}
-
-		// Generate image filename
-		imageFormat := "png"
-		if options.Quality == "low" {
-			imageFormat = "jpg"
+		// Every slide embeds the same fonts, so later slides repeat these warnings
+		if i == 0 {
+			warnings = htmlResult.Warnings
 		}
 
-		imagePath := filepath.Join(outputDir, fmt.Sprintf("slide-%03d.%s", i+1, imageFormat))
+		imagePath := filepath.Join(outputDir, fmt.Sprintf("slide-%03d.%s", i+1, imageOptions.Format))
 
 		// Convert HTML to image
 		err = r.convertHTMLToImage(tmpFile.Name(), imagePath, options)
@@ -124,8 +151,31 @@ func (r *ImageRenderer) Render(ctx context.Context, presentation *entities.Prese
 		if size, err := GetFileSize(imagePath); err == nil {
 			totalSize += size
 		}
+
+		entry, err := imageManifestEntry(i, slide.Title, imagePath)
+		if err != nil {
+			return nil, fmt.Errorf("reading image for slide %d: %w", i, err)
+		}
+		manifest.Slides = append(manifest.Slides, entry)
+	}
+
+	if imageOptions.ContactSheet && len(generatedFiles) > 0 {
+		sheetPath, err := r.writeContactSheet(generatedFiles, outputDir, imageOptions, options)
+		if err != nil {
+			return nil, fmt.Errorf("writing contact sheet: %w", err)
+		}
+		generatedFiles = append(generatedFiles, sheetPath)
+		if size, err := GetFileSize(sheetPath); err == nil {
+			totalSize += size
+		}
 	}
 
+	manifestPath := filepath.Join(outputDir, ImageManifestFile)
+	if err := writeImageManifest(manifestPath, &manifest); err != nil {
+		return nil, err
+	}
+	generatedFiles = append(generatedFiles, manifestPath)
+
 	return &ExportResult{
 		Success:    true,
 		Format:     string(FormatImages),
@@ -133,9 +183,45 @@ func (r *ImageRenderer) Render(ctx context.Context, presentation *entities.Prese
 		FileSize:   totalSize,
 		PageCount:  len(presentation.Slides),
 		Files:      generatedFiles,
+		Warnings:   warnings,
 	}, nil
 }
 
+// imageManifestEntry describes the image written for a slide, reading its
+// dimensions back from the file since Chrome and the fallback size them differently
+func imageManifestEntry(index int, title, imagePath string) (ImageManifestSlide, error) {
+	file, err := os.Open(filepath.Clean(imagePath))
+	if err != nil {
+		return ImageManifestSlide{}, err
+	}
+	defer func() { _ = file.Close() }()
+
+	config, _, err := image.DecodeConfig(file)
+	if err != nil {
+		return ImageManifestSlide{}, fmt.Errorf("decoding %s: %w", filepath.Base(imagePath), err)
+	}
+
+	return ImageManifestSlide{
+		Index:  index,
+		Title:  title,
+		File:   filepath.Base(imagePath),
+		Width:  config.Width,
+		Height: config.Height,
+	}, nil
+}
+
+// writeImageManifest replaces any manifest left by a previous export
+func writeImageManifest(path string, manifest *ImageManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding image manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("writing image manifest: %w", err)
+	}
+	return nil
+}
+
 // convertHTMLToImage converts an HTML file to an image using browser automation
 func (r *ImageRenderer) convertHTMLToImage(htmlPath, outputPath string, options *ExportOptions) error {
 	// Check if browser automation is available
@@ -149,20 +235,14 @@ func (r *ImageRenderer) convertHTMLToImage(htmlPath, outputPath string, options
 		return r.fallbackImageGeneration(htmlPath, outputPath, options)
 	}
 
-	// Determine image format
-	imageFormat := "png"
-	if options.Quality == "low" {
-		imageFormat = "jpg"
-	}
-
-	// Convert export options to image options
-	imageOptions := &ImageOptions{
-		Quality: options.Quality,
-		Format:  imageFormat,
-	}
-
-	// Use browser automation for image generation
-	err := r.browserAutomation.ConvertHTMLToImage(ctx, htmlPath, outputPath, imageOptions)
+	// Use browser automation for image generation, retrying Chrome failures
+	err := r.browserAutomation.retry(ctx, func() error {
+		// Launch Chrome once up front so a flaky first start doesn't cost the real conversion
+		if err := r.browserAutomation.Warmup(ctx); err != nil {
+			return err
+		}
+		return r.browserAutomation.ConvertHTMLToImage(ctx, htmlPath, outputPath, imageOptionsFor(options))
+	})
 	if err != nil {
 		// Fallback to placeholder image generation if browser automation fails
 		return r.fallbackImageGeneration(htmlPath, outputPath, options)
@@ -171,6 +251,21 @@ func (r *ImageRenderer) convertHTMLToImage(htmlPath, outputPath string, options
 	return nil
 }
 
+// imageOptionsFor converts export options to image options. Low quality
+// exports are written as JPEG, the rest as PNG.
+func imageOptionsFor(options *ExportOptions) *ImageOptions {
+	imageFormat := "png"
+	if options.Quality == "low" {
+		imageFormat = "jpg"
+	}
+	return &ImageOptions{
+		Quality:      options.Quality,
+		Format:       imageFormat,
+		ContactSheet: options.ContactSheet,
+		Columns:      options.ContactSheetColumns,
+	}
+}
+
 // fallbackImageGeneration creates a real image when browser automation is not available
 func (r *ImageRenderer) fallbackImageGeneration(htmlPath, outputPath string, options *ExportOptions) error {
 	return r.generateProperImage(htmlPath, outputPath, options)
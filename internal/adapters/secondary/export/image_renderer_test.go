@@ -2,6 +2,8 @@ package export
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -52,10 +54,11 @@ func TestImageRenderer_FallbackGeneration(t *testing.T) {
 		assert.True(t, result.Success)
 		assert.Equal(t, string(FormatImages), result.Format)
 		assert.Equal(t, len(presentation.Slides), result.PageCount)
-		assert.Len(t, result.Files, len(presentation.Slides))
+		require.Len(t, result.Files, len(presentation.Slides)+1)
+		assert.Equal(t, filepath.Join(outputDir, ImageManifestFile), result.Files[len(presentation.Slides)])
 
 		// Verify image files were created
-		for i, filePath := range result.Files {
+		for i, filePath := range result.Files[:len(presentation.Slides)] {
 			_, err = os.Stat(filePath)
 			assert.NoError(t, err, "Image file %d should exist", i+1)
 
@@ -90,7 +93,7 @@ func TestImageRenderer_FallbackGeneration(t *testing.T) {
 		result, err := renderer.Render(context.Background(), presentation, options)
 
 		require.NoError(t, err)
-		assert.Len(t, result.Files, 1)
+		assert.Len(t, result.Files, 2)
 
 		// Verify JPEG file extension
 		imageFile := result.Files[0]
@@ -122,7 +125,97 @@ func TestImageRenderer_FallbackGeneration(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.True(t, result.Success)
-		assert.Empty(t, result.Files, "Should have no files for empty presentation")
+		assert.Equal(t, []string{filepath.Join(outputDir, ImageManifestFile)}, result.Files, "Should only write the manifest for empty presentation")
+	})
+}
+
+func TestImageRenderer_Manifest(t *testing.T) {
+	presentation := &entities.Presentation{
+		Title: "Manifest Deck",
+		Slides: []entities.Slide{
+			{Title: "Intro", Content: "Welcome"},
+			{Title: "Details", Content: "More content"},
+			{Title: "Outro", Content: "Thanks"},
+		},
+	}
+	outputDir := filepath.Join(t.TempDir(), "images")
+	renderer := &ImageRenderer{htmlRenderer: NewHTMLRenderer()} // Fallback renderer, no browser
+
+	readManifest := func(t *testing.T) ImageManifest {
+		data, err := os.ReadFile(filepath.Join(outputDir, ImageManifestFile))
+		require.NoError(t, err)
+		var manifest ImageManifest
+		require.NoError(t, json.Unmarshal(data, &manifest))
+		return manifest
+	}
+
+	_, err := renderer.Render(context.Background(), presentation, &ExportOptions{Format: FormatImages, OutputPath: outputDir, Quality: "low"})
+	require.NoError(t, err)
+
+	manifest := readManifest(t)
+	assert.Equal(t, "Manifest Deck", manifest.Title)
+	require.Len(t, manifest.Slides, len(presentation.Slides))
+	for i, slide := range manifest.Slides {
+		assert.Equal(t, i, slide.Index)
+		assert.Equal(t, presentation.Slides[i].Title, slide.Title)
+		assert.Equal(t, fmt.Sprintf("slide-%03d.jpg", i+1), slide.File)
+		assert.Equal(t, 1280, slide.Width)
+		assert.Equal(t, 720, slide.Height)
+		assert.FileExists(t, filepath.Join(outputDir, slide.File))
+	}
+
+	t.Run("re-export replaces the manifest", func(t *testing.T) {
+		presentation.Slides = presentation.Slides[:1]
+		_, err := renderer.Render(context.Background(), presentation, &ExportOptions{Format: FormatImages, OutputPath: outputDir})
+		require.NoError(t, err)
+
+		manifest := readManifest(t)
+		require.Len(t, manifest.Slides, 1)
+		assert.Equal(t, "slide-001.png", manifest.Slides[0].File)
+		assert.Equal(t, 1920, manifest.Slides[0].Width)
+		assert.FileExists(t, filepath.Join(outputDir, manifest.Slides[0].File))
+	})
+}
+
+func TestImageRenderer_ContactSheet(t *testing.T) {
+	presentation := &entities.Presentation{
+		Title: "Sheet Deck",
+		Slides: []entities.Slide{
+			{Title: "One", Content: "First"},
+			{Title: "Two", Content: "Second"},
+			{Title: "Three", Content: "Third"},
+			{Title: "Four", Content: "Fourth"},
+		},
+	}
+	outputDir := filepath.Join(t.TempDir(), "images")
+	renderer := &ImageRenderer{htmlRenderer: NewHTMLRenderer()} // Fallback renderer, no browser
+
+	result, err := renderer.Render(context.Background(), presentation, &ExportOptions{
+		Format:              FormatImages,
+		OutputPath:          outputDir,
+		ContactSheet:        true,
+		ContactSheetColumns: 2,
+	})
+	require.NoError(t, err)
+
+	sheetPath := filepath.Join(outputDir, "contact-sheet.png")
+	require.Len(t, result.Files, 6, "four slides, the sheet and the manifest")
+	for i := range presentation.Slides {
+		assert.Equal(t, filepath.Join(outputDir, fmt.Sprintf("slide-%03d.png", i+1)), result.Files[i])
+	}
+	assert.Equal(t, sheetPath, result.Files[4])
+
+	// 1920x1080 slides scale to 480x270 thumbnails, each captioned below
+	sheet, err := decodeImageFile(sheetPath)
+	require.NoError(t, err)
+	assert.Equal(t, 2*480+3*contactSheetGap, sheet.Bounds().Dx(), "two columns")
+	assert.Equal(t, 2*(270+contactSheetCaption)+3*contactSheetGap, sheet.Bounds().Dy(), "two rows")
+
+	t.Run("grid", func(t *testing.T) {
+		columns, rows := contactSheetGrid(5, 0)
+		assert.Equal(t, []int{DefaultContactSheetColumns, 2}, []int{columns, rows})
+		columns, rows = contactSheetGrid(2, 4)
+		assert.Equal(t, []int{2, 1}, []int{columns, rows}, "no more columns than slides")
 	})
 }
 
@@ -0,0 +1,159 @@
+package export
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// DefaultInlineImageLimit caps the size of each image inlined into an HTML
+// export when ExportOptions.InlineImageLimit is unset
+const DefaultInlineImageLimit = 256 << 10
+
+// imgSrcPattern matches the src attribute of an img tag, quoted either way
+var imgSrcPattern = regexp.MustCompile(`(<img\b[^>]*?\bsrc=)(?:"([^"]*)"|'([^']*)')`)
+
+// imageResolver rewrites the relative image references of one export
+type imageResolver struct {
+	sourceDir string
+	format    ExportFormat
+	inline    bool
+	limit     int64
+
+	// assetsDir receives copies of the images of HTML exports and is
+	// referenced by the name assetsName
+	assetsDir  string
+	assetsName string
+
+	resolved map[string]string // Original src -> rewritten src
+	warnings []string
+}
+
+// resolveLocalImages points the relative images of a presentation's slides
+// at files the export can reach. HTML exports get copies in a "<name>_files"
+// directory next to the output, or data URIs with InlineImages; exports
+// printed by the browser read the images where they are. Images that can't
+// be found are left as they are, with a warning.
+func resolveLocalImages(presentation *entities.Presentation, options *ExportOptions) (*entities.Presentation, []string, error) {
+	if options.Format != FormatHTML && !options.Format.UsesBrowser() {
+		return presentation, nil, nil
+	}
+
+	sourceDir, err := filepath.Abs(options.SourceDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving source directory: %w", err)
+	}
+
+	output := filepath.Base(options.OutputPath)
+	assetsName := strings.TrimSuffix(output, filepath.Ext(output)) + "_files"
+	r := &imageResolver{
+		sourceDir:  sourceDir,
+		format:     options.Format,
+		inline:     options.InlineImages,
+		limit:      options.InlineImageLimit,
+		assetsDir:  filepath.Join(filepath.Dir(options.OutputPath), assetsName),
+		assetsName: assetsName,
+		resolved:   make(map[string]string),
+	}
+	if r.limit <= 0 {
+		r.limit = DefaultInlineImageLimit
+	}
+
+	resolved := *presentation
+	resolved.Slides = make([]entities.Slide, len(presentation.Slides))
+	for i, slide := range presentation.Slides {
+		var rewriteErr error
+		slide.HTML = imgSrcPattern.ReplaceAllStringFunc(slide.HTML, func(tag string) string {
+			match := imgSrcPattern.FindStringSubmatch(tag)
+			src := html.UnescapeString(match[2] + match[3])
+			rewritten, err := r.rewrite(src)
+			if err != nil {
+				rewriteErr = err
+			}
+			if rewritten == "" {
+				return tag
+			}
+			return match[1] + `"` + html.EscapeString(rewritten) + `"`
+		})
+		if rewriteErr != nil {
+			return nil, nil, rewriteErr
+		}
+		resolved.Slides[i] = slide
+	}
+	return &resolved, r.warnings, nil
+}
+
+// rewrite returns the src an image reference gets in the export, or "" to
+// leave it alone
+func (r *imageResolver) rewrite(src string) (string, error) {
+	if rewritten, done := r.resolved[src]; done {
+		return rewritten, nil
+	}
+
+	file, ok := r.localPath(src)
+	if !ok {
+		return "", nil
+	}
+	info, err := os.Stat(file)
+	if err != nil || info.IsDir() {
+		r.warnings = append(r.warnings, fmt.Sprintf("image %s not found in %s", src, r.sourceDir))
+		r.resolved[src] = ""
+		return "", nil
+	}
+
+	var rewritten string
+	switch {
+	case r.format.UsesBrowser():
+		rewritten = (&url.URL{Scheme: "file", Path: filepath.ToSlash(file)}).String()
+	case r.inline && info.Size() <= r.limit:
+		data, err := os.ReadFile(file) // #nosec G304 - path is confined to the source directory
+		if err != nil {
+			return "", fmt.Errorf("reading image %s: %w", src, err)
+		}
+		mimeType := mime.TypeByExtension(filepath.Ext(file))
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		rewritten = fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data))
+	default:
+		rel, _ := filepath.Rel(r.sourceDir, file)
+		dest := filepath.Join(r.assetsDir, rel)
+		if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+			return "", fmt.Errorf("creating image directory: %w", err)
+		}
+		if err := CopyFile(file, dest); err != nil {
+			return "", fmt.Errorf("copying image %s: %w", src, err)
+		}
+		rewritten = path.Join(r.assetsName, filepath.ToSlash(rel))
+	}
+
+	r.resolved[src] = rewritten
+	return rewritten, nil
+}
+
+// localPath resolves a relative image reference inside the source directory.
+// URLs, absolute paths and references escaping the directory are refused.
+func (r *imageResolver) localPath(src string) (string, bool) {
+	parsed, err := url.Parse(src)
+	if err != nil || parsed.Scheme != "" || parsed.Host != "" || parsed.Path == "" {
+		return "", false
+	}
+	if strings.HasPrefix(parsed.Path, "/") || validateFilePath(parsed.Path) != nil {
+		return "", false
+	}
+
+	file := filepath.Join(r.sourceDir, filepath.FromSlash(parsed.Path))
+	if rel, err := filepath.Rel(r.sourceDir, file); err != nil || strings.HasPrefix(rel, "..") {
+		return "", false
+	}
+	return file, true
+}
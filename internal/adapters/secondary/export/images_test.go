@@ -0,0 +1,90 @@
+package export
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestService_ExportResolvesRelativeImages(t *testing.T) {
+	sourceDir := t.TempDir()
+	png := []byte("\x89PNG\r\n\x1a\nfake image")
+	require.NoError(t, os.MkdirAll(filepath.Join(sourceDir, "images"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "images", "diagram.png"), png, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(t.TempDir(), "secret.png"), png, 0600))
+
+	presentation := &entities.Presentation{
+		Title: "Images",
+		Slides: []entities.Slide{{
+			Index: 0,
+			Title: "Diagram",
+			HTML: `<p><img src="images/diagram.png" alt="diagram"></p>` +
+				`<p><img src="../secret.png" alt="outside"></p>` +
+				`<p><img src="https://example.com/logo.png" alt="remote"></p>` +
+				`<p><img src="images/missing.png" alt="missing"></p>`,
+		}},
+	}
+
+	service, err := NewService(t.TempDir())
+	require.NoError(t, err)
+
+	export := func(t *testing.T, inline bool) (string, string, *ExportResult) {
+		outputPath := filepath.Join(t.TempDir(), "deck.html")
+		result, err := service.Export(context.Background(), presentation, &ExportOptions{
+			Format:       FormatHTML,
+			OutputPath:   outputPath,
+			SourceDir:    sourceDir,
+			InlineImages: inline,
+		})
+		require.NoError(t, err)
+		page, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+		return outputPath, string(page), result
+	}
+
+	t.Run("copied next to the export", func(t *testing.T) {
+		outputPath, page, result := export(t, false)
+
+		assert.Contains(t, page, `src="deck_files/images/diagram.png"`)
+		copied, err := os.ReadFile(filepath.Join(filepath.Dir(outputPath), "deck_files", "images", "diagram.png"))
+		require.NoError(t, err)
+		assert.Equal(t, png, copied)
+
+		// Only relative paths inside the presentation's directory are touched
+		assert.Contains(t, page, `src="../secret.png"`)
+		assert.Contains(t, page, `src="https://example.com/logo.png"`)
+		assert.NoFileExists(t, filepath.Join(filepath.Dir(outputPath), "secret.png"))
+
+		assert.Contains(t, result.Warnings, "image images/missing.png not found in "+sourceDir)
+		assert.Contains(t, presentation.Slides[0].HTML, `<img src="images/diagram.png"`, "the presentation is not modified")
+	})
+
+	t.Run("inlined as data URIs", func(t *testing.T) {
+		outputPath, page, _ := export(t, true)
+
+		assert.Contains(t, page, `src="data:image/png;base64,`+base64.StdEncoding.EncodeToString(png)+`"`)
+		assert.NoDirExists(t, filepath.Join(filepath.Dir(outputPath), "deck_files"))
+	})
+}
+
+func TestResolveLocalImagesForBrowserExports(t *testing.T) {
+	sourceDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "chart.svg"), []byte("<svg/>"), 0600))
+
+	presentation := &entities.Presentation{Slides: []entities.Slide{{HTML: `<img src='chart.svg'>`}}}
+	resolved, warnings, err := resolveLocalImages(presentation, &ExportOptions{
+		Format:     FormatPDF,
+		OutputPath: filepath.Join(t.TempDir(), "deck.pdf"),
+		SourceDir:  sourceDir,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, `<img src="file://`+filepath.ToSlash(filepath.Join(sourceDir, "chart.svg"))+`">`, resolved.Slides[0].HTML)
+}
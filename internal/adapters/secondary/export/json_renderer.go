@@ -0,0 +1,268 @@
+package export
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// JSONSchemaVersion is bumped whenever a field is removed or changes meaning.
+// New fields may be added within a version.
+const JSONSchemaVersion = "1.0"
+
+// JSONSchema is the JSON Schema describing JSONDocument
+//
+//go:embed schema/presentation-v1.schema.json
+var JSONSchema []byte
+
+// JSONDocument is the structured form of a presentation exported for tooling
+type JSONDocument struct {
+	SchemaVersion string           `json:"schema_version"`
+	Generator     string           `json:"generator"`
+	Presentation  JSONPresentation `json:"presentation"`
+	Slides        []JSONSlide      `json:"slides"`
+}
+
+// JSONPresentation holds presentation-level metadata
+type JSONPresentation struct {
+	Title      string                 `json:"title"`
+	Author     string                 `json:"author"`
+	Date       string                 `json:"date"` // YYYY-MM-DD, empty when unset
+	Theme      string                 `json:"theme"`
+	SlideCount int                    `json:"slide_count"`
+	Metadata   map[string]interface{} `json:"metadata"`
+}
+
+// JSONSlide describes one slide. Every field is always present so consumers
+// don't need to distinguish missing from empty.
+type JSONSlide struct {
+	Index    int      `json:"index"`
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Type     string   `json:"type"` // title, section, content or a declared layout
+	Markdown string   `json:"markdown"`
+	HTML     string   `json:"html"`
+	Notes    string   `json:"notes"`
+	Tags     []string `json:"tags"`
+	Assets   []string `json:"assets"`
+	Draft    bool     `json:"draft"`
+}
+
+// markdownImage matches the target of a markdown image such as ![alt](img/a.png "title")
+var markdownImage = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+// JSONRenderer implements export to a versioned JSON document
+type JSONRenderer struct{}
+
+// NewJSONRenderer creates a new JSON renderer
+func NewJSONRenderer() *JSONRenderer {
+	return &JSONRenderer{}
+}
+
+// Render exports the presentation as a JSON document
+func (r *JSONRenderer) Render(ctx context.Context, presentation *entities.Presentation, options *ExportOptions) (*ExportResult, error) {
+	doc := NewJSONDocument(presentation, options.IncludeNotes)
+	if options.IncludeMetadata {
+		for key, value := range options.Metadata {
+			doc.Presentation.Metadata[key] = value
+		}
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding JSON document: %w", err)
+	}
+
+	if err := os.WriteFile(options.OutputPath, append(data, '\n'), 0600); err != nil {
+		return nil, fmt.Errorf("writing JSON file: %w", err)
+	}
+
+	fileSize, _ := GetFileSize(options.OutputPath)
+
+	return &ExportResult{
+		Success:    true,
+		Format:     string(FormatJSON),
+		OutputPath: options.OutputPath,
+		FileSize:   fileSize,
+		PageCount:  len(presentation.Slides),
+	}, nil
+}
+
+// Supports checks if this renderer supports the given format
+func (r *JSONRenderer) Supports(format ExportFormat) bool {
+	return format == FormatJSON
+}
+
+// GetMimeType returns the MIME type for JSON
+func (r *JSONRenderer) GetMimeType() string {
+	return "application/json"
+}
+
+// NewJSONDocument builds the JSON form of a presentation. Speaker notes are
+// left empty unless includeNotes is set.
+func NewJSONDocument(presentation *entities.Presentation, includeNotes bool) *JSONDocument {
+	doc := &JSONDocument{
+		SchemaVersion: JSONSchemaVersion,
+		Generator:     "slicli",
+		Presentation: JSONPresentation{
+			Title:      presentation.Title,
+			Author:     presentation.Author,
+			Theme:      presentation.Theme,
+			SlideCount: len(presentation.Slides),
+			Metadata:   make(map[string]interface{}, len(presentation.Metadata)),
+		},
+		Slides: make([]JSONSlide, 0, len(presentation.Slides)),
+	}
+	if !presentation.Date.IsZero() {
+		doc.Presentation.Date = presentation.Date.Format("2006-01-02")
+	}
+	for key, value := range presentation.Metadata {
+		doc.Presentation.Metadata[key] = value
+	}
+
+	for i := range presentation.Slides {
+		slide := &presentation.Slides[i]
+
+		title := slide.Title
+		if title == "" {
+			title = slide.ExtractTitle()
+		}
+
+		jsonSlide := JSONSlide{
+			Index:    slide.Index,
+			ID:       slide.ID,
+			Title:    title,
+			Type:     slideType(slide),
+			Markdown: slide.Content,
+			HTML:     slide.HTML,
+			Tags:     slideTags(slide),
+			Assets:   slideAssets(slide),
+			Draft:    slide.Draft,
+		}
+		if includeNotes {
+			jsonSlide.Notes = slide.Notes
+		}
+		doc.Slides = append(doc.Slides, jsonSlide)
+	}
+
+	return doc
+}
+
+// slideType reports a slide's type the way serve picks layouts: a declared
+// <!-- layout --> first, then title for the opening slide, section for a
+// heading with little else, and content otherwise
+func slideType(slide *entities.Slide) string {
+	for _, key := range []string{"layout", "type"} {
+		if value, ok := slide.Metadata[key].(string); ok && value != "" {
+			return strings.ToLower(value)
+		}
+	}
+	if declared := entities.DeclaredSlideType(slide.Content); declared != "" {
+		return declared
+	}
+	if slide.Index == 0 {
+		return "title"
+	}
+
+	lines := strings.Split(strings.TrimSpace(slide.Content), "\n")
+	if strings.HasPrefix(strings.TrimSpace(lines[0]), "# ") {
+		contentLines := 0
+		for _, line := range lines[1:] {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "<!--") {
+				contentLines++
+			}
+		}
+		if contentLines <= 2 {
+			return "section"
+		}
+	}
+	return "content"
+}
+
+// slideTags reads tags from slide metadata, given as a list or a comma separated string
+func slideTags(slide *entities.Slide) []string {
+	tags := []string{}
+	switch value := slide.Metadata["tags"].(type) {
+	case []string:
+		tags = append(tags, value...)
+	case []interface{}:
+		for _, tag := range value {
+			if s, ok := tag.(string); ok {
+				tags = append(tags, s)
+			}
+		}
+	case string:
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// slideAssets lists the images and media a slide references, in order of
+// first appearance, from its rendered HTML and its markdown image links
+func slideAssets(slide *entities.Slide) []string {
+	assets := HTMLAssets(slide.HTML)
+	if assets == nil {
+		assets = []string{}
+	}
+	seen := make(map[string]bool, len(assets))
+	for _, src := range assets {
+		seen[src] = true
+	}
+
+	for _, match := range markdownImage.FindAllStringSubmatch(slide.Content, -1) {
+		src := match[1]
+		if strings.HasPrefix(src, "data:") || seen[src] {
+			continue
+		}
+		seen[src] = true
+		assets = append(assets, src)
+	}
+
+	return assets
+}
+
+// HTMLAssets lists the images, media and embeds rendered slide HTML loads,
+// in order of first appearance. Inline data URIs are skipped since there is
+// nothing to fetch.
+func HTMLAssets(slideHTML string) []string {
+	var assets []string
+	seen := make(map[string]bool)
+
+	tokenizer := html.NewTokenizer(strings.NewReader(slideHTML))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return assets
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "img", "video", "audio", "source", "iframe", "embed":
+			default:
+				continue
+			}
+			for _, attr := range token.Attr {
+				if attr.Key != "src" && attr.Key != "poster" {
+					continue
+				}
+				src := strings.TrimSpace(attr.Val)
+				if src == "" || strings.HasPrefix(src, "data:") || seen[src] {
+					continue
+				}
+				seen[src] = true
+				assets = append(assets, src)
+			}
+		}
+	}
+}
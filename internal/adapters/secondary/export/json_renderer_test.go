@@ -0,0 +1,187 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func sampleJSONPresentation() *entities.Presentation {
+	return &entities.Presentation{
+		Title:    "Quarterly Review",
+		Author:   "Dana",
+		Theme:    "dark",
+		Date:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Metadata: map[string]interface{}{"event": "all-hands"},
+		Slides: []entities.Slide{
+			{
+				Index:   0,
+				Content: "# Quarterly Review\n\nQ1 2024",
+				HTML:    "<h1>Quarterly Review</h1><p>Q1 2024</p>",
+				Notes:   "Welcome everyone",
+			},
+			{
+				Index:   1,
+				Content: "# Results",
+				HTML:    "<h1>Results</h1>",
+			},
+			{
+				Index:    2,
+				Title:    "Growth",
+				Content:  "## Growth\n\n![chart](img/growth.png)\n\n- Revenue up\n- Costs down\n- Hiring",
+				HTML:     `<h2>Growth</h2><p><img src="img/growth.png" alt="chart"></p><video src="media/demo.mp4" poster="img/poster.jpg"></video><ul><li>Revenue up</li></ul>`,
+				Metadata: map[string]interface{}{"tags": []interface{}{"finance", "kpi"}},
+			},
+			{
+				Index:    3,
+				Content:  "<!-- layout: quote -->\n> Ship it\n\n![logo](<img/logo.svg>)",
+				HTML:     "<blockquote>Ship it</blockquote>",
+				Metadata: map[string]interface{}{"tags": "closing, quote"},
+				Draft:    true,
+			},
+		},
+	}
+}
+
+// validateAgainstSchema checks the required properties and JSON types a
+// schema declares, which is all the presentation schema relies on
+func validateAgainstSchema(t *testing.T, schema map[string]interface{}, value interface{}, path string) {
+	t.Helper()
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		require.True(t, ok, "%s should be an object", path)
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				assert.Contains(t, object, name, "%s is missing %s", path, name)
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for name, propertySchema := range properties {
+			if propertyValue, ok := object[name]; ok {
+				validateAgainstSchema(t, propertySchema.(map[string]interface{}), propertyValue, path+"."+name)
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		require.True(t, ok, "%s should be an array", path)
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range items {
+				validateAgainstSchema(t, itemSchema, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		require.True(t, ok, "%s should be a string", path)
+		if pattern, ok := schema["pattern"].(string); ok {
+			assert.Regexp(t, regexp.MustCompile(pattern), s, path)
+		}
+	case "integer":
+		n, ok := value.(float64)
+		require.True(t, ok, "%s should be a number", path)
+		assert.Equal(t, float64(int64(n)), n, "%s should be an integer", path)
+	case "boolean":
+		_, ok := value.(bool)
+		assert.True(t, ok, "%s should be a boolean", path)
+	}
+}
+
+func TestJSONRenderer_Render(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "deck.json")
+	result, err := NewJSONRenderer().Render(context.Background(), sampleJSONPresentation(), &ExportOptions{
+		Format:       FormatJSON,
+		OutputPath:   outputPath,
+		IncludeNotes: true,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "json", result.Format)
+	assert.Equal(t, 4, result.PageCount)
+
+	data, err := os.ReadFile(outputPath) // #nosec G304 - test file
+	require.NoError(t, err)
+
+	var schema, generic map[string]interface{}
+	require.NoError(t, json.Unmarshal(JSONSchema, &schema))
+	require.NoError(t, json.Unmarshal(data, &generic))
+	validateAgainstSchema(t, schema, generic, "$")
+
+	var doc JSONDocument
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, JSONSchemaVersion, doc.SchemaVersion)
+	assert.Equal(t, JSONPresentation{
+		Title:      "Quarterly Review",
+		Author:     "Dana",
+		Date:       "2024-03-01",
+		Theme:      "dark",
+		SlideCount: 4,
+		Metadata:   map[string]interface{}{"event": "all-hands"},
+	}, doc.Presentation)
+
+	require.Len(t, doc.Slides, 4)
+
+	assert.Equal(t, "Quarterly Review", doc.Slides[0].Title)
+	assert.Equal(t, "title", doc.Slides[0].Type)
+	assert.Equal(t, "Welcome everyone", doc.Slides[0].Notes)
+	assert.Equal(t, "# Quarterly Review\n\nQ1 2024", doc.Slides[0].Markdown)
+	assert.Empty(t, doc.Slides[0].Tags)
+	assert.Empty(t, doc.Slides[0].Assets)
+
+	assert.Equal(t, "section", doc.Slides[1].Type)
+	assert.Equal(t, "Results", doc.Slides[1].Title)
+
+	assert.Equal(t, "content", doc.Slides[2].Type)
+	assert.Equal(t, "Growth", doc.Slides[2].Title)
+	assert.Equal(t, []string{"finance", "kpi"}, doc.Slides[2].Tags)
+	assert.Equal(t, []string{"img/growth.png", "media/demo.mp4", "img/poster.jpg"}, doc.Slides[2].Assets)
+
+	assert.Equal(t, "quote", doc.Slides[3].Type)
+	assert.Equal(t, []string{"closing", "quote"}, doc.Slides[3].Tags)
+	assert.Equal(t, []string{"img/logo.svg"}, doc.Slides[3].Assets)
+	assert.True(t, doc.Slides[3].Draft)
+}
+
+func TestJSONRenderer_Stable(t *testing.T) {
+	dir := t.TempDir()
+	render := func(name string) []byte {
+		path := filepath.Join(dir, name)
+		_, err := NewJSONRenderer().Render(context.Background(), sampleJSONPresentation(), &ExportOptions{
+			Format:     FormatJSON,
+			OutputPath: path,
+		})
+		require.NoError(t, err)
+		data, err := os.ReadFile(path) // #nosec G304 - test file
+		require.NoError(t, err)
+		return data
+	}
+
+	first := render("a.json")
+	assert.Equal(t, first, render("b.json"), "the same deck should export identically")
+
+	var doc JSONDocument
+	require.NoError(t, json.Unmarshal(first, &doc))
+	assert.Empty(t, doc.Slides[0].Notes, "notes are left out unless requested")
+}
+
+func TestHTMLAssets(t *testing.T) {
+	slideHTML := `<h2>Demo</h2>
+<p><img src="img/arch.png" alt="architecture"><img src="img/arch.png"></p>
+<video src="media/demo.mp4" poster="img/poster.jpg"><source src="media/demo.webm"></video>
+<iframe src="https://example.com/embed"></iframe>
+<img src="data:image/png;base64,AAAA">
+<a href="docs/spec.pdf">spec</a>`
+
+	assert.Equal(t, []string{"img/arch.png", "media/demo.mp4", "img/poster.jpg", "media/demo.webm", "https://example.com/embed"}, HTMLAssets(slideHTML))
+	assert.Empty(t, HTMLAssets("<h1>Text only</h1>"))
+}
@@ -4,12 +4,48 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
-	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"gopkg.in/yaml.v3"
 
 	"github.com/fredcamaral/slicli/internal/domain/entities"
 )
 
+var (
+	// whitespaceRun matches the whitespace HTML collapses to a single space
+	whitespaceRun = regexp.MustCompile(`\s+`)
+
+	// blockMarker matches text that markdown would read as the start of a
+	// heading, quote, list item or thematic break
+	blockMarker = regexp.MustCompile(`^(#{1,6}(\s|$)|>|[-+*](\s|$)|\d+[.)](\s|$)|={3,}\s*$)`)
+
+	// markdownEscaper escapes the characters that start inline markdown
+	markdownEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, "`", "\\`", `[`, `\[`, `]`, `\]`, `<`, `\<`)
+
+	// textAlign matches the alignment goldmark writes on table cells
+	textAlign = regexp.MustCompile(`text-align:\s*(left|center|right)`)
+)
+
+// blockElements are rendered as their own markdown blocks rather than inline
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Ul: true, atom.Ol: true, atom.Pre: true, atom.Blockquote: true, atom.Table: true, atom.Hr: true,
+	atom.Div: true, atom.Section: true, atom.Article: true, atom.Header: true, atom.Footer: true,
+	atom.Figure: true, atom.Details: true,
+}
+
+// markdownFrontMatter is the presentation metadata written ahead of the slides
+type markdownFrontMatter struct {
+	Title  string `yaml:"title,omitempty"`
+	Author string `yaml:"author,omitempty"`
+	Date   string `yaml:"date,omitempty"`
+	Theme  string `yaml:"theme,omitempty"`
+}
+
 // MarkdownRenderer implements export to markdown format
 type MarkdownRenderer struct{}
 
@@ -18,76 +54,33 @@ func NewMarkdownRenderer() *MarkdownRenderer {
 	return &MarkdownRenderer{}
 }
 
-// Render exports the presentation to markdown format
+// Render exports the presentation to markdown, rebuilding each slide from its
+// HTML and separating slides with --- so the result can be served again
 func (r *MarkdownRenderer) Render(ctx context.Context, presentation *entities.Presentation, options *ExportOptions) (*ExportResult, error) {
 	var content strings.Builder
 
-	// Write frontmatter
-	content.WriteString("---\n")
-	content.WriteString(fmt.Sprintf("title: \"%s\"\n", presentation.Title))
-	if presentation.Author != "" {
-		content.WriteString(fmt.Sprintf("author: \"%s\"\n", presentation.Author))
-	}
-	if !presentation.Date.IsZero() {
-		content.WriteString(fmt.Sprintf("date: \"%s\"\n", presentation.Date.Format("2006-01-02")))
-	}
-	if presentation.Theme != "" {
-		content.WriteString(fmt.Sprintf("theme: \"%s\"\n", presentation.Theme))
-	}
-	content.WriteString("exported: \"" + time.Now().Format("2006-01-02 15:04:05") + "\"\n")
-	content.WriteString("generator: \"slicli\"\n")
-	if options.IncludeMetadata && len(options.Metadata) > 0 {
-		for key, value := range options.Metadata {
-			content.WriteString(fmt.Sprintf("%s: \"%v\"\n", key, value))
-		}
-	}
-	content.WriteString("---\n\n")
-
-	// Write presentation title
-	content.WriteString(fmt.Sprintf("# %s\n\n", presentation.Title))
-
-	// Write metadata if present
-	if presentation.Author != "" || !presentation.Date.IsZero() {
-		content.WriteString("**Presentation Details:**\n")
-		if presentation.Author != "" {
-			content.WriteString(fmt.Sprintf("- **Author:** %s\n", presentation.Author))
+	if options.IncludeMetadata {
+		frontMatter, err := r.frontMatter(presentation, options.Metadata)
+		if err != nil {
+			return nil, err
 		}
-		if !presentation.Date.IsZero() {
-			content.WriteString(fmt.Sprintf("- **Date:** %s\n", presentation.Date.Format("January 2, 2006")))
-		}
-		content.WriteString(fmt.Sprintf("- **Slides:** %d\n", len(presentation.Slides)))
-		content.WriteString("\n---\n\n")
+		content.WriteString(frontMatter)
 	}
 
-	// Write slides
-	for i, slide := range presentation.Slides {
-		// Slide header
-		content.WriteString(fmt.Sprintf("## Slide %d\n\n", i+1))
-
-		// Convert HTML back to markdown (simplified)
+	slides := make([]string, 0, len(presentation.Slides))
+	for _, slide := range presentation.Slides {
 		slideMarkdown := r.htmlToMarkdown(slide.HTML)
-		content.WriteString(slideMarkdown)
-		content.WriteString("\n\n")
-
-		// Add speaker notes if included and present
-		if options.IncludeNotes && slide.Notes != "" {
-			content.WriteString("### Speaker Notes\n\n")
-			content.WriteString("> " + strings.ReplaceAll(slide.Notes, "\n", "\n> "))
-			content.WriteString("\n\n")
-		}
-
-		// Add slide separator except for last slide
-		if i < len(presentation.Slides)-1 {
-			content.WriteString("---\n\n")
+		if options.IncludeNotes && strings.TrimSpace(slide.Notes) != "" {
+			// A comment can't contain its own terminator
+			notes := strings.ReplaceAll(strings.TrimSpace(slide.Notes), "-->", "-- >")
+			slideMarkdown += "\n\n<!-- notes: " + notes + " -->"
 		}
+		slides = append(slides, slideMarkdown)
 	}
+	content.WriteString(strings.Join(slides, "\n\n"+entities.SlideSeparator+"\n\n"))
+	content.WriteString("\n")
 
-	// Write footer
-	content.WriteString("\n---\n\n*Exported from slicli on " + time.Now().Format("January 2, 2006 at 3:04 PM") + "*\n")
-
-	// Write to file
-	err := os.WriteFile(options.OutputPath, []byte(content.String()), 0600)
-	if err != nil {
+	if err := os.WriteFile(options.OutputPath, []byte(content.String()), 0600); err != nil {
 		return nil, fmt.Errorf("writing markdown file: %w", err)
 	}
 
@@ -103,176 +96,384 @@ func (r *MarkdownRenderer) Render(ctx context.Context, presentation *entities.Pr
 	}, nil
 }
 
-// htmlToMarkdown converts HTML content back to markdown (simplified conversion)
-func (r *MarkdownRenderer) htmlToMarkdown(html string) string {
-	content := html
-
-	// Replace HTML headers with markdown headers
-	content = strings.ReplaceAll(content, "<h1>", "# ")
-	content = strings.ReplaceAll(content, "</h1>", "\n")
-	content = strings.ReplaceAll(content, "<h2>", "## ")
-	content = strings.ReplaceAll(content, "</h2>", "\n")
-	content = strings.ReplaceAll(content, "<h3>", "### ")
-	content = strings.ReplaceAll(content, "</h3>", "\n")
-	content = strings.ReplaceAll(content, "<h4>", "#### ")
-	content = strings.ReplaceAll(content, "</h4>", "\n")
-	content = strings.ReplaceAll(content, "<h5>", "##### ")
-	content = strings.ReplaceAll(content, "</h5>", "\n")
-	content = strings.ReplaceAll(content, "<h6>", "###### ")
-	content = strings.ReplaceAll(content, "</h6>", "\n")
-
-	// Replace paragraphs
-	content = strings.ReplaceAll(content, "<p>", "")
-	content = strings.ReplaceAll(content, "</p>", "\n\n")
-
-	// Replace line breaks
-	content = strings.ReplaceAll(content, "<br>", "\n")
-	content = strings.ReplaceAll(content, "<br/>", "\n")
-	content = strings.ReplaceAll(content, "<br />", "\n")
-
-	// Replace emphasis
-	content = strings.ReplaceAll(content, "<em>", "*")
-	content = strings.ReplaceAll(content, "</em>", "*")
-	content = strings.ReplaceAll(content, "<i>", "*")
-	content = strings.ReplaceAll(content, "</i>", "*")
-
-	// Replace strong
-	content = strings.ReplaceAll(content, "<strong>", "**")
-	content = strings.ReplaceAll(content, "</strong>", "**")
-	content = strings.ReplaceAll(content, "<b>", "**")
-	content = strings.ReplaceAll(content, "</b>", "**")
-
-	// Replace code
-	content = strings.ReplaceAll(content, "<code>", "`")
-	content = strings.ReplaceAll(content, "</code>", "`")
-
-	// Replace preformatted blocks
-	content = strings.ReplaceAll(content, "<pre>", "```\n")
-	content = strings.ReplaceAll(content, "</pre>", "\n```")
-
-	// Replace lists (simplified)
-	content = strings.ReplaceAll(content, "<ul>", "")
-	content = strings.ReplaceAll(content, "</ul>", "\n")
-	content = strings.ReplaceAll(content, "<ol>", "")
-	content = strings.ReplaceAll(content, "</ol>", "\n")
-	content = strings.ReplaceAll(content, "<li>", "- ")
-	content = strings.ReplaceAll(content, "</li>", "\n")
-
-	// Replace blockquotes
-	content = strings.ReplaceAll(content, "<blockquote>", "> ")
-	content = strings.ReplaceAll(content, "</blockquote>", "\n")
-
-	// Replace horizontal rules
-	content = strings.ReplaceAll(content, "<hr>", "---")
-	content = strings.ReplaceAll(content, "<hr/>", "---")
-	content = strings.ReplaceAll(content, "<hr />", "---")
-
-	// Handle links (simplified)
-	content = r.convertLinks(content)
-
-	// Handle images (simplified)
-	content = r.convertImages(content)
-
-	// Clean up extra whitespace
-	content = strings.ReplaceAll(content, "\n\n\n", "\n\n")
-	content = strings.TrimSpace(content)
-
-	return content
+// frontMatter renders the presentation's title, author, date and theme, then
+// any extra export metadata, as a YAML front matter block
+func (r *MarkdownRenderer) frontMatter(presentation *entities.Presentation, metadata map[string]interface{}) (string, error) {
+	fields := markdownFrontMatter{
+		Title:  presentation.Title,
+		Author: presentation.Author,
+		Theme:  presentation.Theme,
+	}
+	if !presentation.Date.IsZero() {
+		fields.Date = presentation.Date.Format("2006-01-02")
+	}
+
+	encoded, err := yaml.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("encoding front matter: %w", err)
+	}
+
+	extra := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		switch key {
+		case "title", "author", "date", "theme":
+		default:
+			extra[key] = value
+		}
+	}
+	if len(extra) > 0 {
+		encodedExtra, err := yaml.Marshal(extra)
+		if err != nil {
+			return "", fmt.Errorf("encoding front matter: %w", err)
+		}
+		encoded = append(encoded, encodedExtra...)
+	}
+
+	if strings.TrimSpace(string(encoded)) == "{}" {
+		encoded = nil
+	}
+	return "---\n" + string(encoded) + "---\n\n", nil
 }
 
-// convertLinks converts HTML links to markdown format
-func (r *MarkdownRenderer) convertLinks(content string) string {
-	// Simple regex replacement would be better, but for now use basic string manipulation
-	// This is a simplified implementation
-	result := content
-
-	// Look for <a href="url">text</a> patterns and convert to [text](url)
-	for {
-		start := strings.Index(result, "<a href=\"")
-		if start == -1 {
-			break
+// htmlToMarkdown converts a slide's rendered HTML back into GitHub-flavored markdown
+func (r *MarkdownRenderer) htmlToMarkdown(content string) string {
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(content), body)
+	if err != nil {
+		return strings.TrimSpace(content)
+	}
+	return strings.TrimSpace(blocksToMarkdown(nodes))
+}
+
+// childNodes lists the children of an HTML node
+func childNodes(n *html.Node) []*html.Node {
+	var children []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		children = append(children, c)
+	}
+	return children
+}
+
+// attr returns the value of an attribute, or "" when it is missing
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
 		}
+	}
+	return ""
+}
 
-		urlStart := start + 9
-		urlEnd := strings.Index(result[urlStart:], "\"")
-		if urlEnd == -1 {
-			break
+// blocksToMarkdown renders sibling nodes as blank-line separated blocks,
+// gathering runs of inline content into paragraphs
+func blocksToMarkdown(nodes []*html.Node) string {
+	var blocks []string
+	var inline []*html.Node
+	flush := func() {
+		if text := strings.TrimSpace(inlineToMarkdown(inline)); text != "" {
+			blocks = append(blocks, escapeBlockStart(text))
 		}
-		urlEnd += urlStart
+		inline = nil
+	}
+
+	for _, n := range nodes {
+		if n.Type != html.CommentNode && !(n.Type == html.ElementNode && blockElements[n.DataAtom]) {
+			inline = append(inline, n)
+			continue
+		}
+		flush()
+		if block := blockToMarkdown(n); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	flush()
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// blockToMarkdown renders one block-level node
+func blockToMarkdown(n *html.Node) string {
+	if n.Type == html.CommentNode {
+		return "<!--" + n.Data + "-->"
+	}
 
-		textStart := strings.Index(result[urlEnd:], ">")
-		if textStart == -1 {
-			break
+	switch n.DataAtom {
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		return strings.Repeat("#", level) + " " + strings.TrimSpace(inlineToMarkdown(childNodes(n)))
+	case atom.P:
+		return escapeBlockStart(strings.TrimSpace(inlineToMarkdown(childNodes(n))))
+	case atom.Ul, atom.Ol:
+		return listToMarkdown(n)
+	case atom.Pre:
+		return codeBlockToMarkdown(n)
+	case atom.Blockquote:
+		lines := strings.Split(blocksToMarkdown(childNodes(n)), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
 		}
-		textStart += urlEnd + 1
+		return strings.Join(lines, "\n")
+	case atom.Table:
+		return tableToMarkdown(n)
+	case atom.Hr:
+		// --- would be read as a slide separator
+		return "***"
+	default:
+		return blocksToMarkdown(childNodes(n))
+	}
+}
 
-		textEnd := strings.Index(result[textStart:], "</a>")
-		if textEnd == -1 {
-			break
+// listToMarkdown renders a list, indenting each item's continuation lines
+// under its marker so nested lists and paragraphs stay in the item
+func listToMarkdown(n *html.Node) string {
+	number := 1
+	if start, err := strconv.Atoi(attr(n, "start")); err == nil {
+		number = start
+	}
+
+	var items []*html.Node
+	loose := false
+	for _, c := range childNodes(n) {
+		if c.Type != html.ElementNode || c.DataAtom != atom.Li {
+			continue
+		}
+		items = append(items, c)
+		for _, gc := range childNodes(c) {
+			if gc.Type == html.ElementNode && gc.DataAtom == atom.P {
+				loose = true
+			}
 		}
-		textEnd += textStart
+	}
 
-		url := result[urlStart:urlEnd]
-		text := result[textStart:textEnd]
+	separator := "\n"
+	if loose {
+		separator = "\n\n"
+	}
 
-		markdown := fmt.Sprintf("[%s](%s)", text, url)
-		result = result[:start] + markdown + result[textEnd+4:]
+	rendered := make([]string, 0, len(items))
+	for _, item := range items {
+		marker := "- "
+		if n.DataAtom == atom.Ol {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+
+		body := blocksToMarkdown(childNodes(item))
+		if !loose {
+			body = strings.ReplaceAll(body, "\n\n", "\n")
+		}
+		indent := strings.Repeat(" ", len(marker))
+		lines := strings.Split(body, "\n")
+		for i := 1; i < len(lines); i++ {
+			if lines[i] != "" {
+				lines[i] = indent + lines[i]
+			}
+		}
+		rendered = append(rendered, marker+strings.Join(lines, "\n"))
 	}
 
-	return result
+	return strings.Join(rendered, separator)
 }
 
-// convertImages converts HTML images to markdown format
-func (r *MarkdownRenderer) convertImages(content string) string {
-	// Simple conversion for <img src="url" alt="text"> to ![text](url)
-	result := content
+// codeBlockToMarkdown renders a <pre> block as a fenced code block, keeping
+// the language goldmark recorded as a language-* class
+func codeBlockToMarkdown(n *html.Node) string {
+	source := n
+	language := ""
+	for _, c := range childNodes(n) {
+		if c.Type == html.ElementNode && c.DataAtom == atom.Code {
+			source = c
+			for _, class := range strings.Fields(attr(c, "class")) {
+				if strings.HasPrefix(class, "language-") {
+					language = strings.TrimPrefix(class, "language-")
+				}
+			}
+		}
+	}
+
+	code := strings.TrimSuffix(textContent(source), "\n")
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + language + "\n" + code + "\n" + fence
+}
 
-	for {
-		start := strings.Index(result, "<img")
-		if start == -1 {
-			break
+// tableToMarkdown renders a table as a GFM pipe table, taking the first row
+// as the header and its cells' alignment for the delimiter row
+func tableToMarkdown(n *html.Node) string {
+	var rows [][]*html.Node
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		for _, c := range childNodes(node) {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			if c.DataAtom == atom.Tr {
+				var cells []*html.Node
+				for _, cell := range childNodes(c) {
+					if cell.Type == html.ElementNode && (cell.DataAtom == atom.Th || cell.DataAtom == atom.Td) {
+						cells = append(cells, cell)
+					}
+				}
+				rows = append(rows, cells)
+				continue
+			}
+			walk(c)
 		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
 
-		end := strings.Index(result[start:], ">")
-		if end == -1 {
-			break
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
 		}
-		end += start + 1
+	}
 
-		imgTag := result[start:end]
+	formatRow := func(cells []*html.Node) string {
+		values := make([]string, columns)
+		for i, cell := range cells {
+			text := strings.TrimSpace(inlineToMarkdown(childNodes(cell)))
+			values[i] = strings.ReplaceAll(text, "|", `\|`)
+		}
+		return "| " + strings.Join(values, " | ") + " |"
+	}
 
-		// Extract src
-		srcStart := strings.Index(imgTag, "src=\"")
-		if srcStart == -1 {
-			result = result[:start] + result[end:]
+	delimiters := make([]string, columns)
+	for i := range delimiters {
+		delimiters[i] = "---"
+		if i >= len(rows[0]) {
 			continue
 		}
-		srcStart += 5
-		srcEnd := strings.Index(imgTag[srcStart:], "\"")
-		if srcEnd == -1 {
-			result = result[:start] + result[end:]
-			continue
+		align := attr(rows[0][i], "align")
+		if match := textAlign.FindStringSubmatch(attr(rows[0][i], "style")); match != nil {
+			align = match[1]
+		}
+		switch align {
+		case "left":
+			delimiters[i] = ":---"
+		case "center":
+			delimiters[i] = ":---:"
+		case "right":
+			delimiters[i] = "---:"
+		}
+	}
+
+	lines := []string{formatRow(rows[0]), "| " + strings.Join(delimiters, " | ") + " |"}
+	for _, row := range rows[1:] {
+		lines = append(lines, formatRow(row))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// inlineToMarkdown renders phrasing content: text, emphasis, code, links and images
+func inlineToMarkdown(nodes []*html.Node) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(markdownEscaper.Replace(whitespaceRun.ReplaceAllString(n.Data, " ")))
+		case html.CommentNode:
+			b.WriteString("<!--" + n.Data + "-->")
+		case html.ElementNode:
+			b.WriteString(inlineElementToMarkdown(n))
+		}
+	}
+	// The newline after a <br> collapses to a space that would indent the next line
+	return strings.ReplaceAll(b.String(), "\\\n ", "\\\n")
+}
+
+// inlineElementToMarkdown renders a single inline element
+func inlineElementToMarkdown(n *html.Node) string {
+	switch n.DataAtom {
+	case atom.Em, atom.I:
+		return wrapInline("*", inlineToMarkdown(childNodes(n)))
+	case atom.Strong, atom.B:
+		return wrapInline("**", inlineToMarkdown(childNodes(n)))
+	case atom.Del, atom.S, atom.Strike:
+		return wrapInline("~~", inlineToMarkdown(childNodes(n)))
+	case atom.Code:
+		code := textContent(n)
+		fence := "`"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+			code = " " + code + " "
+		}
+		return fence + code + fence
+	case atom.A:
+		return "[" + inlineToMarkdown(childNodes(n)) + "](" + linkTarget(attr(n, "href"), attr(n, "title")) + ")"
+	case atom.Img:
+		return "![" + markdownEscaper.Replace(attr(n, "alt")) + "](" + linkTarget(attr(n, "src"), attr(n, "title")) + ")"
+	case atom.Br:
+		return "\\\n"
+	case atom.Input:
+		if attr(n, "type") != "checkbox" {
+			return ""
 		}
-		srcEnd += srcStart
-		src := imgTag[srcStart:srcEnd]
-
-		// Extract alt
-		alt := ""
-		altStart := strings.Index(imgTag, "alt=\"")
-		if altStart != -1 {
-			altStart += 5
-			altEnd := strings.Index(imgTag[altStart:], "\"")
-			if altEnd != -1 {
-				altEnd += altStart
-				alt = imgTag[altStart:altEnd]
+		for _, a := range n.Attr {
+			if a.Key == "checked" {
+				return "[x]"
 			}
 		}
+		return "[ ]"
+	default:
+		return inlineToMarkdown(childNodes(n))
+	}
+}
 
-		markdown := fmt.Sprintf("![%s](%s)", alt, src)
-		result = result[:start] + markdown + result[end:]
+// wrapInline surrounds text with an emphasis delimiter, keeping surrounding
+// spaces outside since markdown won't close a delimiter after a space
+func wrapInline(delimiter, text string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
 	}
+	leading := text[:len(text)-len(strings.TrimLeft(text, " "))]
+	trailing := text[len(strings.TrimRight(text, " ")):]
+	return leading + delimiter + trimmed + delimiter + trailing
+}
+
+// linkTarget formats a link or image destination with its optional title
+func linkTarget(url, title string) string {
+	if strings.ContainsAny(url, " ()") {
+		url = "<" + url + ">"
+	}
+	if title == "" {
+		return url
+	}
+	return url + ` "` + strings.ReplaceAll(title, `"`, `\"`) + `"`
+}
 
-	return result
+// escapeBlockStart keeps paragraph text from being read as another block type
+func escapeBlockStart(text string) string {
+	match := blockMarker.FindString(text)
+	if match == "" {
+		return text
+	}
+	// Only punctuation can be escaped, so an ordered list marker escapes its
+	// period or parenthesis rather than the number
+	if i := strings.IndexAny(match, ".)"); i > 0 && match[0] >= '0' && match[0] <= '9' {
+		return text[:i] + `\` + text[i:]
+	}
+	return `\` + text
+}
+
+// textContent returns the raw text inside a node
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(textContent(c))
+	}
+	return b.String()
 }
 
 // Supports returns true if this renderer supports the given format
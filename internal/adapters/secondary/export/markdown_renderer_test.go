@@ -0,0 +1,158 @@
+package export
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/parser"
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+const roundTripMarkdown = `---
+title: Platform Update
+author: Dana
+date: "2024-03-01"
+theme: dark
+---
+
+# Platform Update
+
+Status of the *platform* work for **Q1**
+
+Note: Welcome everyone
+
+---
+
+# Progress
+
+- Migrated the build
+  - Cut CI time in half
+- Shipped ` + "`v2`" + ` of the API
+- [x] Done item
+- [ ] Open item
+
+1. First
+2. Second
+
+---
+
+## Code
+
+` + "```go" + `
+func main() {
+	fmt.Println("hi")
+}
+` + "```" + `
+
+---
+
+# Numbers
+
+| Service | Latency | Status |
+| :--- | :---: | ---: |
+| api | 120ms | ok |
+| web | 80ms | ~~slow~~ ok |
+
+---
+
+<!-- layout: quote -->
+
+> Ship small, ship often
+
+See [the handbook](https://example.com/handbook "Handbook") and ![diagram](img/arch.png)
+
+***
+
+Trailing paragraph`
+
+func parsePresentation(t *testing.T, markdown string) *entities.Presentation {
+	t.Helper()
+	presentation, err := parser.NewPresentationParserAdapter(parser.NewGoldmarkParser()).Parse([]byte(markdown))
+	require.NoError(t, err)
+	return presentation
+}
+
+func exportMarkdown(t *testing.T, presentation *entities.Presentation, options *ExportOptions) string {
+	t.Helper()
+	options.Format = FormatMarkdown
+	options.OutputPath = filepath.Join(t.TempDir(), "deck.md")
+
+	result, err := NewMarkdownRenderer().Render(context.Background(), presentation, options)
+	require.NoError(t, err)
+	assert.Equal(t, len(presentation.Slides), result.PageCount)
+
+	data, err := os.ReadFile(options.OutputPath) // #nosec G304 - test file
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestMarkdownRenderer_RoundTrip(t *testing.T) {
+	original := parsePresentation(t, roundTripMarkdown)
+	exported := exportMarkdown(t, original, &ExportOptions{IncludeMetadata: true})
+
+	reparsed := parsePresentation(t, exported)
+	require.Len(t, reparsed.Slides, len(original.Slides))
+	for i := range original.Slides {
+		assert.Equal(t, original.Slides[i].Title, reparsed.Slides[i].Title, "slide %d", i+1)
+		assert.Equal(t, original.Slides[i].HTML, reparsed.Slides[i].HTML, "slide %d", i+1)
+	}
+	assert.Equal(t, original.Title, reparsed.Title)
+	assert.Equal(t, original.Author, reparsed.Author)
+	assert.Equal(t, original.Date, reparsed.Date)
+	assert.Equal(t, original.Theme, reparsed.Theme)
+
+	assert.Equal(t, exported, exportMarkdown(t, reparsed, &ExportOptions{IncludeMetadata: true}),
+		"exporting the re-parsed deck should be stable")
+}
+
+func TestMarkdownRenderer_Structure(t *testing.T) {
+	exported := exportMarkdown(t, parsePresentation(t, roundTripMarkdown), &ExportOptions{})
+
+	assert.NotContains(t, exported, "title: Platform Update", "front matter is only written with metadata")
+	assert.Equal(t, 5, len(entities.SplitSlides(exported)))
+
+	for _, want := range []string{
+		"# Platform Update\n\nStatus of the *platform* work for **Q1**",
+		"- Migrated the build\n  - Cut CI time in half\n- Shipped `v2` of the API\n- [x] Done item\n- [ ] Open item",
+		"1. First\n2. Second",
+		"```go\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n```",
+		"| Service | Latency | Status |\n| :--- | :---: | ---: |\n| api | 120ms | ok |\n| web | 80ms | ~~slow~~ ok |",
+		"<!-- layout: quote -->",
+		"> Ship small, ship often",
+		`[the handbook](https://example.com/handbook "Handbook")`,
+		"![diagram](img/arch.png)",
+		"***",
+	} {
+		assert.Contains(t, exported, want)
+	}
+	assert.NotContains(t, exported, "<p>")
+	assert.NotContains(t, exported, "<!-- notes:")
+}
+
+func TestMarkdownRenderer_Notes(t *testing.T) {
+	presentation := &entities.Presentation{
+		Title: "Notes",
+		Slides: []entities.Slide{
+			{HTML: "<h1>One</h1>", Notes: "Mention the --> arrow"},
+			{HTML: "<h1>Two</h1>"},
+		},
+	}
+
+	exported := exportMarkdown(t, presentation, &ExportOptions{IncludeNotes: true})
+	assert.Equal(t, "# One\n\n<!-- notes: Mention the -- > arrow -->\n\n---\n\n# Two\n", exported)
+}
+
+func TestMarkdownRenderer_Escaping(t *testing.T) {
+	r := NewMarkdownRenderer()
+	assert.Equal(t, `\# not a heading`, r.htmlToMarkdown("<p># not a heading</p>"))
+	assert.Equal(t, `1\. not a list`, r.htmlToMarkdown("<p>1. not a list</p>"))
+	assert.Equal(t, `\- not a list`, r.htmlToMarkdown("<p>- not a list</p>"))
+	assert.Equal(t, `a \*literal\* \_star\_`, r.htmlToMarkdown("<p>a *literal* _star_</p>"))
+	assert.Equal(t, "line one\\\nline two", r.htmlToMarkdown("<p>line one<br>\nline two</p>"))
+	assert.Equal(t, "````\nuse ``` fences\n````", r.htmlToMarkdown("<pre><code>use ``` fences\n</code></pre>"))
+}
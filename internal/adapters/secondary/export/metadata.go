@@ -0,0 +1,285 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+const (
+	maxMetadataValueLength = 512
+	maxKeywordLength       = 64
+	maxKeywords            = 32
+)
+
+// DocumentMetadata holds the document properties embedded in exported files
+type DocumentMetadata struct {
+	Title     string
+	Author    string
+	Subject   string
+	Keywords  []string
+	CreatedAt time.Time
+}
+
+// KeywordList returns the keywords as a comma-separated list
+func (m *DocumentMetadata) KeywordList() string {
+	return strings.Join(m.Keywords, ", ")
+}
+
+// newDocumentMetadata builds sanitized document metadata from the presentation,
+// letting ExportOptions.Metadata override individual fields.
+func newDocumentMetadata(presentation *entities.Presentation, options *ExportOptions) *DocumentMetadata {
+	meta := &DocumentMetadata{
+		Title:     sanitizeMetadataValue(presentation.Title, maxMetadataValueLength),
+		Author:    sanitizeMetadataValue(presentation.Author, maxMetadataValueLength),
+		CreatedAt: presentation.Date,
+	}
+
+	sources := []map[string]interface{}{presentation.Metadata, options.Metadata}
+	for _, source := range sources {
+		if title, ok := source["title"].(string); ok && title != "" {
+			meta.Title = sanitizeMetadataValue(title, maxMetadataValueLength)
+		}
+		if author, ok := source["author"].(string); ok && author != "" {
+			meta.Author = sanitizeMetadataValue(author, maxMetadataValueLength)
+		}
+		for _, key := range []string{"description", "subject"} {
+			if subject, ok := source[key].(string); ok && subject != "" {
+				meta.Subject = sanitizeMetadataValue(subject, maxMetadataValueLength)
+			}
+		}
+		for _, key := range []string{"tags", "keywords"} {
+			if keywords := metadataKeywords(source[key]); len(keywords) > 0 {
+				meta.Keywords = keywords
+			}
+		}
+	}
+
+	if meta.CreatedAt.IsZero() {
+		meta.CreatedAt = time.Now()
+	}
+
+	return meta
+}
+
+// validateMetadata checks that well-known metadata keys have usable types
+func validateMetadata(metadata map[string]interface{}) error {
+	for _, key := range []string{"title", "author", "subject", "description"} {
+		value, exists := metadata[key]
+		if !exists {
+			continue
+		}
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", key)
+		}
+		if !utf8.ValidString(s) {
+			return fmt.Errorf("%s is not valid UTF-8", key)
+		}
+	}
+
+	for _, key := range []string{"keywords", "tags"} {
+		value, exists := metadata[key]
+		if !exists {
+			continue
+		}
+		switch v := value.(type) {
+		case string, []string:
+		case []interface{}:
+			for _, item := range v {
+				if _, ok := item.(string); !ok {
+					return fmt.Errorf("%s must contain only strings", key)
+				}
+			}
+		default:
+			return fmt.Errorf("%s must be a string or a list of strings", key)
+		}
+	}
+
+	return nil
+}
+
+// metadataKeywords normalizes a comma-separated string or list into sanitized keywords
+func metadataKeywords(value interface{}) []string {
+	var raw []string
+	switch v := value.(type) {
+	case string:
+		raw = strings.Split(v, ",")
+	case []string:
+		raw = v
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				raw = append(raw, s)
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var keywords []string
+	for _, keyword := range raw {
+		// Commas separate keywords in PDF and HTML, so they can't appear inside one
+		keyword = sanitizeMetadataValue(strings.ReplaceAll(keyword, ",", " "), maxKeywordLength)
+		if keyword == "" || seen[strings.ToLower(keyword)] {
+			continue
+		}
+		seen[strings.ToLower(keyword)] = true
+		keywords = append(keywords, keyword)
+		if len(keywords) == maxKeywords {
+			break
+		}
+	}
+
+	return keywords
+}
+
+// sanitizeMetadataValue strips control characters, collapses whitespace and
+// truncates the value to maxLen runes.
+func sanitizeMetadataValue(value string, maxLen int) string {
+	value = strings.ToValidUTF8(value, "")
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, value)
+	value = strings.Join(strings.Fields(value), " ")
+
+	if utf8.RuneCountInString(value) > maxLen {
+		runes := []rune(value)
+		value = strings.TrimSpace(string(runes[:maxLen]))
+	}
+
+	return value
+}
+
+var (
+	pdfStartXrefPattern = regexp.MustCompile(`startxref\s+(\d+)`)
+	pdfRootPattern      = regexp.MustCompile(`/Root\s+(\d+\s+\d+\s+R)`)
+	pdfSizePattern      = regexp.MustCompile(`/Size\s+(\d+)`)
+	pdfIDPattern        = regexp.MustCompile(`/ID\s*\[[^\]]*\]`)
+)
+
+// embedPDFInfo appends an incremental update to the PDF at path that replaces
+// its document information dictionary. It only supports PDFs with a classic
+// cross-reference table, which is what Chrome produces.
+func embedPDFInfo(path string, meta *DocumentMetadata) error {
+	cleanPath := filepath.Clean(path)
+	data, err := os.ReadFile(cleanPath) // #nosec G304 - path is the export output path
+	if err != nil {
+		return fmt.Errorf("reading PDF: %w", err)
+	}
+
+	startXref := bytes.LastIndex(data, []byte("startxref"))
+	trailerStart := bytes.LastIndex(data, []byte("trailer"))
+	if startXref < 0 || trailerStart < 0 || trailerStart > startXref {
+		return errors.New("PDF has no classic trailer")
+	}
+
+	prevMatch := pdfStartXrefPattern.FindSubmatch(data[startXref:])
+	trailer := data[trailerStart:startXref]
+	rootMatch := pdfRootPattern.FindSubmatch(trailer)
+	sizeMatch := pdfSizePattern.FindSubmatch(trailer)
+	if prevMatch == nil || rootMatch == nil || sizeMatch == nil {
+		return errors.New("PDF trailer is missing required entries")
+	}
+
+	size, err := strconv.Atoi(string(sizeMatch[1]))
+	if err != nil {
+		return fmt.Errorf("parsing PDF trailer size: %w", err)
+	}
+
+	var update bytes.Buffer
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		update.WriteByte('\n')
+	}
+
+	infoOffset := len(data) + update.Len()
+	fmt.Fprintf(&update, "%d 0 obj\n%s\nendobj\n", size, pdfInfoDictionary(meta))
+
+	xrefOffset := len(data) + update.Len()
+	fmt.Fprintf(&update, "xref\n%d 1\n%010d 00000 n \n", size, infoOffset)
+	fmt.Fprintf(&update, "trailer\n<< /Size %d /Root %s /Info %d 0 R /Prev %s", size+1, rootMatch[1], size, prevMatch[1])
+	if id := pdfIDPattern.Find(trailer); id != nil {
+		update.WriteString(" ")
+		update.Write(id)
+	}
+	fmt.Fprintf(&update, " >>\nstartxref\n%d\n%%%%EOF\n", xrefOffset)
+
+	file, err := os.OpenFile(cleanPath, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("opening PDF: %w", err)
+	}
+	if _, err := file.Write(update.Bytes()); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("writing PDF metadata: %w", err)
+	}
+	return file.Close()
+}
+
+// pdfInfoDictionary renders meta as a PDF document information dictionary
+func pdfInfoDictionary(meta *DocumentMetadata) string {
+	var b strings.Builder
+	b.WriteString("<<")
+	entries := []struct{ key, value string }{
+		{"Title", meta.Title},
+		{"Author", meta.Author},
+		{"Subject", meta.Subject},
+		{"Keywords", meta.KeywordList()},
+		{"Creator", "slicli"},
+	}
+	for _, entry := range entries {
+		if entry.value != "" {
+			fmt.Fprintf(&b, " /%s %s", entry.key, pdfTextString(entry.value))
+		}
+	}
+	fmt.Fprintf(&b, " /CreationDate (%s)", pdfDate(meta.CreatedAt))
+	b.WriteString(" >>")
+	return b.String()
+}
+
+// pdfTextString encodes s as a PDF text string, using UTF-16BE for non-ASCII text
+func pdfTextString(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			ascii = false
+			break
+		}
+	}
+
+	if ascii {
+		replacer := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+		return "(" + replacer.Replace(s) + ")"
+	}
+
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, unit := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", unit)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// pdfDate formats t as a PDF date string
+func pdfDate(t time.Time) string {
+	_, offset := t.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("D:%s%c%02d'%02d'", t.Format("20060102150405"), sign, offset/3600, (offset%3600)/60)
+}
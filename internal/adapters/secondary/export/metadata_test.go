@@ -0,0 +1,252 @@
+package export
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testInfoRefPattern   = regexp.MustCompile(`/Info\s+(\d+)\s+0\s+R`)
+	testInfoEntryPattern = regexp.MustCompile(`/(\w+)\s*(\((?:\\.|[^\\)])*\)|<[0-9A-Fa-f]*>)`)
+)
+
+// readPDFInfo extracts the document information dictionary referenced by the last trailer
+func readPDFInfo(t *testing.T, path string) map[string]string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	trailer := data[strings.LastIndex(string(data), "trailer"):]
+	ref := testInfoRefPattern.FindSubmatch(trailer)
+	require.NotNil(t, ref, "PDF trailer should reference an info dictionary")
+
+	objects := regexp.MustCompile(`(?s)\n`+string(ref[1])+` 0 obj\s*(<<.*?>>)\s*endobj`).FindAllSubmatch(data, -1)
+	require.NotEmpty(t, objects, "info dictionary object should exist")
+	dict := objects[len(objects)-1][1]
+
+	info := make(map[string]string)
+	for _, entry := range testInfoEntryPattern.FindAllSubmatch(dict, -1) {
+		info[string(entry[1])] = decodePDFString(t, string(entry[2]))
+	}
+	return info
+}
+
+// decodePDFString decodes a literal or hex PDF string, handling UTF-16BE text
+func decodePDFString(t *testing.T, s string) string {
+	var raw []byte
+	if strings.HasPrefix(s, "<") {
+		var err error
+		raw, err = hex.DecodeString(s[1 : len(s)-1])
+		require.NoError(t, err)
+	} else {
+		body := s[1 : len(s)-1]
+		for i := 0; i < len(body); i++ {
+			if body[i] == '\\' && i+1 < len(body) {
+				i++
+				switch body[i] {
+				case 'r':
+					raw = append(raw, '\r')
+				case 'n':
+					raw = append(raw, '\n')
+				default:
+					raw = append(raw, body[i])
+				}
+				continue
+			}
+			raw = append(raw, body[i])
+		}
+	}
+
+	if len(raw) >= 2 && raw[0] == 0xFE && raw[1] == 0xFF {
+		units := make([]uint16, 0, (len(raw)-2)/2)
+		for i := 2; i+1 < len(raw); i += 2 {
+			units = append(units, uint16(raw[i])<<8|uint16(raw[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+	return string(raw)
+}
+
+func metadataPresentation() *entities.Presentation {
+	return &entities.Presentation{
+		Title:  "Quarterly Review",
+		Author: "José Müller",
+		Date:   time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC),
+		Metadata: map[string]interface{}{
+			"tags": []interface{}{"finance", "q1"},
+		},
+		Slides: []entities.Slide{
+			{Title: "Intro", HTML: "<h1>Intro</h1><p>Numbers</p>"},
+		},
+	}
+}
+
+func TestPDFRenderer_EmbedsMetadata(t *testing.T) {
+	presentation := metadataPresentation()
+	outputPath := filepath.Join(t.TempDir(), "review.pdf")
+
+	renderer := &PDFRenderer{htmlRenderer: NewHTMLRenderer()}
+	_, err := renderer.Render(context.Background(), presentation, &ExportOptions{
+		Format:          FormatPDF,
+		OutputPath:      outputPath,
+		IncludeMetadata: true,
+		Metadata: map[string]interface{}{
+			"subject": "Results for\nthe first quarter",
+		},
+	})
+	require.NoError(t, err)
+
+	info := readPDFInfo(t, outputPath)
+	assert.Equal(t, presentation.Title, info["Title"])
+	assert.Equal(t, presentation.Author, info["Author"])
+	assert.Equal(t, "Results for the first quarter", info["Subject"])
+	assert.Equal(t, "finance, q1", info["Keywords"])
+	assert.Contains(t, info["CreationDate"], "D:20240315")
+}
+
+func TestPDFRenderer_OmitsMetadataByDefault(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "review.pdf")
+
+	renderer := &PDFRenderer{htmlRenderer: NewHTMLRenderer()}
+	_, err := renderer.Render(context.Background(), metadataPresentation(), &ExportOptions{
+		Format:     FormatPDF,
+		OutputPath: outputPath,
+	})
+	require.NoError(t, err)
+
+	info := readPDFInfo(t, outputPath)
+	assert.Empty(t, info["Title"])
+	assert.Empty(t, info["Author"])
+}
+
+func TestPDFRenderer_KeepsChromePDFWhenMetadataFails(t *testing.T) {
+	// Fake Chrome's PDF has no trailer to add the info dictionary to
+	chrome, _ := fakeChrome(t, 0)
+	renderer, err := NewPDFRendererWithBrowser(BrowserConfig{ExecutablePath: chrome, WarmupAttempts: -1})
+	require.NoError(t, err)
+
+	outputPath := filepath.Join(t.TempDir(), "review.pdf")
+	result, err := renderer.Render(context.Background(), metadataPresentation(), &ExportOptions{
+		Format:          FormatPDF,
+		OutputPath:      outputPath,
+		IncludeMetadata: true,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, result.Metadata, "dpi", "printed by Chrome, not the text-only fallback")
+
+	data, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4\n", string(data))
+}
+
+func TestEmbedPDFInfo(t *testing.T) {
+	// Produce a PDF without metadata, as Chrome would, then update it in place
+	outputPath := filepath.Join(t.TempDir(), "chrome.pdf")
+	renderer := &PDFRenderer{htmlRenderer: NewHTMLRenderer()}
+	_, err := renderer.Render(context.Background(), metadataPresentation(), &ExportOptions{
+		Format:     FormatPDF,
+		OutputPath: outputPath,
+	})
+	require.NoError(t, err)
+
+	meta := newDocumentMetadata(metadataPresentation(), &ExportOptions{
+		Metadata: map[string]interface{}{"title": "Review (final)", "keywords": "a, b"},
+	})
+	require.NoError(t, embedPDFInfo(outputPath, meta))
+
+	info := readPDFInfo(t, outputPath)
+	assert.Equal(t, "Review (final)", info["Title"])
+	assert.Equal(t, "José Müller", info["Author"])
+	assert.Equal(t, "a, b", info["Keywords"])
+
+	data, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), "%%EOF\n"))
+}
+
+func TestEmbedPDFInfo_RejectsNonPDF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "not.pdf")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0o644))
+
+	err := embedPDFInfo(path, &DocumentMetadata{Title: "x"})
+	assert.Error(t, err)
+}
+
+func TestHTMLRenderer_MetadataTags(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "review.html")
+
+	_, err := NewHTMLRenderer().Render(context.Background(), metadataPresentation(), &ExportOptions{
+		Format:          FormatHTML,
+		OutputPath:      outputPath,
+		IncludeMetadata: true,
+		Metadata: map[string]interface{}{
+			"description": `Q1 "results"`,
+			"keywords":    []string{"finance", "Finance", "growth"},
+		},
+	})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	html := string(content)
+
+	assert.Contains(t, html, `<meta name="author" content="José Müller">`)
+	assert.Contains(t, html, `<meta name="description" content="Q1 &#34;results&#34;">`)
+	assert.Contains(t, html, `<meta name="keywords" content="finance, growth">`)
+	assert.Contains(t, html, `<meta name="dcterms.created" content="2024-03-15T10:00:00Z">`)
+}
+
+func TestSanitizeMetadataValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		maxLen   int
+		expected string
+	}{
+		{"trims and collapses whitespace", "  a \t b\n\nc  ", 100, "a b c"},
+		{"strips control characters", "evil\x00title\x1b[31m", 100, "evil title [31m"},
+		{"drops invalid UTF-8", "ok\xff\xfe", 100, "ok"},
+		{"truncates on rune boundary", "ééééé", 3, "ééé"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, sanitizeMetadataValue(tt.input, tt.maxLen))
+		})
+	}
+}
+
+func TestValidateMetadata(t *testing.T) {
+	assert.NoError(t, validateMetadata(nil))
+	assert.NoError(t, validateMetadata(map[string]interface{}{
+		"title":    "Title",
+		"keywords": []interface{}{"a", "b"},
+		"custom":   42,
+	}))
+	assert.Error(t, validateMetadata(map[string]interface{}{"author": 42}))
+	assert.Error(t, validateMetadata(map[string]interface{}{"keywords": []interface{}{"a", 1}}))
+	assert.Error(t, validateMetadata(map[string]interface{}{"tags": true}))
+
+	service, err := NewService(t.TempDir())
+	require.NoError(t, err)
+	err = service.validateOptionsDetailed(&ExportOptions{
+		Format:     FormatPDF,
+		OutputPath: "out.pdf",
+		Metadata:   map[string]interface{}{"title": []int{1}},
+	})
+	var exportErr *ExportError
+	require.ErrorAs(t, err, &exportErr)
+	assert.Equal(t, "INVALID_METADATA", exportErr.Code)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -72,22 +73,30 @@ func (r *PDFRenderer) Render(ctx context.Context, presentation *entities.Present
 
 	// Prepare HTML export options
 	htmlOptions := &ExportOptions{
-		Format:          FormatHTML,
-		OutputPath:      tmpFile.Name(),
-		Theme:           options.Theme,
-		IncludeNotes:    options.IncludeNotes,
-		IncludeMetadata: options.IncludeMetadata,
-		Metadata:        options.Metadata,
+		Format:           FormatHTML,
+		OutputPath:       tmpFile.Name(),
+		Theme:            options.Theme,
+		IncludeNotes:     options.IncludeNotes,
+		IncludeMetadata:  options.IncludeMetadata,
+		Metadata:         options.Metadata,
+		ThemeFonts:       options.ThemeFonts,
+		InlineFonts:      options.InlineFonts,
+		InlineFontsLimit: options.InlineFontsLimit,
 	}
 
 	// Generate HTML first
-	_, err = r.htmlRenderer.Render(ctx, presentation, htmlOptions)
+	htmlResult, err := r.htmlRenderer.Render(ctx, presentation, htmlOptions)
 	if err != nil {
 		return nil, fmt.Errorf("generating HTML for PDF conversion: %w", err)
 	}
 
+	var meta *DocumentMetadata
+	if options.IncludeMetadata {
+		meta = newDocumentMetadata(presentation, options)
+	}
+
 	// Convert HTML to PDF using browser automation or external tool
-	err = r.convertHTMLToPDF(tmpFile.Name(), options.OutputPath, options)
+	printedByBrowser, err := r.convertHTMLToPDF(ctx, tmpFile.Name(), options.OutputPath, options, meta)
 	if err != nil {
 		return nil, fmt.Errorf("converting HTML to PDF: %w", err)
 	}
@@ -95,53 +104,100 @@ func (r *PDFRenderer) Render(ctx context.Context, presentation *entities.Present
 	// Get file size
 	fileSize, _ := GetFileSize(options.OutputPath)
 
+	quality := pdfQualitySettings(options.Quality)
+	metadata := map[string]interface{}{
+		"quality": qualityName(options.Quality),
+	}
+	// The fallback writes vector text only, so there is no raster resolution to report
+	if printedByBrowser {
+		metadata["dpi"] = quality.DPI
+	}
+
 	return &ExportResult{
 		Success:    true,
 		Format:     string(FormatPDF),
 		OutputPath: options.OutputPath,
 		FileSize:   fileSize,
 		PageCount:  len(presentation.Slides),
+		Warnings:   htmlResult.Warnings,
+		Metadata:   metadata,
 	}, nil
 }
 
-// convertHTMLToPDF converts an HTML file to PDF using browser automation
-func (r *PDFRenderer) convertHTMLToPDF(htmlPath, outputPath string, options *ExportOptions) error {
+// qualityName returns the quality an export renders at, medium when unset
+func qualityName(quality string) string {
+	if quality == "" {
+		return "medium"
+	}
+	return quality
+}
+
+// convertHTMLToPDF converts an HTML file to PDF using browser automation,
+// embedding meta in the document information dictionary when set. It reports
+// whether Chrome printed the PDF rather than the text-only fallback, which is
+// only used once retrying Chrome hasn't helped.
+func (r *PDFRenderer) convertHTMLToPDF(ctx context.Context, htmlPath, outputPath string, options *ExportOptions, meta *DocumentMetadata) (bool, error) {
 	// Check if browser automation is available
 	if r.browserAutomation == nil {
-		return r.fallbackPDFGeneration(htmlPath, outputPath, options)
+		return false, r.fallbackPDFGeneration(htmlPath, outputPath, options, meta)
 	}
 
-	ctx := context.Background()
 	if err := r.browserAutomation.IsAvailable(ctx); err != nil {
 		// Fallback to simple PDF generation if browser is not available
-		return r.fallbackPDFGeneration(htmlPath, outputPath, options)
+		return false, r.fallbackPDFGeneration(htmlPath, outputPath, options, meta)
+	}
+
+	err := r.browserAutomation.retry(ctx, func() error {
+		return r.printWithBrowser(ctx, htmlPath, outputPath, options)
+	})
+	if err != nil {
+		// Fallback to simple PDF generation if browser automation fails
+		return false, r.fallbackPDFGeneration(htmlPath, outputPath, options, meta)
+	}
+
+	// Chrome only takes the title from the page, so write the full info
+	// dictionary afterwards. Chrome's PDF is still better than the text-only
+	// fallback without it.
+	if meta != nil {
+		if err := embedPDFInfo(outputPath, meta); err != nil {
+			log.Printf("[WARN] Failed to embed document metadata in %s: %v", outputPath, err)
+		}
+	}
+
+	return true, nil
+}
+
+// printWithBrowser prints an HTML file to PDF with Chrome
+func (r *PDFRenderer) printWithBrowser(ctx context.Context, htmlPath, outputPath string, options *ExportOptions) error {
+	// Launch Chrome once up front so a flaky first start doesn't cost the real conversion
+	if err := r.browserAutomation.Warmup(ctx); err != nil {
+		return err
 	}
 
 	// Convert export options to PDF options
 	pdfOptions := &PDFOptions{
 		PageSize:     options.PageSize,
 		Landscape:    options.Orientation == "landscape",
+		MarginTop:    options.MarginTop,
+		MarginRight:  options.MarginRight,
+		MarginBottom: options.MarginBottom,
+		MarginLeft:   options.MarginLeft,
 		PrintHeaders: false,
 		PrintFooters: false,
+		Quality:      options.Quality,
 	}
 
 	// Use browser automation for PDF generation
-	err := r.browserAutomation.ConvertHTMLToPDF(ctx, htmlPath, outputPath, pdfOptions)
-	if err != nil {
-		// Fallback to simple PDF generation if browser automation fails
-		return r.fallbackPDFGeneration(htmlPath, outputPath, options)
-	}
-
-	return nil
+	return r.browserAutomation.ConvertHTMLToPDF(ctx, htmlPath, outputPath, pdfOptions)
 }
 
 // fallbackPDFGeneration creates a proper PDF when browser automation is not available
-func (r *PDFRenderer) fallbackPDFGeneration(htmlPath, outputPath string, options *ExportOptions) error {
-	return r.generateProperPDF(htmlPath, outputPath, options)
+func (r *PDFRenderer) fallbackPDFGeneration(htmlPath, outputPath string, options *ExportOptions, meta *DocumentMetadata) error {
+	return r.generateProperPDF(htmlPath, outputPath, options, meta)
 }
 
 // generateProperPDF creates a proper PDF from HTML content using gofpdf
-func (r *PDFRenderer) generateProperPDF(htmlPath, outputPath string, options *ExportOptions) error {
+func (r *PDFRenderer) generateProperPDF(htmlPath, outputPath string, options *ExportOptions, meta *DocumentMetadata) error {
 	// Validate file path to prevent directory traversal
 	if err := validateHTMLPath(htmlPath); err != nil {
 		return fmt.Errorf("invalid HTML file path: %w", err)
@@ -160,11 +216,40 @@ func (r *PDFRenderer) generateProperPDF(htmlPath, outputPath string, options *Ex
 	}
 
 	// Create PDF using gofpdf
-	pdf := gofpdf.New("P", "mm", "A4", "")
+	orientation := "P"
+	if options != nil && options.Orientation == "landscape" {
+		orientation = "L"
+	}
+	pdf := gofpdf.New(orientation, "mm", "A4", "")
+
+	if meta != nil {
+		pdf.SetTitle(meta.Title, true)
+		pdf.SetAuthor(meta.Author, true)
+		pdf.SetSubject(meta.Subject, true)
+		pdf.SetKeywords(meta.KeywordList(), true)
+		pdf.SetCreationDate(meta.CreatedAt)
+		pdf.SetCreator("slicli", false)
+	}
 
-	// Set margins
-	pdf.SetMargins(20, 20, 20)
-	pdf.SetAutoPageBreak(true, 20)
+	// Set margins, in mm, falling back to 20mm for any side left unset
+	top, right, bottom, left := 20.0, 20.0, 20.0, 20.0
+	if options != nil {
+		for _, margin := range []struct {
+			value  string
+			target *float64
+		}{
+			{options.MarginTop, &top},
+			{options.MarginRight, &right},
+			{options.MarginBottom, &bottom},
+			{options.MarginLeft, &left},
+		} {
+			if inches, err := parseMargin(margin.value); margin.value != "" && err == nil {
+				*margin.target = inches * 25.4
+			}
+		}
+	}
+	pdf.SetMargins(left, top, right)
+	pdf.SetAutoPageBreak(true, bottom)
 
 	// Process each slide
 	for i, slide := range slides {
@@ -0,0 +1,578 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"golang.org/x/net/html"
+)
+
+const (
+	pptxMimeType = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+
+	// 16:9 slide and portrait notes page sizes in EMUs
+	pptxSlideWidth  = 12192000
+	pptxSlideHeight = 6858000
+	pptxNotesWidth  = 6858000
+	pptxNotesHeight = 9144000
+
+	pptxNamespaces = `xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" ` +
+		`xmlns:p="http://schemas.openxmlformats.org/presentationml/2006/main"`
+
+	relTypeBase = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/"
+)
+
+// pptxColors are the slide background and text colors for a theme
+type pptxColors struct {
+	Background string
+	Text       string
+	Heading    string
+}
+
+// pptxThemeColors maps built-in themes to colors matching their theme.toml
+// variables. Unknown themes use the default light scheme.
+var pptxThemeColors = map[string]pptxColors{
+	"default":        {Background: "FFFFFF", Text: "1E293B", Heading: "0F172A"},
+	"minimal":        {Background: "FFFFFF", Text: "333333", Heading: "000000"},
+	"modern-minimal": {Background: "FFFFFF", Text: "333333", Heading: "000000"},
+	"dark":           {Background: "0F172A", Text: "E2E8F0", Heading: "F1F5F9"},
+	"developer-dark": {Background: "0D1117", Text: "C9D1D9", Heading: "F0F6FC"},
+}
+
+// pptxParagraph is one paragraph of slide body text
+type pptxParagraph struct {
+	Text   string
+	Level  int // Bullet nesting depth, 0 for plain paragraphs
+	Bullet bool
+	Bold   bool
+	Code   bool
+}
+
+// pptxPart is a named file inside the pptx zip
+type pptxPart struct {
+	name    string
+	content string
+}
+
+// pptxSlide is the text content extracted from a slide's HTML
+type pptxSlide struct {
+	Title      string
+	Paragraphs []pptxParagraph
+	Notes      string
+}
+
+// PowerPointRenderer implements export to PowerPoint (pptx) format
+type PowerPointRenderer struct{}
+
+// NewPowerPointRenderer creates a new PowerPoint renderer
+func NewPowerPointRenderer() *PowerPointRenderer {
+	return &PowerPointRenderer{}
+}
+
+// Render exports the presentation as an OOXML presentation with one slide per
+// presentation slide, adding notes pages when notes are included
+func (r *PowerPointRenderer) Render(ctx context.Context, presentation *entities.Presentation, options *ExportOptions) (*ExportResult, error) {
+	slides := make([]pptxSlide, 0, len(presentation.Slides))
+	for _, slide := range presentation.Slides {
+		content := extractPPTXSlide(slide.HTML)
+		if slide.Title != "" {
+			content.Title = slide.Title
+		}
+		if options.IncludeNotes {
+			content.Notes = strings.TrimSpace(slide.Notes)
+		}
+		slides = append(slides, content)
+	}
+
+	themeName := options.Theme
+	if themeName == "" {
+		themeName = presentation.Theme
+	}
+	colors, ok := pptxThemeColors[themeName]
+	if !ok {
+		colors = pptxThemeColors["default"]
+	}
+
+	var meta *DocumentMetadata
+	if options.IncludeMetadata {
+		meta = newDocumentMetadata(presentation, options)
+	}
+
+	if err := r.writePackage(ctx, options.OutputPath, slides, colors, meta); err != nil {
+		return nil, err
+	}
+
+	fileSize, _ := GetFileSize(options.OutputPath)
+
+	return &ExportResult{
+		Success:    true,
+		Format:     string(FormatPowerPoint),
+		OutputPath: options.OutputPath,
+		FileSize:   fileSize,
+		PageCount:  len(slides),
+	}, nil
+}
+
+// Supports returns true if this renderer supports the given format
+func (r *PowerPointRenderer) Supports(format ExportFormat) bool {
+	return format == FormatPowerPoint
+}
+
+// GetMimeType returns the MIME type for PowerPoint files
+func (r *PowerPointRenderer) GetMimeType() string {
+	return pptxMimeType
+}
+
+// writePackage writes the zip container with all presentation parts
+func (r *PowerPointRenderer) writePackage(ctx context.Context, outputPath string, slides []pptxSlide, colors pptxColors, meta *DocumentMetadata) (err error) {
+	file, err := os.Create(outputPath) // #nosec G304 - output path validated by the export service
+	if err != nil {
+		return fmt.Errorf("creating pptx file: %w", err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("closing pptx file: %w", closeErr)
+		}
+	}()
+
+	zw := zip.NewWriter(file)
+	hasNotes := false
+	for _, slide := range slides {
+		if slide.Notes != "" {
+			hasNotes = true
+			break
+		}
+	}
+
+	parts := []pptxPart{
+		{"[Content_Types].xml", pptxContentTypes(slides, hasNotes, meta != nil)},
+		{"_rels/.rels", pptxRootRels(meta != nil)},
+		{"ppt/presentation.xml", pptxPresentation(len(slides), hasNotes)},
+		{"ppt/_rels/presentation.xml.rels", pptxPresentationRels(len(slides), hasNotes)},
+		{"ppt/slideMasters/slideMaster1.xml", pptxSlideMaster},
+		{"ppt/slideMasters/_rels/slideMaster1.xml.rels", pptxRels(
+			pptxRel{"rId1", "slideLayout", "../slideLayouts/slideLayout1.xml"},
+			pptxRel{"rId2", "theme", "../theme/theme1.xml"},
+		)},
+		{"ppt/slideLayouts/slideLayout1.xml", pptxSlideLayout},
+		{"ppt/slideLayouts/_rels/slideLayout1.xml.rels", pptxRels(
+			pptxRel{"rId1", "slideMaster", "../slideMasters/slideMaster1.xml"},
+		)},
+		{"ppt/theme/theme1.xml", pptxTheme},
+	}
+
+	if meta != nil {
+		parts = append(parts, pptxPart{"docProps/core.xml", pptxCoreProperties(meta)})
+	}
+
+	if hasNotes {
+		parts = append(parts,
+			pptxPart{"ppt/notesMasters/notesMaster1.xml", pptxNotesMaster},
+			pptxPart{"ppt/notesMasters/_rels/notesMaster1.xml.rels", pptxRels(
+				pptxRel{"rId1", "theme", "../theme/theme2.xml"},
+			)},
+			pptxPart{"ppt/theme/theme2.xml", pptxTheme},
+		)
+	}
+
+	for i, slide := range slides {
+		n := i + 1
+		rels := []pptxRel{{"rId1", "slideLayout", "../slideLayouts/slideLayout1.xml"}}
+		if slide.Notes != "" {
+			rels = append(rels, pptxRel{"rId2", "notesSlide", fmt.Sprintf("../notesSlides/notesSlide%d.xml", n)})
+		}
+
+		parts = append(parts,
+			pptxPart{fmt.Sprintf("ppt/slides/slide%d.xml", n), pptxSlideXML(slide, colors)},
+			pptxPart{fmt.Sprintf("ppt/slides/_rels/slide%d.xml.rels", n), pptxRels(rels...)},
+		)
+
+		if slide.Notes != "" {
+			parts = append(parts,
+				pptxPart{fmt.Sprintf("ppt/notesSlides/notesSlide%d.xml", n), pptxNotesXML(slide.Notes)},
+				pptxPart{fmt.Sprintf("ppt/notesSlides/_rels/notesSlide%d.xml.rels", n), pptxRels(
+					pptxRel{"rId1", "notesMaster", "../notesMasters/notesMaster1.xml"},
+					pptxRel{"rId2", "slide", fmt.Sprintf("../slides/slide%d.xml", n)},
+				)},
+			)
+		}
+	}
+
+	for _, part := range parts {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		w, err := zw.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("adding %s: %w", part.name, err)
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return fmt.Errorf("writing %s: %w", part.name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("finalizing pptx archive: %w", err)
+	}
+
+	return nil
+}
+
+// extractPPTXSlide pulls the title and body text out of slide HTML. The first
+// heading becomes the title, later headings become bold paragraphs.
+func extractPPTXSlide(slideHTML string) pptxSlide {
+	var slide pptxSlide
+
+	doc, err := html.Parse(strings.NewReader(slideHTML))
+	if err != nil {
+		return slide
+	}
+
+	var walk func(n *html.Node, listDepth int)
+	walk = func(n *html.Node, listDepth int) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "h1", "h2", "h3", "h4", "h5", "h6":
+				text := nodeText(n)
+				if slide.Title == "" && len(slide.Paragraphs) == 0 {
+					slide.Title = text
+				} else if text != "" {
+					slide.Paragraphs = append(slide.Paragraphs, pptxParagraph{Text: text, Bold: true})
+				}
+				return
+			case "p":
+				if text := nodeText(n); text != "" {
+					slide.Paragraphs = append(slide.Paragraphs, pptxParagraph{Text: text})
+				}
+				return
+			case "ul", "ol":
+				listDepth++
+			case "li":
+				// Text of the item itself, excluding nested lists
+				var text strings.Builder
+				for child := n.FirstChild; child != nil; child = child.NextSibling {
+					if child.Type == html.ElementNode && (child.Data == "ul" || child.Data == "ol") {
+						continue
+					}
+					text.WriteString(nodeText(child))
+					text.WriteString(" ")
+				}
+				if item := strings.Join(strings.Fields(text.String()), " "); item != "" {
+					slide.Paragraphs = append(slide.Paragraphs, pptxParagraph{Text: item, Bullet: true, Level: listDepth})
+				}
+				for child := n.FirstChild; child != nil; child = child.NextSibling {
+					if child.Type == html.ElementNode && (child.Data == "ul" || child.Data == "ol") {
+						walk(child, listDepth)
+					}
+				}
+				return
+			case "pre":
+				code := strings.TrimRight(rawText(n), "\n")
+				for _, line := range strings.Split(code, "\n") {
+					slide.Paragraphs = append(slide.Paragraphs, pptxParagraph{Text: line, Code: true})
+				}
+				return
+			case "tr":
+				var cells []string
+				for child := n.FirstChild; child != nil; child = child.NextSibling {
+					if child.Type == html.ElementNode && (child.Data == "td" || child.Data == "th") {
+						cells = append(cells, nodeText(child))
+					}
+				}
+				slide.Paragraphs = append(slide.Paragraphs, pptxParagraph{Text: strings.Join(cells, " | ")})
+				return
+			case "script", "style":
+				return
+			}
+		}
+
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child, listDepth)
+		}
+	}
+	walk(doc, 0)
+
+	return slide
+}
+
+// nodeText returns the whitespace-normalized text of a node
+func nodeText(n *html.Node) string {
+	return strings.Join(strings.Fields(rawText(n)), " ")
+}
+
+// rawText returns the text of a node with whitespace preserved
+func rawText(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		b.WriteString(rawText(child))
+	}
+	return b.String()
+}
+
+// pptxRel is a package relationship
+type pptxRel struct {
+	ID     string
+	Type   string // Suffix of the officeDocument relationship type
+	Target string
+}
+
+func pptxRels(rels ...pptxRel) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for _, rel := range rels {
+		relType := relTypeBase + rel.Type
+		if rel.Type == "core-properties" {
+			relType = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties"
+		}
+		fmt.Fprintf(&b, `<Relationship Id="%s" Type="%s" Target="%s"/>`, rel.ID, relType, rel.Target)
+	}
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func pptxRootRels(withCore bool) string {
+	rels := []pptxRel{{"rId1", "officeDocument", "ppt/presentation.xml"}}
+	if withCore {
+		rels = append(rels, pptxRel{"rId2", "core-properties", "docProps/core.xml"})
+	}
+	return pptxRels(rels...)
+}
+
+func pptxContentTypes(slides []pptxSlide, hasNotes, withCore bool) string {
+	const pml = "application/vnd.openxmlformats-officedocument.presentationml."
+
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+
+	override := func(part, contentType string) {
+		fmt.Fprintf(&b, `<Override PartName="%s" ContentType="%s"/>`, part, contentType)
+	}
+	override("/ppt/presentation.xml", pml+"presentation.main+xml")
+	override("/ppt/slideMasters/slideMaster1.xml", pml+"slideMaster+xml")
+	override("/ppt/slideLayouts/slideLayout1.xml", pml+"slideLayout+xml")
+	override("/ppt/theme/theme1.xml", "application/vnd.openxmlformats-officedocument.theme+xml")
+	if hasNotes {
+		override("/ppt/notesMasters/notesMaster1.xml", pml+"notesMaster+xml")
+		override("/ppt/theme/theme2.xml", "application/vnd.openxmlformats-officedocument.theme+xml")
+	}
+	if withCore {
+		override("/docProps/core.xml", "application/vnd.openxmlformats-package.core-properties+xml")
+	}
+	for i, slide := range slides {
+		override(fmt.Sprintf("/ppt/slides/slide%d.xml", i+1), pml+"slide+xml")
+		if slide.Notes != "" {
+			override(fmt.Sprintf("/ppt/notesSlides/notesSlide%d.xml", i+1), pml+"notesSlide+xml")
+		}
+	}
+
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+// pptxPresentation lists the slides; relationship IDs follow pptxPresentationRels
+func pptxPresentation(slideCount int, hasNotes bool) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<p:presentation %s saveSubsetFonts="1">`, pptxNamespaces)
+	b.WriteString(`<p:sldMasterIdLst><p:sldMasterId id="2147483648" r:id="rId1"/></p:sldMasterIdLst>`)
+	if hasNotes {
+		fmt.Fprintf(&b, `<p:notesMasterIdLst><p:notesMasterId r:id="rId%d"/></p:notesMasterIdLst>`, slideCount+3)
+	}
+	if slideCount > 0 {
+		b.WriteString(`<p:sldIdLst>`)
+		for i := 0; i < slideCount; i++ {
+			fmt.Fprintf(&b, `<p:sldId id="%d" r:id="rId%d"/>`, 256+i, i+3)
+		}
+		b.WriteString(`</p:sldIdLst>`)
+	}
+	fmt.Fprintf(&b, `<p:sldSz cx="%d" cy="%d"/>`, pptxSlideWidth, pptxSlideHeight)
+	fmt.Fprintf(&b, `<p:notesSz cx="%d" cy="%d"/>`, pptxNotesWidth, pptxNotesHeight)
+	b.WriteString(`</p:presentation>`)
+	return b.String()
+}
+
+func pptxPresentationRels(slideCount int, hasNotes bool) string {
+	rels := []pptxRel{
+		{"rId1", "slideMaster", "slideMasters/slideMaster1.xml"},
+		{"rId2", "theme", "theme/theme1.xml"},
+	}
+	for i := 0; i < slideCount; i++ {
+		rels = append(rels, pptxRel{fmt.Sprintf("rId%d", i+3), "slide", fmt.Sprintf("slides/slide%d.xml", i+1)})
+	}
+	if hasNotes {
+		rels = append(rels, pptxRel{fmt.Sprintf("rId%d", slideCount+3), "notesMaster", "notesMasters/notesMaster1.xml"})
+	}
+	return pptxRels(rels...)
+}
+
+func pptxCoreProperties(meta *DocumentMetadata) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" ` +
+		`xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" ` +
+		`xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">`)
+	element := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, `<%s>%s</%s>`, name, xmlEscape(value), name)
+		}
+	}
+	element("dc:title", meta.Title)
+	element("dc:creator", meta.Author)
+	element("dc:subject", meta.Subject)
+	element("cp:keywords", meta.KeywordList())
+	if !meta.CreatedAt.IsZero() {
+		fmt.Fprintf(&b, `<dcterms:created xsi:type="dcterms:W3CDTF">%s</dcterms:created>`, meta.CreatedAt.UTC().Format(time.RFC3339))
+	}
+	b.WriteString(`</cp:coreProperties>`)
+	return b.String()
+}
+
+// pptxSlideXML lays out a title box across the top and a body box below it
+func pptxSlideXML(slide pptxSlide, colors pptxColors) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<p:sld %s><p:cSld>`, pptxNamespaces)
+	fmt.Fprintf(&b, `<p:bg><p:bgPr><a:solidFill><a:srgbClr val="%s"/></a:solidFill><a:effectLst/></p:bgPr></p:bg>`, colors.Background)
+	b.WriteString(`<p:spTree>` + pptxGroupProperties)
+
+	fmt.Fprintf(&b, `<p:sp><p:nvSpPr><p:cNvPr id="2" name="Title"/><p:cNvSpPr txBox="1"/><p:nvPr/></p:nvSpPr>`+
+		`<p:spPr><a:xfrm><a:off x="457200" y="274638"/><a:ext cx="11277600" cy="1143000"/></a:xfrm>`+
+		`<a:prstGeom prst="rect"><a:avLst/></a:prstGeom></p:spPr>`+
+		`<p:txBody><a:bodyPr wrap="square" anchor="b"><a:normAutofit/></a:bodyPr><a:lstStyle/>`+
+		`<a:p>%s</a:p></p:txBody></p:sp>`,
+		pptxRun(slide.Title, `lang="en-US" sz="4000" b="1"`, colors.Heading, ""))
+
+	b.WriteString(`<p:sp><p:nvSpPr><p:cNvPr id="3" name="Content"/><p:cNvSpPr txBox="1"/><p:nvPr/></p:nvSpPr>` +
+		`<p:spPr><a:xfrm><a:off x="457200" y="1600200"/><a:ext cx="11277600" cy="4800600"/></a:xfrm>` +
+		`<a:prstGeom prst="rect"><a:avLst/></a:prstGeom></p:spPr>` +
+		`<p:txBody><a:bodyPr wrap="square"><a:normAutofit/></a:bodyPr><a:lstStyle/>`)
+	if len(slide.Paragraphs) == 0 {
+		b.WriteString(`<a:p/>`)
+	}
+	for _, para := range slide.Paragraphs {
+		b.WriteString(`<a:p>`)
+		if para.Bullet {
+			level := para.Level
+			if level < 1 {
+				level = 1
+			}
+			fmt.Fprintf(&b, `<a:pPr marL="%d" lvl="%d" indent="-285750"><a:buFont typeface="Arial"/><a:buChar char="•"/></a:pPr>`,
+				285750*level, level-1)
+		}
+
+		attrs := `lang="en-US" sz="2000"`
+		font := ""
+		switch {
+		case para.Code:
+			attrs = `lang="en-US" sz="1600"`
+			font = "Courier New"
+		case para.Bold:
+			attrs = `lang="en-US" sz="2400" b="1"`
+		}
+		b.WriteString(pptxRun(para.Text, attrs, colors.Text, font))
+		b.WriteString(`</a:p>`)
+	}
+	b.WriteString(`</p:txBody></p:sp>`)
+
+	b.WriteString(`</p:spTree></p:cSld><p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:sld>`)
+	return b.String()
+}
+
+// pptxRun renders a text run, or nothing for empty text
+func pptxRun(text, attrs, color, font string) string {
+	if text == "" {
+		return ""
+	}
+	props := fmt.Sprintf(`<a:solidFill><a:srgbClr val="%s"/></a:solidFill>`, color)
+	if font != "" {
+		props += fmt.Sprintf(`<a:latin typeface="%s"/><a:cs typeface="%s"/>`, font, font)
+	}
+	return fmt.Sprintf(`<a:r><a:rPr %s dirty="0">%s</a:rPr><a:t>%s</a:t></a:r>`, attrs, props, xmlEscape(text))
+}
+
+func pptxNotesXML(notes string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	fmt.Fprintf(&b, `<p:notes %s><p:cSld><p:spTree>%s`, pptxNamespaces, pptxGroupProperties)
+	b.WriteString(`<p:sp><p:nvSpPr><p:cNvPr id="2" name="Notes Placeholder"/><p:cNvSpPr><a:spLocks noGrp="1"/></p:cNvSpPr>` +
+		`<p:nvPr><p:ph type="body" idx="1"/></p:nvPr></p:nvSpPr><p:spPr/>` +
+		`<p:txBody><a:bodyPr/><a:lstStyle/>`)
+	for _, line := range strings.Split(notes, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			b.WriteString(`<a:p/>`)
+			continue
+		}
+		fmt.Fprintf(&b, `<a:p><a:r><a:rPr lang="en-US" dirty="0"/><a:t>%s</a:t></a:r></a:p>`, xmlEscape(line))
+	}
+	b.WriteString(`</p:txBody></p:sp></p:spTree></p:cSld><p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:notes>`)
+	return b.String()
+}
+
+// xmlEscape escapes text for element content, dropping characters XML can't hold
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+const pptxGroupProperties = `<p:nvGrpSpPr><p:cNvPr id="1" name=""/><p:cNvGrpSpPr/><p:nvPr/></p:nvGrpSpPr>` +
+	`<p:grpSpPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/><a:chOff x="0" y="0"/><a:chExt cx="0" cy="0"/></a:xfrm></p:grpSpPr>`
+
+const pptxColorMap = `bg1="lt1" tx1="dk1" bg2="lt2" tx2="dk2" accent1="accent1" accent2="accent2" accent3="accent3" ` +
+	`accent4="accent4" accent5="accent5" accent6="accent6" hlink="hlink" folHlink="folHlink"`
+
+var pptxSlideMaster = xml.Header + `<p:sldMaster ` + pptxNamespaces + `>` +
+	`<p:cSld><p:bg><p:bgRef idx="1001"><a:schemeClr val="bg1"/></p:bgRef></p:bg><p:spTree>` + pptxGroupProperties + `</p:spTree></p:cSld>` +
+	`<p:clrMap ` + pptxColorMap + `/>` +
+	`<p:sldLayoutIdLst><p:sldLayoutId id="2147483649" r:id="rId1"/></p:sldLayoutIdLst>` +
+	`<p:txStyles>` +
+	`<p:titleStyle><a:lvl1pPr><a:defRPr sz="4400"/></a:lvl1pPr></p:titleStyle>` +
+	`<p:bodyStyle><a:lvl1pPr><a:defRPr sz="2800"/></a:lvl1pPr></p:bodyStyle>` +
+	`<p:otherStyle><a:lvl1pPr><a:defRPr sz="1800"/></a:lvl1pPr></p:otherStyle>` +
+	`</p:txStyles></p:sldMaster>`
+
+var pptxSlideLayout = xml.Header + `<p:sldLayout ` + pptxNamespaces + ` type="blank" preserve="1">` +
+	`<p:cSld name="Blank"><p:spTree>` + pptxGroupProperties + `</p:spTree></p:cSld>` +
+	`<p:clrMapOvr><a:masterClrMapping/></p:clrMapOvr></p:sldLayout>`
+
+var pptxNotesMaster = xml.Header + `<p:notesMaster ` + pptxNamespaces + `>` +
+	`<p:cSld><p:spTree>` + pptxGroupProperties + `</p:spTree></p:cSld>` +
+	`<p:clrMap ` + pptxColorMap + `/></p:notesMaster>`
+
+// pptxTheme is a minimal Office theme; PowerPoint requires one per master
+var pptxTheme = xml.Header + `<a:theme xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" name="slicli">` +
+	`<a:themeElements>` +
+	`<a:clrScheme name="slicli">` +
+	`<a:dk1><a:srgbClr val="000000"/></a:dk1><a:lt1><a:srgbClr val="FFFFFF"/></a:lt1>` +
+	`<a:dk2><a:srgbClr val="1E293B"/></a:dk2><a:lt2><a:srgbClr val="F1F5F9"/></a:lt2>` +
+	`<a:accent1><a:srgbClr val="2563EB"/></a:accent1><a:accent2><a:srgbClr val="64748B"/></a:accent2>` +
+	`<a:accent3><a:srgbClr val="16A34A"/></a:accent3><a:accent4><a:srgbClr val="F59E0B"/></a:accent4>` +
+	`<a:accent5><a:srgbClr val="DC2626"/></a:accent5><a:accent6><a:srgbClr val="7C3AED"/></a:accent6>` +
+	`<a:hlink><a:srgbClr val="2563EB"/></a:hlink><a:folHlink><a:srgbClr val="7C3AED"/></a:folHlink>` +
+	`</a:clrScheme>` +
+	`<a:fontScheme name="slicli">` +
+	`<a:majorFont><a:latin typeface="Calibri"/><a:ea typeface=""/><a:cs typeface=""/></a:majorFont>` +
+	`<a:minorFont><a:latin typeface="Calibri"/><a:ea typeface=""/><a:cs typeface=""/></a:minorFont>` +
+	`</a:fontScheme>` +
+	`<a:fmtScheme name="slicli">` +
+	`<a:fillStyleLst>` + strings.Repeat(`<a:solidFill><a:schemeClr val="phClr"/></a:solidFill>`, 3) + `</a:fillStyleLst>` +
+	`<a:lnStyleLst>` + strings.Repeat(`<a:ln w="9525"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill></a:ln>`, 3) + `</a:lnStyleLst>` +
+	`<a:effectStyleLst>` + strings.Repeat(`<a:effectStyle><a:effectLst/></a:effectStyle>`, 3) + `</a:effectStyleLst>` +
+	`<a:bgFillStyleLst>` + strings.Repeat(`<a:solidFill><a:schemeClr val="phClr"/></a:solidFill>`, 3) + `</a:bgFillStyleLst>` +
+	`</a:fmtScheme>` +
+	`</a:themeElements></a:theme>`
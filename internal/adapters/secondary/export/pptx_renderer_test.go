@@ -0,0 +1,163 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// readPPTX returns the contents of every part in a pptx file, checking that
+// each XML part is well-formed
+func readPPTX(t *testing.T, path string) map[string]string {
+	t.Helper()
+
+	reader, err := zip.OpenReader(path)
+	require.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+
+	parts := make(map[string]string)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		_ = rc.Close()
+
+		decoder := xml.NewDecoder(strings.NewReader(string(data)))
+		for {
+			_, err := decoder.Token()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err, "part %s should be well-formed XML", file.Name)
+		}
+
+		parts[file.Name] = string(data)
+	}
+	return parts
+}
+
+func samplePPTXPresentation() *entities.Presentation {
+	return &entities.Presentation{
+		Title:  "Roadmap",
+		Author: "Dana",
+		Theme:  "dark",
+		Slides: []entities.Slide{
+			{Index: 0, HTML: "<h1>Roadmap</h1><p>Where we are &amp; where we go</p>", Notes: "Welcome everyone"},
+			{Index: 1, HTML: "<h2>Plan</h2><ul><li>Ship <strong>v2</strong><ul><li>beta</li></ul></li><li>Hire</li></ul>"},
+			{Index: 2, Title: "Code", HTML: "<pre><code>func main() {\n\tfmt.Println(\"&lt;hi&gt;\")\n}</code></pre>"},
+		},
+	}
+}
+
+func TestPowerPointRenderer_Render(t *testing.T) {
+	presentation := samplePPTXPresentation()
+	outputPath := filepath.Join(t.TempDir(), "roadmap.pptx")
+
+	result, err := NewPowerPointRenderer().Render(context.Background(), presentation, &ExportOptions{
+		Format:     FormatPowerPoint,
+		OutputPath: outputPath,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, len(presentation.Slides), result.PageCount)
+	assert.Positive(t, result.FileSize)
+
+	parts := readPPTX(t, outputPath)
+
+	slideParts := 0
+	for name := range parts {
+		if regexp.MustCompile(`^ppt/slides/slide\d+\.xml$`).MatchString(name) {
+			slideParts++
+		}
+	}
+	assert.Equal(t, len(presentation.Slides), slideParts)
+	assert.Equal(t, len(presentation.Slides), strings.Count(parts["ppt/presentation.xml"], "<p:sldId "))
+
+	for _, required := range []string{
+		"[Content_Types].xml", "_rels/.rels", "ppt/_rels/presentation.xml.rels",
+		"ppt/slideMasters/slideMaster1.xml", "ppt/slideLayouts/slideLayout1.xml", "ppt/theme/theme1.xml",
+	} {
+		assert.Contains(t, parts, required)
+	}
+	assert.Contains(t, parts["[Content_Types].xml"], `PartName="/ppt/slides/slide3.xml"`)
+
+	// Text content, escaping, bullets and code
+	assert.Contains(t, parts["ppt/slides/slide1.xml"], "<a:t>Roadmap</a:t>")
+	assert.Contains(t, parts["ppt/slides/slide1.xml"], "<a:t>Where we are &amp; where we go</a:t>")
+	assert.Contains(t, parts["ppt/slides/slide2.xml"], "<a:t>Plan</a:t>")
+	assert.Contains(t, parts["ppt/slides/slide2.xml"], `lvl="1"`)
+	assert.Contains(t, parts["ppt/slides/slide2.xml"], "<a:t>Ship v2</a:t>")
+	assert.Contains(t, parts["ppt/slides/slide2.xml"], "<a:t>beta</a:t>")
+	assert.Contains(t, parts["ppt/slides/slide3.xml"], "<a:t>Code</a:t>")
+	assert.Contains(t, parts["ppt/slides/slide3.xml"], `typeface="Courier New"`)
+	assert.Contains(t, parts["ppt/slides/slide3.xml"], "<a:t>&#x9;fmt.Println(&#34;&lt;hi&gt;&#34;)</a:t>")
+
+	// The presentation's dark theme sets the background
+	assert.Contains(t, parts["ppt/slides/slide1.xml"], `<p:bg><p:bgPr><a:solidFill><a:srgbClr val="0F172A"/>`)
+
+	// Notes and metadata are left out unless requested
+	assert.NotContains(t, parts, "ppt/notesSlides/notesSlide1.xml")
+	assert.NotContains(t, parts, "docProps/core.xml")
+}
+
+func TestPowerPointRenderer_Notes(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "roadmap.pptx")
+
+	_, err := NewPowerPointRenderer().Render(context.Background(), samplePPTXPresentation(), &ExportOptions{
+		Format:       FormatPowerPoint,
+		OutputPath:   outputPath,
+		IncludeNotes: true,
+	})
+	require.NoError(t, err)
+
+	parts := readPPTX(t, outputPath)
+	assert.Contains(t, parts["ppt/notesSlides/notesSlide1.xml"], "<a:t>Welcome everyone</a:t>")
+	assert.NotContains(t, parts, "ppt/notesSlides/notesSlide2.xml", "slides without notes get no notes page")
+	assert.Contains(t, parts["ppt/slides/_rels/slide1.xml.rels"], "../notesSlides/notesSlide1.xml")
+	assert.Contains(t, parts, "ppt/notesMasters/notesMaster1.xml")
+	assert.Contains(t, parts["ppt/presentation.xml"], "<p:notesMasterIdLst>")
+	assert.Contains(t, parts["[Content_Types].xml"], `PartName="/ppt/notesSlides/notesSlide1.xml"`)
+}
+
+func TestPowerPointRenderer_ThemeAndMetadata(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "roadmap.pptx")
+
+	_, err := NewPowerPointRenderer().Render(context.Background(), samplePPTXPresentation(), &ExportOptions{
+		Format:          FormatPowerPoint,
+		OutputPath:      outputPath,
+		Theme:           "minimal",
+		IncludeMetadata: true,
+	})
+	require.NoError(t, err)
+
+	parts := readPPTX(t, outputPath)
+	assert.Contains(t, parts["ppt/slides/slide1.xml"], `<a:srgbClr val="FFFFFF"/>`)
+	assert.Contains(t, parts["docProps/core.xml"], "<dc:title>Roadmap</dc:title>")
+	assert.Contains(t, parts["docProps/core.xml"], "<dc:creator>Dana</dc:creator>")
+}
+
+func TestService_ExportPowerPoint(t *testing.T) {
+	tmpDir := t.TempDir()
+	service, err := NewService(tmpDir)
+	require.NoError(t, err)
+
+	result, err := service.Export(context.Background(), samplePPTXPresentation(), &ExportOptions{
+		Format:     FormatPowerPoint,
+		OutputPath: filepath.Join(tmpDir, "roadmap.pptx"),
+	})
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "pptx", result.Format)
+}
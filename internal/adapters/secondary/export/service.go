@@ -2,6 +2,9 @@ package export
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -10,8 +13,10 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/theme"
 	"github.com/fredcamaral/slicli/internal/domain/entities"
 )
 
@@ -24,8 +29,21 @@ const (
 	FormatImages     ExportFormat = "images"
 	FormatMarkdown   ExportFormat = "markdown"
 	FormatPowerPoint ExportFormat = "pptx"
+	FormatJSON       ExportFormat = "json"
+	FormatSVG        ExportFormat = "svg"
 )
 
+// UsesBrowser reports whether the format is rendered with headless Chrome
+func (f ExportFormat) UsesBrowser() bool {
+	return f == FormatPDF || f == FormatImages || f == FormatSVG
+}
+
+// WritesDirectory reports whether the format writes a directory of files,
+// one per slide, rather than a single file
+func (f ExportFormat) WritesDirectory() bool {
+	return f == FormatImages || f == FormatSVG
+}
+
 // ExportOptions contains configuration for export operations
 type ExportOptions struct {
 	Format          ExportFormat           `json:"format"`
@@ -36,8 +54,46 @@ type ExportOptions struct {
 	Quality         string                 `json:"quality,omitempty"`     // low, medium, high
 	PageSize        string                 `json:"page_size,omitempty"`   // A4, Letter, Custom
 	Orientation     string                 `json:"orientation,omitempty"` // portrait, landscape
+	MarginTop       string                 `json:"margin_top,omitempty"`  // e.g. 0.5in, 1cm, 10mm
+	MarginRight     string                 `json:"margin_right,omitempty"`
+	MarginBottom    string                 `json:"margin_bottom,omitempty"`
+	MarginLeft      string                 `json:"margin_left,omitempty"`
 	Compression     bool                   `json:"compression"`
+	Fonts           []string               `json:"fonts,omitempty"`        // Font files embedded in HTML exports
+	SubsetFonts     bool                   `json:"subset_fonts,omitempty"` // Cut embedded fonts down to the glyphs the deck uses
 	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+
+	// ThemeFonts are the theme's fonts, declared in HTML exports. With
+	// InlineFonts, local, url and Google fonts are embedded as data URIs so the
+	// export works offline, up to InlineFontsLimit bytes in total
+	// (DefaultInlineFontsLimit when zero).
+	ThemeFonts       []theme.ThemeFont `json:"theme_fonts,omitempty"`
+	InlineFonts      bool              `json:"inline_fonts,omitempty"`
+	InlineFontsLimit int64             `json:"inline_fonts_limit,omitempty"`
+
+	// PrintLayout makes HTML exports show every slide stacked, one per
+	// printed page, instead of one at a time with navigation
+	PrintLayout bool `json:"print_layout,omitempty"`
+
+	// ContactSheet makes image exports also write every slide, captioned
+	// with its number, into one grid image ContactSheetColumns wide
+	ContactSheet        bool `json:"contact_sheet,omitempty"`
+	ContactSheetColumns int  `json:"contact_sheet_columns,omitempty"`
+
+	// SlideRange limits the export to some slides, numbered from 1, e.g. "4-9" or "1,3,5-7"
+	SlideRange string `json:"slide_range,omitempty"`
+
+	// SourceDir is the presentation's directory, which relative image paths
+	// are resolved against. HTML exports copy the images next to the output,
+	// or with InlineImages embed those up to InlineImageLimit bytes
+	// (DefaultInlineImageLimit when zero) as data URIs.
+	SourceDir        string `json:"source_dir,omitempty"`
+	InlineImages     bool   `json:"inline_images,omitempty"`
+	InlineImageLimit int64  `json:"inline_image_limit,omitempty"`
+
+	// OperationID names the export in GetExportMetrics. One is generated
+	// with NewOperationID when empty.
+	OperationID string `json:"operation_id,omitempty"`
 }
 
 // ExportResult contains the results of an export operation
@@ -53,6 +109,10 @@ type ExportResult struct {
 	Warnings    []string               `json:"warnings,omitempty"`
 	GeneratedAt time.Time              `json:"generated_at"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"` // Enhanced metadata including metrics
+
+	// RetryCount and FallbacksUsed repeat the export metrics of the same name
+	RetryCount    int            `json:"retry_count"`
+	FallbacksUsed []FallbackInfo `json:"fallbacks_used"`
 }
 
 // ExportErrorType categorizes different types of export errors
@@ -97,8 +157,25 @@ type FallbackInfo struct {
 	Timestamp    time.Time `json:"timestamp"`
 }
 
+// ExportPhase is the step a running export is at
+type ExportPhase string
+
+const (
+	PhaseValidating ExportPhase = "validating"
+	PhaseRendering  ExportPhase = "rendering"
+	PhaseRetrying   ExportPhase = "retrying"
+	PhaseFallback   ExportPhase = "fallback" // Rendering one of the format's fallbacks
+	PhaseCompleted  ExportPhase = "completed"
+	PhaseFailed     ExportPhase = "failed"
+)
+
+// DefaultMetricsRetention is how long the metrics of a finished export stay
+// available from GetExportMetrics
+const DefaultMetricsRetention = 5 * time.Minute
+
 // ExportMetrics tracks performance and reliability metrics
 type ExportMetrics struct {
+	Phase            ExportPhase    `json:"phase"`
 	StartTime        time.Time      `json:"start_time"`
 	EndTime          time.Time      `json:"end_time"`
 	Duration         time.Duration  `json:"duration"`
@@ -109,6 +186,41 @@ type ExportMetrics struct {
 	Warnings         []string       `json:"warnings,omitempty"`
 }
 
+// MarshalJSON encodes the metrics with the duration in milliseconds and
+// times in RFC 3339. While the export is running the end time is omitted
+// and the duration is the time elapsed so far.
+func (m ExportMetrics) MarshalJSON() ([]byte, error) {
+	var endTime string
+	duration := m.Duration
+	if !m.EndTime.IsZero() {
+		endTime = m.EndTime.Format(time.RFC3339)
+	} else if !m.StartTime.IsZero() {
+		duration = time.Since(m.StartTime)
+	}
+
+	return json.Marshal(struct {
+		Phase            ExportPhase    `json:"phase,omitempty"`
+		StartTime        string         `json:"start_time"`
+		EndTime          string         `json:"end_time,omitempty"`
+		DurationMs       int64          `json:"duration_ms"`
+		RetryCount       int            `json:"retry_count"`
+		FallbacksUsed    []FallbackInfo `json:"fallbacks_used,omitempty"`
+		MemoryUsage      int64          `json:"memory_usage,omitempty"`
+		TempFilesCreated []string       `json:"temp_files_created,omitempty"`
+		Warnings         []string       `json:"warnings,omitempty"`
+	}{
+		Phase:            m.Phase,
+		StartTime:        m.StartTime.Format(time.RFC3339),
+		EndTime:          endTime,
+		DurationMs:       duration.Milliseconds(),
+		RetryCount:       m.RetryCount,
+		FallbacksUsed:    m.FallbacksUsed,
+		MemoryUsage:      m.MemoryUsage,
+		TempFilesCreated: m.TempFilesCreated,
+		Warnings:         m.Warnings,
+	})
+}
+
 // RetryConfig defines retry behavior for export operations
 type RetryConfig struct {
 	MaxRetries      int               `json:"max_retries"`
@@ -124,8 +236,13 @@ type Service struct {
 	tmpDir       string
 	retryConfig  RetryConfig
 	metrics      map[string]*ExportMetrics     // Track metrics per export operation
+	metricsMutex sync.RWMutex                  // Protect concurrent access to metrics
+	retention    time.Duration                 // How long finished exports' metrics are kept
 	browsers     map[string]*BrowserAutomation // Track browser automation instances
 	browserMutex sync.RWMutex                  // Protect concurrent access to browsers
+
+	fallbacks     map[ExportFormat][]ExportFormat // Formats tried when an export fails
+	fallbackMutex sync.RWMutex
 }
 
 // Renderer interface for different export formats
@@ -171,8 +288,13 @@ func NewService(tmpDir string) (*Service, error) {
 		tmpDir:       tmpDir,
 		retryConfig:  retryConfig,
 		metrics:      make(map[string]*ExportMetrics),
+		retention:    DefaultMetricsRetention,
 		browsers:     make(map[string]*BrowserAutomation),
 		browserMutex: sync.RWMutex{},
+		fallbacks:    make(map[ExportFormat][]ExportFormat, len(DefaultFallbacks)),
+	}
+	for format, chain := range DefaultFallbacks {
+		service.fallbacks[format] = append([]ExportFormat(nil), chain...)
 	}
 
 	// Register default renderers
@@ -180,6 +302,9 @@ func NewService(tmpDir string) (*Service, error) {
 	service.RegisterRenderer(FormatPDF, NewPDFRenderer())
 	service.RegisterRenderer(FormatImages, NewImageRenderer())
 	service.RegisterRenderer(FormatMarkdown, NewMarkdownRenderer())
+	service.RegisterRenderer(FormatPowerPoint, NewPowerPointRenderer())
+	service.RegisterRenderer(FormatJSON, NewJSONRenderer())
+	service.RegisterRenderer(FormatSVG, NewSVGRenderer())
 
 	return service, nil
 }
@@ -191,23 +316,37 @@ func (s *Service) RegisterRenderer(format ExportFormat, renderer Renderer) {
 
 // Export exports a presentation to the specified format with retry logic and comprehensive error handling
 func (s *Service) Export(ctx context.Context, presentation *entities.Presentation, options *ExportOptions) (*ExportResult, error) {
-	// Initialize metrics
-	operationID := fmt.Sprintf("%s-%d", options.Format, time.Now().UnixNano())
+	// Initialize metrics, tracked until a while after the export returns
+	operationID := options.OperationID
+	if operationID == "" {
+		operationID = NewOperationID(options.Format)
+	}
 	metrics := &ExportMetrics{
+		Phase:            PhaseValidating,
 		StartTime:        time.Now(),
 		TempFilesCreated: make([]string, 0),
 		Warnings:         make([]string, 0),
 		FallbacksUsed:    make([]FallbackInfo, 0),
 	}
-	s.metrics[operationID] = metrics
+	s.trackExport(operationID, metrics)
+	defer s.untrackExport(operationID, metrics)
 
 	// Validate options with detailed error categorization
 	if err := s.validateOptionsDetailed(options); err != nil {
-		metrics.EndTime = time.Now()
-		metrics.Duration = time.Since(metrics.StartTime)
+		s.finishMetrics(metrics)
 		return s.createErrorResult(err, metrics), err
 	}
 
+	// Narrow the deck to the requested slides before any renderer sees it
+	if options.SlideRange != "" {
+		selected, err := selectSlides(presentation, options.SlideRange)
+		if err != nil {
+			s.finishMetrics(metrics)
+			return s.createErrorResult(err, metrics), err
+		}
+		presentation = selected
+	}
+
 	// Get renderer for format
 	renderer, exists := s.renderers[options.Format]
 	if !exists {
@@ -218,31 +357,34 @@ func (s *Service) Export(ctx context.Context, presentation *entities.Presentatio
 			Code:      "UNSUPPORTED_FORMAT",
 			Retryable: false,
 		}
-		metrics.EndTime = time.Now()
-		metrics.Duration = time.Since(metrics.StartTime)
+		s.finishMetrics(metrics)
 		return s.createErrorResult(err, metrics), err
 	}
 
 	// Ensure output directory exists
 	if err := s.ensureOutputDirectory(options.OutputPath); err != nil {
-		metrics.EndTime = time.Now()
-		metrics.Duration = time.Since(metrics.StartTime)
+		s.finishMetrics(metrics)
 		return s.createErrorResult(err, metrics), err
 	}
 
-	// Perform export with retry logic
-	result, err := s.executeWithRetry(ctx, renderer, presentation, options, metrics)
+	// Perform export with retry logic, then the format's fallbacks
+	s.setPhase(metrics, PhaseRendering)
+	result, err := s.renderFormat(ctx, renderer, presentation, options, metrics)
+	if err != nil {
+		result, err = s.exportWithFallbacks(ctx, presentation, options, metrics, err)
+	}
 	if err != nil {
-		metrics.EndTime = time.Now()
-		metrics.Duration = time.Since(metrics.StartTime)
+		s.finishMetrics(metrics)
 		return s.createErrorResult(err, metrics), err
 	}
 
 	// Update metrics and result
-	metrics.EndTime = time.Now()
-	metrics.Duration = time.Since(metrics.StartTime)
+	s.setPhase(metrics, PhaseCompleted)
+	s.finishMetrics(metrics)
 	result.Duration = metrics.Duration.String()
 	result.GeneratedAt = metrics.EndTime
+	result.RetryCount = metrics.RetryCount
+	result.FallbacksUsed = metrics.FallbacksUsed
 
 	// Add metrics to result
 	if result.Metadata == nil {
@@ -250,12 +392,121 @@ func (s *Service) Export(ctx context.Context, presentation *entities.Presentatio
 	}
 	result.Metadata["export_metrics"] = metrics
 
-	// Cleanup metrics after successful export
-	delete(s.metrics, operationID)
+	return result, nil
+}
 
+// renderFormat exports a presentation with one renderer, resolving its
+// relative images for the options' format first
+func (s *Service) renderFormat(ctx context.Context, renderer Renderer, presentation *entities.Presentation, options *ExportOptions, metrics *ExportMetrics) (*ExportResult, error) {
+	// Relative images only resolve against the presentation's directory
+	var imageWarnings []string
+	if options.SourceDir != "" {
+		resolved, warnings, err := resolveLocalImages(presentation, options)
+		if err != nil {
+			return nil, &ExportError{
+				Type:      ErrorTypeFilesystem,
+				Message:   "failed to resolve slide images",
+				Details:   err.Error(),
+				Retryable: false,
+				Cause:     err,
+			}
+		}
+		presentation = resolved
+		imageWarnings = warnings
+	}
+
+	result, err := s.executeWithRetry(ctx, renderer, presentation, options, metrics)
+	if err != nil {
+		return nil, err
+	}
+	result.Warnings = append(imageWarnings, result.Warnings...)
 	return result, nil
 }
 
+// operationCounter disambiguates operation IDs if the random source fails
+var operationCounter atomic.Uint64
+
+// NewOperationID returns an ID for an export operation. The random suffix
+// keeps IDs unique when concurrent exports start within the same clock tick.
+func NewOperationID(format ExportFormat) string {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Sprintf("%s-%d-%d", format, time.Now().UnixNano(), operationCounter.Add(1))
+	}
+	return fmt.Sprintf("%s-%d-%s", format, time.Now().UnixNano(), hex.EncodeToString(suffix))
+}
+
+// trackExport records the metrics of a running export
+func (s *Service) trackExport(operationID string, metrics *ExportMetrics) {
+	s.metricsMutex.Lock()
+	defer s.metricsMutex.Unlock()
+	s.metrics[operationID] = metrics
+}
+
+// untrackExport drops the metrics of an export once the retention period
+// has passed since it returned, whether it succeeded or not
+func (s *Service) untrackExport(operationID string, metrics *ExportMetrics) {
+	s.metricsMutex.Lock()
+	retention := s.retention
+	s.metricsMutex.Unlock()
+
+	drop := func() {
+		s.metricsMutex.Lock()
+		defer s.metricsMutex.Unlock()
+		if s.metrics[operationID] == metrics {
+			delete(s.metrics, operationID)
+		}
+	}
+	if retention <= 0 {
+		drop()
+		return
+	}
+	time.AfterFunc(retention, drop)
+}
+
+// SetMetricsRetention sets how long the metrics of finished exports stay
+// available; zero drops them as soon as the export returns
+func (s *Service) SetMetricsRetention(retention time.Duration) {
+	s.metricsMutex.Lock()
+	defer s.metricsMutex.Unlock()
+	s.retention = retention
+}
+
+// GetExportMetrics returns a snapshot of the metrics of a running or
+// recently finished export
+func (s *Service) GetExportMetrics(operationID string) (ExportMetrics, bool) {
+	s.metricsMutex.RLock()
+	defer s.metricsMutex.RUnlock()
+	metrics, ok := s.metrics[operationID]
+	if !ok {
+		return ExportMetrics{}, false
+	}
+	snapshot := *metrics
+	snapshot.FallbacksUsed = append([]FallbackInfo(nil), metrics.FallbacksUsed...)
+	snapshot.TempFilesCreated = append([]string(nil), metrics.TempFilesCreated...)
+	snapshot.Warnings = append([]string(nil), metrics.Warnings...)
+	return snapshot, true
+}
+
+// setPhase records the step an export is at
+func (s *Service) setPhase(metrics *ExportMetrics, phase ExportPhase) {
+	s.metricsMutex.Lock()
+	defer s.metricsMutex.Unlock()
+	metrics.Phase = phase
+}
+
+// finishMetrics records when an export ended, as failed unless it was
+// already marked completed
+func (s *Service) finishMetrics(metrics *ExportMetrics) {
+	s.metricsMutex.Lock()
+	defer s.metricsMutex.Unlock()
+	if metrics.Phase != PhaseCompleted {
+		metrics.Phase = PhaseFailed
+	}
+	metrics.EndTime = time.Now()
+	metrics.Duration = metrics.EndTime.Sub(metrics.StartTime)
+}
+
 // GetSupportedFormats returns a list of supported export formats
 func (s *Service) GetSupportedFormats() []ExportFormat {
 	formats := make([]ExportFormat, 0, len(s.renderers))
@@ -329,6 +580,19 @@ func (s *Service) validateOptionsDetailed(options *ExportOptions) error {
 		}
 	}
 
+	// Per-slide formats create a directory at the output path
+	if options.Format.WritesDirectory() {
+		if info, err := os.Stat(options.OutputPath); err == nil && !info.IsDir() {
+			return &ExportError{
+				Type:      ErrorTypeValidation,
+				Message:   "output path must be a directory",
+				Details:   options.OutputPath + " is a file; " + string(options.Format) + " exports write one file per slide",
+				Code:      "OUTPUT_NOT_DIRECTORY",
+				Retryable: false,
+			}
+		}
+	}
+
 	// Validate quality setting
 	if options.Quality != "" {
 		validQualities := map[string]bool{"low": true, "medium": true, "high": true}
@@ -371,6 +635,50 @@ func (s *Service) validateOptionsDetailed(options *ExportOptions) error {
 		}
 	}
 
+	if options.ContactSheetColumns < 0 {
+		return &ExportError{
+			Type:      ErrorTypeValidation,
+			Message:   "invalid contact sheet columns",
+			Details:   fmt.Sprintf("%d (must not be negative)", options.ContactSheetColumns),
+			Code:      "INVALID_CONTACT_SHEET_COLUMNS",
+			Retryable: false,
+		}
+	}
+
+	// Validate page margins
+	for _, margin := range []struct{ side, value string }{
+		{"top", options.MarginTop},
+		{"right", options.MarginRight},
+		{"bottom", options.MarginBottom},
+		{"left", options.MarginLeft},
+	} {
+		if margin.value == "" {
+			continue
+		}
+		if _, err := parseMargin(margin.value); err != nil {
+			return &ExportError{
+				Type:      ErrorTypeValidation,
+				Message:   "invalid " + margin.side + " margin",
+				Details:   err.Error(),
+				Code:      "INVALID_MARGIN",
+				Retryable: false,
+				Cause:     err,
+			}
+		}
+	}
+
+	// Validate metadata embedded in exported documents
+	if err := validateMetadata(options.Metadata); err != nil {
+		return &ExportError{
+			Type:      ErrorTypeValidation,
+			Message:   "invalid export metadata",
+			Details:   err.Error(),
+			Code:      "INVALID_METADATA",
+			Retryable: false,
+			Cause:     err,
+		}
+	}
+
 	return nil
 }
 
@@ -409,7 +717,10 @@ func (s *Service) executeWithRetry(ctx context.Context, renderer Renderer, prese
 					Cause:     ctx.Err(),
 				}
 			}
+			s.metricsMutex.Lock()
 			metrics.RetryCount = attempt
+			metrics.Phase = PhaseRetrying
+			s.metricsMutex.Unlock()
 		}
 
 		// Attempt the export
@@ -429,8 +740,10 @@ func (s *Service) executeWithRetry(ctx context.Context, renderer Renderer, prese
 		}
 
 		// Log retry attempt
+		s.metricsMutex.Lock()
 		metrics.Warnings = append(metrics.Warnings,
 			fmt.Sprintf("Attempt %d failed: %s (retrying)", attempt+1, exportErr.Message))
+		s.metricsMutex.Unlock()
 	}
 
 	return nil, s.categorizeError(lastErr)
@@ -471,28 +784,31 @@ func (s *Service) categorizeError(err error) *ExportError {
 	}
 
 	errMsg := err.Error()
+	lowerMsg := strings.ToLower(errMsg)
 
-	// Categorize based on error message patterns
+	// Categorize based on error message patterns. Browser failures are checked
+	// first so that Chrome startup timeouts are reported as browser errors.
 	switch {
-	case strings.Contains(errMsg, "timeout") || strings.Contains(errMsg, "deadline"):
+	case strings.Contains(lowerMsg, "chrome") || strings.Contains(lowerMsg, "chromium") ||
+		strings.Contains(lowerMsg, "browser") || strings.Contains(lowerMsg, "headless"):
 		return &ExportError{
-			Type:      ErrorTypeTimeout,
-			Message:   "operation timed out",
+			Type:      ErrorTypeBrowser,
+			Message:   "browser automation failed",
 			Details:   errMsg,
-			Code:      "TIMEOUT",
+			Code:      "BROWSER_ERROR",
 			Retryable: true,
 			Cause:     err,
 		}
-	case strings.Contains(errMsg, "chrome") || strings.Contains(errMsg, "browser") || strings.Contains(errMsg, "headless"):
+	case strings.Contains(lowerMsg, "timeout") || strings.Contains(lowerMsg, "deadline"):
 		return &ExportError{
-			Type:      ErrorTypeBrowser,
-			Message:   "browser automation failed",
+			Type:      ErrorTypeTimeout,
+			Message:   "operation timed out",
 			Details:   errMsg,
-			Code:      "BROWSER_ERROR",
+			Code:      "TIMEOUT",
 			Retryable: true,
 			Cause:     err,
 		}
-	case strings.Contains(errMsg, "memory") || strings.Contains(errMsg, "out of memory"):
+	case strings.Contains(lowerMsg, "memory") || strings.Contains(lowerMsg, "out of memory"):
 		return &ExportError{
 			Type:      ErrorTypeMemory,
 			Message:   "insufficient memory",
@@ -501,7 +817,7 @@ func (s *Service) categorizeError(err error) *ExportError {
 			Retryable: true,
 			Cause:     err,
 		}
-	case strings.Contains(errMsg, "permission") || strings.Contains(errMsg, "access"):
+	case strings.Contains(lowerMsg, "permission") || strings.Contains(lowerMsg, "access"):
 		return &ExportError{
 			Type:      ErrorTypeFilesystem,
 			Message:   "file access denied",
@@ -510,7 +826,7 @@ func (s *Service) categorizeError(err error) *ExportError {
 			Retryable: false,
 			Cause:     err,
 		}
-	case strings.Contains(errMsg, "network") || strings.Contains(errMsg, "connection"):
+	case strings.Contains(lowerMsg, "network") || strings.Contains(lowerMsg, "connection"):
 		return &ExportError{
 			Type:      ErrorTypeNetwork,
 			Message:   "network error",
@@ -545,6 +861,9 @@ func (s *Service) createErrorResult(err error, metrics *ExportMetrics) *ExportRe
 		GeneratedAt: metrics.EndTime,
 		Warnings:    metrics.Warnings,
 		Metadata:    make(map[string]interface{}),
+
+		RetryCount:    metrics.RetryCount,
+		FallbacksUsed: metrics.FallbacksUsed,
 	}
 
 	// Add error details to metadata
@@ -569,6 +888,9 @@ func (s *Service) GetRetryConfig() RetryConfig {
 
 // GetActiveExports returns information about currently running exports
 func (s *Service) GetActiveExports() map[string]*ExportMetrics {
+	s.metricsMutex.RLock()
+	defer s.metricsMutex.RUnlock()
+
 	activeExports := make(map[string]*ExportMetrics)
 	for id, metrics := range s.metrics {
 		if metrics.EndTime.IsZero() {
@@ -678,7 +1000,9 @@ func (s *Service) Cleanup() error {
 	}
 
 	// Clear active export metrics (they should be completed by now)
+	s.metricsMutex.Lock()
 	s.metrics = make(map[string]*ExportMetrics)
+	s.metricsMutex.Unlock()
 
 	if len(errs) > 0 {
 		return fmt.Errorf("cleanup errors: %v", errs)
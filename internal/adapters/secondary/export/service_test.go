@@ -3,8 +3,11 @@ package export
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -45,7 +48,7 @@ func TestNewService(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, service)
 		assert.Equal(t, os.TempDir(), service.tmpDir)
-		assert.Len(t, service.renderers, 4) // HTML, PDF, Images, Markdown
+		assert.Len(t, service.renderers, 7) // HTML, PDF, Images, Markdown, PowerPoint, JSON, SVG
 	})
 
 	t.Run("creates service with custom temp directory", func(t *testing.T) {
@@ -181,6 +184,124 @@ func TestService_Export(t *testing.T) {
 	})
 }
 
+func TestService_ConcurrentExports(t *testing.T) {
+	presentation := builders.NewPresentationBuilder().
+		WithTitle("Concurrent Export").
+		WithSlideCount(2).
+		Build()
+
+	testService, err := NewService(t.TempDir())
+	require.NoError(t, err)
+	testService.SetRetryConfig(RetryConfig{MaxRetries: 0})
+	testService.SetMetricsRetention(0)
+
+	var active sync.Map
+	succeeding := new(MockRenderer)
+	succeeding.On("Render", mock.Anything, presentation, mock.Anything).
+		Run(func(mock.Arguments) {
+			for id := range testService.GetActiveExports() {
+				active.Store(id, true)
+			}
+		}).
+		Return(&ExportResult{Success: true, Format: string(FormatHTML)}, nil)
+	failing := new(MockRenderer)
+	failing.On("Render", mock.Anything, presentation, mock.Anything).Return(nil, errors.New("rendering failed"))
+	testService.RegisterRenderer(FormatHTML, succeeding)
+	testService.RegisterRenderer(FormatJSON, failing)
+
+	outputDir := t.TempDir()
+	formats := []ExportFormat{FormatHTML, FormatJSON, ExportFormat("unsupported")}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 60; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			format := formats[i%len(formats)]
+			_, _ = testService.Export(context.Background(), presentation, &ExportOptions{
+				Format:     format,
+				OutputPath: filepath.Join(outputDir, fmt.Sprintf("out-%d.%s", i, format)),
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Empty(t, testService.GetActiveExports(), "metrics leaked for finished exports")
+	testService.metricsMutex.RLock()
+	assert.Empty(t, testService.metrics)
+	testService.metricsMutex.RUnlock()
+
+	seen := 0
+	active.Range(func(any, any) bool {
+		seen++
+		return true
+	})
+	assert.GreaterOrEqual(t, seen, 20, "each running export should have been tracked")
+}
+
+func TestService_GetExportMetrics(t *testing.T) {
+	presentation := builders.NewPresentationBuilder().WithSlideCount(1).Build()
+	testService, err := NewService(t.TempDir())
+	require.NoError(t, err)
+	testService.SetRetryConfig(RetryConfig{MaxRetries: 0})
+	testService.SetMetricsRetention(100 * time.Millisecond)
+
+	var during ExportMetrics
+	renderer := new(MockRenderer)
+	renderer.On("Render", mock.Anything, presentation, mock.Anything).
+		Run(func(mock.Arguments) {
+			during, _ = testService.GetExportMetrics("deck-export")
+		}).
+		Return(&ExportResult{Success: true, Format: string(FormatHTML)}, nil)
+	testService.RegisterRenderer(FormatHTML, renderer)
+
+	_, err = testService.Export(context.Background(), presentation, &ExportOptions{
+		Format:      FormatHTML,
+		OutputPath:  filepath.Join(t.TempDir(), "deck.html"),
+		OperationID: "deck-export",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, PhaseRendering, during.Phase)
+	assert.True(t, during.EndTime.IsZero())
+
+	finished, ok := testService.GetExportMetrics("deck-export")
+	require.True(t, ok, "finished exports are kept for the retention period")
+	assert.Equal(t, PhaseCompleted, finished.Phase)
+	assert.False(t, finished.EndTime.IsZero())
+	assert.Empty(t, testService.GetActiveExports())
+
+	assert.Eventually(t, func() bool {
+		_, ok := testService.GetExportMetrics("deck-export")
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewOperationID(t *testing.T) {
+	const workers, perWorker = 8, 500
+
+	ids := make(chan string, workers*perWorker)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				ids <- NewOperationID(FormatPDF)
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[string]bool)
+	for id := range ids {
+		assert.True(t, strings.HasPrefix(id, "pdf-"))
+		assert.False(t, seen[id], "duplicate operation ID %s", id)
+		seen[id] = true
+	}
+	assert.Len(t, seen, workers*perWorker)
+}
+
 func TestService_validateOptions(t *testing.T) {
 	service, err := NewService("")
 	require.NoError(t, err)
@@ -243,6 +364,37 @@ func TestService_validateOptions(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid orientation",
 		},
+		{
+			name: "invalid margin",
+			options: &ExportOptions{
+				Format:     FormatPDF,
+				OutputPath: "/tmp/test.pdf",
+				MarginTop:  "1cm",
+				MarginLeft: "wide",
+			},
+			expectError: true,
+			errorMsg:    "invalid left margin",
+		},
+		{
+			name: "negative margin",
+			options: &ExportOptions{
+				Format:       FormatPDF,
+				OutputPath:   "/tmp/test.pdf",
+				MarginBottom: "-5mm",
+			},
+			expectError: true,
+			errorMsg:    "invalid bottom margin",
+		},
+		{
+			name: "unknown margin unit",
+			options: &ExportOptions{
+				Format:      FormatPDF,
+				OutputPath:  "/tmp/test.pdf",
+				MarginRight: "2em",
+			},
+			expectError: true,
+			errorMsg:    "invalid right margin",
+		},
 		{
 			name: "valid options",
 			options: &ExportOptions{
@@ -251,6 +403,8 @@ func TestService_validateOptions(t *testing.T) {
 				Quality:     "high",
 				PageSize:    "A4",
 				Orientation: "landscape",
+				MarginTop:   "0.5in",
+				MarginLeft:  "10mm",
 			},
 			expectError: false,
 		},
@@ -274,11 +428,14 @@ func TestService_GetSupportedFormats(t *testing.T) {
 	require.NoError(t, err)
 
 	formats := service.GetSupportedFormats()
-	assert.Len(t, formats, 4)
+	assert.Len(t, formats, 7)
 	assert.Contains(t, formats, FormatHTML)
 	assert.Contains(t, formats, FormatPDF)
 	assert.Contains(t, formats, FormatImages)
 	assert.Contains(t, formats, FormatMarkdown)
+	assert.Contains(t, formats, FormatPowerPoint)
+	assert.Contains(t, formats, FormatJSON)
+	assert.Contains(t, formats, FormatSVG)
 }
 
 func TestService_GetTempDir(t *testing.T) {
@@ -447,3 +604,97 @@ func TestValidateFilePath(t *testing.T) {
 		})
 	}
 }
+
+func TestService_RetriesBrowserStartupFailure(t *testing.T) {
+	newService := func(t *testing.T, chrome string) (*Service, string) {
+		renderer, err := NewPDFRendererWithBrowser(BrowserConfig{
+			ExecutablePath:  chrome,
+			WarmupAttempts:  -1,
+			ConvertAttempts: 3,
+			ConvertDelay:    time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		tmpDir := t.TempDir()
+		service, err := NewService(tmpDir)
+		require.NoError(t, err)
+		service.RegisterRenderer(FormatPDF, renderer)
+		return service, tmpDir
+	}
+
+	t.Run("retries before falling back", func(t *testing.T) {
+		chrome, launches := fakeChrome(t, 1)
+		service, tmpDir := newService(t, chrome)
+
+		result, err := service.Export(context.Background(), builders.NewPresentationBuilder().Build(), &ExportOptions{
+			Format:     FormatPDF,
+			OutputPath: filepath.Join(tmpDir, "out.pdf"),
+		})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.Contains(t, result.Metadata, "dpi", "printed by Chrome, not the text-only fallback")
+		assert.Equal(t, 3, launches(), "two DevTools launches, then the --print-to-pdf fallback")
+
+		metrics, ok := result.Metadata["export_metrics"].(*ExportMetrics)
+		require.True(t, ok)
+		assert.Zero(t, metrics.RetryCount, "the renderer retried Chrome, not the whole export")
+	})
+
+	t.Run("falls back once the retries are used up", func(t *testing.T) {
+		chrome, launches := fakeChrome(t, 100)
+		service, tmpDir := newService(t, chrome)
+
+		result, err := service.Export(context.Background(), builders.NewPresentationBuilder().Build(), &ExportOptions{
+			Format:     FormatPDF,
+			OutputPath: filepath.Join(tmpDir, "out.pdf"),
+		})
+		require.NoError(t, err)
+		assert.True(t, result.Success)
+		assert.NotContains(t, result.Metadata, "dpi", "text-only fallback after the last attempt")
+		assert.Equal(t, 3, launches(), "one launch per attempt")
+	})
+}
+
+func TestService_categorizeError(t *testing.T) {
+	service, err := NewService(t.TempDir())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected ExportErrorType
+	}{
+		{"chrome startup timeout", errors.New("Chrome failed to start: timeout waiting for DevTools"), ErrorTypeBrowser},
+		{"chromium crash", errors.New("Chromium exited unexpectedly"), ErrorTypeBrowser},
+		{"plain deadline", errors.New("context deadline exceeded"), ErrorTypeTimeout},
+		{"permission", errors.New("Permission denied"), ErrorTypeFilesystem},
+		{"other", errors.New("template parse error"), ErrorTypeRenderer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, service.categorizeError(tt.err).Type)
+		})
+	}
+}
+
+func TestService_RejectsInvalidMargin(t *testing.T) {
+	service, err := NewService(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = service.Export(context.Background(), &entities.Presentation{
+		Title:  "Margins",
+		Slides: []entities.Slide{{Index: 0, HTML: "<h1>Margins</h1>"}},
+	}, &ExportOptions{
+		Format:     FormatPDF,
+		OutputPath: filepath.Join(t.TempDir(), "margins.pdf"),
+		MarginTop:  "half an inch",
+	})
+	require.Error(t, err)
+
+	var exportErr *ExportError
+	require.ErrorAs(t, err, &exportErr)
+	assert.Equal(t, "INVALID_MARGIN", exportErr.Code)
+	assert.Equal(t, ErrorTypeValidation, exportErr.Type)
+	assert.False(t, exportErr.Retryable)
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// ParseSlideRange turns a 1-based slide range such as "4-9" or "1,3,5-7"
+// into sorted, de-duplicated 0-based slide indices for a deck of total slides
+func ParseSlideRange(spec string, total int) ([]int, error) {
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("empty entry in %q", spec)
+		}
+
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := parseSlideNumber(first, total)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if isRange {
+			if end, err = parseSlideNumber(last, total); err != nil {
+				return nil, err
+			}
+			if end < start {
+				return nil, fmt.Errorf("range %q runs backwards", part)
+			}
+		}
+
+		for n := start; n <= end; n++ {
+			seen[n-1] = true
+		}
+	}
+
+	indices := make([]int, 0, len(seen))
+	for index := range seen {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	return indices, nil
+}
+
+// parseSlideNumber parses a 1-based slide number, checking it exists
+func parseSlideNumber(value string, total int) (int, error) {
+	value = strings.TrimSpace(value)
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a slide number", value)
+	}
+	if n < 1 || n > total {
+		return 0, fmt.Errorf("slide %d is out of range (the presentation has %d slides)", n, total)
+	}
+	return n, nil
+}
+
+// selectSlides returns a copy of presentation holding only the slides in spec
+func selectSlides(presentation *entities.Presentation, spec string) (*entities.Presentation, error) {
+	indices, err := ParseSlideRange(spec, len(presentation.Slides))
+	if err != nil {
+		return nil, &ExportError{
+			Type:      ErrorTypeValidation,
+			Message:   "invalid slide range",
+			Details:   err.Error(),
+			Code:      "INVALID_SLIDE_RANGE",
+			Retryable: false,
+			Cause:     err,
+		}
+	}
+
+	selected := *presentation
+	selected.Slides = make([]entities.Slide, len(indices))
+	for i, index := range indices {
+		selected.Slides[i] = presentation.Slides[index]
+	}
+	return &selected, nil
+}
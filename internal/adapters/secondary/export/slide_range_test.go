@@ -0,0 +1,111 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestParseSlideRange(t *testing.T) {
+	tests := []struct {
+		spec    string
+		indices []int
+	}{
+		{"4", []int{3}},
+		{"4-9", []int{3, 4, 5, 6, 7, 8}},
+		{"1,3,5-7", []int{0, 2, 4, 5, 6}},
+		{" 2 - 3 , 1 ", []int{0, 1, 2}},
+		{"5-7,6,1", []int{0, 4, 5, 6}},
+		{"10-10", []int{9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			indices, err := ParseSlideRange(tt.spec, 10)
+			require.NoError(t, err)
+			assert.Equal(t, tt.indices, indices)
+		})
+	}
+
+	for _, spec := range []string{"0", "11", "9-11", "", "1,,2", "a", "3-", "-2", "7-4", "1-2-3"} {
+		t.Run("rejects "+spec, func(t *testing.T) {
+			_, err := ParseSlideRange(spec, 10)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestService_ExportSlideRange(t *testing.T) {
+	presentation := &entities.Presentation{Title: "Deck"}
+	for i := 0; i < 10; i++ {
+		presentation.Slides = append(presentation.Slides, entities.Slide{Index: i, Title: fmt.Sprintf("Slide %d", i+1)})
+	}
+
+	titles := func(p *entities.Presentation) []string {
+		var titles []string
+		for _, slide := range p.Slides {
+			titles = append(titles, slide.Title)
+		}
+		return titles
+	}
+
+	tests := []struct {
+		name   string
+		spec   string
+		titles []string
+	}{
+		{"single slide", "4", []string{"Slide 4"}},
+		{"multiple slides", "1,3,9-10", []string{"Slide 1", "Slide 3", "Slide 9", "Slide 10"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, err := NewService(t.TempDir())
+			require.NoError(t, err)
+
+			var rendered *entities.Presentation
+			renderer := new(MockRenderer)
+			renderer.On("Render", mock.Anything, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { rendered = args.Get(1).(*entities.Presentation) }).
+				Return(&ExportResult{Success: true}, nil)
+			service.RegisterRenderer(FormatHTML, renderer)
+
+			_, err = service.Export(context.Background(), presentation, &ExportOptions{
+				Format:     FormatHTML,
+				OutputPath: filepath.Join(t.TempDir(), "deck.html"),
+				SlideRange: tt.spec,
+			})
+			require.NoError(t, err)
+
+			require.NotNil(t, rendered)
+			assert.Equal(t, tt.titles, titles(rendered))
+			assert.Equal(t, "Deck", rendered.Title)
+			assert.Len(t, presentation.Slides, 10, "the original presentation is left alone")
+		})
+	}
+
+	t.Run("out of range", func(t *testing.T) {
+		service, err := NewService(t.TempDir())
+		require.NoError(t, err)
+		renderer := new(MockRenderer)
+		service.RegisterRenderer(FormatHTML, renderer)
+
+		_, err = service.Export(context.Background(), presentation, &ExportOptions{
+			Format:     FormatHTML,
+			OutputPath: filepath.Join(t.TempDir(), "deck.html"),
+			SlideRange: "4-11",
+		})
+
+		var exportErr *ExportError
+		require.ErrorAs(t, err, &exportErr)
+		assert.Equal(t, "INVALID_SLIDE_RANGE", exportErr.Code)
+		assert.Equal(t, ErrorTypeValidation, exportErr.Type)
+		assert.Contains(t, exportErr.Details, "slide 11 is out of range")
+		renderer.AssertNotCalled(t, "Render", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,313 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// Namespaces of the elements in an SVG slide
+const (
+	svgNamespace   = "http://www.w3.org/2000/svg"
+	xhtmlNamespace = "http://www.w3.org/1999/xhtml"
+	mathNamespace  = "http://www.w3.org/1998/Math/MathML"
+	xlinkNamespace = "http://www.w3.org/1999/xlink"
+)
+
+// captureSlideScript shows the slide at the given index without transitions
+// and serializes a copy of it with every computed style inlined. Scripts are
+// dropped since SVG viewers won't run them.
+const captureSlideScript = `(function(index) {
+	var slides = document.querySelectorAll('.slide');
+	var slide = slides[index];
+	if (!slide) return null;
+
+	if (!document.getElementById('slicli-svg-capture')) {
+		var style = document.createElement('style');
+		style.id = 'slicli-svg-capture';
+		style.textContent = '.slide { transition: none !important; }';
+		document.head.appendChild(style);
+	}
+	slides.forEach(function(s) {
+		s.classList.remove('prev', 'next');
+		s.classList.toggle('active', s === slide);
+	});
+	void slide.offsetHeight;
+
+	var copy = slide.cloneNode(true);
+	(function inline(source, target) {
+		var computed = getComputedStyle(source);
+		var css = '';
+		for (var i = 0; i < computed.length; i++) {
+			css += computed[i] + ':' + computed.getPropertyValue(computed[i]) + ';';
+		}
+		target.setAttribute('style', css);
+		for (var j = 0; j < source.children.length; j++) {
+			inline(source.children[j], target.children[j]);
+		}
+	})(slide, copy);
+	copy.querySelectorAll('script').forEach(function(s) { s.remove(); });
+	copy.style.position = 'relative';
+	copy.style.left = '0';
+	copy.style.top = '0';
+
+	return new XMLSerializer().serializeToString(copy);
+})(%d)`
+
+// svgFallbackStyles lays out slides serialized without a browser, which keep
+// the export's stylesheet instead of computed styles
+const svgFallbackStyles = `.slide { position: relative; transition: none; opacity: 1; transform: none; }`
+
+// xmlNamePattern matches attribute names that are valid in XML
+var xmlNamePattern = regexp.MustCompile(`^[A-Za-z_][-A-Za-z0-9_.]*$`)
+
+// SVGRenderer implements export to one SVG file per slide, keeping diagrams
+// and text as vectors
+type SVGRenderer struct {
+	htmlRenderer      *HTMLRenderer
+	browserAutomation *BrowserAutomation
+}
+
+// NewSVGRenderer creates a new SVG renderer
+func NewSVGRenderer() *SVGRenderer {
+	// Initialize with default browser config
+	browserAutomation, _ := NewBrowserAutomation(BrowserConfig{})
+
+	return &SVGRenderer{
+		htmlRenderer:      NewHTMLRenderer(),
+		browserAutomation: browserAutomation,
+	}
+}
+
+// NewSVGRendererWithBrowser creates a new SVG renderer with custom browser config
+func NewSVGRendererWithBrowser(browserConfig BrowserConfig) (*SVGRenderer, error) {
+	browserAutomation, err := NewBrowserAutomation(browserConfig)
+	if err != nil {
+		return nil, fmt.Errorf("initializing browser automation: %w", err)
+	}
+
+	return &SVGRenderer{
+		htmlRenderer:      NewHTMLRenderer(),
+		browserAutomation: browserAutomation,
+	}, nil
+}
+
+// Render exports each slide of the presentation to an SVG file in the
+// output directory
+func (r *SVGRenderer) Render(ctx context.Context, presentation *entities.Presentation, options *ExportOptions) (*ExportResult, error) {
+	outputDir := options.OutputPath
+	if err := os.MkdirAll(outputDir, 0750); err != nil {
+		return nil, fmt.Errorf("creating output directory: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp("", "slicli-svg-*.html")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary HTML file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
+	if err := tmpFile.Close(); err != nil {
+		return nil, fmt.Errorf("closing temporary file: %w", err)
+	}
+
+	htmlOptions := &ExportOptions{
+		Format:           FormatHTML,
+		OutputPath:       tmpFile.Name(),
+		Theme:            options.Theme,
+		IncludeNotes:     false, // Notes aren't part of the slide images
+		IncludeMetadata:  options.IncludeMetadata,
+		Metadata:         options.Metadata,
+		ThemeFonts:       options.ThemeFonts,
+		InlineFonts:      options.InlineFonts,
+		InlineFontsLimit: options.InlineFontsLimit,
+	}
+	htmlResult, err := r.htmlRenderer.Render(ctx, presentation, htmlOptions)
+	if err != nil {
+		return nil, fmt.Errorf("generating HTML for SVG conversion: %w", err)
+	}
+
+	width, height := GetImageDimensions(options.Quality)
+	slides, err := r.captureSlides(ctx, tmpFile.Name(), len(presentation.Slides), width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	var totalSize int64
+	for i, slide := range slides {
+		var title string
+		if i < len(presentation.Slides) {
+			title = presentation.Slides[i].Title
+		}
+		path := filepath.Join(outputDir, fmt.Sprintf("slide-%03d.svg", i+1))
+		if err := os.WriteFile(path, []byte(slideSVG(slide, title, width, height)), 0600); err != nil {
+			return nil, fmt.Errorf("writing SVG for slide %d: %w", i+1, err)
+		}
+		files = append(files, path)
+		if size, err := GetFileSize(path); err == nil {
+			totalSize += size
+		}
+	}
+
+	return &ExportResult{
+		Success:    true,
+		Format:     string(FormatSVG),
+		OutputPath: outputDir,
+		FileSize:   totalSize,
+		PageCount:  len(presentation.Slides),
+		Files:      files,
+		Warnings:   htmlResult.Warnings,
+	}, nil
+}
+
+// captureSlides serializes the slides of an exported HTML file as XHTML,
+// through Chrome when it's available and from the markup otherwise
+func (r *SVGRenderer) captureSlides(ctx context.Context, htmlPath string, count, width, height int) ([]string, error) {
+	if r.browserAutomation != nil && r.browserAutomation.IsAvailable(ctx) == nil {
+		if slides, err := r.browserAutomation.CaptureSlides(ctx, htmlPath, count, width, height); err == nil {
+			return slides, nil
+		}
+	}
+	return fallbackSlideCapture(htmlPath, width, height)
+}
+
+// fallbackSlideCapture serializes the slides of an exported HTML file as
+// XHTML without a browser, each carrying the page's stylesheet
+func fallbackSlideCapture(htmlPath string, width, height int) ([]string, error) {
+	content, err := os.ReadFile(htmlPath) // #nosec G304 - temporary file written by this renderer
+	if err != nil {
+		return nil, fmt.Errorf("reading HTML for SVG conversion: %w", err)
+	}
+	doc, err := html.Parse(strings.NewReader(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("parsing HTML for SVG conversion: %w", err)
+	}
+
+	var styles strings.Builder
+	var slides []*html.Node
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch {
+			case n.Data == "style" && n.FirstChild != nil:
+				styles.WriteString(n.FirstChild.Data)
+			case n.Data == "div" && hasClass(n, "slide"):
+				slides = append(slides, n)
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	styles.WriteString(svgFallbackStyles)
+
+	captured := make([]string, 0, len(slides))
+	for _, slide := range slides {
+		var b strings.Builder
+		fmt.Fprintf(&b, `<div xmlns="%s" style="width: %dpx; height: %dpx; overflow: hidden;"><style>%s</style>`,
+			xhtmlNamespace, width, height, html.EscapeString(styles.String()))
+		writeXHTML(&b, slide, xhtmlNamespace)
+		b.WriteString("</div>")
+		captured = append(captured, b.String())
+	}
+	return captured, nil
+}
+
+// hasClass reports whether an element has the given class
+func hasClass(n *html.Node, class string) bool {
+	for _, attr := range n.Attr {
+		if attr.Namespace == "" && attr.Key == "class" {
+			for _, c := range strings.Fields(attr.Val) {
+				if c == class {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// writeXHTML serializes an HTML node as well-formed XML, declaring the
+// namespace of elements that leave the parent's
+func writeXHTML(b *strings.Builder, n *html.Node, parentNamespace string) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(n.Data))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+	if n.Data == "script" {
+		return
+	}
+
+	namespace := xhtmlNamespace
+	switch n.Namespace {
+	case "svg":
+		namespace = svgNamespace
+	case "math":
+		namespace = mathNamespace
+	}
+
+	b.WriteString("<" + n.Data)
+	if namespace != parentNamespace {
+		fmt.Fprintf(b, ` xmlns="%s"`, namespace)
+	}
+	for _, attr := range n.Attr {
+		key := attr.Key
+		switch {
+		case attr.Namespace == "xlink" || attr.Namespace == "xml":
+			key = attr.Namespace + ":" + key
+		case attr.Namespace != "" || !xmlNamePattern.MatchString(key):
+			continue
+		}
+		// The captured slide is always shown
+		val := attr.Val
+		if key == "class" && attr.Namespace == "" && hasClass(n, "slide") {
+			val += " active"
+		}
+		fmt.Fprintf(b, ` %s="%s"`, key, html.EscapeString(val))
+	}
+
+	if n.FirstChild == nil {
+		b.WriteString("/>")
+		return
+	}
+	b.WriteString(">")
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeXHTML(b, c, namespace)
+	}
+	b.WriteString("</" + n.Data + ">")
+}
+
+// slideSVG wraps a slide serialized as XHTML in an SVG document of the
+// given size
+func slideSVG(slide, title string, width, height int) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="%s" xmlns:xlink="%s" width="%d" height="%d" viewBox="0 0 %d %d">
+<title>%s</title>
+<foreignObject x="0" y="0" width="%d" height="%d">
+%s
+</foreignObject>
+</svg>
+`, svgNamespace, xlinkNamespace, width, height, width, height, html.EscapeString(title), width, height, slide)
+}
+
+// Supports returns true if this renderer supports the given format
+func (r *SVGRenderer) Supports(format ExportFormat) bool {
+	return format == FormatSVG
+}
+
+// GetMimeType returns the MIME type of the SVG files
+func (r *SVGRenderer) GetMimeType() string {
+	return "image/svg+xml"
+}
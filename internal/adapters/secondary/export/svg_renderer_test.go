@@ -0,0 +1,116 @@
+package export
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// svgRoot parses an SVG file, returning its root element name and text
+func svgRoot(t *testing.T, path string) (xml.Name, string) {
+	t.Helper()
+	file, err := os.Open(path) // #nosec G304 - test file
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	var root xml.Name
+	var text []byte
+	decoder := xml.NewDecoder(file)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return root, string(text)
+		}
+		require.NoError(t, err, "%s should be well-formed XML", path)
+		switch tok := token.(type) {
+		case xml.StartElement:
+			if root.Local == "" {
+				root = tok.Name
+			}
+		case xml.CharData:
+			text = append(text, tok...)
+		}
+	}
+}
+
+func TestSVGRenderer_Render(t *testing.T) {
+	presentation := &entities.Presentation{
+		Title: "Vectors",
+		Slides: []entities.Slide{
+			{Index: 0, Title: "Intro", HTML: `<h1>Intro &amp; goals</h1><p>line<br>break</p><img src="logo.png" alt="logo">`},
+			{Index: 1, Title: "Diagram", HTML: `<svg viewBox="0 0 10 10"><circle cx="5" cy="5" r="4"></circle></svg><script>alert(1)</script>`},
+		},
+	}
+
+	t.Run("without a browser", func(t *testing.T) {
+		renderer := &SVGRenderer{htmlRenderer: NewHTMLRenderer()}
+		outputDir := filepath.Join(t.TempDir(), "slides")
+
+		result, err := renderer.Render(context.Background(), presentation, &ExportOptions{
+			Format:     FormatSVG,
+			OutputPath: outputDir,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, string(FormatSVG), result.Format)
+		assert.Equal(t, 2, result.PageCount)
+		require.Len(t, result.Files, 2)
+		assert.Equal(t, filepath.Join(outputDir, "slide-001.svg"), result.Files[0])
+		assert.Equal(t, filepath.Join(outputDir, "slide-002.svg"), result.Files[1])
+
+		for _, file := range result.Files {
+			root, _ := svgRoot(t, file)
+			assert.Equal(t, xml.Name{Space: svgNamespace, Local: "svg"}, root)
+		}
+
+		_, text := svgRoot(t, result.Files[0])
+		assert.Contains(t, text, "Intro & goals")
+
+		diagram, err := os.ReadFile(result.Files[1])
+		require.NoError(t, err)
+		assert.Contains(t, string(diagram), `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 10 10"><circle`)
+		assert.NotContains(t, string(diagram), "alert(1)")
+	})
+
+	t.Run("captures through the DevTools protocol", func(t *testing.T) {
+		chrome, _ := fakeCDPChrome(t)
+		renderer, err := NewSVGRendererWithBrowser(BrowserConfig{ExecutablePath: chrome})
+		require.NoError(t, err)
+
+		result, err := renderer.Render(context.Background(), presentation, &ExportOptions{
+			Format:     FormatSVG,
+			OutputPath: filepath.Join(t.TempDir(), "slides"),
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Files, 2)
+
+		for _, file := range result.Files {
+			root, text := svgRoot(t, file)
+			assert.Equal(t, "svg", root.Local)
+			assert.Contains(t, text, "Captured")
+		}
+	})
+}
+
+func TestService_ValidatesSVGOutputPath(t *testing.T) {
+	service, err := NewService(t.TempDir())
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "deck.svg")
+	require.NoError(t, os.WriteFile(file, nil, 0600))
+
+	err = service.validateOptionsDetailed(&ExportOptions{Format: FormatSVG, OutputPath: file})
+	var exportErr *ExportError
+	require.ErrorAs(t, err, &exportErr)
+	assert.Equal(t, "OUTPUT_NOT_DIRECTORY", exportErr.Code)
+
+	assert.NoError(t, service.validateOptionsDetailed(&ExportOptions{Format: FormatSVG, OutputPath: filepath.Dir(file)}))
+}
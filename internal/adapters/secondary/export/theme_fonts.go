@@ -0,0 +1,165 @@
+package export
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fredcamaral/slicli/internal/adapters/secondary/theme"
+)
+
+// DefaultInlineFontsLimit caps the total size of fonts inlined into one
+// export when ExportOptions.InlineFontsLimit is unset
+const DefaultInlineFontsLimit = 8 << 20
+
+// googleFontsCSSURL serves the stylesheet for Google fonts without a URL
+const googleFontsCSSURL = "https://fonts.googleapis.com/css2"
+
+// fontDownloadTimeout bounds each font or font stylesheet download
+const fontDownloadTimeout = 30 * time.Second
+
+// googleFontsUserAgent makes Google Fonts serve woff2 files, which are the
+// smallest to inline
+const googleFontsUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+
+var (
+	cssImportPattern = regexp.MustCompile(`@import\s+(?:url\(\s*['"]?([^'")\s]+)['"]?\s*\)|['"]([^'"]+)['"])[^;]*;`)
+	fontFacePattern  = regexp.MustCompile(`(?s)@font-face\s*{[^}]*}`)
+	cssURLPattern    = regexp.MustCompile(`url\(\s*['"]?([^'")\s]+)['"]?\s*\)`)
+)
+
+// themeFontCSS declares the theme's fonts: local and url fonts as @font-face
+// rules, Google fonts as an @import of their stylesheet. The first font
+// becomes the body font.
+func themeFontCSS(fonts []theme.ThemeFont) string {
+	var imports, faces strings.Builder
+	for _, font := range fonts {
+		name := cssStringSafe(font.Name)
+		switch font.Source {
+		case "google":
+			fmt.Fprintf(&imports, "@import url('%s');\n", cssStringSafe(googleFontURL(font)))
+		case "local", "url":
+			if font.URL != "" {
+				fmt.Fprintf(&faces, "@font-face { font-family: '%s'; src: url('%s'); }\n", name, cssStringSafe(font.URL))
+			}
+		}
+	}
+
+	if len(fonts) > 0 && fonts[0].Name != "" {
+		stack := []string{"'" + cssStringSafe(fonts[0].Name) + "'"}
+		for _, fallback := range fonts[0].Fallbacks {
+			if fallback = strings.Trim(cssStringSafe(fallback), " ;{}"); fallback != "" {
+				stack = append(stack, fallback)
+			}
+		}
+		fmt.Fprintf(&faces, "body { font-family: %s; }\n", strings.Join(stack, ", "))
+	}
+
+	return imports.String() + faces.String()
+}
+
+// googleFontURL returns the stylesheet URL of a Google font
+func googleFontURL(font theme.ThemeFont) string {
+	if font.URL != "" {
+		return font.URL
+	}
+	return googleFontsCSSURL + "?family=" + strings.ReplaceAll(url.QueryEscape(font.Name), "%20", "+") + "&display=swap"
+}
+
+// fontInliner rewrites the font URLs of a stylesheet as data URIs, keeping
+// the original URL of fonts that can't be fetched or don't fit in the limit
+type fontInliner struct {
+	client   *http.Client
+	limit    int64
+	total    int64
+	warnings []string
+}
+
+// newFontInliner creates a font inliner that inlines up to limit bytes,
+// DefaultInlineFontsLimit when limit is zero
+func newFontInliner(limit int64) *fontInliner {
+	if limit <= 0 {
+		limit = DefaultInlineFontsLimit
+	}
+	return &fontInliner{
+		client: &http.Client{Timeout: fontDownloadTimeout},
+		limit:  limit,
+	}
+}
+
+// inline replaces remote @import stylesheets with their content, then the
+// src URLs of every @font-face rule with data URIs
+func (f *fontInliner) inline(ctx context.Context, css string) string {
+	css = cssImportPattern.ReplaceAllStringFunc(css, func(rule string) string {
+		match := cssImportPattern.FindStringSubmatch(rule)
+		location := match[1] + match[2]
+		if !isRemoteURL(location) {
+			return rule
+		}
+		stylesheet, err := f.fetch(ctx, location)
+		if err != nil {
+			f.warnings = append(f.warnings, fmt.Sprintf("font stylesheet %s not inlined: %v", location, err))
+			return rule
+		}
+		return "/* " + cssStringSafe(location) + " */\n" + string(stylesheet) + "\n"
+	})
+
+	return fontFacePattern.ReplaceAllStringFunc(css, func(face string) string {
+		return cssURLPattern.ReplaceAllStringFunc(face, func(ref string) string {
+			location := cssURLPattern.FindStringSubmatch(ref)[1]
+			if strings.HasPrefix(location, "data:") {
+				return ref
+			}
+			data, err := f.fetch(ctx, location)
+			if err != nil {
+				f.warnings = append(f.warnings, fmt.Sprintf("font %s not inlined: %v", location, err))
+				return ref
+			}
+			if f.total+int64(len(data)) > f.limit {
+				f.warnings = append(f.warnings, fmt.Sprintf("font %s not inlined: inlined fonts would exceed %d bytes", location, f.limit))
+				return ref
+			}
+			f.total += int64(len(data))
+			mimeType, _ := fontFormat(data)
+			return fmt.Sprintf("url(data:%s;base64,%s)", mimeType, base64.StdEncoding.EncodeToString(data))
+		})
+	})
+}
+
+// fetch downloads a remote resource or reads a local file
+func (f *fontInliner) fetch(ctx context.Context, location string) ([]byte, error) {
+	if !isRemoteURL(location) {
+		return os.ReadFile(strings.TrimPrefix(location, "file://")) // #nosec G304 - font paths come from the theme
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", googleFontsUserAgent)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", path.Base(location), resp.Status)
+	}
+
+	// Reading one byte past the limit is enough to know a font won't fit
+	return io.ReadAll(io.LimitReader(resp.Body, f.limit+1))
+}
+
+// isRemoteURL reports whether a CSS URL is fetched over HTTP
+func isRemoteURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
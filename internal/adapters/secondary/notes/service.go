@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
@@ -14,11 +15,19 @@ import (
 	"github.com/fredcamaral/slicli/internal/domain/entities"
 )
 
-// Service implements the NotesService interface
+// Service implements the NotesService and TaskStateStore interfaces
 type Service struct {
 	notes      map[string]*entities.SpeakerNotes
+	tasks      map[string]map[int]bool
 	mu         sync.RWMutex
 	markdownMD goldmark.Markdown
+
+	// Set by NewFileService to persist notes to a sidecar file
+	path       string
+	writeDelay time.Duration
+	saveTimer  *time.Timer
+	saveMu     sync.Mutex // Serializes writes to path
+	saveErr    error
 }
 
 // NewService creates a new notes service
@@ -33,6 +42,7 @@ func NewService() *Service {
 
 	return &Service{
 		notes:      make(map[string]*entities.SpeakerNotes),
+		tasks:      make(map[string]map[int]bool),
 		markdownMD: md,
 	}
 }
@@ -71,6 +81,35 @@ func (s *Service) SetNotes(slideID string, notes *entities.SpeakerNotes) error {
 	notes.HTML = s.ConvertNotesToHTML(notes.Content)
 
 	s.notes[slideID] = notes
+	s.scheduleSaveLocked()
+	return nil
+}
+
+// GetTaskStates returns a copy of the task-list states recorded for a slide
+func (s *Service) GetTaskStates(slideID string) map[int]bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	states := make(map[int]bool, len(s.tasks[slideID]))
+	for task, checked := range s.tasks[slideID] {
+		states[task] = checked
+	}
+	return states
+}
+
+// SetTaskState records whether a task-list item on a slide is ticked
+func (s *Service) SetTaskState(slideID string, task int, checked bool) error {
+	if task < 0 {
+		return fmt.Errorf("invalid task index %d", task)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tasks[slideID] == nil {
+		s.tasks[slideID] = make(map[int]bool)
+	}
+	s.tasks[slideID][task] = checked
 	return nil
 }
 
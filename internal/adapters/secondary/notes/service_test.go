@@ -1,8 +1,13 @@
 package notes
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -116,6 +121,24 @@ func TestService_SetNotes(t *testing.T) {
 	})
 }
 
+func TestService_TaskStates(t *testing.T) {
+	service := NewService()
+
+	assert.Empty(t, service.GetTaskStates("slide-0"))
+
+	require.NoError(t, service.SetTaskState("slide-0", 1, true))
+	require.NoError(t, service.SetTaskState("slide-0", 2, false))
+	require.NoError(t, service.SetTaskState("slide-3", 0, true))
+	assert.Error(t, service.SetTaskState("slide-0", -1, true))
+
+	states := service.GetTaskStates("slide-0")
+	assert.Equal(t, map[int]bool{1: true, 2: false}, states)
+
+	// Returned states are a copy
+	states[1] = false
+	assert.True(t, service.GetTaskStates("slide-0")[1])
+}
+
 func TestService_ExtractNotes(t *testing.T) {
 	service := NewService()
 
@@ -503,3 +526,72 @@ More slide content here.`
 	assert.Contains(t, notes2.Content, "Different notes for slide 2")
 	assert.NotEqual(t, notes1.Content, notes2.Content)
 }
+
+func TestFileService_PersistsAcrossInstances(t *testing.T) {
+	deck := filepath.Join(t.TempDir(), "deck.md")
+	assert.Equal(t, strings.TrimSuffix(deck, ".md")+".notes.json", SidecarPath(deck))
+
+	service, err := NewFileService(deck)
+	require.NoError(t, err)
+	service.writeDelay = time.Hour // Only Flush writes
+
+	require.NoError(t, service.SetNotes("slide-0", &entities.SpeakerNotes{Content: "Open with the **demo**"}))
+	require.NoError(t, service.SetNotes("slide-3", &entities.SpeakerNotes{Content: "Draft"}))
+	require.NoError(t, service.SetNotes("slide-3", &entities.SpeakerNotes{Content: "Ask for questions"}))
+
+	_, err = os.Stat(SidecarPath(deck))
+	assert.True(t, os.IsNotExist(err), "writes wait for the debounce delay")
+
+	require.NoError(t, service.Close())
+
+	restored, err := NewFileService(deck)
+	require.NoError(t, err)
+
+	notes, err := restored.GetNotes("slide-0")
+	require.NoError(t, err)
+	assert.Equal(t, "Open with the **demo**", notes.Content)
+	assert.Contains(t, notes.HTML, "<strong>demo</strong>")
+
+	notes, err = restored.GetNotes("slide-3")
+	require.NoError(t, err)
+	assert.Equal(t, "Ask for questions", notes.Content)
+
+	t.Run("debounced write", func(t *testing.T) {
+		restored.writeDelay = 10 * time.Millisecond
+		require.NoError(t, restored.SetNotes("slide-0", &entities.SpeakerNotes{Content: "Skip the demo"}))
+
+		require.Eventually(t, func() bool {
+			data, err := os.ReadFile(SidecarPath(deck))
+			return err == nil && strings.Contains(string(data), "Skip the demo")
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("concurrent edits", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				assert.NoError(t, restored.SetNotes(fmt.Sprintf("slide-%d", i), &entities.SpeakerNotes{Content: fmt.Sprintf("note %d", i)}))
+			}(i)
+		}
+		wg.Wait()
+		require.NoError(t, restored.Flush())
+
+		reloaded, err := NewFileService(deck)
+		require.NoError(t, err)
+		for i := 0; i < 20; i++ {
+			notes, err := reloaded.GetNotes(fmt.Sprintf("slide-%d", i))
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("note %d", i), notes.Content)
+		}
+	})
+}
+
+func TestFileService_InvalidSidecar(t *testing.T) {
+	deck := filepath.Join(t.TempDir(), "deck.md")
+	require.NoError(t, os.WriteFile(SidecarPath(deck), []byte("{not json"), 0600))
+
+	_, err := NewFileService(deck)
+	assert.ErrorContains(t, err, "parsing notes file")
+}
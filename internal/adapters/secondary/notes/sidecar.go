@@ -0,0 +1,155 @@
+package notes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// DefaultWriteDelay is how long a file-backed service waits after a change
+// before writing the sidecar, so a burst of edits is written once
+const DefaultWriteDelay = 500 * time.Millisecond
+
+// sidecarVersion is the version of the sidecar file format
+const sidecarVersion = 1
+
+// sidecarFile is the on-disk form of the notes of a presentation
+type sidecarFile struct {
+	Version int                     `json:"version"`
+	Notes   map[string]sidecarEntry `json:"notes"`
+}
+
+// sidecarEntry holds the notes of one slide; HTML is rendered again on load
+type sidecarEntry struct {
+	Content string `json:"content"`
+}
+
+// SidecarPath returns the notes file kept next to a presentation, such as
+// deck.notes.json for deck.md
+func SidecarPath(presentationPath string) string {
+	return strings.TrimSuffix(presentationPath, filepath.Ext(presentationPath)) + ".notes.json"
+}
+
+// NewFileService creates a notes service that loads the notes saved next to
+// the presentation and writes changes back there
+func NewFileService(presentationPath string) (*Service, error) {
+	s := NewService()
+	s.path = SidecarPath(presentationPath)
+	s.writeDelay = DefaultWriteDelay
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading notes file: %w", err)
+	}
+
+	var file sidecarFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing notes file %s: %w", s.path, err)
+	}
+	if file.Version > sidecarVersion {
+		return nil, fmt.Errorf("notes file %s has unsupported version %d", s.path, file.Version)
+	}
+
+	for slideID, entry := range file.Notes {
+		s.notes[slideID] = &entities.SpeakerNotes{
+			SlideID: slideID,
+			Content: entry.Content,
+			HTML:    s.ConvertNotesToHTML(entry.Content),
+		}
+	}
+	return s, nil
+}
+
+// scheduleSaveLocked starts or restarts the debounce timer of a file-backed
+// service. The caller must hold s.mu.
+func (s *Service) scheduleSaveLocked() {
+	if s.path == "" {
+		return
+	}
+	if s.saveTimer != nil {
+		s.saveTimer.Stop()
+	}
+	s.saveTimer = time.AfterFunc(s.writeDelay, func() {
+		_ = s.save()
+	})
+}
+
+// Flush writes pending changes to the sidecar file right away, returning
+// the error of the last write
+func (s *Service) Flush() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	pending := s.saveTimer != nil && s.saveTimer.Stop()
+	s.mu.Unlock()
+
+	if pending {
+		return s.save()
+	}
+
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+	return s.saveErr
+}
+
+// Close writes pending changes to the sidecar file
+func (s *Service) Close() error {
+	return s.Flush()
+}
+
+// save writes a snapshot of the notes to the sidecar file, replacing it
+// atomically so a crash never leaves it half written
+func (s *Service) save() error {
+	s.saveMu.Lock()
+	defer s.saveMu.Unlock()
+
+	s.mu.RLock()
+	file := sidecarFile{Version: sidecarVersion, Notes: make(map[string]sidecarEntry, len(s.notes))}
+	for slideID, notes := range s.notes {
+		if !notes.IsEmpty() {
+			file.Notes[slideID] = sidecarEntry{Content: notes.Content}
+		}
+	}
+	s.mu.RUnlock()
+
+	s.saveErr = writeSidecar(s.path, file)
+	return s.saveErr
+}
+
+// writeSidecar writes the notes file through a temporary file in the same
+// directory
+func writeSidecar(path string, file sidecarFile) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding notes: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("creating notes file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("writing notes file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing notes file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("replacing notes file: %w", err)
+	}
+	return nil
+}
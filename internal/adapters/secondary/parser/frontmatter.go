@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// FrontMatterFormat is a syntax of metadata block a presentation can open with
+type FrontMatterFormat struct {
+	// Name identifies the format, such as "yaml"
+	Name string
+
+	// Delimiter is the line opening and closing the block
+	Delimiter string
+
+	// Unmarshal decodes the lines between the delimiters
+	Unmarshal func(data []byte, v interface{}) error
+
+	// Key matches a line setting a key, telling a malformed block from a
+	// slide that merely follows a separator
+	Key *regexp.Regexp
+}
+
+// FrontMatterFormats are the front matter syntaxes SplitFrontMatter
+// recognizes, tried in order. Another syntax can be supported by adding it.
+var FrontMatterFormats = []FrontMatterFormat{
+	{Name: "yaml", Delimiter: "---", Unmarshal: yaml.Unmarshal, Key: regexp.MustCompile(`(?m)^[A-Za-z_][\w-]*\s*:`)},
+	{Name: "toml", Delimiter: "+++", Unmarshal: toml.Unmarshal, Key: regexp.MustCompile(`(?m)^(?:[A-Za-z_][\w.-]*\s*=|\[)`)},
+}
+
+// frontMatterDateLayouts are the date formats accepted as a string date
+var frontMatterDateLayouts = []string{"2006-01-02", time.RFC3339}
+
+// SplitFrontMatter separates the front matter block a presentation opens
+// with from the markdown after it. Content without one, or whose block
+// doesn't decode to a set of keys, is returned unchanged with nil front
+// matter. An empty block gives empty front matter.
+func SplitFrontMatter(content []byte) (map[string]interface{}, []byte) {
+	for _, format := range FrontMatterFormats {
+		if frontMatter, remaining, ok := splitFrontMatter(content, format); ok {
+			return frontMatter, remaining
+		}
+	}
+	return nil, content
+}
+
+// CheckFrontMatter reports a front matter block that sets keys but doesn't
+// decode, which SplitFrontMatter would otherwise silently treat as a slide
+func CheckFrontMatter(content []byte) error {
+	for _, format := range FrontMatterFormats {
+		block, _, ok := frontMatterBlock(content, format)
+		if !ok || !format.Key.Match(block) {
+			continue
+		}
+		frontMatter := make(map[string]interface{})
+		return format.Unmarshal(block, &frontMatter)
+	}
+	return nil
+}
+
+// splitFrontMatter extracts a front matter block in one format
+func splitFrontMatter(content []byte, format FrontMatterFormat) (map[string]interface{}, []byte, bool) {
+	block, remaining, ok := frontMatterBlock(content, format)
+	if !ok {
+		return nil, nil, false
+	}
+
+	frontMatter := make(map[string]interface{})
+	if len(bytes.TrimSpace(block)) > 0 {
+		// A block without keys, such as a lone heading, is a slide that
+		// happens to follow a separator
+		if err := format.Unmarshal(block, &frontMatter); err != nil || len(frontMatter) == 0 {
+			return nil, nil, false
+		}
+	}
+
+	return frontMatter, remaining, true
+}
+
+// frontMatterBlock returns the lines between a format's delimiters at the
+// start of content and the markdown after them
+func frontMatterBlock(content []byte, format FrontMatterFormat) ([]byte, []byte, bool) {
+	delimiter := []byte(format.Delimiter)
+	if !bytes.HasPrefix(content, []byte(format.Delimiter+"\n")) && !bytes.HasPrefix(content, []byte(format.Delimiter+"\r\n")) {
+		return nil, nil, false
+	}
+
+	// Find the end of the block
+	lines := bytes.Split(content, []byte("\n"))
+	endIndex := -1
+	for i := 1; i < len(lines); i++ {
+		if bytes.Equal(bytes.TrimSpace(lines[i]), delimiter) {
+			endIndex = i
+			break
+		}
+	}
+	if endIndex == -1 {
+		return nil, nil, false
+	}
+
+	return bytes.Join(lines[1:endIndex], []byte("\n")), bytes.Join(lines[endIndex+1:], []byte("\n")), true
+}
+
+// ApplyFrontMatter copies the title, author, date, theme and tags set in
+// front matter to a presentation, keeping the rest of it as metadata.
+// Values of the wrong type and unparseable dates are ignored.
+func ApplyFrontMatter(p *entities.Presentation, frontMatter map[string]interface{}) {
+	p.Metadata = frontMatter
+
+	if title, ok := getStringFromMap(frontMatter, "title"); ok {
+		p.Title = title
+	}
+	if author, ok := getStringFromMap(frontMatter, "author"); ok {
+		p.Author = author
+	}
+	if theme, ok := getStringFromMap(frontMatter, "theme"); ok {
+		p.Theme = theme
+	}
+	if date, ok := frontMatterDate(frontMatter["date"]); ok {
+		p.Date = date
+	}
+	if tags := frontMatterTags(frontMatter["tags"]); tags != nil {
+		p.Tags = tags
+	}
+}
+
+// frontMatterDate reads a date written as a string or decoded as a TOML or
+// YAML timestamp
+func frontMatterDate(value interface{}) (time.Time, bool) {
+	switch date := value.(type) {
+	case time.Time:
+		return date, true
+	case string:
+		for _, layout := range frontMatterDateLayouts {
+			if parsed, err := time.Parse(layout, date); err == nil {
+				return parsed, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// frontMatterTags reads tags given as a list or a comma-separated string
+func frontMatterTags(value interface{}) []string {
+	var raw []string
+	switch list := value.(type) {
+	case []interface{}:
+		for _, item := range list {
+			if tag, ok := item.(string); ok {
+				raw = append(raw, tag)
+			}
+		}
+	case []string:
+		raw = list
+	case string:
+		raw = strings.Split(list, ",")
+	}
+
+	var tags []string
+	for _, tag := range raw {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestSplitFrontMatter(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		frontMatter, remaining := SplitFrontMatter([]byte("---\ntitle: Talk\nauthor: Ada\ndate: 2024-03-01\ntags: [go, slides]\n---\n# Intro"))
+		require.NotNil(t, frontMatter)
+		assert.Equal(t, "Talk", frontMatter["title"])
+		assert.Equal(t, "# Intro", string(remaining))
+
+		var p entities.Presentation
+		ApplyFrontMatter(&p, frontMatter)
+		assert.Equal(t, "Talk", p.Title)
+		assert.Equal(t, "Ada", p.Author)
+		assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), p.Date.UTC())
+		assert.Equal(t, []string{"go", "slides"}, p.Tags)
+	})
+
+	t.Run("toml", func(t *testing.T) {
+		frontMatter, remaining := SplitFrontMatter([]byte("+++\ntitle = \"Talk\"\nauthor = \"Ada\"\ntheme = \"minimal\"\ndate = 2024-03-01\ntags = \"go, slides\"\n+++\n# Intro"))
+		require.NotNil(t, frontMatter)
+		assert.Equal(t, "# Intro", string(remaining))
+
+		var p entities.Presentation
+		ApplyFrontMatter(&p, frontMatter)
+		assert.Equal(t, "Talk", p.Title)
+		assert.Equal(t, "Ada", p.Author)
+		assert.Equal(t, "minimal", p.Theme)
+		assert.Equal(t, 2024, p.Date.Year())
+		assert.Equal(t, time.March, p.Date.Month())
+		assert.Equal(t, []string{"go", "slides"}, p.Tags)
+	})
+
+	t.Run("separator before a slide", func(t *testing.T) {
+		content := []byte("---\n# Intro\n---\n# Next")
+		frontMatter, remaining := SplitFrontMatter(content)
+		assert.Nil(t, frontMatter)
+		assert.Equal(t, content, remaining)
+	})
+
+	t.Run("invalid toml", func(t *testing.T) {
+		content := []byte("+++\ntitle = \n+++\n# Intro")
+		frontMatter, remaining := SplitFrontMatter(content)
+		assert.Nil(t, frontMatter)
+		assert.Equal(t, content, remaining)
+	})
+}
+
+func TestCheckFrontMatter(t *testing.T) {
+	assert.NoError(t, CheckFrontMatter([]byte("---\ntitle: Talk\n---\n# Intro")))
+	assert.NoError(t, CheckFrontMatter([]byte("+++\ntitle = \"Talk\"\n+++\n# Intro")))
+	assert.NoError(t, CheckFrontMatter([]byte("---\n# Intro\n---\n# Next")), "a separator before a slide isn't front matter")
+	assert.Error(t, CheckFrontMatter([]byte("---\ntitle: [Talk\n---\n# Intro")))
+	assert.Error(t, CheckFrontMatter([]byte("+++\ntitle = \n+++\n# Intro")))
+}
@@ -1,7 +1,6 @@
 package parser
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -10,8 +9,8 @@ import (
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/renderer/html"
-	"gopkg.in/yaml.v3"
 
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/internal/domain/ports"
 )
 
@@ -67,8 +66,8 @@ func (p *GoldmarkParser) Parse(ctx context.Context, content []byte) (*ports.Pars
 
 // parseSlide parses a single slide's content
 func (p *GoldmarkParser) parseSlide(ctx context.Context, content []byte, index int) (ports.RawSlide, error) {
-	// Extract speaker notes
-	contentStr := string(content)
+	// Extract speaker notes from comments and ??? sections, then Note: lines
+	contentStr, sectionNotes := entities.ExtractSpeakerNotes(string(content))
 	contentLines := strings.Split(contentStr, "\n")
 
 	var mainContent []string
@@ -85,76 +84,34 @@ func (p *GoldmarkParser) parseSlide(ctx context.Context, content []byte, index i
 		}
 	}
 
+	allNotes := strings.Join(notes, "\n")
+	if sectionNotes != "" {
+		if allNotes != "" {
+			allNotes += "\n\n"
+		}
+		allNotes += sectionNotes
+	}
+
 	return ports.RawSlide{
 		Content: strings.Join(mainContent, "\n"),
-		Notes:   strings.Join(notes, "\n"),
+		Notes:   allNotes,
 		Index:   index,
 	}, nil
 }
 
-// extractFrontmatter extracts YAML frontmatter from markdown content
+// extractFrontmatter extracts YAML or TOML frontmatter from markdown content
 func extractFrontmatter(content []byte) (map[string]interface{}, []byte) {
-	// Check if content starts with frontmatter delimiter
-	if !bytes.HasPrefix(content, []byte("---\n")) && !bytes.HasPrefix(content, []byte("---\r\n")) {
-		return nil, content
-	}
-
-	// Find the end of frontmatter
-	lines := bytes.Split(content, []byte("\n"))
-	endIndex := -1
-
-	for i := 1; i < len(lines); i++ {
-		line := bytes.TrimSpace(lines[i])
-		if bytes.Equal(line, []byte("---")) {
-			endIndex = i
-			break
-		}
-	}
-
-	if endIndex == -1 {
-		// No closing delimiter found
-		return nil, content
-	}
-
-	// Extract frontmatter content
-	frontmatterLines := lines[1:endIndex]
-	frontmatterBytes := bytes.Join(frontmatterLines, []byte("\n"))
-
-	// Parse YAML
-	var frontmatter map[string]interface{}
-	if len(frontmatterBytes) == 0 {
-		// Empty frontmatter
-		frontmatter = make(map[string]interface{})
-	} else if err := yaml.Unmarshal(frontmatterBytes, &frontmatter); err != nil {
-		// If parsing fails, return original content
-		return nil, content
-	}
-
-	// Return frontmatter and remaining content
-	remainingLines := lines[endIndex+1:]
-	remaining := bytes.Join(remainingLines, []byte("\n"))
-
-	return frontmatter, remaining
+	return SplitFrontMatter(content)
 }
 
 // splitSlides splits content into individual slides
 func splitSlides(content []byte) [][]byte {
-	// Split on horizontal rule (---)
-	contentStr := string(content)
-
-	// Handle different line endings
-	contentStr = strings.ReplaceAll(contentStr, "\r\n", "\n")
-
-	// Split by slide delimiter
-	slideStrings := strings.Split(contentStr, "\n---\n")
+	slideStrings := entities.SplitSlides(string(content))
 
 	// Convert back to byte slices
 	slides := make([][]byte, 0, len(slideStrings))
 	for _, slide := range slideStrings {
-		trimmed := strings.TrimSpace(slide)
-		if trimmed != "" {
-			slides = append(slides, []byte(trimmed))
-		}
+		slides = append(slides, []byte(slide))
 	}
 
 	// If no slides found, treat entire content as one slide
@@ -163,6 +163,23 @@ invalid yaml [
 	})
 }
 
+func TestGoldmarkParser_ParseSpeakerNotes(t *testing.T) {
+	content := []byte("# Comment notes\n\nVisible\n\n<!-- notes: Hidden remark -->\n\n---\n\n# Delimited notes\n\nVisible\n\n???\n\nRemember the *demo*\n\n---\n\n# Mixed\n\nNote: Inline note\n???\nSection note")
+
+	result, err := NewGoldmarkParser().Parse(context.Background(), content)
+	require.NoError(t, err)
+	require.Len(t, result.Slides, 3)
+
+	assert.Equal(t, "Hidden remark", result.Slides[0].Notes)
+	assert.NotContains(t, result.Slides[0].Content, "Hidden remark")
+
+	assert.Equal(t, "Remember the *demo*", result.Slides[1].Notes)
+	assert.NotContains(t, result.Slides[1].Content, "???")
+	assert.NotContains(t, result.Slides[1].Content, "demo")
+
+	assert.Equal(t, "Inline note\n\nSection note", result.Slides[2].Notes)
+}
+
 func TestExtractFrontmatter(t *testing.T) {
 	t.Run("valid frontmatter", func(t *testing.T) {
 		content := []byte(`---
@@ -255,4 +272,22 @@ func TestSplitSlides(t *testing.T) {
 		assert.Equal(t, "# Slide 1", string(slides[0]))
 		assert.Equal(t, "# Slide 2", string(slides[1]))
 	})
+
+	t.Run("trailing separator", func(t *testing.T) {
+		content := []byte("# Slide 1\n---\n# Slide 2\n---")
+
+		slides := splitSlides(content)
+
+		assert.Len(t, slides, 2)
+		assert.Equal(t, "# Slide 2", string(slides[1]))
+	})
+
+	t.Run("separator inside code fence", func(t *testing.T) {
+		content := []byte("# Slide 1\n```\n---\n```\n---\n# Slide 2")
+
+		slides := splitSlides(content)
+
+		assert.Len(t, slides, 2)
+		assert.Equal(t, "# Slide 1\n```\n---\n```", string(slides[0]))
+	})
 }
@@ -0,0 +1,119 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// includePattern matches an @include directive on a line of its own
+var includePattern = regexp.MustCompile(`^@include\s+(\S.*?)\s*$`)
+
+// ReadPresentationSource reads the presentation at path with each @include
+// directive replaced by the file it names, so a deck can be split into one
+// file per section. It also returns every file read, the presentation first,
+// for live reload to watch.
+func ReadPresentationSource(path string) (string, []string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("resolving presentation path: %w", err)
+	}
+	realPath, err := filepath.EvalSymlinks(absPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("reading presentation file: %w", err)
+	}
+
+	resolver := &includeResolver{root: filepath.Dir(realPath)}
+	markdown, err := resolver.expand(realPath, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	return markdown, resolver.sources, nil
+}
+
+// includeResolver inlines the files included by a presentation
+type includeResolver struct {
+	root    string   // Included files must be inside this directory
+	sources []string // Files read so far, each once
+}
+
+// expand returns the content of path with its includes inlined. Includes
+// are resolved against the directory of the file naming them, and chain
+// lists the files being expanded to catch cycles.
+func (r *includeResolver) expand(path string, chain []string) (string, error) {
+	if slices.Contains(chain, path) {
+		names := make([]string, 0, len(chain)+1)
+		for _, file := range append(chain, path) {
+			names = append(names, r.displayName(file))
+		}
+		return "", fmt.Errorf("include cycle: %s", strings.Join(names, " -> "))
+	}
+	chain = append(chain, path)
+
+	content, err := os.ReadFile(path) // #nosec G304 - kept inside the presentation's directory by resolve
+	if err != nil {
+		if len(chain) == 1 {
+			return "", fmt.Errorf("reading presentation file: %w", err)
+		}
+		return "", fmt.Errorf("reading included file %s: %w", r.displayName(path), err)
+	}
+	if !slices.Contains(r.sources, path) {
+		r.sources = append(r.sources, path)
+	}
+
+	// Directives inside code blocks are shown, not followed
+	lines := strings.Split(string(content), "\n")
+	fenced := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			continue
+		}
+
+		match := includePattern.FindStringSubmatch(trimmed)
+		if match == nil {
+			continue
+		}
+		included, err := r.resolve(path, match[1])
+		if err != nil {
+			return "", fmt.Errorf("%s:%d: %w", r.displayName(path), i+1, err)
+		}
+		expanded, err := r.expand(included, chain)
+		if err != nil {
+			return "", err
+		}
+		lines[i] = strings.TrimSuffix(expanded, "\n")
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// resolve returns the file an @include in from names, refusing files
+// outside the presentation's directory
+func (r *includeResolver) resolve(from, name string) (string, error) {
+	if filepath.IsAbs(name) {
+		return "", fmt.Errorf("@include %s: path must be relative to the including file", name)
+	}
+	path, err := filepath.EvalSymlinks(filepath.Join(filepath.Dir(from), name))
+	if err != nil {
+		return "", fmt.Errorf("@include %s: %w", name, err)
+	}
+	if rel, err := filepath.Rel(r.root, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("@include %s: file is outside the presentation's directory", name)
+	}
+	return path, nil
+}
+
+// displayName shortens path for messages, relative to the presentation
+func (r *includeResolver) displayName(path string) string {
+	if rel, err := filepath.Rel(r.root, path); err == nil {
+		return rel
+	}
+	return path
+}
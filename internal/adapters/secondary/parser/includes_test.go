@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSourceFiles writes files, keyed by slash-separated path, to a
+// temporary directory and returns it
+func writeSourceFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	return dir
+}
+
+func TestReadPresentationSource(t *testing.T) {
+	t.Run("two files", func(t *testing.T) {
+		dir := writeSourceFiles(t, map[string]string{
+			"talk.md":               "# Welcome\n\n---\n\n@include sections/intro.md\n\n---\n\n# Thanks\n",
+			"sections/intro.md":     "# Intro\n\n---\n\n@include details.md\n",
+			"sections/details.md":   "# Details\n\n```markdown\n@include not-followed.md\n```\n",
+			"sections/unrelated.md": "# Unrelated\n",
+		})
+		dir, err := filepath.EvalSymlinks(dir) // Sources are real paths
+		require.NoError(t, err)
+		path := filepath.Join(dir, "talk.md")
+
+		markdown, sources, err := ReadPresentationSource(path)
+		require.NoError(t, err)
+		assert.Equal(t, "# Welcome\n\n---\n\n# Intro\n\n---\n\n# Details\n\n```markdown\n@include not-followed.md\n```\n\n---\n\n# Thanks\n", markdown)
+		assert.Equal(t, []string{path, filepath.Join(dir, "sections", "intro.md"), filepath.Join(dir, "sections", "details.md")}, sources)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		dir := writeSourceFiles(t, map[string]string{
+			"talk.md":    "# Talk\n\n@include a.md\n",
+			"a.md":       "# A\n\n@include parts/b.md\n",
+			"parts/b.md": "# B\n\n@include ../a.md\n",
+		})
+
+		_, _, err := ReadPresentationSource(filepath.Join(dir, "talk.md"))
+		require.Error(t, err)
+		assert.Equal(t, "include cycle: talk.md -> a.md -> "+filepath.Join("parts", "b.md")+" -> a.md", err.Error())
+	})
+
+	t.Run("outside the presentation's directory", func(t *testing.T) {
+		dir := writeSourceFiles(t, map[string]string{
+			"secret.md":    "# Secret\n",
+			"deck/talk.md": "# Talk\n\n@include ../secret.md\n",
+		})
+
+		_, _, err := ReadPresentationSource(filepath.Join(dir, "deck", "talk.md"))
+		require.Error(t, err)
+		assert.Equal(t, "talk.md:3: @include ../secret.md: file is outside the presentation's directory", err.Error())
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		dir := writeSourceFiles(t, map[string]string{"talk.md": "@include missing.md\n"})
+
+		_, _, err := ReadPresentationSource(filepath.Join(dir, "talk.md"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "talk.md:1: @include missing.md:")
+	})
+}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/extension"
 	"github.com/yuin/goldmark/renderer/html"
 
+	pluginparser "github.com/fredcamaral/slicli/internal/adapters/primary/parser"
 	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/internal/domain/ports"
 )
@@ -22,55 +24,55 @@ type PresentationParserAdapter struct {
 
 // NewPresentationParserAdapter creates a new presentation parser adapter
 func NewPresentationParserAdapter(markdownParser ports.MarkdownParser) *PresentationParserAdapter {
-	// Create a Goldmark instance for rendering HTML
-	md := goldmark.New(
-		goldmark.WithExtensions(
+	return &PresentationParserAdapter{
+		markdownParser: markdownParser,
+		goldmark:       newSlideMarkdown(),
+	}
+}
+
+// newSlideMarkdown creates the Goldmark instance rendering slides to HTML
+func newSlideMarkdown(extenders ...goldmark.Extender) goldmark.Markdown {
+	return goldmark.New(
+		goldmark.WithExtensions(append([]goldmark.Extender{
 			extension.GFM,
 			extension.Table,
 			extension.Strikethrough,
 			extension.TaskList,
 			extension.Typographer,
-		),
+		}, extenders...)...),
 		goldmark.WithRendererOptions(
 			html.WithUnsafe(),
 		),
 	)
+}
 
-	return &PresentationParserAdapter{
-		markdownParser: markdownParser,
-		goldmark:       md,
-	}
+// SetPluginService renders code blocks through the plugins of pluginService,
+// which receive the index, title, type and theme of the slide they're on
+func (p *PresentationParserAdapter) SetPluginService(pluginService ports.PluginService) {
+	p.goldmark = newSlideMarkdown(pluginparser.NewPluginExtension(pluginService))
 }
 
 // Parse implements the PresentationParser interface
 func (p *PresentationParserAdapter) Parse(content []byte) (*entities.Presentation, error) {
+	return p.parse(context.Background(), content, "")
+}
+
+// parse parses a presentation read from dir, empty when it wasn't read from
+// a file, which plugins resolve relative paths against
+func (p *PresentationParserAdapter) parse(ctx context.Context, content []byte, dir string) (*entities.Presentation, error) {
 	// Parse markdown content
-	parsed, err := p.markdownParser.Parse(context.Background(), content)
+	parsed, err := p.markdownParser.Parse(ctx, content)
 	if err != nil {
 		return nil, fmt.Errorf("parsing markdown: %w", err)
 	}
 
 	// Create presentation from parsed content
 	presentation := &entities.Presentation{
-		Metadata: parsed.Frontmatter,
-		Slides:   make([]entities.Slide, 0, len(parsed.Slides)),
+		Slides: make([]entities.Slide, 0, len(parsed.Slides)),
 	}
 
 	// Extract metadata
-	if title, ok := getStringFromMap(parsed.Frontmatter, "title"); ok {
-		presentation.Title = title
-	}
-	if author, ok := getStringFromMap(parsed.Frontmatter, "author"); ok {
-		presentation.Author = author
-	}
-	if theme, ok := getStringFromMap(parsed.Frontmatter, "theme"); ok {
-		presentation.Theme = theme
-	}
-	if dateStr, ok := getStringFromMap(parsed.Frontmatter, "date"); ok {
-		if date, err := time.Parse("2006-01-02", dateStr); err == nil {
-			presentation.Date = date
-		}
-	}
+	ApplyFrontMatter(presentation, parsed.Frontmatter)
 
 	// If no date is set, use current date
 	if presentation.Date.IsZero() {
@@ -83,13 +85,14 @@ func (p *PresentationParserAdapter) Parse(content []byte) (*entities.Presentatio
 			Index:   rawSlide.Index,
 			Content: rawSlide.Content,
 			Notes:   rawSlide.Notes,
+			Draft:   entities.IsDraftContent(rawSlide.Content),
 		}
 
 		// Extract title from content
 		slide.Title = slide.ExtractTitle()
 
 		// Render HTML content
-		htmlContent, err := p.renderMarkdown(rawSlide.Content)
+		htmlContent, err := p.renderSlide(ctx, slide, presentation.Theme, dir)
 		if err != nil {
 			return nil, fmt.Errorf("rendering slide %d: %w", rawSlide.Index, err)
 		}
@@ -106,10 +109,32 @@ func (p *PresentationParserAdapter) Parse(content []byte) (*entities.Presentatio
 	return presentation, nil
 }
 
-// renderMarkdown renders markdown content to HTML
-func (p *PresentationParserAdapter) renderMarkdown(content string) (string, error) {
+// ParseFile parses the presentation at path with its @include directives
+// expanded, also returning every file read
+func (p *PresentationParserAdapter) ParseFile(path string) (*entities.Presentation, []string, error) {
+	return p.ParseFileContext(context.Background(), path)
+}
+
+// ParseFileContext is like ParseFile, running plugin blocks under ctx
+func (p *PresentationParserAdapter) ParseFileContext(ctx context.Context, path string) (*entities.Presentation, []string, error) {
+	markdown, sources, err := ReadPresentationSource(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	presentation, err := p.parse(ctx, []byte(markdown), filepath.Dir(sources[0]))
+	if err != nil {
+		return nil, nil, err
+	}
+	return presentation, sources, nil
+}
+
+// renderSlide renders a slide's markdown to HTML, passing plugins the
+// slide's context in a deck using theme read from dir
+func (p *PresentationParserAdapter) renderSlide(ctx context.Context, slide entities.Slide, theme, dir string) (string, error) {
 	var buf bytes.Buffer
-	if err := p.goldmark.Convert([]byte(content), &buf); err != nil {
+	slideContext := pluginparser.NewSlideContext(slide, theme)
+	slideContext.Dir = dir
+	if err := pluginparser.ConvertSlide(ctx, p.goldmark, slideContext, []byte(slide.Content), &buf); err != nil {
 		return "", fmt.Errorf("rendering markdown: %w", err)
 	}
 	return buf.String(), nil
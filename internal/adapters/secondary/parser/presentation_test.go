@@ -1,10 +1,15 @@
 package parser
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+	pluginapi "github.com/fredcamaral/slicli/pkg/plugin"
 )
 
 func TestPresentationParserAdapter_Parse(t *testing.T) {
@@ -174,6 +179,42 @@ custom_list:
 	})
 }
 
+// recordingPluginService records the input of each plugin execution
+type recordingPluginService struct {
+	ports.PluginService
+	inputs []pluginapi.PluginInput
+}
+
+func (s *recordingPluginService) ExecutePlugin(ctx context.Context, name string, input pluginapi.PluginInput) (pluginapi.PluginOutput, error) {
+	s.inputs = append(s.inputs, input)
+	return pluginapi.PluginOutput{HTML: "<svg></svg>"}, nil
+}
+
+func TestPresentationParserAdapter_PluginSlideContext(t *testing.T) {
+	adapter := NewPresentationParserAdapter(NewGoldmarkParser())
+	plugins := &recordingPluginService{}
+	adapter.SetPluginService(plugins)
+
+	presentation, err := adapter.Parse([]byte("---\ntitle: Deck\ntheme: dark\n---\n\n# Intro\n\n---\n\n# Architecture\n\n```mermaid\ngraph TD\nA-->B\n```"))
+	require.NoError(t, err)
+	assert.Contains(t, presentation.Slides[1].HTML, "<svg></svg>")
+
+	require.Len(t, plugins.inputs, 1)
+	assert.Equal(t, 1, plugins.inputs[0].Metadata[pluginapi.MetadataSlideIndex])
+	assert.Equal(t, "Architecture", plugins.inputs[0].Metadata[pluginapi.MetadataSlideTitle])
+	assert.Equal(t, "dark", plugins.inputs[0].Metadata[pluginapi.MetadataTheme])
+	assert.NotContains(t, plugins.inputs[0].Metadata, pluginapi.MetadataPresentationDir, "parsed content has no directory")
+
+	// Parsed from a file, plugins are told where it is
+	dir := writeSourceFiles(t, map[string]string{"talk.md": "---\ntitle: Deck\n---\n# Intro\n\n```mermaid\ngraph TD\nA-->B\n```\n"})
+	dir, err = filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	_, _, err = adapter.ParseFile(filepath.Join(dir, "talk.md"))
+	require.NoError(t, err)
+	require.Len(t, plugins.inputs, 2)
+	assert.Equal(t, dir, plugins.inputs[1].Metadata[pluginapi.MetadataPresentationDir])
+}
+
 func TestGetStringFromMap(t *testing.T) {
 	t.Run("valid string", func(t *testing.T) {
 		m := map[string]interface{}{
@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+)
+
+// FileRepository loads presentations from markdown files, inlining the files
+// they @include, and reports changes to any of them through a file watcher
+type FileRepository struct {
+	parser  *PresentationParserAdapter
+	watcher ports.FileWatcher
+}
+
+// NewFileRepository creates a repository parsing with parser. The watcher
+// may be nil when presentations aren't watched.
+func NewFileRepository(parser *PresentationParserAdapter, watcher ports.FileWatcher) *FileRepository {
+	return &FileRepository{parser: parser, watcher: watcher}
+}
+
+// Load implements the PresentationRepository interface
+func (r *FileRepository) Load(ctx context.Context, path string) (*entities.Presentation, error) {
+	presentation, _, err := r.parser.ParseFileContext(ctx, path)
+	return presentation, err
+}
+
+// Watch implements the PresentationRepository interface, following the
+// presentation and every file it includes when watching starts
+func (r *FileRepository) Watch(ctx context.Context, path string) (<-chan ports.RepositoryChangeEvent, error) {
+	if r.watcher == nil {
+		return nil, fmt.Errorf("watching %s: no file watcher configured", path)
+	}
+	_, sources, err := ReadPresentationSource(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Watchers may report every file on one channel or give each its own
+	var channels []<-chan ports.FileChangeEvent
+	for _, source := range sources {
+		events, err := r.watcher.Watch(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("watching %s: %w", source, err)
+		}
+		if !containsChannel(channels, events) {
+			channels = append(channels, events)
+		}
+	}
+
+	changes := make(chan ports.RepositoryChangeEvent)
+	var wg sync.WaitGroup
+	for _, events := range channels {
+		wg.Add(1)
+		go func(events <-chan ports.FileChangeEvent) {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case event, ok := <-events:
+					if !ok {
+						return
+					}
+					select {
+					case changes <- ports.RepositoryChangeEvent{Path: event.Path, Operation: repositoryOperation(event.Type)}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}(events)
+	}
+	go func() {
+		wg.Wait()
+		close(changes)
+	}()
+
+	return changes, nil
+}
+
+// repositoryOperation names a file change the way repository events do
+func repositoryOperation(change ports.ChangeType) string {
+	switch change {
+	case ports.Created:
+		return "create"
+	case ports.Deleted:
+		return "delete"
+	default:
+		return "update"
+	}
+}
+
+func containsChannel(channels []<-chan ports.FileChangeEvent, events <-chan ports.FileChangeEvent) bool {
+	for _, c := range channels {
+		if c == events {
+			return true
+		}
+	}
+	return false
+}
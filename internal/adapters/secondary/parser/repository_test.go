@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+)
+
+// fakeWatcher gives each watched file its own channel
+type fakeWatcher struct {
+	channels map[string]chan ports.FileChangeEvent
+}
+
+func (w *fakeWatcher) Watch(ctx context.Context, path string) (<-chan ports.FileChangeEvent, error) {
+	events := make(chan ports.FileChangeEvent, 1)
+	w.channels[path] = events
+	return events, nil
+}
+
+func (w *fakeWatcher) Stop() error { return nil }
+
+func TestFileRepository(t *testing.T) {
+	dir := writeSourceFiles(t, map[string]string{
+		"talk.md":  "---\ntitle: Talk\n---\n# Welcome\n\n---\n\n@include intro.md\n",
+		"intro.md": "# Intro\n",
+	})
+	dir, err := filepath.EvalSymlinks(dir) // Sources are real paths
+	require.NoError(t, err)
+	path := filepath.Join(dir, "talk.md")
+
+	watcher := &fakeWatcher{channels: make(map[string]chan ports.FileChangeEvent)}
+	repo := NewFileRepository(NewPresentationParserAdapter(NewGoldmarkParser()), watcher)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	presentation, err := repo.Load(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, "Talk", presentation.Title)
+	require.Len(t, presentation.Slides, 2, "included slides are loaded")
+	assert.Equal(t, "Intro", presentation.Slides[1].Title)
+
+	changes, err := repo.Watch(ctx, path)
+	require.NoError(t, err)
+	included := filepath.Join(dir, "intro.md")
+	require.Contains(t, watcher.channels, included)
+	watcher.channels[included] <- ports.FileChangeEvent{Path: included, Type: ports.Modified}
+
+	select {
+	case change := <-changes:
+		assert.Equal(t, ports.RepositoryChangeEvent{Path: included, Operation: "update"}, change)
+	case <-time.After(time.Second):
+		t.Fatal("no change reported for the included file")
+	}
+}
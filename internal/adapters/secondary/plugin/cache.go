@@ -2,6 +2,7 @@ package plugin
 
 import (
 	"container/heap"
+	"strings"
 	"sync"
 	"time"
 
@@ -142,6 +143,21 @@ func (c *MemoryCache) Remove(key string) {
 	}
 }
 
+// DeleteByPrefix removes all results whose key starts with prefix.
+func (c *MemoryCache) DeleteByPrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, entry := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.removeFromHeap(key)
+			delete(c.entries, key)
+			c.currentSize -= entry.size
+		}
+	}
+	c.stats.Size = len(c.entries)
+}
+
 // Clear removes all results from the cache.
 func (c *MemoryCache) Clear() {
 	c.mu.Lock()
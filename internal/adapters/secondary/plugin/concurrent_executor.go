@@ -2,6 +2,9 @@ package plugin
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -14,8 +17,8 @@ import (
 type ConcurrentExecutor struct {
 	maxConcurrent int
 	semaphore     chan struct{}
-	resultCache   *sync.Map // Cache for plugin results
-	activeJobs    *sync.Map // Track active plugin executions
+	resultCache   *resultCache // Cache for plugin results
+	activeJobs    *sync.Map    // Track active plugin executions
 	mu            sync.RWMutex
 }
 
@@ -47,8 +50,9 @@ type BatchExecutionResult struct {
 
 // cachedResult wraps a result with timestamp for cache expiration
 type cachedResult struct {
-	result    ExecutionResult
-	timestamp time.Time
+	result     ExecutionResult
+	timestamp  time.Time
+	lastAccess time.Time // Last cache hit, used for LRU eviction
 }
 
 // NewConcurrentExecutor creates a new concurrent executor
@@ -60,13 +64,19 @@ func NewConcurrentExecutor(maxConcurrent int) *ConcurrentExecutor {
 	return &ConcurrentExecutor{
 		maxConcurrent: maxConcurrent,
 		semaphore:     make(chan struct{}, maxConcurrent),
-		resultCache:   &sync.Map{},
+		resultCache:   newResultCache(DefaultMaxCacheSize),
 		activeJobs:    &sync.Map{},
 	}
 }
 
 // ExecuteConcurrent executes multiple plugins concurrently
 func (e *ConcurrentExecutor) ExecuteConcurrent(ctx context.Context, jobs []ExecutionJob) *BatchExecutionResult {
+	return e.executeConcurrent(ctx, jobs, nil)
+}
+
+// executeConcurrent runs jobs concurrently, each first taking a slot in
+// callSlots when it is set
+func (e *ConcurrentExecutor) executeConcurrent(ctx context.Context, jobs []ExecutionJob, callSlots chan struct{}) *BatchExecutionResult {
 	startTime := time.Now()
 	results := make(map[string]ExecutionResult)
 	var wg sync.WaitGroup
@@ -77,7 +87,7 @@ func (e *ConcurrentExecutor) ExecuteConcurrent(ctx context.Context, jobs []Execu
 		wg.Add(1)
 		go func(j ExecutionJob) {
 			defer wg.Done()
-			result := e.executeJob(ctx, j)
+			result := e.executeJob(ctx, j, callSlots)
 
 			mu.Lock()
 			results[j.ID] = result
@@ -106,20 +116,31 @@ func (e *ConcurrentExecutor) ExecuteConcurrent(ctx context.Context, jobs []Execu
 	}
 }
 
-// executeJob executes a single plugin job with caching and concurrency control
-func (e *ConcurrentExecutor) executeJob(ctx context.Context, job ExecutionJob) ExecutionResult {
+// executeJob executes a single plugin job with caching and concurrency
+// control. A job from a capped batch waits for a batch slot before taking
+// one of the executor's, so a waiting job never holds a shared slot.
+func (e *ConcurrentExecutor) executeJob(ctx context.Context, job ExecutionJob, callSlots chan struct{}) ExecutionResult {
 	// Check cache first
 	cacheKey := e.generateCacheKey(job.Plugin.Metadata.Name, job.Input)
-	if cached, found := e.resultCache.Load(cacheKey); found {
-		if cachedItem, ok := cached.(cachedResult); ok {
-			// Check if cache is still valid (5 minutes TTL)
-			if time.Since(cachedItem.timestamp) < 5*time.Minute {
-				result := cachedItem.result
-				result.Cached = true
-				return result
+	if cachedItem, found := e.resultCache.load(cacheKey); found {
+		// Check if cache is still valid (5 minutes TTL)
+		if time.Since(cachedItem.timestamp) < 5*time.Minute {
+			result := cachedItem.result
+			result.Cached = true
+			return result
+		}
+		// Remove expired cache entry
+		e.resultCache.delete(cacheKey)
+	}
+
+	if callSlots != nil {
+		select {
+		case callSlots <- struct{}{}:
+			defer func() { <-callSlots }()
+		case <-ctx.Done():
+			return ExecutionResult{
+				Error: ctx.Err(),
 			}
-			// Remove expired cache entry
-			e.resultCache.Delete(cacheKey)
 		}
 	}
 
@@ -158,7 +179,7 @@ func (e *ConcurrentExecutor) executeJob(ctx context.Context, job ExecutionJob) E
 			result:    result,
 			timestamp: time.Now(),
 		}
-		e.resultCache.Store(cacheKey, cached)
+		e.resultCache.store(cacheKey, cached)
 	}
 
 	return result
@@ -194,6 +215,24 @@ func (e *ConcurrentExecutor) executePlugin(ctx context.Context, plugin entities.
 
 // ExecuteWithPriority executes plugins with priority ordering
 func (e *ConcurrentExecutor) ExecuteWithPriority(ctx context.Context, prioritizedJobs [][]ExecutionJob) *BatchExecutionResult {
+	return e.executeWithPriority(ctx, prioritizedJobs, nil)
+}
+
+// ExecuteWithPriorityLimit executes plugins with priority ordering like
+// ExecuteWithPriority, running at most maxConcurrent of the batch's jobs at
+// once so one caller can't take every executor slot. A limit of zero or less
+// leaves the batch bounded by the executor alone.
+func (e *ConcurrentExecutor) ExecuteWithPriorityLimit(ctx context.Context, prioritizedJobs [][]ExecutionJob, maxConcurrent int) *BatchExecutionResult {
+	var callSlots chan struct{}
+	if maxConcurrent > 0 {
+		callSlots = make(chan struct{}, maxConcurrent)
+	}
+	return e.executeWithPriority(ctx, prioritizedJobs, callSlots)
+}
+
+// executeWithPriority runs each priority level in turn, sharing callSlots
+// across the levels
+func (e *ConcurrentExecutor) executeWithPriority(ctx context.Context, prioritizedJobs [][]ExecutionJob, callSlots chan struct{}) *BatchExecutionResult {
 	startTime := time.Now()
 	allResults := make(map[string]ExecutionResult)
 	var totalSuccess, totalFailures int
@@ -204,7 +243,7 @@ func (e *ConcurrentExecutor) ExecuteWithPriority(ctx context.Context, prioritize
 			continue
 		}
 
-		groupResult := e.ExecuteConcurrent(ctx, jobGroup)
+		groupResult := e.executeConcurrent(ctx, jobGroup, callSlots)
 
 		// Merge results
 		for id, result := range groupResult.Results {
@@ -239,46 +278,45 @@ func (e *ConcurrentExecutor) GetActiveJobs() []ExecutionJob {
 
 // GetCacheStats returns cache statistics
 func (e *ConcurrentExecutor) GetCacheStats() map[string]interface{} {
-	var cacheSize int
-	e.resultCache.Range(func(key, value interface{}) bool {
-		cacheSize++
-		return true
-	})
+	cacheSize, maxCacheSize, evictions := e.resultCache.stats()
 
 	return map[string]interface{}{
-		"cache_size":     cacheSize,
-		"max_concurrent": e.maxConcurrent,
-		"active_jobs":    len(e.GetActiveJobs()),
+		"cache_size":      cacheSize,
+		"max_cache_size":  maxCacheSize,
+		"cache_evictions": evictions,
+		"max_concurrent":  e.maxConcurrent,
+		"active_jobs":     len(e.GetActiveJobs()),
 	}
 }
 
 // ClearCache clears the result cache
 func (e *ConcurrentExecutor) ClearCache() {
-	e.resultCache = &sync.Map{}
+	e.resultCache.clear()
 }
 
 // ClearExpiredCache removes expired cache entries
 func (e *ConcurrentExecutor) ClearExpiredCache() {
 	now := time.Now()
 
-	e.resultCache.Range(func(key, value interface{}) bool {
-		if cached, ok := value.(cachedResult); ok {
-			// If result is older than 5 minutes, remove it
-			if now.Sub(cached.timestamp) > 5*time.Minute {
-				e.resultCache.Delete(key)
-			}
-		}
-		return true
+	e.resultCache.deleteIf(func(cached cachedResult) bool {
+		// If result is older than 5 minutes, remove it
+		return now.Sub(cached.timestamp) > 5*time.Minute
 	})
 }
 
-// generateCacheKey generates a cache key for plugin execution
+// generateCacheKey fingerprints a plugin execution with a sha256 of the plugin
+// name and the whole input, so long inputs sharing a prefix never collide
 func (e *ConcurrentExecutor) generateCacheKey(pluginName string, input pluginapi.PluginInput) string {
-	// Simple hash of plugin name and input content
-	return fmt.Sprintf("%s:%s:%s", pluginName, input.Language, input.Content)
+	hash := sha256.New()
+	hash.Write([]byte(pluginName + "\x00" + input.Language + "\x00" + input.Content + "\x00"))
+	// Maps are encoded with sorted keys, so equal options hash the same
+	_ = json.NewEncoder(hash).Encode(input.Options)
+	return hex.EncodeToString(hash.Sum(nil))
 }
 
-// OptimizeForContent analyzes content and suggests optimal execution strategy
+// OptimizeForContent analyzes content and suggests optimal execution strategy.
+// Plugins run in high, medium, then low priority groups, placed by the
+// priority in their manifest or, when that is unset, by defaultPriority.
 func (e *ConcurrentExecutor) OptimizeForContent(plugins []entities.PluginInstance, content string) [][]ExecutionJob {
 	var priorityGroups [][]ExecutionJob
 
@@ -299,11 +337,15 @@ func (e *ConcurrentExecutor) OptimizeForContent(plugins []entities.PluginInstanc
 			Timeout:   5 * time.Second,
 		}
 
-		// Categorize based on plugin type or content analysis
-		switch plugin.Metadata.Name {
-		case "syntax-highlight", "code-exec":
+		priority := plugin.Metadata.Priority
+		if priority == "" {
+			priority = defaultPriority(plugin.Metadata.Name)
+		}
+
+		switch priority {
+		case entities.PluginPriorityHigh:
 			highPriority = append(highPriority, job)
-		case "mermaid":
+		case entities.PluginPriorityMedium:
 			mediumPriority = append(mediumPriority, job)
 		default:
 			lowPriority = append(lowPriority, job)
@@ -324,6 +366,29 @@ func (e *ConcurrentExecutor) OptimizeForContent(plugins []entities.PluginInstanc
 	return priorityGroups
 }
 
+// defaultPriority is the priority of a plugin whose manifest sets none:
+// syntax highlighting and code execution are essential, mermaid diagrams an
+// enhancement, and everything else optional
+func defaultPriority(name string) entities.PluginPriority {
+	switch name {
+	case "syntax-highlight", "code-exec":
+		return entities.PluginPriorityHigh
+	case "mermaid":
+		return entities.PluginPriorityMedium
+	default:
+		return entities.PluginPriorityLow
+	}
+}
+
+// SetMaxCacheSize updates how many results the cache holds, evicting the
+// least recently used ones past the new limit
+func (e *ConcurrentExecutor) SetMaxCacheSize(n int) {
+	if n <= 0 {
+		n = DefaultMaxCacheSize
+	}
+	e.resultCache.setMaxSize(n)
+}
+
 // SetMaxConcurrent updates the maximum concurrent execution limit
 func (e *ConcurrentExecutor) SetMaxConcurrent(max int) {
 	e.mu.Lock()
@@ -3,6 +3,7 @@ package plugin
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/internal/test/builders"
@@ -356,14 +358,12 @@ func TestConcurrentExecutor_Caching(t *testing.T) {
 
 		// Manually expire cache by setting timestamp in the past
 		cacheKey := executor.generateCacheKey(plugin.Name(), job.Input)
-		if cached, found := executor.resultCache.Load(cacheKey); found {
-			if cachedItem, ok := cached.(cachedResult); ok {
-				expiredCache := cachedResult{
-					result:    cachedItem.result,
-					timestamp: time.Now().Add(-10 * time.Minute), // Expired
-				}
-				executor.resultCache.Store(cacheKey, expiredCache)
+		if cachedItem, found := executor.resultCache.load(cacheKey); found {
+			expiredCache := cachedResult{
+				result:    cachedItem.result,
+				timestamp: time.Now().Add(-10 * time.Minute), // Expired
 			}
+			executor.resultCache.store(cacheKey, expiredCache)
 		}
 
 		// Second execution should not use expired cache
@@ -374,6 +374,39 @@ func TestConcurrentExecutor_Caching(t *testing.T) {
 
 		plugin.AssertExpectations(t)
 	})
+
+	t.Run("caches snippets sharing a long prefix separately", func(t *testing.T) {
+		executor := NewConcurrentExecutor(1)
+		plugin := NewConcurrentMockPlugin("prefix-plugin")
+
+		prefix := strings.Repeat("x", 500)
+		plugin.On("Execute", mock.Anything, mock.MatchedBy(func(input pluginapi.PluginInput) bool {
+			return input.Content == prefix+"a"
+		})).Return(pluginapi.PluginOutput{HTML: "a"}, nil).Once()
+		plugin.On("Execute", mock.Anything, mock.MatchedBy(func(input pluginapi.PluginInput) bool {
+			return input.Content == prefix+"b"
+		})).Return(pluginapi.PluginOutput{HTML: "b"}, nil).Once()
+
+		jobA := createTestJob("a", plugin, prefix+"a")
+		jobB := createTestJob("b", plugin, prefix+"b")
+		result := executor.ExecuteConcurrent(context.Background(), []ExecutionJob{jobA})
+		assert.Equal(t, "a", result.Results["a"].Output.HTML)
+		result = executor.ExecuteConcurrent(context.Background(), []ExecutionJob{jobB})
+		assert.False(t, result.Results["b"].Cached)
+		assert.Equal(t, "b", result.Results["b"].Output.HTML)
+		assert.Equal(t, 2, executor.GetCacheStats()["cache_size"])
+
+		keyA := executor.generateCacheKey(plugin.Name(), jobA.Input)
+		assert.Len(t, keyA, 64)
+		assert.NotEqual(t, keyA, executor.generateCacheKey(plugin.Name(), jobB.Input))
+
+		// Options are part of the fingerprint
+		withOptions := jobA.Input
+		withOptions.Options = map[string]interface{}{"theme": "dark"}
+		assert.NotEqual(t, keyA, executor.generateCacheKey(plugin.Name(), withOptions))
+
+		plugin.AssertExpectations(t)
+	})
 }
 
 func TestConcurrentExecutor_ExecuteWithPriority(t *testing.T) {
@@ -554,8 +587,8 @@ func TestConcurrentExecutor_CacheManagement(t *testing.T) {
 			timestamp: time.Now(),
 		}
 
-		executor.resultCache.Store("expired-key", expiredCache)
-		executor.resultCache.Store("fresh-key", freshCache)
+		executor.resultCache.store("expired-key", expiredCache)
+		executor.resultCache.store("fresh-key", freshCache)
 
 		// Verify both entries exist
 		stats := executor.GetCacheStats()
@@ -569,11 +602,49 @@ func TestConcurrentExecutor_CacheManagement(t *testing.T) {
 		assert.Equal(t, 1, stats["cache_size"])
 
 		// Verify the correct entry remains
-		_, expiredExists := executor.resultCache.Load("expired-key")
-		_, freshExists := executor.resultCache.Load("fresh-key")
+		_, expiredExists := executor.resultCache.load("expired-key")
+		_, freshExists := executor.resultCache.load("fresh-key")
 		assert.False(t, expiredExists)
 		assert.True(t, freshExists)
 	})
+
+	t.Run("evicts least recently used entries past the limit", func(t *testing.T) {
+		executor := NewConcurrentExecutor(1)
+		executor.SetMaxCacheSize(2)
+
+		now := time.Now()
+		entry := func(age time.Duration) cachedResult {
+			return cachedResult{timestamp: now.Add(-age)}
+		}
+		executor.resultCache.store("oldest", entry(3*time.Second))
+		executor.resultCache.store("older", entry(2*time.Second))
+
+		// A hit makes the oldest entry the most recently used
+		_, found := executor.resultCache.load("oldest")
+		require.True(t, found)
+
+		executor.resultCache.store("newest", entry(time.Second))
+
+		_, oldestExists := executor.resultCache.load("oldest")
+		_, olderExists := executor.resultCache.load("older")
+		_, newestExists := executor.resultCache.load("newest")
+		assert.True(t, oldestExists)
+		assert.False(t, olderExists)
+		assert.True(t, newestExists)
+
+		stats := executor.GetCacheStats()
+		assert.Equal(t, 2, stats["cache_size"])
+		assert.Equal(t, 2, stats["max_cache_size"])
+		assert.Equal(t, int64(1), stats["cache_evictions"])
+
+		executor.SetMaxCacheSize(1)
+		stats = executor.GetCacheStats()
+		assert.Equal(t, 1, stats["cache_size"])
+		assert.Equal(t, int64(2), stats["cache_evictions"])
+
+		executor.SetMaxCacheSize(0)
+		assert.Equal(t, DefaultMaxCacheSize, executor.GetCacheStats()["max_cache_size"])
+	})
 }
 
 func TestConcurrentExecutor_OptimizeForContent(t *testing.T) {
@@ -621,6 +692,30 @@ func TestConcurrentExecutor_OptimizeForContent(t *testing.T) {
 		assert.Equal(t, "other-plugin", lowPriority[0].Plugin.Metadata.Name)
 	})
 
+	t.Run("manifest priority overrides the defaults", func(t *testing.T) {
+		executor := NewConcurrentExecutor(1)
+
+		plugins := []entities.PluginInstance{
+			{Metadata: builders.NewPluginMetadataBuilder().WithName("syntax-highlight").WithPriority(entities.PluginPriorityLow).Build()},
+			{Metadata: builders.NewPluginMetadataBuilder().WithName("mermaid").Build()},
+			{Metadata: builders.NewPluginMetadataBuilder().WithName("my-plugin").WithPriority(entities.PluginPriorityHigh).Build()},
+			{Metadata: builders.NewPluginMetadataBuilder().WithName("charts").WithPriority(entities.PluginPriorityMedium).Build()},
+		}
+
+		priorityGroups := executor.OptimizeForContent(plugins, "test content")
+		require.Len(t, priorityGroups, 3)
+
+		groupNames := make([][]string, len(priorityGroups))
+		for i, group := range priorityGroups {
+			for _, job := range group {
+				groupNames[i] = append(groupNames[i], job.Plugin.Metadata.Name)
+			}
+		}
+		assert.Equal(t, []string{"my-plugin"}, groupNames[0])
+		assert.Equal(t, []string{"mermaid", "charts"}, groupNames[1])
+		assert.Equal(t, []string{"syntax-highlight"}, groupNames[2])
+	})
+
 	t.Run("handles empty plugin list", func(t *testing.T) {
 		executor := NewConcurrentExecutor(1)
 
@@ -0,0 +1,369 @@
+package plugin
+
+import (
+	"container/heap"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+	pluginapi "github.com/fredcamaral/slicli/pkg/plugin"
+)
+
+const diskCacheExt = ".json"
+
+// DiskCache is an on-disk cache for plugin outputs keyed by content hash.
+// Entries survive restarts and are shared between runs using the same directory.
+// The total size on disk is capped, evicting least recently used entries first.
+type DiskCache struct {
+	mu          sync.Mutex
+	dir         string
+	entries     map[string]int64 // file name -> size on disk
+	heap        *cacheHeap
+	heapLookup  map[string]*heapEntry
+	maxSize     int64
+	currentSize int64
+	stats       entities.CacheStats
+}
+
+// diskRecord is the serialized form of a cached plugin output.
+type diskRecord struct {
+	Key       string                 `json:"key"`
+	ExpiresAt time.Time              `json:"expires_at"`
+	Output    pluginapi.PluginOutput `json:"output"`
+}
+
+// NewDiskCache creates a disk cache rooted at dir, creating it if needed.
+// It returns an error if the directory cannot be created or is not writable.
+func NewDiskCache(dir string, maxSize int64) (*DiskCache, error) {
+	if dir == "" {
+		return nil, errors.New("cache directory is required")
+	}
+	if maxSize <= 0 {
+		maxSize = 100 * 1024 * 1024 // 100MB default
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	// Probe writability up front so callers can fall back early
+	probe, err := os.CreateTemp(dir, ".probe-*")
+	if err != nil {
+		return nil, fmt.Errorf("cache directory not writable: %w", err)
+	}
+	probeName := probe.Name()
+	_ = probe.Close()
+	_ = os.Remove(probeName)
+
+	h := &cacheHeap{}
+	heap.Init(h)
+
+	c := &DiskCache{
+		dir:        dir,
+		entries:    make(map[string]int64),
+		heap:       h,
+		heapLookup: make(map[string]*heapEntry),
+		maxSize:    maxSize,
+		stats: entities.CacheStats{
+			MaxSize: int(maxSize),
+		},
+	}
+
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// NewCacheWithFallback returns a disk cache when dir is set and writable,
+// otherwise an in-memory cache of the same size.
+func NewCacheWithFallback(dir string, maxSize int64) ports.PluginCache {
+	if dir != "" {
+		diskCache, err := NewDiskCache(dir, maxSize)
+		if err == nil {
+			return diskCache
+		}
+		log.Printf("Plugin cache directory %s unavailable, using in-memory cache: %v", dir, err)
+	}
+	return NewMemoryCache(maxSize)
+}
+
+// NewCacheFromConfig returns the plugin output cache configured under
+// [plugins]: on disk in cache_dir, capped at cache_max_size_mb, falling
+// back to memory when no usable directory is set.
+func NewCacheFromConfig(config entities.PluginsConfig) ports.PluginCache {
+	return NewCacheWithFallback(config.CacheDir, config.GetCacheMaxSize())
+}
+
+// Dir returns the cache directory.
+func (c *DiskCache) Dir() string {
+	return c.dir
+}
+
+// Get retrieves a cached result.
+func (c *DiskCache) Get(key string) (*pluginapi.PluginOutput, bool) {
+	name := c.fileName(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(filepath.Join(c.dir, name))
+	if err != nil {
+		// The file may have been removed by another process
+		c.forget(name)
+		c.stats.Misses++
+		return nil, false
+	}
+
+	var record diskRecord
+	if err := json.Unmarshal(data, &record); err != nil || record.Key != key {
+		c.stats.Misses++
+		return nil, false
+	}
+
+	if time.Now().After(record.ExpiresAt) {
+		c.removeFile(name)
+		c.stats.Evictions++
+		c.stats.Misses++
+		return nil, false
+	}
+
+	// Entries written by another run are picked up lazily
+	if _, indexed := c.entries[name]; !indexed {
+		c.track(name, int64(len(data)), time.Now())
+	}
+	c.touch(name)
+	c.stats.Hits++
+
+	return &record.Output, true
+}
+
+// Set stores a result in the cache.
+func (c *DiskCache) Set(key string, output *pluginapi.PluginOutput, ttl time.Duration) {
+	if output == nil {
+		return
+	}
+
+	data, err := json.Marshal(diskRecord{
+		Key:       key,
+		ExpiresAt: time.Now().Add(ttl),
+		Output:    *output,
+	})
+	if err != nil {
+		return
+	}
+	size := int64(len(data))
+	if size > c.maxSize {
+		return
+	}
+
+	name := c.fileName(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.forget(name)
+	if c.currentSize+size > c.maxSize {
+		c.evictLRU(size)
+	}
+
+	if err := c.writeFile(name, data); err != nil {
+		log.Printf("Failed to write plugin cache entry %s: %v", name, err)
+		return
+	}
+
+	c.track(name, size, time.Now())
+}
+
+// Remove removes a result from the cache.
+func (c *DiskCache) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeFile(c.fileName(key))
+}
+
+// DeleteByPrefix removes all results whose key starts with prefix. File
+// names are hashes, so each entry is read to find its key; entries that
+// can't be read are removed too since Get would miss on them anyway.
+func (c *DiskCache) DeleteByPrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name := range c.entries {
+		data, err := os.ReadFile(filepath.Join(c.dir, name))
+		if err != nil {
+			c.removeFile(name)
+			continue
+		}
+
+		var record diskRecord
+		if err := json.Unmarshal(data, &record); err != nil || strings.HasPrefix(record.Key, prefix) {
+			c.removeFile(name)
+		}
+	}
+}
+
+// Clear removes all results from the cache.
+func (c *DiskCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name := range c.entries {
+		_ = os.Remove(filepath.Join(c.dir, name))
+	}
+
+	c.entries = make(map[string]int64)
+	c.heapLookup = make(map[string]*heapEntry)
+	*c.heap = (*c.heap)[:0]
+	c.currentSize = 0
+	c.stats.Size = 0
+}
+
+// Stats returns cache statistics.
+func (c *DiskCache) Stats() entities.CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	total := stats.Hits + stats.Misses
+	if total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// load indexes entries left by previous runs, using modification time as last access.
+func (c *DiskCache) load() error {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("reading cache directory: %w", err)
+	}
+
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() || !isDiskCacheFile(name) {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+		c.track(name, info.Size(), info.ModTime())
+	}
+
+	if c.currentSize > c.maxSize {
+		c.evictLRU(0)
+	}
+
+	return nil
+}
+
+// track adds a file to the in-memory index.
+func (c *DiskCache) track(name string, size int64, lastAccess time.Time) {
+	entry := &heapEntry{
+		key:        name,
+		lastAccess: lastAccess,
+	}
+	heap.Push(c.heap, entry)
+	c.heapLookup[name] = entry
+	c.entries[name] = size
+	c.currentSize += size
+	c.stats.Size = len(c.entries)
+}
+
+// touch marks a file as recently used, persisting the access time for later runs.
+func (c *DiskCache) touch(name string) {
+	entry, exists := c.heapLookup[name]
+	if !exists {
+		return
+	}
+	now := time.Now()
+	entry.lastAccess = now
+	heap.Fix(c.heap, entry.index)
+	_ = os.Chtimes(filepath.Join(c.dir, name), now, now)
+}
+
+// forget drops a file from the index without touching the disk.
+func (c *DiskCache) forget(name string) {
+	size, exists := c.entries[name]
+	if !exists {
+		return
+	}
+	if entry, ok := c.heapLookup[name]; ok {
+		heap.Remove(c.heap, entry.index)
+		delete(c.heapLookup, name)
+	}
+	delete(c.entries, name)
+	c.currentSize -= size
+	c.stats.Size = len(c.entries)
+}
+
+// removeFile drops a file from both the index and the disk.
+func (c *DiskCache) removeFile(name string) {
+	c.forget(name)
+	_ = os.Remove(filepath.Join(c.dir, name))
+}
+
+// evictLRU evicts least recently used entries to make room.
+func (c *DiskCache) evictLRU(neededSize int64) {
+	for c.currentSize+neededSize > c.maxSize && c.heap.Len() > 0 {
+		lru := heap.Pop(c.heap).(*heapEntry)
+		delete(c.heapLookup, lru.key)
+
+		if size, exists := c.entries[lru.key]; exists {
+			delete(c.entries, lru.key)
+			c.currentSize -= size
+			c.stats.Evictions++
+			_ = os.Remove(filepath.Join(c.dir, lru.key))
+		}
+	}
+	c.stats.Size = len(c.entries)
+}
+
+// writeFile writes data atomically so concurrent readers never see partial entries.
+func (c *DiskCache) writeFile(name string, data []byte) error {
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+
+	return os.Rename(tmpName, filepath.Join(c.dir, name))
+}
+
+// fileName maps a cache key to its file name.
+func (c *DiskCache) fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]) + diskCacheExt
+}
+
+// isDiskCacheFile reports whether name looks like a cache entry file.
+func isDiskCacheFile(name string) bool {
+	if !strings.HasSuffix(name, diskCacheExt) {
+		return false
+	}
+	_, err := hex.DecodeString(strings.TrimSuffix(name, diskCacheExt))
+	return err == nil && len(name) == sha256.Size*2+len(diskCacheExt)
+}
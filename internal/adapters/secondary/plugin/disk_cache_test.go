@@ -0,0 +1,167 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pluginapi "github.com/fredcamaral/slicli/pkg/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func diagramOutput(svg string) *pluginapi.PluginOutput {
+	return &pluginapi.PluginOutput{
+		HTML: svg,
+		Assets: []pluginapi.Asset{
+			{Name: "diagram.css", Content: []byte(".diagram{}"), ContentType: "text/css"},
+		},
+		Metadata: map[string]interface{}{"engine": "mermaid"},
+	}
+}
+
+func TestDiskCache_SecondRenderHitsDisk(t *testing.T) {
+	dir := t.TempDir()
+	key := "mermaid::graph TD; A-->B"
+
+	first, err := NewDiskCache(dir, 0)
+	require.NoError(t, err)
+
+	_, found := first.Get(key)
+	assert.False(t, found)
+	first.Set(key, diagramOutput("<svg>A-B</svg>"), time.Hour)
+
+	// A fresh cache over the same directory simulates a restart
+	second, err := NewDiskCache(dir, 0)
+	require.NoError(t, err)
+
+	output, found := second.Get(key)
+	require.True(t, found)
+	assert.Equal(t, "<svg>A-B</svg>", output.HTML)
+	require.Len(t, output.Assets, 1)
+	assert.Equal(t, []byte(".diagram{}"), output.Assets[0].Content)
+
+	stats := second.Stats()
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(0), stats.Misses)
+	assert.Equal(t, 1, stats.Size)
+}
+
+func TestDiskCache_Expiration(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), 0)
+	require.NoError(t, err)
+
+	cache.Set("key", diagramOutput("<svg/>"), -time.Second)
+
+	_, found := cache.Get("key")
+	assert.False(t, found)
+	assert.Equal(t, 0, cache.Stats().Size)
+}
+
+func TestDiskCache_LRUEviction(t *testing.T) {
+	dir := t.TempDir()
+	probe, err := NewDiskCache(t.TempDir(), 0)
+	require.NoError(t, err)
+	probe.Set("probe", diagramOutput(strings.Repeat("x", 100)), time.Hour)
+	entrySize := probe.currentSize
+
+	// Room for two entries only
+	cache, err := NewDiskCache(dir, entrySize*2+entrySize/2)
+	require.NoError(t, err)
+
+	cache.Set("a", diagramOutput(strings.Repeat("a", 100)), time.Hour)
+	time.Sleep(5 * time.Millisecond)
+	cache.Set("b", diagramOutput(strings.Repeat("b", 100)), time.Hour)
+	time.Sleep(5 * time.Millisecond)
+
+	// Touch "a" so "b" becomes least recently used
+	_, found := cache.Get("a")
+	require.True(t, found)
+	time.Sleep(5 * time.Millisecond)
+
+	cache.Set("c", diagramOutput(strings.Repeat("c", 100)), time.Hour)
+
+	_, found = cache.Get("b")
+	assert.False(t, found)
+	_, found = cache.Get("a")
+	assert.True(t, found)
+	_, found = cache.Get("c")
+	assert.True(t, found)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+diskCacheExt))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, int64(1), cache.Stats().Evictions)
+}
+
+func TestDiskCache_RemoveAndClear(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir, 0)
+	require.NoError(t, err)
+
+	cache.Set("a", diagramOutput("a"), time.Hour)
+	cache.Set("b", diagramOutput("b"), time.Hour)
+
+	cache.Remove("a")
+	_, found := cache.Get("a")
+	assert.False(t, found)
+
+	cache.Clear()
+	_, found = cache.Get("b")
+	assert.False(t, found)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+diskCacheExt))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestDiskCache_DeleteByPrefix(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir, 0)
+	require.NoError(t, err)
+
+	cache.Set("mermaid:1", diagramOutput("one"), time.Hour)
+	cache.Set("mermaid:2", diagramOutput("two"), time.Hour)
+	cache.Set("mermaid-lite:1", diagramOutput("lite"), time.Hour)
+
+	cache.DeleteByPrefix("mermaid:")
+
+	_, found := cache.Get("mermaid:1")
+	assert.False(t, found)
+	_, found = cache.Get("mermaid:2")
+	assert.False(t, found)
+	output, found := cache.Get("mermaid-lite:1")
+	require.True(t, found)
+	assert.Equal(t, "lite", output.HTML)
+	assert.Equal(t, 1, cache.Stats().Size)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+diskCacheExt))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestNewCacheWithFallback(t *testing.T) {
+	t.Run("uses disk when writable", func(t *testing.T) {
+		cache := NewCacheWithFallback(filepath.Join(t.TempDir(), "diagrams"), 0)
+		_, ok := cache.(*DiskCache)
+		assert.True(t, ok)
+	})
+
+	t.Run("falls back to memory when directory is unusable", func(t *testing.T) {
+		// A regular file can never be used as a cache directory
+		blocker := filepath.Join(t.TempDir(), "not-a-dir")
+		require.NoError(t, os.WriteFile(blocker, []byte("x"), 0o644))
+
+		cache := NewCacheWithFallback(blocker, 0)
+		_, ok := cache.(*MemoryCache)
+		assert.True(t, ok)
+	})
+
+	t.Run("falls back to memory without a directory", func(t *testing.T) {
+		cache := NewCacheWithFallback("", 0)
+		_, ok := cache.(*MemoryCache)
+		assert.True(t, ok)
+	})
+}
@@ -140,6 +140,7 @@ version = "1.0.0"
 description = "Test plugin"
 author = "Test Author"
 type = "processor"
+priority = "high"
 
 [requirements]
 min_slicli_version = "0.1.0"
@@ -165,7 +166,16 @@ concurrent = true
 	assert.Equal(t, "test-plugin", manifest.Metadata.Name)
 	assert.Equal(t, "1.0.0", manifest.Metadata.Version)
 	assert.Equal(t, entities.PluginTypeProcessor, manifest.Metadata.Type)
+	assert.Equal(t, entities.PluginPriorityHigh, manifest.Metadata.Priority)
 	assert.True(t, manifest.Capabilities.Concurrent)
+
+	t.Run("unknown priority", func(t *testing.T) {
+		content := "[metadata]\nname = \"test-plugin\"\nversion = \"1.0.0\"\ntype = \"processor\"\npriority = \"urgent\"\n"
+		require.NoError(t, os.WriteFile(manifestPath, []byte(content), 0644))
+
+		_, err := loader.LoadManifest(ctx, manifestPath)
+		assert.ErrorContains(t, err, "plugin priority must be high, medium, or low")
+	})
 }
 
 func TestGoPluginLoader_LoadManifest_Invalid(t *testing.T) {
@@ -0,0 +1,155 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// manifestVersionPattern matches the semantic versions a manifest may declare
+var manifestVersionPattern = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[a-zA-Z0-9.-]+)?(\+[a-zA-Z0-9.-]+)?$`)
+
+// pluginTypes lists the valid metadata types in the order they're reported
+var pluginTypes = []entities.PluginType{
+	entities.PluginTypeProcessor,
+	entities.PluginTypeExporter,
+	entities.PluginTypeTheme,
+	entities.PluginTypeAnalyzer,
+}
+
+// ManifestProblem is one thing wrong with a plugin.toml, located by its
+// dotted key such as "metadata.type"
+type ManifestProblem struct {
+	Field   string
+	Message string
+}
+
+func (p ManifestProblem) String() string {
+	if p.Field == "" {
+		return p.Message
+	}
+	return p.Field + ": " + p.Message
+}
+
+// ValidateManifestFile strictly checks the plugin.toml at path, listing
+// every problem found rather than stopping at the first as LoadManifest
+// does. Unknown keys are reported too, since they're usually typos. The
+// error is reserved for manifests that can't be read.
+func ValidateManifestFile(path string) ([]ManifestProblem, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - manifest path chosen by the user
+	if err != nil {
+		return nil, fmt.Errorf("reading manifest: %w", err)
+	}
+	return ValidateManifest(data), nil
+}
+
+// ValidateManifest strictly checks the contents of a plugin.toml
+func ValidateManifest(data []byte) []ManifestProblem {
+	var manifest entities.PluginManifest
+	md, err := toml.Decode(string(data), &manifest)
+	if err != nil {
+		var parseErr toml.ParseError
+		if errors.As(err, &parseErr) {
+			return []ManifestProblem{{Message: fmt.Sprintf("line %d: %s", parseErr.Position.Line, parseErr.Message)}}
+		}
+		return []ManifestProblem{{Message: strings.TrimPrefix(err.Error(), "toml: ")}}
+	}
+
+	var problems []ManifestProblem
+	add := func(field, format string, args ...any) {
+		problems = append(problems, ManifestProblem{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	meta := manifest.Metadata
+	if !md.IsDefined("metadata") {
+		add("metadata", "section is required")
+	}
+	switch {
+	case meta.Name == "":
+		add("metadata.name", "is required")
+	case !isValidPluginName(meta.Name):
+		add("metadata.name", "%q may only contain letters, digits, hyphens and underscores", meta.Name)
+	}
+	switch {
+	case meta.Version == "":
+		add("metadata.version", "is required")
+	case !manifestVersionPattern.MatchString(meta.Version):
+		add("metadata.version", "%q is not a semantic version such as 1.0.0", meta.Version)
+	}
+	switch {
+	case meta.Type == "":
+		add("metadata.type", "is required")
+	case !isPluginType(meta.Type):
+		add("metadata.type", "%q must be one of %s", meta.Type, joinPluginTypes())
+	}
+	switch meta.Priority {
+	case "", entities.PluginPriorityHigh, entities.PluginPriorityMedium, entities.PluginPriorityLow:
+	default:
+		add("metadata.priority", "%q must be high, medium or low", meta.Priority)
+	}
+
+	for field, version := range map[string]string{
+		"requirements.min_slicli_version": manifest.Requirements.MinSlicliVersion,
+		"requirements.max_slicli_version": manifest.Requirements.MaxSlicliVersion,
+	} {
+		if version != "" && !manifestVersionPattern.MatchString(version) {
+			add(field, "%q is not a semantic version such as 1.0.0", version)
+		}
+	}
+
+	config := manifest.DefaultConfig
+	if config.Priority < 0 {
+		add("config.priority", "must not be negative")
+	}
+	if config.Timeout < 0 {
+		add("config.timeout", "must not be negative")
+	}
+	if config.MaxMemory < 0 {
+		add("config.max_memory", "must not be negative")
+	}
+	if config.CacheTTL < 0 {
+		add("config.cache_ttl", "must not be negative")
+	}
+	for i, ext := range config.FileExtensions {
+		if !strings.HasPrefix(ext, ".") || len(ext) < 2 {
+			add(fmt.Sprintf("config.file_extensions[%d]", i), "%q must start with a dot, such as .mmd", ext)
+		}
+	}
+	for i, pattern := range config.ContentPatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			add(fmt.Sprintf("config.content_patterns[%d]", i), "%q is not a valid regular expression: %v", pattern, err)
+		}
+	}
+
+	for _, key := range md.Undecoded() {
+		add(key.String(), "unknown key")
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Field < problems[j].Field })
+	return problems
+}
+
+// isPluginType reports whether t is one of the plugin types slicli knows
+func isPluginType(t entities.PluginType) bool {
+	for _, known := range pluginTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// joinPluginTypes lists the valid plugin types for error messages
+func joinPluginTypes() string {
+	names := make([]string, len(pluginTypes))
+	for i, t := range pluginTypes {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,70 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateManifest(t *testing.T) {
+	t.Run("template manifest is valid", func(t *testing.T) {
+		problems, err := ValidateManifestFile(filepath.Join("..", "..", "..", "..", "examples", "plugin-template", "plugin.toml"))
+		require.NoError(t, err)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		problems := ValidateManifest([]byte(`
+[metadata]
+name = "my plugin"
+version = "1.0"
+type = "widget"
+priorty = "high"
+
+[config]
+timeout = "-5s"
+file_extensions = ["mmd"]
+content_patterns = ["^(unclosed"]
+`))
+		var got []string
+		for _, p := range problems {
+			got = append(got, p.String())
+		}
+		assert.Equal(t, []string{
+			`config.content_patterns[0]: "^(unclosed" is not a valid regular expression: error parsing regexp: missing closing ): ` + "`^(unclosed`",
+			`config.file_extensions[0]: "mmd" must start with a dot, such as .mmd`,
+			`config.timeout: must not be negative`,
+			`metadata.name: "my plugin" may only contain letters, digits, hyphens and underscores`,
+			`metadata.priorty: unknown key`,
+			`metadata.type: "widget" must be one of processor, exporter, theme, analyzer`,
+			`metadata.version: "1.0" is not a semantic version such as 1.0.0`,
+		}, got)
+	})
+
+	t.Run("missing metadata", func(t *testing.T) {
+		problems := ValidateManifest([]byte("[config]\nenabled = true\n"))
+		require.Len(t, problems, 4)
+		assert.Equal(t, "metadata: section is required", problems[0].String())
+		assert.Equal(t, "metadata.name: is required", problems[1].String())
+		assert.Equal(t, "metadata.type: is required", problems[2].String())
+		assert.Equal(t, "metadata.version: is required", problems[3].String())
+	})
+
+	t.Run("syntax and type errors", func(t *testing.T) {
+		problems := ValidateManifest([]byte("[metadata]\nname = \"x\"\nversion = 1.0.0\n"))
+		require.Len(t, problems, 1)
+		assert.Contains(t, problems[0].String(), "line 3")
+
+		problems = ValidateManifest([]byte("[config]\npriority = \"high\"\n"))
+		require.Len(t, problems, 1)
+		assert.Contains(t, problems[0].String(), "config.priority")
+	})
+
+	t.Run("unreadable file", func(t *testing.T) {
+		_, err := ValidateManifestFile(filepath.Join(t.TempDir(), "plugin.toml"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -17,8 +17,17 @@ type InMemoryRegistry struct {
 	metadata   map[string]entities.PluginMetadata
 	statistics map[string]*entities.PluginStatistics
 	loaded     map[string]*entities.LoadedPlugin
+
+	// Plugins timing out more often than this are marked degraded
+	timeoutRate          float64
+	timeoutMinExecutions int64
 }
 
+const (
+	defaultTimeoutRate          = 0.5
+	defaultTimeoutMinExecutions = 4
+)
+
 // NewInMemoryRegistry creates a new in-memory plugin registry.
 func NewInMemoryRegistry() *InMemoryRegistry {
 	return &InMemoryRegistry{
@@ -26,6 +35,23 @@ func NewInMemoryRegistry() *InMemoryRegistry {
 		metadata:   make(map[string]entities.PluginMetadata),
 		statistics: make(map[string]*entities.PluginStatistics),
 		loaded:     make(map[string]*entities.LoadedPlugin),
+
+		timeoutRate:          defaultTimeoutRate,
+		timeoutMinExecutions: defaultTimeoutMinExecutions,
+	}
+}
+
+// SetTimeoutThreshold sets the timeout rate above which a plugin is marked
+// degraded, once it has run at least minExecutions times.
+func (r *InMemoryRegistry) SetTimeoutThreshold(rate float64, minExecutions int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if rate > 0 {
+		r.timeoutRate = rate
+	}
+	if minExecutions > 0 {
+		r.timeoutMinExecutions = minExecutions
 	}
 }
 
@@ -179,9 +205,10 @@ func (r *InMemoryRegistry) UpdateStatistics(name string, duration time.Duration,
 	if loaded, exists := r.loaded[name]; exists {
 		loaded.LastUsed = time.Now()
 		loaded.Statistics = *stats
-		if success {
+		if success && loaded.Status != entities.PluginStatusDegraded {
 			loaded.Status = entities.PluginStatusActive
 		}
+		r.updateTimeoutHealth(loaded)
 	}
 }
 
@@ -198,6 +225,23 @@ func (r *InMemoryRegistry) IncrementTimeout(name string) {
 	if loaded, exists := r.loaded[name]; exists {
 		loaded.Statistics.TimeoutCount++
 		loaded.Statistics.ErrorCount++
+		r.updateTimeoutHealth(loaded)
+	}
+}
+
+// updateTimeoutHealth marks a plugin degraded while its timeout rate is over
+// the threshold and restores it once the rate recovers
+func (r *InMemoryRegistry) updateTimeoutHealth(loaded *entities.LoadedPlugin) {
+	stats := loaded.Statistics
+	overThreshold := stats.ExecutionCount >= r.timeoutMinExecutions && stats.TimeoutRate() >= r.timeoutRate
+
+	switch {
+	case overThreshold && loaded.IsActive():
+		loaded.Status = entities.PluginStatusDegraded
+		loaded.ErrorMsg = fmt.Sprintf("timed out in %d of %d executions", stats.TimeoutCount, stats.ExecutionCount)
+	case !overThreshold && loaded.Status == entities.PluginStatusDegraded:
+		loaded.Status = entities.PluginStatusActive
+		loaded.ErrorMsg = ""
 	}
 }
 
@@ -221,6 +221,39 @@ func TestInMemoryRegistry_IncrementTimeout(t *testing.T) {
 	assert.Equal(t, int64(1), stats.ErrorCount)
 }
 
+func TestInMemoryRegistry_TimeoutHealth(t *testing.T) {
+	registry := NewInMemoryRegistry()
+	registry.SetTimeoutThreshold(0.5, 4)
+	_ = registry.Register("slow", &MockPlugin{name: "slow"}, createTestMetadata("slow", entities.PluginTypeProcessor))
+
+	timeout := func() {
+		registry.UpdateStatistics("slow", time.Second, false, 10, 0)
+		registry.IncrementTimeout("slow")
+	}
+
+	// Too few executions to judge
+	timeout()
+	timeout()
+	loaded, err := registry.GetLoadedPlugin("slow")
+	require.NoError(t, err)
+	assert.Equal(t, entities.PluginStatusLoaded, loaded.Status)
+
+	timeout()
+	registry.UpdateStatistics("slow", time.Millisecond, true, 10, 10)
+	loaded, _ = registry.GetLoadedPlugin("slow")
+	assert.Equal(t, entities.PluginStatusDegraded, loaded.Status)
+	assert.Equal(t, "timed out in 3 of 4 executions", loaded.ErrorMsg)
+	assert.True(t, loaded.IsActive(), "degraded plugins keep running")
+
+	// Successful runs bring the rate back under the threshold
+	for i := 0; i < 3; i++ {
+		registry.UpdateStatistics("slow", time.Millisecond, true, 10, 10)
+	}
+	loaded, _ = registry.GetLoadedPlugin("slow")
+	assert.Equal(t, entities.PluginStatusActive, loaded.Status)
+	assert.Empty(t, loaded.ErrorMsg)
+}
+
 func TestInMemoryRegistry_IncrementPanic(t *testing.T) {
 	registry := NewInMemoryRegistry()
 
@@ -0,0 +1,145 @@
+package plugin
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// DefaultMaxCacheSize is how many plugin results the concurrent executor
+// keeps unless SetMaxCacheSize says otherwise
+const DefaultMaxCacheSize = 1000
+
+// resultCache holds up to maxSize plugin results, evicting the least
+// recently used one to make room for a new result
+type resultCache struct {
+	mu         sync.Mutex
+	entries    map[string]cachedResult
+	heap       *cacheHeap
+	heapLookup map[string]*heapEntry
+	maxSize    int
+	evictions  int64
+}
+
+// newResultCache creates a cache holding up to maxSize results
+func newResultCache(maxSize int) *resultCache {
+	h := &cacheHeap{}
+	heap.Init(h)
+
+	return &resultCache{
+		entries:    make(map[string]cachedResult),
+		heap:       h,
+		heapLookup: make(map[string]*heapEntry),
+		maxSize:    maxSize,
+	}
+}
+
+// load returns the result stored under key, marking it as just used
+func (c *resultCache) load(key string) (cachedResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result, exists := c.entries[key]
+	if !exists {
+		return cachedResult{}, false
+	}
+
+	result.lastAccess = time.Now()
+	c.entries[key] = result
+	entry := c.heapLookup[key]
+	entry.lastAccess = result.lastAccess
+	heap.Fix(c.heap, entry.index)
+
+	return result, true
+}
+
+// store adds or replaces the result under key, evicting the least recently
+// used results past the size limit
+func (c *resultCache) store(key string, result cachedResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if result.lastAccess.IsZero() {
+		result.lastAccess = result.timestamp
+	}
+
+	c.entries[key] = result
+	if entry, exists := c.heapLookup[key]; exists {
+		entry.lastAccess = result.lastAccess
+		heap.Fix(c.heap, entry.index)
+	} else {
+		entry := &heapEntry{key: key, lastAccess: result.lastAccess}
+		heap.Push(c.heap, entry)
+		c.heapLookup[key] = entry
+	}
+
+	c.evictLocked()
+}
+
+// delete removes the result stored under key
+func (c *resultCache) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeLocked(key)
+}
+
+// deleteIf removes every result for which remove returns true
+func (c *resultCache) deleteIf(remove func(cachedResult) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, result := range c.entries {
+		if remove(result) {
+			c.removeLocked(key)
+		}
+	}
+}
+
+// clear removes every result
+func (c *resultCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]cachedResult)
+	c.heapLookup = make(map[string]*heapEntry)
+	*c.heap = (*c.heap)[:0]
+}
+
+// setMaxSize changes the size limit, evicting results past the new one
+func (c *resultCache) setMaxSize(maxSize int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.maxSize = maxSize
+	c.evictLocked()
+}
+
+// stats returns the number of results held, the size limit and how many
+// results have been evicted
+func (c *resultCache) stats() (size, maxSize int, evictions int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries), c.maxSize, c.evictions
+}
+
+// evictLocked drops least recently used results until the cache fits its
+// size limit. The caller must hold c.mu.
+func (c *resultCache) evictLocked() {
+	for len(c.entries) > c.maxSize && c.heap.Len() > 0 {
+		oldest := heap.Pop(c.heap).(*heapEntry)
+		delete(c.heapLookup, oldest.key)
+		delete(c.entries, oldest.key)
+		c.evictions++
+	}
+}
+
+// removeLocked removes the result stored under key. The caller must hold c.mu.
+func (c *resultCache) removeLocked(key string) {
+	if entry, exists := c.heapLookup[key]; exists {
+		heap.Remove(c.heap, entry.index)
+		delete(c.heapLookup, key)
+	}
+	delete(c.entries, key)
+}
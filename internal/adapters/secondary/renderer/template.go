@@ -131,6 +131,8 @@ const defaultPresentationTemplate = `<!DOCTYPE html>
         .slide blockquote { border-left: 4px solid #ddd; padding-left: 1em; color: #666; }
         .slide table { border-collapse: collapse; width: 100%; }
         .slide table th, .slide table td { border: 1px solid #ddd; padding: 0.5em; }
+        .slide.draft { outline: 3px dashed #e67e22; outline-offset: -12px; }
+        .slide.draft::before { content: "DRAFT"; position: absolute; top: 16px; right: 24px; padding: 2px 8px; background: #e67e22; color: #fff; font-size: 0.75em; font-weight: bold; letter-spacing: 0.1em; border-radius: 3px; }
     </style>
 </head>
 <body>
@@ -141,7 +143,7 @@ const defaultPresentationTemplate = `<!DOCTYPE html>
         </div>
         
         {{range $index, $slide := .Slides}}
-        <div class="slide" data-index="{{$index}}">
+        <div class="slide{{if $slide.Draft}} draft{{end}}" data-index="{{$index}}"{{if $slide.Draft}} data-draft="true"{{end}}>
             {{$slide.HTML | safeHTML}}
             {{if $slide.Notes}}
             <div class="speaker-notes" style="display: none;">
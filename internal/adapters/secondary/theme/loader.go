@@ -2,12 +2,14 @@ package theme
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -71,6 +73,9 @@ func (l *DirectoryLoader) loadWithHistory(ctx context.Context, name string, visi
 	if err := l.loadConfig(theme); err != nil {
 		return nil, fmt.Errorf("loading theme config: %w", err)
 	}
+	if err := l.loadColorScheme(theme); err != nil {
+		return nil, fmt.Errorf("loading theme colors: %w", err)
+	}
 
 	// Load templates
 	if err := l.loadTemplates(theme); err != nil {
@@ -192,6 +197,98 @@ func (l *DirectoryLoader) loadConfig(theme *entities.ThemeEngine) error {
 	return nil
 }
 
+// loadColorScheme adds the colors declared in a theme.json, as installed
+// with marketplace themes, to the theme's CSS variables. Variables set in
+// theme.toml take precedence.
+func (l *DirectoryLoader) loadColorScheme(theme *entities.ThemeEngine) error {
+	colors, err := ColorSchemeVariables(theme.Path)
+	if err != nil {
+		return err
+	}
+	for name, value := range colors {
+		if _, ok := theme.Config.Variables[name]; !ok {
+			theme.Config.Variables[name] = value
+		}
+	}
+	return nil
+}
+
+// ColorSchemeVariables returns the CSS variables for the colors declared in
+// the theme.json of the theme in dir, so its stylesheets can read them
+// through var(--color-primary) and the like. A theme without a theme.json
+// has none.
+func ColorSchemeVariables(dir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "theme.json")) // #nosec G304 - path inside the theme directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading theme.json: %w", err)
+	}
+
+	var manifest PremiumTheme
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing theme.json: %w", err)
+	}
+	return manifest.Colors.Variables(), nil
+}
+
+// Fonts returns the fonts of a loaded theme, for exports to declare: those
+// in the theme.json of marketplace themes, then the [[fonts]] of its
+// theme.toml as local fonts. Local font paths are resolved against the
+// theme's directory.
+func Fonts(theme *entities.ThemeEngine) ([]ThemeFont, error) {
+	var fonts []ThemeFont
+
+	data, err := os.ReadFile(filepath.Join(theme.Path, "theme.json")) // #nosec G304 - path inside the theme directory
+	switch {
+	case err == nil:
+		var manifest PremiumTheme
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("parsing theme.json: %w", err)
+		}
+		fonts = append(fonts, manifest.Fonts...)
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("reading theme.json: %w", err)
+	}
+
+	for _, font := range theme.Config.Fonts {
+		converted := ThemeFont{Name: font.Name, Source: "local", URL: regularFontFile(font.Files)}
+		for _, fallback := range strings.Split(font.Fallback, ",") {
+			if fallback = strings.TrimSpace(fallback); fallback != "" {
+				converted.Fallbacks = append(converted.Fallbacks, fallback)
+			}
+		}
+		fonts = append(fonts, converted)
+	}
+
+	for i, font := range fonts {
+		if font.Source == "local" && font.URL != "" && !filepath.IsAbs(font.URL) {
+			fonts[i].URL = filepath.Join(theme.Path, filepath.FromSlash(font.URL))
+		}
+	}
+	return fonts, nil
+}
+
+// regularFontFile picks the file of a font's regular style from its files
+// by weight or style, or the first by name when it has none
+func regularFontFile(files map[string]string) string {
+	for _, key := range []string{"regular", "normal", "400"} {
+		if file, ok := files[key]; ok {
+			return file
+		}
+	}
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) == 0 {
+		return ""
+	}
+	return files[keys[0]]
+}
+
 // loadTemplates loads all HTML templates from the templates directory
 func (l *DirectoryLoader) loadTemplates(theme *entities.ThemeEngine) error {
 	templatesDir := filepath.Join(theme.Path, "templates")
@@ -279,6 +376,7 @@ func (l *DirectoryLoader) loadAssets(theme *entities.ThemeEngine) error {
 
 		asset := &entities.ThemeAsset{
 			Path:        assetPath,
+			Dir:         filepath.Dir(path),
 			Content:     content,
 			ContentType: entities.GetContentType(assetPath),
 			ModTime:     info.ModTime(),
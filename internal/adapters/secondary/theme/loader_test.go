@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 )
 
 func setupTestTheme(t *testing.T) string {
@@ -300,6 +302,70 @@ func TestDirectoryLoader_Reload(t *testing.T) {
 	assert.Equal(t, newCSS, string(theme2.Assets["css/main.css"].Content))
 }
 
+func TestDirectoryLoader_ColorScheme(t *testing.T) {
+	tmpDir := setupTestTheme(t)
+	themeDir := filepath.Join(tmpDir, "test-theme")
+	require.NoError(t, os.WriteFile(
+		filepath.Join(themeDir, "theme.json"),
+		[]byte(`{"id": "test-theme", "colors": {"primary": "#0b5fff", "accent": "#f97316"}}`),
+		0644,
+	))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(themeDir, "assets", "css", "main.css"),
+		[]byte(`h1 { color: var(--color-primary); border-color: var(--color-accent); }`),
+		0644,
+	))
+
+	theme, err := NewDirectoryLoader(tmpDir).Load(context.Background(), "test-theme")
+	require.NoError(t, err)
+	assert.Equal(t, "#0b5fff", theme.Config.Variables["color-primary"])
+	assert.NotContains(t, theme.Config.Variables, "color-secondary", "unset colors aren't declared")
+	assert.Equal(t, "#000", theme.Config.Variables["primary-color"], "theme.toml variables are kept")
+
+	processed, err := NewAssetProcessor(false).ProcessCSS(theme.Assets["css/main.css"].Content, theme.Config.Variables)
+	require.NoError(t, err)
+	assert.Equal(t, "h1 { color: #0b5fff; border-color: #f97316; }", string(processed))
+}
+
+func TestFonts(t *testing.T) {
+	tmpDir := setupTestTheme(t)
+	themeDir := filepath.Join(tmpDir, "test-theme")
+	require.NoError(t, os.WriteFile(
+		filepath.Join(themeDir, "theme.json"),
+		[]byte(`{"id": "test-theme", "fonts": [
+			{"name": "Inter", "source": "google", "fallbacks": ["sans-serif"]},
+			{"name": "Brand", "source": "local", "url": "assets/fonts/brand.woff2"}
+		]}`),
+		0644,
+	))
+	config, err := os.ReadFile(filepath.Join(themeDir, "theme.toml"))
+	require.NoError(t, err)
+	config = append(config, []byte(`
+[[fonts]]
+name = "Fira Code"
+fallback = "Menlo, monospace"
+files = { bold = "assets/fonts/fira-bold.woff2", regular = "assets/fonts/fira.woff2" }
+`)...)
+	require.NoError(t, os.WriteFile(filepath.Join(themeDir, "theme.toml"), config, 0644))
+
+	theme, err := NewDirectoryLoader(tmpDir).Load(context.Background(), "test-theme")
+	require.NoError(t, err)
+
+	fonts, err := Fonts(theme)
+	require.NoError(t, err)
+	assert.Equal(t, []ThemeFont{
+		{Name: "Inter", Source: "google", Fallbacks: []string{"sans-serif"}},
+		{Name: "Brand", Source: "local", URL: filepath.Join(themeDir, "assets", "fonts", "brand.woff2")},
+		{Name: "Fira Code", Source: "local", URL: filepath.Join(themeDir, "assets", "fonts", "fira.woff2"), Fallbacks: []string{"Menlo", "monospace"}},
+	}, fonts)
+
+	t.Run("theme without fonts", func(t *testing.T) {
+		fonts, err := Fonts(&entities.ThemeEngine{Path: t.TempDir()})
+		require.NoError(t, err)
+		assert.Empty(t, fonts)
+	})
+}
+
 func TestDirectoryLoader_InvalidTheme(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -5,6 +5,10 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -41,6 +45,11 @@ type PremiumTheme struct {
 	Premium     bool             `json:"premium"`
 	Featured    bool             `json:"featured"`
 	Status      ThemeStatus      `json:"status"`
+
+	// Checksum is the hex SHA-256 of the theme download
+	Checksum string `json:"sha256,omitempty"`
+	// Signature is the base64 Ed25519 signature of the theme download
+	Signature string `json:"signature,omitempty"`
 }
 
 // ThemeCategory represents theme categories
@@ -87,6 +96,32 @@ type ThemeColorScheme struct {
 	Error      string `json:"error"`
 }
 
+// Variables maps the colors set in the scheme to the CSS custom properties
+// themes read them through, such as color-primary for var(--color-primary).
+// Names are given without the leading dashes, as ProcessCSS takes them.
+func (c ThemeColorScheme) Variables() map[string]string {
+	colors := map[string]string{
+		"color-primary":    c.Primary,
+		"color-secondary":  c.Secondary,
+		"color-accent":     c.Accent,
+		"color-background": c.Background,
+		"color-surface":    c.Surface,
+		"color-text":       c.Text,
+		"color-text-muted": c.TextMuted,
+		"color-border":     c.Border,
+		"color-success":    c.Success,
+		"color-warning":    c.Warning,
+		"color-error":      c.Error,
+	}
+	variables := make(map[string]string, len(colors))
+	for name, value := range colors {
+		if value = strings.TrimSpace(value); value != "" {
+			variables[name] = value
+		}
+	}
+	return variables
+}
+
 // ThemeStatus represents theme status
 type ThemeStatus string
 
@@ -107,6 +142,11 @@ type PremiumThemeManager struct {
 	cacheExpiry  time.Time
 	userLicenses map[string][]string // user_id -> theme IDs
 	localThemes  map[string]string   // theme_id -> local path
+
+	maxExtractedBytes int64
+	maxArchiveEntries int
+	publicKey         ed25519.PublicKey
+	allowUnverified   bool
 }
 
 // PremiumThemeConfig configures the premium theme manager
@@ -117,8 +157,46 @@ type PremiumThemeConfig struct {
 	CacheTTL  time.Duration
 	UserID    string
 	ThemesDir string
+
+	// MaxExtractedBytes caps the total size extracted from a theme archive
+	MaxExtractedBytes int64
+	// MaxArchiveEntries caps the number of entries in a theme archive
+	MaxArchiveEntries int
+	// PublicKey verifies theme signatures; when set, unsigned themes are refused
+	PublicKey ed25519.PublicKey
+	// AllowUnverified installs themes published without a sha256, which are
+	// otherwise refused unless a signature verifies them
+	AllowUnverified bool
 }
 
+const (
+	// DefaultMaxExtractedBytes is the default total extraction cap (500MB)
+	DefaultMaxExtractedBytes = 500 * 1024 * 1024
+	// DefaultMaxArchiveEntries is the default archive entry cap
+	DefaultMaxArchiveEntries = 10000
+
+	// maxExtractedFileSize caps each extracted file (100MB)
+	maxExtractedFileSize = 100 * 1024 * 1024
+)
+
+var (
+	// ErrArchiveTooLarge is returned when an archive extracts to more than
+	// the configured total size
+	ErrArchiveTooLarge = errors.New("archive exceeds extracted size limit")
+	// ErrTooManyEntries is returned when an archive has more entries than
+	// the configured limit
+	ErrTooManyEntries = errors.New("archive exceeds entry limit")
+	// ErrChecksumMismatch is returned when a download doesn't match the
+	// checksum published for the theme
+	ErrChecksumMismatch = errors.New("theme checksum mismatch")
+	// ErrChecksumMissing is returned when a theme is published without a
+	// checksum and nothing else verifies its download
+	ErrChecksumMissing = errors.New("theme has no published checksum")
+	// ErrInvalidSignature is returned when a download's signature is missing
+	// or doesn't verify against the configured public key
+	ErrInvalidSignature = errors.New("theme signature invalid")
+)
+
 // NewPremiumThemeManager creates a new premium theme manager
 func NewPremiumThemeManager(config PremiumThemeConfig) *PremiumThemeManager {
 	if config.Timeout == 0 {
@@ -130,6 +208,12 @@ func NewPremiumThemeManager(config PremiumThemeConfig) *PremiumThemeManager {
 	if config.ThemesDir == "" {
 		config.ThemesDir = getDefaultThemesDirectory()
 	}
+	if config.MaxExtractedBytes <= 0 {
+		config.MaxExtractedBytes = DefaultMaxExtractedBytes
+	}
+	if config.MaxArchiveEntries <= 0 {
+		config.MaxArchiveEntries = DefaultMaxArchiveEntries
+	}
 
 	return &PremiumThemeManager{
 		baseURL: config.BaseURL,
@@ -137,9 +221,13 @@ func NewPremiumThemeManager(config PremiumThemeConfig) *PremiumThemeManager {
 		httpClient: &http.Client{
 			Timeout: config.Timeout,
 		},
-		cache:        make(map[string]*PremiumTheme),
-		userLicenses: make(map[string][]string),
-		localThemes:  make(map[string]string),
+		cache:             make(map[string]*PremiumTheme),
+		userLicenses:      make(map[string][]string),
+		localThemes:       make(map[string]string),
+		maxExtractedBytes: config.MaxExtractedBytes,
+		maxArchiveEntries: config.MaxArchiveEntries,
+		publicKey:         config.PublicKey,
+		allowUnverified:   config.AllowUnverified,
 	}
 }
 
@@ -243,7 +331,7 @@ func (ptm *PremiumThemeManager) GetTheme(themeID string) (*PremiumTheme, error)
 // DownloadTheme downloads and installs a theme (always free for open source)
 func (ptm *PremiumThemeManager) DownloadTheme(themeID, userID string) error {
 	// All themes are free in open source model
-	_, err := ptm.GetTheme(themeID)
+	theme, err := ptm.GetTheme(themeID)
 	if err != nil {
 		return err
 	}
@@ -269,6 +357,17 @@ func (ptm *PremiumThemeManager) DownloadTheme(themeID, userID string) error {
 		return fmt.Errorf("download failed: %d", resp.StatusCode)
 	}
 
+	// Read the response body to determine content type
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Refuse tampered downloads before anything is written
+	if err := ptm.verifyDownload(theme, body); err != nil {
+		return err
+	}
+
 	// Create themes directory
 	themesDir := getDefaultThemesDirectory()
 	if err := os.MkdirAll(themesDir, 0750); err != nil {
@@ -281,17 +380,12 @@ func (ptm *PremiumThemeManager) DownloadTheme(themeID, userID string) error {
 		return fmt.Errorf("failed to create theme directory: %w", err)
 	}
 
-	// Read the response body to determine content type
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	// Determine if it's a package or single file based on Content-Type and content
 	contentType := resp.Header.Get("Content-Type")
 
-	// Extract theme package based on type
+	// Extract theme package based on type, leaving nothing behind on failure
 	if err := ptm.extractThemeContent(body, contentType, themeDir); err != nil {
+		_ = os.RemoveAll(themeDir)
 		return fmt.Errorf("failed to extract theme content: %w", err)
 	}
 
@@ -303,6 +397,33 @@ func (ptm *PremiumThemeManager) DownloadTheme(themeID, userID string) error {
 	return nil
 }
 
+// verifyDownload checks a theme download against the checksum published
+// with the theme and, when a public key is configured, its signature. Themes
+// without a checksum need a signature or AllowUnverified.
+func (ptm *PremiumThemeManager) verifyDownload(theme *PremiumTheme, data []byte) error {
+	switch {
+	case theme.Checksum != "":
+		sum := sha256.Sum256(data)
+		if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, theme.Checksum) {
+			return fmt.Errorf("%w: %s has sha256 %s, expected %s", ErrChecksumMismatch, theme.ID, actual, theme.Checksum)
+		}
+	case ptm.publicKey == nil && !ptm.allowUnverified:
+		return fmt.Errorf("%w: refusing to install %s unverified", ErrChecksumMissing, theme.ID)
+	}
+
+	if ptm.publicKey == nil {
+		return nil
+	}
+	if theme.Signature == "" {
+		return fmt.Errorf("%w: %s is not signed", ErrInvalidSignature, theme.ID)
+	}
+	signature, err := base64.StdEncoding.DecodeString(theme.Signature)
+	if err != nil || !ed25519.Verify(ptm.publicKey, data, signature) {
+		return fmt.Errorf("%w: %s does not match the configured public key", ErrInvalidSignature, theme.ID)
+	}
+	return nil
+}
+
 // extractThemeContent extracts theme content based on the content type
 func (ptm *PremiumThemeManager) extractThemeContent(data []byte, contentType, destDir string) error {
 	// Detect content type if not provided
@@ -351,13 +472,31 @@ func (ptm *PremiumThemeManager) extractZip(data []byte, destDir string) error {
 		return fmt.Errorf("failed to open ZIP: %w", err)
 	}
 
+	// The central directory lists every entry, so refuse oversized archives
+	// before writing anything
+	budget := ptm.newExtractionBudget()
+	if len(reader.File) > budget.maxEntries {
+		return budget.tooManyEntries()
+	}
+	var declared uint64
+	for _, file := range reader.File {
+		declared += file.UncompressedSize64
+	}
+	if declared > uint64(budget.maxBytes) { // #nosec G115 - maxBytes is always positive
+		return budget.tooLarge()
+	}
+
 	for _, file := range reader.File {
+		if err := budget.addEntry(); err != nil {
+			return err
+		}
+
 		// Security: prevent path traversal
 		if err := ptm.validatePath(file.Name, destDir); err != nil {
 			continue // Skip invalid paths
 		}
 
-		if err := ptm.extractZipFile(file, destDir); err != nil {
+		if err := ptm.extractZipFile(file, destDir, budget); err != nil {
 			return fmt.Errorf("failed to extract file %s: %w", file.Name, err)
 		}
 	}
@@ -374,6 +513,7 @@ func (ptm *PremiumThemeManager) extractTarGz(data []byte, destDir string) error
 	defer func() { _ = gzReader.Close() }()
 
 	tarReader := tar.NewReader(gzReader)
+	budget := ptm.newExtractionBudget()
 
 	for {
 		header, err := tarReader.Next()
@@ -384,12 +524,16 @@ func (ptm *PremiumThemeManager) extractTarGz(data []byte, destDir string) error
 			return fmt.Errorf("failed to read tar entry: %w", err)
 		}
 
+		if err := budget.addEntry(); err != nil {
+			return err
+		}
+
 		// Security: prevent path traversal
 		if err := ptm.validatePath(header.Name, destDir); err != nil {
 			continue // Skip invalid paths
 		}
 
-		if err := ptm.extractTarFile(tarReader, header, destDir); err != nil {
+		if err := ptm.extractTarFile(tarReader, header, destDir, budget); err != nil {
 			return fmt.Errorf("failed to extract file %s: %w", header.Name, err)
 		}
 	}
@@ -416,7 +560,7 @@ func (ptm *PremiumThemeManager) extractSingleFile(data []byte, destDir, filename
 }
 
 // extractZipFile extracts a single file from a ZIP archive
-func (ptm *PremiumThemeManager) extractZipFile(file *zip.File, destDir string) error {
+func (ptm *PremiumThemeManager) extractZipFile(file *zip.File, destDir string, budget *extractionBudget) error {
 	// #nosec G305 - file.Name is validated from trusted theme archive sources
 	// Path traversal protection is handled by theme validation and trusted marketplace
 	destPath := filepath.Join(destDir, file.Name)
@@ -444,9 +588,7 @@ func (ptm *PremiumThemeManager) extractZipFile(file *zip.File, destDir string) e
 	}
 	defer func() { _ = writer.Close() }()
 
-	// Limit copy size to prevent decompression bombs (max 100MB)
-	const maxFileSize = 100 * 1024 * 1024
-	if _, err := io.Copy(writer, io.LimitReader(reader, maxFileSize)); err != nil {
+	if err := budget.copy(writer, reader); err != nil {
 		return err
 	}
 
@@ -455,7 +597,7 @@ func (ptm *PremiumThemeManager) extractZipFile(file *zip.File, destDir string) e
 }
 
 // extractTarFile extracts a single file from a tar archive
-func (ptm *PremiumThemeManager) extractTarFile(reader *tar.Reader, header *tar.Header, destDir string) error {
+func (ptm *PremiumThemeManager) extractTarFile(reader *tar.Reader, header *tar.Header, destDir string, budget *extractionBudget) error {
 	// #nosec G305 - header.Name is validated from trusted theme archive sources
 	// Path traversal protection is handled by theme validation and trusted marketplace
 	destPath := filepath.Join(destDir, header.Name)
@@ -480,9 +622,7 @@ func (ptm *PremiumThemeManager) extractTarFile(reader *tar.Reader, header *tar.H
 		}
 		defer func() { _ = file.Close() }()
 
-		// Limit copy size to prevent decompression bombs (max 100MB)
-		const maxFileSize = 100 * 1024 * 1024
-		if _, err := io.Copy(file, io.LimitReader(reader, maxFileSize)); err != nil {
+		if err := budget.copy(file, reader); err != nil {
 			return err
 		}
 
@@ -497,6 +637,59 @@ func (ptm *PremiumThemeManager) extractTarFile(reader *tar.Reader, header *tar.H
 	}
 }
 
+// extractionBudget tracks the running totals of one archive extraction
+// against the manager's limits to stop decompression bombs
+type extractionBudget struct {
+	maxBytes   int64
+	maxEntries int
+	bytes      int64
+	entries    int
+}
+
+// newExtractionBudget starts a budget for extracting a single archive
+func (ptm *PremiumThemeManager) newExtractionBudget() *extractionBudget {
+	return &extractionBudget{
+		maxBytes:   ptm.maxExtractedBytes,
+		maxEntries: ptm.maxArchiveEntries,
+	}
+}
+
+// addEntry counts an archive entry, failing once there are too many
+func (b *extractionBudget) addEntry() error {
+	b.entries++
+	if b.entries > b.maxEntries {
+		return b.tooManyEntries()
+	}
+	return nil
+}
+
+func (b *extractionBudget) tooManyEntries() error {
+	return fmt.Errorf("%w: more than %d entries", ErrTooManyEntries, b.maxEntries)
+}
+
+// copy writes one file's content, truncated at the per-file cap, and fails
+// once the archive's total goes over the limit
+func (b *extractionBudget) copy(dst io.Writer, src io.Reader) error {
+	limit := b.maxBytes - b.bytes + 1
+	if limit > maxExtractedFileSize {
+		limit = maxExtractedFileSize
+	}
+
+	n, err := io.Copy(dst, io.LimitReader(src, limit))
+	b.bytes += n
+	if err != nil {
+		return err
+	}
+	if b.bytes > b.maxBytes {
+		return b.tooLarge()
+	}
+	return nil
+}
+
+func (b *extractionBudget) tooLarge() error {
+	return fmt.Errorf("%w: more than %d bytes", ErrArchiveTooLarge, b.maxBytes)
+}
+
 // validatePath ensures the path is safe and within the destination directory
 func (ptm *PremiumThemeManager) validatePath(path, destDir string) error {
 	// Clean the path
@@ -688,6 +881,11 @@ func (ptm *PremiumThemeManager) filterFromCache(category ThemeCategory, premiumO
 	return results
 }
 
+// UserThemesDirectory returns the directory marketplace themes are installed in
+func UserThemesDirectory() string {
+	return getDefaultThemesDirectory()
+}
+
 func getDefaultThemesDirectory() string {
 	// Try XDG config directory first
 	if configDir := os.Getenv("XDG_CONFIG_HOME"); configDir != "" {
@@ -0,0 +1,272 @@
+package theme
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildZip returns a zip archive with the given files
+func buildZip(t *testing.T, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+// buildTarGz returns a gzipped tar archive with the given files
+func buildTarGz(t *testing.T, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	writer := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, writer.WriteHeader(&tar.Header{
+			Name:     name,
+			Typeflag: tar.TypeReg,
+			Mode:     0600,
+			Size:     int64(len(content)),
+		}))
+		_, err := writer.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+// manyFiles returns n small files
+func manyFiles(n int) map[string][]byte {
+	files := make(map[string][]byte, n)
+	for i := 0; i < n; i++ {
+		files[fmt.Sprintf("assets/%d.css", i)] = []byte("body{}")
+	}
+	return files
+}
+
+func TestPremiumThemeManager_ExtractionLimits(t *testing.T) {
+	ptm := NewPremiumThemeManager(PremiumThemeConfig{
+		MaxExtractedBytes: 1024,
+		MaxArchiveEntries: 5,
+	})
+
+	// Highly compressible content, as in a decompression bomb
+	bomb := map[string][]byte{
+		"a.css": bytes.Repeat([]byte{' '}, 600),
+		"b.css": bytes.Repeat([]byte{' '}, 600),
+	}
+
+	tests := []struct {
+		name    string
+		extract func(data []byte, destDir string) error
+		build   func(t *testing.T, files map[string][]byte) []byte
+	}{
+		{"zip", ptm.extractZip, buildZip},
+		{"tar.gz", ptm.extractTarGz, buildTarGz},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" within limits", func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, tt.extract(tt.build(t, manyFiles(5)), dir))
+			assert.FileExists(t, filepath.Join(dir, "assets", "4.css"))
+		})
+
+		t.Run(tt.name+" too many entries", func(t *testing.T) {
+			err := tt.extract(tt.build(t, manyFiles(6)), t.TempDir())
+			assert.ErrorIs(t, err, ErrTooManyEntries)
+		})
+
+		t.Run(tt.name+" too large in total", func(t *testing.T) {
+			dir := t.TempDir()
+			err := tt.extract(tt.build(t, bomb), dir)
+			assert.ErrorIs(t, err, ErrArchiveTooLarge)
+
+			// Nothing past the limit reaches the disk
+			var written int64
+			require.NoError(t, filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+				if err == nil && !info.IsDir() {
+					written += info.Size()
+				}
+				return err
+			}))
+			assert.LessOrEqual(t, written, int64(1025))
+		})
+	}
+
+	t.Run("zip refused before writing", func(t *testing.T) {
+		dir := t.TempDir()
+		require.ErrorIs(t, ptm.extractZip(buildZip(t, manyFiles(50)), dir), ErrTooManyEntries)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("zip declaring too much content refused before writing", func(t *testing.T) {
+		dir := t.TempDir()
+		require.ErrorIs(t, ptm.extractZip(buildZip(t, bomb), dir), ErrArchiveTooLarge)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestNewPremiumThemeManager_DefaultLimits(t *testing.T) {
+	ptm := NewPremiumThemeManager(PremiumThemeConfig{})
+	assert.Equal(t, int64(DefaultMaxExtractedBytes), ptm.maxExtractedBytes)
+	assert.Equal(t, DefaultMaxArchiveEntries, ptm.maxArchiveEntries)
+}
+
+func TestPremiumThemeManager_DownloadThemeRemovesRefusedArchive(t *testing.T) {
+	archive := buildZip(t, manyFiles(3))
+	sum := sha256.Sum256(archive)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/themes/bomb/download" {
+			w.Header().Set("Content-Type", "application/zip")
+			_, _ = w.Write(archive)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(PremiumTheme{ID: "bomb", Name: "Bomb", Checksum: hex.EncodeToString(sum[:])})
+	}))
+	defer server.Close()
+
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	ptm := NewPremiumThemeManager(PremiumThemeConfig{BaseURL: server.URL, MaxArchiveEntries: 2})
+	err := ptm.DownloadTheme("bomb", "user")
+	require.ErrorIs(t, err, ErrTooManyEntries)
+
+	assert.NoDirExists(t, filepath.Join(configHome, "slicli", "themes", "bomb"))
+	assert.False(t, ptm.IsThemeInstalled("bomb"))
+}
+
+func TestPremiumThemeManager_DownloadThemeVerifiesIntegrity(t *testing.T) {
+	archive := buildZip(t, map[string][]byte{"style.css": []byte("body{}")})
+	sum := sha256.Sum256(archive)
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, archive))
+
+	// serve publishes meta for the theme and sends payload as its download
+	serve := func(t *testing.T, meta PremiumTheme, payload []byte) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/v1/themes/"+meta.ID+"/download" {
+				w.Header().Set("Content-Type", "application/zip")
+				_, _ = w.Write(payload)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(meta)
+		}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+
+	tampered := append([]byte(nil), archive...)
+	tampered[len(tampered)/2] ^= 0xFF
+
+	tests := []struct {
+		name            string
+		meta            PremiumTheme
+		payload         []byte
+		publicKey       ed25519.PublicKey
+		allowUnverified bool
+		wantErr         error
+	}{
+		{
+			name:    "matching checksum installs",
+			meta:    PremiumTheme{ID: "clean", Checksum: hex.EncodeToString(sum[:])},
+			payload: archive,
+		},
+		{
+			name:    "tampered payload rejected",
+			meta:    PremiumTheme{ID: "tampered", Checksum: hex.EncodeToString(sum[:])},
+			payload: tampered,
+			wantErr: ErrChecksumMismatch,
+		},
+		{
+			name:    "missing checksum rejected",
+			meta:    PremiumTheme{ID: "unverified"},
+			payload: archive,
+			wantErr: ErrChecksumMissing,
+		},
+		{
+			name:            "missing checksum installs when allowed",
+			meta:            PremiumTheme{ID: "allowed"},
+			payload:         archive,
+			allowUnverified: true,
+		},
+		{
+			name:      "signature verifies a theme without checksum",
+			meta:      PremiumTheme{ID: "signed-only", Signature: signature},
+			payload:   archive,
+			publicKey: publicKey,
+		},
+		{
+			name:      "valid signature installs",
+			meta:      PremiumTheme{ID: "signed", Checksum: hex.EncodeToString(sum[:]), Signature: signature},
+			payload:   archive,
+			publicKey: publicKey,
+		},
+		{
+			name:      "unsigned theme rejected with a public key",
+			meta:      PremiumTheme{ID: "unsigned", Checksum: hex.EncodeToString(sum[:])},
+			payload:   archive,
+			publicKey: publicKey,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "signature by another key rejected",
+			meta:      PremiumTheme{ID: "forged", Signature: signature},
+			payload:   archive,
+			publicKey: make(ed25519.PublicKey, ed25519.PublicKeySize),
+			wantErr:   ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configHome := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", configHome)
+
+			ptm := NewPremiumThemeManager(PremiumThemeConfig{
+				BaseURL:         serve(t, tt.meta, tt.payload),
+				PublicKey:       tt.publicKey,
+				AllowUnverified: tt.allowUnverified,
+			})
+			err := ptm.DownloadTheme(tt.meta.ID, "user")
+			themeDir := filepath.Join(configHome, "slicli", "themes", tt.meta.ID)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.NoDirExists(t, themeDir)
+				assert.False(t, ptm.IsThemeInstalled(tt.meta.ID))
+				return
+			}
+			require.NoError(t, err)
+			assert.FileExists(t, filepath.Join(themeDir, "style.css"))
+		})
+	}
+}
@@ -3,25 +3,78 @@ package theme
 import (
 	"bytes"
 	"fmt"
+	"log"
+	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/internal/domain/ports"
 )
 
+var (
+	// cssVariablePattern matches a custom property declaration, --name: value;
+	cssVariablePattern = regexp.MustCompile(`--([a-zA-Z0-9-]+):\s*([^;]+);`)
+
+	// varWithFallbackPattern matches var(--name, fallback)
+	varWithFallbackPattern = regexp.MustCompile(`var\(--([a-zA-Z0-9-]+),\s*([^)]+)\)`)
+
+	// varPattern matches var(--name)
+	varPattern = regexp.MustCompile(`var\(--([a-zA-Z0-9-]+)\)`)
+
+	// varReferencePattern matches the variable any var() call refers to
+	varReferencePattern = regexp.MustCompile(`var\(--([a-zA-Z0-9-]+)`)
+
+	// rootBlockPattern matches a :root rule
+	rootBlockPattern = regexp.MustCompile(`(:root\s*\{[^}]*\})`)
+
+	// importPattern matches @import "file"; and @import url("file");
+	importPattern = regexp.MustCompile(`@import\s+(?:url\(\s*)?["']([^"']+)["']\s*\)?\s*;`)
+)
+
+// DefaultMaxVariableDepth is how many levels of var() nesting ProcessCSS
+// resolves unless configured otherwise
+const DefaultMaxVariableDepth = 10
+
+// MaxImportDepth is how deeply ResolveImports follows nested @import chains
+const MaxImportDepth = 10
+
+// VariableWarning describes a var() reference ProcessCSS could not resolve
+type VariableWarning struct {
+	Name   string
+	Reason string
+}
+
+// String formats the warning for logs
+func (w VariableWarning) String() string {
+	return fmt.Sprintf("--%s: %s", w.Name, w.Reason)
+}
+
 // AssetProcessor processes theme assets
 type AssetProcessor struct {
-	minifyEnabled bool
+	minifyEnabled    bool
+	maxVariableDepth int
 }
 
 // NewAssetProcessor creates a new asset processor
 func NewAssetProcessor(minifyEnabled bool) *AssetProcessor {
 	return &AssetProcessor{
-		minifyEnabled: minifyEnabled,
+		minifyEnabled:    minifyEnabled,
+		maxVariableDepth: DefaultMaxVariableDepth,
 	}
 }
 
+// SetMaxVariableDepth sets how many levels of var() nesting are resolved.
+// Values below 1 restore DefaultMaxVariableDepth.
+func (p *AssetProcessor) SetMaxVariableDepth(depth int) {
+	if depth < 1 {
+		depth = DefaultMaxVariableDepth
+	}
+	p.maxVariableDepth = depth
+}
+
 // Process processes content based on content type
 func (p *AssetProcessor) Process(content []byte, contentType string, variables map[string]string) ([]byte, error) {
 	switch contentType {
@@ -37,16 +90,164 @@ func (p *AssetProcessor) Process(content []byte, contentType string, variables m
 	}
 }
 
-// ProcessCSS processes CSS with variable substitution
+// ProcessCSS processes CSS with variable substitution. References that can't
+// be resolved are left in place and logged as a warning.
 func (p *AssetProcessor) ProcessCSS(content []byte, variables map[string]string) ([]byte, error) {
-	css := string(content)
+	css, warnings := p.resolveVariables(string(content), variables)
+	if len(warnings) > 0 {
+		messages := make([]string, len(warnings))
+		for i, w := range warnings {
+			messages[i] = w.String()
+		}
+		log.Printf("[WARN] unresolved CSS variables: %s", strings.Join(messages, "; "))
+	}
+
+	// Update :root CSS variables definitions with provided variables
+	css = rootBlockPattern.ReplaceAllStringFunc(css, func(match string) string {
+		for name, value := range variables {
+			// Replace --name: oldvalue; patterns with --name: newvalue;
+			oldVar := fmt.Sprintf(`(--%s:\s*[^;]+;)`, regexp.QuoteMeta(name))
+			newVar := fmt.Sprintf("--%s: %s;", name, value)
+			match = regexp.MustCompile(oldVar).ReplaceAllString(match, newVar)
+		}
+		return match
+	})
+
+	// Local imports are spliced in by ResolveImports before this runs; any
+	// left over couldn't be resolved and are disabled
+	css = importPattern.ReplaceAllStringFunc(css, func(match string) string {
+		if isRemoteImport(importPattern.FindStringSubmatch(match)[1]) {
+			return match
+		}
+		return "/* " + match + " */"
+	})
+
+	result := []byte(css)
+
+	// Optionally minify
+	if p.minifyEnabled {
+		minified, err := p.MinifyCSS(result)
+		if err != nil {
+			// If minification fails, return processed but not minified
+			return result, nil
+		}
+		result = minified
+	}
 
+	return result, nil
+}
+
+// ResolveImports replaces each local @import in a stylesheet with the
+// imported file, read relative to dir and itself resolved recursively, so a
+// theme can build on another theme's CSS. Imports must stay inside root, the
+// directory holding the themes, after following symlinks. Remote imports
+// such as web fonts are left for the browser. Import cycles and chains
+// deeper than MaxImportDepth are errors.
+func (p *AssetProcessor) ResolveImports(content []byte, dir, root string) ([]byte, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("resolving themes directory: %w", err)
+	}
+	realRoot, err := filepath.EvalSymlinks(absRoot)
+	if err != nil {
+		return nil, fmt.Errorf("resolving themes directory: %w", err)
+	}
+
+	css, err := resolveImports(string(content), dir, realRoot, nil)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(css), nil
+}
+
+// resolveImports splices the imports of css, tracking the chain of files
+// being imported to detect cycles
+func resolveImports(css, dir, root string, chain []string) (string, error) {
+	var resolveErr error
+	css = importPattern.ReplaceAllStringFunc(css, func(match string) string {
+		target := importPattern.FindStringSubmatch(match)[1]
+		// Root-relative imports name a server path, not a theme file
+		if resolveErr != nil || isRemoteImport(target) || strings.HasPrefix(target, "/") {
+			return match
+		}
+
+		path, err := resolveImportPath(dir, root, target)
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+
+		for i, imported := range chain {
+			if imported == path {
+				cycle := append(append([]string{}, chain[i:]...), path)
+				for j := range cycle {
+					cycle[j] = filepath.Base(cycle[j])
+				}
+				resolveErr = fmt.Errorf("circular @import: %s", strings.Join(cycle, " -> "))
+				return match
+			}
+		}
+		if len(chain) >= MaxImportDepth {
+			resolveErr = fmt.Errorf("@import of %s nests deeper than %d levels", target, MaxImportDepth)
+			return match
+		}
+
+		imported, err := os.ReadFile(path) // #nosec G304 - kept inside the themes directory by resolveImportPath
+		if err != nil {
+			resolveErr = fmt.Errorf("reading @import %s: %w", target, err)
+			return match
+		}
+
+		resolved, err := resolveImports(string(imported), filepath.Dir(path), root, append(chain, path))
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+		return fmt.Sprintf("/* @import %s */\n%s", target, resolved)
+	})
+
+	return css, resolveErr
+}
+
+// resolveImportPath returns the file an @import in dir names, refusing
+// files outside root, the directory holding the themes
+func resolveImportPath(dir, root, target string) (string, error) {
+	path, err := filepath.EvalSymlinks(filepath.Join(dir, filepath.FromSlash(target)))
+	if err != nil {
+		return "", fmt.Errorf("reading @import %s: %w", target, err)
+	}
+	if !filepath.IsAbs(path) {
+		if path, err = filepath.Abs(path); err != nil {
+			return "", fmt.Errorf("reading @import %s: %w", target, err)
+		}
+	}
+	if rel, err := filepath.Rel(root, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("@import %s: file is outside the themes directory", target)
+	}
+	return path, nil
+}
+
+// isRemoteImport reports whether an @import target is a URL the browser fetches
+func isRemoteImport(target string) bool {
+	return strings.Contains(target, "://") || strings.HasPrefix(target, "//") || strings.HasPrefix(target, "data:")
+}
+
+// CheckVariables reports the var() references in a stylesheet that
+// ProcessCSS would leave unresolved: undefined variables, cycles, and nesting
+// deeper than the configured depth
+func (p *AssetProcessor) CheckVariables(content []byte, variables map[string]string) []VariableWarning {
+	_, warnings := p.resolveVariables(string(content), variables)
+	return warnings
+}
+
+// resolveVariables substitutes var() references one nesting level per pass,
+// up to the configured depth, and reports the references left over
+func (p *AssetProcessor) resolveVariables(css string, variables map[string]string) (string, []VariableWarning) {
 	// Create a complete variable map including values from :root definitions
 	allVariables := make(map[string]string)
 
 	// First extract variables from :root definitions
-	rootPattern := regexp.MustCompile(`--([a-zA-Z0-9-]+):\s*([^;]+);`)
-	rootMatches := rootPattern.FindAllStringSubmatch(css, -1)
+	rootMatches := cssVariablePattern.FindAllStringSubmatch(css, -1)
 	for _, match := range rootMatches {
 		if len(match) >= 3 {
 			allVariables[match[1]] = strings.TrimSpace(match[2])
@@ -58,13 +259,24 @@ func (p *AssetProcessor) ProcessCSS(content []byte, variables map[string]string)
 		allVariables[name] = value
 	}
 
+	// Variables whose values lead back to themselves can never resolve, and
+	// substituting them would only grow the stylesheet on every pass
+	cyclic := make(map[string]bool)
+	for name := range allVariables {
+		if variableCycle(name, allVariables) != nil {
+			cyclic[name] = true
+		}
+	}
+
 	// Process var() calls recursively to handle nested patterns
-	maxIterations := 10 // Prevent infinite loops
-	for i := 0; i < maxIterations; i++ {
+	maxDepth := p.maxVariableDepth
+	if maxDepth < 1 {
+		maxDepth = DefaultMaxVariableDepth
+	}
+	for i := 0; i < maxDepth; i++ {
 		changed := false
 
 		// Handle patterns with fallbacks: var(--name, fallback)
-		varWithFallbackPattern := regexp.MustCompile(`var\(--([a-zA-Z0-9-]+),\s*([^)]+)\)`)
 		css = varWithFallbackPattern.ReplaceAllStringFunc(css, func(match string) string {
 			parts := varWithFallbackPattern.FindStringSubmatch(match)
 			if len(parts) < 3 {
@@ -73,7 +285,7 @@ func (p *AssetProcessor) ProcessCSS(content []byte, variables map[string]string)
 			varName := parts[1]
 			fallback := strings.TrimSpace(parts[2])
 
-			if value, ok := allVariables[varName]; ok {
+			if value, ok := allVariables[varName]; ok && !cyclic[varName] {
 				changed = true
 				return value
 			}
@@ -83,10 +295,9 @@ func (p *AssetProcessor) ProcessCSS(content []byte, variables map[string]string)
 		})
 
 		// Handle simple patterns: var(--name)
-		varPattern := regexp.MustCompile(`var\(--([a-zA-Z0-9-]+)\)`)
 		css = varPattern.ReplaceAllStringFunc(css, func(match string) string {
 			varName := match[6 : len(match)-1] // Remove "var(--" and ")"
-			if value, ok := allVariables[varName]; ok {
+			if value, ok := allVariables[varName]; ok && !cyclic[varName] {
 				changed = true
 				return value
 			}
@@ -99,40 +310,117 @@ func (p *AssetProcessor) ProcessCSS(content []byte, variables map[string]string)
 		}
 	}
 
-	// Update :root CSS variables definitions with provided variables
-	rootUpdatePattern := regexp.MustCompile(`(:root\s*\{[^}]*\})`)
-	css = rootUpdatePattern.ReplaceAllStringFunc(css, func(match string) string {
-		for name, value := range variables {
-			// Replace --name: oldvalue; patterns with --name: newvalue;
-			oldVar := fmt.Sprintf(`(--%s:\s*[^;]+;)`, regexp.QuoteMeta(name))
-			newVar := fmt.Sprintf("--%s: %s;", name, value)
-			varPattern := regexp.MustCompile(oldVar)
-			match = varPattern.ReplaceAllString(match, newVar)
+	return css, unresolvedVariables(css, allVariables, maxDepth)
+}
+
+// unresolvedVariables explains each variable still referenced through var()
+// after resolution, in order of first appearance
+func unresolvedVariables(css string, values map[string]string, maxDepth int) []VariableWarning {
+	var warnings []VariableWarning
+	seen := make(map[string]bool)
+	for _, match := range varPattern.FindAllStringSubmatch(css, -1) {
+		name := match[1]
+		if seen[name] {
+			continue
 		}
-		return match
-	})
+		seen[name] = true
 
-	// Process @import statements for theme inheritance
-	// This is a simple implementation - in production you might want more sophisticated handling
-	importPattern := regexp.MustCompile(`@import\s+["']([^"']+)["'];`)
-	css = importPattern.ReplaceAllStringFunc(css, func(match string) string {
-		// For now, just remove @import statements as they're handled at load time
-		return "/* " + match + " */"
-	})
+		reason := fmt.Sprintf("nested deeper than the limit of %d", maxDepth)
+		if _, ok := values[name]; !ok {
+			reason = "not defined"
+		} else if cycle := variableCycle(name, values); cycle != nil {
+			reason = "cycle " + strings.Join(cycle, " -> ")
+		}
+		warnings = append(warnings, VariableWarning{Name: name, Reason: reason})
+	}
+	return warnings
+}
 
-	result := []byte(css)
+// variableCycle follows the var() references in variable values from name and
+// returns the chain of names that leads back onto itself, or nil
+func variableCycle(name string, values map[string]string) []string {
+	var path []string
+	done := make(map[string]bool)
+
+	var visit func(string) []string
+	visit = func(current string) []string {
+		for i, onPath := range path {
+			if onPath == current {
+				cycle := make([]string, 0, len(path)-i+1)
+				for _, n := range append(path[i:], current) {
+					cycle = append(cycle, "--"+n)
+				}
+				return cycle
+			}
+		}
+		value, ok := values[current]
+		if !ok || done[current] {
+			return nil
+		}
 
-	// Optionally minify
-	if p.minifyEnabled {
-		minified, err := p.MinifyCSS(result)
-		if err != nil {
-			// If minification fails, return processed but not minified
-			return result, nil
+		path = append(path, current)
+		for _, ref := range varReferencePattern.FindAllStringSubmatch(value, -1) {
+			if cycle := visit(ref[1]); cycle != nil {
+				return cycle
+			}
 		}
-		result = minified
+		path = path[:len(path)-1]
+		done[current] = true
+		return nil
 	}
 
-	return result, nil
+	return visit(name)
+}
+
+// InspectVariables lists the custom properties a stylesheet declares in :root
+// or reads through var(), sorted by name. As in ProcessCSS, the theme's
+// configured variables take precedence over :root values; properties that are
+// only used report their var() fallback as the default, if any.
+func (p *AssetProcessor) InspectVariables(content []byte, variables map[string]string) []entities.ThemeVariable {
+	css := string(content)
+	found := make(map[string]*entities.ThemeVariable)
+	lookup := func(name string) *entities.ThemeVariable {
+		if v, ok := found[name]; ok {
+			return v
+		}
+		v := &entities.ThemeVariable{Name: name}
+		found[name] = v
+		return v
+	}
+
+	for _, block := range rootBlockPattern.FindAllString(css, -1) {
+		for _, match := range cssVariablePattern.FindAllStringSubmatch(block, -1) {
+			v := lookup(match[1])
+			v.Declared = true
+			v.Default = strings.TrimSpace(match[2])
+		}
+	}
+	for name, value := range variables {
+		v := lookup(name)
+		v.Declared = true
+		v.Default = value
+	}
+
+	for _, match := range varWithFallbackPattern.FindAllStringSubmatch(css, -1) {
+		v := lookup(match[1])
+		v.Used = true
+		// A nested var() fallback is cut short by the pattern, so skip it
+		if fallback := strings.TrimSpace(match[2]); !v.Declared && v.Default == "" && !strings.Contains(fallback, "var(") {
+			v.Default = fallback
+		}
+	}
+	for _, match := range varPattern.FindAllStringSubmatch(css, -1) {
+		lookup(match[1]).Used = true
+	}
+
+	result := make([]entities.ThemeVariable, 0, len(found))
+	for _, v := range found {
+		result = append(result, *v)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
 }
 
 // MinifyCSS minifies CSS content
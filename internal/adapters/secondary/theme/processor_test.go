@@ -1,8 +1,16 @@
 package theme
 
 import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -106,6 +114,86 @@ func TestAssetProcessor_ProcessCSS(t *testing.T) {
 	}
 }
 
+func TestAssetProcessor_ResolveImports(t *testing.T) {
+	processor := NewAssetProcessor(false)
+
+	writeCSS := func(t *testing.T, path, css string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0750))
+		require.NoError(t, os.WriteFile(path, []byte(css), 0600))
+	}
+
+	t.Run("two-level import chain", func(t *testing.T) {
+		themes := t.TempDir()
+		writeCSS(t, filepath.Join(themes, "base", "assets", "css", "reset.css"), "* { margin: 0; }")
+		writeCSS(t, filepath.Join(themes, "base", "assets", "css", "base.css"), "@import 'reset.css';\nbody { color: var(--text); }")
+		childDir := filepath.Join(themes, "child", "assets", "css")
+
+		child := `@import url("https://fonts.example.com/inter.css");
+@import "../../../base/assets/css/base.css";
+h1 { color: red; }`
+		result, err := processor.ResolveImports([]byte(child), childDir, themes)
+		require.NoError(t, err)
+
+		css := string(result)
+		assert.Contains(t, css, `@import url("https://fonts.example.com/inter.css");`, "remote imports are left for the browser")
+		assert.Less(t, strings.Index(css, "* { margin: 0; }"), strings.Index(css, "body { color"), "imports are spliced at the import site")
+		assert.Less(t, strings.Index(css, "body { color"), strings.Index(css, "h1 { color: red; }"))
+		assert.NotContains(t, css, "@import 'reset.css';")
+
+		processed, err := processor.ProcessCSS(result, map[string]string{"text": "#111"})
+		require.NoError(t, err)
+		assert.Contains(t, string(processed), "body { color: #111; }", "imported CSS gets variable substitution")
+		assert.Contains(t, string(processed), `@import url("https://fonts.example.com/inter.css");`)
+	})
+
+	t.Run("cyclic import", func(t *testing.T) {
+		dir := t.TempDir()
+		writeCSS(t, filepath.Join(dir, "a.css"), "@import 'b.css';")
+		writeCSS(t, filepath.Join(dir, "b.css"), "@import 'a.css';")
+
+		_, err := processor.ResolveImports([]byte("@import 'a.css';"), dir, dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "circular @import: a.css -> b.css -> a.css")
+	})
+
+	t.Run("import chain too deep", func(t *testing.T) {
+		dir := t.TempDir()
+		for i := 0; i <= MaxImportDepth; i++ {
+			writeCSS(t, filepath.Join(dir, fmt.Sprintf("%d.css", i)), fmt.Sprintf("@import '%d.css';", i+1))
+		}
+
+		_, err := processor.ResolveImports([]byte("@import '0.css';"), dir, dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nests deeper than")
+	})
+
+	t.Run("missing import", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := processor.ResolveImports([]byte("@import 'missing.css';"), dir, dir)
+		assert.ErrorContains(t, err, "reading @import missing.css")
+	})
+
+	t.Run("imports outside the themes directory", func(t *testing.T) {
+		outside := t.TempDir()
+		writeCSS(t, filepath.Join(outside, "secret.css"), "ssh-config")
+		themes := t.TempDir()
+		themeDir := filepath.Join(themes, "evil")
+		writeCSS(t, filepath.Join(themeDir, "style.css"), "")
+
+		rel, err := filepath.Rel(themeDir, filepath.Join(outside, "secret.css"))
+		require.NoError(t, err)
+		_, err = processor.ResolveImports([]byte("@import '"+filepath.ToSlash(rel)+"';"), themeDir, themes)
+		assert.ErrorContains(t, err, "file is outside the themes directory")
+
+		if runtime.GOOS != "windows" {
+			require.NoError(t, os.Symlink(filepath.Join(outside, "secret.css"), filepath.Join(themeDir, "link.css")))
+			result, err := processor.ResolveImports([]byte("@import 'link.css';"), themeDir, themes)
+			assert.ErrorContains(t, err, "@import link.css: file is outside the themes directory", "symlinks are followed before checking")
+			assert.NotContains(t, string(result), "ssh-config")
+		}
+	})
+}
+
 func TestAssetProcessor_ProcessJS(t *testing.T) {
 	processor := NewAssetProcessor(false)
 
@@ -344,3 +432,101 @@ func TestAssetProcessor_Process(t *testing.T) {
 		})
 	}
 }
+
+func TestAssetProcessor_InspectVariables(t *testing.T) {
+	processor := NewAssetProcessor(false)
+
+	css := `:root {
+		--primary-color: #2563eb;
+		--font-size-base: 20px;
+		--unused-color: #999;
+	}
+	body {
+		color: var(--primary-color);
+		font-size: var(--font-size-base, 18px);
+		background: var(--background-color, #fff);
+		border-color: var(--border-color);
+		outline-color: var(--accent-color, var(--primary-color));
+	}`
+
+	variables := processor.InspectVariables([]byte(css), map[string]string{"font-size-base": "22px"})
+	assert.Equal(t, []entities.ThemeVariable{
+		{Name: "accent-color", Used: true},
+		{Name: "background-color", Default: "#fff", Used: true},
+		{Name: "border-color", Used: true},
+		{Name: "font-size-base", Default: "22px", Declared: true, Used: true},
+		{Name: "primary-color", Default: "#2563eb", Declared: true, Used: true},
+		{Name: "unused-color", Default: "#999", Declared: true},
+	}, variables)
+
+	assert.Empty(t, processor.InspectVariables([]byte("body { color: red; }"), nil))
+}
+
+func TestAssetProcessor_UnresolvedVariables(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	t.Run("cycle is reported", func(t *testing.T) {
+		logged.Reset()
+		processor := NewAssetProcessor(false)
+		css := `:root { --a: var(--b); --b: var(--c); --c: var(--a); --ok: 1px; }
+body { margin: var(--a); padding: var(--ok); border-color: var(--b, red); }`
+
+		result, err := processor.ProcessCSS([]byte(css), nil)
+		require.NoError(t, err)
+		assert.Contains(t, string(result), "margin: var(--a);", "cyclic references are left for the browser")
+		assert.Contains(t, string(result), "padding: 1px;")
+		assert.Contains(t, string(result), "border-color: red;", "a fallback is used instead of a cyclic variable")
+
+		assert.Contains(t, logged.String(), "[WARN] unresolved CSS variables:")
+		assert.Contains(t, logged.String(), "--a: cycle --a -> --b -> --c -> --a")
+
+		assert.Equal(t, []VariableWarning{
+			{Name: "b", Reason: "cycle --b -> --c -> --a -> --b"},
+			{Name: "c", Reason: "cycle --c -> --a -> --b -> --c"},
+			{Name: "a", Reason: "cycle --a -> --b -> --c -> --a"},
+		}, processor.CheckVariables([]byte(css), nil), "every variable in the cycle is listed")
+	})
+
+	t.Run("self reference does not grow the output", func(t *testing.T) {
+		processor := NewAssetProcessor(false)
+		css := `:root { --a: var(--a) var(--a); } body { margin: var(--a); }`
+		result, err := processor.ProcessCSS([]byte(css), nil)
+		require.NoError(t, err)
+		assert.Equal(t, css, string(result))
+		assert.Equal(t, []VariableWarning{{Name: "a", Reason: "cycle --a -> --a"}}, processor.CheckVariables([]byte(css), nil))
+	})
+
+	t.Run("undefined variable", func(t *testing.T) {
+		processor := NewAssetProcessor(false)
+		warnings := processor.CheckVariables([]byte(`body { color: var(--missing); }`), nil)
+		assert.Equal(t, []VariableWarning{{Name: "missing", Reason: "not defined"}}, warnings)
+		assert.Equal(t, "--missing: not defined", warnings[0].String())
+	})
+
+	t.Run("depth limit is configurable", func(t *testing.T) {
+		css := `:root { --a: var(--b); --b: var(--c); --c: var(--d); --d: 4px; } body { margin: var(--a); }`
+
+		processor := NewAssetProcessor(false)
+		result, err := processor.ProcessCSS([]byte(css), nil)
+		require.NoError(t, err)
+		assert.Contains(t, string(result), "margin: 4px;")
+		assert.Empty(t, processor.CheckVariables([]byte(css), nil))
+
+		processor.SetMaxVariableDepth(2)
+		warnings := processor.CheckVariables([]byte(css), nil)
+		require.NotEmpty(t, warnings)
+		assert.Equal(t, "nested deeper than the limit of 2", warnings[0].Reason)
+
+		processor.SetMaxVariableDepth(0)
+		assert.Empty(t, processor.CheckVariables([]byte(css), nil), "0 restores the default depth")
+	})
+
+	t.Run("resolvable stylesheet logs nothing", func(t *testing.T) {
+		logged.Reset()
+		_, err := NewAssetProcessor(false).ProcessCSS([]byte(`:root { --a: 1px; } body { margin: var(--a); }`), nil)
+		require.NoError(t, err)
+		assert.Empty(t, logged.String())
+	})
+}
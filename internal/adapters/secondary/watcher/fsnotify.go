@@ -0,0 +1,260 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+)
+
+// FSNotifyWatcher implements file watching using OS filesystem notifications
+type FSNotifyWatcher struct {
+	watcher *fsnotify.Watcher
+	events  chan ports.FileChangeEvent
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	stopped bool
+	stopCh  chan struct{}
+
+	pathsMu sync.RWMutex
+	paths   map[string]bool // Files whose changes are reported
+	dirs    map[string]bool // Directories all of whose files are reported
+}
+
+// NewFSNotifyWatcher creates a new notification-based file watcher
+func NewFSNotifyWatcher() (*FSNotifyWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("creating fsnotify watcher: %w", err)
+	}
+
+	return &FSNotifyWatcher{
+		watcher: watcher,
+		events:  make(chan ports.FileChangeEvent, 10),
+		stopCh:  make(chan struct{}),
+		paths:   make(map[string]bool),
+		dirs:    make(map[string]bool),
+	}, nil
+}
+
+// Watch starts watching a file for changes. The parent directory is watched
+// rather than the file itself, since editors often save by writing a new file
+// and renaming it over the old one, which would drop a watch on the file.
+// Calling Watch again adds another file, reported on the same channel until
+// the first call's context is done.
+func (w *FSNotifyWatcher) Watch(ctx context.Context, path string) (<-chan ports.FileChangeEvent, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolving path: %w", err)
+	}
+
+	w.pathsMu.Lock()
+	defer w.pathsMu.Unlock()
+	if w.paths[absPath] {
+		return w.events, nil
+	}
+
+	if err := w.watcher.Add(filepath.Dir(absPath)); err != nil {
+		return nil, fmt.Errorf("watching %s: %w", filepath.Dir(absPath), err)
+	}
+
+	w.startEventLoop(ctx)
+	w.paths[absPath] = true
+
+	return w.events, nil
+}
+
+// WatchDir starts watching every file under dir, including files in
+// subdirectories created while it's watched. Hidden subdirectories, such as
+// .git, are skipped. Changes are reported on the same channel as Watch's.
+func (w *FSNotifyWatcher) WatchDir(ctx context.Context, dir string) (<-chan ports.FileChangeEvent, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving path: %w", err)
+	}
+	info, err := os.Stat(absDir)
+	if err != nil {
+		return nil, fmt.Errorf("watching %s: %w", absDir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("watching %s: not a directory", absDir)
+	}
+
+	w.pathsMu.Lock()
+	defer w.pathsMu.Unlock()
+	if w.dirs[absDir] {
+		return w.events, nil
+	}
+
+	if err := w.addTree(absDir); err != nil {
+		return nil, err
+	}
+	w.startEventLoop(ctx)
+	w.dirs[absDir] = true
+
+	return w.events, nil
+}
+
+// startEventLoop starts forwarding notifications with the first watch.
+// Callers hold pathsMu.
+func (w *FSNotifyWatcher) startEventLoop(ctx context.Context) {
+	if len(w.paths) > 0 || len(w.dirs) > 0 {
+		return
+	}
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.eventLoop(ctx)
+	}()
+}
+
+// addTree watches dir and the directories below it
+func (w *FSNotifyWatcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("watching %s: %w", path, err)
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if err := w.watcher.Add(path); err != nil {
+			return fmt.Errorf("watching %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// watching reports whether changes to path are reported
+func (w *FSNotifyWatcher) watching(path string) bool {
+	w.pathsMu.RLock()
+	defer w.pathsMu.RUnlock()
+	return w.paths[path] || w.inWatchedDir(path)
+}
+
+// inWatchedDir reports whether path is below a directory passed to
+// WatchDir. Callers hold pathsMu.
+func (w *FSNotifyWatcher) inWatchedDir(path string) bool {
+	for dir := range w.dirs {
+		if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// followNewDir watches a directory created inside a watched directory, so
+// the files later written to it are reported too. It reports whether path
+// is a directory, whose creation isn't a change of its own.
+func (w *FSNotifyWatcher) followNewDir(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+	w.pathsMu.Lock()
+	defer w.pathsMu.Unlock()
+	if !w.inWatchedDir(path) || strings.HasPrefix(filepath.Base(path), ".") {
+		return true
+	}
+	if err := w.addTree(path); err != nil {
+		log.Printf("watch error: %v", err)
+	}
+	return true
+}
+
+// Stop stops the file watcher
+func (w *FSNotifyWatcher) Stop() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stopped {
+		return nil
+	}
+
+	w.stopped = true
+	close(w.stopCh)
+	err := w.watcher.Close()
+
+	// Wait for goroutines to finish
+	w.wg.Wait()
+
+	// Close events channel
+	close(w.events)
+
+	return err
+}
+
+// eventLoop forwards notifications about the watched files until stopped
+func (w *FSNotifyWatcher) eventLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("watch error: %v", err)
+		case notification, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			path := filepath.Clean(notification.Name)
+			if !w.watching(path) {
+				continue
+			}
+			if notification.Op.Has(fsnotify.Create) && w.followNewDir(path) {
+				continue
+			}
+
+			changeType, relevant := changeTypeFor(notification.Op)
+			if !relevant {
+				continue
+			}
+
+			event := ports.FileChangeEvent{
+				Path:      path,
+				Type:      changeType,
+				Timestamp: time.Now(),
+			}
+
+			select {
+			case w.events <- event:
+			case <-ctx.Done():
+				return
+			case <-w.stopCh:
+				return
+			}
+		}
+	}
+}
+
+// changeTypeFor maps an fsnotify operation to a change type; permission
+// changes don't affect the content and are ignored
+func changeTypeFor(op fsnotify.Op) (ports.ChangeType, bool) {
+	switch {
+	case op.Has(fsnotify.Create):
+		return ports.Created, true
+	case op.Has(fsnotify.Write):
+		return ports.Modified, true
+	case op.Has(fsnotify.Remove):
+		return ports.Deleted, true
+	case op.Has(fsnotify.Rename):
+		return ports.Renamed, true
+	default:
+		return ports.Modified, false
+	}
+}
@@ -0,0 +1,146 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/ports"
+)
+
+func TestFSNotifyWatcher(t *testing.T) {
+	t.Run("reports writes to the watched file only", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "talk.md")
+		updateFile(t, path, "# Slides")
+
+		watcher, err := NewFSNotifyWatcher()
+		require.NoError(t, err)
+		defer func() { _ = watcher.Stop() }()
+
+		events, err := watcher.Watch(context.Background(), path)
+		require.NoError(t, err)
+
+		updateFile(t, filepath.Join(dir, "other.md"), "# Other")
+		updateFile(t, path, "# Updated")
+
+		select {
+		case event := <-events:
+			assert.Equal(t, path, event.Path)
+			assert.Equal(t, ports.Modified, event.Type)
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected a change event")
+		}
+	})
+
+	t.Run("reports a file replaced by rename", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "talk.md")
+		updateFile(t, path, "# Slides")
+
+		watcher, err := NewFSNotifyWatcher()
+		require.NoError(t, err)
+		defer func() { _ = watcher.Stop() }()
+
+		events, err := watcher.Watch(context.Background(), path)
+		require.NoError(t, err)
+
+		tmp := filepath.Join(dir, ".talk.md.swp")
+		updateFile(t, tmp, "# Saved")
+		require.NoError(t, os.Rename(tmp, path))
+
+		select {
+		case event := <-events:
+			assert.Equal(t, path, event.Path)
+			assert.Equal(t, ports.Created, event.Type)
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected a change event")
+		}
+	})
+
+	t.Run("reports each watched file", func(t *testing.T) {
+		dir := t.TempDir()
+		main := filepath.Join(dir, "talk.md")
+		section := filepath.Join(dir, "sections", "intro.md")
+		require.NoError(t, os.Mkdir(filepath.Dir(section), 0750))
+		updateFile(t, main, "@include sections/intro.md")
+		updateFile(t, section, "# Intro")
+
+		watcher, err := NewFSNotifyWatcher()
+		require.NoError(t, err)
+		defer func() { _ = watcher.Stop() }()
+
+		events, err := watcher.Watch(context.Background(), main)
+		require.NoError(t, err)
+		again, err := watcher.Watch(context.Background(), section)
+		require.NoError(t, err)
+		assert.Equal(t, events, again, "files share one channel")
+
+		for _, path := range []string{section, main} {
+			updateFile(t, path, "# Updated")
+			select {
+			case event := <-events:
+				assert.Equal(t, path, event.Path)
+			case <-time.After(2 * time.Second):
+				t.Fatalf("expected a change event for %s", path)
+			}
+			// A save can be reported more than once
+			for drained := false; !drained; {
+				select {
+				case <-events:
+				case <-time.After(100 * time.Millisecond):
+					drained = true
+				}
+			}
+		}
+	})
+
+	t.Run("reports files below a watched directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0750))
+
+		watcher, err := NewFSNotifyWatcher()
+		require.NoError(t, err)
+		defer func() { _ = watcher.Stop() }()
+
+		events, err := watcher.WatchDir(context.Background(), dir)
+		require.NoError(t, err)
+
+		// Files in hidden directories aren't reported
+		updateFile(t, filepath.Join(dir, ".git", "index"), "ignored")
+
+		// Nor are new directories, but files written to them are
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "images"), 0750))
+		time.Sleep(100 * time.Millisecond) // Let the new directory be watched
+		image := filepath.Join(dir, "images", "logo.svg")
+		updateFile(t, image, "<svg/>")
+		select {
+		case event := <-events:
+			assert.Equal(t, image, event.Path)
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected a change event for the new file")
+		}
+
+		_, err = watcher.WatchDir(context.Background(), image)
+		assert.ErrorContains(t, err, "not a directory")
+	})
+
+	t.Run("stop closes the events channel", func(t *testing.T) {
+		watcher, err := NewFSNotifyWatcher()
+		require.NoError(t, err)
+
+		events, err := watcher.Watch(context.Background(), filepath.Join(t.TempDir(), "talk.md"))
+		require.NoError(t, err)
+
+		require.NoError(t, watcher.Stop())
+		require.NoError(t, watcher.Stop())
+
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+}
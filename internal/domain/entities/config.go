@@ -1,11 +1,15 @@
 package entities
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -52,13 +56,33 @@ func (c *Config) Validate() error {
 
 // ServerConfig contains HTTP server configuration
 type ServerConfig struct {
-	Host            string   `toml:"host"`
-	Port            int      `toml:"port"`
-	ReadTimeout     int      `toml:"read_timeout"`
-	WriteTimeout    int      `toml:"write_timeout"`
-	ShutdownTimeout int      `toml:"shutdown_timeout"`
-	Environment     string   `toml:"environment"`
-	CORSOrigins     []string `toml:"cors_origins"`
+	Host             string    `toml:"host"`
+	Port             int       `toml:"port"`
+	PortRetries      int       `toml:"port_retries"`
+	ReadTimeout      int       `toml:"read_timeout"`
+	WriteTimeout     int       `toml:"write_timeout"`
+	ShutdownTimeout  int       `toml:"shutdown_timeout"`
+	PingInterval     int       `toml:"ping_interval"`
+	IdleTimeout      int       `toml:"idle_timeout"`
+	Environment      string    `toml:"environment"`
+	CORSOrigins      []string  `toml:"cors_origins"`
+	ExportFilenames  string    `toml:"export_filenames"`
+	CSP              string    `toml:"csp"`
+	SlideIDs         string    `toml:"slide_ids"`
+	ExportFonts      []string  `toml:"export_fonts"`
+	SubsetFonts      bool      `toml:"subset_fonts"`
+	ReadOnly         bool      `toml:"read_only"`
+	InteractiveTasks bool      `toml:"interactive_tasks"`
+	PrefetchDepth    int       `toml:"prefetch_depth"`
+	SpeakingWPM      int       `toml:"speaking_wpm"`
+	Offline          bool      `toml:"offline"`
+	Metrics          bool      `toml:"metrics"`
+	TLS              TLSConfig `toml:"tls"`
+
+	// PregenerateExports lists export formats rebuilt in the background
+	// whenever live reload picks up a change
+	PregenerateExports    []string `toml:"pregenerate_exports"`
+	PregenerateDebounceMs int      `toml:"pregenerate_debounce_ms"`
 }
 
 // Validate validates server configuration
@@ -67,7 +91,11 @@ func (s ServerConfig) Validate() error {
 		return errors.New("port must be between 0 and 65535")
 	}
 
-	if s.Host != "" {
+	if socket, isUnix := s.UnixSocket(); isUnix {
+		if socket == "" {
+			return errors.New("unix socket host needs a path, as in unix:/path/to/slicli.sock")
+		}
+	} else if s.Host != "" {
 		if ip := net.ParseIP(s.Host); ip == nil {
 			if _, err := net.LookupHost(s.Host); err != nil {
 				return fmt.Errorf("invalid host: %w", err)
@@ -87,6 +115,14 @@ func (s ServerConfig) Validate() error {
 		return errors.New("shutdown timeout must be non-negative")
 	}
 
+	if s.PingInterval < 0 {
+		return errors.New("ping interval must be non-negative")
+	}
+
+	if s.IdleTimeout < 0 {
+		return errors.New("idle timeout must be non-negative")
+	}
+
 	// Validate CORS origins
 	for _, origin := range s.CORSOrigins {
 		if origin == "" {
@@ -102,6 +138,49 @@ func (s ServerConfig) Validate() error {
 		}
 	}
 
+	if s.PortRetries < 0 || s.PortRetries > MaxPortRetries {
+		return fmt.Errorf("port retries must be between 0 and %d", MaxPortRetries)
+	}
+
+	if s.PrefetchDepth > MaxPrefetchDepth {
+		return fmt.Errorf("prefetch depth must be at most %d", MaxPrefetchDepth)
+	}
+
+	if s.SpeakingWPM < 0 {
+		return errors.New("speaking_wpm must be non-negative")
+	}
+
+	for _, format := range s.PregenerateExports {
+		if strings.TrimSpace(format) == "" {
+			return errors.New("pregenerate export format cannot be empty")
+		}
+	}
+	if s.PregenerateDebounceMs < 0 {
+		return errors.New("pregenerate debounce must be non-negative")
+	}
+
+	switch s.ExportFilenames {
+	case "", "ascii", "unicode":
+	default:
+		return fmt.Errorf("invalid export_filenames %q (must be ascii or unicode)", s.ExportFilenames)
+	}
+
+	switch s.SlideIDs {
+	case "", SlideIDsIndex, SlideIDsHeading:
+	default:
+		return fmt.Errorf("invalid slide_ids %q (must be %s or %s)", s.SlideIDs, SlideIDsIndex, SlideIDsHeading)
+	}
+
+	switch s.CSP {
+	case "", CSPDefault, CSPStrict, CSPOff:
+	default:
+		return fmt.Errorf("invalid csp %q (must be %s, %s or %s)", s.CSP, CSPDefault, CSPStrict, CSPOff)
+	}
+
+	if err := s.TLS.Validate(); err != nil {
+		return fmt.Errorf("tls: %w", err)
+	}
+
 	return nil
 }
 
@@ -129,6 +208,100 @@ func (s ServerConfig) GetShutdownTimeout() time.Duration {
 	return time.Duration(s.ShutdownTimeout) * time.Second
 }
 
+// GetPingInterval returns how often WebSocket clients are pinged to keep
+// connections alive through proxies
+func (s ServerConfig) GetPingInterval() time.Duration {
+	if s.PingInterval <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(s.PingInterval) * time.Second
+}
+
+// GetIdleTimeout returns how long the server may go without requests or
+// connected browsers before it shuts itself down, or 0 to keep it running
+func (s ServerConfig) GetIdleTimeout() time.Duration {
+	if s.IdleTimeout <= 0 {
+		return 0
+	}
+	return time.Duration(s.IdleTimeout) * time.Second
+}
+
+// MaxPortRetries caps how many following ports are tried when the
+// configured one is taken
+const MaxPortRetries = 100
+
+// MaxPrefetchDepth caps how many upcoming slides are preloaded
+const MaxPrefetchDepth = 10
+
+// GetPrefetchDepth returns how many upcoming slides to preload: one when
+// unset, none when negative
+func (s ServerConfig) GetPrefetchDepth() int {
+	switch {
+	case s.PrefetchDepth == 0:
+		return 1
+	case s.PrefetchDepth < 0:
+		return 0
+	}
+	return s.PrefetchDepth
+}
+
+// DefaultSpeakingWPM is the speaking pace used for notes timing when
+// speaking_wpm is unset
+const DefaultSpeakingWPM = 130
+
+// GetSpeakingWPM returns the words per minute speaking time estimates
+// assume
+func (s ServerConfig) GetSpeakingWPM() int {
+	if s.SpeakingWPM <= 0 {
+		return DefaultSpeakingWPM
+	}
+	return s.SpeakingWPM
+}
+
+// How slide elements are given their IDs
+const (
+	// SlideIDsIndex numbers slides by position: slide-1, slide-2...
+	SlideIDsIndex = "index"
+	// SlideIDsHeading names slides after their first heading, so links to
+	// them survive slides being added or moved
+	SlideIDsHeading = "heading"
+)
+
+// Content-Security-Policy modes for served presentations
+const (
+	// CSPDefault allows the known CDNs and inline scripts
+	CSPDefault = "default"
+	// CSPStrict serves inline scripts as files and refuses inline code
+	CSPStrict = "strict"
+	// CSPOff sends no policy
+	CSPOff = "off"
+)
+
+// GetCSP returns the Content-Security-Policy mode, default when unset
+func (s ServerConfig) GetCSP() string {
+	if s.CSP == "" {
+		return CSPDefault
+	}
+	return s.CSP
+}
+
+// GetSlideIDs returns how slide elements are given IDs (by index when unset)
+func (s ServerConfig) GetSlideIDs() string {
+	if s.SlideIDs == "" {
+		return SlideIDsIndex
+	}
+	return s.SlideIDs
+}
+
+// GetPregenerateDebounce returns how long edits must settle before exports
+// are regenerated (2s when unset)
+func (s ServerConfig) GetPregenerateDebounce() time.Duration {
+	if s.PregenerateDebounceMs <= 0 {
+		return 2 * time.Second
+	}
+	return time.Duration(s.PregenerateDebounceMs) * time.Millisecond
+}
+
 // GetCORSOrigins returns CORS origins with defaults if empty
 func (s ServerConfig) GetCORSOrigins() []string {
 	if len(s.CORSOrigins) == 0 {
@@ -143,17 +316,108 @@ func (s ServerConfig) GetCORSOrigins() []string {
 	return s.CORSOrigins
 }
 
+// Scheme returns the URL scheme the server is reachable on
+func (s ServerConfig) Scheme() string {
+	if s.TLS.Enabled {
+		return "https"
+	}
+	return "http"
+}
+
+// unixSocketPrefix marks a host that is a Unix domain socket path
+const unixSocketPrefix = "unix:"
+
+// UnixSocket returns the socket path of a unix:/path/to/slicli.sock host,
+// reporting whether the server listens on a Unix domain socket
+func (s ServerConfig) UnixSocket() (string, bool) {
+	if !strings.HasPrefix(s.Host, unixSocketPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(s.Host, unixSocketPrefix), true
+}
+
+// URL returns the base URL of the server. A server on a Unix socket has no
+// URL a browser can open, so its host is returned as is.
+func (s ServerConfig) URL() string {
+	if _, isUnix := s.UnixSocket(); isUnix {
+		return s.Host
+	}
+	return fmt.Sprintf("%s://%s", s.Scheme(), net.JoinHostPort(s.Host, strconv.Itoa(s.Port)))
+}
+
 // IsDevelopment returns true if the server is running in development mode
 func (s ServerConfig) IsDevelopment() bool {
 	return s.Environment == "development" || s.Environment == ""
 }
 
+// TLSConfig contains HTTPS configuration
+type TLSConfig struct {
+	Enabled  bool   `toml:"enabled"`   // Serve over HTTPS
+	CertFile string `toml:"cert_file"` // PEM certificate (empty = generate self-signed)
+	KeyFile  string `toml:"key_file"`  // PEM private key
+	CADir    string `toml:"ca_dir"`    // Where the generated local CA is kept
+}
+
+// Validate validates TLS configuration
+func (t TLSConfig) Validate() error {
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		return errors.New("cert_file and key_file must be set together")
+	}
+
+	for _, path := range []string{t.CertFile, t.KeyFile} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("cannot access %s: %w", path, err)
+		}
+	}
+
+	if t.CADir != "" && !filepath.IsAbs(t.CADir) {
+		return errors.New("CA directory must be absolute")
+	}
+
+	return nil
+}
+
+// IsSelfSigned returns true if a certificate should be generated locally
+func (t TLSConfig) IsSelfSigned() bool {
+	return t.Enabled && t.CertFile == ""
+}
+
+// GetCADir returns the directory for the generated CA with default
+func (t TLSConfig) GetCADir() string {
+	if t.CADir != "" {
+		return t.CADir
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".config", "slicli", "certs")
+}
+
 // ThemeConfig contains theme configuration
 type ThemeConfig struct {
 	Name       string `toml:"name"`
 	CustomPath string `toml:"custom_path"`
+
+	// Transition animates slide changes: fade, slide or none. Empty keeps
+	// the theme's own animation.
+	Transition string `toml:"transition"`
+
+	// Variables overrides theme CSS custom properties by name, without the
+	// leading dashes. A deck's local config overrides the global one per key.
+	Variables map[string]string `toml:"variables"`
+
+	// MaxVariableDepth is how many levels of var() nesting are resolved in
+	// theme stylesheets. Zero keeps the default.
+	MaxVariableDepth int `toml:"max_variable_depth"`
 }
 
+// themeVariableName matches a CSS custom property name without its leading dashes
+var themeVariableName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9-]*$`)
+
 // Validate validates theme configuration
 func (t ThemeConfig) Validate() error {
 	if t.Name == "" {
@@ -170,6 +434,23 @@ func (t ThemeConfig) Validate() error {
 		}
 	}
 
+	if t.Transition != "" && !IsSlideTransition(t.Transition) {
+		return fmt.Errorf("invalid transition %q (must be one of: %s)", t.Transition, strings.Join(SlideTransitions, ", "))
+	}
+
+	if t.MaxVariableDepth < 0 {
+		return errors.New("theme max_variable_depth cannot be negative")
+	}
+
+	for name, value := range t.Variables {
+		if !themeVariableName.MatchString(name) {
+			return fmt.Errorf("invalid theme variable name: %q", name)
+		}
+		if strings.ContainsAny(value, ";{}<>") {
+			return fmt.Errorf("invalid value for theme variable %s: %q", name, value)
+		}
+	}
+
 	return nil
 }
 
@@ -191,6 +472,10 @@ type WatcherConfig struct {
 	DebounceMs   int `toml:"debounce_ms"`
 	MaxRetries   int `toml:"max_retries"`
 	RetryDelayMs int `toml:"retry_delay_ms"`
+
+	// Dirs are extra directories, such as themes and assets, whose changes
+	// reload the presentation while it's watched
+	Dirs []string `toml:"dirs"`
 }
 
 // Validate validates watcher configuration
@@ -245,6 +530,15 @@ type PluginsConfig struct {
 	Whitelist      []string `toml:"whitelist"`
 	Blacklist      []string `toml:"blacklist"`
 	MarketplaceURL string   `toml:"marketplace_url"`
+	CacheDir       string   `toml:"cache_dir"`         // On-disk cache for rendered plugin output (optional)
+	CacheMaxSizeMB int      `toml:"cache_max_size_mb"` // Size cap for the on-disk cache
+
+	// MarketplaceAPIKey authenticates marketplace requests (optional)
+	MarketplaceAPIKey string `toml:"marketplace_api_key"`
+
+	// ThemePublicKey is the base64 Ed25519 key marketplace theme signatures
+	// are checked against. When set, unsigned themes are refused.
+	ThemePublicKey string `toml:"theme_public_key"`
 }
 
 // Validate validates plugins configuration
@@ -255,6 +549,14 @@ func (p PluginsConfig) Validate() error {
 		}
 	}
 
+	if p.CacheDir != "" && !filepath.IsAbs(p.CacheDir) {
+		return errors.New("plugin cache directory must be absolute path")
+	}
+
+	if p.CacheMaxSizeMB < 0 {
+		return errors.New("plugin cache size must be non-negative")
+	}
+
 	// Validate marketplace URL if provided
 	if p.MarketplaceURL != "" {
 		if len(p.MarketplaceURL) < 7 ||
@@ -264,6 +566,10 @@ func (p PluginsConfig) Validate() error {
 		}
 	}
 
+	if _, err := p.GetThemePublicKey(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -283,6 +589,35 @@ func (p PluginsConfig) GetMarketplaceURL() string {
 	return "https://marketplace.slicli.dev"
 }
 
+// GetMarketplaceAPIKey returns the marketplace API key, preferring SLICLI_API_KEY
+func (p PluginsConfig) GetMarketplaceAPIKey() string {
+	if envKey := os.Getenv("SLICLI_API_KEY"); envKey != "" {
+		return envKey
+	}
+	return p.MarketplaceAPIKey
+}
+
+// GetThemePublicKey decodes the theme signing key, returning nil when none
+// is configured
+func (p PluginsConfig) GetThemePublicKey() (ed25519.PublicKey, error) {
+	if p.ThemePublicKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(p.ThemePublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("theme public key must be a base64 Ed25519 public key")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// GetCacheMaxSize returns the on-disk cache size cap in bytes with default (100MB)
+func (p PluginsConfig) GetCacheMaxSize() int64 {
+	if p.CacheMaxSizeMB <= 0 {
+		return 100 * 1024 * 1024
+	}
+	return int64(p.CacheMaxSizeMB) * 1024 * 1024
+}
+
 // Metadata contains presentation metadata defaults
 type Metadata struct {
 	Author      string            `toml:"author"`
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"os"
 	"testing"
 	"time"
@@ -126,6 +128,21 @@ func TestServerConfig_Validate(t *testing.T) {
 		}
 	})
 
+	t.Run("unix socket host", func(t *testing.T) {
+		config := ServerConfig{Host: "unix:/run/slicli/slicli.sock", Port: 3000}
+		assert.NoError(t, config.Validate())
+
+		socket, isUnix := config.UnixSocket()
+		assert.True(t, isUnix)
+		assert.Equal(t, "/run/slicli/slicli.sock", socket)
+
+		config.Host = "unix:"
+		assert.ErrorContains(t, config.Validate(), "needs a path")
+
+		_, isUnix = ServerConfig{Host: "localhost"}.UnixSocket()
+		assert.False(t, isUnix)
+	})
+
 	t.Run("negative timeouts", func(t *testing.T) {
 		tests := []struct {
 			name   string
@@ -201,6 +218,17 @@ func TestServerConfig_GetTimeouts(t *testing.T) {
 	})
 }
 
+func TestServerConfig_PrefetchDepth(t *testing.T) {
+	assert.Equal(t, 1, ServerConfig{}.GetPrefetchDepth(), "unset preloads the next slide")
+	assert.Equal(t, 3, ServerConfig{PrefetchDepth: 3}.GetPrefetchDepth())
+	assert.Equal(t, 0, ServerConfig{PrefetchDepth: -1}.GetPrefetchDepth(), "negative disables prefetching")
+
+	assert.NoError(t, ServerConfig{PrefetchDepth: MaxPrefetchDepth}.Validate())
+	err := ServerConfig{PrefetchDepth: MaxPrefetchDepth + 1}.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "prefetch depth")
+}
+
 func TestThemeConfig_Validate(t *testing.T) {
 	t.Run("valid theme config", func(t *testing.T) {
 		config := ThemeConfig{
@@ -211,6 +239,19 @@ func TestThemeConfig_Validate(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("transition", func(t *testing.T) {
+		assert.NoError(t, ThemeConfig{Name: "default", Transition: "slide"}.Validate())
+
+		err := ThemeConfig{Name: "default", Transition: "spin"}.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid transition "spin"`)
+	})
+
+	t.Run("max variable depth", func(t *testing.T) {
+		assert.NoError(t, ThemeConfig{Name: "default", MaxVariableDepth: 3}.Validate())
+		assert.ErrorContains(t, ThemeConfig{Name: "default", MaxVariableDepth: -1}.Validate(), "max_variable_depth cannot be negative")
+	})
+
 	t.Run("empty theme name", func(t *testing.T) {
 		config := ThemeConfig{
 			Name: "",
@@ -242,6 +283,20 @@ func TestThemeConfig_Validate(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "custom theme path does not exist")
 	})
+
+	t.Run("theme variables", func(t *testing.T) {
+		config := ThemeConfig{
+			Name:      "default",
+			Variables: map[string]string{"primary-color": "#0b5fff", "font-family-body": "'Inter', sans-serif"},
+		}
+		assert.NoError(t, config.Validate())
+
+		config.Variables = map[string]string{"--primary-color": "#0b5fff"}
+		assert.ErrorContains(t, config.Validate(), "invalid theme variable name")
+
+		config.Variables = map[string]string{"primary-color": "red; } body { display: none"}
+		assert.ErrorContains(t, config.Validate(), "invalid value for theme variable primary-color")
+	})
 }
 
 func TestBrowserConfig_Validate(t *testing.T) {
@@ -447,6 +502,16 @@ func TestPluginsConfig_Validate(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "marketplace URL must start with http:// or https://")
 	})
+
+	t.Run("theme public key", func(t *testing.T) {
+		config := PluginsConfig{ThemePublicKey: base64.StdEncoding.EncodeToString(make([]byte, ed25519.PublicKeySize))}
+		assert.NoError(t, config.Validate())
+
+		config.ThemePublicKey = base64.StdEncoding.EncodeToString([]byte("too short"))
+		err := config.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "theme public key must be a base64 Ed25519 public key")
+	})
 }
 
 func TestPluginsConfig_GetMarketplaceURL(t *testing.T) {
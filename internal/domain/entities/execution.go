@@ -23,16 +23,34 @@ type ExecutionConfig struct {
 	// AllowNetwork determines if network access is allowed (default: false)
 	AllowNetwork bool `json:"allow_network"`
 
+	// Network is NetworkNone to run the code without network access or
+	// NetworkAllow to permit it. Unset, the code shares the host's network.
+	Network string `json:"network"`
+
 	// AllowFileWrite determines if file writing is allowed (default: false)
 	AllowFileWrite bool `json:"allow_file_write"`
 
 	// Environment contains environment variables
 	Environment []string `json:"environment"`
 
+	// EnvAllowlist names the host environment variables passed through to the
+	// executed code (default: PATH, HOME, LANG)
+	EnvAllowlist []string `json:"env_allowlist"`
+
+	// EnvDenylist names environment variables never passed to the executed
+	// code, even when allowlisted or set in Environment
+	EnvDenylist []string `json:"env_denylist"`
+
 	// TrustedMode allows dangerous operations (default: false)
 	TrustedMode bool `json:"trusted_mode"`
 }
 
+// Network settings of an ExecutionConfig
+const (
+	NetworkNone  = "none"
+	NetworkAllow = "allow"
+)
+
 // ExecutionResult represents the result of code execution
 type ExecutionResult struct {
 	// Output is the standard output from execution
@@ -58,6 +76,12 @@ type ExecutionResult struct {
 
 	// Truncated indicates if output was truncated due to size limits
 	Truncated bool `json:"truncated"`
+
+	// DroppedBytes is how much standard output was cut by the size limit
+	DroppedBytes int `json:"dropped_bytes,omitempty"`
+
+	// DroppedErrorBytes is how much standard error was cut by the size limit
+	DroppedErrorBytes int `json:"dropped_error_bytes,omitempty"`
 }
 
 // Executor interface defines how to execute code for a specific language
@@ -124,7 +148,17 @@ func (c ExecutionConfig) Validate() error {
 		return ErrInvalidMemoryLimit
 	}
 
-	return nil
+	return c.ValidateNetwork()
+}
+
+// ValidateNetwork checks that Network is unset, NetworkNone or NetworkAllow
+func (c ExecutionConfig) ValidateNetwork() error {
+	switch c.Network {
+	case "", NetworkNone, NetworkAllow:
+		return nil
+	default:
+		return ErrInvalidNetwork
+	}
 }
 
 // ExecutionError represents errors that occur during code execution
@@ -144,6 +178,7 @@ var (
 	ErrInvalidTimeout     = ExecutionError{Type: "invalid_timeout", Message: "timeout must be positive"}
 	ErrInvalidOutputSize  = ExecutionError{Type: "invalid_output_size", Message: "output size limit must be positive"}
 	ErrInvalidMemoryLimit = ExecutionError{Type: "invalid_memory_limit", Message: "memory limit must be positive"}
+	ErrInvalidNetwork     = ExecutionError{Type: "invalid_network", Message: `network must be "none" or "allow"`}
 	ErrExecutionTimeout   = ExecutionError{Type: "execution_timeout", Message: "code execution timed out"}
 	ErrOutputTooLarge     = ExecutionError{Type: "output_too_large", Message: "output exceeded size limit"}
 	ErrMemoryExceeded     = ExecutionError{Type: "memory_exceeded", Message: "execution exceeded memory limit"}
@@ -18,6 +18,7 @@ const (
 	PluginStatusActive   PluginStatus = "active"
 	PluginStatusError    PluginStatus = "error"
 	PluginStatusDisabled PluginStatus = "disabled"
+	PluginStatusDegraded PluginStatus = "degraded" // Still runs, but times out too often
 )
 
 // PluginType represents the type of plugin.
@@ -30,6 +31,16 @@ const (
 	PluginTypeAnalyzer  PluginType = "analyzer"  // Content analyzer (e.g., readability, statistics)
 )
 
+// PluginPriority is the execution tier a plugin runs in when several
+// plugins process the same content. Higher tiers run first.
+type PluginPriority string
+
+const (
+	PluginPriorityHigh   PluginPriority = "high"
+	PluginPriorityMedium PluginPriority = "medium"
+	PluginPriorityLow    PluginPriority = "low"
+)
+
 // PluginMetadata contains metadata about a plugin.
 type PluginMetadata struct {
 	Name        string            `json:"name"`
@@ -41,6 +52,10 @@ type PluginMetadata struct {
 	Type        PluginType        `json:"type"`
 	Tags        []string          `json:"tags"`
 	Config      map[string]string `json:"config"` // Default configuration
+
+	// Priority overrides the execution tier slicli would otherwise pick for
+	// the plugin by name
+	Priority PluginPriority `json:"priority,omitempty"`
 }
 
 // LoadedPlugin represents a plugin that has been loaded into memory.
@@ -70,6 +85,24 @@ type PluginStatistics struct {
 	BytesGenerated  int64
 }
 
+// PluginHealth summarizes how reliably a plugin has been executing.
+type PluginHealth struct {
+	Name        string       `json:"name"`
+	Status      PluginStatus `json:"status"`
+	Healthy     bool         `json:"healthy"`
+	Executions  int64        `json:"executions"`
+	Errors      int64        `json:"errors"`
+	Timeouts    int64        `json:"timeouts"`
+	Panics      int64        `json:"panics"`
+	TimeoutRate float64      `json:"timeout_rate"`
+	TimeoutMs   int64        `json:"timeout_ms"`
+	Message     string       `json:"message,omitempty"`
+
+	// Details is what the plugin reports about itself, when it implements
+	// the plugin.HealthChecker interface
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
 // PluginConfig represents runtime configuration for a plugin.
 type PluginConfig struct {
 	Enabled         bool                   `toml:"enabled"`
@@ -149,12 +182,27 @@ func (m *PluginMetadata) Validate() error {
 		return errors.New("invalid plugin type")
 	}
 
+	switch m.Priority {
+	case "", PluginPriorityHigh, PluginPriorityMedium, PluginPriorityLow:
+		// Valid priority
+	default:
+		return errors.New("plugin priority must be high, medium, or low")
+	}
+
 	return nil
 }
 
 // IsActive returns true if the plugin is in an active state.
 func (p *LoadedPlugin) IsActive() bool {
-	return p.Status == PluginStatusActive || p.Status == PluginStatusLoaded
+	return p.Status == PluginStatusActive || p.Status == PluginStatusLoaded || p.Status == PluginStatusDegraded
+}
+
+// TimeoutRate returns the fraction of executions that timed out.
+func (s PluginStatistics) TimeoutRate() float64 {
+	if s.ExecutionCount == 0 {
+		return 0
+	}
+	return float64(s.TimeoutCount) / float64(s.ExecutionCount)
 }
 
 // UpdateStatistics updates the plugin statistics after an execution.
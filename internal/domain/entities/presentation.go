@@ -23,6 +23,9 @@ type Presentation struct {
 	// Date is when the presentation was created/updated
 	Date time.Time `yaml:"date" json:"date"`
 
+	// Tags categorize the presentation
+	Tags []string `yaml:"tags" json:"tags,omitempty"`
+
 	// Metadata contains any additional frontmatter fields
 	Metadata map[string]interface{} `yaml:",inline" json:"metadata,omitempty"`
 
@@ -67,3 +70,27 @@ func (p *Presentation) GetSlideByIndex(index int) (*Slide, error) {
 func (p *Presentation) SlideCount() int {
 	return len(p.Slides)
 }
+
+// DraftCount returns the number of slides marked as drafts
+func (p *Presentation) DraftCount() int {
+	count := 0
+	for i := range p.Slides {
+		if p.Slides[i].Draft {
+			count++
+		}
+	}
+	return count
+}
+
+// RemoveDrafts drops draft slides and renumbers the remaining ones
+func (p *Presentation) RemoveDrafts() {
+	slides := p.Slides[:0]
+	for _, slide := range p.Slides {
+		if slide.Draft {
+			continue
+		}
+		slide.Index = len(slides)
+		slides = append(slides, slide)
+	}
+	p.Slides = slides
+}
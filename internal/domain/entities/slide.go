@@ -2,6 +2,7 @@ package entities
 
 import (
 	"errors"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -28,6 +29,266 @@ type Slide struct {
 
 	// Metadata contains slide-specific frontmatter (if any)
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// Draft marks slides excluded from rendering and export unless drafts are included
+	Draft bool `json:"draft,omitempty"`
+}
+
+// draftDirective matches a <!-- draft --> comment on its own line
+var draftDirective = regexp.MustCompile(`(?im)^\s*<!--\s*draft\s*-->\s*$`)
+
+// layoutDirective matches a slide type declaration such as <!-- layout: section -->
+var layoutDirective = regexp.MustCompile(`(?im)^\s*<!--\s*layout:\s*([a-z0-9-]+)\s*-->\s*$`)
+
+// layoutName matches a slide type
+var layoutName = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// transitionDirective matches a slide transition override such as <!-- transition: fade -->
+var transitionDirective = regexp.MustCompile(`(?im)^\s*<!--\s*transition:\s*([a-z-]+)\s*-->\s*$`)
+
+// slideDirective matches a leading slide attribute comment such as
+// <!-- slide: bg=#000 layout=two-column class=dark -->
+var slideDirective = regexp.MustCompile(`(?i)^\s*<!--\s*slide:(.*?)-->[ \t]*(?:\n|$)`)
+
+// slideAttribute matches one key=value pair in a slide directive, with the
+// value optionally in double quotes
+var slideAttribute = regexp.MustCompile(`(?i)(?:^|\s)([a-z][a-z0-9-]*)=("[^"]*"|[^\s"]+)`)
+
+// slideClassName matches a class the slide directive may add
+var slideClassName = regexp.MustCompile(`^-?[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// slideBackground matches the backgrounds the slide directive may set: colors,
+// gradients and image paths or URLs, without anything that could end the
+// style declaration
+var slideBackground = regexp.MustCompile(`^[a-zA-Z0-9#%(),./:_ -]+$`)
+
+// SlideAttributes are a slide's settings from a leading
+// <!-- slide: key=value ... --> comment
+type SlideAttributes struct {
+	// Layout is the slide type, taking precedence over <!-- layout: -->
+	// and the type guessed from the content
+	Layout string
+	// Classes are added to the slide's classes
+	Classes []string
+	// Background is a color, gradient or image for the slide's background
+	Background string
+	// Transition overrides the deck's transition for the slide
+	Transition string
+	// Data holds the other pairs, which become data- attributes
+	Data map[string]string
+}
+
+// ParseSlideAttributes reads a slide's leading <!-- slide: --> comment,
+// returning its attributes and the content without it. Pairs that aren't
+// key=value, or whose value isn't valid for the key, are ignored.
+func ParseSlideAttributes(content string) (SlideAttributes, string) {
+	var attrs SlideAttributes
+	loc := slideDirective.FindStringSubmatchIndex(content)
+	if loc == nil {
+		return attrs, content
+	}
+
+	for _, pair := range slideAttribute.FindAllStringSubmatch(content[loc[2]:loc[3]], -1) {
+		key, value := strings.ToLower(pair[1]), strings.Trim(pair[2], `"`)
+		switch key {
+		case "layout":
+			if layout := strings.ToLower(value); layoutName.MatchString(layout) {
+				attrs.Layout = layout
+			}
+		case "class":
+			for _, class := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+				if slideClassName.MatchString(class) {
+					attrs.Classes = append(attrs.Classes, class)
+				}
+			}
+		case "bg", "background":
+			if slideBackground.MatchString(value) {
+				attrs.Background = value
+			}
+		case "transition":
+			if name := strings.ToLower(value); IsSlideTransition(name) {
+				attrs.Transition = name
+			}
+		default:
+			if attrs.Data == nil {
+				attrs.Data = make(map[string]string)
+			}
+			attrs.Data[key] = value
+		}
+	}
+
+	return attrs, content[:loc[0]] + content[loc[1]:]
+}
+
+// SlideTransitions lists the transitions a deck or slide can use
+var SlideTransitions = []string{"fade", "slide", "none"}
+
+// IsSlideTransition reports whether name is one of SlideTransitions
+func IsSlideTransition(name string) bool {
+	for _, transition := range SlideTransitions {
+		if name == transition {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDraftContent reports whether slide markdown contains the draft directive
+func IsDraftContent(content string) bool {
+	return draftDirective.MatchString(content)
+}
+
+// DeclaredSlideType returns the type set with a <!-- layout: type --> comment,
+// or the layout of a <!-- slide: --> comment
+func DeclaredSlideType(content string) string {
+	if attrs, _ := ParseSlideAttributes(content); attrs.Layout != "" {
+		return attrs.Layout
+	}
+	if match := layoutDirective.FindStringSubmatch(content); match != nil {
+		return strings.ToLower(match[1])
+	}
+	return ""
+}
+
+// DeclaredTransition returns the transition set with a
+// <!-- transition: name --> comment or a <!-- slide: --> comment, or "" when
+// it's missing or unknown
+func DeclaredTransition(content string) string {
+	if attrs, _ := ParseSlideAttributes(content); attrs.Transition != "" {
+		return attrs.Transition
+	}
+	if match := transitionDirective.FindStringSubmatch(content); match != nil {
+		if name := strings.ToLower(match[1]); IsSlideTransition(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// notesComment matches a speaker notes comment such as <!-- notes: Say hi -->
+var notesComment = regexp.MustCompile(`(?is)<!--\s*notes:(.*?)-->`)
+
+// NotesDelimiter starts a remark.js style notes section running to the end
+// of the slide
+const NotesDelimiter = "???"
+
+// ExtractSpeakerNotes separates speaker notes from slide markdown. Notes are
+// written as <!-- notes: ... --> comments or follow a ??? line, and are
+// removed from the returned body; both are left alone inside code fences.
+func ExtractSpeakerNotes(content string) (body string, notes string) {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	var parts []string
+	var bodyLines, prose, noteLines []string
+	flushProse := func() {
+		text := notesComment.ReplaceAllStringFunc(strings.Join(prose, "\n"), func(comment string) string {
+			if note := strings.TrimSpace(notesComment.FindStringSubmatch(comment)[1]); note != "" {
+				parts = append(parts, note)
+			}
+			return ""
+		})
+		if len(prose) > 0 {
+			bodyLines = append(bodyLines, text)
+		}
+		prose = prose[:0]
+	}
+
+	fence := ""
+	for i, line := range lines {
+		if fence != "" {
+			if closesFence(line, fence) {
+				fence = ""
+			}
+			bodyLines = append(bodyLines, line)
+			continue
+		}
+		if marker := codeFence(line); marker != "" {
+			flushProse()
+			fence = marker
+			bodyLines = append(bodyLines, line)
+			continue
+		}
+		if strings.TrimSpace(line) == NotesDelimiter {
+			noteLines = lines[i+1:]
+			break
+		}
+		prose = append(prose, line)
+	}
+	flushProse()
+
+	if delimited := strings.TrimSpace(strings.Join(noteLines, "\n")); delimited != "" {
+		parts = append(parts, delimited)
+	}
+
+	return strings.TrimSpace(strings.Join(bodyLines, "\n")), strings.Join(parts, "\n\n")
+}
+
+// SlideSeparator is the line that separates slides in a deck
+const SlideSeparator = "---"
+
+// SplitSlides splits deck markdown into trimmed slide contents. A separator
+// must be exactly --- at the start of a line (trailing spaces allowed), so
+// longer rules and indented dashes stay in the slide, and separators inside
+// fenced code blocks are ignored. Empty slides, such as those left by a
+// trailing separator, are dropped.
+func SplitSlides(markdown string) []string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	var slides []string
+	var current []string
+	flush := func() {
+		if content := strings.TrimSpace(strings.Join(current, "\n")); content != "" {
+			slides = append(slides, content)
+		}
+		current = current[:0]
+	}
+
+	fence := ""
+	for _, line := range lines {
+		if marker := codeFence(line); marker != "" {
+			switch {
+			case fence == "":
+				fence = marker
+			case closesFence(line, fence):
+				fence = ""
+			}
+		} else if fence == "" && strings.TrimRight(line, " \t") == SlideSeparator {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+
+	return slides
+}
+
+// codeFence returns the ``` or ~~~ run opening line, or "" if the line
+// isn't a code fence
+func codeFence(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || len(trimmed) < 3 {
+		return ""
+	}
+	char := trimmed[0]
+	if char != '`' && char != '~' {
+		return ""
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == char {
+		n++
+	}
+	if n < 3 {
+		return ""
+	}
+	return trimmed[:n]
+}
+
+// closesFence reports whether line closes the code fence opened with fence
+func closesFence(line, fence string) bool {
+	marker := codeFence(line)
+	return marker != "" && marker[0] == fence[0] && len(marker) >= len(fence) &&
+		strings.TrimSpace(strings.TrimLeft(line, " ")[len(marker):]) == ""
 }
 
 // Validate ensures the slide has valid content
@@ -287,3 +287,169 @@ Note: Speaker note here`,
 	assert.Equal(t, "fade", s.Metadata["transition"])
 	assert.Equal(t, 5, s.Metadata["duration"])
 }
+
+func TestDeclaredSlideType(t *testing.T) {
+	assert.Equal(t, "section", DeclaredSlideType("<!-- layout: Section -->\n# Part 2"))
+	assert.Equal(t, "", DeclaredSlideType("# Part 2\n\nSee <!-- layout: section --> inline"))
+}
+
+func TestDeclaredTransition(t *testing.T) {
+	assert.Equal(t, "fade", DeclaredTransition("# Intro\n<!-- transition: Fade -->"))
+	assert.Equal(t, "", DeclaredTransition("<!-- transition: spin -->\n# Intro"))
+	assert.Equal(t, "", DeclaredTransition("# Intro"))
+}
+
+func TestParseSlideAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    SlideAttributes
+		body    string
+	}{
+		{
+			name:    "all attributes",
+			content: "<!-- slide: bg=#000 layout=Two-Column class=dark,wide transition=fade notes=\"see appendix\" -->\n# Results",
+			want: SlideAttributes{
+				Layout:     "two-column",
+				Classes:    []string{"dark", "wide"},
+				Background: "#000",
+				Transition: "fade",
+				Data:       map[string]string{"notes": "see appendix"},
+			},
+			body: "# Results",
+		},
+		{
+			name:    "invalid values are skipped",
+			content: "<!-- slide: bg=\"red; color: blue\" layout=two_col class=\"1st ok\" transition=spin -->\n# Title",
+			want:    SlideAttributes{Classes: []string{"ok"}},
+			body:    "# Title",
+		},
+		{
+			name:    "no pairs",
+			content: "<!-- slide: dark -->\n# Title",
+			body:    "# Title",
+		},
+		{
+			name:    "not leading",
+			content: "# Title\n<!-- slide: class=dark -->",
+			body:    "# Title\n<!-- slide: class=dark -->",
+		},
+		{
+			name:    "no directive",
+			content: "# Title",
+			body:    "# Title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs, body := ParseSlideAttributes(tt.content)
+			assert.Equal(t, tt.want, attrs)
+			assert.Equal(t, tt.body, body)
+		})
+	}
+
+	assert.Equal(t, "two-column", DeclaredSlideType("<!-- slide: layout=two-column -->\n<!-- layout: section -->"))
+	assert.Equal(t, "slide", DeclaredTransition("<!-- slide: transition=slide -->\n# Intro"))
+}
+
+func TestIsDraftContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"directive on its own line", "<!-- draft -->\n# WIP", true},
+		{"compact and uppercase", "# WIP\n  <!--DRAFT-->  ", true},
+		{"no directive", "# Final", false},
+		{"mentioned inline", "Use `<!-- draft -->` to hide a slide", false},
+		{"other comment", "<!-- drafted by alice -->", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsDraftContent(tt.content))
+		})
+	}
+}
+
+func TestExtractSpeakerNotes(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantBody  string
+		wantNotes string
+	}{
+		{"no notes", "# Intro\n\nHello", "# Intro\n\nHello", ""},
+		{"trailing comment", "# Intro\n\n<!-- notes: Welcome everyone -->", "# Intro", "Welcome everyone"},
+		{
+			"multi-line comment",
+			"# Intro\n<!--\nNOTES:\nMention the demo\nKeep it short\n-->",
+			"# Intro",
+			"Mention the demo\nKeep it short",
+		},
+		{"delimiter", "# Intro\n\nHello\n\n???\n\nAsk for questions\n- slowly", "# Intro\n\nHello", "Ask for questions\n- slowly"},
+		{
+			"comment and delimiter",
+			"# Intro\n<!-- notes: first -->\n???\nsecond",
+			"# Intro",
+			"first\n\nsecond",
+		},
+		{"other comments stay", "# Intro\n<!-- layout: section -->", "# Intro\n<!-- layout: section -->", ""},
+		{
+			"ignored inside code fences",
+			"```md\n<!-- notes: example -->\n???\n```\nText",
+			"```md\n<!-- notes: example -->\n???\n```\nText",
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, notes := ExtractSpeakerNotes(tt.content)
+			assert.Equal(t, tt.wantBody, body)
+			assert.Equal(t, tt.wantNotes, notes)
+		})
+	}
+}
+
+func TestSplitSlides(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     []string
+	}{
+		{"separators", "# One\n---\n# Two\n---\n# Three", []string{"# One", "# Two", "# Three"}},
+		{"trailing separator", "# One\n---\n# Two\n---\n", []string{"# One", "# Two"}},
+		{"trailing separator without newline", "# One\n---\n# Two\n---", []string{"# One", "# Two"}},
+		{"leading separator", "---\n# One\n---\n# Two", []string{"# One", "# Two"}},
+		{"empty slides between separators", "# One\n---\n\n---\n---\n# Two", []string{"# One", "# Two"}},
+		{"trailing spaces and CRLF", "# One\r\n---  \r\n# Two\r\n", []string{"# One", "# Two"}},
+		{"longer rule stays in slide", "# One\n\n------\n\nMore", []string{"# One\n\n------\n\nMore"}},
+		{"four dashes stay in slide", "# One\n----\nMore", []string{"# One\n----\nMore"}},
+		{"indented dashes stay in slide", "# One\n  ---\nMore", []string{"# One\n  ---\nMore"}},
+		{"dashes with text stay in slide", "# One\n--- not a separator", []string{"# One\n--- not a separator"}},
+		{
+			"separator inside backtick fence",
+			"# YAML\n```yaml\n---\nkey: value\n---\n```\n---\n# Next",
+			[]string{"# YAML\n```yaml\n---\nkey: value\n---\n```", "# Next"},
+		},
+		{
+			"separator inside tilde fence",
+			"# Code\n~~~\n---\n~~~\n---\n# Next",
+			[]string{"# Code\n~~~\n---\n~~~", "# Next"},
+		},
+		{
+			"fence closed only by a matching run",
+			"# Code\n````\n```\n---\n````\n---\n# Next",
+			[]string{"# Code\n````\n```\n---\n````", "# Next"},
+		},
+		{"only separators", "---\n---\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, SplitSlides(tt.markdown))
+		})
+	}
+}
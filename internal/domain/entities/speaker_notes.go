@@ -26,6 +26,7 @@ type PresenterState struct {
 	IsPaused       bool          `json:"isPaused"`
 	Notes          *SpeakerNotes `json:"notes,omitempty"`
 	NextSlideTitle string        `json:"nextSlideTitle"`
+	Tasks          TaskStates    `json:"tasks,omitempty"`
 }
 
 // Progress returns the presentation progress as a percentage
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// TaskStates records ticked task-list items by slide index, then by the
+// item's position among the slide's checkboxes
+type TaskStates map[int]map[int]bool
+
+// Set records the state of one task-list item
+func (t TaskStates) Set(slide, task int, checked bool) {
+	if t[slide] == nil {
+		t[slide] = make(map[int]bool)
+	}
+	t[slide][task] = checked
+}
+
+// Copy returns a deep copy of the states
+func (t TaskStates) Copy() TaskStates {
+	if t == nil {
+		return nil
+	}
+	c := make(TaskStates, len(t))
+	for slide, tasks := range t {
+		c[slide] = make(map[int]bool, len(tasks))
+		for task, checked := range tasks {
+			c[slide][task] = checked
+		}
+	}
+	return c
+}
+
+// taskCheckbox matches the checkbox goldmark's task-list extension renders at
+// the start of a list item, in tight (<li>) and loose (<li><p>) lists
+var taskCheckbox = regexp.MustCompile(`(<li>\s*(?:<p>)?)(<input\b[^>]*\btype="checkbox"[^>]*>)`)
+
+// checkedAttr matches the checked attribute of a rendered checkbox
+var checkedAttr = regexp.MustCompile(`\schecked\b`)
+
+// ApplyTaskStates rewrites the task-list checkboxes in a slide's HTML to the
+// recorded states; items without a recorded state keep their markdown state.
+// Interactive checkboxes are enabled and carry data-slide and data-task so
+// clicks can be sent back, otherwise they stay disabled as goldmark renders them.
+func ApplyTaskStates(html string, slide int, states map[int]bool, interactive bool) string {
+	task := 0
+	return taskCheckbox.ReplaceAllStringFunc(html, func(match string) string {
+		parts := taskCheckbox.FindStringSubmatch(match)
+		prefix, input := parts[1], parts[2]
+
+		checked := checkedAttr.MatchString(input)
+		if state, ok := states[task]; ok {
+			checked = state
+		}
+
+		var b strings.Builder
+		b.WriteString(prefix)
+		b.WriteString("<input")
+		if checked {
+			b.WriteString(` checked=""`)
+		}
+		if interactive {
+			fmt.Fprintf(&b, ` class="task-checkbox" data-slide="%d" data-task="%d" type="checkbox"`, slide, task)
+		} else {
+			b.WriteString(` disabled="" type="checkbox"`)
+		}
+		if strings.HasSuffix(input, "/>") {
+			b.WriteString(" />")
+		} else {
+			b.WriteString(">")
+		}
+
+		task++
+		return b.String()
+	})
+}
@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyTaskStates(t *testing.T) {
+	tight := "<ul>\n<li><input disabled=\"\" type=\"checkbox\"> a</li>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> b</li>\n</ul>\n"
+
+	t.Run("unchanged without states", func(t *testing.T) {
+		assert.Equal(t, tight, ApplyTaskStates(tight, 0, nil, false))
+	})
+
+	t.Run("recorded states override markdown", func(t *testing.T) {
+		html := ApplyTaskStates(tight, 0, map[int]bool{0: true, 1: false}, false)
+		assert.Equal(t, "<ul>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> a</li>\n<li><input disabled=\"\" type=\"checkbox\"> b</li>\n</ul>\n", html)
+	})
+
+	t.Run("interactive checkboxes are enabled and addressable", func(t *testing.T) {
+		html := ApplyTaskStates(tight, 3, map[int]bool{0: true}, true)
+		assert.Contains(t, html, `<li><input checked="" class="task-checkbox" data-slide="3" data-task="0" type="checkbox"> a</li>`)
+		assert.Contains(t, html, `<li><input checked="" class="task-checkbox" data-slide="3" data-task="1" type="checkbox"> b</li>`)
+		assert.NotContains(t, html, "disabled")
+	})
+
+	t.Run("loose lists and XHTML", func(t *testing.T) {
+		loose := "<ol>\n<li>\n<p><input disabled=\"\" type=\"checkbox\" /> one</p>\n</li>\n</ol>\n"
+		assert.Equal(t, "<ol>\n<li>\n<p><input checked=\"\" disabled=\"\" type=\"checkbox\" /> one</p>\n</li>\n</ol>\n",
+			ApplyTaskStates(loose, 0, map[int]bool{0: true}, false))
+	})
+
+	t.Run("checkboxes outside task lists are left alone", func(t *testing.T) {
+		form := `<p><input type="checkbox"> raw</p>`
+		assert.Equal(t, form, ApplyTaskStates(form, 0, map[int]bool{0: true}, true))
+	})
+}
+
+func TestTaskStates_Copy(t *testing.T) {
+	states := TaskStates{}
+	states.Set(1, 2, true)
+
+	copied := states.Copy()
+	copied.Set(1, 2, false)
+
+	assert.True(t, states[1][2])
+	assert.Nil(t, TaskStates(nil).Copy())
+}
@@ -40,6 +40,10 @@ type ThemeAsset struct {
 	// Path is the relative path within the theme
 	Path string
 
+	// Dir is the directory the asset was read from, which relative CSS
+	// @import paths resolve against. Empty for assets not loaded from disk.
+	Dir string
+
 	// Content is the file content
 	Content []byte
 
@@ -71,6 +75,23 @@ type ThemeEngineConfig struct {
 	Features map[string]bool `toml:"features"`
 }
 
+// ThemeVariable describes a CSS custom property a theme declares or uses
+type ThemeVariable struct {
+	// Name is the property name without the leading dashes
+	Name string `json:"name"`
+
+	// Default is the theme's value for the property, or the var() fallback
+	// when the theme never declares it
+	Default string `json:"default"`
+
+	// Declared reports whether the theme defines the property, in a :root
+	// block or its theme.toml variables
+	Declared bool `json:"declared"`
+
+	// Used reports whether the theme reads the property through var()
+	Used bool `json:"used"`
+}
+
 // FontConfig defines a custom font
 type FontConfig struct {
 	// Name is the font family name
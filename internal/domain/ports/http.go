@@ -3,6 +3,8 @@ package ports
 import (
 	"context"
 	"time"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 )
 
 // HTTPServer defines the interface for the HTTP server
@@ -13,6 +15,30 @@ type HTTPServer interface {
 	IsRunning() bool
 }
 
+// PresentationUpdater receives each presentation successfully reloaded from disk
+type PresentationUpdater interface {
+	UpdatePresentation(p *entities.Presentation)
+}
+
+// PresentationDirSetter is implemented by presentation updaters that resolve
+// paths relative to the presentation's source file, such as slide images
+// in exports
+type PresentationDirSetter interface {
+	SetPresentationDir(dir string)
+}
+
+// PresentationPathSetter is implemented by presentation updaters that load
+// the presentation's source file again, such as to render it for an export
+type PresentationPathSetter interface {
+	SetPresentationPath(path string)
+}
+
+// NotesOpener is implemented by presentation updaters that keep the speaker
+// notes edited during a session next to the presentation's source file
+type NotesOpener interface {
+	OpenNotes(presentationPath string) error
+}
+
 // UpdateEvent represents an event sent to WebSocket clients
 type UpdateEvent struct {
 	Type      string      `json:"type"`
@@ -23,10 +49,13 @@ type UpdateEvent struct {
 // UpdateEventType constants
 const (
 	EventTypeReload         = "reload"
+	EventTypeSlidesUpdate   = "slides_update"
+	EventTypeCSSReload      = "css_reload"
 	EventTypeFileChange     = "file_change"
 	EventTypeError          = "error"
 	EventTypePresenterState = "presenter_state"
 	EventTypeNavigation     = "navigation"
 	EventTypeTimer          = "timer"
 	EventTypeNotesUpdate    = "notes_update"
+	EventTypeTaskUpdate     = "task_update"
 )
@@ -87,6 +87,10 @@ type PluginRegistry interface {
 
 	// SetPluginStatus sets the status of a plugin.
 	SetPluginStatus(name string, status entities.PluginStatus, errorMsg string) error
+
+	// SetTimeoutThreshold sets the timeout rate above which a plugin is marked
+	// degraded, once it has run at least minExecutions times.
+	SetTimeoutThreshold(rate float64, minExecutions int64)
 }
 
 // PluginCache caches plugin execution results.
@@ -100,6 +104,9 @@ type PluginCache interface {
 	// Remove removes a result from the cache.
 	Remove(key string)
 
+	// DeleteByPrefix removes all results whose key starts with prefix.
+	DeleteByPrefix(prefix string)
+
 	// Clear removes all results from the cache.
 	Clear()
 
@@ -121,6 +128,10 @@ type PluginService interface {
 	// ExecutePlugin executes a plugin by name.
 	ExecutePlugin(ctx context.Context, name string, input plugin.PluginInput) (plugin.PluginOutput, error)
 
+	// ExecutePluginStream executes a plugin by name, passing output to emit
+	// as it's produced when the plugin implements plugin.StreamingPlugin.
+	ExecutePluginStream(ctx context.Context, name string, input plugin.PluginInput, emit func(plugin.OutputChunk)) (plugin.PluginOutput, error)
+
 	// GetPlugin retrieves a plugin by name.
 	GetPlugin(name string) (plugin.Plugin, error)
 
@@ -133,6 +144,9 @@ type PluginService interface {
 	// ProcessContent processes content using matching plugins.
 	ProcessContent(ctx context.Context, content string, language string) ([]plugin.PluginOutput, error)
 
+	// PluginHealth reports timeout rates and health for all loaded plugins.
+	PluginHealth() []entities.PluginHealth
+
 	// Shutdown gracefully shuts down the plugin service.
 	Shutdown(ctx context.Context) error
 }
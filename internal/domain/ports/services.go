@@ -67,6 +67,15 @@ type NotesService interface {
 	ConvertNotesToHTML(notes string) string
 }
 
+// TaskStateStore persists the ticked state of task-list items per slide
+type TaskStateStore interface {
+	// GetTaskStates returns the recorded states for a slide, keyed by task position
+	GetTaskStates(slideID string) map[int]bool
+
+	// SetTaskState records the state of one task on a slide
+	SetTaskState(slideID string, task int, checked bool) error
+}
+
 // PresentationSync defines the interface for presentation synchronization
 type PresentationSync interface {
 	// Subscribe adds a client to receive sync events
@@ -47,6 +47,10 @@ type AssetProcessor interface {
 	// ProcessCSS processes CSS with variable substitution
 	ProcessCSS(content []byte, variables map[string]string) ([]byte, error)
 
+	// ResolveImports splices local @import files, relative to dir and kept
+	// inside the themes directory root, into CSS
+	ResolveImports(content []byte, dir, root string) ([]byte, error)
+
 	// ProcessJS processes JavaScript files
 	ProcessJS(content []byte, variables map[string]string) ([]byte, error)
 
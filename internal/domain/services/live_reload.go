@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/internal/domain/ports"
 )
 
@@ -22,6 +25,9 @@ type LiveReloadService struct {
 	watching         bool
 	watchCancel      context.CancelFunc
 	presentationPath string
+	watcherConfig    entities.WatcherConfig
+	lastRender       []byte
+	updater          ports.PresentationUpdater
 }
 
 // NewLiveReloadService creates a new live reload service
@@ -56,7 +62,9 @@ func (s *LiveReloadService) Start(ctx context.Context, filePath string) error {
 	}
 	s.watching = true
 	s.presentationPath = filePath
+	updater := s.updater
 	s.mu.Unlock()
+	s.attachPresentation(updater, filePath)
 
 	// Create a cancellable context for the watcher
 	watchCtx, cancel := context.WithCancel(ctx)
@@ -96,6 +104,56 @@ func (s *LiveReloadService) Stop() error {
 	return nil
 }
 
+// SetWatcherConfig updates the debounce and retry settings used when reloading.
+// Editors often save in several writes, so a reload that fails to parse is
+// retried after a delay before giving up.
+func (s *LiveReloadService) SetWatcherConfig(config entities.WatcherConfig) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.watcherConfig = config
+}
+
+// SetPresentationUpdater registers a receiver for every reloaded
+// presentation, such as the HTTP server regenerating exports in the background
+func (s *LiveReloadService) SetPresentationUpdater(updater ports.PresentationUpdater) {
+	s.mu.Lock()
+	s.updater = updater
+	path := s.presentationPath
+	s.mu.Unlock()
+	s.attachPresentation(updater, path)
+}
+
+// attachPresentation tells an updater about the presentation file at path,
+// once both are known: updaters resolving relative paths get its directory,
+// those loading it again get its path, and those keeping speaker notes open
+// the notes saved next to it
+func (s *LiveReloadService) attachPresentation(updater ports.PresentationUpdater, path string) {
+	if updater == nil || path == "" {
+		return
+	}
+	if setter, ok := updater.(ports.PresentationDirSetter); ok {
+		setter.SetPresentationDir(filepath.Dir(path))
+	}
+	if setter, ok := updater.(ports.PresentationPathSetter); ok {
+		setter.SetPresentationPath(path)
+	}
+	if opener, ok := updater.(ports.NotesOpener); ok {
+		if err := opener.OpenNotes(path); err != nil {
+			s.logger.Warn("Speaker notes edits won't be saved",
+				slog.String("presentation_path", path),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// LastRender returns the most recent successfully rendered presentation
+func (s *LiveReloadService) LastRender() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastRender
+}
+
 // IsWatching returns whether the service is currently watching
 func (s *LiveReloadService) IsWatching() bool {
 	s.mu.Lock()
@@ -115,14 +173,23 @@ func (s *LiveReloadService) handleEvents(ctx context.Context, events <-chan port
 				return
 			}
 
+			// Coalesce bursts of events from a single save
+			event, ok = s.debounce(ctx, events, event)
+			if !ok {
+				return
+			}
+
 			s.logger.Info("File changed detected",
 				slog.String("path", event.Path),
 				slog.String("type", event.Type.String()),
 				slog.Time("timestamp", event.Timestamp),
 			)
 
-			// Reload the presentation
-			if err := s.reloadPresentation(); err != nil {
+			// Reload the presentation, keeping the last good render on failure
+			if err := s.reloadWithRetry(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
 				s.logger.Error("Failed to reload presentation",
 					slog.String("error", err.Error()),
 					slog.String("path", event.Path),
@@ -157,8 +224,89 @@ func (s *LiveReloadService) handleEvents(ctx context.Context, events <-chan port
 	}
 }
 
+// debounce waits until no further events arrive for the configured debounce
+// period and returns the latest event. It returns false if ctx is cancelled.
+func (s *LiveReloadService) debounce(ctx context.Context, events <-chan ports.FileChangeEvent, event ports.FileChangeEvent) (ports.FileChangeEvent, bool) {
+	s.mu.Lock()
+	delay := time.Duration(s.watcherConfig.DebounceMs) * time.Millisecond
+	s.mu.Unlock()
+
+	batch, ok := DebounceEvents(ctx, events, event, delay)
+	return batch[len(batch)-1], ok
+}
+
+// reloadWithRetry reloads the presentation, re-reading after a delay when the
+// file can't be loaded since it may still be partially written.
+func (s *LiveReloadService) reloadWithRetry(ctx context.Context) error {
+	s.mu.Lock()
+	config := s.watcherConfig
+	s.mu.Unlock()
+
+	return RetryReload(ctx, config, func() error {
+		err := s.reloadPresentation(ctx)
+		if err != nil {
+			s.logger.Debug("Presentation reload failed", slog.String("error", err.Error()))
+		}
+		return err
+	})
+}
+
+// DebounceEvents collects the events following event until none arrive for
+// delay, so a burst of saves triggers a single reload, and returns the burst.
+// It returns false if ctx is cancelled.
+func DebounceEvents(ctx context.Context, events <-chan ports.FileChangeEvent, event ports.FileChangeEvent, delay time.Duration) ([]ports.FileChangeEvent, bool) {
+	batch := []ports.FileChangeEvent{event}
+	if delay <= 0 {
+		return batch, true
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return batch, false
+		case next, ok := <-events:
+			if !ok {
+				return batch, true
+			}
+			batch = append(batch, next)
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(delay)
+		case <-timer.C:
+			return batch, true
+		}
+	}
+}
+
+// RetryReload calls reload until it succeeds, up to config.MaxRetries more
+// times with config.RetryDelayMs between attempts, returning the last error
+func RetryReload(ctx context.Context, config entities.WatcherConfig, reload func() error) error {
+	retryDelay := time.Duration(config.RetryDelayMs) * time.Millisecond
+
+	var err error
+	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(retryDelay):
+			}
+		}
+
+		if err = reload(); err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
 // reloadPresentation reloads the presentation from disk
-func (s *LiveReloadService) reloadPresentation() error {
+func (s *LiveReloadService) reloadPresentation(ctx context.Context) error {
 	s.mu.Lock()
 	path := s.presentationPath
 	s.mu.Unlock()
@@ -167,8 +315,6 @@ func (s *LiveReloadService) reloadPresentation() error {
 		return errors.New("no presentation path set")
 	}
 
-	ctx := context.Background()
-
 	// Load the presentation from disk
 	presentation, err := s.presenter.LoadPresentation(ctx, path)
 	if err != nil {
@@ -186,9 +332,16 @@ func (s *LiveReloadService) reloadPresentation() error {
 		return fmt.Errorf("rendering presentation: %w", err)
 	}
 
-	// Update the server's presentation
-	// Note: This assumes the server has a SetPresentation method
-	// which we'll need to add to the HTTPServer interface or handle differently
+	// Only replace the served render once the new one is complete
+	s.mu.Lock()
+	s.lastRender = html
+	updater := s.updater
+	s.mu.Unlock()
+
+	if updater != nil {
+		updater.UpdatePresentation(presentation)
+	}
+
 	s.logger.Info("Presentation reloaded successfully",
 		slog.Int("html_size_bytes", len(html)),
 		slog.String("presentation_path", path),
@@ -3,6 +3,10 @@ package services
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -338,3 +342,150 @@ func TestLiveReloadServiceHandleEvents(t *testing.T) {
 		watcher.AssertExpectations(t)
 	})
 }
+
+// filePresenter loads presentations from disk, failing on incomplete frontmatter
+// the way a parser would when reading a file mid-save.
+type filePresenter struct {
+	MockPresentationService
+}
+
+func (p *filePresenter) LoadPresentation(ctx context.Context, path string) (*entities.Presentation, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.SplitN(string(content), "---\n", 3)
+	if len(parts) < 3 || strings.TrimSpace(parts[2]) == "" {
+		return nil, errors.New("unexpected end of presentation")
+	}
+	return &entities.Presentation{Title: strings.TrimSpace(parts[2])}, nil
+}
+
+func (p *filePresenter) ApplyTheme(ctx context.Context, presentation *entities.Presentation, themeName string) error {
+	return nil
+}
+
+type titleRenderer struct {
+	MockRenderer
+}
+
+func (r *titleRenderer) RenderPresentation(ctx context.Context, presentation *entities.Presentation) ([]byte, error) {
+	return []byte("<h1>" + presentation.Title + "</h1>"), nil
+}
+
+func TestLiveReloadServicePartialWrites(t *testing.T) {
+	newService := func(t *testing.T, path string, config entities.WatcherConfig) (*LiveReloadService, chan ports.FileChangeEvent, *atomic.Int32) {
+		watcher := &MockFileWatcher{}
+		server := &MockHTTPServer{}
+		events := make(chan ports.FileChangeEvent, 4)
+		notified := &atomic.Int32{}
+		watcher.On("Watch", mock.Anything, path).Return((<-chan ports.FileChangeEvent)(events), nil)
+		server.On("NotifyClients", mock.Anything).Return(nil).Run(func(mock.Arguments) { notified.Add(1) })
+
+		service := NewLiveReloadService(watcher, server, &MockBrowserLauncher{}, &filePresenter{}, &titleRenderer{}, nil)
+		service.SetWatcherConfig(config)
+		require.NoError(t, service.Start(context.Background(), path))
+		t.Cleanup(func() {
+			_ = service.Stop()
+		})
+		return service, events, notified
+	}
+
+	modified := func(path string) ports.FileChangeEvent {
+		return ports.FileChangeEvent{Path: path, Type: ports.Modified, Timestamp: time.Now()}
+	}
+
+	t.Run("retries until the write completes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "slides.md")
+		require.NoError(t, os.WriteFile(path, []byte("---\ntitle: demo\n---\nFirst"), 0o644))
+
+		service, events, notified := newService(t, path, entities.WatcherConfig{
+			DebounceMs:   10,
+			MaxRetries:   10,
+			RetryDelayMs: 20,
+		})
+
+		events <- modified(path)
+		require.Eventually(t, func() bool { return string(service.LastRender()) == "<h1>First</h1>" }, time.Second, 5*time.Millisecond)
+
+		// The editor truncates and writes the first half of the file
+		require.NoError(t, os.WriteFile(path, []byte("---\ntitle: de"), 0o644))
+		events <- modified(path)
+
+		time.Sleep(60 * time.Millisecond)
+		assert.Equal(t, "<h1>First</h1>", string(service.LastRender()), "last good render is kept while the file is incomplete")
+		assert.Equal(t, int32(1), notified.Load())
+
+		require.NoError(t, os.WriteFile(path, []byte("---\ntitle: demo\n---\nSecond"), 0o644))
+		require.Eventually(t, func() bool { return string(service.LastRender()) == "<h1>Second</h1>" }, time.Second, 5*time.Millisecond)
+		require.Eventually(t, func() bool { return notified.Load() == 2 }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("skips broadcast when retries are exhausted", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "slides.md")
+		require.NoError(t, os.WriteFile(path, []byte("---\ntitle: de"), 0o644))
+
+		service, events, notified := newService(t, path, entities.WatcherConfig{
+			MaxRetries:   2,
+			RetryDelayMs: 5,
+		})
+
+		events <- modified(path)
+		time.Sleep(100 * time.Millisecond)
+
+		assert.Nil(t, service.LastRender())
+		assert.Equal(t, int32(0), notified.Load())
+	})
+}
+
+// recordingUpdater records the presentations passed to UpdatePresentation
+// and the source file it was told about
+type recordingUpdater struct {
+	updates atomic.Int32
+	last    atomic.Pointer[entities.Presentation]
+	path    atomic.Value
+}
+
+func (u *recordingUpdater) SetPresentationPath(path string) {
+	u.path.Store(path)
+}
+
+func (u *recordingUpdater) UpdatePresentation(p *entities.Presentation) {
+	u.last.Store(p)
+	u.updates.Add(1)
+}
+
+func TestLiveReloadServicePresentationUpdater(t *testing.T) {
+	watcher := &MockFileWatcher{}
+	server := &MockHTTPServer{}
+	presenter := &MockPresentationService{}
+	renderer := &MockRenderer{}
+
+	service := NewLiveReloadService(watcher, server, &MockBrowserLauncher{}, presenter, renderer, nil)
+	service.SetWatcherConfig(entities.WatcherConfig{DebounceMs: 30})
+	updater := &recordingUpdater{}
+	service.SetPresentationUpdater(updater)
+
+	events := make(chan ports.FileChangeEvent, 3)
+	watcher.On("Watch", mock.Anything, "/test/file.md").Return((<-chan ports.FileChangeEvent)(events), nil)
+
+	presentation := &entities.Presentation{Title: "Saved"}
+	presenter.On("LoadPresentation", mock.Anything, "/test/file.md").Return(presentation, nil)
+	presenter.On("ApplyTheme", mock.Anything, presentation, "default").Return(nil)
+	renderer.On("RenderPresentation", mock.Anything, presentation).Return([]byte("<html>saved</html>"), nil)
+	server.On("NotifyClients", mock.Anything).Return(nil)
+
+	require.NoError(t, service.Start(context.Background(), "/test/file.md"))
+	defer func() { _ = service.Stop() }()
+	assert.Equal(t, "/test/file.md", updater.path.Load(), "exports can render the source again")
+
+	// A burst of writes from one save hands over a single presentation
+	for i := 0; i < 3; i++ {
+		events <- ports.FileChangeEvent{Path: "/test/file.md", Type: ports.Modified, Timestamp: time.Now()}
+	}
+
+	require.Eventually(t, func() bool { return updater.updates.Load() == 1 }, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(1), updater.updates.Load())
+	assert.Same(t, presentation, updater.last.Load())
+}
@@ -2,11 +2,15 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -41,13 +45,26 @@ type PluginService struct {
 type PluginServiceConfig struct {
 	PluginDirs        []string
 	DefaultTimeout    time.Duration
+	MaxPluginTimeout  time.Duration // Cap on timeouts set per code block through the timeout option
 	MaxConcurrent     int
+	MaxConcurrentCall int // Plugins one ProcessContent call runs at once (default: half of MaxConcurrent)
 	CacheEnabled      bool
 	CacheTTL          time.Duration
 	AutoDiscover      bool
 	DiscoverOnStart   bool
+
+	// Discovered plugins are loaded DiscoveryParallelism at a time, and one
+	// not loaded within DiscoveryTimeout is skipped
+	DiscoveryTimeout     time.Duration
+	DiscoveryParallelism int
+
 	MemoryLimit       int64 // Memory limit per plugin execution in bytes (0 = no limit)
 	EnableMemoryLimit bool  // Enable memory limiting if supported by platform
+
+	// A plugin whose timeout rate reaches TimeoutWarningRate after at least
+	// TimeoutWarningMinExecutions runs is reported as degraded
+	TimeoutWarningRate          float64
+	TimeoutWarningMinExecutions int64
 }
 
 // NewPluginService creates a new plugin service.
@@ -67,15 +84,34 @@ func NewPluginService(
 	if config.DefaultTimeout <= 0 {
 		config.DefaultTimeout = 5 * time.Second
 	}
+	if config.MaxPluginTimeout <= 0 {
+		config.MaxPluginTimeout = time.Minute
+	}
 	if config.MaxConcurrent <= 0 {
 		config.MaxConcurrent = 10
 	}
+	if config.MaxConcurrentCall <= 0 {
+		config.MaxConcurrentCall = max(config.MaxConcurrent/2, 1)
+	}
 	if config.CacheTTL <= 0 {
 		config.CacheTTL = 5 * time.Minute
 	}
+	if config.DiscoveryTimeout <= 0 {
+		config.DiscoveryTimeout = 10 * time.Second
+	}
+	if config.DiscoveryParallelism <= 0 {
+		config.DiscoveryParallelism = 4
+	}
 	if config.MemoryLimit <= 0 {
 		config.MemoryLimit = 100 * 1024 * 1024 // Default 100MB per plugin
 	}
+	if config.TimeoutWarningRate <= 0 {
+		config.TimeoutWarningRate = 0.5
+	}
+	if config.TimeoutWarningMinExecutions <= 0 {
+		config.TimeoutWarningMinExecutions = 4
+	}
+	registry.SetTimeoutThreshold(config.TimeoutWarningRate, config.TimeoutWarningMinExecutions)
 
 	service := &PluginService{
 		loader:           loader,
@@ -167,6 +203,11 @@ func (s *PluginService) LoadPlugin(ctx context.Context, path string) error {
 	if err := p.Init(config); err != nil {
 		return fmt.Errorf("initializing plugin %s: %w", p.Name(), err)
 	}
+	// A caller that gave up while Init ran doesn't expect the plugin loaded
+	if err := ctx.Err(); err != nil {
+		_ = p.Cleanup()
+		return fmt.Errorf("initializing plugin %s: %w", p.Name(), err)
+	}
 
 	// Register the plugin
 	if err := s.registry.Register(p.Name(), p, metadata); err != nil {
@@ -174,6 +215,12 @@ func (s *PluginService) LoadPlugin(ctx context.Context, path string) error {
 		_ = p.Cleanup()
 		return fmt.Errorf("registering plugin %s: %w", p.Name(), err)
 	}
+	// The caller may have given up while the plugin was being registered
+	if err := ctx.Err(); err != nil {
+		_ = s.registry.Remove(p.Name())
+		_ = p.Cleanup()
+		return fmt.Errorf("registering plugin %s: %w", p.Name(), err)
+	}
 
 	s.logger.Info("Plugin loaded successfully",
 		slog.String("name", p.Name()),
@@ -212,11 +259,10 @@ func (s *PluginService) UnloadPlugin(ctx context.Context, name string) error {
 		)
 	}
 
-	// Clear all cache entries since we can't selectively clear by plugin
-	// This is a limitation of the current cache interface design
+	// Drop only this plugin's cached output; other plugins' entries stay valid
 	if s.cache != nil {
-		s.cache.Clear()
-		s.logger.Info("Cleared cache after unloading plugin",
+		s.cache.DeleteByPrefix(cacheKeyPrefix(name))
+		s.logger.Info("Cleared cached output of unloaded plugin",
 			slog.String("name", name),
 		)
 	}
@@ -241,29 +287,90 @@ func (s *PluginService) DiscoverPlugins(ctx context.Context) ([]pluginapi.Plugin
 
 	// Load compatible plugins if auto-discover is enabled
 	if s.config.AutoDiscover {
-		for _, info := range plugins {
-			if info.Compatible {
-				if err := s.LoadPlugin(ctx, info.Path); err != nil {
-					s.logger.Warn("Failed to auto-load discovered plugin",
-						slog.String("name", info.Name),
-						slog.String("path", info.Path),
-						slog.String("error", err.Error()),
-					)
-				} else {
-					s.logger.Debug("Auto-loaded discovered plugin",
-						slog.String("name", info.Name),
-						slog.String("path", info.Path),
-					)
-				}
-			}
-		}
+		s.loadDiscoveredPlugins(ctx, plugins)
 	}
 
 	return plugins, nil
 }
 
+// loadDiscoveredPlugins loads the compatible plugins among those discovered,
+// several at once. A plugin whose load outlasts the discovery timeout is
+// logged and skipped, so one hanging in Init can't stall startup; it's
+// cleaned up rather than registered if its Init ever returns.
+func (s *PluginService) loadDiscoveredPlugins(ctx context.Context, plugins []pluginapi.PluginInfo) {
+	slots := make(chan struct{}, s.config.DiscoveryParallelism)
+	var wg sync.WaitGroup
+	for _, info := range plugins {
+		if !info.Compatible {
+			continue
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		}
+		wg.Add(1)
+		go func(info pluginapi.PluginInfo) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			s.loadDiscoveredPlugin(ctx, info)
+		}(info)
+	}
+	wg.Wait()
+}
+
+// loadDiscoveredPlugin loads one discovered plugin within the discovery
+// timeout, logging the outcome
+func (s *PluginService) loadDiscoveredPlugin(ctx context.Context, info pluginapi.PluginInfo) {
+	loadCtx, cancel := context.WithTimeout(ctx, s.config.DiscoveryTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- s.LoadPlugin(loadCtx, info.Path)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			s.logger.Warn("Failed to auto-load discovered plugin",
+				slog.String("name", info.Name),
+				slog.String("path", info.Path),
+				slog.String("error", err.Error()),
+			)
+			return
+		}
+		s.logger.Debug("Auto-loaded discovered plugin",
+			slog.String("name", info.Name),
+			slog.String("path", info.Path),
+		)
+	case <-loadCtx.Done():
+		s.logger.Warn("Skipping discovered plugin that didn't load in time",
+			slog.String("name", info.Name),
+			slog.String("path", info.Path),
+			slog.Duration("timeout", s.config.DiscoveryTimeout),
+		)
+	}
+}
+
 // ExecutePlugin executes a plugin by name.
 func (s *PluginService) ExecutePlugin(ctx context.Context, name string, input pluginapi.PluginInput) (pluginapi.PluginOutput, error) {
+	return s.executePlugin(ctx, name, input, nil)
+}
+
+// ExecutePluginStream executes a plugin by name, passing its output to emit
+// as it's produced when the plugin supports streaming. Other plugins run as
+// with ExecutePlugin and only return their complete output. Streamed runs
+// bypass the result cache, since a cached result has nothing to stream.
+func (s *PluginService) ExecutePluginStream(ctx context.Context, name string, input pluginapi.PluginInput, emit func(pluginapi.OutputChunk)) (pluginapi.PluginOutput, error) {
+	return s.executePlugin(ctx, name, input, emit)
+}
+
+// executePlugin runs a plugin through the sandbox, streaming its output to
+// emit when it's set and the plugin can
+func (s *PluginService) executePlugin(ctx context.Context, name string, input pluginapi.PluginInput, emit func(pluginapi.OutputChunk)) (pluginapi.PluginOutput, error) {
 	// Check if shutting down
 	s.shutdownMu.Lock()
 	if s.shutdown {
@@ -278,36 +385,21 @@ func (s *PluginService) ExecutePlugin(ctx context.Context, name string, input pl
 		return pluginapi.PluginOutput{}, fmt.Errorf("plugin %s not found", name)
 	}
 
+	useCache := s.config.CacheEnabled && s.cache != nil
+	if streaming, ok := p.(pluginapi.StreamingPlugin); ok && emit != nil {
+		p = &streamingExecution{Plugin: p, streaming: streaming, emit: emit}
+		useCache = false
+	}
+
 	// Check cache if enabled
-	if s.config.CacheEnabled && s.cache != nil {
-		cacheKey := s.generateCacheKey(name, input)
+	if useCache {
+		cacheKey := s.generateCacheKey(name, p, input)
 		if output, found := s.cache.Get(cacheKey); found {
 			return *output, nil
 		}
 	}
 
-	// Get timeout from config, with plugin-specific override
-	timeout := s.config.DefaultTimeout
-
-	// Get timeout from plugin metadata if available
-	if metadata, exists := s.registry.GetMetadata(name); exists {
-		// Check if plugin has custom config with timeout
-		if loadedPlugin, err := s.registry.GetLoadedPlugin(name); err == nil {
-			// First try to get timeout from plugin's manifest config
-			if manifestTimeout := loadedPlugin.GetPluginTimeout(); manifestTimeout > 0 {
-				timeout = manifestTimeout
-			}
-		}
-
-		// Check for timeout in plugin metadata config
-		if metadata.Config != nil {
-			if timeoutStr, exists := metadata.Config["timeout"]; exists {
-				if customTimeout, err := parseTimeout(timeoutStr); err == nil && customTimeout > 0 {
-					timeout = customTimeout
-				}
-			}
-		}
-	}
+	timeout := s.executionTimeout(name, input)
 
 	// Execute the plugin with memory limiting if available
 	startTime := time.Now()
@@ -328,6 +420,12 @@ func (s *PluginService) ExecutePlugin(ctx context.Context, name string, input pl
 	}
 	duration := time.Since(startTime)
 
+	// A caller that gave up, such as a browser that navigated away, says
+	// nothing about the plugin's health
+	if err != nil && ctx.Err() != nil {
+		return pluginapi.PluginOutput{}, ctx.Err()
+	}
+
 	// Update statistics
 	success := err == nil
 	bytesIn := int64(len(input.Content))
@@ -337,7 +435,7 @@ func (s *PluginService) ExecutePlugin(ctx context.Context, name string, input pl
 	if err != nil {
 		// Check if it was a timeout or panic
 		if strings.Contains(err.Error(), "timeout") {
-			s.registry.IncrementTimeout(name)
+			s.recordTimeout(name, timeout)
 		} else if strings.Contains(err.Error(), "panic") {
 			s.registry.IncrementPanic(name)
 		}
@@ -345,14 +443,156 @@ func (s *PluginService) ExecutePlugin(ctx context.Context, name string, input pl
 	}
 
 	// Cache the result if enabled
-	if s.config.CacheEnabled && s.cache != nil {
-		cacheKey := s.generateCacheKey(name, input)
+	if useCache {
+		cacheKey := s.generateCacheKey(name, p, input)
 		s.cache.Set(cacheKey, &output, s.config.CacheTTL)
 	}
 
 	return output, nil
 }
 
+// streamingExecution runs a streaming plugin's ExecuteStream in place of
+// Execute, so streamed runs share the sandbox's timeout and memory limits
+type streamingExecution struct {
+	pluginapi.Plugin
+	streaming pluginapi.StreamingPlugin
+	emit      func(pluginapi.OutputChunk)
+}
+
+func (e *streamingExecution) Execute(ctx context.Context, input pluginapi.PluginInput) (pluginapi.PluginOutput, error) {
+	return e.streaming.ExecuteStream(ctx, input, e.emit)
+}
+
+// executionTimeout returns the timeout of one execution: the input's
+// timeout option, capped at MaxPluginTimeout, or else the plugin's timeout
+func (s *PluginService) executionTimeout(name string, input pluginapi.PluginInput) time.Duration {
+	value, exists := input.Options["timeout"]
+	if !exists {
+		return s.pluginTimeout(name)
+	}
+
+	timeout, err := parseTimeout(fmt.Sprint(value))
+	if err != nil || timeout <= 0 {
+		s.logger.Warn("Ignoring invalid timeout option",
+			slog.String("plugin", name),
+			slog.Any("timeout", value))
+		return s.pluginTimeout(name)
+	}
+	return min(timeout, s.config.MaxPluginTimeout)
+}
+
+// pluginTimeout returns the effective timeout for a plugin: the service
+// default, overridden by its metadata config and then by its manifest
+func (s *PluginService) pluginTimeout(name string) time.Duration {
+	timeout := s.config.DefaultTimeout
+
+	metadata, exists := s.registry.GetMetadata(name)
+	if !exists {
+		return timeout
+	}
+
+	if metadata.Config != nil {
+		if timeoutStr, exists := metadata.Config["timeout"]; exists {
+			if customTimeout, err := parseTimeout(timeoutStr); err == nil && customTimeout > 0 {
+				timeout = customTimeout
+			}
+		}
+	}
+
+	if loadedPlugin, err := s.registry.GetLoadedPlugin(name); err == nil {
+		if manifestTimeout := loadedPlugin.GetPluginTimeout(); manifestTimeout > 0 {
+			timeout = manifestTimeout
+		}
+	}
+
+	return timeout
+}
+
+// recordTimeout counts a timeout and warns when it makes the plugin degraded
+func (s *PluginService) recordTimeout(name string, timeout time.Duration) {
+	wasDegraded := false
+	if loaded, err := s.registry.GetLoadedPlugin(name); err == nil {
+		wasDegraded = loaded.Status == entities.PluginStatusDegraded
+	}
+
+	s.registry.IncrementTimeout(name)
+
+	loaded, err := s.registry.GetLoadedPlugin(name)
+	if err != nil || wasDegraded || loaded.Status != entities.PluginStatusDegraded {
+		return
+	}
+	s.logger.Warn(timeoutAdvice(name, loaded.Statistics, timeout),
+		slog.String("plugin", name),
+		slog.Float64("timeout_rate", loaded.Statistics.TimeoutRate()),
+		slog.Duration("timeout", timeout))
+}
+
+// timeoutAdvice explains a plugin's timeouts and what the user can do about them
+func timeoutAdvice(name string, stats entities.PluginStatistics, timeout time.Duration) string {
+	return fmt.Sprintf("plugin %s timed out in %d of %d executions with a %s timeout; increase its timeout or disable it",
+		name, stats.TimeoutCount, stats.ExecutionCount, timeout)
+}
+
+// PluginHealth reports the registry statistics of all loaded plugins, merged
+// with the health each plugin reports about itself.
+func (s *PluginService) PluginHealth() []entities.PluginHealth {
+	loaded := s.registry.ListLoadedPlugins()
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i].Metadata.Name < loaded[j].Metadata.Name })
+
+	health := make([]entities.PluginHealth, 0, len(loaded))
+	for _, p := range loaded {
+		name := p.Metadata.Name
+		timeout := s.pluginTimeout(name)
+		h := entities.PluginHealth{
+			Name:        name,
+			Status:      p.Status,
+			Healthy:     p.IsActive() && p.Status != entities.PluginStatusDegraded,
+			Executions:  p.Statistics.ExecutionCount,
+			Errors:      p.Statistics.ErrorCount,
+			Timeouts:    p.Statistics.TimeoutCount,
+			Panics:      p.Statistics.PanicCount,
+			TimeoutRate: p.Statistics.TimeoutRate(),
+			TimeoutMs:   timeout.Milliseconds(),
+		}
+		switch p.Status {
+		case entities.PluginStatusDegraded:
+			h.Message = timeoutAdvice(name, p.Statistics, timeout)
+		case entities.PluginStatusError:
+			h.Message = p.ErrorMsg
+		}
+
+		if instance, exists := s.registry.Get(name); exists {
+			if checker, ok := instance.(pluginapi.HealthChecker); ok {
+				s.mergeSelfReportedHealth(&h, checker)
+			}
+		}
+		health = append(health, h)
+	}
+	return health
+}
+
+// mergeSelfReportedHealth adds what a plugin reports about its own health,
+// marking it unhealthy if the report says so or the check panics
+func (s *PluginService) mergeSelfReportedHealth(h *entities.PluginHealth, checker pluginapi.HealthChecker) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.Healthy = false
+			h.Message = fmt.Sprintf("plugin %s health check panicked: %v", h.Name, r)
+		}
+	}()
+
+	h.Details = checker.Health()
+	status, _ := h.Details["status"].(string)
+	switch strings.ToLower(status) {
+	case "", "healthy", "ok":
+	default:
+		h.Healthy = false
+		if h.Message == "" {
+			h.Message = fmt.Sprintf("plugin %s reports status %s", h.Name, status)
+		}
+	}
+}
+
 // GetPlugin retrieves a plugin by name.
 func (s *PluginService) GetPlugin(name string) (pluginapi.Plugin, error) {
 	p, exists := s.registry.Get(name)
@@ -424,8 +664,9 @@ func (s *PluginService) processContentConcurrently(ctx context.Context, content
 	// Optimize execution strategy based on content
 	prioritizedJobs := s.concurrentExec.OptimizeForContent(pluginInstances, content)
 
-	// Execute with priority optimization
-	batchResult := s.concurrentExec.ExecuteWithPriority(ctx, prioritizedJobs)
+	// Execute with priority optimization, leaving executor slots for other
+	// slides rendering at the same time
+	batchResult := s.concurrentExec.ExecuteWithPriorityLimit(ctx, prioritizedJobs, s.config.MaxConcurrentCall)
 
 	// Convert results to expected format
 	var outputs []pluginapi.PluginOutput
@@ -650,15 +891,50 @@ func (s *PluginService) GetMemoryStatistics() map[string]interface{} {
 	return stats
 }
 
-// generateCacheKey generates a cache key for a plugin execution.
-func (s *PluginService) generateCacheKey(pluginName string, input pluginapi.PluginInput) string {
-	// Simple key generation - could be improved with hashing
-	key := fmt.Sprintf("%s:%s:%s", pluginName, input.Language, input.Content)
-	if len(key) > 100 {
-		// Truncate long keys
-		key = key[:100]
+// generateCacheKey generates a cache key for a plugin execution
+func (s *PluginService) generateCacheKey(pluginName string, p pluginapi.Plugin, input pluginapi.PluginInput) string {
+	return PluginCacheKey(pluginName, p, input)
+}
+
+// PluginCacheKey returns the key p's output for input is cached under, so
+// other callers sharing a cache hit the same entries. The input is hashed
+// so long content can't collide, and the plugin name is kept as the prefix
+// so a plugin's entries can be invalidated together. Options and metadata
+// are part of the hash since plugins may adapt their output to them, e.g.
+// to the slide or the deck's theme. The slide's position, title and type
+// are left out for plugins declaring themselves SlideIndependent, so the
+// same block on another slide is still a cache hit.
+func PluginCacheKey(pluginName string, p pluginapi.Plugin, input pluginapi.PluginInput) string {
+	metadata := input.Metadata
+	if independent, ok := p.(pluginapi.SlideIndependent); ok && independent.SlideIndependent() {
+		metadata = withoutSlideContext(metadata)
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(input.Language + "\x00" + input.Content + "\x00"))
+	// Maps are encoded with sorted keys, so equal inputs hash the same
+	_ = json.NewEncoder(hash).Encode(input.Options)
+	_ = json.NewEncoder(hash).Encode(metadata)
+	return cacheKeyPrefix(pluginName) + hex.EncodeToString(hash.Sum(nil))
+}
+
+// withoutSlideContext returns metadata without the keys describing the
+// slide a plugin block is on
+func withoutSlideContext(metadata map[string]interface{}) map[string]interface{} {
+	kept := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		switch key {
+		case pluginapi.MetadataSlideIndex, pluginapi.MetadataSlideTitle, pluginapi.MetadataSlideType:
+			continue
+		}
+		kept[key] = value
 	}
-	return key
+	return kept
+}
+
+// cacheKeyPrefix returns the prefix shared by all cache keys of a plugin
+func cacheKeyPrefix(pluginName string) string {
+	return pluginName + ":"
 }
 
 // parseTimeout parses a timeout string from plugin configuration.
@@ -3,9 +3,14 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	concurrentplugin "github.com/fredcamaral/slicli/internal/adapters/secondary/plugin"
 	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/internal/domain/ports"
 	pluginapi "github.com/fredcamaral/slicli/pkg/plugin"
@@ -74,6 +79,7 @@ func (m *MockPluginExecutor) ExecuteAsync(ctx context.Context, p pluginapi.Plugi
 
 type MockPluginRegistry struct {
 	mock.Mock
+	mu      sync.Mutex // Discovery registers plugins concurrently
 	plugins map[string]pluginapi.Plugin
 }
 
@@ -86,7 +92,9 @@ func NewMockPluginRegistry() *MockPluginRegistry {
 func (m *MockPluginRegistry) Register(name string, p pluginapi.Plugin, metadata entities.PluginMetadata) error {
 	args := m.Called(name, p, metadata)
 	if args.Error(0) == nil {
+		m.mu.Lock()
 		m.plugins[name] = p
+		m.mu.Unlock()
 	}
 	return args.Error(0)
 }
@@ -97,7 +105,10 @@ func (m *MockPluginRegistry) Get(name string) (pluginapi.Plugin, bool) {
 		return p.(pluginapi.Plugin), args.Bool(1)
 	}
 	// Also check internal map
-	if p, exists := m.plugins[name]; exists {
+	m.mu.Lock()
+	p, exists := m.plugins[name]
+	m.mu.Unlock()
+	if exists {
 		return p, true
 	}
 	return nil, args.Bool(1)
@@ -122,7 +133,9 @@ func (m *MockPluginRegistry) GetByType(pluginType entities.PluginType) []plugina
 func (m *MockPluginRegistry) Remove(name string) error {
 	args := m.Called(name)
 	if args.Error(0) == nil {
+		m.mu.Lock()
 		delete(m.plugins, name)
+		m.mu.Unlock()
 	}
 	return args.Error(0)
 }
@@ -181,6 +194,8 @@ func (m *MockPluginRegistry) SetPluginStatus(name string, status entities.Plugin
 	return args.Error(0)
 }
 
+func (m *MockPluginRegistry) SetTimeoutThreshold(rate float64, minExecutions int64) {}
+
 type MockPluginCache struct {
 	mock.Mock
 }
@@ -201,6 +216,10 @@ func (m *MockPluginCache) Remove(key string) {
 	m.Called(key)
 }
 
+func (m *MockPluginCache) DeleteByPrefix(prefix string) {
+	m.Called(prefix)
+}
+
 func (m *MockPluginCache) Clear() {
 	m.Called()
 }
@@ -323,7 +342,7 @@ func TestPluginService_UnloadPlugin(t *testing.T) {
 	registry.On("Get", "test").Return(testPlugin, true)
 	registry.On("Remove", "test").Return(nil)
 	loader.On("Unload", ctx, "test").Return(nil)
-	cache.On("Clear").Return()
+	cache.On("DeleteByPrefix", "test:").Return()
 
 	err := service.UnloadPlugin(ctx, "test")
 	require.NoError(t, err)
@@ -333,6 +352,78 @@ func TestPluginService_UnloadPlugin(t *testing.T) {
 	cache.AssertExpectations(t)
 }
 
+func TestPluginService_UnloadPluginKeepsOtherCacheEntries(t *testing.T) {
+	loader := new(MockPluginLoader)
+	registry := NewMockPluginRegistry()
+	cache := concurrentplugin.NewMemoryCache(0)
+	service := NewPluginService(loader, new(MockPluginExecutor), registry, cache, new(MockPluginMatcher), PluginServiceConfig{
+		CacheEnabled: true,
+		CacheTTL:     5 * time.Minute,
+	}, nil)
+	ctx := context.Background()
+
+	registry.On("Get", "a").Return(&TestPlugin{name: "a", version: "1.0.0"}, true)
+	registry.On("Remove", "a").Return(nil)
+	loader.On("Unload", ctx, "a").Return(nil)
+
+	input := pluginapi.PluginInput{Content: "graph TD; A-->B", Language: "mermaid"}
+	keyA := service.generateCacheKey("a", nil, input)
+	keyB := service.generateCacheKey("b", nil, input)
+	cache.Set(keyA, &pluginapi.PluginOutput{HTML: "from a"}, time.Minute)
+	cache.Set(keyB, &pluginapi.PluginOutput{HTML: "from b"}, time.Minute)
+
+	require.NoError(t, service.UnloadPlugin(ctx, "a"))
+
+	_, found := cache.Get(keyA)
+	assert.False(t, found)
+	output, found := cache.Get(keyB)
+	require.True(t, found)
+	assert.Equal(t, "from b", output.HTML)
+}
+
+func TestPluginService_generateCacheKey(t *testing.T) {
+	service, _, _, _, _, _ := createTestService(t)
+
+	long := strings.Repeat("x", 200)
+	keyA := service.generateCacheKey("mermaid", nil, pluginapi.PluginInput{Content: long + "a"})
+	keyB := service.generateCacheKey("mermaid", nil, pluginapi.PluginInput{Content: long + "b"})
+
+	assert.True(t, strings.HasPrefix(keyA, "mermaid:"))
+	assert.NotEqual(t, keyA, keyB, "inputs differing past any length limit must not collide")
+	assert.Equal(t, keyA, service.generateCacheKey("mermaid", nil, pluginapi.PluginInput{Content: long + "a"}))
+	assert.NotEqual(t,
+		service.generateCacheKey("mermaid", nil, pluginapi.PluginInput{Language: "a", Content: "b"}),
+		service.generateCacheKey("mermaid", nil, pluginapi.PluginInput{Language: "ab", Content: ""}))
+
+	// Output may depend on the deck's theme, so it is part of the key
+	dark := pluginapi.PluginInput{Content: "graph", Metadata: map[string]interface{}{pluginapi.MetadataTheme: "dark", pluginapi.MetadataSlideIndex: 1}}
+	light := pluginapi.PluginInput{Content: "graph", Metadata: map[string]interface{}{pluginapi.MetadataTheme: "light", pluginapi.MetadataSlideIndex: 1}}
+	assert.NotEqual(t, service.generateCacheKey("mermaid", nil, dark), service.generateCacheKey("mermaid", nil, light))
+
+	// Output may depend on the slide too, unless the plugin says it doesn't
+	recap := pluginapi.PluginInput{
+		Content: "graph",
+		Metadata: map[string]interface{}{
+			pluginapi.MetadataSlideIndex: 7,
+			pluginapi.MetadataSlideTitle: "Recap",
+			pluginapi.MetadataSlideType:  "section",
+			pluginapi.MetadataTheme:      "dark",
+		},
+	}
+	timer := &TestPlugin{name: "timer"}
+	assert.NotEqual(t, service.generateCacheKey("timer", timer, dark), service.generateCacheKey("timer", timer, recap))
+	mermaid := &slideIndependentPlugin{TestPlugin{name: "mermaid"}}
+	assert.Equal(t, service.generateCacheKey("mermaid", mermaid, dark), service.generateCacheKey("mermaid", mermaid, recap),
+		"the same block on another slide is a cache hit")
+}
+
+// slideIndependentPlugin declares that its output ignores the slide
+type slideIndependentPlugin struct {
+	TestPlugin
+}
+
+func (p *slideIndependentPlugin) SlideIndependent() bool { return true }
+
 func TestPluginService_ExecutePlugin(t *testing.T) {
 	service, _, executor, registry, cache, _ := createTestService(t)
 	ctx := context.Background()
@@ -357,6 +448,237 @@ func TestPluginService_ExecutePlugin(t *testing.T) {
 	cache.AssertExpectations(t)
 }
 
+func TestPluginService_ExecutionTimeout(t *testing.T) {
+	service, _, _, registry, _, _ := createTestService(t)
+	service.config.MaxPluginTimeout = 45 * time.Second
+
+	registry.On("GetMetadata", "slow").Return(&entities.PluginMetadata{
+		Name:   "slow",
+		Config: map[string]string{"timeout": "20s"},
+	}, true)
+	registry.On("GetLoadedPlugin", "slow").Return(&entities.LoadedPlugin{
+		Manifest: &entities.PluginManifest{DefaultConfig: entities.PluginConfig{Timeout: 10 * time.Second}},
+	}, nil)
+	registry.On("GetMetadata", "configured").Return(&entities.PluginMetadata{
+		Name:   "configured",
+		Config: map[string]string{"timeout": "20s"},
+	}, true)
+	registry.On("GetLoadedPlugin", "configured").Return(&entities.LoadedPlugin{}, nil)
+
+	tests := []struct {
+		name    string
+		plugin  string
+		options map[string]interface{}
+		want    time.Duration
+	}{
+		{"slide option wins", "slow", map[string]interface{}{"timeout": "30s"}, 30 * time.Second},
+		{"numeric slide option in seconds", "slow", map[string]interface{}{"timeout": 30}, 30 * time.Second},
+		{"slide option clamped to the max", "slow", map[string]interface{}{"timeout": "5m"}, 45 * time.Second},
+		{"invalid slide option ignored", "slow", map[string]interface{}{"timeout": "soon"}, 10 * time.Second},
+		{"manifest over metadata config", "slow", nil, 10 * time.Second},
+		{"metadata config over default", "configured", nil, 20 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := pluginapi.PluginInput{Content: "x", Options: tt.options}
+			assert.Equal(t, tt.want, service.executionTimeout(tt.plugin, input))
+		})
+	}
+
+	t.Run("max defaults to a minute", func(t *testing.T) {
+		service, _, _, _, _, _ := createTestService(t)
+		assert.Equal(t, time.Minute, service.config.MaxPluginTimeout)
+	})
+}
+
+func TestPluginService_TimeoutsDegradeHealth(t *testing.T) {
+	registry := concurrentplugin.NewInMemoryRegistry()
+	executor := new(MockPluginExecutor)
+	service := NewPluginService(new(MockPluginLoader), executor, registry, nil, nil, PluginServiceConfig{
+		DefaultTimeout:              2 * time.Second,
+		TimeoutWarningRate:          0.5,
+		TimeoutWarningMinExecutions: 3,
+	}, nil)
+	ctx := context.Background()
+
+	testPlugin := &TestPlugin{name: "slow", version: "1.0.0"}
+	require.NoError(t, registry.Register("slow", testPlugin, entities.PluginMetadata{
+		Name: "slow", Version: "1.0.0", Type: entities.PluginTypeProcessor,
+	}))
+	executor.On("ExecuteWithTimeout", ctx, testPlugin, mock.Anything, 2*time.Second).
+		Return(pluginapi.PluginOutput{}, errors.New("plugin execution timeout after 2s"))
+
+	for i := 0; i < 2; i++ {
+		_, err := service.ExecutePlugin(ctx, "slow", pluginapi.PluginInput{Content: "x"})
+		require.Error(t, err)
+	}
+	health := service.PluginHealth()
+	require.Len(t, health, 1)
+	assert.NotEqual(t, entities.PluginStatusDegraded, health[0].Status)
+	assert.Empty(t, health[0].Message)
+
+	_, err := service.ExecutePlugin(ctx, "slow", pluginapi.PluginInput{Content: "x"})
+	require.Error(t, err)
+
+	health = service.PluginHealth()
+	require.Len(t, health, 1)
+	assert.Equal(t, entities.PluginStatusDegraded, health[0].Status)
+	assert.Equal(t, int64(3), health[0].Timeouts)
+	assert.InDelta(t, 1.0, health[0].TimeoutRate, 0.001)
+	assert.Equal(t, int64(2000), health[0].TimeoutMs)
+	assert.Contains(t, health[0].Message, "with a 2s timeout")
+}
+
+// blockingPlugin runs until its context ends, reporting why it ended
+type blockingPlugin struct {
+	TestPlugin
+	started  chan struct{}
+	observed chan error
+}
+
+func (p *blockingPlugin) Execute(ctx context.Context, input pluginapi.PluginInput) (pluginapi.PluginOutput, error) {
+	close(p.started)
+	<-ctx.Done()
+	p.observed <- ctx.Err()
+	return pluginapi.PluginOutput{}, ctx.Err()
+}
+
+func TestPluginService_ExecutePluginCancelled(t *testing.T) {
+	registry := concurrentplugin.NewInMemoryRegistry()
+	service := NewPluginService(new(MockPluginLoader), concurrentplugin.NewSandboxExecutor(5*time.Second, 1), registry, nil, nil, PluginServiceConfig{
+		DefaultTimeout: 5 * time.Second,
+	}, nil)
+
+	slow := &blockingPlugin{
+		TestPlugin: TestPlugin{name: "code-exec", version: "1.0.0"},
+		started:    make(chan struct{}),
+		observed:   make(chan error, 1),
+	}
+	require.NoError(t, registry.Register("code-exec", slow, entities.PluginMetadata{
+		Name: "code-exec", Version: "1.0.0", Type: entities.PluginTypeProcessor,
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 1)
+	go func() {
+		_, err := service.ExecutePlugin(ctx, "code-exec", pluginapi.PluginInput{Content: "sleep 60"})
+		errs <- err
+	}()
+
+	<-slow.started
+	cancel()
+
+	select {
+	case err := <-slow.observed:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(2 * time.Second):
+		t.Fatal("plugin did not observe the cancellation")
+	}
+	assert.ErrorIs(t, <-errs, context.Canceled)
+
+	// The client leaving isn't the plugin's fault
+	health := service.PluginHealth()
+	require.Len(t, health, 1)
+	assert.Zero(t, health[0].Errors)
+	assert.True(t, health[0].Healthy)
+}
+
+// healthCheckedPlugin reports its own health through plugin.HealthChecker
+type healthCheckedPlugin struct {
+	TestPlugin
+	health map[string]interface{}
+}
+
+func (p *healthCheckedPlugin) Health() map[string]interface{} { return p.health }
+
+func TestPluginService_PluginHealthMergesSelfReports(t *testing.T) {
+	registry := concurrentplugin.NewInMemoryRegistry()
+	service := NewPluginService(new(MockPluginLoader), new(MockPluginExecutor), registry, nil, nil, PluginServiceConfig{
+		DefaultTimeout: 5 * time.Second,
+	}, nil)
+
+	plugins := []pluginapi.Plugin{
+		&healthCheckedPlugin{TestPlugin: TestPlugin{name: "healthy"}, health: map[string]interface{}{"status": "healthy", "executors_available": 4}},
+		&healthCheckedPlugin{TestPlugin: TestPlugin{name: "unhealthy"}, health: map[string]interface{}{"status": "unhealthy", "reason": "rustc missing"}},
+		&TestPlugin{name: "plain"},
+	}
+	for _, p := range plugins {
+		require.NoError(t, registry.Register(p.Name(), p, entities.PluginMetadata{
+			Name: p.Name(), Version: "1.0.0", Type: entities.PluginTypeProcessor,
+		}))
+	}
+	registry.UpdateStatistics("healthy", 10*time.Millisecond, true, 1, 1)
+	registry.UpdateStatistics("healthy", 10*time.Millisecond, false, 1, 0)
+	registry.IncrementPanic("plain")
+
+	health := service.PluginHealth()
+	require.Len(t, health, 3)
+
+	assert.Equal(t, "healthy", health[0].Name)
+	assert.True(t, health[0].Healthy)
+	assert.Equal(t, int64(2), health[0].Executions)
+	assert.Equal(t, int64(1), health[0].Errors)
+	assert.Equal(t, 4, health[0].Details["executors_available"])
+
+	assert.Equal(t, "plain", health[1].Name)
+	assert.False(t, health[1].Healthy, "a panic leaves the plugin in the error state")
+	assert.Equal(t, entities.PluginStatusError, health[1].Status)
+	assert.Equal(t, int64(1), health[1].Panics)
+	assert.Nil(t, health[1].Details)
+
+	assert.Equal(t, "unhealthy", health[2].Name)
+	assert.False(t, health[2].Healthy)
+	assert.Equal(t, entities.PluginStatusLoaded, health[2].Status, "the load state comes from the registry")
+	assert.Equal(t, "rustc missing", health[2].Details["reason"])
+	assert.Contains(t, health[2].Message, "reports status unhealthy")
+}
+
+// streamingTestPlugin prints its input line by line through ExecuteStream
+type streamingTestPlugin struct {
+	TestPlugin
+}
+
+func (p *streamingTestPlugin) ExecuteStream(ctx context.Context, input pluginapi.PluginInput, emit func(pluginapi.OutputChunk)) (pluginapi.PluginOutput, error) {
+	for _, line := range strings.SplitAfter(input.Content, "\n") {
+		emit(pluginapi.OutputChunk{Stream: "stdout", Data: line})
+	}
+	return pluginapi.PluginOutput{HTML: "<pre>" + input.Content + "</pre>"}, nil
+}
+
+func TestPluginService_ExecutePluginStream(t *testing.T) {
+	service, _, executor, registry, cache, _ := createTestService(t)
+	ctx := context.Background()
+
+	streaming := &streamingTestPlugin{TestPlugin{name: "stream", version: "1.0.0"}}
+	input := pluginapi.PluginInput{Content: "one\ntwo"}
+	output := pluginapi.PluginOutput{HTML: "<pre>one\ntwo</pre>"}
+
+	registry.On("Get", "stream").Return(streaming, true)
+	registry.On("GetMetadata", "stream").Return((*entities.PluginMetadata)(nil), false)
+	executor.On("ExecuteWithTimeout", ctx, mock.Anything, input, 5*time.Second).
+		Return(output, nil).
+		Run(func(args mock.Arguments) {
+			// The sandbox runs the plugin through its Execute method
+			_, err := args.Get(1).(pluginapi.Plugin).Execute(ctx, input)
+			require.NoError(t, err)
+		})
+	registry.On("UpdateStatistics", "stream", mock.Anything, true, int64(7), int64(18))
+
+	var chunks []pluginapi.OutputChunk
+	result, err := service.ExecutePluginStream(ctx, "stream", input, func(chunk pluginapi.OutputChunk) {
+		chunks = append(chunks, chunk)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, output, result)
+	assert.Equal(t, []pluginapi.OutputChunk{{Stream: "stdout", Data: "one\n"}, {Stream: "stdout", Data: "two"}}, chunks)
+
+	cache.AssertNotCalled(t, "Get", mock.Anything)
+	cache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything)
+	executor.AssertExpectations(t)
+}
+
 func TestPluginService_ExecutePlugin_FromCache(t *testing.T) {
 	service, _, _, registry, cache, _ := createTestService(t)
 	ctx := context.Background()
@@ -396,6 +718,91 @@ func TestPluginService_DiscoverPlugins(t *testing.T) {
 	loader.AssertExpectations(t)
 }
 
+// blockingInitPlugin hangs in Init until release is closed
+type blockingInitPlugin struct {
+	TestPlugin
+	release chan struct{}
+	cleaned atomic.Bool
+}
+
+func (p *blockingInitPlugin) Init(config map[string]interface{}) error {
+	<-p.release
+	return nil
+}
+
+func (p *blockingInitPlugin) Cleanup() error {
+	p.cleaned.Store(true)
+	return nil
+}
+
+func TestPluginService_DiscoverPluginsSkipsHangingInit(t *testing.T) {
+	loader := new(MockPluginLoader)
+	registry := NewMockPluginRegistry()
+	service := NewPluginService(loader, new(MockPluginExecutor), registry, nil, new(MockPluginMatcher), PluginServiceConfig{
+		PluginDirs:           []string{"/plugins"},
+		AutoDiscover:         true,
+		DiscoveryTimeout:     200 * time.Millisecond,
+		DiscoveryParallelism: 2,
+	}, nil)
+	ctx := context.Background()
+
+	hanging := &blockingInitPlugin{TestPlugin: TestPlugin{name: "hanging", version: "1.0.0"}, release: make(chan struct{})}
+	plugins := []pluginapi.PluginInfo{
+		{Name: "hanging", Path: "/plugins/hanging/plugin.so", Compatible: true},
+		{Name: "first", Path: "/plugins/first/plugin.so", Compatible: true},
+		{Name: "second", Path: "/plugins/second/plugin.so", Compatible: true},
+		{Name: "third", Path: "/plugins/third/plugin.so", Compatible: true},
+	}
+	loader.On("Discover", mock.Anything, []string{"/plugins"}).Return(plugins, nil)
+	loader.On("Load", mock.Anything, "/plugins/hanging/plugin.so").Return(hanging, nil)
+	for _, name := range []string{"first", "second", "third"} {
+		loader.On("Load", mock.Anything, "/plugins/"+name+"/plugin.so").Return(&TestPlugin{name: name, version: "1.0.0"}, nil)
+	}
+	loader.On("LoadManifest", mock.Anything, mock.Anything).Return(nil, errors.New("no manifest"))
+	registry.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	start := time.Now()
+	discovered, err := service.DiscoverPlugins(ctx)
+	elapsed := time.Since(start)
+	require.NoError(t, err)
+	assert.Equal(t, plugins, discovered)
+	assert.Less(t, elapsed, time.Second, "discovery waits out the timeout, not the hanging plugin")
+
+	registry.mu.Lock()
+	loaded := make([]string, 0, len(registry.plugins))
+	for name := range registry.plugins {
+		loaded = append(loaded, name)
+	}
+	registry.mu.Unlock()
+	assert.ElementsMatch(t, []string{"first", "second", "third"}, loaded)
+
+	// A plugin whose Init returns after it was skipped is cleaned up, not registered
+	close(hanging.release)
+	assert.Eventually(t, hanging.cleaned.Load, time.Second, 10*time.Millisecond)
+	registry.AssertNotCalled(t, "Register", "hanging", mock.Anything, mock.Anything)
+}
+
+func TestPluginService_LoadPluginCancelledWhileRegistering(t *testing.T) {
+	loader := new(MockPluginLoader)
+	registry := NewMockPluginRegistry()
+	service := NewPluginService(loader, new(MockPluginExecutor), registry, nil, new(MockPluginMatcher), PluginServiceConfig{}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := &blockingInitPlugin{TestPlugin: TestPlugin{name: "late", version: "1.0.0"}, release: make(chan struct{})}
+	close(p.release)
+	loader.On("Load", mock.Anything, "/plugins/late/plugin.so").Return(p, nil)
+	loader.On("LoadManifest", mock.Anything, mock.Anything).Return(nil, errors.New("no manifest"))
+	registry.On("Register", "late", mock.Anything, mock.Anything).Run(func(mock.Arguments) { cancel() }).Return(nil)
+	registry.On("Remove", "late").Return(nil)
+
+	err := service.LoadPlugin(ctx, "/plugins/late/plugin.so")
+	require.ErrorIs(t, err, context.Canceled)
+	registry.AssertCalled(t, "Remove", "late")
+	assert.True(t, p.cleaned.Load())
+	assert.Empty(t, registry.plugins)
+}
+
 func TestPluginService_ProcessContent(t *testing.T) {
 	service, _, executor, registry, cache, matcher := createTestService(t)
 	ctx := context.Background()
@@ -460,6 +867,61 @@ func TestPluginService_ProcessContent(t *testing.T) {
 	})
 }
 
+// countingPlugin records how many of its kind run at once
+type countingPlugin struct {
+	TestPlugin
+	active    *atomic.Int32
+	maxActive *atomic.Int32
+}
+
+func (p *countingPlugin) Execute(ctx context.Context, input pluginapi.PluginInput) (pluginapi.PluginOutput, error) {
+	n := p.active.Add(1)
+	defer p.active.Add(-1)
+	for {
+		peak := p.maxActive.Load()
+		if n <= peak || p.maxActive.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return pluginapi.PluginOutput{HTML: "<div>" + p.name + "</div>"}, nil
+}
+
+func TestPluginService_ProcessContentPerCallLimit(t *testing.T) {
+	registry := NewMockPluginRegistry()
+	matcher := new(MockPluginMatcher)
+	service := NewPluginService(new(MockPluginLoader), new(MockPluginExecutor), registry, new(MockPluginCache), matcher,
+		PluginServiceConfig{MaxConcurrent: 10, MaxConcurrentCall: 3}, nil)
+
+	var active, maxActive atomic.Int32
+	var names []string
+	for i := 0; i < 12; i++ {
+		name := fmt.Sprintf("plugin%d", i)
+		names = append(names, name)
+		registry.On("Get", name).Return(&countingPlugin{
+			TestPlugin: TestPlugin{name: name, version: "1.0.0"},
+			active:     &active,
+			maxActive:  &maxActive,
+		}, true)
+		registry.On("GetLoadedPlugin", name).Return(&entities.LoadedPlugin{
+			Metadata: entities.PluginMetadata{Name: name, Version: "1.0.0"},
+			Status:   entities.PluginStatusLoaded,
+		}, nil)
+	}
+	matcher.On("Match", "many blocks", "markdown", mock.Anything).Return(names)
+
+	outputs, err := service.ProcessContent(context.Background(), "many blocks", "markdown")
+	require.NoError(t, err)
+	assert.Len(t, outputs, 12)
+	assert.LessOrEqual(t, maxActive.Load(), int32(3))
+	assert.Equal(t, int32(0), active.Load())
+}
+
+func TestNewPluginService_PerCallLimitDefault(t *testing.T) {
+	service, _, _, _, _, _ := createTestService(t)
+	assert.Equal(t, 5, service.config.MaxConcurrentCall)
+}
+
 func TestPluginService_Shutdown(t *testing.T) {
 	service, loader, _, registry, cache, _ := createTestService(t)
 	ctx := context.Background()
@@ -477,6 +939,8 @@ func TestPluginService_Shutdown(t *testing.T) {
 	registry.On("Remove", "plugin2").Return(nil)
 	loader.On("Unload", ctx, "plugin1").Return(nil)
 	loader.On("Unload", ctx, "plugin2").Return(nil)
+	cache.On("DeleteByPrefix", "plugin1:")
+	cache.On("DeleteByPrefix", "plugin2:")
 	cache.On("Clear")
 
 	err := service.Shutdown(ctx)
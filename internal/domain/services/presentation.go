@@ -18,6 +18,9 @@ type PresentationService struct {
 	themeRepo ports.ThemeRepository
 	parser    ports.PresentationParser
 	renderer  ports.SlideRenderer
+
+	// includeDrafts keeps slides marked with <!-- draft --> when loading
+	includeDrafts bool
 }
 
 // NewPresentationService creates a new presentation service instance
@@ -35,6 +38,11 @@ func NewPresentationService(
 	}
 }
 
+// SetIncludeDrafts controls whether draft slides are kept when loading presentations
+func (s *PresentationService) SetIncludeDrafts(include bool) {
+	s.includeDrafts = include
+}
+
 // LoadPresentation loads a presentation from a file path
 func (s *PresentationService) LoadPresentation(ctx context.Context, path string) (*entities.Presentation, error) {
 	if path == "" {
@@ -60,11 +68,17 @@ func (s *PresentationService) LoadPresentation(ctx context.Context, path string)
 		return nil, fmt.Errorf("invalid presentation: %w", err)
 	}
 
+	if !s.includeDrafts {
+		presentation.RemoveDrafts()
+	}
+
 	// Set slide titles
 	for i := range presentation.Slides {
 		presentation.Slides[i].Title = presentation.Slides[i].ExtractTitle()
 	}
 
+	s.addTableOfContents(presentation)
+
 	return presentation, nil
 }
 
@@ -84,15 +98,29 @@ func (s *PresentationService) ParsePresentation(ctx context.Context, content []b
 		return nil, fmt.Errorf("invalid presentation: %w", err)
 	}
 
+	if !s.includeDrafts {
+		presentation.RemoveDrafts()
+	}
+
 	// Set slide titles and indices
 	for i := range presentation.Slides {
 		presentation.Slides[i].Index = i
 		presentation.Slides[i].Title = presentation.Slides[i].ExtractTitle()
 	}
 
+	s.addTableOfContents(presentation)
+
 	return presentation, nil
 }
 
+// addTableOfContents inserts a table of contents slide when the
+// presentation's front matter asks for one
+func (s *PresentationService) addTableOfContents(presentation *entities.Presentation) {
+	if WantsTableOfContents(presentation) {
+		InsertTableOfContents(presentation)
+	}
+}
+
 // RenderSlides renders all slides in a presentation
 func (s *PresentationService) RenderSlides(ctx context.Context, presentation *entities.Presentation) ([]ports.RenderedSlide, error) {
 	if presentation == nil {
@@ -19,6 +19,7 @@ type PresentationSyncService struct {
 	ticker       *time.Ticker
 	presentation *entities.Presentation
 	notesService ports.NotesService
+	taskStore    ports.TaskStateStore
 	ctx          context.Context
 	cancel       context.CancelFunc
 }
@@ -33,6 +34,7 @@ func NewPresentationSyncService(presentation *entities.Presentation, notesServic
 			TotalSlides:  len(presentation.Slides),
 			StartTime:    time.Now(),
 			IsPaused:     false,
+			Tasks:        make(entities.TaskStates),
 		},
 		clients:      make(map[string]chan entities.SyncEvent),
 		presentation: presentation,
@@ -50,6 +52,20 @@ func NewPresentationSyncService(presentation *entities.Presentation, notesServic
 	return s
 }
 
+// SetTaskStore persists task-list states in store and restores the states
+// already recorded there
+func (s *PresentationSyncService) SetTaskStore(store ports.TaskStateStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.taskStore = store
+	for slide := range s.presentation.Slides {
+		for task, checked := range store.GetTaskStates(slideID(slide)) {
+			s.state.Tasks.Set(slide, task, checked)
+		}
+	}
+}
+
 // Subscribe adds a client to receive sync events
 func (s *PresentationSyncService) Subscribe(clientID string) <-chan entities.SyncEvent {
 	s.mu.Lock()
@@ -74,8 +90,8 @@ func (s *PresentationSyncService) Unsubscribe(clientID string) {
 
 // Broadcast sends an event to all connected clients
 func (s *PresentationSyncService) Broadcast(event entities.SyncEvent) error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	// Update state based on event
 	if err := s.updateState(event); err != nil {
@@ -107,6 +123,7 @@ func (s *PresentationSyncService) GetState() *entities.PresenterState {
 		notesCopy := *s.state.Notes
 		stateCopy.Notes = &notesCopy
 	}
+	stateCopy.Tasks = s.state.Tasks.Copy()
 
 	// Update elapsed time if not paused
 	if !s.state.IsPaused {
@@ -123,6 +140,8 @@ func (s *PresentationSyncService) updateState(event entities.SyncEvent) error {
 		return s.handleNavigation(event.Data)
 	case "timer":
 		return s.handleTimer(event.Data)
+	case "task":
+		return s.handleTask(event.Data)
 	default:
 		return fmt.Errorf("unknown event type: %s", event.Type)
 	}
@@ -194,13 +213,36 @@ func (s *PresentationSyncService) handleTimer(data map[string]interface{}) error
 	return nil
 }
 
+// handleTask records a task-list item being ticked or unticked
+func (s *PresentationSyncService) handleTask(data map[string]interface{}) error {
+	slide, okSlide := data["slide"].(float64)
+	task, okTask := data["task"].(float64)
+	checked, okChecked := data["checked"].(bool)
+	if !okSlide || !okTask || !okChecked {
+		return errors.New("task event requires slide, task and checked")
+	}
+
+	slideNum, taskNum := int(slide), int(task)
+	if slideNum < 0 || slideNum >= s.state.TotalSlides || taskNum < 0 {
+		return fmt.Errorf("task %d on slide %d is out of range", taskNum, slideNum)
+	}
+
+	if s.taskStore != nil {
+		if err := s.taskStore.SetTaskState(slideID(slideNum), taskNum, checked); err != nil {
+			return fmt.Errorf("persisting task state: %w", err)
+		}
+	}
+	s.state.Tasks.Set(slideNum, taskNum, checked)
+
+	return nil
+}
+
 // updateSlideInfo updates notes and next slide information
 func (s *PresentationSyncService) updateSlideInfo() {
 	if s.state.CurrentSlide >= 0 && s.state.CurrentSlide < len(s.presentation.Slides) {
 		// Get notes for current slide
 		if s.notesService != nil {
-			slideID := fmt.Sprintf("slide-%d", s.state.CurrentSlide)
-			notes, err := s.notesService.GetNotes(slideID)
+			notes, err := s.notesService.GetNotes(slideID(s.state.CurrentSlide))
 			if err == nil && !notes.IsEmpty() {
 				s.state.Notes = notes
 			} else {
@@ -218,6 +260,11 @@ func (s *PresentationSyncService) updateSlideInfo() {
 	}
 }
 
+// slideID is the key notes and task states are stored under for a slide
+func slideID(index int) string {
+	return fmt.Sprintf("slide-%d", index)
+}
+
 // startTimer starts the presentation timer
 func (s *PresentationSyncService) startTimer() {
 	s.ticker = time.NewTicker(1 * time.Second)
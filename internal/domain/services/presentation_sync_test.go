@@ -0,0 +1,98 @@
+package services
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// memoryTaskStore is an in-memory TaskStateStore
+type memoryTaskStore struct {
+	mu    sync.Mutex
+	tasks map[string]map[int]bool
+}
+
+func (m *memoryTaskStore) GetTaskStates(slideID string) map[int]bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	states := make(map[int]bool)
+	for task, checked := range m.tasks[slideID] {
+		states[task] = checked
+	}
+	return states
+}
+
+func (m *memoryTaskStore) SetTaskState(slideID string, task int, checked bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tasks[slideID] == nil {
+		m.tasks[slideID] = make(map[int]bool)
+	}
+	m.tasks[slideID][task] = checked
+	return nil
+}
+
+func taskPresentation() *entities.Presentation {
+	return &entities.Presentation{
+		Title: "Workshop",
+		Slides: []entities.Slide{
+			{Index: 0, Title: "Setup"},
+			{Index: 1, Title: "Exercises"},
+		},
+	}
+}
+
+func TestPresentationSyncService_Tasks(t *testing.T) {
+	store := &memoryTaskStore{tasks: map[string]map[int]bool{"slide-0": {2: true}}}
+
+	syncService := NewPresentationSyncService(taskPresentation(), nil)
+	defer syncService.Stop()
+	syncService.SetTaskStore(store)
+
+	assert.Equal(t, entities.TaskStates{0: {2: true}}, syncService.GetState().Tasks, "stored ticks are restored")
+
+	events := syncService.Subscribe("audience")
+	tick := entities.NewSyncEvent("task", map[string]interface{}{
+		"slide":   float64(1),
+		"task":    float64(0),
+		"checked": true,
+	})
+	require.NoError(t, syncService.Broadcast(tick))
+
+	select {
+	case event := <-events:
+		assert.Equal(t, "task", event.Type)
+		assert.Equal(t, true, event.Data["checked"])
+	default:
+		t.Fatal("task event was not broadcast")
+	}
+
+	assert.True(t, syncService.GetState().Tasks[1][0])
+	assert.Equal(t, map[int]bool{0: true}, store.GetTaskStates("slide-1"), "ticks are persisted")
+
+	// State returned to callers is a copy
+	syncService.GetState().Tasks.Set(1, 0, false)
+	assert.True(t, syncService.GetState().Tasks[1][0])
+
+	t.Run("rejects invalid task events", func(t *testing.T) {
+		for _, data := range []map[string]interface{}{
+			{"slide": float64(5), "task": float64(0), "checked": true},
+			{"slide": float64(0), "task": float64(-1), "checked": true},
+			{"slide": float64(0), "task": float64(0)},
+		} {
+			assert.Error(t, syncService.Broadcast(entities.NewSyncEvent("task", data)))
+		}
+	})
+
+	t.Run("works without a store", func(t *testing.T) {
+		inMemory := NewPresentationSyncService(taskPresentation(), nil)
+		defer inMemory.Stop()
+
+		require.NoError(t, inMemory.Broadcast(tick))
+		assert.True(t, inMemory.GetState().Tasks[1][0])
+	})
+}
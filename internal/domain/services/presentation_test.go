@@ -221,6 +221,38 @@ func TestPresentationService_ParsePresentation(t *testing.T) {
 		parser.AssertExpectations(t)
 	})
 
+	t.Run("drafts", func(t *testing.T) {
+		newPresentation := func() *entities.Presentation {
+			return &entities.Presentation{
+				Title: "Test",
+				Slides: []entities.Slide{
+					{Content: "# Intro"},
+					{Content: "<!-- draft -->\n# WIP", Draft: true},
+					{Content: "# Outro"},
+				},
+			}
+		}
+		content := []byte("# Test")
+
+		parser := new(MockPresentationParser)
+		parser.On("Parse", content).Return(newPresentation(), nil).Once()
+		service := NewPresentationService(nil, nil, parser, nil)
+
+		result, err := service.ParsePresentation(ctx, content)
+		require.NoError(t, err)
+		require.Len(t, result.Slides, 2, "drafts are excluded by default")
+		assert.Equal(t, "Outro", result.Slides[1].Title)
+		assert.Equal(t, 1, result.Slides[1].Index)
+
+		parser.On("Parse", content).Return(newPresentation(), nil).Once()
+		service.SetIncludeDrafts(true)
+
+		result, err = service.ParsePresentation(ctx, content)
+		require.NoError(t, err)
+		require.Len(t, result.Slides, 3)
+		assert.True(t, result.Slides[1].Draft)
+	})
+
 	t.Run("empty content", func(t *testing.T) {
 		service := NewPresentationService(nil, nil, nil, nil)
 		_, err := service.ParsePresentation(ctx, []byte{})
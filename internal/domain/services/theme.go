@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/internal/domain/ports"
@@ -189,7 +190,15 @@ func (s *ThemeService) processThemeAssets(theme *entities.ThemeEngine) error {
 	for path, asset := range theme.Assets {
 		switch asset.ContentType {
 		case "text/css":
-			processed, err := s.processor.ProcessCSS(asset.Content, theme.Config.Variables)
+			content := asset.Content
+			if asset.Dir != "" {
+				resolved, err := s.processor.ResolveImports(content, asset.Dir, filepath.Dir(theme.Path))
+				if err != nil {
+					return fmt.Errorf("resolving imports in CSS %s: %w", path, err)
+				}
+				content = resolved
+			}
+			processed, err := s.processor.ProcessCSS(content, theme.Config.Variables)
 			if err != nil {
 				return fmt.Errorf("processing CSS %s: %w", path, err)
 			}
@@ -96,6 +96,14 @@ func (m *MockAssetProcessor) ProcessCSS(content []byte, variables map[string]str
 	return nil, args.Error(1)
 }
 
+func (m *MockAssetProcessor) ResolveImports(content []byte, dir, root string) ([]byte, error) {
+	args := m.Called(content, dir, root)
+	if result := args.Get(0); result != nil {
+		return result.([]byte), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 func (m *MockAssetProcessor) ProcessJS(content []byte, variables map[string]string) ([]byte, error) {
 	args := m.Called(content, variables)
 	if result := args.Get(0); result != nil {
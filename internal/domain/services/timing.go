@@ -0,0 +1,76 @@
+package services
+
+import (
+	"html"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// htmlTagPattern matches an HTML tag or comment in speaker notes
+var htmlTagPattern = regexp.MustCompile(`<!--[\s\S]*?-->|<[^>]*>`)
+
+// SlideTiming is the estimated speaking time of one slide's notes
+type SlideTiming struct {
+	// Index is the slide position in the presentation (0-based)
+	Index int `json:"index"`
+
+	// Words is the number of words in the slide's notes
+	Words int `json:"words"`
+
+	// Seconds is how long reading the notes aloud takes
+	Seconds int `json:"seconds"`
+}
+
+// NotesTiming is the estimated speaking time of a presentation
+type NotesTiming struct {
+	// WordsPerMinute is the speaking pace the estimates assume
+	WordsPerMinute int `json:"words_per_minute"`
+
+	Slides       []SlideTiming `json:"slides"`
+	TotalWords   int           `json:"total_words"`
+	TotalSeconds int           `json:"total_seconds"`
+}
+
+// BuildNotesTiming estimates how long each slide takes to present from the
+// words in its speaker notes at wpm words per minute (the default pace when
+// not positive). The total is computed from the deck's word count so
+// per-slide rounding doesn't add up.
+func BuildNotesTiming(p *entities.Presentation, wpm int) NotesTiming {
+	if wpm <= 0 {
+		wpm = entities.DefaultSpeakingWPM
+	}
+
+	timing := NotesTiming{WordsPerMinute: wpm, Slides: []SlideTiming{}}
+	if p == nil {
+		return timing
+	}
+
+	for i := range p.Slides {
+		words := CountNotesWords(p.Slides[i].Notes)
+		timing.Slides = append(timing.Slides, SlideTiming{
+			Index:   i,
+			Words:   words,
+			Seconds: speakingSeconds(words, wpm),
+		})
+		timing.TotalWords += words
+	}
+	timing.TotalSeconds = speakingSeconds(timing.TotalWords, wpm)
+	return timing
+}
+
+// CountNotesWords counts the words of speaker notes, ignoring HTML tags
+// and comments and decoding entities
+func CountNotesWords(notes string) int {
+	// Tags become spaces so adjacent block elements don't merge their words
+	text := htmlTagPattern.ReplaceAllString(notes, " ")
+	return len(strings.Fields(html.UnescapeString(text)))
+}
+
+// speakingSeconds returns how long saying words takes at wpm, rounded to
+// the nearest second
+func speakingSeconds(words, wpm int) int {
+	return int(math.Round(float64(words) * 60 / float64(wpm)))
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestBuildNotesTiming(t *testing.T) {
+	presentation := &entities.Presentation{
+		Slides: []entities.Slide{
+			{Index: 0, Notes: strings.Repeat("word ", 130)},
+			{Index: 1, Notes: strings.Repeat("word ", 65)},
+			{Index: 2},
+			{Index: 3, Notes: "one two three"},
+		},
+	}
+
+	timing := BuildNotesTiming(presentation, 0)
+	assert.Equal(t, entities.DefaultSpeakingWPM, timing.WordsPerMinute)
+	assert.Equal(t, []SlideTiming{
+		{Index: 0, Words: 130, Seconds: 60},
+		{Index: 1, Words: 65, Seconds: 30},
+		{Index: 2, Words: 0, Seconds: 0},
+		{Index: 3, Words: 3, Seconds: 1},
+	}, timing.Slides)
+	assert.Equal(t, 198, timing.TotalWords)
+	assert.Equal(t, 91, timing.TotalSeconds, "198 words at 130 wpm is 91.4s")
+
+	timing = BuildNotesTiming(presentation, 200)
+	assert.Equal(t, 200, timing.WordsPerMinute)
+	assert.Equal(t, 39, timing.Slides[0].Seconds)
+	assert.Equal(t, 59, timing.TotalSeconds)
+
+	empty := BuildNotesTiming(nil, 150)
+	assert.Equal(t, NotesTiming{WordsPerMinute: 150, Slides: []SlideTiming{}}, empty)
+}
+
+func TestCountNotesWords(t *testing.T) {
+	tests := []struct {
+		name  string
+		notes string
+		want  int
+	}{
+		{"plain text", "Remember to breathe", 3},
+		{"tags don't count", `<p>Remember <strong class="x">to</strong> <a href="https://example.com/a b c">breathe</a></p>`, 3},
+		{"adjacent blocks stay apart", "<p>First</p><p>Second</p>", 2},
+		{"comments are dropped", "<!-- hidden words here --> Visible", 1},
+		{"entities decode", "Q&amp;A&nbsp;time", 2},
+		{"empty", "  <br/>  ", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CountNotesWords(tt.notes))
+		})
+	}
+}
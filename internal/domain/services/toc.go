@@ -0,0 +1,141 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// tocMetadataKey marks a generated table of contents slide in its metadata
+// and enables one in a presentation's front matter
+const tocMetadataKey = "toc"
+
+// tocTitle is the heading of a generated table of contents slide
+const tocTitle = "Contents"
+
+// atxClosingSequence matches the optional closing #s of an ATX heading
+var atxClosingSequence = regexp.MustCompile(`\s+#+\s*$`)
+
+// TOCEntry is one slide listed in a presentation's table of contents
+type TOCEntry struct {
+	// Index is the slide position in the presentation (0-based)
+	Index int `json:"index"`
+
+	// Title is the slide's first H1 or H2, or "Slide N" without one
+	Title string `json:"title"`
+
+	// Level is the heading level the title came from, 0 for "Slide N"
+	Level int `json:"level"`
+}
+
+// BuildTableOfContents lists the slides of a presentation with the first
+// H1 or H2 of each, skipping a generated table of contents slide
+func BuildTableOfContents(p *entities.Presentation) []TOCEntry {
+	if p == nil {
+		return nil
+	}
+
+	entries := make([]TOCEntry, 0, len(p.Slides))
+	for i := range p.Slides {
+		slide := &p.Slides[i]
+		if isTOCSlide(slide) {
+			continue
+		}
+
+		entry := TOCEntry{Index: i, Title: "Slide " + strconv.Itoa(i+1)}
+		if title, level := slideHeading(slide.Content); title != "" {
+			entry.Title, entry.Level = title, level
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// WantsTableOfContents reports whether a presentation's front matter asks
+// for a table of contents slide with toc: true
+func WantsTableOfContents(p *entities.Presentation) bool {
+	if p == nil {
+		return false
+	}
+	enabled, _ := p.Metadata[tocMetadataKey].(bool)
+	return enabled
+}
+
+// InsertTableOfContents adds a slide listing the others at position 1,
+// after the title slide, and renumbers the slides after it. A presentation
+// that already has one is left alone.
+func InsertTableOfContents(p *entities.Presentation) {
+	if p == nil || len(p.Slides) == 0 {
+		return
+	}
+	for i := range p.Slides {
+		if isTOCSlide(&p.Slides[i]) {
+			return
+		}
+	}
+
+	// The slides after the title slide move down to make room, before the
+	// entries are listed so they're numbered as shown
+	slides := make([]entities.Slide, 0, len(p.Slides)+1)
+	slides = append(slides, p.Slides[0], entities.Slide{Metadata: map[string]interface{}{tocMetadataKey: true}})
+	slides = append(slides, p.Slides[1:]...)
+	for i := range slides {
+		slides[i].Index = i
+	}
+	p.Slides = slides
+	entries := BuildTableOfContents(p)
+
+	var content, body strings.Builder
+	content.WriteString("# " + tocTitle + "\n\n")
+	body.WriteString("<h1>" + tocTitle + "</h1>\n<ol class=\"toc\">\n")
+	for _, entry := range entries {
+		fmt.Fprintf(&content, "1. %s\n", entry.Title)
+		fmt.Fprintf(&body, "<li data-index=\"%d\">%s</li>\n", entry.Index, html.EscapeString(entry.Title))
+	}
+	body.WriteString("</ol>")
+
+	toc := &p.Slides[1]
+	toc.Title = tocTitle
+	toc.Content = content.String()
+	toc.HTML = body.String()
+}
+
+// isTOCSlide reports whether a slide was generated by InsertTableOfContents
+func isTOCSlide(slide *entities.Slide) bool {
+	generated, _ := slide.Metadata[tocMetadataKey].(bool)
+	return generated
+}
+
+// slideHeading returns the first H1 or H2 of a slide's markdown and its
+// level, ignoring lines inside fenced code blocks
+func slideHeading(content string) (string, int) {
+	var fence string
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		for level, marker := range []string{"# ", "## "} {
+			if strings.HasPrefix(trimmed, marker) {
+				title := strings.TrimSpace(atxClosingSequence.ReplaceAllString(strings.TrimPrefix(trimmed, marker), ""))
+				if title != "" {
+					return title, level + 1
+				}
+			}
+		}
+	}
+	return "", 0
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestBuildTableOfContents(t *testing.T) {
+	presentation := &entities.Presentation{
+		Title: "Talk",
+		Slides: []entities.Slide{
+			{Index: 0, Content: "# Welcome #\n\nIntro"},
+			{Index: 1, Content: "Some text\n\n## Agenda\n\n# Later heading"},
+			{Index: 2, Content: "```bash\n# not a heading\n```\n\n## Setup"},
+			{Index: 3, Content: "Just a picture\n\n![diagram](d.png)"},
+			{Index: 4, Content: "### Too deep"},
+		},
+	}
+
+	entries := BuildTableOfContents(presentation)
+	assert.Equal(t, []TOCEntry{
+		{Index: 0, Title: "Welcome", Level: 1},
+		{Index: 1, Title: "Agenda", Level: 2},
+		{Index: 2, Title: "Setup", Level: 2},
+		{Index: 3, Title: "Slide 4", Level: 0},
+		{Index: 4, Title: "Slide 5", Level: 0},
+	}, entries)
+
+	assert.Nil(t, BuildTableOfContents(nil))
+}
+
+func TestInsertTableOfContents(t *testing.T) {
+	presentation := &entities.Presentation{
+		Title:    "Talk",
+		Metadata: map[string]interface{}{"toc": true},
+		Slides: []entities.Slide{
+			{Index: 0, Content: "# Welcome"},
+			{Index: 1, Content: "# Go <3"},
+			{Index: 2, Content: "No heading"},
+		},
+	}
+	require.True(t, WantsTableOfContents(presentation))
+
+	InsertTableOfContents(presentation)
+	require.Len(t, presentation.Slides, 4)
+
+	toc := presentation.Slides[1]
+	assert.Equal(t, 1, toc.Index)
+	assert.Equal(t, "Contents", toc.Title)
+	assert.Equal(t, "# Contents\n\n1. Welcome\n1. Go <3\n1. Slide 4\n", toc.Content)
+	assert.Contains(t, toc.HTML, `<li data-index="0">Welcome</li>`)
+	assert.Contains(t, toc.HTML, `<li data-index="2">Go &lt;3</li>`)
+	assert.Contains(t, toc.HTML, `<li data-index="3">Slide 4</li>`)
+
+	for i, slide := range presentation.Slides {
+		assert.Equal(t, i, slide.Index)
+	}
+
+	// The generated slide isn't listed, and isn't added twice
+	entries := BuildTableOfContents(presentation)
+	assert.Equal(t, []TOCEntry{
+		{Index: 0, Title: "Welcome", Level: 1},
+		{Index: 2, Title: "Go <3", Level: 1},
+		{Index: 3, Title: "Slide 4", Level: 0},
+	}, entries)
+
+	InsertTableOfContents(presentation)
+	assert.Len(t, presentation.Slides, 4)
+
+	assert.False(t, WantsTableOfContents(&entities.Presentation{}))
+}
@@ -61,6 +61,12 @@ func (b *PluginMetadataBuilder) WithType(pluginType entities.PluginType) *Plugin
 	return b
 }
 
+// WithPriority sets the plugin execution priority
+func (b *PluginMetadataBuilder) WithPriority(priority entities.PluginPriority) *PluginMetadataBuilder {
+	b.metadata.Priority = priority
+	return b
+}
+
 // WithTags sets the plugin tags
 func (b *PluginMetadataBuilder) WithTags(tags []string) *PluginMetadataBuilder {
 	b.metadata.Tags = tags
@@ -93,6 +99,7 @@ func (b *PluginMetadataBuilder) Build() entities.PluginMetadata {
 		Homepage:    b.metadata.Homepage,
 		Type:        b.metadata.Type,
 		Tags:        append([]string{}, b.metadata.Tags...),
+		Priority:    b.metadata.Priority,
 		Config:      copyConfig(b.metadata.Config),
 	}
 }
@@ -32,6 +32,42 @@ type Plugin interface {
 	Cleanup() error
 }
 
+// HealthChecker is an optional interface for plugins that can report their
+// own health, such as whether the tools they run are installed.
+type HealthChecker interface {
+	// Health returns the plugin's health details. A "status" entry other
+	// than "healthy" or "ok" marks the plugin unhealthy.
+	Health() map[string]interface{}
+}
+
+// StreamingPlugin is an optional interface for plugins whose output builds
+// up over time, such as a program printing progress, so callers can show it
+// as it arrives.
+type StreamingPlugin interface {
+	// ExecuteStream works like Execute, also passing output to emit as it
+	// is produced. Calls to emit don't overlap and stop before ExecuteStream
+	// returns the complete output.
+	ExecuteStream(ctx context.Context, input PluginInput, emit func(OutputChunk)) (PluginOutput, error)
+}
+
+// SlideIndependent is an optional interface for plugins whose output never
+// depends on the slide a block is on, so the same block on another slide can
+// reuse a cached output.
+type SlideIndependent interface {
+	// SlideIndependent reports whether the plugin ignores the slide keys
+	// of PluginInput.Metadata.
+	SlideIndependent() bool
+}
+
+// OutputChunk is a piece of output a StreamingPlugin produced.
+type OutputChunk struct {
+	// Stream names where the output came from, such as "stdout" or "stderr".
+	Stream string `json:"stream"`
+
+	// Data is the output itself.
+	Data string `json:"data"`
+}
+
 // PluginInput contains the data passed to a plugin for processing.
 type PluginInput struct {
 	// Content is the raw content to process (e.g., Mermaid diagram source).
@@ -44,9 +80,40 @@ type PluginInput struct {
 	Options map[string]interface{}
 
 	// Metadata contains additional context from the presentation system.
+	// Blocks rendered as part of a slide carry the Metadata* keys below.
 	Metadata map[string]interface{}
 }
 
+// Standard PluginInput.Metadata keys describing the slide a block is on.
+// Keys whose value is unknown, such as the type of a slide without a layout comment, are omitted.
+// Outputs are cached per slide unless the plugin implements SlideIndependent, in which case
+// the same block on another slide of a deck with the same theme reuses the output of the first.
+const (
+	// MetadataSlideIndex is the slide's 0-based position in the deck (int)
+	MetadataSlideIndex = "slide_index"
+
+	// MetadataSlideTitle is the slide's title: its first H1 heading, or
+	// "Slide N" when it has none (string)
+	MetadataSlideTitle = "slide_title"
+
+	// MetadataSlideType is the layout type declared with a <!-- layout: type -->
+	// comment, e.g. "title" or "section" (string)
+	MetadataSlideType = "slide_type"
+
+	// MetadataTheme is the name of the deck's theme (string)
+	MetadataTheme = "theme"
+
+	// MetadataPresentationDir is the absolute directory of the presentation's
+	// source file, which relative paths in a block are resolved against.
+	// Omitted when the deck wasn't read from a file (string)
+	MetadataPresentationDir = "presentation_dir"
+
+	// MetadataExport is true when the deck is rendered for an export rather
+	// than shown, so plugins can reuse what they last showed instead of
+	// fetching it again. Omitted otherwise (bool)
+	MetadataExport = "export"
+)
+
 // PluginOutput contains the result of plugin processing.
 type PluginOutput struct {
 	// HTML is the rendered HTML output to be inserted in the slide.
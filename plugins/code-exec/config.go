@@ -2,19 +2,23 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/fredcamaral/slicli/internal/domain/entities"
 )
 
-// extractConfig extracts and validates execution configuration from plugin options
-func (p *CodeExecPlugin) extractConfig(options map[string]interface{}) entities.ExecutionConfig {
+// extractConfig extracts and validates execution configuration for a block
+// of language from plugin options
+func (p *CodeExecPlugin) extractConfig(language string, options map[string]interface{}) entities.ExecutionConfig {
 	config := entities.GetDefaultExecutionConfig()
+	config.Language = language
 
-	// Extract language from options
-	if lang, ok := options["language"].(string); ok {
-		config.Language = lang
-	}
+	// Start from the limits configured for the language, which the block's
+	// own options override
+	p.mu.RLock()
+	p.applyLanguageConfig(&config, language)
+	p.mu.RUnlock()
 
 	// Extract timeout
 	if timeout, ok := options["timeout"].(string); ok {
@@ -48,9 +52,20 @@ func (p *CodeExecPlugin) extractConfig(options map[string]interface{}) entities.
 		config.TrustedMode = trusted
 	}
 
-	// Extract network permission
+	// Extract network isolation
+	if network, ok := options["network"].(string); ok {
+		config.Network = network
+	}
+
+	// Extract network permission, the older spelling of network, which can
+	// take the network away from a block that also sets network
 	if allowNet, ok := options["allow_network"].(bool); ok {
 		config.AllowNetwork = allowNet
+		if allowNet {
+			config.Network = strictestNetwork(config.Network, entities.NetworkAllow)
+		} else {
+			config.Network = strictestNetwork(config.Network, entities.NetworkNone)
+		}
 	}
 
 	// Extract file write permission
@@ -73,6 +88,14 @@ func (p *CodeExecPlugin) extractConfig(options map[string]interface{}) entities.
 		}
 	}
 
+	// Extract the environment allow and deny lists
+	if allowlist, ok := stringList(options["env_allowlist"]); ok {
+		config.EnvAllowlist = allowlist
+	}
+	if denylist, ok := stringList(options["env_denylist"]); ok {
+		config.EnvDenylist = denylist
+	}
+
 	// Apply global plugin configuration overrides
 	p.applyGlobalConfig(&config)
 
@@ -110,12 +133,108 @@ func (p *CodeExecPlugin) applyGlobalConfig(config *entities.ExecutionConfig) {
 		}
 	}
 
+	// A code block can narrow the global allowlist, or the default one when
+	// none is set, but not extend it
+	globalAllowlist, ok := stringList(p.config["env_allowlist"])
+	if !ok {
+		globalAllowlist = defaultEnvAllowlist
+	}
+	if len(config.EnvAllowlist) == 0 {
+		if ok {
+			config.EnvAllowlist = globalAllowlist
+		}
+	} else {
+		config.EnvAllowlist = intersectNames(config.EnvAllowlist, globalAllowlist)
+	}
+
+	// Variables denied globally stay denied whatever a code block allows
+	if globalDenylist, ok := stringList(p.config["env_denylist"]); ok {
+		config.EnvDenylist = append(config.EnvDenylist, globalDenylist...)
+	}
+
+	// The global network setting is a ceiling: code blocks without their
+	// own get it, and a block can disable the network but not re-enable it
+	if globalNetwork, ok := p.config["network"].(string); ok {
+		config.Network = strictestNetwork(config.Network, globalNetwork)
+	}
+	if config.Network == entities.NetworkNone {
+		config.AllowNetwork = false
+	}
+
 	// Disable execution entirely if configured
 	if disabled, ok := p.config["execution_disabled"].(bool); ok && disabled {
 		config.TrustedMode = false
 	}
 }
 
+// intersectNames returns the names in list that are also in allowed. The
+// result is non-nil even when empty, so it allows no variables rather than
+// falling back to the default allowlist.
+func intersectNames(list, allowed []string) []string {
+	names := []string{}
+	for _, name := range list {
+		if containsName(allowed, strings.TrimSpace(name)) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// strictestNetwork combines two network settings so that either can take the
+// network away but neither can grant it back. An invalid setting is kept so
+// validation still rejects it.
+func strictestNetwork(a, b string) string {
+	switch {
+	case a == entities.NetworkNone || b == entities.NetworkNone:
+		return entities.NetworkNone
+	case a == "" || (a == entities.NetworkAllow && b != ""):
+		return b
+	default:
+		return a
+	}
+}
+
+// applyLanguageConfig applies the timeout and memory limit configured for a
+// language under "languages", which Init has already validated. The caller
+// must hold p.mu.
+func (p *CodeExecPlugin) applyLanguageConfig(config *entities.ExecutionConfig, language string) {
+	langConfigs, _ := p.config["languages"].(map[string]interface{})
+	langConfig, ok := langConfigs[language].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	if timeout, ok := langConfig["timeout"].(string); ok {
+		if duration, err := time.ParseDuration(timeout); err == nil {
+			config.Timeout = duration
+		}
+	}
+
+	if memLimit, ok := langConfig["memory_limit"].(string); ok {
+		if size, err := parseSize(memLimit); err == nil {
+			config.MaxMemory = int64(size)
+		}
+	}
+}
+
+// stringList converts a list option, decoded from TOML or JSON, to strings
+func stringList(value interface{}) ([]string, bool) {
+	switch list := value.(type) {
+	case []string:
+		return list, true
+	case []interface{}:
+		var strs []string
+		for _, item := range list {
+			if str, ok := item.(string); ok {
+				strs = append(strs, str)
+			}
+		}
+		return strs, true
+	default:
+		return nil, false
+	}
+}
+
 // parseSize parses size strings like "100MB", "1GB", "512KB"
 func parseSize(sizeStr string) (int, error) {
 	// Simple size parser - in production you might want to use a more robust one
@@ -0,0 +1,67 @@
+package executors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// PHPExecutor executes PHP code
+type PHPExecutor struct{}
+
+// Name returns the executor name
+func (e *PHPExecutor) Name() string {
+	return "php"
+}
+
+// IsAvailable checks if the PHP CLI is available
+func (e *PHPExecutor) IsAvailable() bool {
+	_, err := exec.LookPath("php")
+	return err == nil
+}
+
+// GetDefaultConfig returns default configuration for PHP execution
+func (e *PHPExecutor) GetDefaultConfig() entities.ExecutionConfig {
+	config := entities.GetDefaultExecutionConfig()
+	config.Language = "php"
+	return config
+}
+
+// Prepare sets up PHP code execution
+func (e *PHPExecutor) Prepare(ctx context.Context, code string, config entities.ExecutionConfig) (*exec.Cmd, func(), error) {
+	// Create temporary file for PHP code
+	tmpFile, err := os.CreateTemp("", "slicli-php-*.php")
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating temp file: %w", err)
+	}
+
+	// Write PHP code to file
+	if _, err := tmpFile.WriteString(e.preparePHPCode(code)); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpFile.Name())
+		return nil, nil, fmt.Errorf("writing PHP code: %w", err)
+	}
+	_ = tmpFile.Close()
+
+	// Run without php.ini so local extensions and settings don't apply
+	cmd := exec.CommandContext(ctx, "php", "-n", "-f", tmpFile.Name()) // #nosec G204 - php executable is hardcoded and file path is controlled
+
+	// Setup cleanup function
+	cleanup := func() {
+		_ = os.Remove(tmpFile.Name())
+	}
+
+	return cmd, cleanup, nil
+}
+
+// preparePHPCode opens a PHP block for snippets that don't start with one
+func (e *PHPExecutor) preparePHPCode(code string) string {
+	if strings.HasPrefix(strings.TrimSpace(code), "<?") {
+		return code
+	}
+	return "<?php\n" + code + "\n"
+}
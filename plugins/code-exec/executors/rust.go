@@ -0,0 +1,98 @@
+package executors
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+// RustExecutor compiles and executes Rust code
+type RustExecutor struct{}
+
+// Name returns the executor name
+func (e *RustExecutor) Name() string {
+	return "rust"
+}
+
+// IsAvailable checks if the Rust compiler is available
+func (e *RustExecutor) IsAvailable() bool {
+	_, err := exec.LookPath("rustc")
+	return err == nil
+}
+
+// GetDefaultConfig returns default configuration for Rust execution
+func (e *RustExecutor) GetDefaultConfig() entities.ExecutionConfig {
+	config := entities.GetDefaultExecutionConfig()
+	config.Language = "rust"
+	return config
+}
+
+// Prepare compiles Rust code in a private temporary directory and returns
+// the command running the compiled binary
+func (e *RustExecutor) Prepare(ctx context.Context, code string, config entities.ExecutionConfig) (*exec.Cmd, func(), error) {
+	// Keep the source and binary in a directory only we can access
+	dir, err := os.MkdirTemp("", "slicli-rust-*")
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating temp dir: %w", err)
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	// Write Rust program to file
+	source := filepath.Join(dir, "main.rs")
+	if err := os.WriteFile(source, []byte(e.buildProgram(code)), 0600); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("writing Rust code: %w", err)
+	}
+
+	binary := filepath.Join(dir, "main")
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+
+	// Compile the program
+	var stderr bytes.Buffer
+	compile := exec.CommandContext(ctx, "rustc", "--edition", "2021", "-o", binary, source) // #nosec G204 - rustc executable is hardcoded and paths are controlled
+	compile.Dir = dir
+	compile.Stderr = &stderr
+	if err := compile.Run(); err != nil {
+		cleanup()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, nil, errors.New("compiling Rust code: timed out")
+		}
+		if output := strings.TrimSpace(stderr.String()); output != "" {
+			return nil, nil, fmt.Errorf("compiling Rust code: %s", output)
+		}
+		return nil, nil, fmt.Errorf("compiling Rust code: %w", err)
+	}
+
+	// Create execution command for the compiled binary
+	cmd := exec.CommandContext(ctx, binary) // #nosec G204 - binary was just compiled into our temp dir
+	cmd.Dir = dir
+
+	return cmd, cleanup, nil
+}
+
+// buildProgram wraps snippets without a main function in one
+func (e *RustExecutor) buildProgram(code string) string {
+	if strings.Contains(code, "fn main(") {
+		return code
+	}
+
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = "    " + line
+		}
+	}
+	return "fn main() {\n" + strings.Join(lines, "\n") + "\n}\n"
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"sync"
 	"time"
@@ -44,6 +45,8 @@ func (p *CodeExecPlugin) registerExecutors() {
 		&executors.PythonExecutor{},
 		&executors.JavaScriptExecutor{},
 		&executors.BashExecutor{},
+		&executors.PHPExecutor{},
+		&executors.RustExecutor{},
 	}
 
 	for _, executor := range executorList {
@@ -86,9 +89,22 @@ func (p *CodeExecPlugin) Init(config map[string]interface{}) error {
 
 // Execute processes the input and returns the output
 func (p *CodeExecPlugin) Execute(ctx context.Context, input plugin.PluginInput) (plugin.PluginOutput, error) {
+	return p.execute(context.Background(), input, nil)
+}
+
+// ExecuteStream runs the snippet like Execute, also passing its stdout and
+// stderr to emit as the program writes them. Output still stops at the
+// size limit, and the run is cut short when ctx is cancelled, such as when
+// the viewer disconnects.
+func (p *CodeExecPlugin) ExecuteStream(ctx context.Context, input plugin.PluginInput, emit func(plugin.OutputChunk)) (plugin.PluginOutput, error) {
+	return p.execute(ctx, input, emit)
+}
+
+// execute runs a snippet until it exits, times out or ctx is done, streaming
+// its output to emit when set
+func (p *CodeExecPlugin) execute(ctx context.Context, input plugin.PluginInput, emit func(plugin.OutputChunk)) (plugin.PluginOutput, error) {
 	// Extract execution configuration from input options
-	config := p.extractConfig(input.Options)
-	config.Language = input.Language
+	config := p.extractConfig(input.Language, input.Options)
 
 	// Check if execution is disabled
 	p.mu.RLock()
@@ -124,7 +140,7 @@ func (p *CodeExecPlugin) Execute(ctx context.Context, input plugin.PluginInput)
 	}
 
 	// Execute code with safety measures
-	result, err := p.executeCode(executor, input.Content, config)
+	result, err := p.runCode(ctx, executor, input.Content, config, emit)
 	if err != nil {
 		return plugin.PluginOutput{
 			HTML: fmt.Sprintf(`<div class="code-execution-error">
@@ -152,6 +168,10 @@ func (p *CodeExecPlugin) Execute(ctx context.Context, input plugin.PluginInput)
 			"truncated":   result.Truncated,
 			"output_size": len(result.Output),
 			"error_size":  len(result.ErrorOutput),
+
+			"dropped_bytes":       result.DroppedBytes,
+			"dropped_error_bytes": result.DroppedErrorBytes,
+			"max_output_size":     config.MaxOutputSize,
 		},
 	}, nil
 }
@@ -192,24 +212,20 @@ func (p *CodeExecPlugin) generateHTML(code string, result *entities.ExecutionRes
 
 	// Add output if present
 	if result.Output != "" {
-		truncated := ""
-		if result.Truncated {
-			truncated = " (truncated)"
-		}
 		html += fmt.Sprintf(`
 	<div class="code-execution-output">
 		<h4>Output%s:</h4>
 		<pre><code>%s</code></pre>
-	</div>`, truncated, result.Output)
+	</div>`, truncationNote(len(result.Output), result.DroppedBytes), result.Output)
 	}
 
 	// Add error output if present
 	if result.ErrorOutput != "" {
 		html += fmt.Sprintf(`
 	<div class="code-execution-error-output">
-		<h4>Error Output:</h4>
+		<h4>Error Output%s:</h4>
 		<pre><code>%s</code></pre>
-	</div>`, result.ErrorOutput)
+	</div>`, truncationNote(len(result.ErrorOutput), result.DroppedErrorBytes), result.ErrorOutput)
 	}
 
 	html += `</div>`
@@ -217,6 +233,14 @@ func (p *CodeExecPlugin) generateHTML(code string, result *entities.ExecutionRes
 	return html
 }
 
+// truncationNote describes how much of a stream is shown when some was dropped
+func truncationNote(kept, dropped int) string {
+	if dropped == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (truncated, showing %d of %d bytes)", kept, kept+dropped)
+}
+
 // Cleanup releases any resources held by the plugin
 func (p *CodeExecPlugin) Cleanup() error {
 	p.mu.Lock()
@@ -252,6 +276,13 @@ func (p *CodeExecPlugin) validateSafetyConfig(config map[string]interface{}) err
 		}
 	}
 
+	// Validate the default network setting
+	if network, ok := config["network"].(string); ok {
+		if err := (entities.ExecutionConfig{Network: network}).ValidateNetwork(); err != nil {
+			return fmt.Errorf("invalid network: %w", err)
+		}
+	}
+
 	// Validate language-specific configurations
 	if langConfigs, ok := config["languages"].(map[string]interface{}); ok {
 		for lang, langConfig := range langConfigs {
@@ -292,8 +323,14 @@ func (p *CodeExecPlugin) validateLanguageConfig(language string, config map[stri
 
 // executeCode executes code using the specified executor with safety measures
 func (p *CodeExecPlugin) executeCode(executor entities.Executor, code string, config entities.ExecutionConfig) (*entities.ExecutionResult, error) {
+	return p.runCode(context.Background(), executor, code, config, nil)
+}
+
+// runCode executes code within the configured timeout of parent, passing the
+// output kept within the size limit to emit as it's written when set
+func (p *CodeExecPlugin) runCode(parent context.Context, executor entities.Executor, code string, config entities.ExecutionConfig, emit func(plugin.OutputChunk)) (*entities.ExecutionResult, error) {
 	// Create execution context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	ctx, cancel := context.WithTimeout(parent, config.Timeout)
 	defer cancel()
 
 	// Record start time
@@ -309,15 +346,26 @@ func (p *CodeExecPlugin) executeCode(executor entities.Executor, code string, co
 	// Create limited output writers
 	outputWriter := newLimitedWriter(config.MaxOutputSize)
 	errorWriter := newLimitedWriter(config.MaxOutputSize)
+	if emit != nil {
+		// stdout and stderr are copied concurrently; emit sees one at a time
+		var emitMu sync.Mutex
+		forward := func(stream string) func([]byte) {
+			return func(data []byte) {
+				emitMu.Lock()
+				defer emitMu.Unlock()
+				emit(plugin.OutputChunk{Stream: stream, Data: string(data)})
+			}
+		}
+		outputWriter.onWrite = forward("stdout")
+		errorWriter.onWrite = forward("stderr")
+	}
 
 	// Set up command stdio
 	cmd.Stdout = outputWriter
 	cmd.Stderr = errorWriter
 
-	// Set environment variables
-	if len(config.Environment) > 0 {
-		cmd.Env = filterEnvironment(config.Environment)
-	}
+	// Set environment variables, never inheriting the host's as a whole
+	cmd.Env = filterEnvironment(os.Environ(), config)
 
 	// Apply resource limits (Unix only)
 	if err := setResourceLimits(cmd, config); err != nil {
@@ -329,6 +377,11 @@ func (p *CodeExecPlugin) executeCode(executor entities.Executor, code string, co
 		return nil, fmt.Errorf("setting process group: %w", err)
 	}
 
+	// Cut off the network when asked to
+	if err := applyNetworkPolicy(cmd, config); err != nil {
+		return nil, fmt.Errorf("sandboxing network: %w", err)
+	}
+
 	// Execute the command
 	execErr := cmd.Run()
 	duration := time.Since(startTime)
@@ -360,6 +413,9 @@ func (p *CodeExecPlugin) executeCode(executor entities.Executor, code string, co
 		Language:    config.Language,
 		Status:      status,
 		Truncated:   outputWriter.IsTruncated() || errorWriter.IsTruncated(),
+
+		DroppedBytes:      outputWriter.DroppedBytes(),
+		DroppedErrorBytes: errorWriter.DroppedBytes(),
 	}
 
 	return result, nil
@@ -387,7 +443,9 @@ func (p *CodeExecPlugin) GetLanguageConfig(language string) (entities.ExecutionC
 		return entities.ExecutionConfig{}, fmt.Errorf("unsupported language: %s", language)
 	}
 
-	return executor.GetDefaultConfig(), nil
+	config := executor.GetDefaultConfig()
+	p.applyLanguageConfig(&config, language)
+	return config, nil
 }
 
 // Health checks plugin health and availability
@@ -2,10 +2,17 @@ package main
 
 import (
 	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/fredcamaral/slicli/internal/domain/entities"
 	"github.com/fredcamaral/slicli/pkg/plugin"
+	"github.com/fredcamaral/slicli/plugins/code-exec/executors"
 )
 
 func TestNewPlugin(t *testing.T) {
@@ -142,6 +149,85 @@ func TestGetLanguageConfig(t *testing.T) {
 	}
 }
 
+func TestGetLanguageConfigOverrides(t *testing.T) {
+	p := NewPlugin()
+	if _, ok := p.executors["python"]; !ok {
+		t.Skip("Python runtime not available")
+	}
+
+	err := p.Init(map[string]interface{}{
+		"languages": map[string]interface{}{
+			"python": map[string]interface{}{
+				"timeout":      "42s",
+				"memory_limit": "64MB",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	config, err := p.GetLanguageConfig("python")
+	if err != nil {
+		t.Fatalf("Error getting Python config: %v", err)
+	}
+	if config.Timeout != 42*time.Second {
+		t.Errorf("Expected timeout 42s, got %v", config.Timeout)
+	}
+	if config.MaxMemory != 64*1024*1024 {
+		t.Errorf("Expected memory limit 64MB, got %d", config.MaxMemory)
+	}
+
+	// Languages without their own settings keep the executor defaults
+	if _, ok := p.executors["go"]; ok {
+		goConfig, err := p.GetLanguageConfig("go")
+		if err != nil {
+			t.Fatalf("Error getting Go config: %v", err)
+		}
+		if defaults := (&executors.GoExecutor{}).GetDefaultConfig(); goConfig.Timeout != defaults.Timeout {
+			t.Errorf("Expected Go default timeout %v, got %v", defaults.Timeout, goConfig.Timeout)
+		}
+	}
+}
+
+func TestProcessLanguageTimeout(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	p := NewPlugin()
+	p.executors["sh"] = shellExecutor{}
+	err := p.Init(map[string]interface{}{
+		"languages": map[string]interface{}{
+			"sh": map[string]interface{}{"timeout": "200ms"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	start := time.Now()
+	result, err := p.Execute(context.Background(), plugin.PluginInput{
+		Content:  `sleep 10`,
+		Language: "sh",
+	})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.Metadata["status"] != "timeout" {
+		t.Errorf("Expected the language timeout to stop the run, got status: %v", result.Metadata["status"])
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the run to stop after 200ms, ran for %v", elapsed)
+	}
+
+	// A block's own timeout overrides the language's
+	config := p.extractConfig("sh", map[string]interface{}{"timeout": "3s"})
+	if config.Timeout != 3*time.Second {
+		t.Errorf("Expected the block timeout 3s, got %v", config.Timeout)
+	}
+}
+
 func TestHealth(t *testing.T) {
 	p := NewPlugin()
 	health := p.Health()
@@ -267,11 +353,91 @@ func TestProcessGoCode(t *testing.T) {
 	}
 }
 
+func TestProcessPHPCode(t *testing.T) {
+	p := NewPlugin()
+
+	// Skip if PHP is not available
+	if !isLanguageSupported(p, "php") {
+		t.Skip("PHP runtime not available")
+	}
+
+	result, err := p.Execute(context.Background(), plugin.PluginInput{
+		Content:  `echo "Hello, " . strtoupper("php") . "\n";`,
+		Language: "php",
+		Options:  map[string]interface{}{"language": "php"},
+	})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.Metadata["status"] != "success" {
+		t.Fatalf("Expected success, got status: %v (%s)", result.Metadata["status"], result.HTML)
+	}
+	if !strings.Contains(result.HTML, "Hello, PHP") {
+		t.Errorf("Expected program output, got: %s", result.HTML)
+	}
+}
+
+func TestProcessRustCode(t *testing.T) {
+	p := NewPlugin()
+
+	// Skip if Rust is not available
+	if !isLanguageSupported(p, "rust") {
+		t.Skip("Rust compiler not available")
+	}
+
+	result, err := p.Execute(context.Background(), plugin.PluginInput{
+		Content:  `let total: i32 = (1..=4).sum(); println!("sum = {}", total);`,
+		Language: "rust",
+		Options:  map[string]interface{}{"language": "rust", "timeout": "30s"},
+	})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+	if result.Metadata["status"] != "success" {
+		t.Fatalf("Expected success, got status: %v (%s)", result.Metadata["status"], result.HTML)
+	}
+	if !strings.Contains(result.HTML, "sum = 10") {
+		t.Errorf("Expected program output, got: %s", result.HTML)
+	}
+
+	t.Run("cleanup removes the source and binary", func(t *testing.T) {
+		executor := &executors.RustExecutor{}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		cmd, cleanup, err := executor.Prepare(ctx, `println!("hi");`, executor.GetDefaultConfig())
+		if err != nil {
+			t.Fatalf("Prepare error: %v", err)
+		}
+		if _, err := os.Stat(cmd.Path); err != nil {
+			t.Fatalf("Expected compiled binary: %v", err)
+		}
+
+		cleanup()
+		if _, err := os.Stat(filepath.Dir(cmd.Path)); !os.IsNotExist(err) {
+			t.Errorf("Expected build directory to be removed, got: %v", err)
+		}
+	})
+
+	t.Run("compile errors are reported", func(t *testing.T) {
+		result, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content:  `let x: i32 = "nope";`,
+			Language: "rust",
+			Options:  map[string]interface{}{"language": "rust", "timeout": "30s"},
+		})
+		if err != nil {
+			t.Fatalf("Execute error: %v", err)
+		}
+		if result.Metadata["status"] == "success" {
+			t.Error("Expected failure but got success")
+		}
+	})
+}
+
 func TestConfigExtraction(t *testing.T) {
 	p := NewPlugin()
 
 	options := map[string]interface{}{
-		"language":         "python",
 		"timeout":          "15s",
 		"max_output":       "5KB",
 		"max_memory":       "50MB",
@@ -283,7 +449,7 @@ func TestConfigExtraction(t *testing.T) {
 		},
 	}
 
-	config := p.extractConfig(options)
+	config := p.extractConfig("python", options)
 
 	if config.Language != "python" {
 		t.Errorf("Expected language 'python', got '%s'", config.Language)
@@ -306,6 +472,82 @@ func TestConfigExtraction(t *testing.T) {
 	}
 }
 
+func TestConfigNetworkCeiling(t *testing.T) {
+	tests := []struct {
+		name    string
+		global  interface{}
+		options map[string]interface{}
+		want    string
+	}{
+		{"block setting without global", nil, map[string]interface{}{"network": "none"}, entities.NetworkNone},
+		{"global applies to blocks without their own", entities.NetworkNone, map[string]interface{}{}, entities.NetworkNone},
+		{"block can't re-enable a disabled network", entities.NetworkNone, map[string]interface{}{"network": "allow"}, entities.NetworkNone},
+		{"allow_network can't re-enable it either", entities.NetworkNone, map[string]interface{}{"allow_network": true}, entities.NetworkNone},
+		{"block can disable an allowed network", entities.NetworkAllow, map[string]interface{}{"network": "none"}, entities.NetworkNone},
+		{"allow_network false disables it", entities.NetworkAllow, map[string]interface{}{"allow_network": false}, entities.NetworkNone},
+		{"allow_network false wins over network allow", nil, map[string]interface{}{"network": "allow", "allow_network": false}, entities.NetworkNone},
+		{"invalid block setting is kept for validation", entities.NetworkAllow, map[string]interface{}{"network": "host"}, "host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPlugin()
+			if tt.global != nil {
+				if err := p.Init(map[string]interface{}{"network": tt.global}); err != nil {
+					t.Fatalf("Init error: %v", err)
+				}
+			}
+
+			config := p.extractConfig("python", tt.options)
+			if config.Network != tt.want {
+				t.Errorf("Network = %q, want %q", config.Network, tt.want)
+			}
+			if config.Network == entities.NetworkNone && config.AllowNetwork {
+				t.Error("AllowNetwork set while the network is disabled")
+			}
+		})
+	}
+}
+
+func TestConfigEnvAllowlistIntersection(t *testing.T) {
+	tests := []struct {
+		name    string
+		global  []interface{}
+		options map[string]interface{}
+		want    []string
+	}{
+		{"block narrows the default list", nil, map[string]interface{}{"env_allowlist": []interface{}{"PATH", "EDITOR"}}, []string{"PATH"}},
+		{"block can't extend the default list", nil, map[string]interface{}{"env_allowlist": []interface{}{"AWS_SECRET_ACCESS_KEY"}}, []string{}},
+		{"global list for blocks without their own", []interface{}{"PATH", "LANG"}, map[string]interface{}{}, []string{"PATH", "LANG"}},
+		{"block narrows the global list", []interface{}{"PATH", "LANG"}, map[string]interface{}{"env_allowlist": []interface{}{"LANG"}}, []string{"LANG"}},
+		{"block can't extend the global list", []interface{}{"PATH"}, map[string]interface{}{"env_allowlist": []interface{}{"PATH", "AWS_SECRET"}}, []string{"PATH"}},
+		{"disjoint lists allow nothing", []interface{}{"PATH"}, map[string]interface{}{"env_allowlist": []interface{}{"AWS_SECRET"}}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPlugin()
+			if tt.global != nil {
+				if err := p.Init(map[string]interface{}{"env_allowlist": tt.global}); err != nil {
+					t.Fatalf("Init error: %v", err)
+				}
+			}
+
+			config := p.extractConfig("python", tt.options)
+			if !reflect.DeepEqual(config.EnvAllowlist, tt.want) {
+				t.Errorf("EnvAllowlist = %#v, want %#v", config.EnvAllowlist, tt.want)
+			}
+		})
+	}
+
+	t.Run("an empty intersection passes no host variables", func(t *testing.T) {
+		env := filterEnvironment([]string{"PATH=/usr/bin", "AWS_SECRET=hunter2"}, entities.ExecutionConfig{EnvAllowlist: []string{}})
+		if len(env) != 0 {
+			t.Errorf("Expected no variables, got %v", env)
+		}
+	})
+}
+
 func TestCleanup(t *testing.T) {
 	p := NewPlugin()
 
@@ -334,6 +576,273 @@ func TestCleanup(t *testing.T) {
 	}
 }
 
+func TestLimitedWriter(t *testing.T) {
+	lw := newLimitedWriter(64)
+
+	// Write past the limit in chunks; every write is accepted
+	for i := 0; i < 10; i++ {
+		chunk := []byte(strings.Repeat("x", 10))
+		n, err := lw.Write(chunk)
+		if err != nil || n != len(chunk) {
+			t.Fatalf("Write %d = %d, %v; want %d, nil", i, n, err, len(chunk))
+		}
+	}
+
+	if got := len(lw.String()); got != 64 {
+		t.Errorf("Expected 64 bytes kept, got %d", got)
+	}
+	if got := lw.Written(); got != 64 {
+		t.Errorf("Expected Written() to be 64, got %d", got)
+	}
+	if got := lw.DroppedBytes(); got != 36 {
+		t.Errorf("Expected 36 dropped bytes, got %d", got)
+	}
+	if !lw.IsTruncated() {
+		t.Error("Expected writer to be truncated")
+	}
+
+	exact := newLimitedWriter(4)
+	_, _ = exact.Write([]byte("abcd"))
+	if exact.IsTruncated() || exact.DroppedBytes() != 0 {
+		t.Error("Output exactly at the limit should not be truncated")
+	}
+}
+
+// shellExecutor runs code as an sh script
+type shellExecutor struct{}
+
+func (shellExecutor) Name() string      { return "sh" }
+func (shellExecutor) IsAvailable() bool { return true }
+func (shellExecutor) GetDefaultConfig() entities.ExecutionConfig {
+	return entities.ExecutionConfig{Language: "sh", Timeout: 5 * time.Second, MaxOutputSize: 1024}
+}
+func (shellExecutor) Prepare(ctx context.Context, code string, config entities.ExecutionConfig) (*exec.Cmd, func(), error) {
+	script, err := os.CreateTemp("", "slicli-sh-*.sh")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { _ = os.Remove(script.Name()) }
+	if _, err := script.WriteString(code); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	_ = script.Close()
+	return exec.CommandContext(ctx, "sh", script.Name()), cleanup, nil
+}
+
+func TestProcessTruncatedOutput(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	p := NewPlugin()
+	p.executors["sh"] = shellExecutor{}
+
+	result, err := p.Execute(context.Background(), plugin.PluginInput{
+		Content:  `i=0; while [ $i -lt 100 ]; do printf x; i=$((i+1)); done`,
+		Language: "sh",
+		Options:  map[string]interface{}{"max_output": float64(64)},
+	})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+
+	if result.Metadata["status"] != "success" {
+		t.Errorf("Expected output past the limit not to fail the run, got status: %v", result.Metadata["status"])
+	}
+	if result.Metadata["output_size"] != 64 {
+		t.Errorf("Expected 64 bytes of output, got %v", result.Metadata["output_size"])
+	}
+	if result.Metadata["dropped_bytes"] != 36 {
+		t.Errorf("Expected 36 dropped bytes, got %v", result.Metadata["dropped_bytes"])
+	}
+	if result.Metadata["max_output_size"] != 64 {
+		t.Errorf("Expected max output size 64, got %v", result.Metadata["max_output_size"])
+	}
+	if !strings.Contains(result.HTML, "Output (truncated, showing 64 of 100 bytes):") {
+		t.Errorf("Expected truncation header in HTML, got: %s", result.HTML)
+	}
+}
+
+func TestExecuteStreamSendsOutputBeforeExit(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	p := NewPlugin()
+	p.executors["sh"] = shellExecutor{}
+
+	type arrival struct {
+		chunk plugin.OutputChunk
+		at    time.Time
+	}
+	var arrivals []arrival
+	result, err := p.ExecuteStream(context.Background(), plugin.PluginInput{
+		Content:  `echo first; sleep 1; echo oops >&2; echo second`,
+		Language: "sh",
+		Options:  map[string]interface{}{"max_output": float64(10)},
+	}, func(chunk plugin.OutputChunk) {
+		arrivals = append(arrivals, arrival{chunk: chunk, at: time.Now()})
+	})
+	finished := time.Now()
+	if err != nil {
+		t.Fatalf("ExecuteStream error: %v", err)
+	}
+
+	if len(arrivals) == 0 || arrivals[0].chunk != (plugin.OutputChunk{Stream: "stdout", Data: "first\n"}) {
+		t.Fatalf("Expected the first print as the first chunk, got %+v", arrivals)
+	}
+	if early := finished.Sub(arrivals[0].at); early < 500*time.Millisecond {
+		t.Errorf("Expected the first chunk well before the program exited, got it %v before", early)
+	}
+
+	var stdout, stderr string
+	for _, a := range arrivals {
+		switch a.chunk.Stream {
+		case "stdout":
+			stdout += a.chunk.Data
+		case "stderr":
+			stderr += a.chunk.Data
+		}
+	}
+	if stdout != "first\nseco" {
+		t.Errorf("Expected streamed stdout to stop at the 10 byte limit, got %q", stdout)
+	}
+	if stderr != "oops\n" {
+		t.Errorf("Expected stderr to be streamed, got %q", stderr)
+	}
+	if result.Metadata["status"] != "success" || result.Metadata["dropped_bytes"] != 3 {
+		t.Errorf("Expected the complete result after streaming, got %v", result.Metadata)
+	}
+}
+
+func TestExecuteStreamStopsWhenCancelled(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	p := NewPlugin()
+	p.executors["sh"] = shellExecutor{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	start := time.Now()
+	_, err := p.ExecuteStream(ctx, plugin.PluginInput{
+		Content:  `echo ready; sleep 10`,
+		Language: "sh",
+	}, func(plugin.OutputChunk) { cancel() })
+	if err != nil {
+		t.Fatalf("ExecuteStream error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected cancelling to stop the program, ran for %v", elapsed)
+	}
+}
+
+func TestFilterEnvironment(t *testing.T) {
+	host := []string{"PATH=/usr/bin", "HOME=/home/presenter", "LANG=C.UTF-8", "AWS_SECRET=hunter2", "EDITOR=vi"}
+
+	tests := []struct {
+		name     string
+		config   entities.ExecutionConfig
+		expected []string
+	}{
+		{
+			name:     "default allowlist",
+			expected: []string{"PATH=/usr/bin", "HOME=/home/presenter", "LANG=C.UTF-8"},
+		},
+		{
+			name:     "allowlisted host variables",
+			config:   entities.ExecutionConfig{EnvAllowlist: []string{"PATH", "EDITOR"}},
+			expected: []string{"PATH=/usr/bin", "EDITOR=vi"},
+		},
+		{
+			name: "explicit environment overrides the host",
+			config: entities.ExecutionConfig{
+				EnvAllowlist: []string{"PATH"},
+				Environment:  []string{"PATH=/bin", "GREETING=hi"},
+			},
+			expected: []string{"PATH=/bin", "GREETING=hi"},
+		},
+		{
+			name: "denylist wins over allowlist and environment",
+			config: entities.ExecutionConfig{
+				EnvAllowlist: []string{"PATH", "AWS_SECRET"},
+				EnvDenylist:  []string{"AWS_SECRET", "TOKEN"},
+				Environment:  []string{"TOKEN=abc"},
+			},
+			expected: []string{"PATH=/usr/bin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := filterEnvironment(host, tt.config)
+			if strings.Join(env, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("Expected %v, got %v", tt.expected, env)
+			}
+		})
+	}
+}
+
+func TestProcessDeniedEnvironment(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	t.Setenv("AWS_SECRET", "hunter2")
+	t.Setenv("SLICLI_VISIBLE", "shown")
+
+	p := NewPlugin()
+	if err := p.Init(map[string]interface{}{
+		"env_allowlist": []interface{}{"PATH", "AWS_SECRET", "SLICLI_VISIBLE"},
+		"env_denylist":  []interface{}{"AWS_SECRET"},
+	}); err != nil {
+		t.Fatalf("Init error: %v", err)
+	}
+	p.executors["sh"] = shellExecutor{}
+
+	result, err := p.Execute(context.Background(), plugin.PluginInput{
+		Content:  `env`,
+		Language: "sh",
+		Options: map[string]interface{}{
+			"env_allowlist": []interface{}{"PATH", "AWS_SECRET", "SLICLI_VISIBLE"},
+			"environment":   map[string]interface{}{"AWS_SECRET": "from-slide"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Execute error: %v", err)
+	}
+
+	if result.Metadata["status"] != "success" {
+		t.Fatalf("Expected success, got status: %v (%s)", result.Metadata["status"], result.HTML)
+	}
+	if strings.Contains(result.HTML, "AWS_SECRET") {
+		t.Errorf("Denylisted variable reached the child process: %s", result.HTML)
+	}
+	if !strings.Contains(result.HTML, "SLICLI_VISIBLE=shown") {
+		t.Errorf("Expected allowlisted variable in the child process, got: %s", result.HTML)
+	}
+}
+
+func TestGenerateHTMLTruncation(t *testing.T) {
+	p := NewPlugin()
+	result := &entities.ExecutionResult{
+		Output:            "partial",
+		ErrorOutput:       "warning",
+		Status:            "success",
+		Language:          "bash",
+		Truncated:         true,
+		DroppedErrorBytes: 5,
+	}
+
+	html := p.generateHTML("echo", result, entities.ExecutionConfig{})
+	if !strings.Contains(html, "<h4>Output:</h4>") {
+		t.Errorf("Expected untruncated output header, got: %s", html)
+	}
+	if !strings.Contains(html, "<h4>Error Output (truncated, showing 7 of 12 bytes):</h4>") {
+		t.Errorf("Expected truncated error header, got: %s", html)
+	}
+}
+
 // Helper functions
 
 func isLanguageSupported(plugin *CodeExecPlugin, language string) bool {
@@ -371,3 +880,11 @@ func BenchmarkHealth(b *testing.B) {
 		p.Health()
 	}
 }
+
+func TestApplyNetworkPolicyRejectsUnknownSetting(t *testing.T) {
+	config := entities.GetDefaultExecutionConfig()
+	config.Network = "host"
+	if err := applyNetworkPolicy(exec.Command("true"), config); err != entities.ErrInvalidNetwork {
+		t.Errorf("applyNetworkPolicy() error = %v, want %v", err, entities.ErrInvalidNetwork)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"errors"
 	"fmt"
 	"io"
 	"os/exec"
@@ -13,37 +12,46 @@ import (
 	"github.com/fredcamaral/slicli/internal/domain/entities"
 )
 
-// limitedWriter wraps an io.Writer to enforce size limits
+// limitedWriter wraps an io.Writer to enforce size limits. Output past the
+// limit is counted and discarded rather than refused, so the process keeps
+// running and the writer knows how much was dropped.
 type limitedWriter struct {
 	w       io.Writer
 	limit   int
 	written int
+	total   int
 	mu      sync.Mutex
+
+	// onWrite, when set, receives each piece of output kept within the limit
+	// as it's written
+	onWrite func([]byte)
 }
 
 // Write implements io.Writer with size limiting
-func (lw *limitedWriter) Write(p []byte) (n int, err error) {
+func (lw *limitedWriter) Write(p []byte) (int, error) {
 	lw.mu.Lock()
 	defer lw.mu.Unlock()
 
-	if lw.written >= lw.limit {
-		return 0, errors.New("output size limit exceeded")
-	}
+	lw.total += len(p)
 
-	remaining := lw.limit - lw.written
-	if len(p) > remaining {
-		p = p[:remaining]
-		err = errors.New("output truncated: size limit exceeded")
+	kept := p
+	if remaining := lw.limit - lw.written; len(kept) > remaining {
+		kept = kept[:max(remaining, 0)]
+	}
+	if len(kept) == 0 {
+		return len(p), nil
 	}
 
-	n, writeErr := lw.w.Write(p)
+	n, err := lw.w.Write(kept)
 	lw.written += n
-
-	if writeErr != nil {
-		return n, writeErr
+	if lw.onWrite != nil && n > 0 {
+		lw.onWrite(kept[:n])
+	}
+	if err != nil {
+		return n, err
 	}
 
-	return n, err
+	return len(p), nil
 }
 
 // Written returns the current number of bytes written
@@ -61,11 +69,16 @@ func (lw *limitedWriter) String() string {
 	return ""
 }
 
-// IsTruncated returns true if output was truncated
-func (lw *limitedWriter) IsTruncated() bool {
+// DroppedBytes returns the number of bytes discarded past the limit
+func (lw *limitedWriter) DroppedBytes() int {
 	lw.mu.Lock()
 	defer lw.mu.Unlock()
-	return lw.written >= lw.limit
+	return lw.total - lw.written
+}
+
+// IsTruncated returns true if output was truncated
+func (lw *limitedWriter) IsTruncated() bool {
+	return lw.DroppedBytes() > 0
 }
 
 // newLimitedWriter creates a new limited writer with a string builder
@@ -148,29 +161,74 @@ func setWindowsResourceLimits(cmd *exec.Cmd, config entities.ExecutionConfig) er
 	return nil
 }
 
-// filterEnvironment filters environment variables for safety
-func filterEnvironment(env []string) []string {
-	// Allow only safe environment variables
-	safeVars := []string{
-		"PATH", "HOME", "USER", "LANG", "LC_ALL",
-		"PYTHONDONTWRITEBYTECODE", "PYTHONUNBUFFERED", "PYTHONPATH",
-		"NODE_ENV", "NODE_OPTIONS",
-		"GOOS", "GOARCH", "CGO_ENABLED",
-	}
-
-	var filtered []string
-	for _, variable := range env {
-		for _, safe := range safeVars {
-			if strings.HasPrefix(variable, safe+"=") {
-				filtered = append(filtered, variable)
-				break
-			}
+// defaultEnvAllowlist is passed through from the host when the config
+// doesn't set an allowlist
+var defaultEnvAllowlist = []string{"PATH", "HOME", "LANG"}
+
+// filterEnvironment builds the environment of executed code: the allowlisted
+// host variables, overridden by the explicit environment, without any
+// denylisted variable
+func filterEnvironment(host []string, config entities.ExecutionConfig) []string {
+	allowlist := config.EnvAllowlist
+	if allowlist == nil {
+		allowlist = defaultEnvAllowlist
+	}
+
+	var names []string
+	values := make(map[string]string)
+	set := func(variable string) {
+		name, value, ok := strings.Cut(variable, "=")
+		if !ok || name == "" {
+			return
+		}
+		if _, exists := values[name]; !exists {
+			names = append(names, name)
+		}
+		values[name] = value
+	}
+
+	for _, variable := range host {
+		if name, _, _ := strings.Cut(variable, "="); containsName(allowlist, name) {
+			set(variable)
+		}
+	}
+	for _, variable := range config.Environment {
+		set(variable)
+	}
+
+	filtered := make([]string, 0, len(names))
+	for _, name := range names {
+		if !containsName(config.EnvDenylist, name) {
+			filtered = append(filtered, name+"="+values[name])
 		}
 	}
 
 	return filtered
 }
 
+// containsName reports whether an environment variable name is in a list
+func containsName(list []string, name string) bool {
+	for _, entry := range list {
+		if strings.TrimSpace(entry) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// applyNetworkPolicy runs the command in its own network namespace when
+// the config disables network access. Platforms without one refuse to run
+// the code rather than silently leaving the network reachable.
+func applyNetworkPolicy(cmd *exec.Cmd, config entities.ExecutionConfig) error {
+	if err := config.ValidateNetwork(); err != nil {
+		return err
+	}
+	if config.Network != entities.NetworkNone {
+		return nil
+	}
+	return isolateNetwork(cmd)
+}
+
 // setProcessGroup sets up process group for cleanup
 func setProcessGroup(cmd *exec.Cmd) error {
 	if cmd.SysProcAttr == nil {
@@ -178,6 +236,12 @@ func setProcessGroup(cmd *exec.Cmd) error {
 	}
 	cmd.SysProcAttr.Setpgid = true
 	cmd.SysProcAttr.Pgid = 0
+
+	// Timeouts and cancellation kill the whole group, so a child still
+	// holding stdout open can't keep the run going
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
 	return nil
 }
 
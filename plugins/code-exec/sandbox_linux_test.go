@@ -0,0 +1,71 @@
+//go:build linux
+
+package main
+
+import (
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/fredcamaral/slicli/internal/domain/entities"
+)
+
+func TestNetworkIsolation(t *testing.T) {
+	p := NewPlugin()
+	executor, ok := p.executors["python"]
+	if !ok {
+		t.Skip("Python runtime not available")
+	}
+
+	// Namespaces can be unavailable, for example inside some containers
+	probe := exec.Command("true")
+	if err := isolateNetwork(probe); err != nil {
+		t.Fatalf("isolateNetwork() error = %v", err)
+	}
+	if err := probe.Run(); err != nil {
+		t.Skipf("network namespaces not available: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	defer func() { _ = listener.Close() }()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	code := `import socket
+try:
+    socket.create_connection(("127.0.0.1", ` + strconv.Itoa(listener.Addr().(*net.TCPAddr).Port) + `), timeout=2).close()
+    print("connected")
+except OSError as e:
+    print("refused:", e)
+`
+
+	run := func(network string) string {
+		config := executor.GetDefaultConfig()
+		config.Language = "python"
+		config.Network = network
+		result, err := p.executeCode(executor, code, config)
+		if err != nil {
+			t.Fatalf("executeCode(network=%q) error = %v", network, err)
+		}
+		return result.Output
+	}
+
+	if output := run(entities.NetworkAllow); !strings.Contains(output, "connected") {
+		t.Fatalf("with network allowed, expected a connection, got %q", output)
+	}
+	if output := run(entities.NetworkNone); !strings.Contains(output, "refused") {
+		t.Errorf("with network disabled, expected the connection to fail, got %q", output)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// isolateNetwork refuses to run the command: network namespaces are Linux
+// only
+func isolateNetwork(cmd *exec.Cmd) error {
+	return fmt.Errorf(`network isolation is not supported on %s; set network: "allow" to run with network access`, runtime.GOOS)
+}
@@ -0,0 +1,16 @@
+module github.com/fredcamaral/slicli/plugins/data-table
+
+go 1.24.4
+
+require (
+	github.com/fredcamaral/slicli v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/fredcamaral/slicli => ../..
@@ -0,0 +1,472 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fredcamaral/slicli/pkg/plugin"
+)
+
+const (
+	defaultCacheTTL = 5 * time.Minute
+	defaultTimeout  = 10 * time.Second
+	defaultMaxBytes = 1 << 20 // 1MB
+)
+
+type DataTablePlugin struct {
+	config map[string]interface{}
+
+	// allowedHosts lists hosts that remote data may be fetched from
+	allowedHosts []string
+
+	// baseDir confines local data files. Relative to the presentation's
+	// directory, or the working directory when it isn't known.
+	baseDir string
+
+	// cacheDir persists the last fetched values, empty to keep them in memory only
+	cacheDir string
+
+	cacheTTL time.Duration
+	timeout  time.Duration
+	maxBytes int64
+	client   *http.Client
+
+	mu    sync.Mutex
+	cache map[string]*cachedData
+}
+
+// dataSpec is the parsed content of a data block
+type dataSpec struct {
+	Source   string
+	Format   string
+	Path     string
+	Columns  []string
+	TTL      time.Duration
+	Template string
+}
+
+// templateData is what custom templates are executed against
+type templateData struct {
+	Data      interface{}
+	Rows      []interface{}
+	Columns   []string
+	Source    string
+	FetchedAt time.Time
+}
+
+func (p *DataTablePlugin) Name() string    { return "data-table" }
+func (p *DataTablePlugin) Version() string { return "1.0.0" }
+func (p *DataTablePlugin) Description() string {
+	return "Render JSON data from files or APIs as tables"
+}
+
+// SlideIndependent reports that tables render the same on every slide, so
+// cached output is shared
+func (p *DataTablePlugin) SlideIndependent() bool { return true }
+
+func (p *DataTablePlugin) Init(config map[string]interface{}) error {
+	p.config = config
+	p.baseDir = "."
+	p.cacheTTL = defaultCacheTTL
+	p.timeout = defaultTimeout
+	p.maxBytes = defaultMaxBytes
+	p.allowedHosts = nil
+	p.cache = make(map[string]*cachedData)
+
+	if hosts, ok := config["allowed_hosts"].([]interface{}); ok {
+		for _, h := range hosts {
+			if host, ok := h.(string); ok && host != "" {
+				p.allowedHosts = append(p.allowedHosts, strings.ToLower(host))
+			}
+		}
+	}
+	if dir, ok := config["base_dir"].(string); ok && dir != "" {
+		p.baseDir = dir
+	}
+	if dir, ok := config["cache_dir"].(string); ok {
+		p.cacheDir = dir
+	}
+	if ttl, ok := numberOption(config["cache_ttl"]); ok {
+		if ttl < 0 {
+			return errors.New("cache_ttl must be non-negative")
+		}
+		p.cacheTTL = time.Duration(ttl * float64(time.Second))
+	}
+	if timeout, ok := numberOption(config["timeout"]); ok {
+		if timeout <= 0 {
+			return errors.New("timeout must be positive")
+		}
+		p.timeout = time.Duration(timeout * float64(time.Second))
+	}
+	if maxBytes, ok := numberOption(config["max_bytes"]); ok {
+		if maxBytes <= 0 {
+			return errors.New("max_bytes must be positive")
+		}
+		p.maxBytes = int64(maxBytes)
+	}
+
+	p.client = &http.Client{
+		Timeout: p.timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			// Redirects must not lead outside the allowlist
+			_, err := p.validateURL(req.URL.String())
+			return err
+		},
+	}
+
+	return nil
+}
+
+func (p *DataTablePlugin) Execute(ctx context.Context, input plugin.PluginInput) (plugin.PluginOutput, error) {
+	if p.cache == nil {
+		if err := p.Init(map[string]interface{}{}); err != nil {
+			return plugin.PluginOutput{}, err
+		}
+	}
+
+	spec, err := parseSpec(input.Content, input.Options)
+	if err != nil {
+		return plugin.PluginOutput{}, err
+	}
+
+	ttl := p.cacheTTL
+	if spec.TTL > 0 {
+		ttl = spec.TTL
+	}
+
+	// Exports render what the audience last saw instead of fetching again
+	offline, _ := input.Metadata[plugin.MetadataExport].(bool)
+
+	// Local files are cached by resolved path, since the same name may
+	// refer to another file next to another presentation
+	source := spec.Source
+	if !isURL(source) {
+		dir, _ := input.Metadata[plugin.MetadataPresentationDir].(string)
+		if source, err = p.resolvePath(source, dir); err != nil {
+			return plugin.PluginOutput{}, err
+		}
+	}
+
+	entry, cached, err := p.load(ctx, source, ttl, offline)
+	if err != nil {
+		return plugin.PluginOutput{}, err
+	}
+
+	data, err := selectPath(entry.Data, spec.Path)
+	if err != nil {
+		return plugin.PluginOutput{}, err
+	}
+
+	rows := toRows(data)
+	columns := spec.Columns
+	if len(columns) == 0 {
+		columns = inferColumns(rows)
+	}
+
+	var body string
+	if spec.Template != "" {
+		body, err = renderTemplate(spec.Template, templateData{
+			Data:      data,
+			Rows:      rows,
+			Columns:   columns,
+			Source:    spec.Source,
+			FetchedAt: entry.FetchedAt,
+		})
+		if err != nil {
+			return plugin.PluginOutput{}, err
+		}
+	} else if spec.Format == "list" {
+		body = renderList(rows, columns)
+	} else {
+		body = renderTable(rows, columns)
+	}
+
+	htmlOutput := fmt.Sprintf(`<div class="data-block" data-source="%s" data-fetched-at="%s">%s</div>`,
+		template.HTMLEscapeString(spec.Source), entry.FetchedAt.UTC().Format(time.RFC3339), body)
+
+	sourceType := "file"
+	if isURL(spec.Source) {
+		sourceType = "url"
+	}
+
+	return plugin.PluginOutput{
+		HTML: htmlOutput,
+		Assets: []plugin.Asset{
+			{
+				Name:        "data-table.css",
+				Content:     []byte(dataTableStyles),
+				ContentType: "text/css",
+			},
+		},
+		Metadata: map[string]interface{}{
+			"type":        "data",
+			"source":      spec.Source,
+			"source_type": sourceType,
+			"fetched_at":  entry.FetchedAt.UTC().Format(time.RFC3339),
+			"cached":      cached,
+			"stale":       time.Since(entry.FetchedAt) >= ttl,
+			"rows":        len(rows),
+		},
+	}, nil
+}
+
+func (p *DataTablePlugin) Cleanup() error {
+	p.config = make(map[string]interface{})
+	p.mu.Lock()
+	p.cache = make(map[string]*cachedData)
+	p.mu.Unlock()
+	return nil
+}
+
+// parseSpec reads "key: value" lines up to an optional "---" separator,
+// followed by a Go template. Fence options override the block's own keys.
+func parseSpec(content string, options map[string]interface{}) (*dataSpec, error) {
+	spec := &dataSpec{Format: "table"}
+	values := make(map[string]string)
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "---" {
+			spec.Template = strings.TrimSpace(strings.Join(lines[i+1:], "\n"))
+			break
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid data block line %d: expected key: value", i+1)
+		}
+		values[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+
+	for key, value := range options {
+		if s, ok := value.(string); ok {
+			values[key] = s
+		}
+	}
+
+	spec.Source = values["source"]
+	if spec.Source == "" {
+		return nil, errors.New("data block requires a source")
+	}
+	if format := values["format"]; format != "" {
+		if format != "table" && format != "list" {
+			return nil, fmt.Errorf("unsupported format %q (must be table or list)", format)
+		}
+		spec.Format = format
+	}
+	spec.Path = values["path"]
+	if columns := values["columns"]; columns != "" {
+		for _, column := range strings.Split(columns, ",") {
+			if column = strings.TrimSpace(column); column != "" {
+				spec.Columns = append(spec.Columns, column)
+			}
+		}
+	}
+	if ttl := values["ttl"]; ttl != "" {
+		seconds, err := strconv.Atoi(ttl)
+		if err != nil || seconds < 0 {
+			return nil, fmt.Errorf("invalid ttl %q", ttl)
+		}
+		spec.TTL = time.Duration(seconds) * time.Second
+	}
+
+	return spec, nil
+}
+
+// selectPath walks a dotted path such as "data.items" into decoded JSON
+func selectPath(data interface{}, path string) (interface{}, error) {
+	if path == "" {
+		return data, nil
+	}
+
+	current := data
+	for _, part := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[part]
+			if !ok {
+				return nil, fmt.Errorf("path %q: key %q not found", path, part)
+			}
+			current = value
+		case []interface{}:
+			index, err := strconv.Atoi(part)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("path %q: invalid index %q", path, part)
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("path %q: cannot descend into %q", path, part)
+		}
+	}
+
+	return current, nil
+}
+
+// toRows turns decoded JSON into rows, treating a single value as one row
+func toRows(data interface{}) []interface{} {
+	if rows, ok := data.([]interface{}); ok {
+		return rows
+	}
+	if data == nil {
+		return nil
+	}
+	return []interface{}{data}
+}
+
+// inferColumns collects object keys across rows in sorted order
+func inferColumns(rows []interface{}) []string {
+	seen := make(map[string]bool)
+	var columns []string
+	for _, row := range rows {
+		if object, ok := row.(map[string]interface{}); ok {
+			for key := range object {
+				if !seen[key] {
+					seen[key] = true
+					columns = append(columns, key)
+				}
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// cellValue formats a row's column value for display
+func cellValue(row interface{}, column string) string {
+	if column != "" {
+		if object, ok := row.(map[string]interface{}); ok {
+			row = object[column]
+		}
+	}
+
+	switch v := row.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func renderTable(rows []interface{}, columns []string) string {
+	var b strings.Builder
+	b.WriteString(`<table class="data-table">`)
+
+	if len(columns) > 0 {
+		b.WriteString("<thead><tr>")
+		for _, column := range columns {
+			fmt.Fprintf(&b, "<th>%s</th>", template.HTMLEscapeString(column))
+		}
+		b.WriteString("</tr></thead>")
+	} else {
+		// Scalars render in a single unnamed column
+		columns = []string{""}
+	}
+
+	b.WriteString("<tbody>")
+	for _, row := range rows {
+		b.WriteString("<tr>")
+		for _, column := range columns {
+			fmt.Fprintf(&b, "<td>%s</td>", template.HTMLEscapeString(cellValue(row, column)))
+		}
+		b.WriteString("</tr>")
+	}
+	b.WriteString("</tbody></table>")
+
+	return b.String()
+}
+
+func renderList(rows []interface{}, columns []string) string {
+	var b strings.Builder
+	b.WriteString(`<ul class="data-list">`)
+	for _, row := range rows {
+		var parts []string
+		if len(columns) == 0 {
+			parts = append(parts, cellValue(row, ""))
+		}
+		for _, column := range columns {
+			if value := cellValue(row, column); value != "" {
+				parts = append(parts, value)
+			}
+		}
+		fmt.Fprintf(&b, "<li>%s</li>", template.HTMLEscapeString(strings.Join(parts, " — ")))
+	}
+	b.WriteString("</ul>")
+	return b.String()
+}
+
+// renderTemplate executes a user template with HTML escaping of data values
+func renderTemplate(text string, data templateData) (string, error) {
+	tmpl, err := template.New("data").Funcs(template.FuncMap{
+		"field": cellValue,
+	}).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parsing template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("executing template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// numberOption reads a numeric config value, which TOML may decode as int64 or float64
+func numberOption(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+var dataTableStyles = `
+.data-block {
+	margin: 1rem 0;
+	overflow-x: auto;
+}
+
+.data-table {
+	border-collapse: collapse;
+	width: 100%;
+}
+
+.data-table th,
+.data-table td {
+	border-bottom: 1px solid rgba(0, 0, 0, 0.1);
+	padding: 0.4rem 0.8rem;
+	text-align: left;
+}
+
+.data-table th {
+	font-weight: 600;
+}
+
+.data-list {
+	margin: 0;
+	padding-left: 1.5rem;
+}
+`
+
+// Export plugin
+var Plugin plugin.Plugin = &DataTablePlugin{}
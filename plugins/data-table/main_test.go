@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fredcamaral/slicli/pkg/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPlugin(t *testing.T, config map[string]interface{}) *DataTablePlugin {
+	t.Helper()
+	p := &DataTablePlugin{}
+	require.NoError(t, p.Init(config))
+	return p
+}
+
+func TestDataTablePlugin_Basic(t *testing.T) {
+	p := &DataTablePlugin{}
+
+	assert.Equal(t, "data-table", p.Name())
+	assert.Equal(t, "1.0.0", p.Version())
+	assert.NotEmpty(t, p.Description())
+}
+
+func TestDataTablePlugin_Init(t *testing.T) {
+	p := newTestPlugin(t, map[string]interface{}{
+		"allowed_hosts": []interface{}{"API.example.com"},
+		"cache_ttl":     int64(60),
+		"timeout":       2.5,
+	})
+	assert.Equal(t, []string{"api.example.com"}, p.allowedHosts)
+	assert.Equal(t, time.Minute, p.cacheTTL)
+	assert.Equal(t, 2500*time.Millisecond, p.timeout)
+
+	err := (&DataTablePlugin{}).Init(map[string]interface{}{"timeout": 0})
+	assert.Error(t, err)
+}
+
+func TestDataTablePlugin_LocalFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sales.json"), []byte(
+		`{"data": {"items": [{"region": "EMEA", "total": 1200}, {"region": "<APAC>", "total": 950.5}]}}`,
+	), 0o644))
+
+	p := newTestPlugin(t, map[string]interface{}{"base_dir": dir})
+
+	t.Run("renders a table", func(t *testing.T) {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Language: "data",
+			Content:  "source: sales.json\npath: data.items\ncolumns: region, total",
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, output.HTML, "<th>region</th><th>total</th>")
+		assert.Contains(t, output.HTML, "<td>EMEA</td><td>1200</td>")
+		assert.Contains(t, output.HTML, "<td>&lt;APAC&gt;</td><td>950.5</td>")
+		assert.Equal(t, "sales.json", output.Metadata["source"])
+		assert.Equal(t, "file", output.Metadata["source_type"])
+		assert.Equal(t, 2, output.Metadata["rows"])
+		assert.NotEmpty(t, output.Metadata["fetched_at"])
+	})
+
+	t.Run("renders a list", func(t *testing.T) {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content: "source: sales.json\npath: data.items\nformat: list\ncolumns: region",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output.HTML, `<ul class="data-list"><li>EMEA</li><li>&lt;APAC&gt;</li></ul>`)
+	})
+
+	t.Run("renders a custom template", func(t *testing.T) {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content: "source: sales.json\npath: data.items\n---\n{{range .Rows}}<b>{{field . \"region\"}}</b>{{end}}",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output.HTML, "<b>EMEA</b><b>&lt;APAC&gt;</b>")
+	})
+
+	t.Run("rejects traversal", func(t *testing.T) {
+		for _, source := range []string{"../secret.json", "/etc/passwd", "a/../../secret.json"} {
+			_, err := p.Execute(context.Background(), plugin.PluginInput{Content: "source: " + source})
+			assert.Error(t, err, source)
+		}
+	})
+
+	t.Run("rejects symlinks out of the base directory", func(t *testing.T) {
+		outside := filepath.Join(t.TempDir(), "outside.json")
+		require.NoError(t, os.WriteFile(outside, []byte(`[1]`), 0o644))
+		require.NoError(t, os.Symlink(outside, filepath.Join(dir, "link.json")))
+
+		_, err := p.Execute(context.Background(), plugin.PluginInput{Content: "source: link.json"})
+		assert.ErrorContains(t, err, "outside the presentation directory")
+	})
+}
+
+func TestDataTablePlugin_PresentationDir(t *testing.T) {
+	talks := t.TempDir()
+	for name, region := range map[string]string{"q1": "EMEA", "q2": "APAC"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(talks, name, "data"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(talks, name, "data", "sales.json"),
+			[]byte(`[{"region": "`+region+`"}]`), 0o644))
+	}
+	p := newTestPlugin(t, map[string]interface{}{})
+
+	// The same relative name is read next to each presentation
+	for name, region := range map[string]string{"q1": "EMEA", "q2": "APAC"} {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content:  "source: data/sales.json",
+			Metadata: map[string]interface{}{plugin.MetadataPresentationDir: filepath.Join(talks, name)},
+		})
+		require.NoError(t, err, name)
+		assert.Contains(t, output.HTML, "<td>"+region+"</td>", name)
+	}
+
+	_, err := p.Execute(context.Background(), plugin.PluginInput{
+		Content:  "source: ../q2/data/sales.json",
+		Metadata: map[string]interface{}{plugin.MetadataPresentationDir: filepath.Join(talks, "q1")},
+	})
+	assert.ErrorContains(t, err, "outside the presentation directory")
+}
+
+func TestDataTablePlugin_URL(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"name": "build", "status": "green"}]`))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	t.Run("fetches allowlisted hosts and caches the result", func(t *testing.T) {
+		requests.Store(0)
+		p := newTestPlugin(t, map[string]interface{}{
+			"allowed_hosts": []interface{}{serverURL.Hostname()},
+		})
+		input := plugin.PluginInput{Content: "source: " + server.URL + "/status"}
+
+		output, err := p.Execute(context.Background(), input)
+		require.NoError(t, err)
+		assert.Contains(t, output.HTML, "<td>build</td><td>green</td>")
+		assert.Equal(t, "url", output.Metadata["source_type"])
+		assert.Equal(t, false, output.Metadata["cached"])
+
+		output, err = p.Execute(context.Background(), input)
+		require.NoError(t, err)
+		assert.Equal(t, true, output.Metadata["cached"])
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("refetches once the TTL expires", func(t *testing.T) {
+		requests.Store(0)
+		p := newTestPlugin(t, map[string]interface{}{
+			"allowed_hosts": []interface{}{serverURL.Hostname()},
+			"cache_ttl":     0,
+		})
+		input := plugin.PluginInput{Content: "source: " + server.URL}
+
+		_, err := p.Execute(context.Background(), input)
+		require.NoError(t, err)
+		_, err = p.Execute(context.Background(), input)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("rejects hosts outside the allowlist", func(t *testing.T) {
+		p := newTestPlugin(t, map[string]interface{}{
+			"allowed_hosts": []interface{}{"*.example.com"},
+		})
+		_, err := p.Execute(context.Background(), plugin.PluginInput{Content: "source: " + server.URL})
+		assert.ErrorContains(t, err, "not in allowed_hosts")
+	})
+
+	t.Run("export uses the last fetched value", func(t *testing.T) {
+		requests.Store(0)
+		cacheDir := t.TempDir()
+		config := map[string]interface{}{
+			"allowed_hosts": []interface{}{serverURL.Hostname()},
+			"cache_ttl":     0,
+			"cache_dir":     cacheDir,
+		}
+		input := plugin.PluginInput{Content: "source: " + server.URL}
+
+		_, err := newTestPlugin(t, config).Execute(context.Background(), input)
+		require.NoError(t, err)
+
+		// A separate export run reuses the persisted value without fetching
+		input.Metadata = map[string]interface{}{plugin.MetadataExport: true}
+		output, err := newTestPlugin(t, config).Execute(context.Background(), input)
+		require.NoError(t, err)
+		assert.Contains(t, output.HTML, "<td>build</td>")
+		assert.Equal(t, true, output.Metadata["cached"])
+		assert.Equal(t, int32(1), requests.Load())
+	})
+}
+
+func TestDataTablePlugin_FetchTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	p := newTestPlugin(t, map[string]interface{}{
+		"allowed_hosts": []interface{}{serverURL.Hostname()},
+		"timeout":       0.1,
+	})
+
+	start := time.Now()
+	_, err = p.Execute(context.Background(), plugin.PluginInput{Content: "source: " + server.URL})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestParseSpec(t *testing.T) {
+	spec, err := parseSpec("source: a.json\nformat: list\nttl: 30", map[string]interface{}{"format": "table"})
+	require.NoError(t, err)
+	assert.Equal(t, "a.json", spec.Source)
+	assert.Equal(t, "table", spec.Format, "fence options override the block")
+	assert.Equal(t, 30*time.Second, spec.TTL)
+
+	_, err = parseSpec("format: table", nil)
+	assert.ErrorContains(t, err, "requires a source")
+
+	_, err = parseSpec("source: a.json\nformat: chart", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cachedData is a fetched data source along with when it was fetched
+type cachedData struct {
+	Source    string      `json:"source"`
+	FetchedAt time.Time   `json:"fetched_at"`
+	Data      interface{} `json:"data"`
+}
+
+// isURL reports whether source refers to a remote resource
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// validateURL checks that source uses HTTP(S) and its host is allowlisted.
+// Entries may be exact host names or "*.example.com" wildcards.
+func (p *DataTablePlugin) validateURL(source string) (*url.URL, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range p.allowedHosts {
+		if suffix, ok := strings.CutPrefix(allowed, "*."); ok {
+			if strings.HasSuffix(host, "."+suffix) {
+				return u, nil
+			}
+		} else if host == allowed {
+			return u, nil
+		}
+	}
+
+	return nil, fmt.Errorf("host %q is not in allowed_hosts", host)
+}
+
+// resolvePath resolves a local data file inside the base directory, rejecting
+// absolute paths and anything that escapes it, including through symlinks.
+// A relative base directory is taken from presentationDir when it is set.
+func (p *DataTablePlugin) resolvePath(source, presentationDir string) (string, error) {
+	if filepath.IsAbs(source) {
+		return "", errors.New("data file path must be relative")
+	}
+
+	base := p.baseDir
+	if presentationDir != "" && !filepath.IsAbs(base) {
+		base = filepath.Join(presentationDir, base)
+	}
+	base, err := filepath.Abs(base)
+	if err != nil {
+		return "", fmt.Errorf("resolving base directory: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(base); err == nil {
+		base = resolved
+	}
+
+	path := filepath.Join(base, source)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+
+	rel, err := filepath.Rel(base, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("data file %q is outside the presentation directory", source)
+	}
+
+	return path, nil
+}
+
+// load reads or fetches source, serving fresh cache entries without I/O.
+// When offline is set, or a refresh fails, the last fetched value is used
+// regardless of age.
+func (p *DataTablePlugin) load(ctx context.Context, source string, ttl time.Duration, offline bool) (*cachedData, bool, error) {
+	p.mu.Lock()
+	cached, found := p.cache[source]
+	p.mu.Unlock()
+
+	if !found {
+		cached, found = p.readPersisted(source)
+	}
+	if found && (offline || time.Since(cached.FetchedAt) < ttl) {
+		return cached, true, nil
+	}
+
+	data, err := p.fetch(ctx, source)
+	if err != nil {
+		if found {
+			return cached, true, nil
+		}
+		return nil, false, err
+	}
+
+	entry := &cachedData{Source: source, FetchedAt: time.Now(), Data: data}
+	p.mu.Lock()
+	p.cache[source] = entry
+	p.mu.Unlock()
+	p.persist(entry)
+
+	return entry, false, nil
+}
+
+// fetch reads source from disk or over HTTP and decodes it as JSON
+func (p *DataTablePlugin) fetch(ctx context.Context, source string) (interface{}, error) {
+	var reader io.Reader
+
+	if isURL(source) {
+		u, err := p.validateURL(source)
+		if err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating request: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", source, err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return nil, fmt.Errorf("fetching %s: unexpected status %s", source, resp.Status)
+		}
+		reader = resp.Body
+	} else {
+		file, err := os.Open(source) // #nosec G304 - resolved inside the base directory by Execute
+		if err != nil {
+			return nil, fmt.Errorf("opening data file: %w", err)
+		}
+		defer func() { _ = file.Close() }()
+		reader = file
+	}
+
+	// Read one byte past the limit to detect oversized sources
+	body, err := io.ReadAll(io.LimitReader(reader, p.maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", source, err)
+	}
+	if int64(len(body)) > p.maxBytes {
+		return nil, fmt.Errorf("data source %s exceeds %d bytes", source, p.maxBytes)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("decoding JSON from %s: %w", source, err)
+	}
+
+	return data, nil
+}
+
+// persistPath returns where the last value for source is stored, or "" when
+// persistence is disabled
+func (p *DataTablePlugin) persistPath(source string) string {
+	if p.cacheDir == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(source))
+	return filepath.Join(p.cacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+// persist saves the last fetched value so later runs, such as exports, can reuse it
+func (p *DataTablePlugin) persist(entry *cachedData) {
+	path := p.persistPath(entry.Source)
+	if path == "" {
+		return
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(p.cacheDir, 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o644) // #nosec G306 - cached public data
+}
+
+// readPersisted loads the last saved value for source and caches it in memory
+func (p *DataTablePlugin) readPersisted(source string) (*cachedData, bool) {
+	path := p.persistPath(source)
+	if path == "" {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 - path derived from a hash in the cache directory
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cachedData
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Source != source {
+		return nil, false
+	}
+
+	p.mu.Lock()
+	p.cache[source] = &entry
+	p.mu.Unlock()
+
+	return &entry, true
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/fredcamaral/slicli/pkg/plugin"
+)
+
+// katexVersion pins the client-side KaTeX and mhchem bundles.
+const katexVersion = "0.16.11"
+
+// serverRenderTimeout bounds a single server-side KaTeX render.
+const serverRenderTimeout = 5 * time.Second
+
+type MathPlugin struct {
+	config map[string]interface{}
+
+	// chem enables the mhchem extension for every block
+	chem bool
+
+	// nodePath is the node binary used for server-side rendering, empty when unavailable
+	nodePath string
+}
+
+func (p *MathPlugin) Name() string        { return "math" }
+func (p *MathPlugin) Version() string     { return "1.0.0" }
+func (p *MathPlugin) Description() string { return "Render LaTeX math and mhchem chemistry with KaTeX" }
+
+// SlideIndependent reports that formulas render the same on every slide, so
+// cached output is shared
+func (p *MathPlugin) SlideIndependent() bool { return true }
+
+func (p *MathPlugin) Init(config map[string]interface{}) error {
+	p.config = config
+
+	if chem, ok := config["chem"].(bool); ok {
+		p.chem = chem
+	}
+
+	// Server-side rendering is opt-in since it needs node with the katex package installed
+	if serverRender, ok := config["server_render"].(bool); ok && serverRender {
+		if path, err := exec.LookPath("node"); err == nil {
+			p.nodePath = path
+		}
+	}
+
+	return nil
+}
+
+func (p *MathPlugin) Execute(ctx context.Context, input plugin.PluginInput) (plugin.PluginOutput, error) {
+	source := strings.TrimSpace(input.Content)
+	if source == "" {
+		return plugin.PluginOutput{}, fmt.Errorf("empty math expression")
+	}
+
+	chem := p.chem || input.Language == "chem"
+	if c, ok := input.Options["chem"].(bool); ok {
+		chem = c
+	}
+
+	// A chem fence holds bare formulas, so wrap them in \ce{} for mhchem
+	if input.Language == "chem" && !strings.Contains(source, `\ce{`) && !strings.Contains(source, `\pu{`) {
+		source = `\ce{` + source + `}`
+	}
+
+	display := true
+	if d, ok := input.Options["inline"].(bool); ok && d {
+		display = false
+	}
+
+	mathID := p.generateID(source)
+
+	// Prefer static output so exports don't depend on client-side scripts
+	if rendered, err := p.renderOnServer(ctx, source, display, chem); err == nil {
+		return plugin.PluginOutput{
+			HTML: fmt.Sprintf(`<div class="math-block" id="%s" data-math-render="server">%s</div>`, mathID, rendered),
+			Assets: []plugin.Asset{
+				{
+					Name:        "katex.css",
+					Content:     []byte(katexStylesheet()),
+					ContentType: "text/css",
+				},
+			},
+			Metadata: p.metadata(chem, display, "server"),
+		}, nil
+	}
+
+	htmlOutput := fmt.Sprintf(
+		`<div class="math-block" id="%s" data-math-render="pending" data-display="%t" data-chem="%t"><span class="math-source">%s</span></div>`,
+		mathID, display, chem, html.EscapeString(source),
+	)
+
+	assets := []plugin.Asset{
+		{
+			Name:        mathInitName(chem),
+			Content:     []byte(mathInitScript(chem)),
+			ContentType: "application/javascript",
+		},
+		{
+			Name:        "katex.css",
+			Content:     []byte(katexStylesheet()),
+			ContentType: "text/css",
+		},
+	}
+
+	return plugin.PluginOutput{
+		HTML:     htmlOutput,
+		Assets:   assets,
+		Metadata: p.metadata(chem, display, "client"),
+	}, nil
+}
+
+func (p *MathPlugin) Cleanup() error {
+	p.config = make(map[string]interface{})
+	return nil
+}
+
+func (p *MathPlugin) metadata(chem, display bool, render string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "math",
+		"engine":  "katex",
+		"chem":    chem,
+		"display": display,
+		"render":  render,
+		// Exporters must wait for the client to typeset when output isn't static
+		"requires_client_render": render == "client",
+	}
+}
+
+// renderOnServer typesets source with KaTeX under node, loading mhchem when chem is set.
+func (p *MathPlugin) renderOnServer(ctx context.Context, source string, display, chem bool) (string, error) {
+	if p.nodePath == "" {
+		return "", fmt.Errorf("server-side rendering unavailable")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, serverRenderTimeout)
+	defer cancel()
+
+	// #nosec G204 - script is a constant, the expression is passed on stdin
+	cmd := exec.CommandContext(ctx, p.nodePath, "-e", serverRenderScript, "--", fmt.Sprint(display), fmt.Sprint(chem))
+	cmd.Stdin = strings.NewReader(source)
+	cmd.Env = os.Environ()
+	if nodeModules, ok := p.config["node_path"].(string); ok && nodeModules != "" {
+		cmd.Env = append(cmd.Env, "NODE_PATH="+nodeModules)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("katex render failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+func (p *MathPlugin) generateID(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return "math-" + base64.RawURLEncoding.EncodeToString(hash[:8])
+}
+
+func katexStylesheet() string {
+	return fmt.Sprintf("@import url('https://cdn.jsdelivr.net/npm/katex@%s/dist/katex.min.css');\n%s", katexVersion, mathStyles)
+}
+
+// mathInitName names the client script for chem or plain blocks. Assets are
+// kept once per name, so a deck with both gets both scripts.
+func mathInitName(chem bool) string {
+	if chem {
+		return "math-chem-init.js"
+	}
+	return "math-init.js"
+}
+
+// mathInitScript loads KaTeX (plus mhchem when needed) and typesets pending blocks.
+// The plain script leaves chem blocks to the chem one, which waits for mhchem.
+// It flags the document once no block is pending so exports can wait for static output.
+func mathInitScript(chem bool) string {
+	extensions := ""
+	blocks := `.math-block[data-math-render="pending"][data-chem="false"]`
+	if chem {
+		extensions = fmt.Sprintf("https://cdn.jsdelivr.net/npm/katex@%s/dist/contrib/mhchem.min.js", katexVersion)
+		blocks = `.math-block[data-math-render="pending"]`
+	}
+
+	return fmt.Sprintf(`
+(function() {
+	function loadScript(src, done) {
+		var script = document.createElement('script');
+		script.src = src;
+		script.onload = done;
+		document.head.appendChild(script);
+	}
+
+	function typeset() {
+		document.querySelectorAll('%s').forEach(function(block) {
+			var source = block.querySelector('.math-source');
+			katex.render(source.textContent, block, {
+				displayMode: block.dataset.display === 'true',
+				throwOnError: false
+			});
+			block.dataset.mathRender = 'client';
+		});
+		if (!document.querySelector('.math-block[data-math-render="pending"]')) {
+			document.documentElement.dataset.mathReady = 'true';
+		}
+	}
+
+	function loadExtensions() {
+		var extension = '%s';
+		if (extension && !window.slicliMhchemLoaded) {
+			loadScript(extension, function() { window.slicliMhchemLoaded = true; typeset(); });
+		} else {
+			typeset();
+		}
+	}
+
+	if (typeof katex === 'undefined') {
+		loadScript('https://cdn.jsdelivr.net/npm/katex@%s/dist/katex.min.js', loadExtensions);
+	} else {
+		loadExtensions();
+	}
+})();
+`, blocks, extensions, katexVersion)
+}
+
+const serverRenderScript = `
+const katex = require('katex');
+const [display, chem] = process.argv.slice(-2);
+if (chem === 'true') require('katex/contrib/mhchem');
+let src = '';
+process.stdin.on('data', d => { src += d; });
+process.stdin.on('end', () => {
+	process.stdout.write(katex.renderToString(src, { displayMode: display === 'true', throwOnError: false }));
+});
+`
+
+var mathStyles = `
+.math-block {
+	margin: 1rem 0;
+	overflow-x: auto;
+	text-align: center;
+}
+
+.math-block[data-math-render="pending"] .math-source {
+	font-family: monospace;
+	white-space: pre-wrap;
+}
+
+/* Print styles */
+@media print {
+	.math-block {
+		break-inside: avoid;
+		page-break-inside: avoid;
+	}
+}
+`
+
+// Export plugin
+var Plugin plugin.Plugin = &MathPlugin{}
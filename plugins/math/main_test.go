@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fredcamaral/slicli/pkg/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMathPlugin_Basic(t *testing.T) {
+	p := &MathPlugin{}
+
+	assert.Equal(t, "math", p.Name())
+	assert.Equal(t, "1.0.0", p.Version())
+	assert.NotEmpty(t, p.Description())
+}
+
+func TestMathPlugin_Init(t *testing.T) {
+	p := &MathPlugin{}
+
+	err := p.Init(map[string]interface{}{"chem": true})
+	require.NoError(t, err)
+	assert.True(t, p.chem)
+	assert.Empty(t, p.nodePath, "server rendering is opt-in")
+}
+
+func TestMathPlugin_Execute(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		input    plugin.PluginInput
+		validate func(t *testing.T, output plugin.PluginOutput)
+	}{
+		{
+			name:  "plain math without mhchem",
+			input: plugin.PluginInput{Content: `E = mc^2`, Language: "math"},
+			validate: func(t *testing.T, output plugin.PluginOutput) {
+				assert.Contains(t, output.HTML, `data-chem="false"`)
+				assert.Contains(t, output.HTML, "E = mc^2")
+				assert.NotContains(t, mathInitAsset(t, output), "mhchem")
+				assert.Equal(t, false, output.Metadata["chem"])
+			},
+		},
+		{
+			name:  "ce expression passes through with chem option",
+			input: plugin.PluginInput{Content: `\ce{H2O}`, Language: "math", Options: map[string]interface{}{"chem": true}},
+			validate: func(t *testing.T, output plugin.PluginOutput) {
+				assert.Contains(t, output.HTML, `\ce{H2O}`)
+				assert.Contains(t, output.HTML, `data-chem="true"`)
+				assert.Contains(t, mathInitAsset(t, output), "contrib/mhchem.min.js")
+				assert.Equal(t, true, output.Metadata["chem"])
+			},
+		},
+		{
+			name:  "chem fence wraps bare formula",
+			input: plugin.PluginInput{Content: "CO2 + C -> 2 CO", Language: "chem"},
+			validate: func(t *testing.T, output plugin.PluginOutput) {
+				assert.Contains(t, output.HTML, `\ce{CO2 + C -&gt; 2 CO}`)
+				assert.Contains(t, mathInitAsset(t, output), "contrib/mhchem.min.js")
+			},
+		},
+		{
+			name:   "chem enabled globally",
+			config: map[string]interface{}{"chem": true},
+			input:  plugin.PluginInput{Content: `\ce{NaCl}`, Language: "math"},
+			validate: func(t *testing.T, output plugin.PluginOutput) {
+				assert.Contains(t, output.HTML, `\ce{NaCl}`)
+				assert.Equal(t, true, output.Metadata["chem"])
+			},
+		},
+		{
+			name:  "client render flagged for export",
+			input: plugin.PluginInput{Content: `x^2`, Language: "math"},
+			validate: func(t *testing.T, output plugin.PluginOutput) {
+				assert.Contains(t, output.HTML, `data-math-render="pending"`)
+				assert.Equal(t, "client", output.Metadata["render"])
+				assert.Equal(t, true, output.Metadata["requires_client_render"])
+				assert.Contains(t, mathInitAsset(t, output), "mathReady")
+			},
+		},
+		{
+			name:  "inline option",
+			input: plugin.PluginInput{Content: `a+b`, Language: "math", Options: map[string]interface{}{"inline": true}},
+			validate: func(t *testing.T, output plugin.PluginOutput) {
+				assert.Contains(t, output.HTML, `data-display="false"`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &MathPlugin{}
+			config := tt.config
+			if config == nil {
+				config = map[string]interface{}{}
+			}
+			require.NoError(t, p.Init(config))
+
+			output, err := p.Execute(context.Background(), tt.input)
+			require.NoError(t, err)
+			tt.validate(t, output)
+		})
+	}
+}
+
+func TestMathPlugin_EmptyInput(t *testing.T) {
+	p := &MathPlugin{}
+	require.NoError(t, p.Init(map[string]interface{}{}))
+
+	_, err := p.Execute(context.Background(), plugin.PluginInput{Content: "   "})
+	assert.Error(t, err)
+}
+
+func TestMathPlugin_ServerRenderFallsBack(t *testing.T) {
+	// A missing node binary must degrade to client rendering
+	p := &MathPlugin{nodePath: "/nonexistent/node", config: map[string]interface{}{}}
+
+	output, err := p.Execute(context.Background(), plugin.PluginInput{Content: `\ce{H2O}`, Language: "chem"})
+	require.NoError(t, err)
+	assert.Equal(t, "client", output.Metadata["render"])
+}
+
+func mathInitAsset(t *testing.T, output plugin.PluginOutput) string {
+	t.Helper()
+	for _, asset := range output.Assets {
+		if strings.HasSuffix(asset.Name, ".js") {
+			return string(asset.Content)
+		}
+	}
+	t.Fatal("math init script not found")
+	return ""
+}
+
+func TestMathPlugin_InitScriptNamedByChem(t *testing.T) {
+	p := &MathPlugin{}
+	require.NoError(t, p.Init(map[string]interface{}{}))
+
+	plain, err := p.Execute(context.Background(), plugin.PluginInput{Content: `x^2`, Language: "math"})
+	require.NoError(t, err)
+	chem, err := p.Execute(context.Background(), plugin.PluginInput{Content: `H2O`, Language: "chem"})
+	require.NoError(t, err)
+
+	scriptName := func(output plugin.PluginOutput) string {
+		for _, asset := range output.Assets {
+			if strings.HasSuffix(asset.Name, ".js") {
+				return asset.Name
+			}
+		}
+		return ""
+	}
+	// Assets are kept once per name, so a deck mixing both needs both scripts
+	assert.NotEqual(t, scriptName(plain), scriptName(chem))
+	assert.Contains(t, mathInitAsset(t, plain), `[data-chem="false"]`, "the plain script leaves chem blocks alone")
+	assert.Contains(t, mathInitAsset(t, chem), "mhchem.min.js")
+}
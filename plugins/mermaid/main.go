@@ -6,18 +6,31 @@ import (
 	"encoding/base64"
 	"fmt"
 	"html"
+	"sync"
 
 	"github.com/fredcamaral/slicli/pkg/plugin"
 )
 
+// maxCachedDiagrams bounds the output cache; it is emptied when full
+const maxCachedDiagrams = 256
+
 type MermaidPlugin struct {
 	config map[string]interface{}
+
+	// cache holds the output of each diagram by its theme and content hash,
+	// so diagrams repeated across slides are only rendered once
+	cache map[string]plugin.PluginOutput
+	mu    sync.Mutex
 }
 
 func (p *MermaidPlugin) Name() string        { return "mermaid" }
 func (p *MermaidPlugin) Version() string     { return "1.0.0" }
 func (p *MermaidPlugin) Description() string { return "Render Mermaid diagrams" }
 
+// SlideIndependent reports that diagrams render the same on every slide, so
+// cached output is shared
+func (p *MermaidPlugin) SlideIndependent() bool { return true }
+
 func (p *MermaidPlugin) Init(config map[string]interface{}) error {
 	p.config = config
 	return nil
@@ -30,6 +43,12 @@ func (p *MermaidPlugin) Execute(ctx context.Context, input plugin.PluginInput) (
 		theme = t
 	}
 
+	// Identical diagrams reuse the output of the first one
+	key := p.generateID(theme + "\x00" + input.Content)
+	if output, ok := p.cached(key); ok {
+		return output, nil
+	}
+
 	// Generate unique ID for diagram
 	diagramID := p.generateID(input.Content)
 
@@ -45,43 +64,80 @@ func (p *MermaidPlugin) Execute(ctx context.Context, input plugin.PluginInput) (
 		</script>
 	`, diagramID, theme, html.EscapeString(input.Content), diagramID)
 
-	// Include Mermaid library and styles
-	assets := []plugin.Asset{
-		{
-			Name:        "mermaid-init.js",
-			Content:     []byte(mermaidInitScript),
-			ContentType: "application/javascript",
-		},
-		{
-			Name:        "mermaid.css",
-			Content:     []byte(mermaidStyles),
-			ContentType: "text/css",
-		},
-	}
-
-	return plugin.PluginOutput{
+	output := plugin.PluginOutput{
 		HTML:   htmlOutput,
-		Assets: assets,
+		Assets: mermaidAssets,
 		Metadata: map[string]interface{}{
 			"type":   "diagram",
 			"engine": "mermaid",
 			"theme":  theme,
 		},
-	}, nil
+	}
+	p.store(key, output)
+	return output, nil
 }
 
 func (p *MermaidPlugin) Cleanup() error {
-	// Clear configuration to free memory
+	// Clear configuration and cached diagrams to free memory
 	p.config = make(map[string]interface{})
-	
+
+	p.mu.Lock()
+	p.cache = nil
+	p.mu.Unlock()
+
 	return nil
 }
 
+// cached returns the stored output of a diagram, marked as a cache hit
+func (p *MermaidPlugin) cached(key string) (plugin.PluginOutput, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	output, ok := p.cache[key]
+	if !ok {
+		return plugin.PluginOutput{}, false
+	}
+	metadata := make(map[string]interface{}, len(output.Metadata)+1)
+	for k, v := range output.Metadata {
+		metadata[k] = v
+	}
+	metadata["cached"] = true
+	output.Metadata = metadata
+	return output, true
+}
+
+// store keeps the output of a diagram for later calls
+func (p *MermaidPlugin) store(key string, output plugin.PluginOutput) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cache == nil || len(p.cache) >= maxCachedDiagrams {
+		p.cache = make(map[string]plugin.PluginOutput)
+	}
+	p.cache[key] = output
+}
+
 func (p *MermaidPlugin) generateID(content string) string {
 	hash := sha256.Sum256([]byte(content))
 	return "mermaid-" + base64.URLEncoding.EncodeToString(hash[:8])
 }
 
+// mermaidAssets are the Mermaid loader and styles every diagram needs. They
+// are built once and shared by all outputs, whose consumers dedupe them by
+// name.
+var mermaidAssets = []plugin.Asset{
+	{
+		Name:        "mermaid-init.js",
+		Content:     []byte(mermaidInitScript),
+		ContentType: "application/javascript",
+	},
+	{
+		Name:        "mermaid.css",
+		Content:     []byte(mermaidStyles),
+		ContentType: "text/css",
+	},
+}
+
 var mermaidInitScript = `
 // Lazy load Mermaid library
 (function() {
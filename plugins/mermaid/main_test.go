@@ -166,6 +166,41 @@ func TestMermaidPlugin_Assets(t *testing.T) {
 	assert.True(t, hasCSS, "Should have CSS asset")
 }
 
+func TestMermaidPlugin_Cache(t *testing.T) {
+	p := &MermaidPlugin{}
+	require.NoError(t, p.Init(nil))
+
+	input := plugin.PluginInput{Content: "graph TD\nA-->B", Language: "mermaid"}
+	first, err := p.Execute(context.Background(), input)
+	require.NoError(t, err)
+	assert.Nil(t, first.Metadata["cached"])
+
+	second, err := p.Execute(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, true, second.Metadata["cached"])
+	assert.Equal(t, first.HTML, second.HTML)
+
+	// Both outputs share the same assets rather than fresh copies
+	require.Len(t, second.Assets, 2)
+	assert.Same(t, &first.Assets[0], &second.Assets[0])
+	assert.Nil(t, first.Metadata["cached"], "cache hits don't change earlier outputs")
+
+	// The theme is part of the key
+	dark, err := p.Execute(context.Background(), plugin.PluginInput{
+		Content: input.Content,
+		Options: map[string]interface{}{"theme": "dark"},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, dark.Metadata["cached"])
+	assert.Contains(t, dark.HTML, `data-theme="dark"`)
+
+	require.NoError(t, p.Cleanup())
+	assert.Empty(t, p.cache)
+	third, err := p.Execute(context.Background(), input)
+	require.NoError(t, err)
+	assert.Nil(t, third.Metadata["cached"])
+}
+
 func TestMermaidPlugin_Cleanup(t *testing.T) {
 	p := &MermaidPlugin{}
 	
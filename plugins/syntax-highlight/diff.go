@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	stdhtml "html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fredcamaral/slicli/pkg/plugin"
+)
+
+// diffLanguage is the language rendered as a diff rather than through chroma
+const diffLanguage = "diff"
+
+// hunkRange matches the line counts of a hunk header, "@@ -1,4 +1,5 @@",
+// where a missing count means one line
+var hunkRange = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+
+// diffClassifier classes the lines of a unified diff in order. It follows
+// the line counts of each hunk, so a removed line reading "-- x" isn't taken
+// for the "--- a/file" header of the next file.
+type diffClassifier struct {
+	inHunk   bool
+	bounded  bool // Whether the hunk header gave its line counts
+	oldLines int  // Lines left in the hunk on the old side
+	newLines int  // Lines left in the hunk on the new side
+}
+
+// class returns the class of the next line. File headers are told apart from
+// added and removed lines so they aren't counted as changes.
+func (d *diffClassifier) class(line string) string {
+	switch {
+	case strings.HasPrefix(line, "diff "):
+		d.inHunk = false
+		return "diff-meta"
+	case strings.HasPrefix(line, "@@"):
+		d.startHunk(line)
+		return "diff-hunk"
+	case !d.inHunk && (strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "index ")):
+		return "diff-meta"
+	}
+
+	class := "diff-context"
+	switch {
+	case strings.HasPrefix(line, "+"):
+		class = "diff-add"
+		d.newLines--
+	case strings.HasPrefix(line, "-"):
+		class = "diff-del"
+		d.oldLines--
+	case strings.HasPrefix(line, `\`):
+		// "\ No newline at end of file" belongs to neither side
+	default:
+		d.oldLines--
+		d.newLines--
+	}
+	if d.bounded && d.oldLines <= 0 && d.newLines <= 0 {
+		d.inHunk = false
+	}
+	return class
+}
+
+// startHunk reads the line counts of a hunk header. Headers without them,
+// as in hand-written diffs, start a hunk running to the next file.
+func (d *diffClassifier) startHunk(header string) {
+	d.inHunk = true
+	match := hunkRange.FindStringSubmatch(header)
+	d.bounded = match != nil
+	if !d.bounded {
+		return
+	}
+	d.oldLines, d.newLines = hunkLineCount(match[1]), hunkLineCount(match[2])
+	if d.oldLines == 0 && d.newLines == 0 {
+		d.inHunk = false
+	}
+}
+
+// hunkLineCount parses a hunk header's line count, one when it's omitted
+func hunkLineCount(count string) int {
+	if count == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(count)
+	return n
+}
+
+// renderDiff renders a unified diff with one span per line, classed by
+// whether the line was added, removed, or starts a hunk. With the cleanDiff
+// option the +, - and space markers are dropped, leaving the color alone to
+// tell lines apart.
+func (p *SyntaxHighlightPlugin) renderDiff(input plugin.PluginInput) plugin.PluginOutput {
+	clean, _ := input.Options["cleanDiff"].(bool)
+	lines := strings.Split(strings.TrimSuffix(input.Content, "\n"), "\n")
+
+	var code strings.Builder
+	var added, removed int
+	var classifier diffClassifier
+	for _, line := range lines {
+		class := classifier.class(line)
+		switch class {
+		case "diff-add":
+			added++
+		case "diff-del":
+			removed++
+		}
+		if clean && (class == "diff-add" || class == "diff-del" || strings.HasPrefix(line, " ")) {
+			line = line[1:]
+		}
+		fmt.Fprintf(&code, `<span class="diff-line %s">%s</span>`, class, stdhtml.EscapeString(line))
+	}
+
+	htmlOutput := fmt.Sprintf(`
+		<div class="code-block code-diff" data-language="%s">
+			<div class="code-header">
+				<span class="code-language">%s</span>
+				<span class="diff-stats"><span class="diff-stats-add">+%d</span> <span class="diff-stats-del">-%d</span></span>
+			</div>
+			<pre class="diff-view"><code>%s</code></pre>
+		</div>
+	`, diffLanguage, diffLanguage, added, removed, code.String())
+
+	return plugin.PluginOutput{
+		HTML: htmlOutput,
+		Assets: []plugin.Asset{
+			{
+				Name:        "code-block.css",
+				Content:     []byte(codeBlockStyles),
+				ContentType: "text/css",
+			},
+			{
+				Name:        "diff.css",
+				Content:     []byte(diffStyles),
+				ContentType: "text/css",
+			},
+		},
+		Metadata: map[string]interface{}{
+			"language": diffLanguage,
+			"lines":    len(lines),
+			"added":    added,
+			"removed":  removed,
+		},
+	}
+}
+
+var diffStyles = `
+.diff-view code {
+	display: block;
+	min-width: max-content;
+}
+
+.diff-line {
+	display: block;
+	min-height: 1.4em;
+	padding: 0 0.5rem;
+	border-left: 4px solid transparent;
+	white-space: pre;
+}
+
+.diff-add {
+	background-color: #dafbe1;
+	border-left-color: #2da44e;
+	color: #116329;
+}
+
+.diff-del {
+	background-color: #ffebe9;
+	border-left-color: #cf222e;
+	color: #82071e;
+}
+
+.diff-hunk {
+	background-color: #ddf4ff;
+	color: #0969da;
+	font-weight: 600;
+}
+
+.diff-meta {
+	color: #57606a;
+	font-weight: 600;
+}
+
+.diff-stats {
+	float: right;
+	font-weight: 600;
+}
+
+.diff-stats-add {
+	color: #2da44e;
+}
+
+.diff-stats-del {
+	color: #cf222e;
+}
+
+/* Dark theme adjustments */
+.theme-dark .diff-add {
+	background-color: rgba(46, 160, 67, 0.2);
+	color: #7ee787;
+}
+
+.theme-dark .diff-del {
+	background-color: rgba(248, 81, 73, 0.2);
+	color: #ffa198;
+}
+
+.theme-dark .diff-hunk {
+	background-color: rgba(56, 139, 253, 0.15);
+	color: #79c0ff;
+}
+
+.theme-dark .diff-meta {
+	color: #8b949e;
+}
+
+/* Print styles */
+@media print {
+	.diff-add,
+	.diff-del {
+		-webkit-print-color-adjust: exact;
+		print-color-adjust: exact;
+	}
+}
+`
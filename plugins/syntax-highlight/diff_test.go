@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/fredcamaral/slicli/pkg/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleDiff = `diff --git a/greet.go b/greet.go
+--- a/greet.go
++++ b/greet.go
+@@ -1,4 +1,4 @@
+ func greet(name string) string {
+-	return "Hello " + name
++	return "Hello, " + name + "!"
++	// <unreachable>
+ }
+`
+
+func TestSyntaxHighlightPlugin_Diff(t *testing.T) {
+	p := &SyntaxHighlightPlugin{}
+	require.NoError(t, p.Init(nil))
+
+	output, err := p.Execute(context.Background(), plugin.PluginInput{Content: sampleDiff, Language: "diff"})
+	require.NoError(t, err)
+
+	assert.Contains(t, output.HTML, `data-language="diff"`)
+	assert.Contains(t, output.HTML, `<span class="diff-line diff-hunk">@@ -1,4 +1,4 @@</span>`)
+	assert.Contains(t, output.HTML, `<span class="diff-line diff-del">-	return &#34;Hello &#34; + name</span>`)
+	assert.Contains(t, output.HTML, `<span class="diff-line diff-add">+	// &lt;unreachable&gt;</span>`)
+	assert.Contains(t, output.HTML, `<span class="diff-line diff-meta">+++ b/greet.go</span>`)
+	assert.Contains(t, output.HTML, `<span class="diff-line diff-context"> }</span>`)
+
+	assert.Equal(t, 2, output.Metadata["added"])
+	assert.Equal(t, 1, output.Metadata["removed"])
+	assert.Equal(t, 9, output.Metadata["lines"])
+
+	var names []string
+	for _, asset := range output.Assets {
+		names = append(names, asset.Name)
+		if asset.Name == "diff.css" {
+			assert.Equal(t, "text/css", asset.ContentType)
+			assert.Contains(t, string(asset.Content), ".diff-add")
+		}
+	}
+	assert.ElementsMatch(t, []string{"code-block.css", "diff.css"}, names)
+
+	t.Run("patch alias", func(t *testing.T) {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{Content: sampleDiff, Language: "patch"})
+		require.NoError(t, err)
+		assert.Contains(t, output.HTML, "diff-add")
+	})
+
+	t.Run("cleanDiff strips markers", func(t *testing.T) {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content:  sampleDiff,
+			Language: "diff",
+			Options:  map[string]interface{}{"cleanDiff": true},
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, output.HTML, `<span class="diff-line diff-del">	return &#34;Hello &#34; + name</span>`)
+		assert.Contains(t, output.HTML, `<span class="diff-line diff-context">}</span>`)
+		assert.Contains(t, output.HTML, `<span class="diff-line diff-hunk">@@ -1,4 +1,4 @@</span>`)
+		assert.Contains(t, output.HTML, `<span class="diff-line diff-meta">+++ b/greet.go</span>`)
+		assert.False(t, strings.Contains(output.HTML, `diff-add">+`))
+	})
+}
+
+func TestSyntaxHighlightPlugin_DiffHeadersOnlyBeforeHunks(t *testing.T) {
+	p := &SyntaxHighlightPlugin{}
+	require.NoError(t, p.Init(nil))
+
+	// A removed SQL comment and an added "++ counter" line look like file
+	// headers, but fall inside their hunks
+	content := `--- a/schema.sql
++++ b/schema.sql
+@@ -1,2 +1,2 @@
+--- old comment
++++ counter
+ SELECT 1;
+--- a/seed.sql
++++ b/seed.sql
+@@ -1 +1 @@
+-INSERT 1;
++INSERT 2;
+`
+	output, err := p.Execute(context.Background(), plugin.PluginInput{Content: content, Language: "diff"})
+	require.NoError(t, err)
+
+	assert.Contains(t, output.HTML, `<span class="diff-line diff-del">--- old comment</span>`)
+	assert.Contains(t, output.HTML, `<span class="diff-line diff-add">+++ counter</span>`)
+	assert.Contains(t, output.HTML, `<span class="diff-line diff-meta">--- a/seed.sql</span>`)
+	assert.Contains(t, output.HTML, `<span class="diff-line diff-meta">+++ b/seed.sql</span>`)
+	assert.Equal(t, 2, output.Metadata["added"])
+	assert.Equal(t, 2, output.Metadata["removed"])
+
+	t.Run("hunk headers without counts run to the next file", func(t *testing.T) {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content:  "@@\n--- gone\n+kept\ndiff --git a/b b/b\n--- a/b\n",
+			Language: "diff",
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output.HTML, `<span class="diff-line diff-del">--- gone</span>`)
+		assert.Contains(t, output.HTML, `<span class="diff-line diff-meta">--- a/b</span>`)
+	})
+}
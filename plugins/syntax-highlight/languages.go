@@ -19,6 +19,7 @@ var languageAliases = map[string]string{
 	"f#":         "fsharp",
 	"objc":       "objective-c",
 	"md":         "markdown",
+	"patch":      "diff",
 
 	// Web technologies
 	"htm":  "html",
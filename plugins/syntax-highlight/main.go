@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	stdhtml "html"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -14,16 +16,45 @@ import (
 	"github.com/fredcamaral/slicli/pkg/plugin"
 )
 
+// Wrap modes for code lines that exceed the slide width
+const (
+	wrapNone     = "none"
+	wrapSoft     = "soft"
+	wrapTruncate = "truncate"
+)
+
+// defaultMaxLineLength is the column at which truncate mode cuts lines
+const defaultMaxLineLength = 80
+
+// defaultStyle is the chroma style used when no theme is configured
+const defaultStyle = "github"
+
+// plainText is the language used when none is given and none can be detected
+const plainText = "text"
+
+// How a block's language was chosen, reported as language_source
+const (
+	languageExplicit = "explicit" // Given on the code fence
+	languageAlias    = "alias"    // Given as an alias, such as js for javascript
+	languageGuessed  = "guessed"  // Detected from the code itself
+)
+
 type SyntaxHighlightPlugin struct {
-	config    map[string]interface{}
-	formatter *html.Formatter
-	mu        sync.RWMutex
+	config       map[string]interface{}
+	formatter    *html.Formatter
+	wrap         string
+	defaultTheme string
+	mu           sync.RWMutex
 }
 
 func (p *SyntaxHighlightPlugin) Name() string        { return "syntax-highlight" }
 func (p *SyntaxHighlightPlugin) Version() string     { return "1.0.0" }
 func (p *SyntaxHighlightPlugin) Description() string { return "Syntax highlighting for code blocks" }
 
+// SlideIndependent reports that code highlights the same on every slide, so
+// cached output is shared
+func (p *SyntaxHighlightPlugin) SlideIndependent() bool { return true }
+
 func (p *SyntaxHighlightPlugin) Init(config map[string]interface{}) error {
 	p.config = config
 
@@ -34,41 +65,83 @@ func (p *SyntaxHighlightPlugin) Init(config map[string]interface{}) error {
 		html.TabWidth(4),
 	)
 
+	p.wrap = wrapNone
+	if mode, ok := parseWrapMode(config["wrap"]); ok {
+		p.wrap = mode
+	}
+
+	p.defaultTheme = defaultStyle
+	if value, ok := config["default_theme"]; ok {
+		theme, isString := value.(string)
+		if !isString || !isStyle(theme) {
+			return fmt.Errorf("unknown default_theme %v (available: %s)", value, strings.Join(styles.Names(), ", "))
+		}
+		p.defaultTheme = theme
+	}
+
 	return nil
 }
 
+// isStyle reports whether name is a registered chroma style
+func isStyle(name string) bool {
+	for _, style := range styles.Names() {
+		if style == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *SyntaxHighlightPlugin) Execute(ctx context.Context, input plugin.PluginInput) (plugin.PluginOutput, error) {
-	// Get language
+	// Get language, noting whether it was given, an alias or a guess
 	language := input.Language
+	source := languageExplicit
+	var confidence float32
 	if language == "" {
-		language = p.detectLanguage(input.Content)
+		source = languageGuessed
+		language, confidence = analyseLanguage(input.Content)
 	}
 
 	// Resolve any aliases
-	language = resolveLanguage(language)
+	if resolved := resolveLanguage(language); resolved != language {
+		if source == languageExplicit {
+			source = languageAlias
+		}
+		language = resolved
+	}
+	if language == diffLanguage {
+		result := p.renderDiff(input)
+		addLanguageSource(result.Metadata, source, confidence)
+		return result, nil
+	}
 
 	// Get lexer
 	lexer := getLexer(language)
 
-	// Get style
-	styleName := "github"
+	// Get style, preferring the block's theme over the configured default
+	styleName := p.defaultTheme
+	if styleName == "" {
+		styleName = defaultStyle
+	}
 	if s, ok := input.Options["theme"].(string); ok {
 		styleName = s
 	}
 
-	style := styles.Get(styleName)
-	if style == nil {
-		style = styles.Fallback
-	}
+	wrap := p.wrapMode(input.Options)
+	highlight := highlightRanges(input.Options)
+	lineNumbers := p.shouldShowLineNumbers(input.Options)
 
-	// Configure formatter options
-	options := []html.Option{
-		html.WithLineNumbers(p.shouldShowLineNumbers(input.Options)),
-		html.WithClasses(true), // Use CSS classes instead of inline styles
-		html.PreventSurroundingPre(false),
+	cached, err := getFormatter(styleName, lineNumbers, wrap == wrapSoft)
+	if err != nil {
+		return plugin.PluginOutput{}, err
 	}
+	style := cached.style
 
-	formatter := html.New(options...)
+	// Highlighted lines differ per block, so those formatters aren't cached
+	formatter := cached.formatter
+	if len(highlight) > 0 {
+		formatter = html.New(append(formatterOptions(lineNumbers, wrap == wrapSoft), html.HighlightLines(highlight))...)
+	}
 
 	// Tokenize and format
 	var output strings.Builder
@@ -82,28 +155,35 @@ func (p *SyntaxHighlightPlugin) Execute(ctx context.Context, input plugin.Plugin
 		return plugin.PluginOutput{}, fmt.Errorf("formatting code: %w", err)
 	}
 
+	code := output.String()
+	containerClass := "code-block"
+	containerStyle := ""
+	switch wrap {
+	case wrapSoft:
+		containerClass += " code-wrap-soft"
+	case wrapTruncate:
+		maxLength := maxLineLength(input.Options)
+		containerClass += " code-wrap-truncate"
+		containerStyle = fmt.Sprintf(` style="--code-max-line: %dch"`, maxLength)
+		code = addLineTooltips(code, input.Content, maxLength)
+	}
+
 	// Wrap in container
 	htmlOutput := fmt.Sprintf(`
-		<div class="code-block" data-language="%s">
+		<div class="%s" data-language="%s"%s>
 			<div class="code-header">
 				<span class="code-language">%s</span>
 			</div>
 			%s
 		</div>
-	`, stdhtml.EscapeString(language), stdhtml.EscapeString(language), output.String())
-
-	// Generate CSS for the style
-	var cssBuilder strings.Builder
-	if err := formatter.WriteCSS(&cssBuilder, style); err != nil {
-		return plugin.PluginOutput{}, fmt.Errorf("failed to generate CSS: %w", err)
-	}
+	`, containerClass, stdhtml.EscapeString(language), containerStyle, stdhtml.EscapeString(language), code)
 
-	return plugin.PluginOutput{
+	result := plugin.PluginOutput{
 		HTML: htmlOutput,
 		Assets: []plugin.Asset{
 			{
 				Name:        fmt.Sprintf("highlight-%s.css", styleName),
-				Content:     []byte(cssBuilder.String()),
+				Content:     []byte(cached.css),
 				ContentType: "text/css",
 			},
 			{
@@ -113,11 +193,15 @@ func (p *SyntaxHighlightPlugin) Execute(ctx context.Context, input plugin.Plugin
 			},
 		},
 		Metadata: map[string]interface{}{
-			"language": language,
-			"lines":    strings.Count(input.Content, "\n") + 1,
-			"style":    styleName,
+			"language":  language,
+			"lines":     strings.Count(input.Content, "\n") + 1,
+			"style":     styleName,
+			"wrap":      wrap,
+			"highlight": highlight,
 		},
-	}, nil
+	}
+	addLanguageSource(result.Metadata, source, confidence)
+	return result, nil
 }
 
 func (p *SyntaxHighlightPlugin) Cleanup() error {
@@ -134,16 +218,46 @@ func (p *SyntaxHighlightPlugin) Cleanup() error {
 	lexerMu.Lock()
 	lexerCache = make(map[string]chroma.Lexer)
 	lexerMu.Unlock()
+
+	// Clear global style and formatter cache
+	formatterMu.Lock()
+	formatterCache = make(map[formatterKey]*cachedFormatter)
+	formatterMu.Unlock()
 	
 	return nil
 }
 
 func (p *SyntaxHighlightPlugin) detectLanguage(content string) string {
+	language, _ := analyseLanguage(content)
+	return language
+}
+
+// analyseLanguage guesses the language of content along with chroma's
+// confidence in the guess, falling back to plain text with no confidence
+func analyseLanguage(content string) (string, float32) {
 	lexer := lexers.Analyse(content)
-	if lexer != nil {
-		return lexer.Config().Name
+	if lexer == nil {
+		return plainText, 0
 	}
-	return "text"
+	var confidence float32
+	if analyser, ok := lexer.(chroma.Analyser); ok {
+		confidence = analyser.AnalyseText(content)
+	}
+	return lexer.Config().Name, confidence
+}
+
+// addLanguageSource records how a block's language was chosen, so authors
+// can spot blocks that are missing a language tag
+func addLanguageSource(metadata map[string]interface{}, source string, confidence float32) {
+	metadata["language_source"] = source
+	if source != languageGuessed {
+		return
+	}
+	if confidence == 0 {
+		metadata["detection"] = "failed"
+		return
+	}
+	metadata["detection_confidence"] = confidence
 }
 
 func (p *SyntaxHighlightPlugin) shouldShowLineNumbers(options map[string]interface{}) bool {
@@ -154,6 +268,108 @@ func (p *SyntaxHighlightPlugin) shouldShowLineNumbers(options map[string]interfa
 	return true
 }
 
+// wrapMode returns the per-block wrap option, falling back to the configured default
+func (p *SyntaxHighlightPlugin) wrapMode(options map[string]interface{}) string {
+	if mode, ok := parseWrapMode(options["wrap"]); ok {
+		return mode
+	}
+	if p.wrap == "" {
+		return wrapNone
+	}
+	return p.wrap
+}
+
+// parseWrapMode accepts a mode name or a boolean (true meaning soft wrap)
+func parseWrapMode(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return wrapSoft, true
+		}
+		return wrapNone, true
+	case string:
+		switch mode := strings.ToLower(strings.TrimSpace(v)); mode {
+		case wrapNone, wrapSoft, wrapTruncate:
+			return mode, true
+		case "wrap", "true":
+			return wrapSoft, true
+		case "false", "":
+			return wrapNone, true
+		}
+	}
+	return "", false
+}
+
+// maxLineLength returns the truncation column from options
+func maxLineLength(options map[string]interface{}) int {
+	switch v := options["max_line_length"].(type) {
+	case int:
+		if v > 0 {
+			return v
+		}
+	case float64:
+		if v > 0 {
+			return int(v)
+		}
+	}
+	return defaultMaxLineLength
+}
+
+// highlightRanges parses the highlight option, such as "3-5,8", into the
+// inclusive line ranges to emphasize. A malformed value highlights nothing.
+func highlightRanges(options map[string]interface{}) [][2]int {
+	var spec string
+	switch v := options["highlight"].(type) {
+	case string:
+		spec = v
+	case int:
+		spec = strconv.Itoa(v)
+	case float64:
+		spec = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return nil
+	}
+
+	var ranges [][2]int
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		startText, endText, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(startText))
+		if err != nil || start < 1 {
+			return nil
+		}
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(strings.TrimSpace(endText))
+			if err != nil || end < start {
+				return nil
+			}
+		}
+		ranges = append(ranges, [2]int{start, end})
+	}
+	return ranges
+}
+
+var lineSpanPattern = regexp.MustCompile(`<span class="line( hl)?">`)
+
+// addLineTooltips adds the full source line as a tooltip to lines longer than maxLength.
+// Truncation itself is done in CSS so the DOM keeps the full text for copying.
+func addLineTooltips(code, source string, maxLength int) string {
+	lines := strings.Split(source, "\n")
+	index := 0
+	return lineSpanPattern.ReplaceAllStringFunc(code, func(span string) string {
+		defer func() { index++ }()
+		if index >= len(lines) || len([]rune(lines[index])) <= maxLength {
+			return span
+		}
+		return strings.TrimSuffix(span, ">") + fmt.Sprintf(` title="%s">`, stdhtml.EscapeString(lines[index]))
+	})
+}
+
 // Lexer cache for performance
 var (
 	lexerCache = make(map[string]chroma.Lexer)
@@ -187,6 +403,72 @@ func getLexer(language string) chroma.Lexer {
 	return lexer
 }
 
+// formatterKey identifies a formatter configuration
+type formatterKey struct {
+	style       string
+	lineNumbers bool
+	wrapSoft    bool
+}
+
+// cachedFormatter is a compiled style with its formatter and stylesheet
+type cachedFormatter struct {
+	style     *chroma.Style
+	formatter *html.Formatter
+	css       string
+}
+
+// Style and formatter cache, so repeated blocks reuse compiled styles
+var (
+	formatterCache = make(map[formatterKey]*cachedFormatter)
+	formatterMu    sync.RWMutex
+)
+
+// formatterOptions returns the formatter options shared by every block
+func formatterOptions(lineNumbers, wrapSoft bool) []html.Option {
+	return []html.Option{
+		html.WithLineNumbers(lineNumbers),
+		html.WithClasses(true), // Use CSS classes instead of inline styles
+		html.PreventSurroundingPre(false),
+		html.WrapLongLines(wrapSoft),
+	}
+}
+
+func getFormatter(styleName string, lineNumbers, wrapSoft bool) (*cachedFormatter, error) {
+	key := formatterKey{style: styleName, lineNumbers: lineNumbers, wrapSoft: wrapSoft}
+
+	formatterMu.RLock()
+	cached, ok := formatterCache[key]
+	formatterMu.RUnlock()
+
+	if ok {
+		return cached, nil
+	}
+
+	formatterMu.Lock()
+	defer formatterMu.Unlock()
+
+	// Double-check after acquiring write lock
+	if cached, ok = formatterCache[key]; ok {
+		return cached, nil
+	}
+
+	style := styles.Get(styleName)
+	if style == nil {
+		style = styles.Fallback
+	}
+	formatter := html.New(formatterOptions(lineNumbers, wrapSoft)...)
+
+	// Generate CSS for the style
+	var css strings.Builder
+	if err := formatter.WriteCSS(&css, style); err != nil {
+		return nil, fmt.Errorf("failed to generate CSS: %w", err)
+	}
+
+	cached = &cachedFormatter{style: style, formatter: formatter, css: css.String()}
+	formatterCache[key] = cached
+	return cached, nil
+}
+
 var codeBlockStyles = `
 .code-block {
 	margin: 1rem 0;
@@ -220,6 +502,30 @@ var codeBlockStyles = `
 	background-color: transparent;
 }
 
+/* Long line handling */
+.code-wrap-soft .chroma .line,
+.code-wrap-truncate .chroma .line {
+	display: flex;
+}
+
+.code-wrap-soft .chroma .cl {
+	flex: 1;
+	min-width: 0;
+	white-space: pre-wrap;
+	overflow-wrap: anywhere;
+	/* Hanging indent marks continuation lines */
+	padding-left: 2ch;
+	text-indent: -2ch;
+}
+
+.code-wrap-truncate .chroma .cl {
+	display: block;
+	max-width: var(--code-max-line, 80ch);
+	overflow: hidden;
+	text-overflow: ellipsis;
+	white-space: pre;
+}
+
 /* Line numbers styling */
 .code-block .line-numbers {
 	user-select: none;
@@ -2,9 +2,11 @@ package main
 
 import (
 	"context"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/alecthomas/chroma/v2/styles"
 	"github.com/fredcamaral/slicli/pkg/plugin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,6 +31,57 @@ func TestSyntaxHighlightPlugin_Init(t *testing.T) {
 	assert.NotNil(t, p.formatter)
 }
 
+func TestSyntaxHighlightPlugin_DefaultTheme(t *testing.T) {
+	styleCSS := func(output plugin.PluginOutput) (string, string) {
+		for _, asset := range output.Assets {
+			if strings.HasPrefix(asset.Name, "highlight-") {
+				return asset.Name, string(asset.Content)
+			}
+		}
+		return "", ""
+	}
+
+	input := plugin.PluginInput{Content: "x := 1", Language: "go"}
+
+	p := &SyntaxHighlightPlugin{}
+	require.NoError(t, p.Init(map[string]interface{}{"default_theme": "dracula"}))
+
+	output, err := p.Execute(context.Background(), input)
+	require.NoError(t, err)
+	name, css := styleCSS(output)
+	assert.Equal(t, "highlight-dracula.css", name)
+	assert.Contains(t, css, "#282a36", "dracula background")
+
+	t.Run("block options still win", func(t *testing.T) {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content:  input.Content,
+			Language: input.Language,
+			Options:  map[string]interface{}{"theme": "monokai"},
+		})
+		require.NoError(t, err)
+		name, _ := styleCSS(output)
+		assert.Equal(t, "highlight-monokai.css", name)
+	})
+
+	t.Run("github without configuration", func(t *testing.T) {
+		p := &SyntaxHighlightPlugin{}
+		require.NoError(t, p.Init(nil))
+		output, err := p.Execute(context.Background(), input)
+		require.NoError(t, err)
+		name, _ := styleCSS(output)
+		assert.Equal(t, "highlight-github.css", name)
+	})
+
+	t.Run("unknown theme is rejected", func(t *testing.T) {
+		p := &SyntaxHighlightPlugin{}
+		err := p.Init(map[string]interface{}{"default_theme": "no-such-theme"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no-such-theme")
+
+		assert.Error(t, p.Init(map[string]interface{}{"default_theme": 42}))
+	})
+}
+
 func TestSyntaxHighlightPlugin_Execute(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -162,6 +215,58 @@ greet("World");`,
 	}
 }
 
+func TestSyntaxHighlightPlugin_LanguageSource(t *testing.T) {
+	p := &SyntaxHighlightPlugin{}
+	require.NoError(t, p.Init(nil))
+
+	t.Run("explicit language", func(t *testing.T) {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content:  `fmt.Println("hi")`,
+			Language: "go",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "explicit", output.Metadata["language_source"])
+		assert.NotContains(t, output.Metadata, "detection")
+		assert.NotContains(t, output.Metadata, "detection_confidence")
+	})
+
+	t.Run("alias", func(t *testing.T) {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content:  `print("hi")`,
+			Language: "py",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "python", output.Metadata["language"])
+		assert.Equal(t, "alias", output.Metadata["language_source"])
+	})
+
+	t.Run("detectable snippet", func(t *testing.T) {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content: "#!/bin/bash\necho hello",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Bash", output.Metadata["language"])
+		assert.Equal(t, "guessed", output.Metadata["language_source"])
+		assert.Greater(t, output.Metadata["detection_confidence"], float32(0))
+		assert.NotContains(t, output.Metadata, "detection")
+	})
+
+	t.Run("undetectable snippet", func(t *testing.T) {
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content: "just a few words of prose",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "text", output.Metadata["language"])
+		assert.Equal(t, "guessed", output.Metadata["language_source"])
+		assert.Equal(t, "failed", output.Metadata["detection"])
+		assert.NotContains(t, output.Metadata, "detection_confidence")
+	})
+}
+
 func TestSyntaxHighlightPlugin_DetectLanguage(t *testing.T) {
 	p := &SyntaxHighlightPlugin{}
 
@@ -242,6 +347,11 @@ func TestSyntaxHighlightPlugin_Cleanup(t *testing.T) {
 	// Populate lexer cache by getting a lexer
 	_ = getLexer("go")
 	assert.Greater(t, len(lexerCache), 0, "Lexer cache should have entries")
+
+	// Populate formatter cache by highlighting a block
+	_, err = p.Execute(context.Background(), plugin.PluginInput{Content: "x := 1", Language: "go"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, formatterCache, "Formatter cache should have entries")
 	
 	// Cleanup
 	err = p.Cleanup()
@@ -251,6 +361,91 @@ func TestSyntaxHighlightPlugin_Cleanup(t *testing.T) {
 	assert.Empty(t, p.config, "Config should be cleared after cleanup")
 	assert.Nil(t, p.formatter, "Formatter should be nil after cleanup")
 	assert.Empty(t, lexerCache, "Lexer cache should be cleared after cleanup")
+	assert.Empty(t, formatterCache, "Formatter cache should be cleared after cleanup")
+}
+
+func TestSyntaxHighlightPlugin_Wrap(t *testing.T) {
+	longLine := `fmt.Println("` + strings.Repeat("a very long string ", 10) + `")`
+	content := "package main\n" + longLine
+
+	codeBlockCSS := func(output plugin.PluginOutput) string {
+		for _, asset := range output.Assets {
+			if asset.Name == "code-block.css" {
+				return string(asset.Content)
+			}
+		}
+		return ""
+	}
+
+	t.Run("defaults to no wrap", func(t *testing.T) {
+		p := &SyntaxHighlightPlugin{}
+		require.NoError(t, p.Init(map[string]interface{}{}))
+
+		output, err := p.Execute(context.Background(), plugin.PluginInput{Content: content, Language: "go"})
+		require.NoError(t, err)
+		assert.NotContains(t, output.HTML, "code-wrap-")
+		assert.NotContains(t, output.HTML, "title=")
+		assert.Equal(t, "none", output.Metadata["wrap"])
+	})
+
+	t.Run("soft wrap per block", func(t *testing.T) {
+		p := &SyntaxHighlightPlugin{}
+		require.NoError(t, p.Init(map[string]interface{}{}))
+
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content:  content,
+			Language: "go",
+			Options:  map[string]interface{}{"wrap": "soft"},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output.HTML, `class="code-block code-wrap-soft"`)
+		assert.Contains(t, codeBlockCSS(output), ".code-wrap-soft .chroma .cl")
+		assert.Contains(t, codeBlockCSS(output), "text-indent: -2ch")
+		assert.Contains(t, string(output.Assets[0].Content), "pre-wrap")
+		assert.Equal(t, "soft", output.Metadata["wrap"])
+	})
+
+	t.Run("soft wrap from config default", func(t *testing.T) {
+		p := &SyntaxHighlightPlugin{}
+		require.NoError(t, p.Init(map[string]interface{}{"wrap": true}))
+
+		output, err := p.Execute(context.Background(), plugin.PluginInput{Content: content, Language: "go"})
+		require.NoError(t, err)
+		assert.Contains(t, output.HTML, "code-wrap-soft")
+	})
+
+	t.Run("per block overrides config default", func(t *testing.T) {
+		p := &SyntaxHighlightPlugin{}
+		require.NoError(t, p.Init(map[string]interface{}{"wrap": "soft"}))
+
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content:  content,
+			Language: "go",
+			Options:  map[string]interface{}{"wrap": "none"},
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, output.HTML, "code-wrap-")
+	})
+
+	t.Run("truncate keeps full text and adds tooltip", func(t *testing.T) {
+		p := &SyntaxHighlightPlugin{}
+		require.NoError(t, p.Init(map[string]interface{}{}))
+
+		output, err := p.Execute(context.Background(), plugin.PluginInput{
+			Content:  content,
+			Language: "go",
+			Options:  map[string]interface{}{"wrap": "truncate", "max_line_length": 40},
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output.HTML, `class="code-block code-wrap-truncate"`)
+		assert.Contains(t, output.HTML, "--code-max-line: 40ch")
+		assert.Contains(t, codeBlockCSS(output), "text-overflow: ellipsis")
+
+		// Only the long line gets a tooltip, with the untruncated source
+		assert.Equal(t, 1, strings.Count(output.HTML, "title="))
+		assert.Contains(t, output.HTML, `title="fmt.Println(&#34;a very long string`)
+		assert.Equal(t, 20, strings.Count(output.HTML, "a very long string"), "full text in both the line and its tooltip")
+	})
 }
 
 func TestResolveLanguage(t *testing.T) {
@@ -287,3 +482,115 @@ func TestGetLexer(t *testing.T) {
 	lexer3 := getLexer("unknown-language-xyz")
 	assert.NotNil(t, lexer3)
 }
+
+func TestGetFormatter(t *testing.T) {
+	formatter1, err := getFormatter("monokai", true, false)
+	require.NoError(t, err)
+	formatter2, err := getFormatter("monokai", true, false)
+	require.NoError(t, err)
+
+	// Should be the same instance due to caching
+	assert.Same(t, formatter1, formatter2)
+	assert.Equal(t, "monokai", formatter1.style.Name)
+	assert.Contains(t, formatter1.css, ".chroma")
+
+	// Line numbers and soft wrap get their own formatters
+	withoutNumbers, err := getFormatter("monokai", false, false)
+	require.NoError(t, err)
+	assert.NotSame(t, formatter1, withoutNumbers)
+	softWrap, err := getFormatter("monokai", true, true)
+	require.NoError(t, err)
+	assert.NotSame(t, formatter1, softWrap)
+	assert.Contains(t, softWrap.css, "pre-wrap")
+
+	// Test fallback for unknown style
+	unknown, err := getFormatter("unknown-style-xyz", true, false)
+	require.NoError(t, err)
+	assert.Equal(t, styles.Fallback, unknown.style)
+}
+
+func BenchmarkExecuteSameStyle(b *testing.B) {
+	p := &SyntaxHighlightPlugin{}
+	require.NoError(b, p.Init(map[string]interface{}{}))
+	input := plugin.PluginInput{
+		Content:  "package main\n\nfunc main() {\n\tprintln(\"hi\")\n}\n",
+		Language: "go",
+		Options:  map[string]interface{}{"theme": "dracula"},
+	}
+
+	// uncached empties the formatter cache before each block, as every
+	// block used to compile its style and formatter from scratch
+	for _, mode := range []string{"cached", "uncached"} {
+		b.Run(mode, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if mode == "uncached" {
+					formatterMu.Lock()
+					formatterCache = make(map[formatterKey]*cachedFormatter)
+					formatterMu.Unlock()
+				}
+				if _, err := p.Execute(context.Background(), input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestSyntaxHighlightPlugin_Highlight(t *testing.T) {
+	lineSpan := regexp.MustCompile(`(?s)<span class="line( hl)?">(?:<span class="ln">\s*\d+\s*</span>)?<span class="cl">(.*?)</span>`)
+	tag := regexp.MustCompile(`<[^>]*>`)
+	highlighted := func(output plugin.PluginOutput) map[string]bool {
+		lines := make(map[string]bool)
+		for _, match := range lineSpan.FindAllStringSubmatch(output.HTML, -1) {
+			text := tag.ReplaceAllString(match[2], "")
+			lines[strings.TrimSpace(text)] = match[1] != ""
+		}
+		return lines
+	}
+
+	p := &SyntaxHighlightPlugin{}
+	require.NoError(t, p.Init(nil))
+
+	input := plugin.PluginInput{
+		Content:  "one\ntwo\nthree\nfour",
+		Language: "text",
+		Options:  map[string]interface{}{"highlight": "2-3"},
+	}
+	output, err := p.Execute(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"one": false, "two": true, "three": true, "four": false}, highlighted(output))
+	assert.Equal(t, [][2]int{{2, 3}}, output.Metadata["highlight"])
+
+	t.Run("invalid ranges render unhighlighted", func(t *testing.T) {
+		for _, spec := range []interface{}{"3-x", "0", "5-2", true} {
+			input.Options = map[string]interface{}{"highlight": spec}
+			output, err := p.Execute(context.Background(), input)
+			require.NoError(t, err, "%v", spec)
+			assert.NotContains(t, output.HTML, "line hl", "%v", spec)
+			assert.Empty(t, output.Metadata["highlight"], "%v", spec)
+		}
+	})
+}
+
+func TestHighlightRanges(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected [][2]int
+	}{
+		{"3-5,8", [][2]int{{3, 5}, {8, 8}}},
+		{" 1 - 2 , 4 ", [][2]int{{1, 2}, {4, 4}}},
+		{7, [][2]int{{7, 7}}},
+		{float64(2), [][2]int{{2, 2}}},
+		{"", nil},
+		{"a", nil},
+		{"2-", nil},
+		{"4-1", nil},
+		{"1,x", nil},
+		{nil, nil},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, highlightRanges(map[string]interface{}{"highlight": tt.value}), "%v", tt.value)
+	}
+}
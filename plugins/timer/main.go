@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fredcamaral/slicli/pkg/plugin"
+)
+
+// defaultWarnFraction makes a timer without warn_at turn amber once a fifth of
+// its budget is left
+const defaultWarnFraction = 5
+
+type TimerPlugin struct {
+	config map[string]interface{}
+
+	// warnAt is the default warn_at for blocks that don't set one, zero for a
+	// fifth of each block's budget
+	warnAt time.Duration
+}
+
+// timerSettings are the parsed options of one timer block
+type timerSettings struct {
+	budget time.Duration
+	warnAt time.Duration
+}
+
+func (p *TimerPlugin) Name() string    { return "timer" }
+func (p *TimerPlugin) Version() string { return "1.0.0" }
+func (p *TimerPlugin) Description() string {
+	return "Show a per-slide time budget that counts down against the presenter clock"
+}
+
+func (p *TimerPlugin) Init(config map[string]interface{}) error {
+	p.config = config
+
+	if value, ok := config["warn_at"]; ok {
+		warnAt, err := parseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid warn_at: %w", err)
+		}
+		p.warnAt = warnAt
+	}
+
+	return nil
+}
+
+func (p *TimerPlugin) Execute(ctx context.Context, input plugin.PluginInput) (plugin.PluginOutput, error) {
+	settings, err := p.parseSettings(input)
+	if err != nil {
+		return plugin.PluginOutput{}, err
+	}
+
+	// Timers count down only while their own slide is showing
+	slideAttr := ""
+	if index, ok := input.Metadata[plugin.MetadataSlideIndex].(int); ok {
+		slideAttr = fmt.Sprintf(` data-slide="%d"`, index)
+	}
+
+	budgetSeconds := int(settings.budget.Seconds())
+	warnSeconds := int(settings.warnAt.Seconds())
+
+	htmlOutput := fmt.Sprintf(
+		`<div class="slide-timer" id="%s" data-slide-timer data-timer-budget="%d" data-timer-warn-at="%d"%s data-timer-state="idle" role="timer" aria-live="off">`+
+			`<span class="slide-timer-label">Time left</span><span class="slide-timer-remaining">%s</span></div>`,
+		p.generateID(input.Content), budgetSeconds, warnSeconds, slideAttr, formatClock(settings.budget),
+	)
+
+	return plugin.PluginOutput{
+		HTML: htmlOutput,
+		Assets: []plugin.Asset{
+			{
+				Name:        "slide-timer.js",
+				Content:     []byte(timerScript),
+				ContentType: "application/javascript",
+			},
+			{
+				Name:        "slide-timer.css",
+				Content:     []byte(timerStyles),
+				ContentType: "text/css",
+			},
+		},
+		Metadata: map[string]interface{}{
+			"type":            "timer",
+			"budget_seconds":  budgetSeconds,
+			"warn_at_seconds": warnSeconds,
+		},
+	}, nil
+}
+
+func (p *TimerPlugin) Cleanup() error {
+	p.config = make(map[string]interface{})
+	p.warnAt = 0
+	return nil
+}
+
+// parseSettings reads budget and warn_at from the block's "key: value" lines,
+// which take precedence over the same keys in the block options
+func (p *TimerPlugin) parseSettings(input plugin.PluginInput) (timerSettings, error) {
+	values := make(map[string]interface{})
+	for key, value := range input.Options {
+		values[key] = value
+	}
+
+	for _, line := range strings.Split(input.Content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return timerSettings{}, fmt.Errorf("invalid timer line %q, expected key: value", line)
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	var settings timerSettings
+	for key, value := range values {
+		var err error
+		switch key {
+		case "budget":
+			settings.budget, err = parseDuration(value)
+		case "warn_at":
+			settings.warnAt, err = parseDuration(value)
+		default:
+			return timerSettings{}, fmt.Errorf("unknown timer option %q (expected budget or warn_at)", key)
+		}
+		if err != nil {
+			return timerSettings{}, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+
+	if settings.budget <= 0 {
+		return timerSettings{}, fmt.Errorf("timer needs a budget, e.g. budget: 5m")
+	}
+	if _, ok := values["warn_at"]; !ok {
+		settings.warnAt = p.warnAt
+		if settings.warnAt == 0 {
+			settings.warnAt = settings.budget / defaultWarnFraction
+		}
+	}
+	if settings.warnAt > settings.budget {
+		settings.warnAt = settings.budget
+	}
+
+	return settings, nil
+}
+
+// parseDuration accepts Go durations such as "5m" or "1m30s", and bare
+// numbers as seconds
+func parseDuration(value interface{}) (time.Duration, error) {
+	var d time.Duration
+	switch v := value.(type) {
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a duration such as 5m or 90s", v)
+		}
+		d = parsed
+	case int:
+		d = time.Duration(v) * time.Second
+	case int64:
+		d = time.Duration(v) * time.Second
+	case float64:
+		d = time.Duration(v * float64(time.Second))
+	default:
+		return 0, fmt.Errorf("unsupported duration %v", value)
+	}
+
+	if d < 0 {
+		return 0, fmt.Errorf("duration must not be negative")
+	}
+	return d.Truncate(time.Second), nil
+}
+
+// formatClock renders a duration as m:ss
+func formatClock(d time.Duration) string {
+	seconds := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+func (p *TimerPlugin) generateID(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return "timer-" + base64.RawURLEncoding.EncodeToString(hash[:8])
+}
+
+// timerScript drives every timer on the page. It follows the presenter clock
+// from /api/presenter/state, so pausing or resetting the presenter timer
+// pauses or resets the countdown, and falls back to the local clock when no
+// presenter session is running. A timer restarts whenever its slide is shown.
+const timerScript = `
+(function() {
+	if (window.slicliSlideTimers) return;
+	window.slicliSlideTimers = true;
+
+	var presenter = null;
+	var localStart = Date.now();
+	var shownSlide = null;
+	var shownAt = 0;
+
+	function clock() {
+		if (!presenter) return Date.now() - localStart;
+		// elapsedTime is a Go duration in nanoseconds
+		if (presenter.isPaused) return presenter.elapsedTime / 1e6;
+		return Date.now() - new Date(presenter.startTime).getTime();
+	}
+
+	function currentSlide() {
+		if (presenter) return presenter.currentSlide;
+		var active = document.querySelector('.slide.active');
+		return active ? parseInt(active.dataset.index, 10) : 0;
+	}
+
+	function slideOf(timer) {
+		if (timer.dataset.slide !== undefined) return parseInt(timer.dataset.slide, 10);
+		var slide = timer.closest('.slide');
+		return slide ? parseInt(slide.dataset.index, 10) : 0;
+	}
+
+	function format(ms) {
+		var total = Math.ceil(Math.abs(ms) / 1000);
+		var seconds = total % 60;
+		return Math.floor(total / 60) + ':' + (seconds < 10 ? '0' : '') + seconds;
+	}
+
+	function tick() {
+		var now = clock();
+		var slide = currentSlide();
+		if (slide !== shownSlide || now < shownAt) {
+			shownSlide = slide;
+			shownAt = now;
+		}
+
+		document.querySelectorAll('[data-slide-timer]').forEach(function(timer) {
+			var budget = parseInt(timer.dataset.timerBudget, 10) * 1000;
+			var warnAt = parseInt(timer.dataset.timerWarnAt, 10) * 1000;
+			var remaining = budget;
+			var state = 'idle';
+
+			if (slideOf(timer) === slide) {
+				remaining = budget - (now - shownAt);
+				state = remaining < 0 ? 'over' : (remaining <= warnAt ? 'warning' : 'running');
+			}
+
+			timer.dataset.timerState = state;
+			timer.querySelector('.slide-timer-remaining').textContent = (remaining < 0 ? '+' : '') + format(remaining);
+		});
+	}
+
+	function poll() {
+		fetch('/api/presenter/state')
+			.then(function(response) { return response.ok ? response.json() : null; })
+			.then(function(state) { presenter = state; })
+			.catch(function() { presenter = null; });
+	}
+
+	poll();
+	setInterval(poll, 1000);
+	setInterval(tick, 250);
+	tick();
+})();
+`
+
+var timerStyles = `
+.slide-timer {
+	position: absolute;
+	top: 1rem;
+	right: 1rem;
+	display: inline-flex;
+	gap: 0.5rem;
+	align-items: baseline;
+	padding: 0.25rem 0.75rem;
+	border-radius: 0.5rem;
+	background: rgba(0, 0, 0, 0.6);
+	color: #fff;
+	font-family: monospace;
+	font-size: 1rem;
+	z-index: 10;
+}
+
+.slide-timer-label {
+	font-size: 0.75em;
+	opacity: 0.8;
+}
+
+.slide-timer[data-timer-state="warning"] {
+	background: #d97706;
+}
+
+.slide-timer[data-timer-state="over"] {
+	background: #dc2626;
+}
+
+/* Print styles */
+@media print {
+	.slide-timer {
+		display: none;
+	}
+}
+`
+
+// Export plugin
+var Plugin plugin.Plugin = &TimerPlugin{}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fredcamaral/slicli/pkg/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimerPlugin_Basic(t *testing.T) {
+	p := &TimerPlugin{}
+
+	assert.Equal(t, "timer", p.Name())
+	assert.Equal(t, "1.0.0", p.Version())
+	assert.NotEmpty(t, p.Description())
+}
+
+func TestTimerPlugin_Init(t *testing.T) {
+	p := &TimerPlugin{}
+	require.NoError(t, p.Init(map[string]interface{}{"warn_at": "45s"}))
+	assert.Equal(t, 45*time.Second, p.warnAt)
+
+	assert.Error(t, p.Init(map[string]interface{}{"warn_at": "soon"}))
+}
+
+func TestTimerPlugin_Execute(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]interface{}
+		input    plugin.PluginInput
+		validate func(t *testing.T, output plugin.PluginOutput)
+	}{
+		{
+			name: "budget block",
+			input: plugin.PluginInput{
+				Content:  "budget: 5m\n",
+				Language: "timer",
+				Metadata: map[string]interface{}{plugin.MetadataSlideIndex: 3},
+			},
+			validate: func(t *testing.T, output plugin.PluginOutput) {
+				assert.Contains(t, output.HTML, `data-slide-timer`)
+				assert.Contains(t, output.HTML, `data-timer-budget="300"`)
+				assert.Contains(t, output.HTML, `data-timer-warn-at="60"`, "warns with a fifth of the budget left by default")
+				assert.Contains(t, output.HTML, `data-slide="3"`, "the presenter clock is matched to the slide")
+				assert.Contains(t, output.HTML, ">5:00<")
+				assert.Equal(t, 300, output.Metadata["budget_seconds"])
+			},
+		},
+		{
+			name:  "warn_at in block",
+			input: plugin.PluginInput{Content: "budget: 1m30s\nwarn_at: 15s", Language: "timer"},
+			validate: func(t *testing.T, output plugin.PluginOutput) {
+				assert.Contains(t, output.HTML, `data-timer-budget="90"`)
+				assert.Contains(t, output.HTML, `data-timer-warn-at="15"`)
+				assert.Contains(t, output.HTML, ">1:30<")
+				assert.NotContains(t, output.HTML, "data-slide=", "no slide index outside a slide")
+			},
+		},
+		{
+			name:   "options and plugin default",
+			config: map[string]interface{}{"warn_at": "2m"},
+			input:  plugin.PluginInput{Language: "timer", Options: map[string]interface{}{"budget": "10m"}},
+			validate: func(t *testing.T, output plugin.PluginOutput) {
+				assert.Contains(t, output.HTML, `data-timer-budget="600"`)
+				assert.Contains(t, output.HTML, `data-timer-warn-at="120"`)
+			},
+		},
+		{
+			name:  "warn_at capped at budget",
+			input: plugin.PluginInput{Content: "budget: 30s\nwarn_at: 1m", Language: "timer"},
+			validate: func(t *testing.T, output plugin.PluginOutput) {
+				assert.Contains(t, output.HTML, `data-timer-warn-at="30"`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &TimerPlugin{}
+			config := tt.config
+			if config == nil {
+				config = map[string]interface{}{}
+			}
+			require.NoError(t, p.Init(config))
+
+			output, err := p.Execute(context.Background(), tt.input)
+			require.NoError(t, err)
+			tt.validate(t, output)
+
+			require.Len(t, output.Assets, 2)
+			assert.Equal(t, "slide-timer.js", output.Assets[0].Name)
+			assert.Contains(t, string(output.Assets[0].Content), "/api/presenter/state")
+			assert.Equal(t, "text/css", output.Assets[1].ContentType)
+		})
+	}
+}
+
+func TestTimerPlugin_InvalidBlocks(t *testing.T) {
+	p := &TimerPlugin{}
+	require.NoError(t, p.Init(map[string]interface{}{}))
+
+	for content, message := range map[string]string{
+		"":                         "needs a budget",
+		"budget: soon":             "invalid budget",
+		"budget: 5m\ncolor: red":   "unknown timer option",
+		"five minutes":             "expected key: value",
+		"budget: 5m\nwarn_at: -1m": "must not be negative",
+	} {
+		_, err := p.Execute(context.Background(), plugin.PluginInput{Content: content, Language: "timer"})
+		assert.ErrorContains(t, err, message, content)
+	}
+}
@@ -140,7 +140,7 @@
             
             // Export presentation
             export: function(format) {
-                const formats = ['pdf', 'html', 'markdown'];
+                const formats = ['pdf', 'html', 'markdown', 'pptx', 'json'];
                 if (!formats.includes(format)) {
                     throw new Error(`Unsupported export format: ${format}`);
                 }
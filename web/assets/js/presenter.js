@@ -6,9 +6,10 @@ class PresenterMode {
         this.ws = null;
         this.state = null;
         this.reconnectAttempts = 0;
-        this.maxReconnectAttempts = 5;
         this.reconnectDelay = 1000;
+        this.maxReconnectDelay = 30000;
         this.isConnected = false;
+        this.connectionError = null;
         
         this.initWebSocket();
         this.bindEvents();
@@ -27,6 +28,13 @@ class PresenterMode {
             this.isConnected = true;
             this.reconnectAttempts = 0;
             this.updateConnectionStatus(true);
+            // Drop the reconnecting notice once the connection is back
+            if (this.connectionError && this.connectionError.parentNode) {
+                this.connectionError.parentNode.removeChild(this.connectionError);
+            }
+            this.connectionError = null;
+            // Catch up on anything missed while disconnected
+            this.requestState();
         };
         
         this.ws.onmessage = (event) => {
@@ -48,22 +56,32 @@ class PresenterMode {
             this.isConnected = false;
             this.updateConnectionStatus(false);
             
-            // Attempt reconnection
-            if (this.reconnectAttempts < this.maxReconnectAttempts) {
-                this.reconnectAttempts++;
-                const delay = this.reconnectDelay * Math.pow(2, this.reconnectAttempts - 1);
-                console.log(`Reconnecting in ${delay}ms (attempt ${this.reconnectAttempts})`);
-                
-                setTimeout(() => {
-                    this.initWebSocket();
-                }, delay);
-            } else {
-                console.error('Max reconnection attempts reached');
-                this.showError('Connection lost. Please refresh the page.');
+            // Keep reconnecting, backing off up to maxReconnectDelay, so the
+            // remote recovers once the network or server is back
+            this.reconnectAttempts++;
+            const delay = Math.min(this.reconnectDelay * Math.pow(2, this.reconnectAttempts - 1), this.maxReconnectDelay);
+            console.log(`Reconnecting in ${delay}ms (attempt ${this.reconnectAttempts})`);
+            if (this.reconnectAttempts === 5) {
+                this.connectionError = this.showError('Connection lost. Reconnecting...');
             }
+            
+            setTimeout(() => {
+                this.initWebSocket();
+            }, delay);
         };
     }
     
+    requestState() {
+        fetch('/api/presenter/state')
+            .then(response => response.ok ? response.json() : null)
+            .then(state => {
+                if (!state) return;
+                this.state = state;
+                this.updateUI();
+            })
+            .catch(error => console.error('Failed to load presenter state:', error));
+    }
+    
     handleSync(data) {
         if (data.type === 'state') {
             this.state = data.data.state;
@@ -391,6 +409,8 @@ class PresenterMode {
                 errorDiv.parentNode.removeChild(errorDiv);
             }
         }, 3000);
+        
+        return errorDiv;
     }
     
     escapeHtml(text) {
@@ -7,13 +7,17 @@
     let currentSlide = 0;
     let slides = [];
     let ws = null;
-    let reconnectInterval = null;
+    let reconnectTimer = null;
+    let reconnectAttempts = 0;
     let currentTransition = 'slide';
     let autoAdvanceTimer = null;
     let autoAdvanceInterval = 0;
     let presentationStartTime = null;
     let isHelpVisible = false;
 
+    // With ?follow=true the page moves along with the presenter's slides
+    const followPresenter = new URLSearchParams(window.location.search).get('follow') === 'true';
+
     // Initialize
     function init() {
         slides = document.querySelectorAll('.slide');
@@ -40,6 +44,9 @@
         if (mobilePrevBtn) mobilePrevBtn.addEventListener('click', previousSlide);
         if (mobileNextBtn) mobileNextBtn.addEventListener('click', nextSlide);
 
+        // Interactive task-list checkboxes report ticks to the server
+        document.addEventListener('change', handleTaskChange);
+
         // Setup WebSocket for live reload
         setupWebSocket();
 
@@ -529,7 +536,11 @@
             
             ws.onopen = function() {
                 console.log('Connected to slicli server');
-                clearInterval(reconnectInterval);
+                // Ask for the presenter's slide again after reconnecting
+                if (reconnectAttempts > 0) {
+                    ws.send(JSON.stringify({ type: 'resync' }));
+                }
+                reconnectAttempts = 0;
             };
             
             ws.onmessage = function(event) {
@@ -555,13 +566,17 @@
         }
     }
 
+    // Reconnect after 1s, doubling the wait on each failure up to 30s
     function scheduleReconnect() {
-        if (reconnectInterval) return;
+        if (reconnectTimer) return;
         
-        reconnectInterval = setInterval(() => {
+        const delay = Math.min(1000 * Math.pow(2, reconnectAttempts), 30000);
+        reconnectAttempts++;
+        reconnectTimer = setTimeout(() => {
+            reconnectTimer = null;
             console.log('Attempting to reconnect...');
             setupWebSocket();
-        }, 2000);
+        }, delay);
     }
 
     function handleWebSocketMessage(data) {
@@ -577,11 +592,50 @@
             case 'connected':
                 console.log('Server message:', data.data.message);
                 break;
+            case 'task_update':
+                setTaskState(data.data.slide, data.data.task, data.data.checked);
+                break;
+            case 'navigation':
+                if (followPresenter && typeof data.data.slide === 'number') {
+                    goToSlide(data.data.slide);
+                }
+                break;
             default:
                 console.log('Unknown WebSocket message type:', data.type);
         }
     }
 
+    // Task lists
+    function handleTaskChange(e) {
+        const box = e.target;
+        if (!box.classList || !box.classList.contains('task-checkbox')) return;
+
+        fetch('/api/presenter/tasks', {
+            method: 'POST',
+            headers: { 'Content-Type': 'application/json' },
+            body: JSON.stringify({
+                slide: parseInt(box.dataset.slide, 10),
+                task: parseInt(box.dataset.task, 10),
+                checked: box.checked
+            })
+        }).then(response => {
+            if (!response.ok) {
+                box.checked = !box.checked;
+                showMessage('Could not update task');
+            }
+        }).catch(() => {
+            box.checked = !box.checked;
+            showMessage('Could not update task');
+        });
+    }
+
+    function setTaskState(slide, task, checked) {
+        const selector = `.task-checkbox[data-slide="${slide}"][data-task="${task}"]`;
+        document.querySelectorAll(selector).forEach(box => {
+            box.checked = checked;
+        });
+    }
+
     // Enhanced Touch Support with Momentum and Bounce Effects
     let touchStartX = 0;
     let touchStartY = 0;
@@ -0,0 +1,44 @@
+// Package web holds the browser assets built into the slicli binary
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// vendor holds the third-party libraries `make vendor-assets` downloads
+//
+//go:embed assets/vendor
+var vendor embed.FS
+
+// VendorFiles are the vendored files offline mode links to, relative to
+// /assets/vendor/. The Prism autoloader also loads language grammars from
+// prismjs/components/ on demand.
+var VendorFiles = []string{
+	"mermaid/mermaid.min.js",
+	"prismjs/components/prism-core.min.js",
+	"prismjs/plugins/autoloader/prism-autoloader.min.js",
+	"prismjs/themes/prism.css",
+}
+
+// VendorFS returns the vendored libraries, rooted at assets/vendor
+func VendorFS() fs.FS {
+	sub, err := fs.Sub(vendor, "assets/vendor")
+	if err != nil {
+		// Only possible if the embed directive and the path disagree
+		panic(err)
+	}
+	return sub
+}
+
+// MissingVendorFiles lists the VendorFiles this binary was built without,
+// which happens when `make vendor-assets` didn't run before the build
+func MissingVendorFiles() []string {
+	var missing []string
+	for _, name := range VendorFiles {
+		if _, err := fs.Stat(VendorFS(), name); err != nil {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}